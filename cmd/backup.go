@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"cchoice/internal/backup"
+	"cchoice/internal/conf"
+	"cchoice/internal/database"
+	"cchoice/internal/errs"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/spf13/cobra"
+)
+
+var flagsBackup struct {
+	list bool
+}
+
+var flagsRestore struct {
+	snapshot   string
+	target     string
+	at         string
+	list       bool
+	verifyOnly bool
+	replica    bool
+}
+
+func init() {
+	fb := cmdBackup.Flags
+	fb().BoolVarP(&flagsBackup.list, "list", "l", false, "List snapshots instead of taking one")
+
+	fr := cmdRestore.Flags
+	fr().StringVarP(&flagsRestore.snapshot, "snapshot", "s", "", "Snapshot name to restore, or 'latest'")
+	fr().StringVarP(&flagsRestore.target, "target", "t", "", "Database file to restore into. Defaults to the DB_URL file")
+	fr().BoolVarP(&flagsRestore.list, "list", "l", false, "List snapshots")
+	fr().BoolVarP(&flagsRestore.verifyOnly, "verify-only", "v", false, "Download and verify the snapshot without restoring it")
+	fr().BoolVarP(&flagsRestore.replica, "replica", "r", false, "Restore from the WAL replica instead of a snapshot")
+	fr().StringVar(&flagsRestore.at, "at", "", "With --replica, the time to restore to as RFC3339 or 'YYYY-MM-DD HH:MM:SS' local time. Defaults to now")
+
+	rootCmd.AddCommand(cmdBackup, cmdRestore)
+}
+
+func mustInitBackup() *backup.Backup {
+	store, err := backup.NewStorage()
+	if err != nil {
+		panic(errors.Join(errs.ErrCmd, err))
+	}
+	cfg := conf.Conf().Backup
+	return backup.New(nil, store, cfg.Prefix, cfg.KeepLast)
+}
+
+func printSnapshots(ctx context.Context, b *backup.Backup) {
+	snapshots, err := b.List(ctx)
+	if err != nil {
+		panic(errors.Join(errs.ErrCmd, err))
+	}
+	if len(snapshots) == 0 {
+		fmt.Println("No snapshots found")
+		return
+	}
+	for _, snapshot := range snapshots {
+		fmt.Printf("%s\t%s\t%d bytes\n", snapshot.Name, snapshot.CreatedAt.Format(time.DateTime), snapshot.Size)
+	}
+}
+
+func printGenerations(ctx context.Context, r *backup.Replica) {
+	generations, err := r.Generations(ctx)
+	if err != nil {
+		panic(errors.Join(errs.ErrCmd, err))
+	}
+	if len(generations) == 0 {
+		fmt.Println("No replica generations found")
+		return
+	}
+	for _, generation := range generations {
+		segments, err := r.Segments(ctx, generation.ID)
+		if err != nil {
+			panic(errors.Join(errs.ErrCmd, err))
+		}
+		until := generation.StartedAt
+		if len(segments) > 0 {
+			until = segments[len(segments)-1].At
+		}
+		fmt.Printf(
+			"%s\t%s - %s\t%d segments\n",
+			generation.ID,
+			generation.StartedAt.Local().Format(time.DateTime),
+			until.Local().Format(time.DateTime),
+			len(segments),
+		)
+	}
+}
+
+func parseRestoreAt(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return at, nil
+	}
+	return time.ParseInLocation(time.DateTime, value, time.Local)
+}
+
+func restoreTarget() string {
+	if flagsRestore.target != "" {
+		return flagsRestore.target
+	}
+	dbPath, err := backup.DBPath(conf.Conf().DBURL)
+	if err != nil {
+		panic(errors.Join(errs.ErrCmd, err))
+	}
+	return dbPath
+}
+
+func restoreReplica(ctx context.Context) {
+	store, err := backup.NewStorage()
+	if err != nil {
+		panic(errors.Join(errs.ErrCmd, err))
+	}
+	r := backup.NewReplica("", store, conf.Conf().Replica.Prefix, 0, 0)
+
+	if flagsRestore.list {
+		printGenerations(ctx, r)
+		return
+	}
+
+	at, err := parseRestoreAt(flagsRestore.at)
+	if err != nil {
+		panic(errors.Join(errs.ErrCmd, err))
+	}
+	target := restoreTarget()
+	report, err := r.RestoreAt(ctx, at, target)
+	if err != nil {
+		panic(errors.Join(errs.ErrCmd, err))
+	}
+	fmt.Printf("Restored generation %s as of %s into %s\n", report.Snapshot.Name, report.Snapshot.CreatedAt.Local().Format(time.DateTime), target)
+	if report.Previous != "" {
+		fmt.Printf("Previous database kept at %s\n", report.Previous)
+	}
+	fmt.Printf("Migration version: %d\n", report.GooseVersion)
+}
+
+var cmdBackup = &cobra.Command{
+	Use:   "backup",
+	Short: "Snapshot the database into backup storage and prune old snapshots",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg := conf.Conf().Backup
+
+		if flagsBackup.list {
+			printSnapshots(ctx, mustInitBackup())
+			return
+		}
+
+		db := database.New(database.DB_MODE_RW)
+		defer db.Close()
+		if db.Driver() != database.DB_DRIVER_SQLITE3 {
+			panic(errors.Join(errs.ErrCmd, errs.ErrDBDriver, fmt.Errorf("backups need the sqlite3 driver, not %s", db.Driver())))
+		}
+
+		store, err := backup.NewStorage()
+		if err != nil {
+			panic(errors.Join(errs.ErrCmd, err))
+		}
+		report, err := backup.New(db.GetDB(), store, cfg.Prefix, cfg.KeepLast).Run(ctx, time.Now())
+		if err != nil {
+			panic(errors.Join(errs.ErrCmd, err))
+		}
+
+		fmt.Printf("Snapshot: %s (%d bytes)\n", report.Snapshot.Key, report.Snapshot.Size)
+		fmt.Printf("Migration version: %d\n", report.GooseVersion)
+		fmt.Printf("Pruned: %d\n", report.Pruned)
+	},
+}
+
+var cmdRestore = &cobra.Command{
+	Use:   "restore",
+	Short: "List, verify or restore database snapshots. Stop the server before restoring",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		if flagsRestore.replica {
+			restoreReplica(ctx)
+			return
+		}
+
+		b := mustInitBackup()
+
+		if flagsRestore.list || flagsRestore.snapshot == "" {
+			printSnapshots(ctx, b)
+			if flagsRestore.snapshot == "" && !flagsRestore.list {
+				fmt.Println("\nPass --snapshot <name> or --snapshot latest to restore one")
+			}
+			return
+		}
+
+		target := restoreTarget()
+
+		if flagsRestore.verifyOnly {
+			checkPath := target + ".verify"
+			defer os.Remove(checkPath)
+			name, err := b.Download(ctx, flagsRestore.snapshot, checkPath)
+			if err != nil {
+				panic(errors.Join(errs.ErrCmd, err))
+			}
+			version, err := backup.Verify(ctx, checkPath, 0)
+			if err != nil {
+				panic(errors.Join(errs.ErrCmd, err))
+			}
+			fmt.Printf("Snapshot %s is OK at migration version %d\n", name, version)
+			return
+		}
+
+		report, err := b.Restore(ctx, flagsRestore.snapshot, target)
+		if err != nil {
+			panic(errors.Join(errs.ErrCmd, err))
+		}
+		fmt.Printf("Restored %s into %s\n", report.Snapshot.Name, target)
+		if report.Previous != "" {
+			fmt.Printf("Previous database kept at %s\n", report.Previous)
+		}
+		fmt.Printf("Migration version: %d\n", report.GooseVersion)
+	},
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"cchoice/internal/conf"
+	"cchoice/internal/database/pgmigrate"
+	"cchoice/internal/errs"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "github.com/joho/godotenv/autoload"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/spf13/cobra"
+)
+
+var flagsMigrateSQLiteToPostgres struct {
+	from       string
+	to         string
+	batchSize  int
+	truncate   bool
+	verifyOnly bool
+}
+
+func init() {
+	f := cmdMigrateSQLiteToPostgres.Flags
+	f().StringVarP(&flagsMigrateSQLiteToPostgres.from, "from", "f", "", "SQLite database to copy from. Defaults to DB_URL")
+	f().StringVarP(&flagsMigrateSQLiteToPostgres.to, "to", "t", "", "Postgres URL to copy into, already migrated with migrations/postgres")
+	f().IntVarP(&flagsMigrateSQLiteToPostgres.batchSize, "batch-size", "b", 500, "Rows per INSERT")
+	f().BoolVar(&flagsMigrateSQLiteToPostgres.truncate, "truncate", false, "Empty the target tables before copying")
+	f().BoolVarP(&flagsMigrateSQLiteToPostgres.verifyOnly, "verify-only", "v", false, "Compare the databases without copying")
+	if err := cmdMigrateSQLiteToPostgres.MarkFlagRequired("to"); err != nil {
+		panic(err)
+	}
+	rootCmd.AddCommand(cmdMigrateSQLiteToPostgres)
+}
+
+func printMigrateReport(report *pgmigrate.Report) {
+	fmt.Printf("Migration version: %d\n", report.GooseVersion)
+	for _, table := range report.Tables {
+		status := "OK"
+		if !table.OK() {
+			status = fmt.Sprintf("MISMATCH (target %d rows, checksum %016x)", table.TargetRows, table.TargetChecksum)
+		}
+		fmt.Printf("%s\t%d rows\t%016x\t%s\n", table.Name, table.SourceRows, table.SourceChecksum, status)
+	}
+}
+
+var cmdMigrateSQLiteToPostgres = &cobra.Command{
+	Use:   "migrate_sqlite_to_postgres",
+	Short: "Copy the SQLite database into Postgres and verify every table. Stop the server first",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		from := flagsMigrateSQLiteToPostgres.from
+		if from == "" {
+			from = conf.Conf().DBURL
+		}
+		if !strings.HasPrefix(from, "file:") {
+			from = "file:" + from
+		}
+		src, err := sql.Open("sqlite3", from+"?mode=ro&_busy_timeout=5000")
+		if err != nil {
+			panic(errors.Join(errs.ErrCmd, err))
+		}
+		defer src.Close()
+
+		dst, err := sql.Open("pgx", flagsMigrateSQLiteToPostgres.to)
+		if err != nil {
+			panic(errors.Join(errs.ErrCmd, err))
+		}
+		defer dst.Close()
+
+		m := pgmigrate.New(src, dst, flagsMigrateSQLiteToPostgres.batchSize)
+		var report *pgmigrate.Report
+		if flagsMigrateSQLiteToPostgres.verifyOnly {
+			report, err = m.Verify(ctx)
+		} else {
+			report, err = m.Copy(ctx, flagsMigrateSQLiteToPostgres.truncate)
+		}
+		if report != nil {
+			printMigrateReport(report)
+		}
+		if err != nil {
+			panic(errors.Join(errs.ErrCmd, err))
+		}
+		fmt.Printf("Verified %d tables\n", len(report.Tables))
+	},
+}
@@ -0,0 +1,47 @@
+package components
+
+import (
+	"cchoice/cmd/web/models"
+	"cchoice/internal/enums"
+)
+
+func incidentStatusLabel(status enums.IncidentStatus) string {
+	switch status {
+	case enums.INCIDENT_STATUS_OPEN:
+		return "Open"
+	case enums.INCIDENT_STATUS_NTE_ISSUED:
+		return "Awaiting Response"
+	case enums.INCIDENT_STATUS_RESPONDED:
+		return "Responded"
+	case enums.INCIDENT_STATUS_DECIDED:
+		return "Decided"
+	default:
+		return status.String()
+	}
+}
+
+func incidentDecisionLabel(decision enums.IncidentDecision) string {
+	switch decision {
+	case enums.INCIDENT_DECISION_WARNING:
+		return "Warning"
+	case enums.INCIDENT_DECISION_SUSPENSION:
+		return "Suspension"
+	case enums.INCIDENT_DECISION_NO_ACTION:
+		return "No Action"
+	default:
+		return ""
+	}
+}
+
+// incidentDecisionSummary reads like "Suspension, 2026-07-22 to 2026-07-24".
+func incidentDecisionSummary(incident models.IncidentItem) string {
+	label := incidentDecisionLabel(incident.Decision)
+	switch {
+	case label == "" || incident.EffectiveFrom == "":
+		return label
+	case incident.EffectiveTo == "":
+		return label + ", from " + incident.EffectiveFrom
+	default:
+		return label + ", " + incident.EffectiveFrom + " to " + incident.EffectiveTo
+	}
+}
@@ -0,0 +1,33 @@
+package components
+
+import (
+	"cchoice/cmd/web/models"
+	"cchoice/internal/enums"
+)
+
+// permissionAccessShort keeps the matrix cells narrow, "R" or "RW".
+func permissionAccessShort(access enums.PermissionAccess) string {
+	switch access {
+	case enums.PERMISSION_ACCESS_READ:
+		return "R"
+	case enums.PERMISSION_ACCESS_WRITE:
+		return "RW"
+	default:
+		return ""
+	}
+}
+
+func permissionGroupsNotAssigned(groups []models.PermissionGroup, row models.PermissionStaffRow) []models.PermissionGroup {
+	assigned := make(map[string]bool, len(row.Groups))
+	for _, group := range row.Groups {
+		assigned[group.ID] = true
+	}
+
+	res := make([]models.PermissionGroup, 0, len(groups))
+	for _, group := range groups {
+		if !assigned[group.ID] {
+			res = append(res, group)
+		}
+	}
+	return res
+}
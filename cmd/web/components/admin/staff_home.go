@@ -11,6 +11,8 @@ import (
 var baseStaffCards = []models.StaffCard{
 	{Link: "/admin/staff/attendance", Title: "Attendance", Description: "View your attendance", Icon: svg.Clock("text-primary")},
 	{Link: "/admin/staff/time-off", Title: "Time Off", Description: "Request a time off", Icon: svg.Box("text-primary")},
+	{Link: "/admin/staff/shifts", Title: "Shifts", Description: "View your shifts and request swaps", Icon: svg.Calendar("text-primary")},
+	{Link: "/admin/staff/incidents", Title: "Incidents", Description: "Respond to notices to explain", Icon: svg.MenuLines("text-primary")},
 	{Link: "/admin/profile", Title: "Profile", Description: "View and manage your profile", Icon: svg.User("text-primary")},
 }
 
@@ -30,6 +32,12 @@ var staffCardsWithRoles = []struct {
 		Description: "Generate C-Points for a customer",
 		Icon:        svg.Lightning("text-primary"),
 	}, AllowedRole: enums.STAFF_ROLE_CREATE_CPOINTS},
+	{Card: models.StaffCard{
+		Link:        "/admin/staff/kiosk",
+		Title:       "Attendance Kiosk",
+		Description: "Show the time in/out QR code for the shop",
+		Icon:        svg.Clock("text-primary"),
+	}, AllowedRole: enums.STAFF_ROLE_ATTENDANCE_KIOSK},
 	{Card: models.StaffCard{
 		Link:        "/admin/holidays",
 		Title:       "Holidays",
@@ -52,6 +60,30 @@ var staffCardsWithRoles = []struct {
 		Description: "Manage dynamic themes",
 		Icon:        svg.Gear("text-primary"),
 	}, AllowedRole: enums.STAFF_ROLE_MANAGE_THEMES},
+	{Card: models.StaffCard{
+		Link:        "/admin/experiments",
+		Title:       "A/B Experiments",
+		Description: "Test themes and promo banners",
+		Icon:        svg.Lightning("text-primary"),
+	}, AllowedRole: enums.STAFF_ROLE_MANAGE_THEMES},
+	{Card: models.StaffCard{
+		Link:        "/admin/experiments",
+		Title:       "A/B Experiments",
+		Description: "Test themes and promo banners",
+		Icon:        svg.Lightning("text-primary"),
+	}, AllowedRole: enums.STAFF_ROLE_MANAGE_PROMOS},
+	{Card: models.StaffCard{
+		Link:        "/admin/analytics",
+		Title:       "Analytics",
+		Description: "Sales, top products and funnel",
+		Icon:        svg.Document("text-primary"),
+	}, AllowedRole: enums.STAFF_ROLE_VIEW_ANALYTICS},
+	{Card: models.StaffCard{
+		Link:        "/admin/search",
+		Title:       "Search Merchandising",
+		Description: "Search insights and search rules",
+		Icon:        svg.Search("", "text-primary"),
+	}, AllowedRole: enums.STAFF_ROLE_MANAGE_SEARCH},
 	{
 		Card:        models.StaffCard{Link: "/admin/memos", Title: "Manage Memos", Description: "Create and manage staff memorandums", Icon: svg.Document("text-primary")},
 		AllowedRole: enums.STAFF_ROLE_MANAGE_MEMO,
@@ -80,6 +112,18 @@ var staffCardsWithRoles = []struct {
 		Card:        models.StaffCard{Link: "/admin/quotations", Title: "Manage Quotations", Description: "View and approve customer quotations", Icon: svg.Document("text-primary")},
 		AllowedRole: enums.STAFF_ROLE_MANAGE_QUOTATIONS,
 	},
+	{
+		Card:        models.StaffCard{Link: "/admin/reviews", Title: "Product Reviews", Description: "Moderate customer product reviews", Icon: svg.Document("text-primary")},
+		AllowedRole: enums.STAFF_ROLE_MANAGE_REVIEWS,
+	},
+	{
+		Card:        models.StaffCard{Link: "/admin/sale-campaigns", Title: "Sale Campaigns", Description: "Schedule discounts across many products", Icon: svg.Document("text-primary")},
+		AllowedRole: enums.STAFF_ROLE_MANAGE_PROMOS,
+	},
+	{
+		Card:        models.StaffCard{Link: "/admin/payroll", Title: "Payroll", Description: "Compute pay and generate payslips", Icon: svg.Document("text-primary")},
+		AllowedRole: enums.STAFF_ROLE_MANAGE_PAYROLL,
+	},
 	{
 		Card:        models.StaffCard{Link: "/admin/imports", Title: "Imports", Description: "Bulk upload", Icon: svg.Document("text-primary")},
 		AllowedRole: enums.STAFF_ROLE_EDIT_PRODUCTS,
@@ -93,7 +137,10 @@ var staffCardsWithRoles = []struct {
 func filterStaffCardsByRole(roles []enums.StaffRole) []models.StaffCard {
 	result := make([]models.StaffCard, 0, len(staffCardsWithRoles))
 	for _, card := range staffCardsWithRoles {
-		if slices.Contains(roles, card.AllowedRole) {
+		// A page open to several roles has a card per role, shown once.
+		if slices.Contains(roles, card.AllowedRole) && !slices.ContainsFunc(result, func(c models.StaffCard) bool {
+			return c.Link == card.Card.Link
+		}) {
 			result = append(result, card.Card)
 		}
 	}
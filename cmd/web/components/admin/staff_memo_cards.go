@@ -1,9 +1,33 @@
 package components
 
-import "fmt"
+import (
+	"fmt"
+
+	"cchoice/cmd/web/models"
+)
 
 func staffMemoLinkClickScript(memoID string) string {
 	return fmt.Sprintf(`on click
   set #staff-memo-accept-%s.disabled to false
   set #staff-memo-reject-%s.disabled to false`, memoID, memoID)
 }
+
+func requiredStaffMemos(memos []models.StaffMemoCard) []models.StaffMemoCard {
+	result := make([]models.StaffMemoCard, 0, len(memos))
+	for _, memo := range memos {
+		if memo.RequiresAcknowledgement {
+			result = append(result, memo)
+		}
+	}
+	return result
+}
+
+func optionalStaffMemos(memos []models.StaffMemoCard) []models.StaffMemoCard {
+	result := make([]models.StaffMemoCard, 0, len(memos))
+	for _, memo := range memos {
+		if !memo.RequiresAcknowledgement {
+			result = append(result, memo)
+		}
+	}
+	return result
+}
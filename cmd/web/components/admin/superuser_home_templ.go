@@ -18,9 +18,14 @@ var staffCards = []models.StaffCard{
 	{Link: "/admin/profile", Title: "Profile", Description: "View and manage your profile", Icon: svg.User("text-primary")},
 	{Link: "/admin/superuser/attendance", Title: "Attendance", Description: "View and manage employee attendance records", Icon: svg.Clock("text-primary")},
 	{Link: "/admin/superuser/time-off", Title: "Time Off", Description: "View and manage staff time off records", Icon: svg.Box("text-primary")},
+	{Link: "/admin/superuser/leave-credits", Title: "Leave Credits", Description: "View and adjust staff leave balances", Icon: svg.Calendar("text-primary")},
+	{Link: "/admin/superuser/shifts", Title: "Shift Schedules", Description: "Set weekly shifts, rest days and approve shift swaps", Icon: svg.Clock("text-primary")},
+	{Link: "/admin/superuser/incidents", Title: "Incidents", Description: "File incidents, issue notices to explain and record decisions", Icon: svg.MenuLines("text-primary")},
+	{Link: "/admin/superuser/kiosk-punches", Title: "Kiosk Punches", Description: "Review kiosk time in and out with photos", Icon: svg.Clock("text-primary")},
 	{Link: "/admin/holidays", Title: "Holidays", Description: "Manage Philippines holidays", Icon: svg.Calendar("text-primary")},
 	{Link: "/admin/superuser/staffs", Title: "Employees", Description: "View and manage employees", Icon: svg.People("text-primary")},
 	{Link: "/admin/superuser/staffs/create", Title: "Add Employee", Description: "Add a new staff member", Icon: svg.User("text-primary")},
+	{Link: "/admin/superuser/permissions", Title: "Permissions", Description: "Manage role groups and review who can access what", Icon: svg.People("text-primary")},
 
 	{Link: "/admin/superuser/products", Title: "Manage Products", Description: "View and manage all products", Icon: svg.MenuLines("text-primary")},
 	{Link: "/admin/superuser/products/create", Title: "Create Product", Description: "Create a product", Icon: svg.Box("text-primary")},
@@ -35,6 +40,12 @@ var staffCards = []models.StaffCard{
 
 	{Link: "/admin/themes", Title: "Manage Themes", Description: "Manage dynamic themes", Icon: svg.Gear("text-primary")},
 
+	{Link: "/admin/experiments", Title: "A/B Experiments", Description: "Test themes and promo banners", Icon: svg.Lightning("text-primary")},
+
+	{Link: "/admin/analytics", Title: "Analytics", Description: "Sales, top products and funnel", Icon: svg.Document("text-primary")},
+
+	{Link: "/admin/search", Title: "Search Merchandising", Description: "Search insights and search rules", Icon: svg.Search("", "text-primary")},
+
 	{Link: "/admin/cpoints/generate", Title: "Generate C-Points", Description: "Generate C-Points for a customer", Icon: svg.Lightning("text-primary")},
 
 	{Link: "/admin/memos", Title: "Manage Memos", Description: "Create and manage staff memorandums", Icon: svg.Document("text-primary")},
@@ -43,11 +54,18 @@ var staffCards = []models.StaffCard{
 
 	{Link: "/admin/quotations", Title: "Manage Quotations", Description: "View and approve customer quotations", Icon: svg.Document("text-primary")},
 
+	{Link: "/admin/reviews", Title: "Product Reviews", Description: "Moderate customer product reviews", Icon: svg.Document("text-primary")},
+
+	{Link: "/admin/sale-campaigns", Title: "Sale Campaigns", Description: "Schedule discounts across many products", Icon: svg.Document("text-primary")},
+
+	{Link: "/admin/payroll", Title: "Payroll", Description: "Compute pay and generate payslips", Icon: svg.Document("text-primary")},
+
 	{Link: "/admin/superuser/customers", Title: "Customers", Description: "View all registered customers", Icon: svg.Group("text-primary")},
 
 	{Link: "/admin/exports", Title: "Exports", Description: "Export data", Icon: svg.Document("text-primary")},
 	{Link: "/admin/imports", Title: "Imports", Description: "Bulk upload", Icon: svg.Document("text-primary")},
 
+	{Link: "/admin/superuser/sessions", Title: "Sessions", Description: "View and sign out active sessions", Icon: svg.Group("text-primary")},
 	{Link: "/admin/superuser/logs", Title: "Staff Logs", Description: "View staff action logs", Icon: svg.Document("text-primary")},
 	{Link: "/admin/superuser/envs", Title: "Envs", Description: "View application configuration", Icon: svg.Gear("text-primary")},
 }
@@ -125,7 +143,7 @@ func AdminSuperuserHomePage(fullName string, memos []models.StaffMemoCard) templ
 			var templ_7745c5c3_Var2 templ.SafeURL
 			templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinURLErrs(utils.URL(card.Link))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `admin/superuser_home.templ`, Line: 68, Col: 35}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `admin/superuser_home.templ`, Line: 86, Col: 35}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
 			if templ_7745c5c3_Err != nil {
@@ -146,7 +164,7 @@ func AdminSuperuserHomePage(fullName string, memos []models.StaffMemoCard) templ
 			var templ_7745c5c3_Var3 string
 			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(card.Title)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `admin/superuser_home.templ`, Line: 74, Col: 73}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `admin/superuser_home.templ`, Line: 92, Col: 73}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 			if templ_7745c5c3_Err != nil {
@@ -159,7 +177,7 @@ func AdminSuperuserHomePage(fullName string, memos []models.StaffMemoCard) templ
 			var templ_7745c5c3_Var4 string
 			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(card.Description)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `admin/superuser_home.templ`, Line: 75, Col: 59}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `admin/superuser_home.templ`, Line: 93, Col: 59}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
 			if templ_7745c5c3_Err != nil {
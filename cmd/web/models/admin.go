@@ -40,6 +40,9 @@ type AdminMemoListItem struct {
 	CreatedAt     string
 	EmailsSentAt  string
 	RecipientIDs  []string
+
+	RequiresAcknowledgement bool
+	AcknowledgeBy           string
 }
 
 type AdminMemoRecipientRow struct {
@@ -52,13 +55,32 @@ type AdminMemoRecipientRow struct {
 	RejectReason string
 	AcceptedAt   string
 	RejectedAt   string
+
+	ReminderCount  int64
+	LastRemindedAt string
+}
+
+type AdminMemoAcknowledgementSummary struct {
+	MemoID        string
+	Title         string
+	StartDate     string
+	EndDate       string
+	AcknowledgeBy string
+	EscalatedAt   string
+	Total         int
+	Accepted      int
+	Rejected      int
+	Percent       int
+	Overdue       bool
+	Pending       []AdminMemoRecipientRow
 }
 
 type StaffMemoCard struct {
-	ID      string
-	Title   string
-	Message string
-	FileURL string
+	ID                      string
+	Title                   string
+	Message                 string
+	FileURL                 string
+	RequiresAcknowledgement bool
 }
 
 type AdminCategoryListItem struct {
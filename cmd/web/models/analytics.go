@@ -0,0 +1,6 @@
+package models
+
+type AdminAnalyticsOption struct {
+	Value string
+	Label string
+}
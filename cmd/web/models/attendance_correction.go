@@ -0,0 +1,14 @@
+package models
+
+import "cchoice/internal/enums"
+
+type AttendanceCorrectionItem struct {
+	ID        string
+	StaffName string
+	Date      string
+	Requested string
+	Original  string
+	Reason    string
+	Status    enums.AttendanceCorrectionStatus
+	CreatedAt string
+}
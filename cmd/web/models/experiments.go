@@ -0,0 +1,6 @@
+package models
+
+type AdminExperimentOption struct {
+	Value string
+	Label string
+}
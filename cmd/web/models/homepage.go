@@ -93,6 +93,8 @@ type ProductPageData struct {
 	Sizes                      []string
 	Specs                      []ProductSpec
 	ExternalLinks              []ProductExternalPlatformLink
+	RatingAverage              float64
+	RatingCount                int64
 }
 
 type RelatedProduct struct {
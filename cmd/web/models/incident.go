@@ -0,0 +1,47 @@
+package models
+
+import "cchoice/internal/enums"
+
+type IncidentItem struct {
+	ID               string
+	StaffID          string
+	StaffName        string
+	ReportedByName   string
+	IncidentDate     string
+	Title            string
+	Description      string
+	Status           enums.IncidentStatus
+	NTEMemoID        string
+	ResponseDeadline string
+	Response         string
+	RespondedAt      string
+	Decision         enums.IncidentDecision
+	DecisionNotes    string
+	EffectiveFrom    string
+	EffectiveTo      string
+	DecidedAt        string
+	CanRespond       bool
+}
+
+type StaffHRFileMemo struct {
+	ID                      string
+	Title                   string
+	StartDate               string
+	EndDate                 string
+	RequiresAcknowledgement bool
+	AcknowledgeBy           string
+	ActionStatus            enums.MemoStaffActionStatus
+	RejectReason            string
+	ActedAt                 string
+}
+
+type StaffHRFilePageData struct {
+	StaffID   string
+	StaffName string
+	Position  string
+	DateHired string
+	Status    enums.StaffStatus
+	Today     string
+	Incidents []IncidentItem
+	Memos     []StaffHRFileMemo
+}
@@ -0,0 +1,24 @@
+package models
+
+import "cchoice/internal/enums"
+
+type StaffKioskPageData struct {
+	Staffs         []Staff
+	RefreshSeconds int
+}
+
+type StaffKioskScanPageData struct {
+	Token   string
+	Expired bool
+	HasPIN  bool
+}
+
+type KioskPunchItem struct {
+	ID        string
+	StaffName string
+	KioskName string
+	Type      enums.KioskPunchType
+	Method    enums.KioskPunchMethod
+	HasPhoto  bool
+	CreatedAt string
+}
@@ -0,0 +1,32 @@
+package models
+
+import "cchoice/internal/enums"
+
+type LeaveBalance struct {
+	Type        enums.TimeOff
+	Entitlement string
+	CarriedOver string
+	Accrued     string
+	Used        string
+	Adjusted    string
+	Available   string
+}
+
+type AdminLeaveCreditStaffItem struct {
+	StaffID   string
+	FullName  string
+	Position  string
+	DateHired string
+	VL        string
+	SL        string
+}
+
+type AdminLeaveLedgerItem struct {
+	LeaveType enums.TimeOff
+	EntryType enums.LeaveEntryType
+	Period    string
+	Credits   string
+	Note      string
+	CreatedBy string
+	CreatedAt string
+}
@@ -0,0 +1,43 @@
+package models
+
+import "cchoice/internal/enums"
+
+type AdminPayRateItem struct {
+	StaffID  string
+	FullName string
+	Position string
+	RateType enums.PayRateType
+	Rate     string
+}
+
+type AdminPayrollRunListItem struct {
+	ID          string
+	Period      string
+	Status      enums.PayrollRunStatus
+	StaffCount  int64
+	TotalNetPay string
+	UpdatedAt   string
+	LockedAt    string
+}
+
+type AdminPayrollRunItem struct {
+	StaffID          string
+	FullName         string
+	Position         string
+	RateType         enums.PayRateType
+	DailyRate        string
+	DaysWorked       int64
+	AbsentDays       int64
+	LateMinutes      int64
+	UndertimeMinutes int64
+	OvertimeMinutes  int64
+	GrossPay         string
+	Contributions    string
+	WithholdingTax   string
+	NetPay           string
+}
+
+type AdminPayrollRunDetailPageData struct {
+	Run   AdminPayrollRunListItem
+	Items []AdminPayrollRunItem
+}
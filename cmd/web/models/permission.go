@@ -0,0 +1,31 @@
+package models
+
+import "cchoice/internal/enums"
+
+type PermissionGroup struct {
+	ID          string
+	Name        string
+	Description string
+	Access      map[enums.StaffRole]enums.PermissionAccess
+}
+
+type PermissionStaffRow struct {
+	ID          string
+	FullName    string
+	IsSuperuser bool
+	Groups      []PermissionGroup
+	Access      map[enums.StaffRole]enums.PermissionAccess
+}
+
+type RoutePermission struct {
+	Method      string
+	Route       string
+	Requirement string
+}
+
+type PermissionsPage struct {
+	Roles  []enums.StaffRole
+	Groups []PermissionGroup
+	Staffs []PermissionStaffRow
+	Routes []RoutePermission
+}
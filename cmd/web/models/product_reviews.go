@@ -0,0 +1,39 @@
+package models
+
+import "cchoice/internal/enums"
+
+type ProductReviewItem struct {
+	ID           string
+	ReviewerName string
+	Rating       int64
+	Title        string
+	Body         string
+	PhotoURLs    []string
+	CreatedAt    string
+}
+
+type ProductReviewsSectionData struct {
+	ProductID       string
+	Slug            string
+	RatingAverage   float64
+	RatingCount     int64
+	Reviews         []ProductReviewItem
+	IsLoggedIn      bool
+	CanReview       bool
+	AlreadyReviewed bool
+}
+
+type AdminProductReviewListItem struct {
+	ID              string
+	ProductName     string
+	ProductSerial   string
+	CustomerName    string
+	CustomerEmail   string
+	Rating          int64
+	Title           string
+	Body            string
+	Status          enums.ProductReviewStatus
+	RejectionReason string
+	PhotoURLs       []string
+	CreatedAt       string
+}
@@ -0,0 +1,48 @@
+package models
+
+import "cchoice/internal/enums"
+
+type AdminSaleCampaignListItem struct {
+	ID            string
+	Name          string
+	Selector      enums.SaleCampaignSelector
+	SelectorValue string
+	DiscountLabel string
+	StartsAt      string
+	EndsAt        string
+	Status        enums.SaleCampaignStatus
+	ProductCount  int64
+}
+
+type AdminSaleCampaignFormOptions struct {
+	Brands     []AdminSaleCampaignOption
+	Categories []string
+}
+
+type AdminSaleCampaignOption struct {
+	Value string
+	Label string
+}
+
+type AdminSaleCampaignPreviewItem struct {
+	Serial       string
+	Name         string
+	UnitPrice    string
+	SalePrice    string
+	HasOtherSale bool
+}
+
+type AdminSaleCampaignReportItem struct {
+	Serial    string
+	Name      string
+	UnitsSold int64
+	Revenue   string
+}
+
+type AdminSaleCampaignDetailPageData struct {
+	Campaign     AdminSaleCampaignListItem
+	Products     []AdminSaleCampaignPreviewItem
+	Report       []AdminSaleCampaignReportItem
+	TotalUnits   int64
+	TotalRevenue string
+}
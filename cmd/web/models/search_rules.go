@@ -0,0 +1,6 @@
+package models
+
+type AdminSearchRuleOption struct {
+	Value string
+	Label string
+}
@@ -0,0 +1,68 @@
+package models
+
+import "cchoice/internal/enums"
+
+type AdminShiftStaffItem struct {
+	StaffID      string
+	FullName     string
+	Position     string
+	DefaultShift string
+	Pattern      string
+}
+
+type AdminShiftDay struct {
+	Week    int
+	Weekday int
+	Label   string
+	TimeIn  string
+	TimeOut string
+	RestDay bool
+}
+
+type AdminShiftWeek struct {
+	Index int
+	Label string
+	Days  []AdminShiftDay
+}
+
+type AdminShiftOverrideItem struct {
+	ID       string
+	Date     string
+	Shift    string
+	Note     string
+	FromSwap bool
+}
+
+type AdminShiftEditor struct {
+	StaffID      string
+	FullName     string
+	Position     string
+	DefaultShift string
+	CycleWeeks   int
+	AnchorDate   string
+	Weeks        []AdminShiftWeek
+	Overrides    []AdminShiftOverrideItem
+}
+
+type ShiftSwapItem struct {
+	ID          string
+	Requester   string
+	Counterpart string
+	Date        string
+	Reason      string
+	Status      enums.ShiftSwapStatus
+	CreatedAt   string
+}
+
+type StaffShiftDayItem struct {
+	Date    string
+	Weekday string
+	Shift   string
+	RestDay bool
+}
+
+type StaffShiftsPageData struct {
+	Days      []StaffShiftDayItem
+	Swaps     []ShiftSwapItem
+	Coworkers []Staff
+}
@@ -62,6 +62,7 @@ type CategorySectionProduct struct {
 	OrigPriceDisplay   string
 	PriceDisplay       string
 	DiscountPercentage string
+	RatingAverage      float64
 	queries.GetProductsByCategoryIDRow
 }
 
@@ -99,6 +100,7 @@ func ToCategorySectionProducts[T queries.GetProductsByCategoryIDRow](
 			OrigPriceDisplay:           origPrice.Display(),
 			PriceDisplay:               discountedPrice.Display(),
 			DiscountPercentage:         discountPercentage,
+			RatingAverage:              utils.RatingAverage(r.RatingCount, r.RatingTotal),
 		})
 	}
 	return res
@@ -109,15 +111,14 @@ type SearchResultProduct struct {
 	CDNURL       string
 	CDNURL1280   string
 	PriceDisplay string
-	queries.GetProductsBySearchQueryRow
+	queries.GetProductsBySearchQueryPaginatedRow
 }
 
-func ToSearchResultProduct[T queries.GetProductsBySearchQueryRow](
+func ToSearchResultProduct(
 	encoder encode.IEncode,
 	getCDNURL CDNURLFunc,
-	data T,
+	r queries.GetProductsBySearchQueryPaginatedRow,
 ) SearchResultProduct {
-	r := queries.GetProductsBySearchQueryRow(data)
 	price := utils.NewMoney(r.UnitPriceWithVat, r.UnitPriceWithVatCurrency)
 
 	cdnURL := r.CdnUrl.String
@@ -130,11 +131,11 @@ func ToSearchResultProduct[T queries.GetProductsBySearchQueryRow](
 	}
 
 	return SearchResultProduct{
-		GetProductsBySearchQueryRow: r,
-		ProductID:                   encoder.Encode(r.ID),
-		CDNURL:                      cdnURL,
-		CDNURL1280:                  cdnURLThumbnail,
-		PriceDisplay:                price.Display(),
+		GetProductsBySearchQueryPaginatedRow: r,
+		ProductID:                            encoder.Encode(r.ID),
+		CDNURL:                               cdnURL,
+		CDNURL1280:                           cdnURLThumbnail,
+		PriceDisplay:                         price.Display(),
 	}
 }
 
@@ -169,6 +170,7 @@ type AdminStaffProfile struct {
 	CanLunchBreakIn  bool
 	CanLunchBreakOut bool
 	RequireInShop    bool
+	HasKioskPIN      bool
 }
 
 type CustomerProfile struct {
@@ -236,6 +238,7 @@ type AttendanceStat struct {
 	OutStatus     enums.TimeOutStatus
 	InLate        time.Duration
 	Undertime     time.Duration
+	Overtime      time.Duration
 	EarlyIn       time.Duration
 	InShop        bool
 	OutShop       bool
@@ -0,0 +1,23 @@
+package models
+
+// TwoFactorEnrolment is shown while setting up an authenticator app. QRCode
+// is a data URI of the otpauth link.
+type TwoFactorEnrolment struct {
+	QRCode     string
+	Secret     string
+	ConfirmURL string
+}
+
+type TwoFactorSettings struct {
+	Enabled           bool
+	EnabledAt         string
+	RecoveryCodesLeft int64
+	TrustedDevices    int64
+	IsSuperuser       bool
+	Enrolment         TwoFactorEnrolment
+}
+
+type TwoFactorRecoveryCodes struct {
+	Codes       []string
+	ContinueURL string
+}
@@ -0,0 +1,20 @@
+package models
+
+type ProductWishlistSectionData struct {
+	ProductID    string
+	Slug         string
+	IsLoggedIn   bool
+	IsWishlisted bool
+	InStock      bool
+}
+
+type CustomerWishlistItem struct {
+	ProductID    string
+	Name         string
+	Serial       string
+	Slug         string
+	PriceDisplay string
+	ImageURL     string
+	InStock      bool
+	AddedAt      string
+}
@@ -0,0 +1,449 @@
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"cchoice/internal/errs"
+	"cchoice/internal/storage"
+)
+
+const (
+	namePrefix = "cchoice_backup_"
+	nameSuffix = ".sqlite.gz"
+	nameLayout = "2006-01-02_15-04-05"
+
+	// LatestSnapshot can be passed wherever a snapshot name is expected.
+	LatestSnapshot = "latest"
+)
+
+type Snapshot struct {
+	CreatedAt time.Time
+	Name      string
+	Key       string
+	Size      int64
+}
+
+type Report struct {
+	Snapshot     Snapshot
+	Previous     string
+	GooseVersion int64
+	Pruned       int
+}
+
+// Backup snapshots a live SQLite database with VACUUM INTO, which gives a
+// consistent copy without blocking writers, and keeps the gzipped snapshots in
+// object storage under prefix.
+type Backup struct {
+	db       *sql.DB
+	store    storage.IObjectLister
+	prefix   string
+	keepLast int
+}
+
+// New builds a Backup. db is the source database and can be nil when the
+// Backup is only used to list or restore snapshots.
+func New(db *sql.DB, store storage.IObjectLister, prefix string, keepLast int) *Backup {
+	if store == nil {
+		panic("object storage is required")
+	}
+	return &Backup{
+		db:       db,
+		store:    store,
+		prefix:   strings.Trim(prefix, "/"),
+		keepLast: keepLast,
+	}
+}
+
+func SnapshotName(at time.Time) string {
+	return namePrefix + at.UTC().Format(nameLayout) + nameSuffix
+}
+
+func parseSnapshotName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, namePrefix) || !strings.HasSuffix(name, nameSuffix) {
+		return time.Time{}, false
+	}
+	ts := strings.TrimSuffix(strings.TrimPrefix(name, namePrefix), nameSuffix)
+	at, err := time.Parse(nameLayout, ts)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+func (b *Backup) key(name string) string {
+	if b.prefix == "" {
+		return name
+	}
+	return b.prefix + "/" + name
+}
+
+// DBPath returns the database file behind a DB_URL such as "file:./test.db".
+func DBPath(dbURL string) (string, error) {
+	p := strings.TrimPrefix(dbURL, "file:")
+	p, _, _ = strings.Cut(p, "?")
+	if strings.HasPrefix(p, "///") {
+		p = strings.TrimPrefix(p, "//")
+	}
+	if p == "" || p == ":memory:" || strings.HasPrefix(p, "//") {
+		return "", fmt.Errorf("%w: '%s'", errs.ErrBackupDBURL, dbURL)
+	}
+	return filepath.Clean(p), nil
+}
+
+// Run takes a snapshot, verifies it before it leaves the machine, uploads it
+// and then prunes the snapshots past the retention.
+func (b *Backup) Run(ctx context.Context, now time.Time) (*Report, error) {
+	if b.db == nil {
+		return nil, errors.Join(errs.ErrBackup, errors.New("no source database"))
+	}
+
+	version, err := gooseVersion(ctx, b.db)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir, err := os.MkdirTemp("", namePrefix)
+	if err != nil {
+		return nil, errors.Join(errs.ErrBackup, err)
+	}
+	defer os.RemoveAll(workDir)
+
+	snapshotPath := filepath.Join(workDir, "cchoice.sqlite")
+	if _, err := b.db.ExecContext(ctx, "VACUUM INTO ?", snapshotPath); err != nil {
+		return nil, errors.Join(errs.ErrBackup, err)
+	}
+	if _, err := Verify(ctx, snapshotPath, version); err != nil {
+		return nil, err
+	}
+
+	name := SnapshotName(now)
+	archivePath := filepath.Join(workDir, name)
+	size, err := compress(snapshotPath, archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return nil, errors.Join(errs.ErrBackup, err)
+	}
+	defer archive.Close()
+
+	key := b.key(name)
+	if err := b.store.PutObject(ctx, key, archive, "application/gzip"); err != nil {
+		return nil, errors.Join(errs.ErrBackup, err)
+	}
+
+	pruned, err := b.Prune(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Report{
+		Snapshot: Snapshot{
+			CreatedAt: now.UTC().Truncate(time.Second),
+			Name:      name,
+			Key:       key,
+			Size:      size,
+		},
+		GooseVersion: version,
+		Pruned:       pruned,
+	}, nil
+}
+
+// List returns the snapshots in storage, newest first. Objects under the
+// prefix that are not named like a snapshot are ignored.
+func (b *Backup) List(ctx context.Context) ([]Snapshot, error) {
+	objects, err := b.store.ListObjects(ctx, b.key(""), 0)
+	if err != nil {
+		return nil, errors.Join(errs.ErrBackup, err)
+	}
+
+	snapshots := make([]Snapshot, 0, len(objects))
+	for _, obj := range objects {
+		name := path.Base(obj.Key)
+		createdAt, ok := parseSnapshotName(name)
+		if !ok {
+			continue
+		}
+		snapshots = append(snapshots, Snapshot{
+			CreatedAt: createdAt,
+			Name:      name,
+			Key:       b.key(name),
+			Size:      obj.Size,
+		})
+	}
+	slices.SortFunc(snapshots, func(a, b Snapshot) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return snapshots, nil
+}
+
+// Prune deletes every snapshot past the newest keepLast. The snapshot at
+// current, the one just uploaded, is always kept and counts toward keepLast,
+// even when snapshots named later than it are in storage. A keepLast of zero
+// or less keeps everything.
+func (b *Backup) Prune(ctx context.Context, current string) (int, error) {
+	if b.keepLast <= 0 {
+		return 0, nil
+	}
+	snapshots, err := b.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	kept := 0
+	if slices.ContainsFunc(snapshots, func(s Snapshot) bool { return s.Key == current }) {
+		kept++
+	}
+	pruned := 0
+	for _, snapshot := range snapshots {
+		if snapshot.Key == current {
+			continue
+		}
+		if kept < b.keepLast {
+			kept++
+			continue
+		}
+		if err := b.store.DeleteObject(ctx, snapshot.Key); err != nil {
+			return pruned, errors.Join(errs.ErrBackup, err)
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+func (b *Backup) resolve(ctx context.Context, name string) (string, error) {
+	if name != LatestSnapshot {
+		if _, ok := parseSnapshotName(name); !ok {
+			return "", fmt.Errorf("%w: '%s'", errs.ErrBackupNotFound, name)
+		}
+		return name, nil
+	}
+	snapshots, err := b.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(snapshots) == 0 {
+		return "", errs.ErrBackupNotFound
+	}
+	return snapshots[0].Name, nil
+}
+
+// Download writes the decompressed snapshot to dst.
+func (b *Backup) Download(ctx context.Context, name string, dst string) (string, error) {
+	name, err := b.resolve(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	key := b.key(name)
+	exists, err := b.store.ObjectExists(ctx, key)
+	if err != nil {
+		return "", errors.Join(errs.ErrBackup, err)
+	}
+	if !exists {
+		return "", fmt.Errorf("%w: '%s'", errs.ErrBackupNotFound, name)
+	}
+
+	body, err := b.store.GetObject(ctx, key)
+	if err != nil {
+		return "", errors.Join(errs.ErrBackup, err)
+	}
+	defer body.Close()
+
+	if err := decompress(body, dst); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// Restore replaces the database at target with a snapshot. The snapshot is
+// verified before anything is touched, and the current database together with
+// its WAL is kept next to it with a ".pre-restore-<time>" suffix.
+func (b *Backup) Restore(ctx context.Context, name string, target string) (*Report, error) {
+	restoring := target + ".restoring"
+	defer os.Remove(restoring)
+
+	name, err := b.Download(ctx, name, restoring)
+	if err != nil {
+		return nil, err
+	}
+	version, err := Verify(ctx, restoring, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	kept, err := install(restoring, target)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, _ := parseSnapshotName(name)
+	return &Report{
+		Snapshot: Snapshot{
+			CreatedAt: createdAt,
+			Name:      name,
+			Key:       b.key(name),
+		},
+		Previous:     kept,
+		GooseVersion: version,
+	}, nil
+}
+
+// install moves a verified database into place at target. An existing
+// database and its WAL are renamed with a ".pre-restore-<time>" suffix first,
+// and the kept path is returned.
+func install(restored string, target string) (string, error) {
+	var kept string
+	if _, err := os.Stat(target); err == nil {
+		kept = target + ".pre-restore-" + time.Now().UTC().Format(nameLayout)
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			if err := os.Rename(target+suffix, kept+suffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return "", errors.Join(errs.ErrBackup, err)
+			}
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", errors.Join(errs.ErrBackup, err)
+	}
+
+	if err := os.Rename(restored, target); err != nil {
+		return "", errors.Join(errs.ErrBackup, err)
+	}
+	return kept, nil
+}
+
+// Verify opens the snapshot read-only, runs PRAGMA integrity_check and reads
+// the goose migration version. When wantVersion is set the snapshot must be at
+// exactly that version.
+func Verify(ctx context.Context, snapshotPath string, wantVersion int64) (int64, error) {
+	db, err := sql.Open("sqlite3", "file:"+snapshotPath+"?mode=ro")
+	if err != nil {
+		return 0, errors.Join(errs.ErrBackup, err)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return 0, errors.Join(errs.ErrBackupIntegrity, err)
+	}
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			rows.Close()
+			return 0, errors.Join(errs.ErrBackupIntegrity, err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return 0, errors.Join(errs.ErrBackupIntegrity, err)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, errors.Join(errs.ErrBackupIntegrity, err)
+	}
+	if len(problems) > 0 {
+		return 0, fmt.Errorf("%w: %s", errs.ErrBackupIntegrity, strings.Join(problems[:min(len(problems), 5)], "; "))
+	}
+
+	version, err := gooseVersion(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	if version == 0 {
+		return 0, fmt.Errorf("%w: no migrations applied", errs.ErrBackupVersion)
+	}
+	if wantVersion > 0 && version != wantVersion {
+		return 0, fmt.Errorf("%w: snapshot is at %d, expected %d", errs.ErrBackupVersion, version, wantVersion)
+	}
+	return version, nil
+}
+
+// gooseVersion mirrors how goose reads the current version: the highest
+// version whose latest row is an apply rather than a rollback.
+func gooseVersion(ctx context.Context, db *sql.DB) (int64, error) {
+	var version int64
+	err := db.QueryRowContext(ctx, `
+		SELECT g.version_id
+		FROM goose_db_version g
+		WHERE g.is_applied = 1
+		AND NOT EXISTS (
+			SELECT 1 FROM goose_db_version n
+			WHERE n.version_id = g.version_id AND n.id > g.id
+		)
+		ORDER BY g.version_id DESC
+		LIMIT 1
+	`).Scan(&version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, errors.Join(errs.ErrBackupVersion, err)
+	}
+	return version, nil
+}
+
+func compress(src string, dst string) (int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, errors.Join(errs.ErrBackup, err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return 0, errors.Join(errs.ErrBackup, err)
+	}
+	defer out.Close()
+
+	zw := gzip.NewWriter(out)
+	if _, err := io.Copy(zw, in); err != nil {
+		return 0, errors.Join(errs.ErrBackup, err)
+	}
+	if err := zw.Close(); err != nil {
+		return 0, errors.Join(errs.ErrBackup, err)
+	}
+
+	info, err := out.Stat()
+	if err != nil {
+		return 0, errors.Join(errs.ErrBackup, err)
+	}
+	return info.Size(), nil
+}
+
+func decompress(src io.Reader, dst string) error {
+	zr, err := gzip.NewReader(src)
+	if err != nil {
+		return errors.Join(errs.ErrBackup, err)
+	}
+	defer zr.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return errors.Join(errs.ErrBackup, err)
+	}
+	if _, err := io.Copy(out, zr); err != nil {
+		out.Close()
+		return errors.Join(errs.ErrBackup, err)
+	}
+	if err := out.Close(); err != nil {
+		return errors.Join(errs.ErrBackup, err)
+	}
+	return nil
+}
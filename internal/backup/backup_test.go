@@ -0,0 +1,167 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cchoice/internal/errs"
+	"cchoice/internal/storage/local"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSourceDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+path+"?_journal_mode=wal")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`
+		CREATE TABLE goose_db_version (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			version_id INTEGER NOT NULL,
+			is_applied INTEGER NOT NULL,
+			tstamp TIMESTAMP DEFAULT (datetime('now'))
+		);
+		INSERT INTO goose_db_version (version_id, is_applied) VALUES (0, 1), (20260101000000, 1), (20260201000000, 1), (20260201000000, 0);
+		CREATE TABLE tbl_items (id INTEGER PRIMARY KEY, name TEXT NOT NULL);
+		INSERT INTO tbl_items (name) VALUES ('drill'), ('saw');
+	`)
+	require.NoError(t, err)
+	return db
+}
+
+func TestDBPath(t *testing.T) {
+	for dbURL, want := range map[string]string{
+		"file:./test.db":          "test.db",
+		"file:/data/cchoice.db?x": "/data/cchoice.db",
+		"file:///data/cchoice.db": "/data/cchoice.db",
+		"prod.db":                 "prod.db",
+	} {
+		got, err := DBPath(dbURL)
+		require.NoError(t, err, dbURL)
+		assert.Equal(t, want, got, dbURL)
+	}
+
+	for _, dbURL := range []string{"", ":memory:", "file::memory:?cache=shared", "file://host/cchoice.db"} {
+		_, err := DBPath(dbURL)
+		assert.ErrorIs(t, err, errs.ErrBackupDBURL, dbURL)
+	}
+}
+
+func TestSnapshotName(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 3, 4, 5, 0, time.UTC)
+	name := SnapshotName(at)
+	assert.Equal(t, "cchoice_backup_2026-10-19_03-04-05.sqlite.gz", name)
+
+	parsed, ok := parseSnapshotName(name)
+	require.True(t, ok)
+	assert.True(t, at.Equal(parsed))
+
+	_, ok = parseSnapshotName("cchoice_backup_2026-10-19.tar.gz")
+	assert.False(t, ok)
+}
+
+func TestBackupRunAndRestore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db := newSourceDB(t, filepath.Join(dir, "source.db"))
+
+	store, err := local.NewObjectStorage(filepath.Join(dir, "store"))
+	require.NoError(t, err)
+	b := New(db, store, "backups/db", 2)
+
+	start := time.Date(2026, time.October, 19, 3, 0, 0, 0, time.UTC)
+	var last *Report
+	for i := range 3 {
+		last, err = b.Run(ctx, start.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(20260101000000), last.GooseVersion)
+	}
+	assert.Equal(t, 1, last.Pruned)
+
+	snapshots, err := b.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, last.Snapshot.Name, snapshots[0].Name)
+	assert.True(t, snapshots[0].CreatedAt.After(snapshots[1].CreatedAt))
+
+	_, err = db.Exec("DELETE FROM tbl_items")
+	require.NoError(t, err)
+
+	target := filepath.Join(dir, "restored.db")
+	require.NoError(t, os.WriteFile(target, []byte("old"), 0o600))
+	report, err := b.Restore(ctx, LatestSnapshot, target)
+	require.NoError(t, err)
+	assert.Equal(t, last.Snapshot.Name, report.Snapshot.Name)
+
+	restored, err := sql.Open("sqlite3", "file:"+target+"?mode=ro")
+	require.NoError(t, err)
+	defer restored.Close()
+	var count int
+	require.NoError(t, restored.QueryRow("SELECT COUNT(*) FROM tbl_items").Scan(&count))
+	assert.Equal(t, 2, count)
+
+	kept, err := filepath.Glob(target + ".pre-restore-*")
+	require.NoError(t, err)
+	assert.Len(t, kept, 1)
+
+	_, err = b.Restore(ctx, SnapshotName(start), target)
+	assert.ErrorIs(t, err, errs.ErrBackupNotFound)
+}
+
+func TestBackupRunKeepsNewSnapshot(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db := newSourceDB(t, filepath.Join(dir, "source.db"))
+
+	store, err := local.NewObjectStorage(filepath.Join(dir, "store"))
+	require.NoError(t, err)
+	b := New(db, store, "backups/db", 2)
+
+	// Snapshots named after the one being taken, e.g. copied in by hand.
+	later := time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 2 {
+		_, err := b.Run(ctx, later.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+	}
+
+	report, err := b.Run(ctx, time.Date(2026, time.October, 19, 3, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Pruned)
+
+	snapshots, err := b.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, SnapshotName(later.Add(time.Hour)), snapshots[0].Name)
+	assert.Equal(t, report.Snapshot.Name, snapshots[1].Name)
+}
+
+func TestVerifyRejectsBadSnapshots(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "source.db")
+	newSourceDB(t, path)
+
+	_, err := Verify(ctx, path, 20260201000000)
+	assert.ErrorIs(t, err, errs.ErrBackupVersion)
+
+	empty := filepath.Join(dir, "empty.db")
+	emptyDB, err := sql.Open("sqlite3", "file:"+empty)
+	require.NoError(t, err)
+	_, err = emptyDB.Exec("CREATE TABLE goose_db_version (id INTEGER PRIMARY KEY, version_id INTEGER, is_applied INTEGER)")
+	require.NoError(t, err)
+	require.NoError(t, emptyDB.Close())
+	_, err = Verify(ctx, empty, 0)
+	assert.ErrorIs(t, err, errs.ErrBackupVersion)
+
+	garbage := filepath.Join(dir, "garbage.db")
+	require.NoError(t, os.WriteFile(garbage, []byte("not a database"), 0o600))
+	_, err = Verify(ctx, garbage, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,584 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cchoice/internal/errs"
+	"cchoice/internal/metrics"
+	"cchoice/internal/storage"
+)
+
+const (
+	generationLayout = "20060102T150405.000Z"
+	generationSuffix = ".sqlite.gz"
+	segmentSuffix    = ".wal.gz"
+)
+
+// Replica ships the WAL of a live database to object storage, the way
+// Litestream does. Each generation starts with a copy of the database file and
+// is followed by segments holding the WAL frames of whole transactions, so the
+// database can be rebuilt as of any segment.
+//
+// While it runs, the replica keeps a read transaction open on its own
+// connection. That stops SQLite from restarting the WAL over frames that have
+// not been shipped yet, and the replica checkpoints itself after shipping. If
+// the WAL still restarts in a way that could have dropped frames, a new
+// generation is started.
+type Replica struct {
+	store           storage.IObjectLister
+	db              *sql.DB
+	conn            *sql.Conn
+	now             func() time.Time
+	dbPath          string
+	prefix          string
+	checkpointPages int
+	keepGenerations int
+
+	pos     walPosition
+	reading bool
+
+	mu     sync.RWMutex
+	status ReplicaStatus
+}
+
+type walPosition struct {
+	startedAt      time.Time
+	generation     string
+	header         walHeader
+	checksum       [2]uint32
+	offset         int64
+	seq            int
+	uncheckpointed int
+	hasHeader      bool
+	// restartOK is set when every frame in the WAL is known to be shipped, so
+	// a single WAL restart from here on loses nothing.
+	restartOK bool
+}
+
+type ReplicaStatus struct {
+	RunningSince time.Time
+	LastSyncAt   time.Time
+	Generation   string
+	LastError    string
+	Offset       int64
+	Segments     int
+}
+
+type Generation struct {
+	StartedAt time.Time
+	ID        string
+	Key       string
+}
+
+type Segment struct {
+	At   time.Time
+	Key  string
+	Seq  int
+	Size int64
+}
+
+// NewReplica builds a Replica for the database file at dbPath. dbPath can be
+// empty when the Replica is only used to list or restore generations.
+func NewReplica(dbPath string, store storage.IObjectLister, prefix string, checkpointPages int, keepGenerations int) *Replica {
+	if store == nil {
+		panic("object storage is required")
+	}
+	return &Replica{
+		store:           store,
+		now:             time.Now,
+		dbPath:          dbPath,
+		prefix:          strings.Trim(prefix, "/"),
+		checkpointPages: checkpointPages,
+		keepGenerations: keepGenerations,
+	}
+}
+
+func (r *Replica) key(parts ...string) string {
+	if r.prefix == "" {
+		return path.Join(parts...)
+	}
+	return path.Join(append([]string{r.prefix}, parts...)...)
+}
+
+func (r *Replica) Open(ctx context.Context) error {
+	if r.dbPath == "" {
+		return errors.Join(errs.ErrReplica, errs.ErrPathEmpty)
+	}
+	db, err := sql.Open("sqlite3", "file:"+r.dbPath+"?_journal_mode=wal&_busy_timeout=5000")
+	if err != nil {
+		return errors.Join(errs.ErrReplica, err)
+	}
+	db.SetMaxOpenConns(1)
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		db.Close()
+		return errors.Join(errs.ErrReplica, err)
+	}
+	r.db = db
+	r.conn = conn
+
+	r.mu.Lock()
+	r.status.RunningSince = r.now()
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *Replica) Close() error {
+	if r.conn == nil {
+		return nil
+	}
+	readErr := r.endRead(context.Background())
+	connErr := r.conn.Close()
+	dbErr := r.db.Close()
+	r.conn, r.db = nil, nil
+	if err := errors.Join(readErr, connErr, dbErr); err != nil {
+		return errors.Join(errs.ErrReplica, err)
+	}
+	return nil
+}
+
+func (r *Replica) beginRead(ctx context.Context) error {
+	if r.reading {
+		return nil
+	}
+	if _, err := r.conn.ExecContext(ctx, "BEGIN"); err != nil {
+		return errors.Join(errs.ErrReplica, err)
+	}
+	var n int
+	if err := r.conn.QueryRowContext(ctx, "SELECT COUNT(1) FROM sqlite_master").Scan(&n); err != nil {
+		_, _ = r.conn.ExecContext(ctx, "ROLLBACK")
+		return errors.Join(errs.ErrReplica, err)
+	}
+	r.reading = true
+	return nil
+}
+
+func (r *Replica) endRead(ctx context.Context) error {
+	if !r.reading {
+		return nil
+	}
+	r.reading = false
+	if _, err := r.conn.ExecContext(ctx, "ROLLBACK"); err != nil {
+		return errors.Join(errs.ErrReplica, err)
+	}
+	return nil
+}
+
+// StartGeneration uploads a copy of the database file and ships the WAL from
+// its beginning afterwards. The read transaction is taken first, so the copy
+// plus every frame in the current WAL always adds up to the live database.
+func (r *Replica) StartGeneration(ctx context.Context) error {
+	if err := r.endRead(ctx); err != nil {
+		return err
+	}
+	if err := r.beginRead(ctx); err != nil {
+		return err
+	}
+
+	startedAt := r.now().UTC()
+	pos := walPosition{
+		startedAt:  startedAt,
+		generation: startedAt.Format(generationLayout),
+		restartOK:  true,
+	}
+	if header, err := readWALHeader(r.dbPath + "-wal"); err == nil {
+		pos.header = header
+		pos.hasHeader = true
+		pos.offset = walHeaderSize
+		pos.checksum = header.checksum
+	}
+
+	workDir, err := os.MkdirTemp("", "cchoice_replica_")
+	if err != nil {
+		return errors.Join(errs.ErrReplica, err)
+	}
+	defer os.RemoveAll(workDir)
+
+	archivePath := filepath.Join(workDir, pos.generation+generationSuffix)
+	if _, err := compress(r.dbPath, archivePath); err != nil {
+		return errors.Join(errs.ErrReplica, err)
+	}
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return errors.Join(errs.ErrReplica, err)
+	}
+	defer archive.Close()
+	if err := r.store.PutObject(ctx, r.key(pos.generation+generationSuffix), archive, "application/gzip"); err != nil {
+		return errors.Join(errs.ErrReplica, err)
+	}
+
+	r.pos = pos
+	metrics.Replication.Generation()
+	r.mu.Lock()
+	r.status.Generation = pos.generation
+	r.status.Segments = 0
+	r.status.Offset = pos.offset
+	r.status.LastSyncAt = startedAt
+	r.status.LastError = ""
+	r.mu.Unlock()
+
+	return r.pruneGenerations(ctx)
+}
+
+func readWALHeader(walPath string) (walHeader, error) {
+	f, err := os.Open(walPath)
+	if err != nil {
+		return walHeader{}, err
+	}
+	defer f.Close()
+
+	b := make([]byte, walHeaderSize)
+	if _, err := io.ReadFull(f, b); err != nil {
+		return walHeader{}, err
+	}
+	return parseWALHeader(b)
+}
+
+// Sync ships the transactions committed to the WAL since the last sync as one
+// segment and returns the number of frames shipped.
+func (r *Replica) Sync(ctx context.Context) (int, error) {
+	if r.pos.generation == "" {
+		return 0, errors.Join(errs.ErrReplica, errs.ErrReplicaNoGeneration)
+	}
+
+	data, err := os.ReadFile(r.dbPath + "-wal")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return 0, errors.Join(errs.ErrReplica, err)
+	}
+	header, err := parseWALHeader(data)
+	if err != nil {
+		// No WAL yet, or one that is being reset. Either way nothing new has
+		// been committed to it.
+		r.synced()
+		return 0, nil
+	}
+
+	pos := r.pos
+	if !pos.hasHeader || header.salt1 != pos.header.salt1 || header.salt2 != pos.header.salt2 {
+		if pos.hasHeader && (!pos.restartOK || header.salt1 != pos.header.salt1+1) {
+			return 0, errs.ErrReplicaDiscontinuity
+		}
+		pos.header = header
+		pos.hasHeader = true
+		pos.offset = walHeaderSize
+		pos.checksum = header.checksum
+	}
+	if pos.offset > int64(len(data)) {
+		return 0, errs.ErrReplicaDiscontinuity
+	}
+
+	scan := scanWALFrames(header, pos.checksum, data[pos.offset:])
+	if scan.frames == 0 {
+		r.pos = pos
+		r.synced()
+		return 0, nil
+	}
+
+	frames := data[pos.offset : pos.offset+scan.size]
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(frames); err != nil {
+		return 0, errors.Join(errs.ErrReplica, err)
+	}
+	if err := zw.Close(); err != nil {
+		return 0, errors.Join(errs.ErrReplica, err)
+	}
+
+	pos.seq++
+	segmentName := fmt.Sprintf("%010d_%013d%s", pos.seq, r.now().UnixMilli(), segmentSuffix)
+	if err := r.store.PutObjectFromBytes(ctx, r.key(pos.generation, segmentName), buf.Bytes(), "application/gzip"); err != nil {
+		return 0, errors.Join(errs.ErrReplica, err)
+	}
+
+	pos.offset += scan.size
+	pos.checksum = scan.checksum
+	pos.uncheckpointed += scan.frames
+	pos.restartOK = false
+	r.pos = pos
+	metrics.Replication.Shipped(scan.frames, scan.size)
+	r.synced()
+
+	if r.checkpointPages > 0 && pos.uncheckpointed >= r.checkpointPages {
+		if err := r.checkpoint(ctx); err != nil {
+			return scan.frames, err
+		}
+	}
+	return scan.frames, nil
+}
+
+func (r *Replica) synced() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status.LastSyncAt = r.now()
+	r.status.LastError = ""
+	r.status.Offset = r.pos.offset
+	r.status.Segments = r.pos.seq
+}
+
+// checkpoint lets SQLite move the shipped frames into the database file. The
+// read transaction is dropped only for the checkpoint itself. A restart of the
+// WAL afterwards is safe only when the WAL held nothing but shipped frames
+// and all of them were checkpointed.
+func (r *Replica) checkpoint(ctx context.Context) error {
+	if err := r.endRead(ctx); err != nil {
+		return err
+	}
+	var busy, walFrames, checkpointed int64
+	checkpointErr := r.conn.QueryRowContext(ctx, "PRAGMA wal_checkpoint(PASSIVE)").Scan(&busy, &walFrames, &checkpointed)
+	if err := r.beginRead(ctx); err != nil {
+		return err
+	}
+	if checkpointErr != nil {
+		return errors.Join(errs.ErrReplica, checkpointErr)
+	}
+
+	shipped := (r.pos.offset - walHeaderSize) / r.pos.header.frameSize()
+	r.pos.restartOK = busy == 0 && walFrames == shipped && checkpointed == walFrames
+	r.pos.uncheckpointed = 0
+	return nil
+}
+
+func (r *Replica) fail(err error) {
+	metrics.Replication.Error()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status.LastError = err.Error()
+}
+
+func (r *Replica) Status() ReplicaStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status
+}
+
+// Lag is how long ago every committed transaction was known to be shipped.
+func (r *Replica) Lag() time.Duration {
+	status := r.Status()
+	since := status.LastSyncAt
+	if since.IsZero() {
+		since = status.RunningSince
+	}
+	if since.IsZero() {
+		return 0
+	}
+	return r.now().Sub(since)
+}
+
+// Generations returns the generations in storage, newest first.
+func (r *Replica) Generations(ctx context.Context) ([]Generation, error) {
+	prefix := r.prefix
+	if prefix != "" {
+		prefix += "/"
+	}
+	objects, err := r.store.ListObjects(ctx, prefix, 0)
+	if err != nil {
+		return nil, errors.Join(errs.ErrReplica, err)
+	}
+
+	generations := make([]Generation, 0, len(objects))
+	for _, obj := range objects {
+		name := path.Base(obj.Key)
+		id, ok := strings.CutSuffix(name, generationSuffix)
+		if !ok {
+			continue
+		}
+		startedAt, err := time.Parse(generationLayout, id)
+		if err != nil {
+			continue
+		}
+		generations = append(generations, Generation{
+			StartedAt: startedAt,
+			ID:        id,
+			Key:       r.key(name),
+		})
+	}
+	slices.SortFunc(generations, func(a, b Generation) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+	return generations, nil
+}
+
+// Segments returns the segments of a generation in the order they were shipped.
+func (r *Replica) Segments(ctx context.Context, generation string) ([]Segment, error) {
+	objects, err := r.store.ListObjects(ctx, r.key(generation)+"/", 0)
+	if err != nil {
+		return nil, errors.Join(errs.ErrReplica, err)
+	}
+
+	segments := make([]Segment, 0, len(objects))
+	for _, obj := range objects {
+		name := path.Base(obj.Key)
+		seqPart, atPart, ok := strings.Cut(strings.TrimSuffix(name, segmentSuffix), "_")
+		if !ok || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		seq, err := strconv.Atoi(seqPart)
+		if err != nil {
+			continue
+		}
+		at, err := strconv.ParseInt(atPart, 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, Segment{
+			At:   time.UnixMilli(at).UTC(),
+			Key:  r.key(generation, name),
+			Seq:  seq,
+			Size: obj.Size,
+		})
+	}
+	slices.SortFunc(segments, func(a, b Segment) int {
+		return a.Seq - b.Seq
+	})
+	return segments, nil
+}
+
+func (r *Replica) pruneGenerations(ctx context.Context) error {
+	if r.keepGenerations <= 0 {
+		return nil
+	}
+	generations, err := r.Generations(ctx)
+	if err != nil {
+		return err
+	}
+	if len(generations) <= r.keepGenerations {
+		return nil
+	}
+
+	for _, generation := range generations[r.keepGenerations:] {
+		segments, err := r.Segments(ctx, generation.ID)
+		if err != nil {
+			return err
+		}
+		for _, segment := range segments {
+			if err := r.store.DeleteObject(ctx, segment.Key); err != nil {
+				return errors.Join(errs.ErrReplica, err)
+			}
+		}
+		if err := r.store.DeleteObject(ctx, generation.Key); err != nil {
+			return errors.Join(errs.ErrReplica, err)
+		}
+	}
+	return nil
+}
+
+// RestoreAt rebuilds the database as of at into target: the newest generation
+// started by then, plus every segment shipped by then. The result is verified
+// before it replaces target.
+func (r *Replica) RestoreAt(ctx context.Context, at time.Time, target string) (*Report, error) {
+	generations, err := r.Generations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idx := slices.IndexFunc(generations, func(g Generation) bool {
+		return !g.StartedAt.After(at)
+	})
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %s", errs.ErrReplicaNoGeneration, at.Format(time.RFC3339))
+	}
+	generation := generations[idx]
+
+	segments, err := r.Segments(ctx, generation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	restoring := target + ".restoring"
+	defer os.Remove(restoring)
+
+	body, err := r.store.GetObject(ctx, generation.Key)
+	if err != nil {
+		return nil, errors.Join(errs.ErrReplica, err)
+	}
+	err = decompress(body, restoring)
+	body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	restoredAt := generation.StartedAt
+	if err := r.applySegments(ctx, restoring, segments, at, &restoredAt); err != nil {
+		return nil, err
+	}
+
+	version, err := Verify(ctx, restoring, 0)
+	if err != nil {
+		return nil, err
+	}
+	kept, err := install(restoring, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Report{
+		Snapshot: Snapshot{
+			CreatedAt: restoredAt,
+			Name:      generation.ID,
+			Key:       generation.Key,
+		},
+		Previous:     kept,
+		GooseVersion: version,
+	}, nil
+}
+
+func (r *Replica) applySegments(ctx context.Context, dbPath string, segments []Segment, at time.Time, restoredAt *time.Time) error {
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0)
+	if err != nil {
+		return errors.Join(errs.ErrReplica, err)
+	}
+	defer f.Close()
+
+	// The page size is a big-endian uint16 at offset 16, where 1 means 65536.
+	header := make([]byte, 18)
+	if _, err := f.ReadAt(header, 0); err != nil {
+		return errors.Join(errs.ErrReplica, err)
+	}
+	pageSize := int64(binary.BigEndian.Uint16(header[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+
+	for _, segment := range segments {
+		if segment.At.After(at) {
+			break
+		}
+		compressed, err := r.store.GetObjectBytes(ctx, segment.Key)
+		if err != nil {
+			return errors.Join(errs.ErrReplica, err)
+		}
+		zr, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return errors.Join(errs.ErrReplica, err)
+		}
+		frames, err := io.ReadAll(zr)
+		zr.Close()
+		if err != nil {
+			return errors.Join(errs.ErrReplica, err)
+		}
+		if err := applyWALFrames(f, pageSize, frames); err != nil {
+			return errors.Join(errs.ErrReplica, fmt.Errorf("segment %d: %w", segment.Seq, err))
+		}
+		*restoredAt = segment.At
+	}
+
+	if err := f.Sync(); err != nil {
+		return errors.Join(errs.ErrReplica, err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cchoice/internal/errs"
+	"cchoice/internal/storage/local"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWALHeader(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "source.db")
+	db := newSourceDB(t, path)
+	_, err := db.Exec("INSERT INTO tbl_items (name) VALUES ('grinder')")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path + "-wal")
+	require.NoError(t, err)
+	header, err := parseWALHeader(data)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4096, header.pageSize)
+
+	scan := scanWALFrames(header, header.checksum, data[walHeaderSize:])
+	assert.Positive(t, scan.frames)
+	assert.Equal(t, int64(scan.frames)*header.frameSize(), scan.size)
+
+	torn := scanWALFrames(header, header.checksum, data[walHeaderSize:walHeaderSize+scan.size-1])
+	assert.Less(t, torn.frames, scan.frames)
+
+	corrupt := append([]byte(nil), data...)
+	corrupt[24] ^= 0xff
+	_, err = parseWALHeader(corrupt)
+	assert.ErrorIs(t, err, errs.ErrReplicaWAL)
+}
+
+func countItems(t *testing.T, path string) int {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	require.NoError(t, err)
+	defer db.Close()
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM tbl_items").Scan(&count))
+	return count
+}
+
+func TestReplicaSyncAndRestoreAt(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "source.db")
+	db := newSourceDB(t, path)
+
+	store, err := local.NewObjectStorage(filepath.Join(dir, "store"))
+	require.NoError(t, err)
+
+	clock := time.Date(2026, time.October, 19, 3, 0, 0, 0, time.UTC)
+	r := NewReplica(path, store, "replica", 2, 2)
+	r.now = func() time.Time { return clock }
+	require.NoError(t, r.Open(ctx))
+	defer r.Close()
+	require.NoError(t, r.StartGeneration(ctx))
+
+	insert := func(n int) {
+		for range n {
+			_, err := db.Exec("INSERT INTO tbl_items (name) VALUES ('hammer')")
+			require.NoError(t, err)
+		}
+	}
+
+	// Each sync after the first checkpoint lets the next one run, so the WAL
+	// restarts under the replica at least once.
+	for range 4 {
+		clock = clock.Add(time.Minute)
+		insert(3)
+		_, err := r.Sync(ctx)
+		require.NoError(t, err)
+	}
+	mid := clock
+	midCount := 2 + 4*3
+
+	for range 4 {
+		clock = clock.Add(time.Minute)
+		insert(5)
+		_, err := r.Sync(ctx)
+		require.NoError(t, err)
+	}
+	status := r.Status()
+	assert.Positive(t, status.Segments)
+	assert.Empty(t, status.LastError)
+
+	target := filepath.Join(dir, "restored.db")
+	report, err := r.RestoreAt(ctx, clock, target)
+	require.NoError(t, err)
+	assert.Equal(t, status.Generation, report.Snapshot.Name)
+	assert.Equal(t, midCount+4*5, countItems(t, target))
+
+	report, err = r.RestoreAt(ctx, mid, target)
+	require.NoError(t, err)
+	assert.True(t, report.Snapshot.CreatedAt.Equal(mid))
+	assert.NotEmpty(t, report.Previous)
+	assert.Equal(t, midCount, countItems(t, target))
+
+	_, err = r.RestoreAt(ctx, clock.Add(-time.Hour), target)
+	assert.ErrorIs(t, err, errs.ErrReplicaNoGeneration)
+}
+
+func TestReplicaPrunesGenerations(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "source.db")
+	db := newSourceDB(t, path)
+
+	store, err := local.NewObjectStorage(filepath.Join(dir, "store"))
+	require.NoError(t, err)
+
+	clock := time.Date(2026, time.October, 19, 3, 0, 0, 0, time.UTC)
+	r := NewReplica(path, store, "replica", 0, 2)
+	r.now = func() time.Time { return clock }
+	require.NoError(t, r.Open(ctx))
+	defer r.Close()
+
+	for range 3 {
+		clock = clock.Add(time.Hour)
+		require.NoError(t, r.StartGeneration(ctx))
+		_, err := db.Exec("INSERT INTO tbl_items (name) VALUES ('level')")
+		require.NoError(t, err)
+		_, err = r.Sync(ctx)
+		require.NoError(t, err)
+	}
+
+	generations, err := r.Generations(ctx)
+	require.NoError(t, err)
+	require.Len(t, generations, 2)
+	assert.Equal(t, r.Status().Generation, generations[0].ID)
+
+	_, err = r.RestoreAt(ctx, clock.Add(-2*time.Hour), filepath.Join(dir, "restored.db"))
+	assert.ErrorIs(t, err, errs.ErrReplicaNoGeneration)
+}
@@ -0,0 +1,172 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cchoice/internal/conf"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+	"cchoice/internal/metrics"
+	"cchoice/internal/storage"
+	"cchoice/internal/storage/linode"
+	"cchoice/internal/storage/local"
+)
+
+// NewStorage returns the object storage snapshots are kept in. This is
+// configured apart from STORAGE_PROVIDER because product images may live in
+// Cloudflare Images, which cannot hold anything but images.
+func NewStorage() (storage.IObjectLister, error) {
+	cfg := conf.Conf()
+	provider := strings.ToUpper(cfg.Backup.StorageProvider)
+	switch provider {
+	case storage.STORAGE_PROVIDER_LOCAL.String():
+		return local.NewObjectStorage(cfg.Backup.LocalDir)
+	case storage.STORAGE_PROVIDER_LINODE.String():
+		bucketConfig, ok := cfg.Linode.GetBucketConfig(enums.LINODE_BUCKET_PRIVATE)
+		if !ok || bucketConfig.Bucket == "" {
+			return nil, errs.ErrLinodeServiceInit
+		}
+		return linode.NewClient(linode.Config{
+			Endpoint:   cfg.Linode.Endpoint,
+			Region:     cfg.Linode.Region,
+			AccessKey:  bucketConfig.AccessKey,
+			SecretKey:  bucketConfig.SecretKey,
+			Bucket:     bucketConfig.Bucket,
+			BasePrefix: cfg.Linode.BasePrefix,
+		})
+	default:
+		return nil, fmt.Errorf("%w: '%s'", errs.ErrBackupUnsupported, cfg.Backup.StorageProvider)
+	}
+}
+
+func (b *Backup) runLogged(ctx context.Context, now time.Time) {
+	const logtag = "[Backup] Run"
+	report, err := b.Run(ctx, now)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		return
+	}
+	logs.LogCtx(ctx).Info(
+		logtag,
+		zap.String("snapshot", report.Snapshot.Key),
+		zap.Int64("size", report.Snapshot.Size),
+		zap.Int64("goose_version", report.GooseVersion),
+		zap.Int("pruned", report.Pruned),
+	)
+}
+
+// RunScheduler takes a snapshot every interval. On start it only takes one
+// when the newest snapshot is already older than the interval, so frequent
+// restarts do not pile up snapshots and long uptimes do not skip them.
+func (b *Backup) RunScheduler(ctx context.Context, interval time.Duration) {
+	logs.Log().Info("[Backup] Starting database backup scheduler", zap.Duration("interval", interval))
+
+	snapshots, err := b.List(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error("[Backup] RunScheduler", zap.Error(err))
+	}
+	if len(snapshots) == 0 || time.Since(snapshots[0].CreatedAt) >= interval {
+		b.runLogged(ctx, time.Now())
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.runLogged(ctx, time.Now())
+		}
+	}
+}
+
+func (r *Replica) startLogged(ctx context.Context) bool {
+	if err := r.StartGeneration(ctx); err != nil {
+		r.fail(err)
+		logs.LogCtx(ctx).Error("[Replica] StartGeneration", zap.Error(err))
+		return false
+	}
+	logs.LogCtx(ctx).Info("[Replica] Started generation", zap.String("generation", r.Status().Generation))
+	return true
+}
+
+// Run replicates until ctx is done. The WAL is synced every syncInterval and a
+// new generation is started every snapshotInterval, or sooner when the WAL
+// restarted past frames that were never shipped.
+func (r *Replica) Run(ctx context.Context, syncInterval time.Duration, snapshotInterval time.Duration) {
+	const logtag = "[Replica] Run"
+	logs.Log().Info(
+		"[Replica] Starting WAL replication",
+		zap.String("db", r.dbPath),
+		zap.Duration("sync_interval", syncInterval),
+		zap.Duration("snapshot_interval", snapshotInterval),
+	)
+
+	if err := r.Open(ctx); err != nil {
+		r.fail(err)
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		return
+	}
+	defer r.Close()
+
+	started := r.startLogged(ctx)
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if started {
+				if _, err := r.Sync(context.Background()); err != nil {
+					logs.Log().Error(logtag, zap.Error(err))
+				}
+			}
+			return
+		case <-ticker.C:
+		}
+
+		switch {
+		case !started:
+			started = r.startLogged(ctx)
+		case r.now().Sub(r.pos.startedAt) >= snapshotInterval:
+			if _, err := r.Sync(ctx); err != nil {
+				logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+			}
+			started = r.startLogged(ctx)
+		default:
+			if _, err := r.Sync(ctx); err != nil {
+				r.fail(err)
+				logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+				if errors.Is(err, errs.ErrReplicaDiscontinuity) {
+					started = r.startLogged(ctx)
+				}
+			}
+		}
+		metrics.Replication.Lag(r.Lag())
+	}
+}
+
+// Health reports the replica for /health.
+func (r *Replica) Health() map[string]string {
+	status := r.Status()
+	health := map[string]string{
+		"replication_status":      "up",
+		"replication_generation":  status.Generation,
+		"replication_lag_seconds": fmt.Sprintf("%.1f", r.Lag().Seconds()),
+	}
+	if !status.LastSyncAt.IsZero() {
+		health["replication_last_sync"] = status.LastSyncAt.UTC().Format(time.RFC3339)
+	}
+	if status.LastError != "" {
+		health["replication_status"] = "degraded"
+		health["replication_error"] = status.LastError
+	}
+	return health
+}
@@ -0,0 +1,131 @@
+package backup
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+
+	"cchoice/internal/errs"
+)
+
+// The WAL layout is described in https://www.sqlite.org/fileformat2.html#walformat.
+// Every header field is big-endian; the magic number only decides the byte
+// order of the words the checksum runs over.
+const (
+	walHeaderSize      = 32
+	walFrameHeaderSize = 24
+	walMagicLE         = 0x377f0682
+	walMagicBE         = 0x377f0683
+)
+
+type walHeader struct {
+	checksum  [2]uint32
+	pageSize  uint32
+	salt1     uint32
+	salt2     uint32
+	bigEndian bool
+}
+
+func (h walHeader) frameSize() int64 {
+	return walFrameHeaderSize + int64(h.pageSize)
+}
+
+func parseWALHeader(b []byte) (walHeader, error) {
+	if len(b) < walHeaderSize {
+		return walHeader{}, fmt.Errorf("%w: short header", errs.ErrReplicaWAL)
+	}
+	magic := binary.BigEndian.Uint32(b[0:4])
+	if magic != walMagicLE && magic != walMagicBE {
+		return walHeader{}, fmt.Errorf("%w: bad magic %x", errs.ErrReplicaWAL, magic)
+	}
+
+	h := walHeader{
+		pageSize:  binary.BigEndian.Uint32(b[8:12]),
+		salt1:     binary.BigEndian.Uint32(b[16:20]),
+		salt2:     binary.BigEndian.Uint32(b[20:24]),
+		bigEndian: magic == walMagicBE,
+	}
+	if h.pageSize < 512 || h.pageSize&(h.pageSize-1) != 0 {
+		return walHeader{}, fmt.Errorf("%w: bad page size %d", errs.ErrReplicaWAL, h.pageSize)
+	}
+	h.checksum = walChecksum(h.bigEndian, [2]uint32{}, b[:24])
+	if h.checksum[0] != binary.BigEndian.Uint32(b[24:28]) || h.checksum[1] != binary.BigEndian.Uint32(b[28:32]) {
+		return walHeader{}, fmt.Errorf("%w: header checksum mismatch", errs.ErrReplicaWAL)
+	}
+	return h, nil
+}
+
+func walChecksum(bigEndian bool, sum [2]uint32, b []byte) [2]uint32 {
+	order := binary.ByteOrder(binary.LittleEndian)
+	if bigEndian {
+		order = binary.BigEndian
+	}
+	s1, s2 := sum[0], sum[1]
+	for i := 0; i+8 <= len(b); i += 8 {
+		s1 += order.Uint32(b[i:]) + s2
+		s2 += order.Uint32(b[i+4:]) + s1
+	}
+	return [2]uint32{s1, s2}
+}
+
+type walScan struct {
+	checksum [2]uint32
+	size     int64
+	frames   int
+}
+
+// scanWALFrames walks the frames in b, which must start on a frame boundary
+// whose preceding running checksum is sum. Only whole transactions are
+// returned: the scan ends at the last commit frame before the first frame that
+// is torn, left over from before a WAL restart (salt mismatch) or corrupt
+// (checksum mismatch).
+func scanWALFrames(h walHeader, sum [2]uint32, b []byte) walScan {
+	frameSize := h.frameSize()
+	result := walScan{checksum: sum}
+
+	var offset int64
+	var frames int
+	for offset+frameSize <= int64(len(b)) {
+		frame := b[offset : offset+frameSize]
+		if binary.BigEndian.Uint32(frame[8:12]) != h.salt1 || binary.BigEndian.Uint32(frame[12:16]) != h.salt2 {
+			break
+		}
+		sum = walChecksum(h.bigEndian, sum, frame[0:8])
+		sum = walChecksum(h.bigEndian, sum, frame[walFrameHeaderSize:])
+		if sum[0] != binary.BigEndian.Uint32(frame[16:20]) || sum[1] != binary.BigEndian.Uint32(frame[20:24]) {
+			break
+		}
+
+		offset += frameSize
+		frames++
+		if binary.BigEndian.Uint32(frame[4:8]) != 0 {
+			result = walScan{checksum: sum, size: offset, frames: frames}
+		}
+	}
+	return result
+}
+
+// applyWALFrames writes the pages of already validated frames into a database
+// file, truncating it to the database size recorded in each commit frame.
+func applyWALFrames(db *os.File, pageSize int64, b []byte) error {
+	frameSize := walFrameHeaderSize + pageSize
+	if int64(len(b))%frameSize != 0 {
+		return fmt.Errorf("%w: segment is not a whole number of frames", errs.ErrReplicaWAL)
+	}
+	for offset := int64(0); offset < int64(len(b)); offset += frameSize {
+		frame := b[offset : offset+frameSize]
+		pgno := int64(binary.BigEndian.Uint32(frame[0:4]))
+		if pgno == 0 {
+			return fmt.Errorf("%w: frame without a page number", errs.ErrReplicaWAL)
+		}
+		if _, err := db.WriteAt(frame[walFrameHeaderSize:], (pgno-1)*pageSize); err != nil {
+			return err
+		}
+		if commit := int64(binary.BigEndian.Uint32(frame[4:8])); commit != 0 {
+			if err := db.Truncate(commit * pageSize); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
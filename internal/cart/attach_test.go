@@ -0,0 +1,180 @@
+//go:build fts5
+
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"cchoice/internal/database"
+	"cchoice/internal/database/dbtest"
+	"cchoice/internal/logs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logs.SetLogger(zap.NewNop())
+	os.Exit(m.Run())
+}
+
+type idEncoder struct{}
+
+func (idEncoder) Name() string { return "id" }
+
+func (idEncoder) Encode(id int64) string { return strconv.FormatInt(id, 10) }
+
+func (idEncoder) Decode(s string) int64 {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return id
+}
+
+const testCustomerID = 7
+
+// openCartDB migrates a scratch SQLite database with two products and returns
+// a read-write service on it. Needs the fts5 tag for the migrations.
+func openCartDB(t *testing.T) database.IService {
+	t.Helper()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	db, err := sql.Open("sqlite3", "file:"+path)
+	require.NoError(t, err)
+	err = dbtest.ApplyMigrations(ctx, db, filepath.Join("..", "..", "migrations", "sqlite3"), false)
+	db.Close()
+	require.NoError(t, err)
+
+	dbRW, err := database.Open(database.DB_DRIVER_SQLITE3, "file:"+path, database.DB_MODE_RW)
+	require.NoError(t, err)
+	t.Cleanup(func() { dbRW.GetDB().Close() })
+
+	// The migrations turn foreign keys on for their own connection only, and
+	// tbl_products still references the long gone tbl_brand.
+	_, err = dbRW.GetDB().ExecContext(ctx, `
+		INSERT INTO tbl_brands (id, name, status) VALUES (1, 'Bosch', 'ACTIVE');
+		INSERT INTO tbl_products (
+			id, serial, name, description, brand_id, status,
+			unit_price_without_vat, unit_price_with_vat,
+			unit_price_without_vat_currency, unit_price_with_vat_currency
+		) VALUES
+			(1, 'GSB-180', 'Impact Drill', '', 1, 'ACTIVE', 100000, 112000, 'PHP', 'PHP'),
+			(2, 'GWS-750', 'Angle Grinder', '', 1, 'ACTIVE', 200000, 224000, 'PHP', 'PHP');
+	`)
+	require.NoError(t, err)
+	return dbRW
+}
+
+func createCheckout(t *testing.T, dbRW database.IService, sessionID string, customerID sql.NullInt64, lines map[int64]int64) int64 {
+	t.Helper()
+	var id int64
+	require.NoError(t, dbRW.GetDB().QueryRow(
+		"INSERT INTO tbl_checkouts (session_id, customer_id) VALUES (?, ?) RETURNING id",
+		sessionID, customerID,
+	).Scan(&id))
+	for productID, qty := range lines {
+		_, err := dbRW.GetDB().Exec(`
+			INSERT INTO tbl_checkout_lines (checkout_id, product_id, name, serial, description, amount, currency, quantity)
+			VALUES (?, ?, '', '', '', 0, 'PHP', ?)
+		`, id, productID, qty)
+		require.NoError(t, err)
+	}
+	return id
+}
+
+type checkoutRow struct {
+	SessionID  string
+	Status     string
+	CustomerID sql.NullInt64
+}
+
+func getCheckout(t *testing.T, dbRW database.IService, id int64) checkoutRow {
+	t.Helper()
+	var row checkoutRow
+	require.NoError(t, dbRW.GetDB().QueryRow(
+		"SELECT session_id, status, customer_id FROM tbl_checkouts WHERE id = ?", id,
+	).Scan(&row.SessionID, &row.Status, &row.CustomerID))
+	return row
+}
+
+func TestAttachCustomer(t *testing.T) {
+	ctx := context.Background()
+	customer := sql.NullInt64{Int64: testCustomerID, Valid: true}
+
+	t.Run("claims the session checkout when the customer has none", func(t *testing.T) {
+		dbRW := openCartDB(t)
+		id := createCheckout(t, dbRW, "laptop", sql.NullInt64{}, map[int64]int64{1: 1})
+
+		got, err := AttachCustomer(ctx, dbRW, idEncoder{}, "laptop", testCustomerID, []string{"1"}, MergeModeCombine)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1"}, got)
+		assert.Equal(t, checkoutRow{SessionID: "laptop", Status: "PENDING", CustomerID: customer}, getCheckout(t, dbRW, id))
+	})
+
+	t.Run("combine moves the saved cart onto the session", func(t *testing.T) {
+		dbRW := openCartDB(t)
+		owned := createCheckout(t, dbRW, "phone", customer, map[int64]int64{1: 2})
+		anonymous := createCheckout(t, dbRW, "laptop", sql.NullInt64{}, map[int64]int64{1: 1, 2: 1})
+
+		got, err := AttachCustomer(ctx, dbRW, idEncoder{}, "laptop", testCustomerID, []string{"1", "2"}, MergeModeCombine)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "1", "1", "2"}, got)
+		assert.Equal(t, checkoutRow{SessionID: "laptop", Status: "PENDING", CustomerID: customer}, getCheckout(t, dbRW, owned))
+		assert.Equal(t, checkoutRow{SessionID: "", Status: "CANCELLED"}, getCheckout(t, dbRW, anonymous))
+	})
+
+	t.Run("restore keeps the larger quantity", func(t *testing.T) {
+		dbRW := openCartDB(t)
+		createCheckout(t, dbRW, "phone", customer, map[int64]int64{1: 2})
+
+		got, err := AttachCustomer(ctx, dbRW, idEncoder{}, "laptop", testCustomerID, []string{"1", "2"}, MergeModeRestore)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "1", "2"}, got)
+	})
+
+	t.Run("session already owns the saved cart", func(t *testing.T) {
+		dbRW := openCartDB(t)
+		owned := createCheckout(t, dbRW, "laptop", customer, map[int64]int64{1: 2})
+
+		got, err := AttachCustomer(ctx, dbRW, idEncoder{}, "laptop", testCustomerID, []string{"1", "1"}, MergeModeCombine)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "1"}, got)
+		assert.Equal(t, "laptop", getCheckout(t, dbRW, owned).SessionID)
+	})
+
+	t.Run("no session token", func(t *testing.T) {
+		dbRW := openCartDB(t)
+		owned := createCheckout(t, dbRW, "phone", customer, map[int64]int64{1: 2})
+
+		got, err := AttachCustomer(ctx, dbRW, idEncoder{}, "", testCustomerID, []string{"2"}, MergeModeCombine)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2"}, got)
+		assert.Equal(t, "phone", getCheckout(t, dbRW, owned).SessionID)
+	})
+}
+
+func TestReleaseSession(t *testing.T) {
+	ctx := context.Background()
+	dbRW := openCartDB(t)
+	customer := sql.NullInt64{Int64: testCustomerID, Valid: true}
+	owned := createCheckout(t, dbRW, "laptop", customer, nil)
+	anonymous := createCheckout(t, dbRW, "phone", sql.NullInt64{}, nil)
+
+	require.NoError(t, ReleaseSession(ctx, dbRW, ""))
+	assert.Equal(t, "laptop", getCheckout(t, dbRW, owned).SessionID)
+
+	require.NoError(t, ReleaseSession(ctx, dbRW, "laptop"))
+	assert.Equal(t, checkoutRow{SessionID: "", Status: "PENDING", CustomerID: customer}, getCheckout(t, dbRW, owned))
+
+	require.NoError(t, ReleaseSession(ctx, dbRW, "phone"))
+	assert.Equal(t, "phone", getCheckout(t, dbRW, anonymous).SessionID)
+}
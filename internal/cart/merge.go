@@ -0,0 +1,149 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/logs"
+
+	"go.uber.org/zap"
+)
+
+type MergeMode int
+
+const (
+	// MergeModeCombine adds quantities from both carts. Used on login, where the
+	// anonymous cart holds items the customer has not added to their account yet.
+	MergeModeCombine MergeMode = iota
+	// MergeModeRestore keeps the larger quantity per product. Used when a
+	// logged-in customer opens their cart on another device so nothing doubles.
+	MergeModeRestore
+)
+
+// MergeProductIDs merges two session product ID lists, where each occurrence of
+// an ID is one unit. Order follows first appearance in base, then incoming.
+func MergeProductIDs(base []string, incoming []string, mode MergeMode) []string {
+	order := make([]string, 0, len(base)+len(incoming))
+	baseQty := map[string]int64{}
+	incomingQty := map[string]int64{}
+	for _, productID := range base {
+		if _, ok := baseQty[productID]; !ok {
+			order = append(order, productID)
+		}
+		baseQty[productID]++
+	}
+	for _, productID := range incoming {
+		if _, ok := baseQty[productID]; !ok {
+			if _, ok := incomingQty[productID]; !ok {
+				order = append(order, productID)
+			}
+		}
+		incomingQty[productID]++
+	}
+
+	merged := make([]string, 0, len(base)+len(incoming))
+	for _, productID := range order {
+		var qty int64
+		switch mode {
+		case MergeModeCombine:
+			qty = baseQty[productID] + incomingQty[productID]
+		default:
+			qty = max(baseQty[productID], incomingQty[productID])
+		}
+		qty = min(qty, constants.MaxCartLineQty)
+		for range qty {
+			merged = append(merged, productID)
+		}
+	}
+	return merged
+}
+
+// AttachCustomer binds the customer's pending checkout to the current session
+// token and returns the product IDs the session cart should hold afterwards.
+// Any other checkout previously tied to the token is detached so cart lookups
+// by token stay unambiguous.
+func AttachCustomer(
+	ctx context.Context,
+	dbRW database.IService,
+	encoder encode.IEncode,
+	token string,
+	customerID int64,
+	sessionProductIDs []string,
+	mode MergeMode,
+) ([]string, error) {
+	const logtag = "[Cart AttachCustomer]"
+
+	if token == "" {
+		return sessionProductIDs, nil
+	}
+
+	dbCustomerID := sql.NullInt64{Int64: customerID, Valid: true}
+	owned, err := dbRW.GetQueries().GetPendingCheckoutByCustomerID(ctx, dbCustomerID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if err := dbRW.GetQueries().SetCheckoutCustomerIDBySessionID(ctx, queries.SetCheckoutCustomerIDBySessionIDParams{
+			CustomerID: dbCustomerID,
+			SessionID:  token,
+		}); err != nil {
+			return nil, err
+		}
+		return sessionProductIDs, nil
+	}
+
+	if owned.SessionID == token {
+		return sessionProductIDs, nil
+	}
+
+	checkoutLines, err := dbRW.GetQueries().GetCheckoutLinesByCheckoutID(ctx, owned.ID)
+	if err != nil {
+		return nil, err
+	}
+	ownedProductIDs := make([]string, 0, len(checkoutLines))
+	for _, line := range checkoutLines {
+		productID := encoder.Encode(line.ProductID)
+		for range line.Quantity {
+			ownedProductIDs = append(ownedProductIDs, productID)
+		}
+	}
+
+	if err := dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		if err := qtx.DetachCheckoutsBySessionID(ctx, queries.DetachCheckoutsBySessionIDParams{
+			SessionID: token,
+			KeepID:    owned.ID,
+		}); err != nil {
+			return err
+		}
+		return qtx.UpdateCheckoutSessionID(ctx, queries.UpdateCheckoutSessionIDParams{
+			SessionID: token,
+			ID:        owned.ID,
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	merged := MergeProductIDs(ownedProductIDs, sessionProductIDs, mode)
+	logs.LogCtx(ctx).Info(
+		logtag,
+		zap.Int64("checkout id", owned.ID),
+		zap.Int("owned items", len(ownedProductIDs)),
+		zap.Int("session items", len(sessionProductIDs)),
+		zap.Int("merged items", len(merged)),
+	)
+	return merged, nil
+}
+
+// ReleaseSession unbinds a customer-owned checkout from the token on logout so
+// the next visitor on the same browser starts with an empty cart.
+func ReleaseSession(ctx context.Context, dbRW database.IService, token string) error {
+	if token == "" {
+		return nil
+	}
+	return dbRW.GetQueries().ReleaseCustomerCheckoutBySessionID(ctx, token)
+}
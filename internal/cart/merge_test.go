@@ -0,0 +1,37 @@
+package cart
+
+import (
+	"slices"
+	"testing"
+
+	"cchoice/internal/constants"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeProductIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     []string
+		incoming []string
+		mode     MergeMode
+		want     []string
+	}{
+		{"combine adds quantities", []string{"a", "b"}, []string{"a", "c"}, MergeModeCombine, []string{"a", "a", "b", "c"}},
+		{"restore keeps larger quantity", []string{"a", "b"}, []string{"a", "a", "c"}, MergeModeRestore, []string{"a", "a", "b", "c"}},
+		{"empty base", nil, []string{"b", "a", "b"}, MergeModeCombine, []string{"b", "b", "a"}},
+		{"empty incoming", []string{"a"}, nil, MergeModeRestore, []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MergeProductIDs(tt.base, tt.incoming, tt.mode))
+		})
+	}
+}
+
+func TestMergeProductIDsCapsQuantity(t *testing.T) {
+	base := slices.Repeat([]string{"a"}, constants.MaxCartLineQty)
+	merged := MergeProductIDs(base, []string{"a"}, MergeModeCombine)
+	assert.Len(t, merged, constants.MaxCartLineQty)
+}
@@ -30,6 +30,7 @@ type appConfig struct {
 	BasicAuth          BasicAuth
 	AppEnvRaw          string `env:"APP_ENV" env-required:""`
 	DBURL              string `env:"DB_URL" env-required:""`
+	DBDriver           string `env:"DB_DRIVER" env-default:"sqlite3"`
 	PaymentService     string `env:"PAYMENT_SERVICE" env-required:""`
 	ShippingService    string `env:"SHIPPING_SERVICE" env-required:""`
 	GeocodingService   string `env:"GEOCODING_SERVICE" env-required:""`
@@ -41,9 +42,15 @@ type appConfig struct {
 	StorageProvider    string `env:"STORAGE_PROVIDER" env-default:"LOCAL"`
 	MailService        string `env:"MAIL_SERVICE"`
 	CPointHMACSecret   string `env:"CPOINT_HMAC_SECRET" env-required:""`
+	KioskHMACSecret    string `env:"KIOSK_HMAC_SECRET" env-required:""`
 	Server             ServerConfig
 	Settings           Settings
 	RateLimit          RateLimitConfig
+	AbandonedCart      AbandonedCartConfig
+	Memo               MemoConfig
+	Events             EventsConfig
+	Backup             BackupConfig
+	Replica            ReplicaConfig
 	AppEnv             enums.AppEnv
 	LogMinLevel        int `env:"LOG_MIN_LEVEL" env-default:"1"`
 	Test               Test
@@ -54,6 +61,8 @@ type Test struct {
 	LocalOTP            bool `env:"TEST_LOCAL_OTP" env-default:"0"`             // true = sends an email
 	LocalForgotPassword bool `env:"TEST_LOCAL_FORGOT_PASSWORD" env-default:"0"` // true = sends an email
 	LocalMemoEmailSend  bool `env:"TEST_LOCAL_MEMO_EMAIL_SEND" env-default:"0"`
+	LocalWishlistEmail  bool `env:"TEST_LOCAL_WISHLIST_EMAIL" env-default:"0"` // true = sends wishlist and stock alert emails
+	LocalAbandonedCart  bool `env:"TEST_LOCAL_ABANDONED_CART" env-default:"0"` // true = sends abandoned cart reminders
 }
 
 type RateLimitConfig struct {
@@ -62,6 +71,42 @@ type RateLimitConfig struct {
 	TTL   time.Duration `env:"RATE_LIMIT_TTL" env-default:"3m"`
 }
 
+type AbandonedCartConfig struct {
+	IdleAfter    time.Duration `env:"ABANDONED_CART_IDLE_AFTER" env-default:"4h"`
+	ScanInterval time.Duration `env:"ABANDONED_CART_SCAN_INTERVAL" env-default:"15m"`
+}
+
+type MemoConfig struct {
+	ReminderInterval time.Duration `env:"MEMO_REMINDER_INTERVAL" env-default:"24h"`
+	ScanInterval     time.Duration `env:"MEMO_SCAN_INTERVAL" env-default:"1h"`
+}
+
+type EventsConfig struct {
+	BufferSize     int           `env:"EVENTS_BUFFER_SIZE" env-default:"4096"`
+	BatchSize      int           `env:"EVENTS_BATCH_SIZE" env-default:"200"`
+	FlushInterval  time.Duration `env:"EVENTS_FLUSH_INTERVAL" env-default:"5s"`
+	RollupInterval time.Duration `env:"EVENTS_ROLLUP_INTERVAL" env-default:"1h"`
+	RetentionDays  int           `env:"EVENTS_RETENTION_DAYS" env-default:"90"`
+}
+
+type BackupConfig struct {
+	Enabled         bool          `env:"BACKUP_ENABLED" env-default:"0"`
+	StorageProvider string        `env:"BACKUP_STORAGE_PROVIDER" env-default:"LOCAL"`
+	LocalDir        string        `env:"BACKUP_LOCAL_DIR" env-default:"./backups"`
+	Prefix          string        `env:"BACKUP_PREFIX" env-default:"backups/db"`
+	Interval        time.Duration `env:"BACKUP_INTERVAL" env-default:"24h"`
+	KeepLast        int           `env:"BACKUP_KEEP_LAST" env-default:"14"`
+}
+
+type ReplicaConfig struct {
+	Enabled          bool          `env:"REPLICA_ENABLED" env-default:"0"`
+	Prefix           string        `env:"REPLICA_PREFIX" env-default:"replica"`
+	SyncInterval     time.Duration `env:"REPLICA_SYNC_INTERVAL" env-default:"1s"`
+	SnapshotInterval time.Duration `env:"REPLICA_SNAPSHOT_INTERVAL" env-default:"24h"`
+	CheckpointPages  int           `env:"REPLICA_CHECKPOINT_PAGES" env-default:"1000"`
+	KeepGenerations  int           `env:"REPLICA_KEEP_GENERATIONS" env-default:"2"`
+}
+
 type BasicAuth struct {
 	Username     string `env:"BASIC_AUTH_USERNAME"`
 	PasswordHash string `env:"BASIC_AUTH_PASSWORD_HASH"`
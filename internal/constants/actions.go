@@ -8,6 +8,7 @@ const (
 	ActionCreate       = "create"
 	ActionDelete       = "delete"
 	ActionExport       = "export"
+	ActionLock         = "lock"
 	ActionReset        = "reset"
 	ActionTrigger      = "trigger"
 	ActionUpdate       = "update"
@@ -15,24 +16,38 @@ const (
 )
 
 const (
-	ModuleAttendanceReportCSV  = "attendance_report_csv"
-	ModuleAttendanceReportXLSX = "attendance_report_xlsx"
-	ModuleBrands               = "brands"
-	ModuleCategories           = "categories"
-	ModuleCPoints              = "cpoints"
-	ModuleHolidays             = "holidays"
-	ModuleMemos                = "memos"
-	ModuleOrders               = "orders"
-	ModuleQuotations           = "quotations"
-	ModuleProductInventories   = "product_inventories"
-	ModulePasswordReset        = "password_reset"
-	ModuleProducts             = "products"
-	ModuleProductsExportCSV    = "products_export_csv"
-	ModuleProductsExportXLSX   = "products_export_xlsx"
-	ModuleProductsBulkImport   = "products_bulk_import"
-	ModulePromos               = "promos"
-	ModuleStaff                = "staffs"
-	ModuleThemes               = "themes"
-	ModuleTimeOff              = "time_off"
-	ModuleTrackedLinks         = "tracked_links"
+	ModuleAnalyticsExportXLSX   = "analytics_export_xlsx"
+	ModuleAttendanceCorrections = "attendance_corrections"
+	ModuleAttendanceKiosk       = "attendance_kiosk"
+	ModuleAttendanceReportCSV   = "attendance_report_csv"
+	ModuleAttendanceReportXLSX  = "attendance_report_xlsx"
+	ModuleBrands                = "brands"
+	ModuleCategories            = "categories"
+	ModuleCPoints               = "cpoints"
+	ModuleExperiments           = "experiments"
+	ModuleHolidays              = "holidays"
+	ModuleIncidents             = "incidents"
+	ModuleLeaveCredits          = "leave_credits"
+	ModuleMemos                 = "memos"
+	ModuleOrders                = "orders"
+	ModuleQuotations            = "quotations"
+	ModuleProductInventories    = "product_inventories"
+	ModulePasswordReset         = "password_reset"
+	ModulePayroll               = "payroll"
+	ModulePayslips              = "payslips"
+	ModuleProducts              = "products"
+	ModuleProductsExportCSV     = "products_export_csv"
+	ModuleProductsExportXLSX    = "products_export_xlsx"
+	ModuleProductsBulkImport    = "products_bulk_import"
+	ModuleProductReviews        = "product_reviews"
+	ModulePromos                = "promos"
+	ModuleSaleCampaigns         = "sale_campaigns"
+	ModuleSearchRules           = "search_rules"
+	ModuleShifts                = "shifts"
+	ModuleSessions              = "sessions"
+	ModuleStaff                 = "staffs"
+	ModuleThemes                = "themes"
+	ModuleTimeOff               = "time_off"
+	ModuleTrackedLinks          = "tracked_links"
+	ModuleTwoFactor             = "two_factor"
 )
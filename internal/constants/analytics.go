@@ -0,0 +1,8 @@
+package constants
+
+const (
+	AnalyticsDefaultDays      = 30
+	AnalyticsMaxDays          = 366
+	AnalyticsTopProductsLimit = 20
+	AnalyticsZeroResultsLimit = 20
+)
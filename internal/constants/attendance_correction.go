@@ -0,0 +1,8 @@
+package constants
+
+const (
+	AttendanceCorrectionListLimit = 100
+	// AttendanceCorrectionMaxAgeDays is how far back a staff can ask for a
+	// correction.
+	AttendanceCorrectionMaxAgeDays = 31
+)
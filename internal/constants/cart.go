@@ -2,4 +2,6 @@ package constants
 
 const (
 	MaxCartLineQty = 99
+
+	AbandonedCartBatchSize = 100
 )
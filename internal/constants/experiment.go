@@ -0,0 +1,16 @@
+package constants
+
+import "time"
+
+const (
+	// ExperimentBucketCookie holds the random key that keeps a visitor on the
+	// same variant across visits.
+	ExperimentBucketCookie    = "cchoice_bucket"
+	ExperimentBucketKeyLength = 24
+	ExperimentBucketTTL       = 365 * 24 * time.Hour
+
+	// ExperimentSignificanceLevel is the p-value under which a variant's
+	// difference from the control is reported as significant.
+	ExperimentSignificanceLevel = 0.05
+	ExperimentMaxVariantWeight  = 100
+)
@@ -0,0 +1,7 @@
+package constants
+
+const (
+	IncidentListLimit = 200
+	// IncidentMemoHistoryLimit caps the memos shown in a staff HR file.
+	IncidentMemoHistoryLimit = 50
+)
@@ -0,0 +1,14 @@
+package constants
+
+import "time"
+
+const (
+	// KioskTokenTTL is how long a QR code shown on the kiosk stays valid. It
+	// outlives KioskQRRefresh so a code scanned right before it rotates still
+	// works.
+	KioskTokenTTL       = 45 * time.Second
+	KioskQRRefresh      = 20 * time.Second
+	KioskPinMaxAttempts = 5
+	KioskPinLockout     = 15 * time.Minute
+	KioskPhotoMaxSize   = 5 << 20
+)
@@ -0,0 +1,8 @@
+package constants
+
+import "time"
+
+const (
+	LeaveSchedulerInterval = time.Hour
+	LeaveLedgerLimit       = 50
+)
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	MemoReminderBatchSize   = 100
+	MemoEscalationBatchSize = 20
+)
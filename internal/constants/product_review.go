@@ -0,0 +1,11 @@
+package constants
+
+const (
+	ProductReviewMinRating   int64 = 1
+	ProductReviewMaxRating   int64 = 5
+	ProductReviewMaxPhotos         = 3
+	ProductReviewMaxTitleLen       = 120
+	ProductReviewMaxBodyLen        = 2000
+	ProductReviewsPageLimit  int64 = 10
+	ProductReviewsSEOLimit   int64 = 5
+)
@@ -0,0 +1,9 @@
+package constants
+
+import "time"
+
+const (
+	SaleCampaignSchedulerInterval = time.Minute
+	SaleCampaignMaxNameLen        = 120
+	SaleCampaignMaxPercentage     = 99
+)
@@ -0,0 +1,7 @@
+package constants
+
+const (
+	SearchInsightsLimit       = 20
+	SearchLowClickMinSearches = 3
+	SearchRuleMaxPosition     = 100
+)
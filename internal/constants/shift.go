@@ -0,0 +1,8 @@
+package constants
+
+const (
+	ShiftMaxCycleWeeks  = 4
+	ShiftUpcomingDays   = 14
+	ShiftSwapListLimit  = 100
+	ShiftOverridesAhead = 365
+)
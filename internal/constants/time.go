@@ -11,6 +11,7 @@ const (
 	DateTimeLayoutISO      = "2006-01-02 15:04:05"
 	DateTimeLayoutFilename = "2006-01-02_15-04-05"
 	DateTimeLayoutTZISO    = "2006-01-02T15:04:05Z"
+	DateTimeLayoutInput    = "2006-01-02T15:04"
 	DateLayoutDisplay      = "Monday, January 2, 2006"
 	TimeLayoutHHMM         = "15:04"
 	TimeLayoutHHMMSS       = "15:04:05"
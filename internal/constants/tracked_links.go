@@ -0,0 +1,19 @@
+package constants
+
+import "time"
+
+const (
+	// TrackedLinkFirstTouchCookie keeps the first /l/{slug} a visitor came
+	// through and is never overwritten while it lives.
+	TrackedLinkFirstTouchCookie = "cchoice_link_first"
+	TrackedLinkLastTouchCookie  = "cchoice_link_last"
+	TrackedLinkAttributionTTL   = 30 * 24 * time.Hour
+
+	TrackedLinkAnalyticsDefaultDays = 30
+	TrackedLinkAnalyticsMaxDays     = 365
+	TrackedLinkTopReferrers         = 10
+)
+
+// TrackedLinkDuplicateWindow is how long repeat hits from the same hashed IP
+// on the same link count as one click.
+const TrackedLinkDuplicateWindow = 30 * time.Second
@@ -0,0 +1,15 @@
+package constants
+
+import "time"
+
+const (
+	TwoFactorIssuer        = "C-Choice"
+	TwoFactorRecoveryCodes = 10
+	// TwoFactorMaxAttempts is how many wrong codes a staff can enter within 15
+	// minutes before their second factor is locked for 15 minutes, however
+	// often they log in again. See tbl_staff_two_factor_failures.
+	TwoFactorMaxAttempts  = 5
+	TwoFactorPendingTTL   = 10 * time.Minute
+	TwoFactorDeviceTTL    = 30 * 24 * time.Hour
+	TwoFactorDeviceCookie = "cchoice_staff_device"
+)
@@ -3,11 +3,13 @@ package database
 import (
 	"cchoice/internal/conf"
 	"cchoice/internal/database/queries"
+	"cchoice/internal/errs"
 	"cchoice/internal/logs"
 	"context"
 	"database/sql"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	_ "github.com/joho/godotenv/autoload"
@@ -22,16 +24,39 @@ const (
 	DB_MODE_RW DBMode = "rw"
 )
 
+type DBDriver string
+
+const (
+	DB_DRIVER_SQLITE3  DBDriver = "sqlite3"
+	DB_DRIVER_POSTGRES DBDriver = "postgres"
+)
+
+func ParseDBDriver(driver string) (DBDriver, error) {
+	switch DBDriver(strings.ToLower(driver)) {
+	case DB_DRIVER_SQLITE3, "":
+		return DB_DRIVER_SQLITE3, nil
+	case DB_DRIVER_POSTGRES, "postgresql":
+		return DB_DRIVER_POSTGRES, nil
+	default:
+		return "", fmt.Errorf("%w: %s", errs.ErrDBDriver, driver)
+	}
+}
+
 type IService interface {
 	Health() map[string]string
 	Close() error
 	GetQueries() *queries.Queries
 	GetDB() *sql.DB
+	Mode() DBMode
+	Driver() DBDriver
+	RunInTx(ctx context.Context, fn func(tx *sql.Tx, qtx *queries.Queries) error) error
 }
 
 type service struct {
 	db      *sql.DB
 	queries *queries.Queries
+	mode    DBMode
+	driver  DBDriver
 }
 
 func (s *service) GetQueries() *queries.Queries {
@@ -42,13 +67,21 @@ func (s *service) GetDB() *sql.DB {
 	return s.db
 }
 
+func (s *service) Mode() DBMode {
+	return s.mode
+}
+
+func (s *service) Driver() DBDriver {
+	return s.driver
+}
+
 var (
 	dbInstanceRO *service
 	dbInstanceRW *service
 )
 
 func New(mode DBMode) IService {
-	dburl := conf.Conf().DBURL
+	cfg := conf.Conf()
 	switch mode {
 	case DB_MODE_RO:
 		if dbInstanceRO != nil {
@@ -62,13 +95,21 @@ func New(mode DBMode) IService {
 		panic("db mode enum not handled")
 	}
 
-	dataSourceName := dburl + "?_journal_mode=wal" + "&mode=" + string(mode)
+	driver, err := ParseDBDriver(cfg.DBDriver)
+	if err != nil {
+		logs.Log().Fatal("db driver", zap.Error(err))
+		return nil
+	}
+
 	logs.Log().Info(
 		"Initializing DB...",
-		zap.String("source name", dataSourceName),
+		zap.String("driver", string(driver)),
 		zap.String("mode", string(mode)),
 	)
-	db, err := sql.Open("sqlite3", dataSourceName)
+	if driver == DB_DRIVER_SQLITE3 {
+		logs.Log().Info("SQLite data source", zap.String("source name", dataSourceName(cfg.DBURL, mode)))
+	}
+	svc, err := openDriver(driver, cfg.DBURL, mode)
 	if err != nil {
 		logs.Log().Fatal("db open", zap.Error(err))
 		return nil
@@ -76,22 +117,57 @@ func New(mode DBMode) IService {
 
 	switch mode {
 	case DB_MODE_RO:
-		dbInstanceRO = &service{
-			db:      db,
-			queries: queries.New(db),
-		}
+		dbInstanceRO = svc
 		return dbInstanceRO
 	case DB_MODE_RW:
-		dbInstanceRW = &service{
-			db:      db,
-			queries: queries.New(db),
-		}
+		dbInstanceRW = svc
 		return dbInstanceRW
 	default:
 		panic("db mode enum not handled")
 	}
 }
 
+// Open connects to a database outside of the configuration and the shared
+// instances New hands out, e.g. a scratch database in tests.
+func Open(driver DBDriver, dburl string, mode DBMode) (IService, error) {
+	svc, err := openDriver(driver, dburl, mode)
+	if err != nil {
+		return nil, err
+	}
+	return svc, nil
+}
+
+func openDriver(driver DBDriver, dburl string, mode DBMode) (*service, error) {
+	if driver == DB_DRIVER_POSTGRES {
+		return openPostgres(dburl, mode)
+	}
+	return open(dburl, mode)
+}
+
+// dataSourceName enforces the mode on the connection itself: besides
+// mode=ro, read-only connections set query_only so SQLite rejects writes even
+// where the file would allow them. Read-write connections start their
+// transactions with BEGIN IMMEDIATE, so a busy database is reported when the
+// transaction starts rather than on its first write, where RunInTx can retry
+// it cleanly.
+func dataSourceName(dburl string, mode DBMode) string {
+	dsn := dburl + "?_journal_mode=wal&_busy_timeout=5000&mode=" + string(mode)
+	if mode == DB_MODE_RO {
+		return dsn + "&_query_only=1"
+	}
+	return dsn + "&_txlock=immediate"
+}
+
+func open(dburl string, mode DBMode) (*service, error) {
+	db := sql.OpenDB(newSQLiteConnector(dataSourceName(dburl, mode)))
+	return &service{
+		db:      db,
+		queries: queries.New(instrument(db, mode)),
+		mode:    mode,
+		driver:  DB_DRIVER_SQLITE3,
+	}, nil
+}
+
 // Health checks the health of the database connection by pinging the database.
 // It returns a map with keys indicating various health statistics.
 func (s *service) Health() map[string]string {
@@ -144,7 +220,11 @@ func (s *service) Health() map[string]string {
 }
 
 func (s *service) Close() error {
-	logs.Log().Info("Disconnected from database", zap.String("db url", conf.Conf().DBURL))
+	logs.Log().Info(
+		"Disconnected from database",
+		zap.String("driver", string(s.driver)),
+		zap.String("mode", string(s.mode)),
+	)
 	return s.db.Close()
 }
 
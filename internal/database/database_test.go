@@ -0,0 +1,155 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cchoice/internal/database/queries"
+	"cchoice/internal/errs"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openPair(t *testing.T) (*service, *service) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	// mode=rw does not create the file; migrations do that in the app.
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	dburl := "file:" + path
+
+	rw, err := open(dburl, DB_MODE_RW)
+	require.NoError(t, err)
+	t.Cleanup(func() { rw.db.Close() })
+	_, err = rw.db.Exec("CREATE TABLE tbl_items (id INTEGER PRIMARY KEY, name TEXT NOT NULL)")
+	require.NoError(t, err)
+
+	ro, err := open(dburl, DB_MODE_RO)
+	require.NoError(t, err)
+	t.Cleanup(func() { ro.db.Close() })
+	return ro, rw
+}
+
+func TestQueryName(t *testing.T) {
+	assert.Equal(t, "GetProducts", queryName("-- name: GetProducts :many\nSELECT 1"))
+	assert.Equal(t, "CreateItem", queryName("  -- name: CreateItem :exec\nINSERT INTO x VALUES (1)"))
+	assert.Equal(t, rawQueryName, queryName("SELECT 1"))
+	assert.Equal(t, rawQueryName, queryName("-- name: "))
+}
+
+func TestReadOnlyConnectionRejectsWrites(t *testing.T) {
+	ctx := context.Background()
+	ro, rw := openPair(t)
+
+	insert := "-- name: CreateItem :exec\nINSERT INTO tbl_items (name) VALUES ('drill')"
+	_, err := instrument(ro.db, ro.mode).ExecContext(ctx, insert)
+	assert.ErrorIs(t, err, errs.ErrDBReadOnly)
+	assert.ErrorContains(t, err, "CreateItem")
+
+	_, err = instrument(rw.db, rw.mode).ExecContext(ctx, insert)
+	require.NoError(t, err)
+
+	var count int
+	require.NoError(t, instrument(ro.db, ro.mode).QueryRowContext(ctx, "SELECT COUNT(*) FROM tbl_items").Scan(&count))
+	assert.Equal(t, 1, count)
+
+	// SQLite only runs the statement on Scan.
+	var id int64
+	err = instrument(ro.db, ro.mode).QueryRowContext(ctx, "-- name: CreateItemReturning :one\nINSERT INTO tbl_items (name) VALUES ('saw') RETURNING id").Scan(&id)
+	assert.ErrorIs(t, err, errs.ErrDBReadOnly)
+	assert.ErrorContains(t, err, "CreateItemReturning")
+
+	tx, err := ro.db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	_, err = tx.ExecContext(ctx, insert)
+	assert.ErrorIs(t, err, errs.ErrDBReadOnly)
+	require.NoError(t, tx.Rollback())
+
+	err = ro.RunInTx(ctx, func(*sql.Tx, *queries.Queries) error { return nil })
+	assert.ErrorIs(t, err, errs.ErrDBReadOnly)
+}
+
+func TestRunInTx(t *testing.T) {
+	ctx := context.Background()
+	ro, rw := openPair(t)
+	count := func() int {
+		var n int
+		require.NoError(t, ro.db.QueryRow("SELECT COUNT(*) FROM tbl_items").Scan(&n))
+		return n
+	}
+
+	errFail := errors.New("fail")
+	err := rw.RunInTx(ctx, func(tx *sql.Tx, _ *queries.Queries) error {
+		if _, err := tx.Exec("INSERT INTO tbl_items (name) VALUES ('saw')"); err != nil {
+			return err
+		}
+		return errFail
+	})
+	assert.ErrorIs(t, err, errFail)
+	assert.Equal(t, 0, count())
+
+	attempts := 0
+	err = rw.RunInTx(ctx, func(tx *sql.Tx, _ *queries.Queries) error {
+		attempts++
+		if _, err := tx.Exec("INSERT INTO tbl_items (name) VALUES ('saw')"); err != nil {
+			return err
+		}
+		if attempts < 3 {
+			return sqlite3.Error{Code: sqlite3.ErrBusy}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 1, count())
+
+	attempts = 0
+	err = rw.RunInTx(ctx, func(*sql.Tx, *queries.Queries) error {
+		attempts++
+		return sqlite3.Error{Code: sqlite3.ErrLocked}
+	})
+	assert.ErrorIs(t, err, errs.ErrDBBusy)
+	assert.Equal(t, txMaxAttempts, attempts)
+}
+
+func observedQueries(t *testing.T) map[string]uint64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	observed := map[string]uint64{}
+	for _, family := range families {
+		if family.GetName() != "cchoice_db_query_duration_seconds" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "query" {
+					observed[label.GetValue()] += m.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+	return observed
+}
+
+func TestRunInTxInstrumentsQueries(t *testing.T) {
+	ctx := context.Background()
+	_, rw := openPair(t)
+
+	_, err := rw.db.Exec("CREATE TABLE tbl_brands (id INTEGER PRIMARY KEY, name TEXT NOT NULL, created_at DATETIME, updated_at DATETIME)")
+	require.NoError(t, err)
+
+	before := observedQueries(t)["CreateBrands"]
+	err = rw.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		_, err := qtx.CreateBrands(ctx, "Bosch")
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, before+1, observedQueries(t)["CreateBrands"])
+}
@@ -0,0 +1,347 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"cchoice/internal/errs"
+)
+
+// The queries are written for SQLite and the app only uses the sqlc code
+// generated from them, so on PostgreSQL they are rewritten at the driver level:
+// every statement, including raw ones and those run through Queries.WithTx,
+// goes through postgresDialect.rewrite before it reaches pgx. The rewrites are
+// covered by dialect_test.go, which also fails on any query in sql/ or
+// generated from it that still holds a SQLite-only construct after rewriting,
+// and postgres_test.go
+// prepares every generated query against a real PostgreSQL.
+
+// sqliteOnlyFunctions, and the GLOB operator, have no PostgreSQL counterpart
+// the rewriter knows of. Queries using them are refused with ErrDBDialect
+// instead of being sent to PostgreSQL.
+var sqliteOnlyFunctions = map[string]bool{
+	"CHANGES":           true,
+	"GLOB":              true,
+	"HEX":               true,
+	"IFNULL":            true,
+	"INSTR":             true,
+	"JULIANDAY":         true,
+	"LAST_INSERT_ROWID": true,
+	"PRINTF":            true,
+	"RANDOMBLOB":        true,
+	"STRFTIME":          true,
+	"TOTAL":             true,
+	"TYPEOF":            true,
+	"UNIXEPOCH":         true,
+}
+
+var (
+	reBooleanCompare = regexp.MustCompile(`^(\s*(?:=|!=|<>)\s*)([01])\b`)
+	reMatchColumn    = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)\.name\s*$`)
+)
+
+// postgresDialect caches rewritten statements, since database/sql hands the
+// same sqlc constant to the driver on every call. booleans holds the BOOLEAN
+// columns of the schema, which SQLite stores as 0 and 1 and the queries compare
+// that way. They are read from the database, so new columns need no code.
+type postgresDialect struct {
+	booleans map[string]bool
+	cache    sync.Map
+}
+
+func newPostgresDialect(booleans []string) *postgresDialect {
+	d := &postgresDialect{booleans: make(map[string]bool, len(booleans))}
+	for _, column := range booleans {
+		d.booleans[strings.ToLower(column)] = true
+	}
+	return d
+}
+
+func (d *postgresDialect) rewrite(query string) (string, error) {
+	if cached, ok := d.cache.Load(query); ok {
+		return cached.(string), nil
+	}
+	rewritten, unsupported := rewriteSQLite(query, d.booleans)
+	if len(unsupported) > 0 {
+		return "", errors.Join(
+			errs.ErrDBDialect,
+			fmt.Errorf("%s: %s", queryName(query), strings.Join(unsupported, ", ")),
+		)
+	}
+	d.cache.Store(query, rewritten)
+	return rewritten, nil
+}
+
+type sqliteRewriter struct {
+	src      string
+	out      strings.Builder
+	pos      int
+	maxParam int
+	booleans map[string]bool
+	// unsupported collects the sqliteOnlyFunctions the query calls.
+	unsupported []string
+}
+
+// rewriteSQLite turns a SQLite statement into PostgreSQL:
+//   - ?, ?NNN             -> $N, numbered the way SQLite numbers them
+//   - datetime('now', m)  -> LOCALTIMESTAMP + INTERVAL m
+//   - date('now')         -> CURRENT_DATE, date(x) -> CAST(x AS DATE)
+//   - date(x, m)          -> CAST(CAST(x AS TIMESTAMP) + INTERVAL m AS DATE)
+//   - group_concat(x, s)  -> STRING_AGG(CAST(x AS TEXT), s)
+//   - max(a, b), min(a, b) -> GREATEST(a, b), LEAST(a, b)
+//   - LIKE                -> ILIKE, since SQLite's LIKE ignores case
+//   - bool_col = 1        -> bool_col = TRUE
+//   - t.name MATCH x      -> t.name_search @@ websearch_to_tsquery('simple', x)
+//
+// String literals, quoted identifiers and comments are copied as they are.
+func rewriteSQLite(query string, booleans map[string]bool) (string, []string) {
+	r := &sqliteRewriter{src: query, booleans: booleans}
+	r.run(len(query))
+	slices.Sort(r.unsupported)
+	return r.out.String(), slices.Compact(r.unsupported)
+}
+
+func (r *sqliteRewriter) run(end int) {
+	for r.pos < end {
+		c := r.src[r.pos]
+		switch {
+		case c == '\'' || c == '"':
+			r.copyQuoted(c)
+		case strings.HasPrefix(r.src[r.pos:], "--"):
+			r.copyUntil("\n", false)
+		case strings.HasPrefix(r.src[r.pos:], "/*"):
+			r.copyUntil("*/", true)
+		case c == '?':
+			r.param()
+		case isIdentStart(c):
+			r.word(end)
+		default:
+			r.out.WriteByte(c)
+			r.pos++
+		}
+	}
+}
+
+func (r *sqliteRewriter) copyQuoted(quote byte) {
+	start := r.pos
+	r.pos++
+	for r.pos < len(r.src) {
+		if r.src[r.pos] == quote {
+			// A doubled quote is an escaped one.
+			if r.pos+1 < len(r.src) && r.src[r.pos+1] == quote {
+				r.pos += 2
+				continue
+			}
+			r.pos++
+			break
+		}
+		r.pos++
+	}
+	r.out.WriteString(r.src[start:r.pos])
+}
+
+func (r *sqliteRewriter) copyUntil(terminator string, inclusive bool) {
+	start := r.pos
+	idx := strings.Index(r.src[r.pos:], terminator)
+	if idx < 0 {
+		r.pos = len(r.src)
+	} else {
+		r.pos += idx
+		if inclusive {
+			r.pos += len(terminator)
+		}
+	}
+	r.out.WriteString(r.src[start:r.pos])
+}
+
+func (r *sqliteRewriter) param() {
+	r.pos++
+	start := r.pos
+	for r.pos < len(r.src) && r.src[r.pos] >= '0' && r.src[r.pos] <= '9' {
+		r.pos++
+	}
+	n := r.maxParam + 1
+	if r.pos > start {
+		n, _ = strconv.Atoi(r.src[start:r.pos])
+	}
+	r.maxParam = max(r.maxParam, n)
+	r.out.WriteString("$" + strconv.Itoa(n))
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+func (r *sqliteRewriter) word(end int) {
+	start := r.pos
+	for r.pos < end && isIdentPart(r.src[r.pos]) {
+		r.pos++
+	}
+	word := r.src[start:r.pos]
+	upper := strings.ToUpper(word)
+
+	maxParam, unsupported := r.maxParam, len(r.unsupported)
+	if args, next, ok := r.callArgs(end); ok {
+		if call, ok := r.rewriteCall(upper, args); ok {
+			r.out.WriteString(call)
+			r.pos = next
+			return
+		}
+	}
+	// Not a call we rewrite: its arguments are rewritten in place instead.
+	r.maxParam = maxParam
+	r.unsupported = r.unsupported[:unsupported]
+	if sqliteOnlyFunctions[upper] && (upper == "GLOB" || strings.HasPrefix(strings.TrimLeft(r.src[r.pos:end], " \t"), "(")) {
+		r.unsupported = append(r.unsupported, upper)
+	}
+
+	switch {
+	case upper == "LIKE":
+		r.out.WriteString("ILIKE")
+	case upper == "MATCH":
+		out := r.out.String()
+		if m := reMatchColumn.FindStringSubmatchIndex(out); m != nil {
+			r.out.Reset()
+			r.out.WriteString(out[:m[0]])
+			r.out.WriteString(out[m[2]:m[3]] + ".name_search @@")
+			r.matchArg(end)
+			return
+		}
+		r.out.WriteString(word)
+	case r.booleans[strings.ToLower(word)]:
+		r.out.WriteString(word)
+		if m := reBooleanCompare.FindStringSubmatch(r.src[r.pos:end]); m != nil {
+			r.out.WriteString(m[1])
+			if m[2] == "1" {
+				r.out.WriteString("TRUE")
+			} else {
+				r.out.WriteString("FALSE")
+			}
+			r.pos += len(m[0])
+		}
+	default:
+		r.out.WriteString(word)
+	}
+}
+
+// matchArg wraps the MATCH operand, a parameter or a literal, in
+// websearch_to_tsquery, which accepts whatever a shopper types.
+func (r *sqliteRewriter) matchArg(end int) {
+	for r.pos < end && strings.ContainsRune(" \t\r\n", rune(r.src[r.pos])) {
+		r.pos++
+	}
+	sub := &sqliteRewriter{src: r.src, pos: r.pos, maxParam: r.maxParam, booleans: r.booleans}
+	if r.pos < end && r.src[r.pos] == '?' {
+		sub.param()
+	} else if r.pos < end && r.src[r.pos] == '\'' {
+		sub.copyQuoted('\'')
+	}
+	r.out.WriteString(" websearch_to_tsquery('simple', " + sub.out.String() + ")")
+	r.pos = sub.pos
+	r.maxParam = sub.maxParam
+}
+
+// callArgs returns the rewritten arguments when the word just read is
+// followed by a parenthesised argument list.
+func (r *sqliteRewriter) callArgs(end int) ([]string, int, bool) {
+	i := r.pos
+	for i < end && (r.src[i] == ' ' || r.src[i] == '\t') {
+		i++
+	}
+	if i >= end || r.src[i] != '(' {
+		return nil, 0, false
+	}
+
+	var args []string
+	depth := 0
+	argStart := i + 1
+	for j := i; j < end; j++ {
+		switch r.src[j] {
+		case '\'', '"':
+			quote := r.src[j]
+			for j++; j < end && r.src[j] != quote; j++ {
+			}
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				args = append(args, r.sub(argStart, j))
+				return args, j + 1, true
+			}
+		case ',':
+			if depth == 1 {
+				args = append(args, r.sub(argStart, j))
+				argStart = j + 1
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+// sub rewrites src[start:end] as an argument, keeping parameter numbering
+// running across arguments.
+func (r *sqliteRewriter) sub(start int, end int) string {
+	sub := &sqliteRewriter{src: r.src, pos: start, maxParam: r.maxParam, booleans: r.booleans}
+	sub.run(end)
+	r.maxParam = sub.maxParam
+	r.unsupported = append(r.unsupported, sub.unsupported...)
+	return strings.TrimSpace(sub.out.String())
+}
+
+func isNow(arg string) bool {
+	return strings.EqualFold(arg, "'now'")
+}
+
+func (r *sqliteRewriter) rewriteCall(fn string, args []string) (string, bool) {
+	switch fn {
+	case "DATETIME":
+		switch {
+		case len(args) == 1 && isNow(args[0]):
+			return "LOCALTIMESTAMP", true
+		case len(args) == 2 && isNow(args[0]):
+			return "(LOCALTIMESTAMP + INTERVAL " + args[1] + ")", true
+		case len(args) == 1:
+			return "CAST(" + args[0] + " AS TIMESTAMP)", true
+		case len(args) == 2:
+			return "(CAST(" + args[0] + " AS TIMESTAMP) + INTERVAL " + args[1] + ")", true
+		}
+	case "DATE":
+		switch {
+		case len(args) == 1 && isNow(args[0]):
+			return "CURRENT_DATE", true
+		case len(args) == 2 && isNow(args[0]):
+			return "CAST(LOCALTIMESTAMP + INTERVAL " + args[1] + " AS DATE)", true
+		case len(args) == 1:
+			return "CAST(" + args[0] + " AS DATE)", true
+		case len(args) == 2:
+			return "CAST(CAST(" + args[0] + " AS TIMESTAMP) + INTERVAL " + args[1] + " AS DATE)", true
+		}
+	case "GROUP_CONCAT":
+		switch len(args) {
+		case 1:
+			return "STRING_AGG(CAST(" + args[0] + " AS TEXT), ',')", true
+		case 2:
+			return "STRING_AGG(CAST(" + args[0] + " AS TEXT), " + args[1] + ")", true
+		}
+	case "MAX", "MIN":
+		if len(args) < 2 {
+			return "", false
+		}
+		name := "GREATEST"
+		if fn == "MIN" {
+			name = "LEAST"
+		}
+		return name + "(" + strings.Join(args, ", ") + ")", true
+	}
+	return "", false
+}
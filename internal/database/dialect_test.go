@@ -0,0 +1,231 @@
+package database
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"cchoice/internal/errs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBooleans = map[string]bool{"is_active": true, "is_main": true}
+
+func TestRewriteSQLite(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "positional parameters",
+			query: "SELECT * FROM t WHERE a = ? AND b = ? LIMIT ?",
+			want:  "SELECT * FROM t WHERE a = $1 AND b = $2 LIMIT $3",
+		},
+		{
+			name:  "numbered parameters continue the numbering",
+			query: "SELECT * FROM t WHERE a = ?2 AND b = ?1 AND c = ?",
+			want:  "SELECT * FROM t WHERE a = $2 AND b = $1 AND c = $3",
+		},
+		{
+			name:  "strings and comments are left alone",
+			query: "-- is it ?\nSELECT 'what?', \"a?\" FROM t WHERE a = ? /* b = ? */",
+			want:  "-- is it ?\nSELECT 'what?', \"a?\" FROM t WHERE a = $1 /* b = ? */",
+		},
+		{
+			name:  "datetime now",
+			query: "UPDATE t SET updated_at = datetime('now') WHERE created_at < DATETIME('now', '-7 days')",
+			want:  "UPDATE t SET updated_at = LOCALTIMESTAMP WHERE created_at < (LOCALTIMESTAMP + INTERVAL '-7 days')",
+		},
+		{
+			name:  "datetime and date of an expression",
+			query: "SELECT date('now'), date(created_at), datetime(?) FROM t",
+			want:  "SELECT CURRENT_DATE, CAST(created_at AS DATE), CAST($1 AS TIMESTAMP) FROM t",
+		},
+		{
+			name:  "datetime and date of an expression with a modifier",
+			query: "SELECT DATE(o.paid_at, '+8 hours'), datetime(created_at, '+8 hours') FROM t",
+			want:  "SELECT CAST(CAST(o.paid_at AS TIMESTAMP) + INTERVAL '+8 hours' AS DATE), (CAST(created_at AS TIMESTAMP) + INTERVAL '+8 hours') FROM t",
+		},
+		{
+			name:  "group_concat",
+			query: "SELECT GROUP_CONCAT(id), group_concat(name, ', ') FROM t",
+			want:  "SELECT STRING_AGG(CAST(id AS TEXT), ','), STRING_AGG(CAST(name AS TEXT), ', ') FROM t",
+		},
+		{
+			name:  "scalar max and min but not the aggregates",
+			query: "SELECT MAX(a, ?), min(b, 0), MAX(c) FROM t",
+			want:  "SELECT GREATEST(a, $1), LEAST(b, 0), MAX(c) FROM t",
+		},
+		{
+			name:  "like ignores case",
+			query: "SELECT * FROM t WHERE name LIKE ?",
+			want:  "SELECT * FROM t WHERE name ILIKE $1",
+		},
+		{
+			name:  "boolean columns",
+			query: "SELECT * FROM t WHERE t.is_active = 1 AND is_main != 0 AND quantity = 1",
+			want:  "SELECT * FROM t WHERE t.is_active = TRUE AND is_main != FALSE AND quantity = 1",
+		},
+		{
+			name:  "full-text match",
+			query: "SELECT rowid FROM tbl_products_fts WHERE tbl_products_fts.name MATCH ? LIMIT ?",
+			want:  "SELECT rowid FROM tbl_products_fts WHERE tbl_products_fts.name_search @@ websearch_to_tsquery('simple', $1) LIMIT $2",
+		},
+		{
+			name:  "nested calls",
+			query: "SELECT MAX(COALESCE(a, 0), datetime('now')) FROM t WHERE b = ?",
+			want:  "SELECT GREATEST(COALESCE(a, 0), LOCALTIMESTAMP) FROM t WHERE b = $1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unsupported := rewriteSQLite(tt.query, testBooleans)
+			assert.Equal(t, tt.want, got)
+			assert.Empty(t, unsupported)
+		})
+	}
+}
+
+func TestRewriteSQLiteReportsUnsupported(t *testing.T) {
+	query := "SELECT IFNULL(a, 0), MAX(strftime('%Y', created_at), ?) FROM t WHERE name GLOB ? AND ifnull(b, 1) = 1"
+	got, unsupported := rewriteSQLite(query, nil)
+	assert.Equal(t, "SELECT IFNULL(a, 0), GREATEST(strftime('%Y', created_at), $1) FROM t WHERE name GLOB $2 AND ifnull(b, 1) = 1", got)
+	assert.Equal(t, []string{"GLOB", "IFNULL", "STRFTIME"}, unsupported)
+
+	_, unsupported = rewriteSQLite("SELECT total_price, hex_color FROM t", nil)
+	assert.Empty(t, unsupported)
+}
+
+func TestPostgresDialectCachesRewrites(t *testing.T) {
+	d := newPostgresDialect([]string{"IS_ACTIVE"})
+	query := "-- name: GetItem :one\nSELECT * FROM t WHERE id = ? AND is_active = 1"
+	want := "-- name: GetItem :one\nSELECT * FROM t WHERE id = $1 AND is_active = TRUE"
+	got, err := d.rewrite(query)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	cached, ok := d.cache.Load(query)
+	require.True(t, ok)
+	assert.Equal(t, want, cached)
+}
+
+func TestPostgresDialectRefusesUnsupported(t *testing.T) {
+	d := newPostgresDialect(nil)
+	query := "-- name: GetItem :one\nSELECT IFNULL(a, 0) FROM t WHERE name GLOB ?"
+	_, err := d.rewrite(query)
+	require.ErrorIs(t, err, errs.ErrDBDialect)
+	assert.ErrorContains(t, err, "GetItem: GLOB, IFNULL")
+	_, ok := d.cache.Load(query)
+	assert.False(t, ok)
+}
+
+// generatedQueries returns the SQL of every query sqlc generated, by name.
+func generatedQueries(t *testing.T) map[string]string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("queries", "*.sql.go"))
+	require.NoError(t, err)
+
+	generated := make(map[string]string)
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		require.NoError(t, err)
+		ast.Inspect(file, func(n ast.Node) bool {
+			lit, ok := n.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			query, err := strconv.Unquote(lit.Value)
+			if err == nil && strings.HasPrefix(query, "-- name: ") {
+				// The slices are expanded into parameters before the query
+				// reaches the driver.
+				generated[queryName(query)] = reSlice.ReplaceAllString(query, "?")
+			}
+			return true
+		})
+	}
+	require.NotEmpty(t, generated)
+	return generated
+}
+
+var reSlice = regexp.MustCompile(`/\*SLICE:\w+\*/\?`)
+
+// postgresBooleans reads the BOOLEAN columns from migrations/postgres, the
+// way the driver reads them from a migrated database.
+func postgresBooleans(t *testing.T) map[string]bool {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("..", "..", "migrations", "postgres", "*.sql"))
+	require.NoError(t, err)
+
+	booleans := make(map[string]bool)
+	re := regexp.MustCompile(`(?m)^\s+(?:ADD COLUMN )?([a-z_]+) BOOLEAN\b`)
+	for _, path := range paths {
+		schema, err := os.ReadFile(path)
+		require.NoError(t, err)
+		for _, m := range re.FindAllStringSubmatch(string(schema), -1) {
+			booleans[m[1]] = true
+		}
+	}
+	require.NotEmpty(t, booleans)
+	return booleans
+}
+
+// sourceQueries returns every query in sql/*.sql, by name, cut at the next
+// "-- name:" line.
+func sourceQueries(t *testing.T) map[string]string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("sql", "*.sql"))
+	require.NoError(t, err)
+
+	source := make(map[string]string)
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		for i, query := range strings.Split(string(content), "-- name: ") {
+			if i == 0 {
+				continue
+			}
+			query = "-- name: " + query
+			source[queryName(query)] = query
+		}
+	}
+	require.NotEmpty(t, source)
+	return source
+}
+
+func TestSourceQueriesRewriteCleanly(t *testing.T) {
+	d := newPostgresDialect(nil)
+	generated := generatedQueries(t)
+	for name, query := range sourceQueries(t) {
+		_, err := d.rewrite(query)
+		assert.NoError(t, err, name)
+		assert.Contains(t, generated, name, "sqlc generate has not been run")
+	}
+}
+
+func TestGeneratedQueriesRewriteCleanly(t *testing.T) {
+	booleans := postgresBooleans(t)
+	strip := regexp.MustCompile(`'(?:[^']|'')*'|--[^\n]*|/\*.*?\*/`)
+	leftovers := []string{"?", " MATCH ", "GROUP_CONCAT", "DATETIME(", "INSERT OR ", "REPLACE INTO"}
+
+	for name, query := range generatedQueries(t) {
+		rewritten, unsupported := rewriteSQLite(query, booleans)
+		assert.Empty(t, unsupported, name)
+
+		code := strings.ToUpper(strip.ReplaceAllString(rewritten, ""))
+		for _, leftover := range leftovers {
+			assert.NotContains(t, code, leftover, name)
+		}
+		for column := range booleans {
+			assert.NotRegexp(t, `\b`+strings.ToUpper(column)+`\s*(=|!=|<>)\s*[01]\b`, code, name)
+		}
+	}
+}
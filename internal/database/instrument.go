@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cchoice/internal/database/queries"
+	"cchoice/internal/errs"
+	"cchoice/internal/metrics"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+const rawQueryName = "raw"
+
+// instrumentedDB times every query sqlc runs and labels it with the query
+// name from the "-- name: GetProducts :many" comment sqlc puts in front of the
+// SQL. Transactions from RunInTx are instrumented too.
+type instrumentedDB struct {
+	db   queries.DBTX
+	mode DBMode
+}
+
+func instrument(db queries.DBTX, mode DBMode) queries.DBTX {
+	return &instrumentedDB{db: db, mode: mode}
+}
+
+func queryName(query string) string {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(query), "-- name: ")
+	if !ok {
+		return rawQueryName
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	if name == "" {
+		return rawQueryName
+	}
+	return name
+}
+
+// readOnlyError reports a write rejected by a read-only connection as
+// errs.ErrDBReadOnly along with the query that attempted it. The connections
+// apply it to every statement and to reading rows, where a write run through
+// QueryRow fails, so it holds for raw queries and transactions alike.
+func readOnlyError(query string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var sqliteErr sqlite3.Error
+	if (errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrReadonly) || postgresErrorCode(err) == pgReadOnlyTransaction {
+		return errors.Join(fmt.Errorf("%w: %s", errs.ErrDBReadOnly, queryName(query)), err)
+	}
+	return err
+}
+
+func (i *instrumentedDB) observe(name string, start time.Time, err error) {
+	metrics.DB.Query(name, string(i.mode), time.Since(start), err != nil && !errors.Is(err, sql.ErrNoRows))
+}
+
+func (i *instrumentedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	res, err := i.db.ExecContext(ctx, query, args...)
+	i.observe(queryName(query), start, err)
+	return res, err
+}
+
+func (i *instrumentedDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return i.db.PrepareContext(ctx, query)
+}
+
+func (i *instrumentedDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := i.db.QueryContext(ctx, query, args...)
+	i.observe(queryName(query), start, err)
+	return rows, err
+}
+
+// QueryRowContext times running the query only, since *sql.Row surfaces the
+// rest on Scan.
+func (i *instrumentedDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := i.db.QueryRowContext(ctx, query, args...)
+	i.observe(queryName(query), start, row.Err())
+	return row
+}
+
+var _ queries.DBTX = (*instrumentedDB)(nil)
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"cchoice/internal/database/dbtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func migrationNames(t *testing.T, driver string) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("..", "..", "migrations", driver, "*.sql"))
+	require.NoError(t, err)
+	names := make([]string, 0, len(paths))
+	for _, path := range paths {
+		names = append(names, filepath.Base(path))
+	}
+	slices.Sort(names)
+	return names
+}
+
+// migrations/postgres starts at a baseline holding the SQLite schema of its
+// version. From there on the two sets must move together, so a database can be
+// copied across by migrate_sqlite_to_postgres at any version.
+func TestPostgresMigrationsInStep(t *testing.T) {
+	sqlite := migrationNames(t, "sqlite3")
+	postgres := migrationNames(t, "postgres")
+	require.NotEmpty(t, postgres)
+
+	baseline := postgres[0]
+	require.True(t, strings.HasSuffix(baseline, "_baseline.sql"), baseline)
+	baselineVersion, _, _ := strings.Cut(baseline, "_")
+
+	var want []string
+	for _, name := range sqlite {
+		version, _, _ := strings.Cut(name, "_")
+		switch {
+		case version == baselineVersion:
+			want = append(want, baseline)
+		case version > baselineVersion:
+			want = append(want, name)
+		}
+	}
+	assert.Equal(t, want, postgres, "every SQLite migration after the baseline needs a postgres one with the same name")
+}
+
+func TestPostgresMigrationsHaveDown(t *testing.T) {
+	for _, name := range migrationNames(t, "postgres") {
+		migration, err := os.ReadFile(filepath.Join("..", "..", "migrations", "postgres", name))
+		require.NoError(t, err)
+		assert.Contains(t, string(migration), "-- +goose Down", name)
+		assert.NotEmpty(t, strings.TrimSpace(dbtest.GooseUp(string(migration))), name)
+	}
+}
@@ -0,0 +1,519 @@
+package pgmigrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cchoice/internal/errs"
+)
+
+// maxParams is the most bind parameters a PostgreSQL statement can take.
+const maxParams = 65535
+
+type Table struct {
+	Name    string
+	Columns []Column
+}
+
+type Column struct {
+	Name string
+	// Type is the PostgreSQL data_type from information_schema.columns.
+	Type string
+}
+
+type TableReport struct {
+	Name           string
+	SourceRows     int64
+	TargetRows     int64
+	SourceChecksum uint64
+	TargetChecksum uint64
+}
+
+func (tr TableReport) OK() bool {
+	return tr.SourceRows == tr.TargetRows && tr.SourceChecksum == tr.TargetChecksum
+}
+
+type Report struct {
+	GooseVersion int64
+	Tables       []TableReport
+}
+
+// Mismatched returns the tables whose rows differ between the databases.
+func (r *Report) Mismatched() []string {
+	var names []string
+	for _, tr := range r.Tables {
+		if !tr.OK() {
+			names = append(names, tr.Name)
+		}
+	}
+	return names
+}
+
+// Migrator copies a SQLite database into a PostgreSQL database whose schema was
+// created from migrations/postgres at the same goose version. The copy runs in
+// one transaction on the target, and the result is verified by comparing row
+// counts and an order-independent checksum of every table.
+type Migrator struct {
+	src       *sql.DB
+	dst       *sql.DB
+	batchSize int
+}
+
+func New(src *sql.DB, dst *sql.DB, batchSize int) *Migrator {
+	if src == nil || dst == nil {
+		panic("source and target databases are required")
+	}
+	return &Migrator{
+		src:       src,
+		dst:       dst,
+		batchSize: max(batchSize, 1),
+	}
+}
+
+// Copy copies every table and then verifies the target. The target tables
+// must be empty unless truncate is set, in which case they are emptied first.
+func (m *Migrator) Copy(ctx context.Context, truncate bool) (*Report, error) {
+	version, err := m.checkVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := m.tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := m.dst.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Join(errs.ErrDBMigrate, err)
+	}
+	defer tx.Rollback()
+
+	if truncate {
+		names := make([]string, 0, len(tables))
+		for _, table := range tables {
+			names = append(names, quoteIdent(table.Name))
+		}
+		if _, err := tx.ExecContext(ctx, "TRUNCATE "+strings.Join(names, ", ")+" RESTART IDENTITY CASCADE"); err != nil {
+			return nil, errors.Join(errs.ErrDBMigrate, err)
+		}
+	}
+
+	for _, table := range tables {
+		if err := m.copyTable(ctx, tx, table, truncate); err != nil {
+			return nil, errors.Join(errs.ErrDBMigrate, fmt.Errorf("table %s: %w", table.Name, err))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Join(errs.ErrDBMigrate, err)
+	}
+
+	return m.verify(ctx, version, tables)
+}
+
+// Verify compares the databases without copying anything.
+func (m *Migrator) Verify(ctx context.Context) (*Report, error) {
+	version, err := m.checkVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := m.tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.verify(ctx, version, tables)
+}
+
+func (m *Migrator) checkVersions(ctx context.Context) (int64, error) {
+	srcVersion, err := gooseVersion(ctx, m.src)
+	if err != nil {
+		return 0, errors.Join(errs.ErrDBMigrate, fmt.Errorf("source: %w", err))
+	}
+	dstVersion, err := gooseVersion(ctx, m.dst)
+	if err != nil {
+		return 0, errors.Join(errs.ErrDBMigrate, fmt.Errorf("target: %w", err))
+	}
+	if srcVersion != dstVersion {
+		return 0, errors.Join(
+			errs.ErrDBMigrate,
+			fmt.Errorf("source is at migration %d but target is at %d, run goose with migrations/postgres first", srcVersion, dstVersion),
+		)
+	}
+	return srcVersion, nil
+}
+
+func gooseVersion(ctx context.Context, db *sql.DB) (int64, error) {
+	var version sql.NullInt64
+	err := db.QueryRowContext(ctx, `
+		SELECT MAX(version_id)
+		FROM goose_db_version
+		WHERE is_applied
+	`).Scan(&version)
+	return version.Int64, err
+}
+
+// tables lists the source tables in an order that inserts referenced rows
+// before the rows referencing them, with the columns both databases share.
+func (m *Migrator) tables(ctx context.Context) ([]Table, error) {
+	names, err := sourceTables(ctx, m.src)
+	if err != nil {
+		return nil, errors.Join(errs.ErrDBMigrate, err)
+	}
+
+	deps := make(map[string][]string, len(names))
+	for _, name := range names {
+		refs, err := sourceReferences(ctx, m.src, name)
+		if err != nil {
+			return nil, errors.Join(errs.ErrDBMigrate, fmt.Errorf("table %s: %w", name, err))
+		}
+		deps[name] = refs
+	}
+
+	var tables []Table
+	for _, name := range sortTables(names, deps) {
+		srcColumns, err := sourceColumns(ctx, m.src, name)
+		if err != nil {
+			return nil, errors.Join(errs.ErrDBMigrate, fmt.Errorf("table %s: %w", name, err))
+		}
+		dstTypes, err := targetColumns(ctx, m.dst, name)
+		if err != nil {
+			return nil, errors.Join(errs.ErrDBMigrate, fmt.Errorf("table %s: %w", name, err))
+		}
+		if len(dstTypes) == 0 {
+			return nil, errors.Join(errs.ErrDBMigrate, fmt.Errorf("table %s does not exist in the target", name))
+		}
+
+		table := Table{Name: name}
+		for _, column := range srcColumns {
+			typ, ok := dstTypes[column]
+			if !ok {
+				return nil, errors.Join(errs.ErrDBMigrate, fmt.Errorf("column %s.%s does not exist in the target", name, column))
+			}
+			table.Columns = append(table.Columns, Column{Name: column, Type: typ})
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+func sourceTables(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT name
+		FROM sqlite_master
+		WHERE type = 'table'
+		AND name NOT LIKE 'sqlite_%'
+		AND name NOT LIKE 'tbl_products_fts%'
+		AND name != 'goose_db_version'
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+func sourceReferences(ctx context.Context, db *sql.DB, table string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT DISTINCT "table" FROM pragma_foreign_key_list(?)`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refs []string
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
+
+func sourceColumns(ctx context.Context, db *sql.DB, table string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM pragma_table_info(?) ORDER BY cid`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	return columns, rows.Err()
+}
+
+// targetColumns returns the data types of the columns that can be written,
+// leaving out generated ones such as tbl_products.name_search.
+func targetColumns(ctx context.Context, db *sql.DB, table string) (map[string]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT column_name, data_type
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()
+		AND table_name = $1
+		AND is_generated = 'NEVER'
+	`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	types := make(map[string]string)
+	for rows.Next() {
+		var column, typ string
+		if err := rows.Scan(&column, &typ); err != nil {
+			return nil, err
+		}
+		types[column] = typ
+	}
+	return types, rows.Err()
+}
+
+// sortTables orders names so that every table comes after the tables it
+// references. Tables in a reference cycle keep their relative name order at
+// the end, where the foreign keys decide whether their rows can be inserted.
+func sortTables(names []string, deps map[string][]string) []string {
+	pending := make(map[string]int, len(names))
+	referencedBy := make(map[string][]string)
+	for _, name := range names {
+		for _, ref := range deps[name] {
+			if ref == name || !slices.Contains(names, ref) {
+				continue
+			}
+			pending[name]++
+			referencedBy[ref] = append(referencedBy[ref], name)
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	done := make(map[string]bool, len(names))
+	for len(sorted) < len(names) {
+		progressed := false
+		for _, name := range names {
+			if done[name] || pending[name] > 0 {
+				continue
+			}
+			done[name] = true
+			sorted = append(sorted, name)
+			for _, dependent := range referencedBy[name] {
+				pending[dependent]--
+			}
+			progressed = true
+		}
+		if !progressed {
+			for _, name := range names {
+				if !done[name] {
+					done[name] = true
+					sorted = append(sorted, name)
+				}
+			}
+		}
+	}
+	return sorted
+}
+
+func (m *Migrator) copyTable(ctx context.Context, tx *sql.Tx, table Table, truncated bool) error {
+	if !truncated {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM "+quoteIdent(table.Name)+")").Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("target table is not empty, pass --truncate to replace its rows")
+		}
+	}
+
+	rows, err := m.src.QueryContext(ctx, selectColumns(table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	batchSize := min(m.batchSize, maxParams/len(table.Columns))
+	batch := make([]any, 0, batchSize*len(table.Columns))
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		_, err := tx.ExecContext(ctx, insertStatement(table, len(batch)/len(table.Columns)), batch...)
+		batch = batch[:0]
+		return err
+	}
+
+	for rows.Next() {
+		values, err := scanRow(rows, len(table.Columns))
+		if err != nil {
+			return err
+		}
+		for i, column := range table.Columns {
+			value, err := convertValue(values[i], column.Type)
+			if err != nil {
+				return fmt.Errorf("column %s: %w", column.Name, err)
+			}
+			batch = append(batch, value)
+		}
+		if len(batch) == batchSize*len(table.Columns) {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return resetIdentity(ctx, tx, table)
+}
+
+// resetIdentity moves the identity sequence past the copied ids, since rows
+// inserted with an explicit id do not advance it.
+func resetIdentity(ctx context.Context, tx *sql.Tx, table Table) error {
+	var identity sql.NullString
+	err := tx.QueryRowContext(ctx, `
+		SELECT column_name
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()
+		AND table_name = $1
+		AND is_identity = 'YES'
+	`, table.Name).Scan(&identity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	column := quoteIdent(identity.String)
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(
+		"SELECT setval(pg_get_serial_sequence($1, $2), COALESCE(MAX(%s), 0) + 1, false) FROM %s",
+		column,
+		quoteIdent(table.Name),
+	), table.Name, identity.String)
+	return err
+}
+
+func (m *Migrator) verify(ctx context.Context, version int64, tables []Table) (*Report, error) {
+	report := &Report{GooseVersion: version}
+	for _, table := range tables {
+		srcRows, srcSum, err := checksumTable(ctx, m.src, table, true)
+		if err != nil {
+			return nil, errors.Join(errs.ErrDBMigrate, fmt.Errorf("source table %s: %w", table.Name, err))
+		}
+		dstRows, dstSum, err := checksumTable(ctx, m.dst, table, false)
+		if err != nil {
+			return nil, errors.Join(errs.ErrDBMigrate, fmt.Errorf("target table %s: %w", table.Name, err))
+		}
+		report.Tables = append(report.Tables, TableReport{
+			Name:           table.Name,
+			SourceRows:     srcRows,
+			TargetRows:     dstRows,
+			SourceChecksum: srcSum,
+			TargetChecksum: dstSum,
+		})
+	}
+
+	if mismatched := report.Mismatched(); len(mismatched) > 0 {
+		return report, errors.Join(errs.ErrDBMigrate, fmt.Errorf("rows differ in %s", strings.Join(mismatched, ", ")))
+	}
+	return report, nil
+}
+
+// checksumTable sums the hashes of every row, so rows can be read in whatever
+// order each database returns them. Source values are converted first, which
+// makes them compare the way they were written to the target.
+func checksumTable(ctx context.Context, db *sql.DB, table Table, source bool) (int64, uint64, error) {
+	rows, err := db.QueryContext(ctx, selectColumns(table))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer rows.Close()
+
+	var count int64
+	var sum uint64
+	for rows.Next() {
+		values, err := scanRow(rows, len(table.Columns))
+		if err != nil {
+			return 0, 0, err
+		}
+		if source {
+			for i, column := range table.Columns {
+				if values[i], err = convertValue(values[i], column.Type); err != nil {
+					return 0, 0, fmt.Errorf("column %s: %w", column.Name, err)
+				}
+			}
+		}
+		count++
+		sum += hashRow(values, table.Columns)
+	}
+	return count, sum, rows.Err()
+}
+
+func scanRow(rows *sql.Rows, n int) ([]any, error) {
+	values := make([]any, n)
+	ptrs := make([]any, n)
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	if err := rows.Scan(ptrs...); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func selectColumns(table Table) string {
+	columns := make([]string, 0, len(table.Columns))
+	for _, column := range table.Columns {
+		columns = append(columns, quoteIdent(column.Name))
+	}
+	return "SELECT " + strings.Join(columns, ", ") + " FROM " + quoteIdent(table.Name)
+}
+
+func insertStatement(table Table, rows int) string {
+	columns := make([]string, 0, len(table.Columns))
+	for _, column := range table.Columns {
+		columns = append(columns, quoteIdent(column.Name))
+	}
+
+	var b strings.Builder
+	b.WriteString("INSERT INTO " + quoteIdent(table.Name) + " (" + strings.Join(columns, ", ") + ") VALUES ")
+	param := 1
+	for row := range rows {
+		if row > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("(")
+		for i := range table.Columns {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "$%d", param)
+			param++
+		}
+		b.WriteString(")")
+	}
+	return b.String()
+}
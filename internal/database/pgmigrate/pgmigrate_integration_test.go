@@ -0,0 +1,103 @@
+//go:build fts5
+
+package pgmigrate
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"cchoice/internal/database/dbtest"
+	"cchoice/internal/errs"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCopyIntoPostgres copies a SQLite database migrated with
+// migrations/sqlite3 into a PostgreSQL one migrated with migrations/postgres,
+// the way migrate_sqlite_to_postgres does. It needs the fts5 tag for the
+// SQLite schema and a PostgreSQL, see dbtest.PostgresURLEnv.
+func TestCopyIntoPostgres(t *testing.T) {
+	ctx := context.Background()
+	migrations := filepath.Join("..", "..", "..", "migrations")
+
+	dburl := dbtest.PostgresSchema(t)
+	dst, err := sql.Open("pgx", dburl)
+	require.NoError(t, err)
+	t.Cleanup(func() { dst.Close() })
+	require.NoError(t, dbtest.ApplyMigrations(ctx, dst, filepath.Join(migrations, "postgres"), true))
+
+	srcurl := "file:" + filepath.Join(t.TempDir(), "test.db")
+	src, err := sql.Open("sqlite3", srcurl)
+	require.NoError(t, err)
+	require.NoError(t, dbtest.ApplyMigrations(ctx, src, filepath.Join(migrations, "sqlite3"), false))
+	// The migrations turn foreign keys on for their connection, and
+	// tbl_products still references the long gone tbl_brand; the app's own
+	// connections run without them.
+	require.NoError(t, src.Close())
+	src, err = sql.Open("sqlite3", srcurl)
+	require.NoError(t, err)
+	t.Cleanup(func() { src.Close() })
+
+	_, err = src.ExecContext(ctx, `
+		INSERT INTO tbl_brands (id, name, status) VALUES (1, 'Bosch', 'ACTIVE'), (2, 'Makita', 'ACTIVE');
+		INSERT INTO tbl_products (
+			id, serial, name, description, brand_id, status,
+			unit_price_without_vat, unit_price_with_vat,
+			unit_price_without_vat_currency, unit_price_with_vat_currency,
+			created_at, updated_at, slug
+		) VALUES
+			(1, 'GSB-180', 'Cordless Impact Drill', 'Two speeds', 1, 'ACTIVE', 100000, 112000, 'PHP', 'PHP', '2026-01-02 03:04:05', '2026-01-02 03:04:05', 'gsb-180'),
+			(2, 'HP-1630', 'Hammer Drill', NULL, 2, 'ACTIVE', 250000, 280000, 'PHP', 'PHP', '2026-02-03 04:05:06', '2026-02-03 04:05:06', NULL);
+		INSERT INTO tbl_product_sales (
+			product_id, sale_price_without_vat, sale_price_with_vat,
+			sale_price_without_vat_currency, sale_price_with_vat_currency,
+			discount_type, discount_value, starts_at, ends_at, is_active
+		) VALUES (1, 90000, 100800, 'PHP', 'PHP', 'percentage', 10, '2026-03-01 00:00:00', '2026-03-31 23:59:59', 1);
+	`)
+	require.NoError(t, err)
+
+	m := New(src, dst, 1)
+	report, err := m.Copy(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, report.Mismatched())
+
+	rows := map[string]int64{}
+	for _, table := range report.Tables {
+		rows[table.Name] = table.TargetRows
+	}
+	assert.Equal(t, int64(2), rows["tbl_brands"])
+	assert.Equal(t, int64(2), rows["tbl_products"])
+	assert.Equal(t, int64(1), rows["tbl_product_sales"])
+
+	var active bool
+	require.NoError(t, dst.QueryRowContext(ctx, "SELECT is_active FROM tbl_product_sales WHERE product_id = 1").Scan(&active))
+	assert.True(t, active)
+
+	// The identity sequences continue after the copied ids.
+	var id int64
+	require.NoError(t, dst.QueryRowContext(ctx, "INSERT INTO tbl_brands (name) VALUES ('Dewalt') RETURNING id").Scan(&id))
+	assert.Equal(t, int64(3), id)
+	_, err = dst.ExecContext(ctx, "DELETE FROM tbl_brands WHERE id = $1", id)
+	require.NoError(t, err)
+
+	_, err = m.Verify(ctx)
+	require.NoError(t, err)
+
+	_, err = dst.ExecContext(ctx, "UPDATE tbl_products SET name = 'Impact Drill' WHERE id = 1")
+	require.NoError(t, err)
+	report, err = m.Verify(ctx)
+	assert.ErrorIs(t, err, errs.ErrDBMigrate)
+	require.NotNil(t, report)
+	assert.Equal(t, []string{"tbl_products"}, report.Mismatched())
+
+	_, err = m.Copy(ctx, false)
+	assert.ErrorIs(t, err, errs.ErrDBMigrate)
+
+	report, err = m.Copy(ctx, true)
+	require.NoError(t, err)
+	assert.Empty(t, report.Mismatched())
+}
@@ -0,0 +1,175 @@
+package pgmigrate
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openSQLite(t *testing.T, schema string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec(schema)
+	require.NoError(t, err)
+	return db
+}
+
+func TestSortTables(t *testing.T) {
+	names := []string{"tbl_a", "tbl_b", "tbl_c", "tbl_d", "tbl_x", "tbl_y"}
+	deps := map[string][]string{
+		"tbl_a": {"tbl_c", "tbl_b"},
+		"tbl_b": {"tbl_c"},
+		"tbl_c": {"tbl_c"},
+		"tbl_d": {"tbl_missing"},
+		"tbl_x": {"tbl_y"},
+		"tbl_y": {"tbl_x"},
+	}
+	assert.Equal(t, []string{"tbl_c", "tbl_d", "tbl_b", "tbl_a", "tbl_x", "tbl_y"}, sortTables(names, deps))
+}
+
+func TestSourceSchema(t *testing.T) {
+	db := openSQLite(t, `
+		CREATE TABLE goose_db_version (id INTEGER PRIMARY KEY, version_id INTEGER NOT NULL, is_applied INTEGER NOT NULL);
+		INSERT INTO goose_db_version (version_id, is_applied) VALUES (0, 1), (20260101000000, 1), (20260201000000, 0);
+		CREATE TABLE tbl_brands (id INTEGER PRIMARY KEY, name TEXT NOT NULL);
+		CREATE TABLE tbl_products (
+			id INTEGER PRIMARY KEY,
+			brand_id INTEGER NOT NULL REFERENCES tbl_brands(id),
+			name TEXT NOT NULL
+		);
+		-- The shadow tables of the FTS5 index are skipped with it.
+		CREATE TABLE tbl_products_fts_data (id INTEGER PRIMARY KEY, block BLOB);
+	`)
+	ctx := context.Background()
+
+	version, err := gooseVersion(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20260101000000), version)
+
+	names, err := sourceTables(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tbl_brands", "tbl_products"}, names)
+
+	refs, err := sourceReferences(ctx, db, "tbl_products")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tbl_brands"}, refs)
+
+	columns, err := sourceColumns(ctx, db, "tbl_products")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "brand_id", "name"}, columns)
+}
+
+func TestConvertValue(t *testing.T) {
+	at := time.Date(2026, 7, 28, 12, 30, 45, 123456000, time.UTC)
+	tests := []struct {
+		name  string
+		value any
+		typ   string
+		want  any
+	}{
+		{"null", nil, typeBoolean, nil},
+		{"boolean from integer", int64(1), typeBoolean, true},
+		{"boolean from zero", int64(0), typeBoolean, false},
+		{"timestamp from time", at.In(time.FixedZone("PHT", 8*60*60)), typeTimestamp, at},
+		{"timestamp from datetime text", "2026-07-28 12:30:45.123456", typeTimestamp, at},
+		{"timestamptz from goqite text", "2026-07-28T12:30:45.123456Z", typeTimestampTZ, at},
+		{"date from text", "2026-07-28", typeDate, time.Date(2026, 7, 28, 0, 0, 0, 0, time.UTC)},
+		{"bytea from text", "body", typeBytea, []byte("body")},
+		{"text from bytes", []byte("name"), typeText, "name"},
+		{"text from integer", int64(42), typeText, "42"},
+		{"bigint from whole float", float64(3), typeBigint, int64(3)},
+		{"double from integer", int64(3), typeDouble, float64(3)},
+		{"unknown types pass through", "x", "character varying", "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertValue(tt.value, tt.typ)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := convertValue("yesterday", typeTimestamp)
+	assert.Error(t, err)
+	_, err = convertValue(float64(1.5), typeBigint)
+	assert.Error(t, err)
+	_, err = convertValue(int64(1), typeBytea)
+	assert.Error(t, err)
+}
+
+func TestHashRowMatchesReadBack(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: typeBigint},
+		{Name: "is_active", Type: typeBoolean},
+		{Name: "created_at", Type: typeTimestamp},
+		{Name: "name", Type: typeText},
+	}
+	source := []any{int64(1), int64(1), "2026-07-28 12:30:45.1234567", []byte("drill")}
+	for i, column := range columns {
+		var err error
+		source[i], err = convertValue(source[i], column.Type)
+		require.NoError(t, err)
+	}
+	// PostgreSQL keeps microseconds and hands TIMESTAMP back in UTC.
+	target := []any{int64(1), true, time.Date(2026, 7, 28, 12, 30, 45, 123456000, time.UTC), "drill"}
+	assert.Equal(t, hashRow(source, columns), hashRow(target, columns))
+
+	target[3] = "saw"
+	assert.NotEqual(t, hashRow(source, columns), hashRow(target, columns))
+}
+
+func TestChecksumIgnoresRowOrder(t *testing.T) {
+	ctx := context.Background()
+	table := Table{
+		Name: "tbl_items",
+		Columns: []Column{
+			{Name: "id", Type: typeBigint},
+			{Name: "name", Type: typeText},
+		},
+	}
+	a := openSQLite(t, `
+		CREATE TABLE tbl_items (id INTEGER PRIMARY KEY, name TEXT);
+		INSERT INTO tbl_items VALUES (1, 'a'), (2, NULL), (3, 'c');
+	`)
+	b := openSQLite(t, `
+		CREATE TABLE tbl_items (id INTEGER, name TEXT);
+		INSERT INTO tbl_items VALUES (3, 'c'), (1, 'a'), (2, NULL);
+	`)
+
+	countA, sumA, err := checksumTable(ctx, a, table, true)
+	require.NoError(t, err)
+	countB, sumB, err := checksumTable(ctx, b, table, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), countA)
+	assert.Equal(t, countA, countB)
+	assert.Equal(t, sumA, sumB)
+
+	_, err = b.Exec("UPDATE tbl_items SET name = '' WHERE id = 2")
+	require.NoError(t, err)
+	_, sumB, err = checksumTable(ctx, b, table, true)
+	require.NoError(t, err)
+	assert.NotEqual(t, sumA, sumB)
+}
+
+func TestInsertStatement(t *testing.T) {
+	table := Table{
+		Name: "tbl_items",
+		Columns: []Column{
+			{Name: "id", Type: typeBigint},
+			{Name: "order", Type: typeText},
+		},
+	}
+	assert.Equal(
+		t,
+		`INSERT INTO "tbl_items" ("id", "order") VALUES ($1, $2), ($3, $4)`,
+		insertStatement(table, 2),
+	)
+}
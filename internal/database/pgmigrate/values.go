@@ -0,0 +1,189 @@
+package pgmigrate
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	typeBoolean     = "boolean"
+	typeTimestamp   = "timestamp without time zone"
+	typeTimestampTZ = "timestamp with time zone"
+	typeDate        = "date"
+	typeBytea       = "bytea"
+	typeText        = "text"
+	typeBigint      = "bigint"
+	typeInteger     = "integer"
+	typeDouble      = "double precision"
+)
+
+// timeLayouts are the formats the SQLite database holds times in: the ones
+// DATETIME('now') and the sqlite3 driver write, and goqite's RFC3339.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	time.DateOnly,
+}
+
+func parseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", s)
+}
+
+// convertValue turns a value read from SQLite into one PostgreSQL accepts for
+// a column of type typ. SQLite does not enforce column types, so booleans
+// arrive as integers and times as either time.Time or text.
+func convertValue(value any, typ string) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch typ {
+	case typeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case int64:
+			return v != 0, nil
+		case float64:
+			return v != 0, nil
+		case string:
+			return strconv.ParseBool(v)
+		case []byte:
+			return strconv.ParseBool(string(v))
+		}
+
+	case typeTimestamp, typeTimestampTZ, typeDate:
+		switch v := value.(type) {
+		case time.Time:
+			return v.UTC(), nil
+		case string:
+			t, err := parseTime(v)
+			return t.UTC(), err
+		case []byte:
+			t, err := parseTime(string(v))
+			return t.UTC(), err
+		case int64:
+			return time.Unix(v, 0).UTC(), nil
+		}
+
+	case typeBytea:
+		switch v := value.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return []byte(v), nil
+		}
+
+	case typeText:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case []byte:
+			return string(v), nil
+		case int64:
+			return strconv.FormatInt(v, 10), nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		case time.Time:
+			return v.UTC().Format("2006-01-02 15:04:05"), nil
+		}
+
+	case typeBigint, typeInteger:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case float64:
+			if v != math.Trunc(v) {
+				return nil, fmt.Errorf("%v is not an integer", v)
+			}
+			return int64(v), nil
+		case string:
+			return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		case []byte:
+			return strconv.ParseInt(strings.TrimSpace(string(v)), 10, 64)
+		}
+
+	case typeDouble:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int64:
+			return float64(v), nil
+		case string:
+			return strconv.ParseFloat(strings.TrimSpace(v), 64)
+		case []byte:
+			return strconv.ParseFloat(strings.TrimSpace(string(v)), 64)
+		}
+
+	default:
+		return value, nil
+	}
+	return nil, fmt.Errorf("cannot store %T as %s", value, typ)
+}
+
+// canonical formats a converted value, or one read back from PostgreSQL, the
+// same way for both. Times are compared at the microsecond precision
+// PostgreSQL keeps, and TIMESTAMP columns by their wall clock.
+func canonical(value any, typ string) string {
+	switch v := value.(type) {
+	case nil:
+		return "\x00"
+	case time.Time:
+		switch typ {
+		case typeDate:
+			return v.Format(time.DateOnly)
+		case typeTimestampTZ:
+			return v.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)
+		default:
+			return v.Truncate(time.Microsecond).Format("2006-01-02T15:04:05.999999")
+		}
+	case []byte:
+		if typ == typeBytea {
+			return fmt.Sprintf("%x", v)
+		}
+		return string(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func hashRow(values []any, columns []Column) uint64 {
+	h := fnv.New64a()
+	var size [8]byte
+	for i, value := range values {
+		s := canonical(value, columns[i].Type)
+		binary.LittleEndian.PutUint64(size[:], uint64(len(s)))
+		h.Write(size[:])
+		h.Write([]byte(s))
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,165 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+
+	"cchoice/internal/database/queries"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// SQLSTATE codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgReadOnlyTransaction = "25006"
+	pgSerializationFailed = "40001"
+	pgDeadlockDetected    = "40P01"
+	pgLockNotAvailable    = "55P03"
+)
+
+// postgresConfig applies the mode to the session: read-only connections
+// default every transaction to READ ONLY, so the server rejects writes the
+// same way query_only does on SQLite. Queries go through the simple protocol
+// because the SQLite queries lean on untyped parameters. With the extended
+// protocol the server has to type every parameter while preparing, and fails
+// on ones such as `? IS NULL`. The simple protocol sends them as literals,
+// which it types from the context like SQLite does.
+func postgresConfig(dburl string, mode DBMode) (*pgx.ConnConfig, error) {
+	cfg, err := pgx.ParseConfig(dburl)
+	if err != nil {
+		return nil, err
+	}
+	cfg.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+	if _, ok := cfg.RuntimeParams["timezone"]; !ok {
+		cfg.RuntimeParams["timezone"] = "UTC"
+	}
+	if mode == DB_MODE_RO {
+		cfg.RuntimeParams["default_transaction_read_only"] = "on"
+	}
+	return cfg, nil
+}
+
+func openPostgres(dburl string, mode DBMode) (*service, error) {
+	cfg, err := postgresConfig(dburl, mode)
+	if err != nil {
+		return nil, err
+	}
+	db := sql.OpenDB(&postgresConnector{Connector: stdlib.GetConnector(*cfg)})
+	return &service{
+		db:      db,
+		queries: queries.New(instrument(db, mode)),
+		mode:    mode,
+		driver:  DB_DRIVER_POSTGRES,
+	}, nil
+}
+
+// postgresConnector hands out connections that rewrite every statement before
+// pgx sees it and report rejected writes as errs.ErrDBReadOnly. Everything
+// else is the stdlib connection as it is. The dialect
+// is built on the first connection, from the schema it finds there.
+type postgresConnector struct {
+	driver.Connector
+	mu      sync.Mutex
+	dialect *postgresDialect
+}
+
+func (c *postgresConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pgConn := conn.(*stdlib.Conn)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dialect == nil {
+		booleans, err := booleanColumns(ctx, pgConn.Conn())
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		c.dialect = newPostgresDialect(booleans)
+	}
+	return &postgresConn{Conn: pgConn, dialect: c.dialect}, nil
+}
+
+func booleanColumns(ctx context.Context, conn *pgx.Conn) ([]string, error) {
+	rows, err := conn.Query(ctx, `
+		SELECT DISTINCT column_name
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()
+		AND data_type = 'boolean'
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+type postgresConn struct {
+	*stdlib.Conn
+	dialect *postgresDialect
+}
+
+func (c *postgresConn) Prepare(query string) (driver.Stmt, error) {
+	rewritten, err := c.dialect.rewrite(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.Conn.Prepare(rewritten)
+}
+
+func (c *postgresConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	rewritten, err := c.dialect.rewrite(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.Conn.PrepareContext(ctx, rewritten)
+}
+
+func (c *postgresConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	rewritten, err := c.dialect.rewrite(query)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.Conn.ExecContext(ctx, rewritten, args)
+	return res, readOnlyError(query, err)
+}
+
+func (c *postgresConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rewritten, err := c.dialect.rewrite(query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := c.Conn.QueryContext(ctx, rewritten, args)
+	if err != nil {
+		return nil, readOnlyError(query, err)
+	}
+	if pgRows, ok := rows.(*stdlib.Rows); ok {
+		return &postgresRows{Rows: pgRows, query: query}, nil
+	}
+	return rows, nil
+}
+
+// postgresRows maps errors pgx only reports once the rows are read.
+type postgresRows struct {
+	*stdlib.Rows
+	query string
+}
+
+func (r *postgresRows) Next(dest []driver.Value) error {
+	return readOnlyError(r.query, r.Rows.Next(dest))
+}
+
+func postgresErrorCode(err error) string {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return ""
+	}
+	return pgErr.Code
+}
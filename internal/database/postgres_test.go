@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"cchoice/internal/database/dbtest"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/errs"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// These tests need a PostgreSQL, see dbtest.PostgresURLEnv. Each runs in a
+// fresh schema migrated with migrations/postgres.
+
+func openPostgresPair(t *testing.T) (string, *service, *service) {
+	t.Helper()
+	ctx := context.Background()
+	dburl := dbtest.PostgresSchema(t)
+
+	admin, err := sql.Open("pgx", dburl)
+	require.NoError(t, err)
+	t.Cleanup(func() { admin.Close() })
+	require.NoError(t, dbtest.ApplyMigrations(ctx, admin, filepath.Join("..", "..", "migrations", "postgres"), true))
+
+	rw, err := openPostgres(dburl, DB_MODE_RW)
+	require.NoError(t, err)
+	t.Cleanup(func() { rw.db.Close() })
+	ro, err := openPostgres(dburl, DB_MODE_RO)
+	require.NoError(t, err)
+	t.Cleanup(func() { ro.db.Close() })
+	return dburl, ro, rw
+}
+
+// TestPostgresQueriesPrepare has the server parse and plan every generated
+// query, which catches unknown columns, functions and operators. Parameters it
+// cannot type while preparing are fine: they go out as literals.
+func TestPostgresQueriesPrepare(t *testing.T) {
+	dburl, _, _ := openPostgresPair(t)
+	ctx := context.Background()
+
+	conn, err := pgx.Connect(ctx, dburl)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close(ctx) })
+
+	booleans, err := booleanColumns(ctx, conn)
+	require.NoError(t, err)
+	assert.Contains(t, booleans, "is_active")
+	d := newPostgresDialect(booleans)
+
+	for name, query := range generatedQueries(t) {
+		rewritten, err := d.rewrite(query)
+		require.NoError(t, err, name)
+		_, err = conn.Prepare(ctx, "", rewritten)
+		if postgresErrorCode(err) == "42P18" {
+			continue
+		}
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestPostgresService(t *testing.T) {
+	_, ro, rw := openPostgresPair(t)
+	ctx := context.Background()
+
+	brandID, err := rw.GetQueries().CreateBrands(ctx, "Bosch")
+	require.NoError(t, err)
+	product, err := rw.GetQueries().CreateProducts(ctx, queries.CreateProductsParams{
+		Serial:                      "GSB-180",
+		Name:                        "Bosch Cordless Impact Drill",
+		BrandID:                     brandID,
+		Status:                      "ACTIVE",
+		UnitPriceWithoutVat:         100000,
+		UnitPriceWithVat:            112000,
+		UnitPriceWithoutVatCurrency: "PHP",
+		UnitPriceWithVatCurrency:    "PHP",
+	})
+	require.NoError(t, err)
+	assert.False(t, product.CreatedAt.IsZero())
+
+	_, err = rw.GetDB().ExecContext(ctx, "INSERT INTO tbl_product_images (product_id, path, thumbnail) VALUES (?, ?, ?)", product.ID, "a.webp", "a_96.webp")
+	require.NoError(t, err)
+
+	found, err := ro.GetQueries().GetProductsBySearchQueryPaginated(ctx, queries.GetProductsBySearchQueryPaginatedParams{
+		SearchTerm: "impact drill",
+		Name:       "impact drill",
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, product.ID, found[0].ID)
+	assert.Equal(t, "Bosch", found[0].BrandName)
+
+	assert.ErrorIs(t, ro.GetQueries().SoftDeleteBrand(ctx, brandID), errs.ErrDBReadOnly)
+	assert.ErrorIs(t, ro.RunInTx(ctx, nil), errs.ErrDBReadOnly)
+
+	rollback := errors.New("rollback")
+	err = rw.RunInTx(ctx, func(tx *sql.Tx, qtx *queries.Queries) error {
+		if _, err := qtx.CreateBrands(ctx, "Makita"); err != nil {
+			return err
+		}
+		return rollback
+	})
+	assert.ErrorIs(t, err, rollback)
+	_, err = ro.GetQueries().GetBrandsIDByName(ctx, "Makita")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,421 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: analytics.sql
+
+package queries
+
+import (
+	"context"
+)
+
+const getAnalyticsFunnel = `-- name: GetAnalyticsFunnel :one
+SELECT
+	(
+		SELECT COUNT(DISTINCT cl.checkout_id)
+		FROM tbl_checkout_lines cl
+		WHERE DATE(cl.created_at, '+8 hours') BETWEEN CAST(?1 AS TEXT) AND CAST(?2 AS TEXT)
+	) AS carts,
+	(
+		SELECT COUNT(DISTINCT cp.checkout_id)
+		FROM tbl_checkout_payments cp
+		WHERE DATE(cp.created_at, '+8 hours') BETWEEN CAST(?1 AS TEXT) AND CAST(?2 AS TEXT)
+	) AS finalized,
+	(
+		SELECT COUNT(DISTINCT o.checkout_id)
+		FROM tbl_orders o
+		WHERE o.paid_at IS NOT NULL
+		AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+		AND DATE(o.paid_at, '+8 hours') BETWEEN CAST(?1 AS TEXT) AND CAST(?2 AS TEXT)
+	) AS paid
+`
+
+type GetAnalyticsFunnelParams struct {
+	StartDate string
+	EndDate   string
+}
+
+type GetAnalyticsFunnelRow struct {
+	Carts     int64
+	Finalized int64
+	Paid      int64
+}
+
+// Carts are checkouts that had a product added in the range, finalized carts
+// are those that reached the payment gateway, and paid carts have a paid
+// order.
+func (q *Queries) GetAnalyticsFunnel(ctx context.Context, arg GetAnalyticsFunnelParams) (GetAnalyticsFunnelRow, error) {
+	row := q.db.QueryRowContext(ctx, getAnalyticsFunnel, arg.StartDate, arg.EndDate)
+	var i GetAnalyticsFunnelRow
+	err := row.Scan(&i.Carts, &i.Finalized, &i.Paid)
+	return i, err
+}
+
+const getAnalyticsLinkClicks = `-- name: GetAnalyticsLinkClicks :one
+SELECT
+	COUNT(*) AS clicks,
+	COUNT(DISTINCT ip_hash) AS unique_visitors
+FROM tbl_link_clicks
+WHERE is_bot = 0
+AND is_duplicate = 0
+AND DATE(clicked_at, '+8 hours') BETWEEN CAST(?1 AS TEXT) AND CAST(?2 AS TEXT)
+`
+
+type GetAnalyticsLinkClicksParams struct {
+	StartDate string
+	EndDate   string
+}
+
+type GetAnalyticsLinkClicksRow struct {
+	Clicks         int64
+	UniqueVisitors int64
+}
+
+func (q *Queries) GetAnalyticsLinkClicks(ctx context.Context, arg GetAnalyticsLinkClicksParams) (GetAnalyticsLinkClicksRow, error) {
+	row := q.db.QueryRowContext(ctx, getAnalyticsLinkClicks, arg.StartDate, arg.EndDate)
+	var i GetAnalyticsLinkClicksRow
+	err := row.Scan(&i.Clicks, &i.UniqueVisitors)
+	return i, err
+}
+
+const getAnalyticsPaymentMethods = `-- name: GetAnalyticsPaymentMethods :many
+SELECT
+	CAST(COALESCE(NULLIF(cp.payment_method_type, ''), 'UNKNOWN') AS TEXT) AS label,
+	COUNT(*) AS orders,
+	CAST(COALESCE(SUM(o.total_amount), 0) AS INTEGER) AS revenue
+FROM tbl_orders o
+LEFT JOIN tbl_checkout_payments cp ON cp.id = o.checkout_payment_id
+WHERE o.paid_at IS NOT NULL
+AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+AND DATE(o.paid_at, '+8 hours') BETWEEN CAST(?1 AS TEXT) AND CAST(?2 AS TEXT)
+GROUP BY label
+ORDER BY orders DESC
+`
+
+type GetAnalyticsPaymentMethodsParams struct {
+	StartDate string
+	EndDate   string
+}
+
+type GetAnalyticsPaymentMethodsRow struct {
+	Label   string
+	Orders  int64
+	Revenue int64
+}
+
+func (q *Queries) GetAnalyticsPaymentMethods(ctx context.Context, arg GetAnalyticsPaymentMethodsParams) ([]GetAnalyticsPaymentMethodsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAnalyticsPaymentMethods, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAnalyticsPaymentMethodsRow
+	for rows.Next() {
+		var i GetAnalyticsPaymentMethodsRow
+		if err := rows.Scan(&i.Label, &i.Orders, &i.Revenue); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAnalyticsRepeatCustomers = `-- name: GetAnalyticsRepeatCustomers :one
+WITH paid AS (
+	SELECT
+		COALESCE(CAST(customer_id AS TEXT), LOWER(customer_email)) AS customer_key,
+		DATE(paid_at, '+8 hours') AS paid_day
+	FROM tbl_orders
+	WHERE paid_at IS NOT NULL
+	AND status NOT IN ('CANCELLED', 'REFUNDED')
+	AND DATE(paid_at, '+8 hours') <= CAST(?1 AS TEXT)
+),
+per_customer AS (
+	SELECT
+		customer_key,
+		COUNT(*) AS orders,
+		MAX(CASE WHEN paid_day >= CAST(?2 AS TEXT) THEN 1 ELSE 0 END) AS in_range
+	FROM paid
+	GROUP BY customer_key
+)
+SELECT
+	CAST(COALESCE(SUM(in_range), 0) AS INTEGER) AS customers,
+	CAST(COALESCE(SUM(CASE WHEN in_range = 1 AND orders > 1 THEN 1 ELSE 0 END), 0) AS INTEGER) AS repeat_customers
+FROM per_customer
+`
+
+type GetAnalyticsRepeatCustomersParams struct {
+	EndDate   string
+	StartDate string
+}
+
+type GetAnalyticsRepeatCustomersRow struct {
+	Customers       int64
+	RepeatCustomers int64
+}
+
+// Customers who paid in the range, and how many of them have at least one
+// other paid order on or before the end of the range.
+func (q *Queries) GetAnalyticsRepeatCustomers(ctx context.Context, arg GetAnalyticsRepeatCustomersParams) (GetAnalyticsRepeatCustomersRow, error) {
+	row := q.db.QueryRowContext(ctx, getAnalyticsRepeatCustomers, arg.EndDate, arg.StartDate)
+	var i GetAnalyticsRepeatCustomersRow
+	err := row.Scan(&i.Customers, &i.RepeatCustomers)
+	return i, err
+}
+
+const getAnalyticsRevenueByBrand = `-- name: GetAnalyticsRevenueByBrand :many
+SELECT
+	CAST(COALESCE(b.name, 'Unknown') AS TEXT) AS label,
+	CAST(COALESCE(SUM(ol.quantity), 0) AS INTEGER) AS units,
+	CAST(COALESCE(SUM(ol.total_price), 0) AS INTEGER) AS revenue
+FROM tbl_order_lines ol
+JOIN tbl_orders o ON o.id = ol.order_id
+LEFT JOIN tbl_products p ON p.id = ol.product_id
+LEFT JOIN tbl_brands b ON b.id = p.brand_id
+WHERE o.paid_at IS NOT NULL
+AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+AND DATE(o.paid_at, '+8 hours') BETWEEN CAST(?1 AS TEXT) AND CAST(?2 AS TEXT)
+GROUP BY label
+ORDER BY revenue DESC
+`
+
+type GetAnalyticsRevenueByBrandParams struct {
+	StartDate string
+	EndDate   string
+}
+
+type GetAnalyticsRevenueByBrandRow struct {
+	Label   string
+	Units   int64
+	Revenue int64
+}
+
+func (q *Queries) GetAnalyticsRevenueByBrand(ctx context.Context, arg GetAnalyticsRevenueByBrandParams) ([]GetAnalyticsRevenueByBrandRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAnalyticsRevenueByBrand, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAnalyticsRevenueByBrandRow
+	for rows.Next() {
+		var i GetAnalyticsRevenueByBrandRow
+		if err := rows.Scan(&i.Label, &i.Units, &i.Revenue); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAnalyticsRevenueByCategory = `-- name: GetAnalyticsRevenueByCategory :many
+SELECT
+	CAST(COALESCE(pc.category, 'Uncategorized') AS TEXT) AS label,
+	CAST(COALESCE(SUM(ol.quantity), 0) AS INTEGER) AS units,
+	CAST(COALESCE(SUM(ol.total_price), 0) AS INTEGER) AS revenue
+FROM tbl_order_lines ol
+JOIN tbl_orders o ON o.id = ol.order_id
+LEFT JOIN tbl_products_categories ppc ON ppc.product_id = ol.product_id
+LEFT JOIN tbl_product_categories pc ON pc.id = ppc.category_id
+WHERE o.paid_at IS NOT NULL
+AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+AND DATE(o.paid_at, '+8 hours') BETWEEN CAST(?1 AS TEXT) AND CAST(?2 AS TEXT)
+GROUP BY label
+ORDER BY revenue DESC
+`
+
+type GetAnalyticsRevenueByCategoryParams struct {
+	StartDate string
+	EndDate   string
+}
+
+type GetAnalyticsRevenueByCategoryRow struct {
+	Label   string
+	Units   int64
+	Revenue int64
+}
+
+// A product can sit in several categories, so the category rows may add up
+// to more than the total revenue.
+func (q *Queries) GetAnalyticsRevenueByCategory(ctx context.Context, arg GetAnalyticsRevenueByCategoryParams) ([]GetAnalyticsRevenueByCategoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAnalyticsRevenueByCategory, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAnalyticsRevenueByCategoryRow
+	for rows.Next() {
+		var i GetAnalyticsRevenueByCategoryRow
+		if err := rows.Scan(&i.Label, &i.Units, &i.Revenue); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAnalyticsRevenuePerDay = `-- name: GetAnalyticsRevenuePerDay :many
+SELECT
+	CAST(DATE(paid_at, '+8 hours') AS TEXT) AS day,
+	COUNT(*) AS orders,
+	CAST(COALESCE(SUM(total_amount), 0) AS INTEGER) AS revenue
+FROM tbl_orders
+WHERE paid_at IS NOT NULL
+AND status NOT IN ('CANCELLED', 'REFUNDED')
+AND DATE(paid_at, '+8 hours') BETWEEN CAST(?1 AS TEXT) AND CAST(?2 AS TEXT)
+GROUP BY DATE(paid_at, '+8 hours')
+ORDER BY day
+`
+
+type GetAnalyticsRevenuePerDayParams struct {
+	StartDate string
+	EndDate   string
+}
+
+type GetAnalyticsRevenuePerDayRow struct {
+	Day     string
+	Orders  int64
+	Revenue int64
+}
+
+func (q *Queries) GetAnalyticsRevenuePerDay(ctx context.Context, arg GetAnalyticsRevenuePerDayParams) ([]GetAnalyticsRevenuePerDayRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAnalyticsRevenuePerDay, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAnalyticsRevenuePerDayRow
+	for rows.Next() {
+		var i GetAnalyticsRevenuePerDayRow
+		if err := rows.Scan(&i.Day, &i.Orders, &i.Revenue); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAnalyticsSalesTotals = `-- name: GetAnalyticsSalesTotals :one
+
+SELECT
+	COUNT(*) AS orders,
+	CAST(COALESCE(SUM(total_amount), 0) AS INTEGER) AS revenue,
+	COUNT(DISTINCT COALESCE(CAST(customer_id AS TEXT), LOWER(customer_email))) AS customers
+FROM tbl_orders
+WHERE paid_at IS NOT NULL
+AND status NOT IN ('CANCELLED', 'REFUNDED')
+AND DATE(paid_at, '+8 hours') BETWEEN CAST(?1 AS TEXT) AND CAST(?2 AS TEXT)
+`
+
+type GetAnalyticsSalesTotalsParams struct {
+	StartDate string
+	EndDate   string
+}
+
+type GetAnalyticsSalesTotalsRow struct {
+	Orders    int64
+	Revenue   int64
+	Customers int64
+}
+
+// Analytics count an order once it is paid and not cancelled or refunded,
+// bucketed by the date it was paid. Timestamps are stored in UTC and shifted
+// by '+8 hours' so the days match the PH dates picked in the admin.
+func (q *Queries) GetAnalyticsSalesTotals(ctx context.Context, arg GetAnalyticsSalesTotalsParams) (GetAnalyticsSalesTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getAnalyticsSalesTotals, arg.StartDate, arg.EndDate)
+	var i GetAnalyticsSalesTotalsRow
+	err := row.Scan(&i.Orders, &i.Revenue, &i.Customers)
+	return i, err
+}
+
+const getAnalyticsTopProducts = `-- name: GetAnalyticsTopProducts :many
+SELECT
+	ol.serial,
+	ol.name,
+	CAST(COALESCE(b.name, '') AS TEXT) AS brand,
+	CAST(COALESCE(SUM(ol.quantity), 0) AS INTEGER) AS units,
+	CAST(COALESCE(SUM(ol.total_price), 0) AS INTEGER) AS revenue
+FROM tbl_order_lines ol
+JOIN tbl_orders o ON o.id = ol.order_id
+LEFT JOIN tbl_products p ON p.id = ol.product_id
+LEFT JOIN tbl_brands b ON b.id = p.brand_id
+WHERE o.paid_at IS NOT NULL
+AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+AND DATE(o.paid_at, '+8 hours') BETWEEN CAST(?1 AS TEXT) AND CAST(?2 AS TEXT)
+AND (CAST(?3 AS INTEGER) = 0 OR p.brand_id = CAST(?3 AS INTEGER))
+GROUP BY ol.serial, ol.name, brand
+ORDER BY units DESC, revenue DESC
+LIMIT ?4
+`
+
+type GetAnalyticsTopProductsParams struct {
+	StartDate string
+	EndDate   string
+	BrandID   int64
+	Limit     int64
+}
+
+type GetAnalyticsTopProductsRow struct {
+	Serial  string
+	Name    string
+	Brand   string
+	Units   int64
+	Revenue int64
+}
+
+func (q *Queries) GetAnalyticsTopProducts(ctx context.Context, arg GetAnalyticsTopProductsParams) ([]GetAnalyticsTopProductsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAnalyticsTopProducts,
+		arg.StartDate,
+		arg.EndDate,
+		arg.BrandID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAnalyticsTopProductsRow
+	for rows.Next() {
+		var i GetAnalyticsTopProductsRow
+		if err := rows.Scan(
+			&i.Serial,
+			&i.Name,
+			&i.Brand,
+			&i.Units,
+			&i.Revenue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
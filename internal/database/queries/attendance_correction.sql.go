@@ -0,0 +1,301 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: attendance_correction.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const approveStaffAttendanceCorrection = `-- name: ApproveStaffAttendanceCorrection :execrows
+UPDATE tbl_staff_attendance_corrections
+SET
+	status = 'APPROVED',
+	original_time_in = ?1,
+	original_time_out = ?2,
+	original_lunch_break_in = ?3,
+	original_lunch_break_out = ?4,
+	decided_by = ?5,
+	decided_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE id = ?6 AND status = 'PENDING'
+`
+
+type ApproveStaffAttendanceCorrectionParams struct {
+	OriginalTimeIn        sql.NullString
+	OriginalTimeOut       sql.NullString
+	OriginalLunchBreakIn  sql.NullString
+	OriginalLunchBreakOut sql.NullString
+	DecidedBy             sql.NullInt64
+	ID                    int64
+}
+
+func (q *Queries) ApproveStaffAttendanceCorrection(ctx context.Context, arg ApproveStaffAttendanceCorrectionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, approveStaffAttendanceCorrection,
+		arg.OriginalTimeIn,
+		arg.OriginalTimeOut,
+		arg.OriginalLunchBreakIn,
+		arg.OriginalLunchBreakOut,
+		arg.DecidedBy,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createStaffAttendanceCorrection = `-- name: CreateStaffAttendanceCorrection :one
+INSERT INTO tbl_staff_attendance_corrections (
+	staff_id,
+	for_date,
+	time_in,
+	time_out,
+	lunch_break_in,
+	lunch_break_out,
+	reason,
+	status,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?, 'PENDING',
+	DATETIME('now'),
+	DATETIME('now')
+) RETURNING id
+`
+
+type CreateStaffAttendanceCorrectionParams struct {
+	StaffID       int64
+	ForDate       string
+	TimeIn        sql.NullString
+	TimeOut       sql.NullString
+	LunchBreakIn  sql.NullString
+	LunchBreakOut sql.NullString
+	Reason        string
+}
+
+func (q *Queries) CreateStaffAttendanceCorrection(ctx context.Context, arg CreateStaffAttendanceCorrectionParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createStaffAttendanceCorrection,
+		arg.StaffID,
+		arg.ForDate,
+		arg.TimeIn,
+		arg.TimeOut,
+		arg.LunchBreakIn,
+		arg.LunchBreakOut,
+		arg.Reason,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getApprovedStaffAttendanceCorrectionsByDateRange = `-- name: GetApprovedStaffAttendanceCorrectionsByDateRange :many
+SELECT DISTINCT staff_id, for_date
+FROM tbl_staff_attendance_corrections
+WHERE
+	status = 'APPROVED'
+	AND for_date >= ?1
+	AND for_date <= ?2
+`
+
+type GetApprovedStaffAttendanceCorrectionsByDateRangeParams struct {
+	StartDate string
+	EndDate   string
+}
+
+type GetApprovedStaffAttendanceCorrectionsByDateRangeRow struct {
+	StaffID int64
+	ForDate string
+}
+
+func (q *Queries) GetApprovedStaffAttendanceCorrectionsByDateRange(ctx context.Context, arg GetApprovedStaffAttendanceCorrectionsByDateRangeParams) ([]GetApprovedStaffAttendanceCorrectionsByDateRangeRow, error) {
+	rows, err := q.db.QueryContext(ctx, getApprovedStaffAttendanceCorrectionsByDateRange, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetApprovedStaffAttendanceCorrectionsByDateRangeRow
+	for rows.Next() {
+		var i GetApprovedStaffAttendanceCorrectionsByDateRangeRow
+		if err := rows.Scan(&i.StaffID, &i.ForDate); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingStaffAttendanceCorrectionForDate = `-- name: GetPendingStaffAttendanceCorrectionForDate :one
+SELECT id
+FROM tbl_staff_attendance_corrections
+WHERE
+	status = 'PENDING'
+	AND staff_id = ?1
+	AND for_date = ?2
+LIMIT 1
+`
+
+type GetPendingStaffAttendanceCorrectionForDateParams struct {
+	StaffID int64
+	ForDate string
+}
+
+func (q *Queries) GetPendingStaffAttendanceCorrectionForDate(ctx context.Context, arg GetPendingStaffAttendanceCorrectionForDateParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getPendingStaffAttendanceCorrectionForDate, arg.StaffID, arg.ForDate)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getStaffAttendanceCorrectionByID = `-- name: GetStaffAttendanceCorrectionByID :one
+SELECT id, staff_id, for_date, time_in, time_out, lunch_break_in, lunch_break_out, reason, status, original_time_in, original_time_out, original_lunch_break_in, original_lunch_break_out, decided_by, decided_at, created_at, updated_at
+FROM tbl_staff_attendance_corrections
+WHERE id = ?
+LIMIT 1
+`
+
+func (q *Queries) GetStaffAttendanceCorrectionByID(ctx context.Context, id int64) (TblStaffAttendanceCorrection, error) {
+	row := q.db.QueryRowContext(ctx, getStaffAttendanceCorrectionByID, id)
+	var i TblStaffAttendanceCorrection
+	err := row.Scan(
+		&i.ID,
+		&i.StaffID,
+		&i.ForDate,
+		&i.TimeIn,
+		&i.TimeOut,
+		&i.LunchBreakIn,
+		&i.LunchBreakOut,
+		&i.Reason,
+		&i.Status,
+		&i.OriginalTimeIn,
+		&i.OriginalTimeOut,
+		&i.OriginalLunchBreakIn,
+		&i.OriginalLunchBreakOut,
+		&i.DecidedBy,
+		&i.DecidedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getStaffAttendanceCorrections = `-- name: GetStaffAttendanceCorrections :many
+SELECT
+	tbl_staff_attendance_corrections.id, tbl_staff_attendance_corrections.staff_id, tbl_staff_attendance_corrections.for_date, tbl_staff_attendance_corrections.time_in, tbl_staff_attendance_corrections.time_out, tbl_staff_attendance_corrections.lunch_break_in, tbl_staff_attendance_corrections.lunch_break_out, tbl_staff_attendance_corrections.reason, tbl_staff_attendance_corrections.status, tbl_staff_attendance_corrections.original_time_in, tbl_staff_attendance_corrections.original_time_out, tbl_staff_attendance_corrections.original_lunch_break_in, tbl_staff_attendance_corrections.original_lunch_break_out, tbl_staff_attendance_corrections.decided_by, tbl_staff_attendance_corrections.decided_at, tbl_staff_attendance_corrections.created_at, tbl_staff_attendance_corrections.updated_at,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name
+FROM tbl_staff_attendance_corrections
+INNER JOIN tbl_staffs ON tbl_staffs.id = tbl_staff_attendance_corrections.staff_id
+WHERE
+	(tbl_staff_attendance_corrections.staff_id = ?1 OR ?1 = 0)
+	AND (tbl_staff_attendance_corrections.status = ?2 OR ?2 = '')
+ORDER BY tbl_staff_attendance_corrections.created_at DESC, tbl_staff_attendance_corrections.id DESC
+LIMIT ?3
+`
+
+type GetStaffAttendanceCorrectionsParams struct {
+	StaffID int64
+	Status  string
+	Limit   int64
+}
+
+type GetStaffAttendanceCorrectionsRow struct {
+	ID                    int64
+	StaffID               int64
+	ForDate               string
+	TimeIn                sql.NullString
+	TimeOut               sql.NullString
+	LunchBreakIn          sql.NullString
+	LunchBreakOut         sql.NullString
+	Reason                string
+	Status                string
+	OriginalTimeIn        sql.NullString
+	OriginalTimeOut       sql.NullString
+	OriginalLunchBreakIn  sql.NullString
+	OriginalLunchBreakOut sql.NullString
+	DecidedBy             sql.NullInt64
+	DecidedAt             sql.NullTime
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	FirstName             string
+	MiddleName            sql.NullString
+	LastName              string
+}
+
+func (q *Queries) GetStaffAttendanceCorrections(ctx context.Context, arg GetStaffAttendanceCorrectionsParams) ([]GetStaffAttendanceCorrectionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffAttendanceCorrections, arg.StaffID, arg.Status, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStaffAttendanceCorrectionsRow
+	for rows.Next() {
+		var i GetStaffAttendanceCorrectionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StaffID,
+			&i.ForDate,
+			&i.TimeIn,
+			&i.TimeOut,
+			&i.LunchBreakIn,
+			&i.LunchBreakOut,
+			&i.Reason,
+			&i.Status,
+			&i.OriginalTimeIn,
+			&i.OriginalTimeOut,
+			&i.OriginalLunchBreakIn,
+			&i.OriginalLunchBreakOut,
+			&i.DecidedBy,
+			&i.DecidedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FirstName,
+			&i.MiddleName,
+			&i.LastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rejectStaffAttendanceCorrection = `-- name: RejectStaffAttendanceCorrection :execrows
+UPDATE tbl_staff_attendance_corrections
+SET
+	status = 'REJECTED',
+	decided_by = ?,
+	decided_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE id = ? AND status = 'PENDING'
+`
+
+type RejectStaffAttendanceCorrectionParams struct {
+	DecidedBy sql.NullInt64
+	ID        int64
+}
+
+func (q *Queries) RejectStaffAttendanceCorrection(ctx context.Context, arg RejectStaffAttendanceCorrectionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, rejectStaffAttendanceCorrection, arg.DecidedBy, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -63,7 +63,7 @@ INSERT INTO tbl_checkouts(
 	updated_at
 ) VALUES (
 	?, datetime('now'), datetime('now')
-) RETURNING id, session_id, created_at, updated_at, status
+) RETURNING id, session_id, created_at, updated_at, status, customer_id, email, reminder_sent_at, recovery_token_hash, recovered_at
 `
 
 func (q *Queries) CreateCheckout(ctx context.Context, sessionID string) (TblCheckout, error) {
@@ -75,6 +75,11 @@ func (q *Queries) CreateCheckout(ctx context.Context, sessionID string) (TblChec
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Status,
+		&i.CustomerID,
+		&i.Email,
+		&i.ReminderSentAt,
+		&i.RecoveryTokenHash,
+		&i.RecoveredAt,
 	)
 	return i, err
 }
@@ -233,6 +238,108 @@ func (q *Queries) DeleteCheckoutLineByID(ctx context.Context, id int64) error {
 	return err
 }
 
+const detachCheckoutsBySessionID = `-- name: DetachCheckoutsBySessionID :exec
+UPDATE tbl_checkouts
+SET session_id = '',
+	status = CASE WHEN status = 'PENDING' THEN 'CANCELLED' ELSE status END,
+	updated_at = DATETIME('now')
+WHERE session_id = ?1
+	AND session_id != ''
+	AND id != ?2
+`
+
+type DetachCheckoutsBySessionIDParams struct {
+	SessionID string
+	KeepID    int64
+}
+
+func (q *Queries) DetachCheckoutsBySessionID(ctx context.Context, arg DetachCheckoutsBySessionIDParams) error {
+	_, err := q.db.ExecContext(ctx, detachCheckoutsBySessionID, arg.SessionID, arg.KeepID)
+	return err
+}
+
+const getAbandonedCheckouts = `-- name: GetAbandonedCheckouts :many
+SELECT
+	tbl_checkouts.id,
+	tbl_checkouts.session_id,
+	CAST(COALESCE(tbl_customers.email, tbl_checkouts.email, '') AS TEXT) AS email
+FROM tbl_checkouts
+LEFT JOIN tbl_customers ON tbl_customers.id = tbl_checkouts.customer_id
+WHERE tbl_checkouts.status = 'PENDING'
+	AND tbl_checkouts.reminder_sent_at IS NULL
+	AND (tbl_checkouts.customer_id IS NOT NULL OR COALESCE(tbl_checkouts.email, '') != '')
+	AND tbl_checkouts.updated_at <= ?1
+	AND EXISTS (
+		SELECT 1 FROM tbl_checkout_lines
+		WHERE tbl_checkout_lines.checkout_id = tbl_checkouts.id
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM tbl_checkout_lines
+		WHERE tbl_checkout_lines.checkout_id = tbl_checkouts.id
+			AND tbl_checkout_lines.updated_at > ?1
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM tbl_orders
+		WHERE tbl_orders.checkout_id = tbl_checkouts.id
+	)
+ORDER BY tbl_checkouts.updated_at ASC
+LIMIT ?2
+`
+
+type GetAbandonedCheckoutsParams struct {
+	IdleBefore time.Time
+	Limit      int64
+}
+
+type GetAbandonedCheckoutsRow struct {
+	ID        int64
+	SessionID string
+	Email     string
+}
+
+func (q *Queries) GetAbandonedCheckouts(ctx context.Context, arg GetAbandonedCheckoutsParams) ([]GetAbandonedCheckoutsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAbandonedCheckouts, arg.IdleBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAbandonedCheckoutsRow
+	for rows.Next() {
+		var i GetAbandonedCheckoutsRow
+		if err := rows.Scan(&i.ID, &i.SessionID, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCheckoutByRecoveryTokenHash = `-- name: GetCheckoutByRecoveryTokenHash :one
+SELECT id, session_id
+FROM tbl_checkouts
+WHERE recovery_token_hash = ?
+	AND status = 'PENDING'
+LIMIT 1
+`
+
+type GetCheckoutByRecoveryTokenHashRow struct {
+	ID        int64
+	SessionID string
+}
+
+func (q *Queries) GetCheckoutByRecoveryTokenHash(ctx context.Context, recoveryTokenHash sql.NullString) (GetCheckoutByRecoveryTokenHashRow, error) {
+	row := q.db.QueryRowContext(ctx, getCheckoutByRecoveryTokenHash, recoveryTokenHash)
+	var i GetCheckoutByRecoveryTokenHashRow
+	err := row.Scan(&i.ID, &i.SessionID)
+	return i, err
+}
+
 const getCheckoutIDBySessionID = `-- name: GetCheckoutIDBySessionID :one
 SELECT id FROM tbl_checkouts
 WHERE session_id = ?
@@ -484,6 +591,77 @@ func (q *Queries) GetCheckoutPaymentByReferenceNumber(ctx context.Context, refer
 	return i, err
 }
 
+const getPendingCheckoutByCustomerID = `-- name: GetPendingCheckoutByCustomerID :one
+SELECT tbl_checkouts.id, tbl_checkouts.session_id
+FROM tbl_checkouts
+WHERE tbl_checkouts.customer_id = ?
+	AND tbl_checkouts.status = 'PENDING'
+	AND NOT EXISTS (
+		SELECT 1 FROM tbl_orders
+		WHERE tbl_orders.checkout_id = tbl_checkouts.id
+	)
+ORDER BY tbl_checkouts.updated_at DESC, tbl_checkouts.id DESC
+LIMIT 1
+`
+
+type GetPendingCheckoutByCustomerIDRow struct {
+	ID        int64
+	SessionID string
+}
+
+func (q *Queries) GetPendingCheckoutByCustomerID(ctx context.Context, customerID sql.NullInt64) (GetPendingCheckoutByCustomerIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getPendingCheckoutByCustomerID, customerID)
+	var i GetPendingCheckoutByCustomerIDRow
+	err := row.Scan(&i.ID, &i.SessionID)
+	return i, err
+}
+
+const markCheckoutRecovered = `-- name: MarkCheckoutRecovered :execrows
+UPDATE tbl_checkouts
+SET recovered_at = DATETIME('now')
+WHERE id = ?
+	AND reminder_sent_at IS NOT NULL
+	AND recovered_at IS NULL
+`
+
+func (q *Queries) MarkCheckoutRecovered(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markCheckoutRecovered, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markCheckoutReminderSent = `-- name: MarkCheckoutReminderSent :exec
+UPDATE tbl_checkouts
+SET reminder_sent_at = DATETIME('now'),
+	recovery_token_hash = ?
+WHERE id = ?
+`
+
+type MarkCheckoutReminderSentParams struct {
+	RecoveryTokenHash sql.NullString
+	ID                int64
+}
+
+func (q *Queries) MarkCheckoutReminderSent(ctx context.Context, arg MarkCheckoutReminderSentParams) error {
+	_, err := q.db.ExecContext(ctx, markCheckoutReminderSent, arg.RecoveryTokenHash, arg.ID)
+	return err
+}
+
+const releaseCustomerCheckoutBySessionID = `-- name: ReleaseCustomerCheckoutBySessionID :exec
+UPDATE tbl_checkouts
+SET session_id = ''
+WHERE session_id = ?
+	AND customer_id IS NOT NULL
+	AND status = 'PENDING'
+`
+
+func (q *Queries) ReleaseCustomerCheckoutBySessionID(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, releaseCustomerCheckoutBySessionID, sessionID)
+	return err
+}
+
 const removeItemInCheckoutLinesByID = `-- name: RemoveItemInCheckoutLinesByID :exec
 DELETE FROM tbl_checkout_lines
 WHERE checkout_id = ?
@@ -511,6 +689,57 @@ func (q *Queries) RemoveItemInCheckoutLinesByID(ctx context.Context, arg RemoveI
 	return err
 }
 
+const setCheckoutCustomerID = `-- name: SetCheckoutCustomerID :exec
+UPDATE tbl_checkouts
+SET customer_id = ?,
+	updated_at = DATETIME('now')
+WHERE id = ? AND status = 'PENDING'
+`
+
+type SetCheckoutCustomerIDParams struct {
+	CustomerID sql.NullInt64
+	ID         int64
+}
+
+func (q *Queries) SetCheckoutCustomerID(ctx context.Context, arg SetCheckoutCustomerIDParams) error {
+	_, err := q.db.ExecContext(ctx, setCheckoutCustomerID, arg.CustomerID, arg.ID)
+	return err
+}
+
+const setCheckoutCustomerIDBySessionID = `-- name: SetCheckoutCustomerIDBySessionID :exec
+UPDATE tbl_checkouts
+SET customer_id = ?,
+	updated_at = DATETIME('now')
+WHERE session_id = ? AND status = 'PENDING'
+`
+
+type SetCheckoutCustomerIDBySessionIDParams struct {
+	CustomerID sql.NullInt64
+	SessionID  string
+}
+
+func (q *Queries) SetCheckoutCustomerIDBySessionID(ctx context.Context, arg SetCheckoutCustomerIDBySessionIDParams) error {
+	_, err := q.db.ExecContext(ctx, setCheckoutCustomerIDBySessionID, arg.CustomerID, arg.SessionID)
+	return err
+}
+
+const setCheckoutEmailBySessionID = `-- name: SetCheckoutEmailBySessionID :exec
+UPDATE tbl_checkouts
+SET email = ?,
+	updated_at = DATETIME('now')
+WHERE session_id = ? AND status = 'PENDING'
+`
+
+type SetCheckoutEmailBySessionIDParams struct {
+	Email     sql.NullString
+	SessionID string
+}
+
+func (q *Queries) SetCheckoutEmailBySessionID(ctx context.Context, arg SetCheckoutEmailBySessionIDParams) error {
+	_, err := q.db.ExecContext(ctx, setCheckoutEmailBySessionID, arg.Email, arg.SessionID)
+	return err
+}
+
 const updateCheckoutLineQtyByID = `-- name: UpdateCheckoutLineQtyByID :one
 UPDATE tbl_checkout_lines
 SET quantity = MIN(99, MAX(1, quantity + ?)), updated_at = datetime('now')
@@ -569,12 +798,29 @@ func (q *Queries) UpdateCheckoutPaymentOnSuccess(ctx context.Context, arg Update
 	return i, err
 }
 
+const updateCheckoutSessionID = `-- name: UpdateCheckoutSessionID :exec
+UPDATE tbl_checkouts
+SET session_id = ?,
+	updated_at = DATETIME('now')
+WHERE id = ?
+`
+
+type UpdateCheckoutSessionIDParams struct {
+	SessionID string
+	ID        int64
+}
+
+func (q *Queries) UpdateCheckoutSessionID(ctx context.Context, arg UpdateCheckoutSessionIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateCheckoutSessionID, arg.SessionID, arg.ID)
+	return err
+}
+
 const updateCheckoutStatus = `-- name: UpdateCheckoutStatus :one
 UPDATE tbl_checkouts
 SET status = ?,
 	updated_at = DATETIME('now')
 WHERE id = ?
-RETURNING id, session_id, created_at, updated_at, status
+RETURNING id, session_id, created_at, updated_at, status, customer_id, email, reminder_sent_at, recovery_token_hash, recovered_at
 `
 
 type UpdateCheckoutStatusParams struct {
@@ -591,6 +837,11 @@ func (q *Queries) UpdateCheckoutStatus(ctx context.Context, arg UpdateCheckoutSt
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Status,
+		&i.CustomerID,
+		&i.Email,
+		&i.ReminderSentAt,
+		&i.RecoveryTokenHash,
+		&i.RecoveredAt,
 	)
 	return i, err
 }
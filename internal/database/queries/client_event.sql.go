@@ -0,0 +1,254 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: client_event.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createClientEvent = `-- name: CreateClientEvent :exec
+INSERT INTO tbl_client_events (
+	event,
+	value,
+	session_hash,
+	customer_id,
+	product_id,
+	result_count,
+	created_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, CAST(? AS TEXT)
+)
+`
+
+type CreateClientEventParams struct {
+	Event       string
+	Value       string
+	SessionHash string
+	CustomerID  sql.NullInt64
+	ProductID   sql.NullInt64
+	ResultCount sql.NullInt64
+	CreatedAt   string
+}
+
+func (q *Queries) CreateClientEvent(ctx context.Context, arg CreateClientEventParams) error {
+	_, err := q.db.ExecContext(ctx, createClientEvent,
+		arg.Event,
+		arg.Value,
+		arg.SessionHash,
+		arg.CustomerID,
+		arg.ProductID,
+		arg.ResultCount,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const deleteClientEventsBefore = `-- name: DeleteClientEventsBefore :execrows
+DELETE FROM tbl_client_events
+WHERE DATE(created_at) < CAST(?1 AS TEXT)
+`
+
+func (q *Queries) DeleteClientEventsBefore(ctx context.Context, before string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteClientEventsBefore, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getLatestClientEventRollupDay = `-- name: GetLatestClientEventRollupDay :one
+SELECT CAST(COALESCE(MAX(day), '') AS TEXT) AS day
+FROM tbl_client_event_rollups
+`
+
+func (q *Queries) GetLatestClientEventRollupDay(ctx context.Context) (string, error) {
+	row := q.db.QueryRowContext(ctx, getLatestClientEventRollupDay)
+	var day string
+	err := row.Scan(&day)
+	return day, err
+}
+
+const getLowClickSearches = `-- name: GetLowClickSearches :many
+WITH latest AS (
+	SELECT COALESCE(MAX(day), '') AS day FROM tbl_client_event_rollups
+), events AS (
+	SELECT LOWER(TRIM(r.value)) AS term, r.event AS event, r.hits - r.zero_results AS hits
+	FROM tbl_client_event_rollups r
+	WHERE r.event IN (CAST(?1 AS TEXT), CAST(?2 AS TEXT))
+	AND r.day BETWEEN CAST(?5 AS TEXT) AND CAST(?6 AS TEXT)
+	UNION ALL
+	SELECT
+		LOWER(TRIM(e.value)) AS term,
+		e.event AS event,
+		CASE WHEN e.result_count = 0 THEN 0 ELSE 1 END AS hits
+	FROM tbl_client_events e, latest
+	WHERE e.event IN (CAST(?1 AS TEXT), CAST(?2 AS TEXT))
+	AND DATE(e.created_at) > latest.day
+	AND DATE(e.created_at) BETWEEN CAST(?5 AS TEXT) AND CAST(?6 AS TEXT)
+)
+SELECT
+	CAST(term AS TEXT) AS term,
+	CAST(SUM(CASE WHEN event = CAST(?1 AS TEXT) THEN hits ELSE 0 END) AS INTEGER) AS searches,
+	CAST(SUM(CASE WHEN event = CAST(?2 AS TEXT) THEN hits ELSE 0 END) AS INTEGER) AS clicks
+FROM events
+WHERE term != ''
+GROUP BY term
+HAVING searches >= CAST(?3 AS INTEGER)
+ORDER BY CAST(clicks AS REAL) / searches ASC, searches DESC
+LIMIT ?4
+`
+
+type GetLowClickSearchesParams struct {
+	SearchEvent string
+	ClickEvent  string
+	MinSearches int64
+	Limit       int64
+	StartDate   string
+	EndDate     string
+}
+
+type GetLowClickSearchesRow struct {
+	Term     string
+	Searches int64
+	Clicks   int64
+}
+
+// GetLowClickSearches compares searches that returned products against the
+// clicks on those results. Terms with fewer than min_searches are left out so
+// a single unlucky search does not top the list.
+func (q *Queries) GetLowClickSearches(ctx context.Context, arg GetLowClickSearchesParams) ([]GetLowClickSearchesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getLowClickSearches,
+		arg.SearchEvent,
+		arg.ClickEvent,
+		arg.MinSearches,
+		arg.Limit,
+		arg.StartDate,
+		arg.EndDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLowClickSearchesRow
+	for rows.Next() {
+		var i GetLowClickSearchesRow
+		if err := rows.Scan(&i.Term, &i.Searches, &i.Clicks); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getZeroResultSearches = `-- name: GetZeroResultSearches :many
+WITH latest AS (
+	SELECT COALESCE(MAX(day), '') AS day FROM tbl_client_event_rollups
+), searches AS (
+	SELECT LOWER(TRIM(r.value)) AS term, r.zero_results AS searches, r.day AS day
+	FROM tbl_client_event_rollups r
+	WHERE r.event = CAST(?2 AS TEXT)
+	AND r.zero_results > 0
+	AND r.day BETWEEN CAST(?3 AS TEXT) AND CAST(?4 AS TEXT)
+	UNION ALL
+	SELECT LOWER(TRIM(e.value)) AS term, 1 AS searches, DATE(e.created_at) AS day
+	FROM tbl_client_events e, latest
+	WHERE e.event = CAST(?2 AS TEXT)
+	AND e.result_count = 0
+	AND DATE(e.created_at) > latest.day
+	AND DATE(e.created_at) BETWEEN CAST(?3 AS TEXT) AND CAST(?4 AS TEXT)
+)
+SELECT
+	CAST(term AS TEXT) AS term,
+	CAST(SUM(searches) AS INTEGER) AS searches,
+	CAST(MAX(day) AS TEXT) AS last_searched_on
+FROM searches
+WHERE term != ''
+GROUP BY term
+ORDER BY searches DESC, last_searched_on DESC
+LIMIT ?1
+`
+
+type GetZeroResultSearchesParams struct {
+	Limit     int64
+	Event     string
+	StartDate string
+	EndDate   string
+}
+
+type GetZeroResultSearchesRow struct {
+	Term           string
+	Searches       int64
+	LastSearchedOn string
+}
+
+// GetZeroResultSearches reads rolled up days from the rollups and the days
+// after the latest rollup from the raw events.
+func (q *Queries) GetZeroResultSearches(ctx context.Context, arg GetZeroResultSearchesParams) ([]GetZeroResultSearchesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getZeroResultSearches,
+		arg.Limit,
+		arg.Event,
+		arg.StartDate,
+		arg.EndDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetZeroResultSearchesRow
+	for rows.Next() {
+		var i GetZeroResultSearchesRow
+		if err := rows.Scan(&i.Term, &i.Searches, &i.LastSearchedOn); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rollupClientEvents = `-- name: RollupClientEvents :exec
+INSERT INTO tbl_client_event_rollups (day, event, value, hits, sessions, zero_results)
+SELECT
+	DATE(created_at) AS day,
+	event,
+	value,
+	COUNT(*) AS hits,
+	COUNT(DISTINCT NULLIF(session_hash, '')) AS sessions,
+	SUM(CASE WHEN result_count = 0 THEN 1 ELSE 0 END) AS zero_results
+FROM tbl_client_events
+WHERE DATE(created_at) >= CAST(?1 AS TEXT)
+AND DATE(created_at) < CAST(?2 AS TEXT)
+GROUP BY DATE(created_at), event, value
+ON CONFLICT (day, event, value) DO UPDATE SET
+	hits = excluded.hits,
+	sessions = excluded.sessions,
+	zero_results = excluded.zero_results
+`
+
+type RollupClientEventsParams struct {
+	Since  string
+	Before string
+}
+
+// RollupClientEvents recomputes the daily rollups from the raw events so a
+// day that was rolled up while events were still being flushed is corrected
+// on the next run.
+func (q *Queries) RollupClientEvents(ctx context.Context, arg RollupClientEventsParams) error {
+	_, err := q.db.ExecContext(ctx, rollupClientEvents, arg.Since, arg.Before)
+	return err
+}
@@ -11,7 +11,7 @@ import (
 )
 
 const getEmailJobByID = `-- name: GetEmailJobByID :one
-SELECT id, queue_id, recipient, cc, subject, template_name, order_id, otp_code, checkout_payment_id, created_at, updated_at, memo_id FROM tbl_email_jobs
+SELECT id, queue_id, recipient, cc, subject, template_name, order_id, otp_code, checkout_payment_id, created_at, updated_at, memo_id, product_id, checkout_id FROM tbl_email_jobs
 WHERE id = ?
 LIMIT 1
 `
@@ -32,12 +32,14 @@ func (q *Queries) GetEmailJobByID(ctx context.Context, id int64) (TblEmailJob, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.MemoID,
+		&i.ProductID,
+		&i.CheckoutID,
 	)
 	return i, err
 }
 
 const getEmailJobByQueueID = `-- name: GetEmailJobByQueueID :one
-SELECT id, queue_id, recipient, cc, subject, template_name, order_id, otp_code, checkout_payment_id, created_at, updated_at, memo_id FROM tbl_email_jobs
+SELECT id, queue_id, recipient, cc, subject, template_name, order_id, otp_code, checkout_payment_id, created_at, updated_at, memo_id, product_id, checkout_id FROM tbl_email_jobs
 WHERE queue_id = ?
 LIMIT 1
 `
@@ -58,12 +60,14 @@ func (q *Queries) GetEmailJobByQueueID(ctx context.Context, queueID string) (Tbl
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.MemoID,
+		&i.ProductID,
+		&i.CheckoutID,
 	)
 	return i, err
 }
 
 const getEmailJobsByCheckoutPaymentID = `-- name: GetEmailJobsByCheckoutPaymentID :many
-SELECT id, queue_id, recipient, cc, subject, template_name, order_id, otp_code, checkout_payment_id, created_at, updated_at, memo_id FROM tbl_email_jobs
+SELECT id, queue_id, recipient, cc, subject, template_name, order_id, otp_code, checkout_payment_id, created_at, updated_at, memo_id, product_id, checkout_id FROM tbl_email_jobs
 WHERE checkout_payment_id = ?
 ORDER BY created_at DESC
 `
@@ -90,6 +94,8 @@ func (q *Queries) GetEmailJobsByCheckoutPaymentID(ctx context.Context, checkoutP
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.MemoID,
+			&i.ProductID,
+			&i.CheckoutID,
 		); err != nil {
 			return nil, err
 		}
@@ -105,7 +111,7 @@ func (q *Queries) GetEmailJobsByCheckoutPaymentID(ctx context.Context, checkoutP
 }
 
 const getEmailJobsByOrderID = `-- name: GetEmailJobsByOrderID :many
-SELECT id, queue_id, recipient, cc, subject, template_name, order_id, otp_code, checkout_payment_id, created_at, updated_at, memo_id FROM tbl_email_jobs
+SELECT id, queue_id, recipient, cc, subject, template_name, order_id, otp_code, checkout_payment_id, created_at, updated_at, memo_id, product_id, checkout_id FROM tbl_email_jobs
 WHERE order_id = ?
 ORDER BY created_at DESC
 `
@@ -132,6 +138,8 @@ func (q *Queries) GetEmailJobsByOrderID(ctx context.Context, orderID sql.NullInt
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.MemoID,
+			&i.ProductID,
+			&i.CheckoutID,
 		); err != nil {
 			return nil, err
 		}
@@ -157,13 +165,15 @@ INSERT INTO tbl_email_jobs(
 	checkout_payment_id,
 	otp_code,
 	memo_id,
+	product_id,
+	checkout_id,
 	created_at,
 	updated_at
 ) VALUES (
-	?, ?, ?, ?, ?, ?, ?, ?, ?,
+	?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
 	datetime('now'),
 	datetime('now')
-) RETURNING id, queue_id, recipient, cc, subject, template_name, order_id, otp_code, checkout_payment_id, created_at, updated_at, memo_id
+) RETURNING id, queue_id, recipient, cc, subject, template_name, order_id, otp_code, checkout_payment_id, created_at, updated_at, memo_id, product_id, checkout_id
 `
 
 type InsertEmailJobParams struct {
@@ -176,6 +186,8 @@ type InsertEmailJobParams struct {
 	CheckoutPaymentID sql.NullString
 	OtpCode           sql.NullString
 	MemoID            sql.NullInt64
+	ProductID         sql.NullInt64
+	CheckoutID        sql.NullInt64
 }
 
 func (q *Queries) InsertEmailJob(ctx context.Context, arg InsertEmailJobParams) (TblEmailJob, error) {
@@ -189,6 +201,8 @@ func (q *Queries) InsertEmailJob(ctx context.Context, arg InsertEmailJobParams)
 		arg.CheckoutPaymentID,
 		arg.OtpCode,
 		arg.MemoID,
+		arg.ProductID,
+		arg.CheckoutID,
 	)
 	var i TblEmailJob
 	err := row.Scan(
@@ -204,6 +218,8 @@ func (q *Queries) InsertEmailJob(ctx context.Context, arg InsertEmailJobParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.MemoID,
+		&i.ProductID,
+		&i.CheckoutID,
 	)
 	return i, err
 }
@@ -0,0 +1,574 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: experiment.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+)
+
+const addExperimentVariantPromo = `-- name: AddExperimentVariantPromo :exec
+INSERT INTO tbl_experiment_variant_promos (variant_id, promo_id)
+VALUES (?, ?)
+ON CONFLICT DO NOTHING
+`
+
+type AddExperimentVariantPromoParams struct {
+	VariantID int64
+	PromoID   int64
+}
+
+func (q *Queries) AddExperimentVariantPromo(ctx context.Context, arg AddExperimentVariantPromoParams) error {
+	_, err := q.db.ExecContext(ctx, addExperimentVariantPromo, arg.VariantID, arg.PromoID)
+	return err
+}
+
+const createExperiment = `-- name: CreateExperiment :one
+INSERT INTO tbl_experiments (
+	name,
+	kind,
+	start_date,
+	end_date,
+	created_by,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, DATETIME('now'), DATETIME('now')
+) RETURNING id
+`
+
+type CreateExperimentParams struct {
+	Name      string
+	Kind      string
+	StartDate string
+	EndDate   string
+	CreatedBy int64
+}
+
+func (q *Queries) CreateExperiment(ctx context.Context, arg CreateExperimentParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createExperiment,
+		arg.Name,
+		arg.Kind,
+		arg.StartDate,
+		arg.EndDate,
+		arg.CreatedBy,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createExperimentExposure = `-- name: CreateExperimentExposure :exec
+INSERT INTO tbl_experiment_exposures (
+	experiment_id,
+	variant_id,
+	bucket_key,
+	created_at
+) VALUES (
+	?, ?, ?, DATETIME('now')
+)
+ON CONFLICT (experiment_id, bucket_key) DO NOTHING
+`
+
+type CreateExperimentExposureParams struct {
+	ExperimentID int64
+	VariantID    int64
+	BucketKey    string
+}
+
+func (q *Queries) CreateExperimentExposure(ctx context.Context, arg CreateExperimentExposureParams) error {
+	_, err := q.db.ExecContext(ctx, createExperimentExposure, arg.ExperimentID, arg.VariantID, arg.BucketKey)
+	return err
+}
+
+const createExperimentVariant = `-- name: CreateExperimentVariant :one
+INSERT INTO tbl_experiment_variants (
+	experiment_id,
+	name,
+	weight,
+	theme_id,
+	created_at
+) VALUES (
+	?, ?, ?, ?, DATETIME('now')
+) RETURNING id
+`
+
+type CreateExperimentVariantParams struct {
+	ExperimentID int64
+	Name         string
+	Weight       int64
+	ThemeID      sql.NullInt64
+}
+
+func (q *Queries) CreateExperimentVariant(ctx context.Context, arg CreateExperimentVariantParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createExperimentVariant,
+		arg.ExperimentID,
+		arg.Name,
+		arg.Weight,
+		arg.ThemeID,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteExperimentVariant = `-- name: DeleteExperimentVariant :execrows
+DELETE FROM tbl_experiment_variants
+WHERE id = ?1 AND experiment_id = ?2
+`
+
+type DeleteExperimentVariantParams struct {
+	ID           int64
+	ExperimentID int64
+}
+
+func (q *Queries) DeleteExperimentVariant(ctx context.Context, arg DeleteExperimentVariantParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExperimentVariant, arg.ID, arg.ExperimentID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getActiveVariantPromos = `-- name: GetActiveVariantPromos :many
+SELECT tbl_promos.id, tbl_promos.title, tbl_promos.description, tbl_promos.media_url, tbl_promos.start_date, tbl_promos.end_date, tbl_promos.type, tbl_promos.status, tbl_promos.created_at, tbl_promos.updated_at, tbl_promos.deleted_at, tbl_promos.banner_only, tbl_promos.priority
+FROM tbl_promos
+JOIN tbl_experiment_variant_promos vp ON vp.promo_id = tbl_promos.id
+WHERE vp.variant_id = ?
+AND tbl_promos.deleted_at = '1970-01-01 00:00:00+00:00'
+AND tbl_promos.status = 'PUBLISHED'
+AND tbl_promos.start_date <= DATETIME('now')
+AND tbl_promos.end_date >= DATETIME('now')
+ORDER BY tbl_promos.priority ASC
+`
+
+type GetActiveVariantPromosRow struct {
+	TblPromo TblPromo
+}
+
+func (q *Queries) GetActiveVariantPromos(ctx context.Context, variantID int64) ([]GetActiveVariantPromosRow, error) {
+	rows, err := q.db.QueryContext(ctx, getActiveVariantPromos, variantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActiveVariantPromosRow
+	for rows.Next() {
+		var i GetActiveVariantPromosRow
+		if err := rows.Scan(
+			&i.TblPromo.ID,
+			&i.TblPromo.Title,
+			&i.TblPromo.Description,
+			&i.TblPromo.MediaUrl,
+			&i.TblPromo.StartDate,
+			&i.TblPromo.EndDate,
+			&i.TblPromo.Type,
+			&i.TblPromo.Status,
+			&i.TblPromo.CreatedAt,
+			&i.TblPromo.UpdatedAt,
+			&i.TblPromo.DeletedAt,
+			&i.TblPromo.BannerOnly,
+			&i.TblPromo.Priority,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExperimentByID = `-- name: GetExperimentByID :one
+SELECT id, name, kind, status, start_date, end_date, created_by, created_at, updated_at
+FROM tbl_experiments
+WHERE id = ? AND status != 'DELETED'
+LIMIT 1
+`
+
+func (q *Queries) GetExperimentByID(ctx context.Context, id int64) (TblExperiment, error) {
+	row := q.db.QueryRowContext(ctx, getExperimentByID, id)
+	var i TblExperiment
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.Status,
+		&i.StartDate,
+		&i.EndDate,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getExperimentExposure = `-- name: GetExperimentExposure :one
+SELECT variant_id
+FROM tbl_experiment_exposures
+WHERE experiment_id = ? AND bucket_key = ?
+`
+
+type GetExperimentExposureParams struct {
+	ExperimentID int64
+	BucketKey    string
+}
+
+func (q *Queries) GetExperimentExposure(ctx context.Context, arg GetExperimentExposureParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getExperimentExposure, arg.ExperimentID, arg.BucketKey)
+	var variant_id int64
+	err := row.Scan(&variant_id)
+	return variant_id, err
+}
+
+const getExperimentVariantPromos = `-- name: GetExperimentVariantPromos :many
+SELECT
+	vp.variant_id,
+	p.id AS promo_id,
+	p.title
+FROM tbl_experiment_variant_promos vp
+JOIN tbl_experiment_variants v ON v.id = vp.variant_id
+JOIN tbl_promos p ON p.id = vp.promo_id
+WHERE v.experiment_id = ?
+ORDER BY p.priority ASC
+`
+
+type GetExperimentVariantPromosRow struct {
+	VariantID int64
+	PromoID   int64
+	Title     string
+}
+
+func (q *Queries) GetExperimentVariantPromos(ctx context.Context, experimentID int64) ([]GetExperimentVariantPromosRow, error) {
+	rows, err := q.db.QueryContext(ctx, getExperimentVariantPromos, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetExperimentVariantPromosRow
+	for rows.Next() {
+		var i GetExperimentVariantPromosRow
+		if err := rows.Scan(&i.VariantID, &i.PromoID, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExperimentVariantResults = `-- name: GetExperimentVariantResults :many
+SELECT
+	v.id AS variant_id,
+	COUNT(DISTINCT e.id) AS exposures,
+	COUNT(DISTINCT CASE WHEN e.added_to_cart_at IS NOT NULL THEN e.id END) AS added_to_cart,
+	COUNT(DISTINCT CASE WHEN o.id IS NOT NULL THEN e.id END) AS paid_orders,
+	CAST(COALESCE(SUM(o.total_amount), 0) AS INTEGER) AS revenue
+FROM tbl_experiment_variants v
+LEFT JOIN tbl_experiment_exposures e ON e.variant_id = v.id
+LEFT JOIN tbl_orders o
+	ON o.checkout_id = e.checkout_id
+	AND o.paid_at IS NOT NULL
+	AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+WHERE v.experiment_id = ?
+GROUP BY v.id
+ORDER BY v.id
+`
+
+type GetExperimentVariantResultsRow struct {
+	VariantID   int64
+	Exposures   int64
+	AddedToCart int64
+	PaidOrders  int64
+	Revenue     int64
+}
+
+func (q *Queries) GetExperimentVariantResults(ctx context.Context, experimentID int64) ([]GetExperimentVariantResultsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getExperimentVariantResults, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetExperimentVariantResultsRow
+	for rows.Next() {
+		var i GetExperimentVariantResultsRow
+		if err := rows.Scan(
+			&i.VariantID,
+			&i.Exposures,
+			&i.AddedToCart,
+			&i.PaidOrders,
+			&i.Revenue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExperimentVariants = `-- name: GetExperimentVariants :many
+SELECT
+	v.id,
+	v.experiment_id,
+	v.name,
+	v.weight,
+	v.theme_id,
+	CAST(COALESCE(t.title, '') AS TEXT) AS theme_title
+FROM tbl_experiment_variants v
+LEFT JOIN tbl_themes t ON t.id = v.theme_id
+WHERE v.experiment_id = ?
+ORDER BY v.id
+`
+
+type GetExperimentVariantsRow struct {
+	ID           int64
+	ExperimentID int64
+	Name         string
+	Weight       int64
+	ThemeID      sql.NullInt64
+	ThemeTitle   string
+}
+
+func (q *Queries) GetExperimentVariants(ctx context.Context, experimentID int64) ([]GetExperimentVariantsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getExperimentVariants, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetExperimentVariantsRow
+	for rows.Next() {
+		var i GetExperimentVariantsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ExperimentID,
+			&i.Name,
+			&i.Weight,
+			&i.ThemeID,
+			&i.ThemeTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExperiments = `-- name: GetExperiments :many
+SELECT id, name, kind, status, start_date, end_date, created_by, created_at, updated_at
+FROM tbl_experiments
+WHERE status != 'DELETED'
+ORDER BY start_date DESC, id DESC
+`
+
+func (q *Queries) GetExperiments(ctx context.Context) ([]TblExperiment, error) {
+	rows, err := q.db.QueryContext(ctx, getExperiments)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TblExperiment
+	for rows.Next() {
+		var i TblExperiment
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Kind,
+			&i.Status,
+			&i.StartDate,
+			&i.EndDate,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOverlappingExperiments = `-- name: GetOverlappingExperiments :many
+SELECT id
+FROM tbl_experiments
+WHERE status = 'PUBLISHED'
+AND kind = ?1
+AND id != ?2
+AND start_date <= ?3
+AND end_date >= ?4
+`
+
+type GetOverlappingExperimentsParams struct {
+	Kind      string
+	ID        int64
+	EndDate   string
+	StartDate string
+}
+
+func (q *Queries) GetOverlappingExperiments(ctx context.Context, arg GetOverlappingExperimentsParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, getOverlappingExperiments,
+		arg.Kind,
+		arg.ID,
+		arg.EndDate,
+		arg.StartDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRunningExperimentByKind = `-- name: GetRunningExperimentByKind :one
+SELECT id, name, kind, status, start_date, end_date, created_by, created_at, updated_at
+FROM tbl_experiments
+WHERE status = 'PUBLISHED'
+AND kind = ?
+AND start_date <= DATE('now')
+AND end_date >= DATE('now')
+ORDER BY start_date DESC
+LIMIT 1
+`
+
+func (q *Queries) GetRunningExperimentByKind(ctx context.Context, kind string) (TblExperiment, error) {
+	row := q.db.QueryRowContext(ctx, getRunningExperimentByKind, kind)
+	var i TblExperiment
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.Status,
+		&i.StartDate,
+		&i.EndDate,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const markExperimentAddToCart = `-- name: MarkExperimentAddToCart :many
+UPDATE tbl_experiment_exposures
+SET added_to_cart_at = DATETIME('now')
+WHERE bucket_key = ?1
+AND added_to_cart_at IS NULL
+AND experiment_id IN (
+	SELECT id FROM tbl_experiments
+	WHERE status = 'PUBLISHED'
+	AND start_date <= DATE('now')
+	AND end_date >= DATE('now')
+)
+RETURNING experiment_id, variant_id
+`
+
+type MarkExperimentAddToCartRow struct {
+	ExperimentID int64
+	VariantID    int64
+}
+
+func (q *Queries) MarkExperimentAddToCart(ctx context.Context, bucketKey string) ([]MarkExperimentAddToCartRow, error) {
+	rows, err := q.db.QueryContext(ctx, markExperimentAddToCart, bucketKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MarkExperimentAddToCartRow
+	for rows.Next() {
+		var i MarkExperimentAddToCartRow
+		if err := rows.Scan(&i.ExperimentID, &i.VariantID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setExperimentExposureCheckout = `-- name: SetExperimentExposureCheckout :exec
+UPDATE tbl_experiment_exposures
+SET checkout_id = ?1
+WHERE bucket_key = ?2
+AND experiment_id IN (
+	SELECT id FROM tbl_experiments
+	WHERE status = 'PUBLISHED'
+	AND start_date <= DATE('now')
+	AND end_date >= DATE('now')
+)
+`
+
+type SetExperimentExposureCheckoutParams struct {
+	CheckoutID sql.NullInt64
+	BucketKey  string
+}
+
+func (q *Queries) SetExperimentExposureCheckout(ctx context.Context, arg SetExperimentExposureCheckoutParams) error {
+	_, err := q.db.ExecContext(ctx, setExperimentExposureCheckout, arg.CheckoutID, arg.BucketKey)
+	return err
+}
+
+const updateExperimentStatus = `-- name: UpdateExperimentStatus :execrows
+UPDATE tbl_experiments
+SET status = ?1, updated_at = DATETIME('now')
+WHERE id = ?2 AND status != 'DELETED'
+`
+
+type UpdateExperimentStatusParams struct {
+	Status string
+	ID     int64
+}
+
+func (q *Queries) UpdateExperimentStatus(ctx context.Context, arg UpdateExperimentStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateExperimentStatus, arg.Status, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,327 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: incident.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createStaffIncident = `-- name: CreateStaffIncident :one
+INSERT INTO tbl_staff_incidents (
+	staff_id,
+	reported_by,
+	incident_date,
+	title,
+	description,
+	status,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, 'OPEN',
+	DATETIME('now'),
+	DATETIME('now')
+) RETURNING id
+`
+
+type CreateStaffIncidentParams struct {
+	StaffID      int64
+	ReportedBy   int64
+	IncidentDate string
+	Title        string
+	Description  string
+}
+
+func (q *Queries) CreateStaffIncident(ctx context.Context, arg CreateStaffIncidentParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createStaffIncident,
+		arg.StaffID,
+		arg.ReportedBy,
+		arg.IncidentDate,
+		arg.Title,
+		arg.Description,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const decideStaffIncident = `-- name: DecideStaffIncident :execrows
+UPDATE tbl_staff_incidents
+SET
+	status = 'DECIDED',
+	decision = ?1,
+	decision_notes = ?2,
+	effective_from = ?3,
+	effective_to = ?4,
+	decided_by = ?5,
+	decided_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE id = ?6 AND status != 'DECIDED'
+`
+
+type DecideStaffIncidentParams struct {
+	Decision      sql.NullString
+	DecisionNotes sql.NullString
+	EffectiveFrom sql.NullString
+	EffectiveTo   sql.NullString
+	DecidedBy     sql.NullInt64
+	ID            int64
+}
+
+func (q *Queries) DecideStaffIncident(ctx context.Context, arg DecideStaffIncidentParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, decideStaffIncident,
+		arg.Decision,
+		arg.DecisionNotes,
+		arg.EffectiveFrom,
+		arg.EffectiveTo,
+		arg.DecidedBy,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getStaffIncidentByID = `-- name: GetStaffIncidentByID :one
+SELECT id, staff_id, reported_by, incident_date, title, description, status, nte_memo_id, response_deadline, response, responded_at, decision, decision_notes, effective_from, effective_to, decided_by, decided_at, created_at, updated_at
+FROM tbl_staff_incidents
+WHERE id = ?
+LIMIT 1
+`
+
+func (q *Queries) GetStaffIncidentByID(ctx context.Context, id int64) (TblStaffIncident, error) {
+	row := q.db.QueryRowContext(ctx, getStaffIncidentByID, id)
+	var i TblStaffIncident
+	err := row.Scan(
+		&i.ID,
+		&i.StaffID,
+		&i.ReportedBy,
+		&i.IncidentDate,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.NteMemoID,
+		&i.ResponseDeadline,
+		&i.Response,
+		&i.RespondedAt,
+		&i.Decision,
+		&i.DecisionNotes,
+		&i.EffectiveFrom,
+		&i.EffectiveTo,
+		&i.DecidedBy,
+		&i.DecidedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getStaffIncidents = `-- name: GetStaffIncidents :many
+SELECT
+	tbl_staff_incidents.id, tbl_staff_incidents.staff_id, tbl_staff_incidents.reported_by, tbl_staff_incidents.incident_date, tbl_staff_incidents.title, tbl_staff_incidents.description, tbl_staff_incidents.status, tbl_staff_incidents.nte_memo_id, tbl_staff_incidents.response_deadline, tbl_staff_incidents.response, tbl_staff_incidents.responded_at, tbl_staff_incidents.decision, tbl_staff_incidents.decision_notes, tbl_staff_incidents.effective_from, tbl_staff_incidents.effective_to, tbl_staff_incidents.decided_by, tbl_staff_incidents.decided_at, tbl_staff_incidents.created_at, tbl_staff_incidents.updated_at,
+	staff.first_name,
+	staff.middle_name,
+	staff.last_name,
+	reporter.first_name AS reporter_first_name,
+	reporter.middle_name AS reporter_middle_name,
+	reporter.last_name AS reporter_last_name
+FROM tbl_staff_incidents
+INNER JOIN tbl_staffs AS staff ON staff.id = tbl_staff_incidents.staff_id
+INNER JOIN tbl_staffs AS reporter ON reporter.id = tbl_staff_incidents.reported_by
+WHERE
+	(tbl_staff_incidents.staff_id = ?1 OR ?1 = 0)
+	AND (tbl_staff_incidents.status = ?2 OR ?2 = '')
+ORDER BY tbl_staff_incidents.incident_date DESC, tbl_staff_incidents.id DESC
+LIMIT ?3
+`
+
+type GetStaffIncidentsParams struct {
+	StaffID int64
+	Status  string
+	Limit   int64
+}
+
+type GetStaffIncidentsRow struct {
+	ID                 int64
+	StaffID            int64
+	ReportedBy         int64
+	IncidentDate       string
+	Title              string
+	Description        string
+	Status             string
+	NteMemoID          sql.NullInt64
+	ResponseDeadline   sql.NullString
+	Response           sql.NullString
+	RespondedAt        sql.NullTime
+	Decision           sql.NullString
+	DecisionNotes      sql.NullString
+	EffectiveFrom      sql.NullString
+	EffectiveTo        sql.NullString
+	DecidedBy          sql.NullInt64
+	DecidedAt          sql.NullTime
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	FirstName          string
+	MiddleName         sql.NullString
+	LastName           string
+	ReporterFirstName  string
+	ReporterMiddleName sql.NullString
+	ReporterLastName   string
+}
+
+func (q *Queries) GetStaffIncidents(ctx context.Context, arg GetStaffIncidentsParams) ([]GetStaffIncidentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffIncidents, arg.StaffID, arg.Status, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStaffIncidentsRow
+	for rows.Next() {
+		var i GetStaffIncidentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StaffID,
+			&i.ReportedBy,
+			&i.IncidentDate,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.NteMemoID,
+			&i.ResponseDeadline,
+			&i.Response,
+			&i.RespondedAt,
+			&i.Decision,
+			&i.DecisionNotes,
+			&i.EffectiveFrom,
+			&i.EffectiveTo,
+			&i.DecidedBy,
+			&i.DecidedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FirstName,
+			&i.MiddleName,
+			&i.LastName,
+			&i.ReporterFirstName,
+			&i.ReporterMiddleName,
+			&i.ReporterLastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffSuspensionsByDateRange = `-- name: GetStaffSuspensionsByDateRange :many
+SELECT staff_id, effective_from, effective_to
+FROM tbl_staff_incidents
+WHERE
+	decision = 'SUSPENSION'
+	AND (staff_id = ?1 OR ?1 = 0)
+	AND effective_from <= CAST(?2 AS TEXT)
+	AND effective_to >= CAST(?3 AS TEXT)
+`
+
+type GetStaffSuspensionsByDateRangeParams struct {
+	StaffID   int64
+	EndDate   string
+	StartDate string
+}
+
+type GetStaffSuspensionsByDateRangeRow struct {
+	StaffID       int64
+	EffectiveFrom sql.NullString
+	EffectiveTo   sql.NullString
+}
+
+func (q *Queries) GetStaffSuspensionsByDateRange(ctx context.Context, arg GetStaffSuspensionsByDateRangeParams) ([]GetStaffSuspensionsByDateRangeRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffSuspensionsByDateRange, arg.StaffID, arg.EndDate, arg.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStaffSuspensionsByDateRangeRow
+	for rows.Next() {
+		var i GetStaffSuspensionsByDateRangeRow
+		if err := rows.Scan(&i.StaffID, &i.EffectiveFrom, &i.EffectiveTo); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const issueStaffIncidentNTE = `-- name: IssueStaffIncidentNTE :execrows
+UPDATE tbl_staff_incidents
+SET
+	status = 'NTE_ISSUED',
+	nte_memo_id = ?1,
+	response_deadline = ?2,
+	updated_at = DATETIME('now')
+WHERE id = ?3 AND status = 'OPEN'
+`
+
+type IssueStaffIncidentNTEParams struct {
+	NteMemoID        sql.NullInt64
+	ResponseDeadline sql.NullString
+	ID               int64
+}
+
+func (q *Queries) IssueStaffIncidentNTE(ctx context.Context, arg IssueStaffIncidentNTEParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, issueStaffIncidentNTE, arg.NteMemoID, arg.ResponseDeadline, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const respondToStaffIncident = `-- name: RespondToStaffIncident :execrows
+UPDATE tbl_staff_incidents
+SET
+	status = 'RESPONDED',
+	response = ?1,
+	responded_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE
+	id = ?2
+	AND staff_id = ?3
+	AND status = 'NTE_ISSUED'
+	AND response_deadline >= CAST(?4 AS TEXT)
+`
+
+type RespondToStaffIncidentParams struct {
+	Response sql.NullString
+	ID       int64
+	StaffID  int64
+	Today    string
+}
+
+func (q *Queries) RespondToStaffIncident(ctx context.Context, arg RespondToStaffIncidentParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, respondToStaffIncident,
+		arg.Response,
+		arg.ID,
+		arg.StaffID,
+		arg.Today,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,275 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: kiosk.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createAttendanceKioskPunch = `-- name: CreateAttendanceKioskPunch :one
+INSERT INTO tbl_attendance_kiosk_punches (
+	staff_id,
+	kiosk_staff_id,
+	for_date,
+	punch_type,
+	method,
+	photo_key,
+	useragent_id,
+	token_nonce
+) VALUES (
+	?1,
+	?2,
+	?3,
+	?4,
+	?5,
+	?6,
+	?7,
+	?8
+)
+ON CONFLICT (token_nonce) DO NOTHING
+RETURNING id
+`
+
+type CreateAttendanceKioskPunchParams struct {
+	StaffID      int64
+	KioskStaffID int64
+	ForDate      string
+	PunchType    string
+	Method       string
+	PhotoKey     string
+	UseragentID  sql.NullInt64
+	TokenNonce   sql.NullString
+}
+
+// CreateAttendanceKioskPunch returns no row when the QR token's nonce was
+// already used by another punch.
+func (q *Queries) CreateAttendanceKioskPunch(ctx context.Context, arg CreateAttendanceKioskPunchParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createAttendanceKioskPunch,
+		arg.StaffID,
+		arg.KioskStaffID,
+		arg.ForDate,
+		arg.PunchType,
+		arg.Method,
+		arg.PhotoKey,
+		arg.UseragentID,
+		arg.TokenNonce,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteAttendanceKioskPunch = `-- name: DeleteAttendanceKioskPunch :exec
+DELETE FROM tbl_attendance_kiosk_punches WHERE id = ?
+`
+
+func (q *Queries) DeleteAttendanceKioskPunch(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteAttendanceKioskPunch, id)
+	return err
+}
+
+const getAttendanceKioskPunchByID = `-- name: GetAttendanceKioskPunchByID :one
+SELECT id, staff_id, kiosk_staff_id, for_date, punch_type, method, photo_key, useragent_id, created_at, token_nonce
+FROM tbl_attendance_kiosk_punches
+WHERE id = ?
+LIMIT 1
+`
+
+func (q *Queries) GetAttendanceKioskPunchByID(ctx context.Context, id int64) (TblAttendanceKioskPunch, error) {
+	row := q.db.QueryRowContext(ctx, getAttendanceKioskPunchByID, id)
+	var i TblAttendanceKioskPunch
+	err := row.Scan(
+		&i.ID,
+		&i.StaffID,
+		&i.KioskStaffID,
+		&i.ForDate,
+		&i.PunchType,
+		&i.Method,
+		&i.PhotoKey,
+		&i.UseragentID,
+		&i.CreatedAt,
+		&i.TokenNonce,
+	)
+	return i, err
+}
+
+const getAttendanceKioskPunchesByDate = `-- name: GetAttendanceKioskPunchesByDate :many
+SELECT
+	tbl_attendance_kiosk_punches.id,
+	tbl_attendance_kiosk_punches.staff_id,
+	tbl_attendance_kiosk_punches.for_date,
+	tbl_attendance_kiosk_punches.punch_type,
+	tbl_attendance_kiosk_punches.method,
+	tbl_attendance_kiosk_punches.photo_key,
+	tbl_attendance_kiosk_punches.created_at,
+	staff.first_name,
+	staff.middle_name,
+	staff.last_name,
+	kiosk.first_name AS kiosk_first_name,
+	kiosk.last_name AS kiosk_last_name
+FROM tbl_attendance_kiosk_punches
+INNER JOIN tbl_staffs AS staff ON staff.id = tbl_attendance_kiosk_punches.staff_id
+INNER JOIN tbl_staffs AS kiosk ON kiosk.id = tbl_attendance_kiosk_punches.kiosk_staff_id
+WHERE tbl_attendance_kiosk_punches.for_date = ?1
+ORDER BY tbl_attendance_kiosk_punches.created_at DESC
+`
+
+type GetAttendanceKioskPunchesByDateRow struct {
+	ID             int64
+	StaffID        int64
+	ForDate        string
+	PunchType      string
+	Method         string
+	PhotoKey       string
+	CreatedAt      time.Time
+	FirstName      string
+	MiddleName     sql.NullString
+	LastName       string
+	KioskFirstName string
+	KioskLastName  string
+}
+
+func (q *Queries) GetAttendanceKioskPunchesByDate(ctx context.Context, forDate string) ([]GetAttendanceKioskPunchesByDateRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAttendanceKioskPunchesByDate, forDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAttendanceKioskPunchesByDateRow
+	for rows.Next() {
+		var i GetAttendanceKioskPunchesByDateRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StaffID,
+			&i.ForDate,
+			&i.PunchType,
+			&i.Method,
+			&i.PhotoKey,
+			&i.CreatedAt,
+			&i.FirstName,
+			&i.MiddleName,
+			&i.LastName,
+			&i.KioskFirstName,
+			&i.KioskLastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffKioskPin = `-- name: GetStaffKioskPin :one
+SELECT staff_id, pin_hash, failed_attempts, locked_until, created_at, updated_at
+FROM tbl_staff_kiosk_pins
+WHERE staff_id = ?
+LIMIT 1
+`
+
+func (q *Queries) GetStaffKioskPin(ctx context.Context, staffID int64) (TblStaffKioskPin, error) {
+	row := q.db.QueryRowContext(ctx, getStaffKioskPin, staffID)
+	var i TblStaffKioskPin
+	err := row.Scan(
+		&i.StaffID,
+		&i.PinHash,
+		&i.FailedAttempts,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const incrementStaffKioskPinFailures = `-- name: IncrementStaffKioskPinFailures :one
+UPDATE tbl_staff_kiosk_pins
+SET
+	failed_attempts = failed_attempts + 1,
+	updated_at = DATETIME('now')
+WHERE staff_id = ?
+RETURNING failed_attempts
+`
+
+func (q *Queries) IncrementStaffKioskPinFailures(ctx context.Context, staffID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, incrementStaffKioskPinFailures, staffID)
+	var failed_attempts int64
+	err := row.Scan(&failed_attempts)
+	return failed_attempts, err
+}
+
+const lockStaffKioskPin = `-- name: LockStaffKioskPin :exec
+UPDATE tbl_staff_kiosk_pins
+SET
+	failed_attempts = 0,
+	locked_until = ?1,
+	updated_at = DATETIME('now')
+WHERE staff_id = ?2
+`
+
+type LockStaffKioskPinParams struct {
+	LockedUntil sql.NullTime
+	StaffID     int64
+}
+
+func (q *Queries) LockStaffKioskPin(ctx context.Context, arg LockStaffKioskPinParams) error {
+	_, err := q.db.ExecContext(ctx, lockStaffKioskPin, arg.LockedUntil, arg.StaffID)
+	return err
+}
+
+const resetStaffKioskPinFailures = `-- name: ResetStaffKioskPinFailures :exec
+UPDATE tbl_staff_kiosk_pins
+SET
+	failed_attempts = 0,
+	locked_until = NULL,
+	updated_at = DATETIME('now')
+WHERE staff_id = ?
+`
+
+func (q *Queries) ResetStaffKioskPinFailures(ctx context.Context, staffID int64) error {
+	_, err := q.db.ExecContext(ctx, resetStaffKioskPinFailures, staffID)
+	return err
+}
+
+const updateAttendanceKioskPunchPhotoKey = `-- name: UpdateAttendanceKioskPunchPhotoKey :exec
+UPDATE tbl_attendance_kiosk_punches SET photo_key = ? WHERE id = ?
+`
+
+type UpdateAttendanceKioskPunchPhotoKeyParams struct {
+	PhotoKey string
+	ID       int64
+}
+
+func (q *Queries) UpdateAttendanceKioskPunchPhotoKey(ctx context.Context, arg UpdateAttendanceKioskPunchPhotoKeyParams) error {
+	_, err := q.db.ExecContext(ctx, updateAttendanceKioskPunchPhotoKey, arg.PhotoKey, arg.ID)
+	return err
+}
+
+const upsertStaffKioskPin = `-- name: UpsertStaffKioskPin :exec
+INSERT INTO tbl_staff_kiosk_pins (staff_id, pin_hash)
+VALUES (?1, ?2)
+ON CONFLICT (staff_id) DO UPDATE SET
+	pin_hash = excluded.pin_hash,
+	failed_attempts = 0,
+	locked_until = NULL,
+	updated_at = DATETIME('now')
+`
+
+type UpsertStaffKioskPinParams struct {
+	StaffID int64
+	PinHash string
+}
+
+func (q *Queries) UpsertStaffKioskPin(ctx context.Context, arg UpsertStaffKioskPinParams) error {
+	_, err := q.db.ExecContext(ctx, upsertStaffKioskPin, arg.StaffID, arg.PinHash)
+	return err
+}
@@ -0,0 +1,379 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: leave.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createStaffLeaveEntry = `-- name: CreateStaffLeaveEntry :exec
+INSERT INTO tbl_staff_leave_ledger (
+	staff_id,
+	leave_type,
+	entry_type,
+	year,
+	period,
+	credits,
+	time_off_id,
+	note,
+	created_by,
+	created_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?, ?, ?,
+	DATETIME('now')
+)
+ON CONFLICT DO NOTHING
+`
+
+type CreateStaffLeaveEntryParams struct {
+	StaffID   int64
+	LeaveType string
+	EntryType string
+	Year      int64
+	Period    string
+	Credits   int64
+	TimeOffID sql.NullInt64
+	Note      string
+	CreatedBy sql.NullInt64
+}
+
+func (q *Queries) CreateStaffLeaveEntry(ctx context.Context, arg CreateStaffLeaveEntryParams) error {
+	_, err := q.db.ExecContext(ctx, createStaffLeaveEntry,
+		arg.StaffID,
+		arg.LeaveType,
+		arg.EntryType,
+		arg.Year,
+		arg.Period,
+		arg.Credits,
+		arg.TimeOffID,
+		arg.Note,
+		arg.CreatedBy,
+	)
+	return err
+}
+
+const getLeaveBalances = `-- name: GetLeaveBalances :many
+SELECT
+	staff_id,
+	leave_type,
+	CAST(SUM(credits) AS INTEGER) AS balance
+FROM tbl_staff_leave_ledger
+GROUP BY staff_id, leave_type
+`
+
+type GetLeaveBalancesRow struct {
+	StaffID   int64
+	LeaveType string
+	Balance   int64
+}
+
+func (q *Queries) GetLeaveBalances(ctx context.Context) ([]GetLeaveBalancesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getLeaveBalances)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLeaveBalancesRow
+	for rows.Next() {
+		var i GetLeaveBalancesRow
+		if err := rows.Scan(&i.StaffID, &i.LeaveType, &i.Balance); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLeaveStaffByID = `-- name: GetLeaveStaffByID :one
+SELECT
+	tbl_staffs.id,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position,
+	tbl_staffs.date_hired
+FROM tbl_staffs
+WHERE
+	tbl_staffs.id = ?
+	AND tbl_staffs.deleted_at = '1970-01-01 00:00:00+00:00'
+LIMIT 1
+`
+
+type GetLeaveStaffByIDRow struct {
+	ID         int64
+	FirstName  string
+	MiddleName sql.NullString
+	LastName   string
+	Position   string
+	DateHired  string
+}
+
+func (q *Queries) GetLeaveStaffByID(ctx context.Context, id int64) (GetLeaveStaffByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getLeaveStaffByID, id)
+	var i GetLeaveStaffByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.MiddleName,
+		&i.LastName,
+		&i.Position,
+		&i.DateHired,
+	)
+	return i, err
+}
+
+const getLeaveStaffs = `-- name: GetLeaveStaffs :many
+SELECT
+	tbl_staffs.id,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position,
+	tbl_staffs.date_hired
+FROM tbl_staffs
+WHERE
+	tbl_staffs.deleted_at = '1970-01-01 00:00:00+00:00'
+	AND tbl_staffs.status != 'RESIGNED'
+ORDER BY tbl_staffs.last_name ASC, tbl_staffs.first_name ASC
+`
+
+type GetLeaveStaffsRow struct {
+	ID         int64
+	FirstName  string
+	MiddleName sql.NullString
+	LastName   string
+	Position   string
+	DateHired  string
+}
+
+func (q *Queries) GetLeaveStaffs(ctx context.Context) ([]GetLeaveStaffsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getLeaveStaffs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLeaveStaffsRow
+	for rows.Next() {
+		var i GetLeaveStaffsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.MiddleName,
+			&i.LastName,
+			&i.Position,
+			&i.DateHired,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffLeaveBalance = `-- name: GetStaffLeaveBalance :one
+SELECT CAST(COALESCE(SUM(credits), 0) AS INTEGER) AS balance
+FROM tbl_staff_leave_ledger
+WHERE staff_id = ? AND leave_type = ?
+`
+
+type GetStaffLeaveBalanceParams struct {
+	StaffID   int64
+	LeaveType string
+}
+
+func (q *Queries) GetStaffLeaveBalance(ctx context.Context, arg GetStaffLeaveBalanceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getStaffLeaveBalance, arg.StaffID, arg.LeaveType)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const getStaffLeaveBalanceUntilYear = `-- name: GetStaffLeaveBalanceUntilYear :one
+SELECT CAST(COALESCE(SUM(credits), 0) AS INTEGER) AS balance
+FROM tbl_staff_leave_ledger
+WHERE
+	staff_id = ?1
+	AND leave_type = ?2
+	AND year <= ?3
+`
+
+type GetStaffLeaveBalanceUntilYearParams struct {
+	StaffID   int64
+	LeaveType string
+	Year      int64
+}
+
+func (q *Queries) GetStaffLeaveBalanceUntilYear(ctx context.Context, arg GetStaffLeaveBalanceUntilYearParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getStaffLeaveBalanceUntilYear, arg.StaffID, arg.LeaveType, arg.Year)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const getStaffLeaveFirstYear = `-- name: GetStaffLeaveFirstYear :one
+SELECT CAST(COALESCE(MIN(year), ?1) AS INTEGER) AS first_year
+FROM tbl_staff_leave_ledger
+WHERE staff_id = ?2 AND leave_type = ?3
+`
+
+type GetStaffLeaveFirstYearParams struct {
+	DefaultYear int64
+	StaffID     int64
+	LeaveType   string
+}
+
+func (q *Queries) GetStaffLeaveFirstYear(ctx context.Context, arg GetStaffLeaveFirstYearParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getStaffLeaveFirstYear, arg.DefaultYear, arg.StaffID, arg.LeaveType)
+	var first_year int64
+	err := row.Scan(&first_year)
+	return first_year, err
+}
+
+const getStaffLeaveLedger = `-- name: GetStaffLeaveLedger :many
+SELECT
+	tbl_staff_leave_ledger.id, tbl_staff_leave_ledger.staff_id, tbl_staff_leave_ledger.leave_type, tbl_staff_leave_ledger.entry_type, tbl_staff_leave_ledger.year, tbl_staff_leave_ledger.period, tbl_staff_leave_ledger.credits, tbl_staff_leave_ledger.time_off_id, tbl_staff_leave_ledger.note, tbl_staff_leave_ledger.created_by, tbl_staff_leave_ledger.created_at,
+	creator.first_name AS creator_first_name,
+	creator.middle_name AS creator_middle_name,
+	creator.last_name AS creator_last_name
+FROM tbl_staff_leave_ledger
+LEFT JOIN tbl_staffs creator ON creator.id = tbl_staff_leave_ledger.created_by
+WHERE tbl_staff_leave_ledger.staff_id = ?
+ORDER BY tbl_staff_leave_ledger.created_at DESC, tbl_staff_leave_ledger.id DESC
+LIMIT ?
+`
+
+type GetStaffLeaveLedgerParams struct {
+	StaffID int64
+	Limit   int64
+}
+
+type GetStaffLeaveLedgerRow struct {
+	ID                int64
+	StaffID           int64
+	LeaveType         string
+	EntryType         string
+	Year              int64
+	Period            string
+	Credits           int64
+	TimeOffID         sql.NullInt64
+	Note              string
+	CreatedBy         sql.NullInt64
+	CreatedAt         time.Time
+	CreatorFirstName  sql.NullString
+	CreatorMiddleName sql.NullString
+	CreatorLastName   sql.NullString
+}
+
+func (q *Queries) GetStaffLeaveLedger(ctx context.Context, arg GetStaffLeaveLedgerParams) ([]GetStaffLeaveLedgerRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffLeaveLedger, arg.StaffID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStaffLeaveLedgerRow
+	for rows.Next() {
+		var i GetStaffLeaveLedgerRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StaffID,
+			&i.LeaveType,
+			&i.EntryType,
+			&i.Year,
+			&i.Period,
+			&i.Credits,
+			&i.TimeOffID,
+			&i.Note,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.CreatorFirstName,
+			&i.CreatorMiddleName,
+			&i.CreatorLastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffLeaveTimeOffCredits = `-- name: GetStaffLeaveTimeOffCredits :one
+SELECT CAST(COALESCE(SUM(credits), 0) AS INTEGER) AS credits
+FROM tbl_staff_leave_ledger
+WHERE time_off_id = ?
+`
+
+func (q *Queries) GetStaffLeaveTimeOffCredits(ctx context.Context, timeOffID sql.NullInt64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getStaffLeaveTimeOffCredits, timeOffID)
+	var credits int64
+	err := row.Scan(&credits)
+	return credits, err
+}
+
+const getStaffLeaveTotals = `-- name: GetStaffLeaveTotals :many
+SELECT
+	leave_type,
+	entry_type,
+	year,
+	CAST(SUM(credits) AS INTEGER) AS credits
+FROM tbl_staff_leave_ledger
+WHERE staff_id = ?
+GROUP BY leave_type, entry_type, year
+`
+
+type GetStaffLeaveTotalsRow struct {
+	LeaveType string
+	EntryType string
+	Year      int64
+	Credits   int64
+}
+
+func (q *Queries) GetStaffLeaveTotals(ctx context.Context, staffID int64) ([]GetStaffLeaveTotalsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffLeaveTotals, staffID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStaffLeaveTotalsRow
+	for rows.Next() {
+		var i GetStaffLeaveTotalsRow
+		if err := rows.Scan(
+			&i.LeaveType,
+			&i.EntryType,
+			&i.Year,
+			&i.Credits,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
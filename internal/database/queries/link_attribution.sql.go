@@ -0,0 +1,233 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: link_attribution.sql
+
+package queries
+
+import (
+	"context"
+)
+
+const getCampaignClickTotals = `-- name: GetCampaignClickTotals :many
+SELECT
+	CAST(COALESCE(l.source, '') AS TEXT) AS source,
+	CAST(COALESCE(l.campaign, '') AS TEXT) AS campaign,
+	COUNT(DISTINCT l.id) AS links,
+	COUNT(c.id) AS clicks,
+	COUNT(DISTINCT c.ip_hash) AS unique_visitors
+FROM tbl_tracked_links l
+LEFT JOIN tbl_link_clicks c
+	ON c.link_id = l.id
+	AND (CAST(?1 AS BOOLEAN) OR (c.is_bot = 0 AND c.is_duplicate = 0))
+GROUP BY 1, 2
+ORDER BY clicks DESC
+`
+
+type GetCampaignClickTotalsRow struct {
+	Source         string
+	Campaign       string
+	Links          int64
+	Clicks         int64
+	UniqueVisitors int64
+}
+
+func (q *Queries) GetCampaignClickTotals(ctx context.Context, includeFiltered bool) ([]GetCampaignClickTotalsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCampaignClickTotals, includeFiltered)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCampaignClickTotalsRow
+	for rows.Next() {
+		var i GetCampaignClickTotalsRow
+		if err := rows.Scan(
+			&i.Source,
+			&i.Campaign,
+			&i.Links,
+			&i.Clicks,
+			&i.UniqueVisitors,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCampaignFirstTouchTotals = `-- name: GetCampaignFirstTouchTotals :many
+SELECT
+	CAST(COALESCE(l.source, '') AS TEXT) AS source,
+	CAST(COALESCE(l.campaign, '') AS TEXT) AS campaign,
+	COUNT(DISTINCT a.checkout_id) AS carts,
+	COUNT(DISTINCT o.id) AS orders,
+	CAST(COALESCE(SUM(o.total_amount), 0) AS INTEGER) AS revenue
+FROM tbl_checkout_link_attributions a
+JOIN tbl_tracked_links l ON l.id = a.first_link_id
+LEFT JOIN tbl_orders o
+	ON o.checkout_id = a.checkout_id
+	AND o.paid_at IS NOT NULL
+	AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+GROUP BY 1, 2
+`
+
+type GetCampaignFirstTouchTotalsRow struct {
+	Source   string
+	Campaign string
+	Carts    int64
+	Orders   int64
+	Revenue  int64
+}
+
+func (q *Queries) GetCampaignFirstTouchTotals(ctx context.Context) ([]GetCampaignFirstTouchTotalsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCampaignFirstTouchTotals)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCampaignFirstTouchTotalsRow
+	for rows.Next() {
+		var i GetCampaignFirstTouchTotalsRow
+		if err := rows.Scan(
+			&i.Source,
+			&i.Campaign,
+			&i.Carts,
+			&i.Orders,
+			&i.Revenue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCampaignLastTouchTotals = `-- name: GetCampaignLastTouchTotals :many
+SELECT
+	CAST(COALESCE(l.source, '') AS TEXT) AS source,
+	CAST(COALESCE(l.campaign, '') AS TEXT) AS campaign,
+	COUNT(DISTINCT a.checkout_id) AS carts,
+	COUNT(DISTINCT o.id) AS orders,
+	CAST(COALESCE(SUM(o.total_amount), 0) AS INTEGER) AS revenue
+FROM tbl_checkout_link_attributions a
+JOIN tbl_tracked_links l ON l.id = a.last_link_id
+LEFT JOIN tbl_orders o
+	ON o.checkout_id = a.checkout_id
+	AND o.paid_at IS NOT NULL
+	AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+GROUP BY 1, 2
+`
+
+type GetCampaignLastTouchTotalsRow struct {
+	Source   string
+	Campaign string
+	Carts    int64
+	Orders   int64
+	Revenue  int64
+}
+
+func (q *Queries) GetCampaignLastTouchTotals(ctx context.Context) ([]GetCampaignLastTouchTotalsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCampaignLastTouchTotals)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCampaignLastTouchTotalsRow
+	for rows.Next() {
+		var i GetCampaignLastTouchTotalsRow
+		if err := rows.Scan(
+			&i.Source,
+			&i.Campaign,
+			&i.Carts,
+			&i.Orders,
+			&i.Revenue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLinkAttributionTotals = `-- name: GetLinkAttributionTotals :one
+SELECT
+	CAST(COUNT(DISTINCT CASE WHEN a.first_link_id = ?1 THEN a.checkout_id END) AS INTEGER) AS first_touch_carts,
+	CAST(COUNT(DISTINCT CASE WHEN a.last_link_id = ?1 THEN a.checkout_id END) AS INTEGER) AS last_touch_carts,
+	CAST(COUNT(DISTINCT CASE WHEN a.first_link_id = ?1 AND o.id IS NOT NULL THEN o.id END) AS INTEGER) AS first_touch_orders,
+	CAST(COUNT(DISTINCT CASE WHEN a.last_link_id = ?1 AND o.id IS NOT NULL THEN o.id END) AS INTEGER) AS last_touch_orders,
+	CAST(COALESCE(SUM(CASE WHEN a.first_link_id = ?1 THEN o.total_amount END), 0) AS INTEGER) AS first_touch_revenue,
+	CAST(COALESCE(SUM(CASE WHEN a.last_link_id = ?1 THEN o.total_amount END), 0) AS INTEGER) AS last_touch_revenue
+FROM tbl_checkout_link_attributions a
+LEFT JOIN tbl_orders o
+	ON o.checkout_id = a.checkout_id
+	AND o.paid_at IS NOT NULL
+	AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+WHERE a.first_link_id = ?1 OR a.last_link_id = ?1
+`
+
+type GetLinkAttributionTotalsRow struct {
+	FirstTouchCarts   int64
+	LastTouchCarts    int64
+	FirstTouchOrders  int64
+	LastTouchOrders   int64
+	FirstTouchRevenue int64
+	LastTouchRevenue  int64
+}
+
+func (q *Queries) GetLinkAttributionTotals(ctx context.Context, linkID string) (GetLinkAttributionTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getLinkAttributionTotals, linkID)
+	var i GetLinkAttributionTotalsRow
+	err := row.Scan(
+		&i.FirstTouchCarts,
+		&i.LastTouchCarts,
+		&i.FirstTouchOrders,
+		&i.LastTouchOrders,
+		&i.FirstTouchRevenue,
+		&i.LastTouchRevenue,
+	)
+	return i, err
+}
+
+const upsertCheckoutLinkAttribution = `-- name: UpsertCheckoutLinkAttribution :exec
+INSERT INTO tbl_checkout_link_attributions (
+	checkout_id,
+	first_link_id,
+	last_link_id,
+	created_at,
+	updated_at
+) VALUES (
+	?1, ?2, ?3, DATETIME('now'), DATETIME('now')
+)
+ON CONFLICT (checkout_id) DO UPDATE SET
+	last_link_id = excluded.last_link_id,
+	updated_at = DATETIME('now')
+`
+
+type UpsertCheckoutLinkAttributionParams struct {
+	CheckoutID  int64
+	FirstLinkID string
+	LastLinkID  string
+}
+
+func (q *Queries) UpsertCheckoutLinkAttribution(ctx context.Context, arg UpsertCheckoutLinkAttributionParams) error {
+	_, err := q.db.ExecContext(ctx, upsertCheckoutLinkAttribution, arg.CheckoutID, arg.FirstLinkID, arg.LastLinkID)
+	return err
+}
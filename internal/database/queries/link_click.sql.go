@@ -13,11 +13,36 @@ import (
 const countLinkClicksByLinkID = `-- name: CountLinkClicksByLinkID :one
 SELECT COUNT(*) as count
 FROM tbl_link_clicks
-WHERE link_id = ?
+WHERE link_id = ?1
+AND (CAST(?2 AS BOOLEAN) OR (is_bot = 0 AND is_duplicate = 0))
 `
 
-func (q *Queries) CountLinkClicksByLinkID(ctx context.Context, linkID string) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countLinkClicksByLinkID, linkID)
+type CountLinkClicksByLinkIDParams struct {
+	LinkID          string
+	IncludeFiltered bool
+}
+
+func (q *Queries) CountLinkClicksByLinkID(ctx context.Context, arg CountLinkClicksByLinkIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countLinkClicksByLinkID, arg.LinkID, arg.IncludeFiltered)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countRecentLinkClicksByIPHash = `-- name: CountRecentLinkClicksByIPHash :one
+SELECT COUNT(*) AS count
+FROM tbl_link_clicks
+WHERE link_id = ?1 AND ip_hash = ?2 AND clicked_at >= ?3
+`
+
+type CountRecentLinkClicksByIPHashParams struct {
+	LinkID string
+	IpHash sql.NullString
+	Since  string
+}
+
+func (q *Queries) CountRecentLinkClicksByIPHash(ctx context.Context, arg CountRecentLinkClicksByIPHashParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countRecentLinkClicksByIPHash, arg.LinkID, arg.IpHash, arg.Since)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -33,11 +58,13 @@ INSERT INTO tbl_link_clicks (
     device,
     utm_source,
     utm_medium,
-    utm_campaign
+    utm_campaign,
+    is_bot,
+    is_duplicate
 ) VALUES (
     ?,
     datetime('now'),
-    ?, ?, ?, ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
 )
 `
 
@@ -50,6 +77,8 @@ type CreateLinkClickParams struct {
 	UtmSource   sql.NullString
 	UtmMedium   sql.NullString
 	UtmCampaign sql.NullString
+	IsBot       bool
+	IsDuplicate bool
 }
 
 func (q *Queries) CreateLinkClick(ctx context.Context, arg CreateLinkClickParams) error {
@@ -62,10 +91,82 @@ func (q *Queries) CreateLinkClick(ctx context.Context, arg CreateLinkClickParams
 		arg.UtmSource,
 		arg.UtmMedium,
 		arg.UtmCampaign,
+		arg.IsBot,
+		arg.IsDuplicate,
 	)
 	return err
 }
 
+const getLinkClickTotals = `-- name: GetLinkClickTotals :one
+SELECT
+    COUNT(*) AS clicks,
+    COUNT(DISTINCT ip_hash) AS unique_visitors
+FROM tbl_link_clicks
+WHERE link_id = ?1
+AND (CAST(?2 AS BOOLEAN) OR (is_bot = 0 AND is_duplicate = 0))
+`
+
+type GetLinkClickTotalsParams struct {
+	LinkID          string
+	IncludeFiltered bool
+}
+
+type GetLinkClickTotalsRow struct {
+	Clicks         int64
+	UniqueVisitors int64
+}
+
+func (q *Queries) GetLinkClickTotals(ctx context.Context, arg GetLinkClickTotalsParams) (GetLinkClickTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getLinkClickTotals, arg.LinkID, arg.IncludeFiltered)
+	var i GetLinkClickTotalsRow
+	err := row.Scan(&i.Clicks, &i.UniqueVisitors)
+	return i, err
+}
+
+const getLinkClicksByDevice = `-- name: GetLinkClicksByDevice :many
+SELECT
+    CAST(COALESCE(NULLIF(device, ''), 'Unknown') AS TEXT) AS label,
+    COUNT(*) AS clicks
+FROM tbl_link_clicks
+WHERE link_id = ?1
+AND (CAST(?2 AS BOOLEAN) OR (is_bot = 0 AND is_duplicate = 0))
+GROUP BY label
+ORDER BY clicks DESC
+`
+
+type GetLinkClicksByDeviceParams struct {
+	LinkID          string
+	IncludeFiltered bool
+}
+
+type GetLinkClicksByDeviceRow struct {
+	Label  string
+	Clicks int64
+}
+
+func (q *Queries) GetLinkClicksByDevice(ctx context.Context, arg GetLinkClicksByDeviceParams) ([]GetLinkClicksByDeviceRow, error) {
+	rows, err := q.db.QueryContext(ctx, getLinkClicksByDevice, arg.LinkID, arg.IncludeFiltered)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLinkClicksByDeviceRow
+	for rows.Next() {
+		var i GetLinkClicksByDeviceRow
+		if err := rows.Scan(&i.Label, &i.Clicks); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLinkClicksByLinkID = `-- name: GetLinkClicksByLinkID :many
 SELECT
     id,
@@ -77,14 +178,22 @@ SELECT
     device,
     utm_source,
     utm_medium,
-    utm_campaign
+    utm_campaign,
+    is_bot,
+    is_duplicate
 FROM tbl_link_clicks
-WHERE link_id = ?
+WHERE link_id = ?1
+AND (CAST(?2 AS BOOLEAN) OR (is_bot = 0 AND is_duplicate = 0))
 ORDER BY clicked_at DESC
 `
 
-func (q *Queries) GetLinkClicksByLinkID(ctx context.Context, linkID string) ([]TblLinkClick, error) {
-	rows, err := q.db.QueryContext(ctx, getLinkClicksByLinkID, linkID)
+type GetLinkClicksByLinkIDParams struct {
+	LinkID          string
+	IncludeFiltered bool
+}
+
+func (q *Queries) GetLinkClicksByLinkID(ctx context.Context, arg GetLinkClicksByLinkIDParams) ([]TblLinkClick, error) {
+	rows, err := q.db.QueryContext(ctx, getLinkClicksByLinkID, arg.LinkID, arg.IncludeFiltered)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +212,8 @@ func (q *Queries) GetLinkClicksByLinkID(ctx context.Context, linkID string) ([]T
 			&i.UtmSource,
 			&i.UtmMedium,
 			&i.UtmCampaign,
+			&i.IsBot,
+			&i.IsDuplicate,
 		); err != nil {
 			return nil, err
 		}
@@ -116,3 +227,116 @@ func (q *Queries) GetLinkClicksByLinkID(ctx context.Context, linkID string) ([]T
 	}
 	return items, nil
 }
+
+const getLinkClicksByReferrer = `-- name: GetLinkClicksByReferrer :many
+SELECT
+    CAST(COALESCE(NULLIF(referrer, ''), 'Direct') AS TEXT) AS label,
+    COUNT(*) AS clicks
+FROM tbl_link_clicks
+WHERE link_id = ?1
+AND (CAST(?2 AS BOOLEAN) OR (is_bot = 0 AND is_duplicate = 0))
+GROUP BY label
+ORDER BY clicks DESC
+LIMIT ?3
+`
+
+type GetLinkClicksByReferrerParams struct {
+	LinkID          string
+	IncludeFiltered bool
+	Limit           int64
+}
+
+type GetLinkClicksByReferrerRow struct {
+	Label  string
+	Clicks int64
+}
+
+func (q *Queries) GetLinkClicksByReferrer(ctx context.Context, arg GetLinkClicksByReferrerParams) ([]GetLinkClicksByReferrerRow, error) {
+	rows, err := q.db.QueryContext(ctx, getLinkClicksByReferrer, arg.LinkID, arg.IncludeFiltered, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLinkClicksByReferrerRow
+	for rows.Next() {
+		var i GetLinkClicksByReferrerRow
+		if err := rows.Scan(&i.Label, &i.Clicks); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLinkClicksPerDay = `-- name: GetLinkClicksPerDay :many
+SELECT
+    CAST(DATE(clicked_at) AS TEXT) AS day,
+    COUNT(*) AS clicks,
+    COUNT(DISTINCT ip_hash) AS unique_visitors
+FROM tbl_link_clicks
+WHERE link_id = ?1 AND clicked_at >= ?2
+AND (CAST(?3 AS BOOLEAN) OR (is_bot = 0 AND is_duplicate = 0))
+GROUP BY DATE(clicked_at)
+ORDER BY day
+`
+
+type GetLinkClicksPerDayParams struct {
+	LinkID          string
+	Since           string
+	IncludeFiltered bool
+}
+
+type GetLinkClicksPerDayRow struct {
+	Day            string
+	Clicks         int64
+	UniqueVisitors int64
+}
+
+func (q *Queries) GetLinkClicksPerDay(ctx context.Context, arg GetLinkClicksPerDayParams) ([]GetLinkClicksPerDayRow, error) {
+	rows, err := q.db.QueryContext(ctx, getLinkClicksPerDay, arg.LinkID, arg.Since, arg.IncludeFiltered)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLinkClicksPerDayRow
+	for rows.Next() {
+		var i GetLinkClicksPerDayRow
+		if err := rows.Scan(&i.Day, &i.Clicks, &i.UniqueVisitors); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLinkFilteredClickTotals = `-- name: GetLinkFilteredClickTotals :one
+SELECT
+    CAST(COALESCE(SUM(is_bot), 0) AS INTEGER) AS bots,
+    CAST(COALESCE(SUM(CASE WHEN is_bot = 0 THEN is_duplicate ELSE 0 END), 0) AS INTEGER) AS duplicates
+FROM tbl_link_clicks
+WHERE link_id = ?1
+`
+
+type GetLinkFilteredClickTotalsRow struct {
+	Bots       int64
+	Duplicates int64
+}
+
+func (q *Queries) GetLinkFilteredClickTotals(ctx context.Context, linkID string) (GetLinkFilteredClickTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getLinkFilteredClickTotals, linkID)
+	var i GetLinkFilteredClickTotalsRow
+	err := row.Scan(&i.Bots, &i.Duplicates)
+	return i, err
+}
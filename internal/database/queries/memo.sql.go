@@ -10,6 +10,19 @@ import (
 	"database/sql"
 )
 
+const clearMemoEscalated = `-- name: ClearMemoEscalated :exec
+UPDATE tbl_memos
+SET
+    escalated_at = NULL,
+    updated_at = datetime('now')
+WHERE id = ?
+`
+
+func (q *Queries) ClearMemoEscalated(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, clearMemoEscalated, id)
+	return err
+}
+
 const createMemo = `-- name: CreateMemo :one
 INSERT INTO tbl_memos (
     title,
@@ -18,12 +31,14 @@ INSERT INTO tbl_memos (
     status,
     start_date,
     end_date,
+    requires_acknowledgement,
+    acknowledge_by,
     created_by,
     created_at,
     updated_at,
     deleted_at
 ) VALUES (
-    ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?,
     datetime('now'),
     datetime('now'),
     '1970-01-01 00:00:00+00:00'
@@ -31,13 +46,15 @@ INSERT INTO tbl_memos (
 `
 
 type CreateMemoParams struct {
-	Title     string
-	Message   string
-	FileUrl   sql.NullString
-	Status    string
-	StartDate string
-	EndDate   string
-	CreatedBy int64
+	Title                   string
+	Message                 string
+	FileUrl                 sql.NullString
+	Status                  string
+	StartDate               string
+	EndDate                 string
+	RequiresAcknowledgement bool
+	AcknowledgeBy           sql.NullString
+	CreatedBy               int64
 }
 
 func (q *Queries) CreateMemo(ctx context.Context, arg CreateMemoParams) (int64, error) {
@@ -48,6 +65,8 @@ func (q *Queries) CreateMemo(ctx context.Context, arg CreateMemoParams) (int64,
 		arg.Status,
 		arg.StartDate,
 		arg.EndDate,
+		arg.RequiresAcknowledgement,
+		arg.AcknowledgeBy,
 		arg.CreatedBy,
 	)
 	var id int64
@@ -57,7 +76,7 @@ func (q *Queries) CreateMemo(ctx context.Context, arg CreateMemoParams) (int64,
 
 const getAllMemos = `-- name: GetAllMemos :many
 SELECT
-    m.id, m.title, m.message, m.status, m.start_date, m.end_date, m.created_by, m.created_at, m.updated_at, m.deleted_at, m.file_url, m.emails_sent_at,
+    m.id, m.title, m.message, m.status, m.start_date, m.end_date, m.created_by, m.created_at, m.updated_at, m.deleted_at, m.file_url, m.emails_sent_at, m.requires_acknowledgement, m.acknowledge_by, m.escalated_at,
     s.first_name AS creator_first_name,
     s.middle_name AS creator_middle_name,
     s.last_name AS creator_last_name,
@@ -98,6 +117,9 @@ func (q *Queries) GetAllMemos(ctx context.Context) ([]GetAllMemosRow, error) {
 			&i.TblMemo.DeletedAt,
 			&i.TblMemo.FileUrl,
 			&i.TblMemo.EmailsSentAt,
+			&i.TblMemo.RequiresAcknowledgement,
+			&i.TblMemo.AcknowledgeBy,
+			&i.TblMemo.EscalatedAt,
 			&i.CreatorFirstName,
 			&i.CreatorMiddleName,
 			&i.CreatorLastName,
@@ -117,7 +139,7 @@ func (q *Queries) GetAllMemos(ctx context.Context) ([]GetAllMemosRow, error) {
 }
 
 const getMemoByID = `-- name: GetMemoByID :one
-SELECT tbl_memos.id, tbl_memos.title, tbl_memos.message, tbl_memos.status, tbl_memos.start_date, tbl_memos.end_date, tbl_memos.created_by, tbl_memos.created_at, tbl_memos.updated_at, tbl_memos.deleted_at, tbl_memos.file_url, tbl_memos.emails_sent_at
+SELECT tbl_memos.id, tbl_memos.title, tbl_memos.message, tbl_memos.status, tbl_memos.start_date, tbl_memos.end_date, tbl_memos.created_by, tbl_memos.created_at, tbl_memos.updated_at, tbl_memos.deleted_at, tbl_memos.file_url, tbl_memos.emails_sent_at, tbl_memos.requires_acknowledgement, tbl_memos.acknowledge_by, tbl_memos.escalated_at
 FROM tbl_memos
 WHERE id = ?
 AND deleted_at = '1970-01-01 00:00:00+00:00'
@@ -144,13 +166,16 @@ func (q *Queries) GetMemoByID(ctx context.Context, id int64) (GetMemoByIDRow, er
 		&i.TblMemo.DeletedAt,
 		&i.TblMemo.FileUrl,
 		&i.TblMemo.EmailsSentAt,
+		&i.TblMemo.RequiresAcknowledgement,
+		&i.TblMemo.AcknowledgeBy,
+		&i.TblMemo.EscalatedAt,
 	)
 	return i, err
 }
 
 const getMemoWithCreatorByID = `-- name: GetMemoWithCreatorByID :one
 SELECT
-    m.id, m.title, m.message, m.status, m.start_date, m.end_date, m.created_by, m.created_at, m.updated_at, m.deleted_at, m.file_url, m.emails_sent_at,
+    m.id, m.title, m.message, m.status, m.start_date, m.end_date, m.created_by, m.created_at, m.updated_at, m.deleted_at, m.file_url, m.emails_sent_at, m.requires_acknowledgement, m.acknowledge_by, m.escalated_at,
     s.first_name AS creator_first_name,
     s.middle_name AS creator_middle_name,
     s.last_name AS creator_last_name,
@@ -186,6 +211,9 @@ func (q *Queries) GetMemoWithCreatorByID(ctx context.Context, id int64) (GetMemo
 		&i.TblMemo.DeletedAt,
 		&i.TblMemo.FileUrl,
 		&i.TblMemo.EmailsSentAt,
+		&i.TblMemo.RequiresAcknowledgement,
+		&i.TblMemo.AcknowledgeBy,
+		&i.TblMemo.EscalatedAt,
 		&i.CreatorFirstName,
 		&i.CreatorMiddleName,
 		&i.CreatorLastName,
@@ -194,8 +222,79 @@ func (q *Queries) GetMemoWithCreatorByID(ctx context.Context, id int64) (GetMemo
 	return i, err
 }
 
+const getMemosDueForEscalation = `-- name: GetMemosDueForEscalation :many
+SELECT m.id, m.title, m.message, m.status, m.start_date, m.end_date, m.created_by, m.created_at, m.updated_at, m.deleted_at, m.file_url, m.emails_sent_at, m.requires_acknowledgement, m.acknowledge_by, m.escalated_at
+FROM tbl_memos m
+WHERE m.deleted_at = '1970-01-01 00:00:00+00:00'
+AND m.status = 'PUBLISHED'
+AND m.requires_acknowledgement = 1
+AND m.acknowledge_by IS NOT NULL
+AND m.acknowledge_by < ?1
+AND m.escalated_at IS NULL
+AND EXISTS (
+    SELECT 1
+    FROM tbl_memo_recipients r
+    JOIN tbl_staffs s ON s.id = r.staff_id
+    LEFT JOIN tbl_memo_staff_actions a ON a.memo_id = r.memo_id AND a.staff_id = r.staff_id
+    WHERE r.memo_id = m.id
+    AND a.id IS NULL
+    AND s.deleted_at = '1970-01-01 00:00:00+00:00'
+    AND s.status != 'RESIGNED'
+)
+ORDER BY m.acknowledge_by ASC, m.id ASC
+LIMIT ?2
+`
+
+type GetMemosDueForEscalationParams struct {
+	Today sql.NullString
+	Limit int64
+}
+
+type GetMemosDueForEscalationRow struct {
+	TblMemo TblMemo
+}
+
+func (q *Queries) GetMemosDueForEscalation(ctx context.Context, arg GetMemosDueForEscalationParams) ([]GetMemosDueForEscalationRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMemosDueForEscalation, arg.Today, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMemosDueForEscalationRow
+	for rows.Next() {
+		var i GetMemosDueForEscalationRow
+		if err := rows.Scan(
+			&i.TblMemo.ID,
+			&i.TblMemo.Title,
+			&i.TblMemo.Message,
+			&i.TblMemo.Status,
+			&i.TblMemo.StartDate,
+			&i.TblMemo.EndDate,
+			&i.TblMemo.CreatedBy,
+			&i.TblMemo.CreatedAt,
+			&i.TblMemo.UpdatedAt,
+			&i.TblMemo.DeletedAt,
+			&i.TblMemo.FileUrl,
+			&i.TblMemo.EmailsSentAt,
+			&i.TblMemo.RequiresAcknowledgement,
+			&i.TblMemo.AcknowledgeBy,
+			&i.TblMemo.EscalatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPendingMemosForStaff = `-- name: GetPendingMemosForStaff :many
-SELECT m.id, m.title, m.message, m.status, m.start_date, m.end_date, m.created_by, m.created_at, m.updated_at, m.deleted_at, m.file_url, m.emails_sent_at
+SELECT m.id, m.title, m.message, m.status, m.start_date, m.end_date, m.created_by, m.created_at, m.updated_at, m.deleted_at, m.file_url, m.emails_sent_at, m.requires_acknowledgement, m.acknowledge_by, m.escalated_at
 FROM tbl_memos m
 JOIN tbl_memo_recipients r ON r.memo_id = m.id AND r.staff_id = ?
 LEFT JOIN tbl_memo_staff_actions a ON a.memo_id = m.id AND a.staff_id = ?
@@ -238,6 +337,9 @@ func (q *Queries) GetPendingMemosForStaff(ctx context.Context, arg GetPendingMem
 			&i.TblMemo.DeletedAt,
 			&i.TblMemo.FileUrl,
 			&i.TblMemo.EmailsSentAt,
+			&i.TblMemo.RequiresAcknowledgement,
+			&i.TblMemo.AcknowledgeBy,
+			&i.TblMemo.EscalatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -252,6 +354,23 @@ func (q *Queries) GetPendingMemosForStaff(ctx context.Context, arg GetPendingMem
 	return items, nil
 }
 
+const markMemoEscalated = `-- name: MarkMemoEscalated :execrows
+UPDATE tbl_memos
+SET
+    escalated_at = datetime('now'),
+    updated_at = datetime('now')
+WHERE id = ?
+AND escalated_at IS NULL
+`
+
+func (q *Queries) MarkMemoEscalated(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markMemoEscalated, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const softDeleteMemo = `-- name: SoftDeleteMemo :exec
 UPDATE tbl_memos
 SET
@@ -276,19 +395,23 @@ SET
     status = ?,
     start_date = ?,
     end_date = ?,
+    requires_acknowledgement = ?,
+    acknowledge_by = ?,
     updated_at = datetime('now')
 WHERE id = ?
 AND deleted_at = '1970-01-01 00:00:00+00:00'
 `
 
 type UpdateMemoParams struct {
-	Title     string
-	Message   string
-	FileUrl   sql.NullString
-	Status    string
-	StartDate string
-	EndDate   string
-	ID        int64
+	Title                   string
+	Message                 string
+	FileUrl                 sql.NullString
+	Status                  string
+	StartDate               string
+	EndDate                 string
+	RequiresAcknowledgement bool
+	AcknowledgeBy           sql.NullString
+	ID                      int64
 }
 
 func (q *Queries) UpdateMemo(ctx context.Context, arg UpdateMemoParams) error {
@@ -299,6 +422,8 @@ func (q *Queries) UpdateMemo(ctx context.Context, arg UpdateMemoParams) error {
 		arg.Status,
 		arg.StartDate,
 		arg.EndDate,
+		arg.RequiresAcknowledgement,
+		arg.AcknowledgeBy,
 		arg.ID,
 	)
 	return err
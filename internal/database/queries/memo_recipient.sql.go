@@ -108,6 +108,71 @@ func (q *Queries) GetMemoRecipientStaffIDs(ctx context.Context, memoID int64) ([
 	return items, nil
 }
 
+const getMemoRecipientsDueForReminder = `-- name: GetMemoRecipientsDueForReminder :many
+SELECT
+    r.memo_id,
+    r.staff_id,
+    s.email,
+    m.title
+FROM tbl_memo_recipients r
+JOIN tbl_memos m ON m.id = r.memo_id
+JOIN tbl_staffs s ON s.id = r.staff_id
+LEFT JOIN tbl_memo_staff_actions a ON a.memo_id = r.memo_id AND a.staff_id = r.staff_id
+WHERE m.deleted_at = '1970-01-01 00:00:00+00:00'
+AND m.status = 'PUBLISHED'
+AND m.requires_acknowledgement = 1
+AND m.start_date <= ?1
+AND m.end_date >= ?1
+AND a.id IS NULL
+AND s.email != ''
+AND s.deleted_at = '1970-01-01 00:00:00+00:00'
+AND s.status != 'RESIGNED'
+AND COALESCE(r.last_reminded_at, r.created_at) <= ?2
+ORDER BY r.memo_id ASC, r.staff_id ASC
+LIMIT ?3
+`
+
+type GetMemoRecipientsDueForReminderParams struct {
+	Today        string
+	RemindBefore sql.NullString
+	Limit        int64
+}
+
+type GetMemoRecipientsDueForReminderRow struct {
+	MemoID  int64
+	StaffID int64
+	Email   string
+	Title   string
+}
+
+func (q *Queries) GetMemoRecipientsDueForReminder(ctx context.Context, arg GetMemoRecipientsDueForReminderParams) ([]GetMemoRecipientsDueForReminderRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMemoRecipientsDueForReminder, arg.Today, arg.RemindBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMemoRecipientsDueForReminderRow
+	for rows.Next() {
+		var i GetMemoRecipientsDueForReminderRow
+		if err := rows.Scan(
+			&i.MemoID,
+			&i.StaffID,
+			&i.Email,
+			&i.Title,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getMemoRecipientsWithActions = `-- name: GetMemoRecipientsWithActions :many
 SELECT
     r.staff_id,
@@ -117,6 +182,8 @@ SELECT
     s.email,
     s.position,
     s.user_type,
+    r.reminder_count,
+    r.last_reminded_at,
     a.status AS action_status,
     a.reject_reason,
     a.accepted_at,
@@ -139,6 +206,8 @@ type GetMemoRecipientsWithActionsRow struct {
 	Email           string
 	Position        string
 	UserType        string
+	ReminderCount   int64
+	LastRemindedAt  sql.NullString
 	ActionStatus    sql.NullString
 	RejectReason    sql.NullString
 	AcceptedAt      sql.NullString
@@ -163,6 +232,8 @@ func (q *Queries) GetMemoRecipientsWithActions(ctx context.Context, memoID int64
 			&i.Email,
 			&i.Position,
 			&i.UserType,
+			&i.ReminderCount,
+			&i.LastRemindedAt,
 			&i.ActionStatus,
 			&i.RejectReason,
 			&i.AcceptedAt,
@@ -182,6 +253,77 @@ func (q *Queries) GetMemoRecipientsWithActions(ctx context.Context, memoID int64
 	return items, nil
 }
 
+const getMemosReceivedByStaff = `-- name: GetMemosReceivedByStaff :many
+SELECT
+    m.id,
+    m.title,
+    m.start_date,
+    m.end_date,
+    m.requires_acknowledgement,
+    m.acknowledge_by,
+    a.status AS action_status,
+    a.reject_reason,
+    a.created_at AS action_created_at
+FROM tbl_memo_recipients r
+JOIN tbl_memos m ON m.id = r.memo_id
+LEFT JOIN tbl_memo_staff_actions a ON a.memo_id = r.memo_id AND a.staff_id = r.staff_id
+WHERE r.staff_id = ?1
+AND m.deleted_at = '1970-01-01 00:00:00+00:00'
+AND m.status != 'DRAFT'
+ORDER BY m.start_date DESC, m.id DESC
+LIMIT ?2
+`
+
+type GetMemosReceivedByStaffParams struct {
+	StaffID int64
+	Limit   int64
+}
+
+type GetMemosReceivedByStaffRow struct {
+	ID                      int64
+	Title                   string
+	StartDate               string
+	EndDate                 string
+	RequiresAcknowledgement bool
+	AcknowledgeBy           sql.NullString
+	ActionStatus            sql.NullString
+	RejectReason            sql.NullString
+	ActionCreatedAt         sql.NullString
+}
+
+func (q *Queries) GetMemosReceivedByStaff(ctx context.Context, arg GetMemosReceivedByStaffParams) ([]GetMemosReceivedByStaffRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMemosReceivedByStaff, arg.StaffID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMemosReceivedByStaffRow
+	for rows.Next() {
+		var i GetMemosReceivedByStaffRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.StartDate,
+			&i.EndDate,
+			&i.RequiresAcknowledgement,
+			&i.AcknowledgeBy,
+			&i.ActionStatus,
+			&i.RejectReason,
+			&i.ActionCreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const isMemoRecipient = `-- name: IsMemoRecipient :one
 SELECT COUNT(*) AS count
 FROM tbl_memo_recipients
@@ -199,3 +341,21 @@ func (q *Queries) IsMemoRecipient(ctx context.Context, arg IsMemoRecipientParams
 	err := row.Scan(&count)
 	return count, err
 }
+
+const markMemoRecipientReminded = `-- name: MarkMemoRecipientReminded :exec
+UPDATE tbl_memo_recipients
+SET
+    reminder_count = reminder_count + 1,
+    last_reminded_at = datetime('now')
+WHERE memo_id = ? AND staff_id = ?
+`
+
+type MarkMemoRecipientRemindedParams struct {
+	MemoID  int64
+	StaffID int64
+}
+
+func (q *Queries) MarkMemoRecipientReminded(ctx context.Context, arg MarkMemoRecipientRemindedParams) error {
+	_, err := q.db.ExecContext(ctx, markMemoRecipientReminded, arg.MemoID, arg.StaffID)
+	return err
+}
@@ -20,6 +20,19 @@ type Goqite struct {
 	Priority int64
 }
 
+type TblAttendanceKioskPunch struct {
+	ID           int64
+	StaffID      int64
+	KioskStaffID int64
+	ForDate      string
+	PunchType    string
+	Method       string
+	PhotoKey     string
+	UseragentID  sql.NullInt64
+	CreatedAt    time.Time
+	TokenNonce   sql.NullString
+}
+
 type TblBrand struct {
 	ID        int64
 	Name      string
@@ -41,11 +54,16 @@ type TblBrandImage struct {
 }
 
 type TblCheckout struct {
-	ID        int64
-	SessionID string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Status    string
+	ID                int64
+	SessionID         string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Status            string
+	CustomerID        sql.NullInt64
+	Email             sql.NullString
+	ReminderSentAt    sql.NullTime
+	RecoveryTokenHash sql.NullString
+	RecoveredAt       sql.NullTime
 }
 
 type TblCheckoutLine struct {
@@ -62,6 +80,14 @@ type TblCheckoutLine struct {
 	UpdatedAt   time.Time
 }
 
+type TblCheckoutLinkAttribution struct {
+	CheckoutID  int64
+	FirstLinkID string
+	LastLinkID  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
 type TblCheckoutPayment struct {
 	ID                     string
 	Gateway                string
@@ -82,6 +108,26 @@ type TblCheckoutPayment struct {
 	PaymentIntentID        sql.NullString
 }
 
+type TblClientEvent struct {
+	ID          int64
+	Event       string
+	Value       string
+	SessionHash string
+	CustomerID  sql.NullInt64
+	ProductID   sql.NullInt64
+	ResultCount sql.NullInt64
+	CreatedAt   time.Time
+}
+
+type TblClientEventRollup struct {
+	Day         string
+	Event       string
+	Value       string
+	Hits        int64
+	Sessions    int64
+	ZeroResults int64
+}
+
 type TblCpoint struct {
 	ID          int64
 	CustomerID  int64
@@ -144,6 +190,44 @@ type TblEmailJob struct {
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 	MemoID            sql.NullInt64
+	ProductID         sql.NullInt64
+	CheckoutID        sql.NullInt64
+}
+
+type TblExperiment struct {
+	ID        int64
+	Name      string
+	Kind      string
+	Status    string
+	StartDate string
+	EndDate   string
+	CreatedBy int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type TblExperimentExposure struct {
+	ID            int64
+	ExperimentID  int64
+	VariantID     int64
+	BucketKey     string
+	CheckoutID    sql.NullInt64
+	AddedToCartAt sql.NullTime
+	CreatedAt     time.Time
+}
+
+type TblExperimentVariant struct {
+	ID           int64
+	ExperimentID int64
+	Name         string
+	Weight       int64
+	ThemeID      sql.NullInt64
+	CreatedAt    time.Time
+}
+
+type TblExperimentVariantPromo struct {
+	VariantID int64
+	PromoID   int64
 }
 
 type TblExternalApiLog struct {
@@ -196,28 +280,35 @@ type TblLinkClick struct {
 	UtmSource   sql.NullString
 	UtmMedium   sql.NullString
 	UtmCampaign sql.NullString
+	IsBot       bool
+	IsDuplicate bool
 }
 
 type TblMemo struct {
-	ID           int64
-	Title        string
-	Message      string
-	Status       string
-	StartDate    string
-	EndDate      string
-	CreatedBy    int64
-	CreatedAt    string
-	UpdatedAt    string
-	DeletedAt    string
-	FileUrl      sql.NullString
-	EmailsSentAt string
+	ID                      int64
+	Title                   string
+	Message                 string
+	Status                  string
+	StartDate               string
+	EndDate                 string
+	CreatedBy               int64
+	CreatedAt               string
+	UpdatedAt               string
+	DeletedAt               string
+	FileUrl                 sql.NullString
+	EmailsSentAt            string
+	RequiresAcknowledgement bool
+	AcknowledgeBy           sql.NullString
+	EscalatedAt             sql.NullString
 }
 
 type TblMemoRecipient struct {
-	ID        int64
-	MemoID    int64
-	StaffID   int64
-	CreatedAt string
+	ID             int64
+	MemoID         int64
+	StaffID        int64
+	CreatedAt      string
+	ReminderCount  int64
+	LastRemindedAt sql.NullString
 }
 
 type TblMemoStaffAction struct {
@@ -318,6 +409,45 @@ type TblPasswordResetToken struct {
 	UpdatedAt sql.NullTime
 }
 
+type TblPayrollRun struct {
+	ID          int64
+	PeriodStart string
+	PeriodEnd   string
+	Status      string
+	CreatedBy   int64
+	LockedBy    sql.NullInt64
+	LockedAt    sql.NullTime
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type TblPayrollRunItem struct {
+	ID                 int64
+	PayrollRunID       int64
+	StaffID            int64
+	RateType           string
+	Rate               int64
+	DailyRate          int64
+	DaysWorked         int64
+	AbsentDays         int64
+	LateMinutes        int64
+	UndertimeMinutes   int64
+	OvertimeMinutes    int64
+	BasicPay           int64
+	HolidayPay         int64
+	OvertimePay        int64
+	LateDeduction      int64
+	UndertimeDeduction int64
+	AbsenceDeduction   int64
+	GrossPay           int64
+	Sss                int64
+	Philhealth         int64
+	Pagibig            int64
+	WithholdingTax     int64
+	NetPay             int64
+	CreatedAt          time.Time
+}
+
 type TblProduct struct {
 	ID                          int64
 	Serial                      string
@@ -373,6 +503,37 @@ type TblProductInventory struct {
 	UpdatedAt string
 }
 
+type TblProductRating struct {
+	ProductID   int64
+	RatingCount int64
+	RatingTotal int64
+	UpdatedAt   time.Time
+}
+
+type TblProductReview struct {
+	ID              int64
+	ProductID       int64
+	CustomerID      int64
+	OrderID         int64
+	Rating          int64
+	Title           string
+	Body            string
+	Status          string
+	ModeratedBy     sql.NullInt64
+	ModeratedAt     sql.NullTime
+	RejectionReason string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type TblProductReviewPhoto struct {
+	ID        int64
+	ReviewID  int64
+	Path      string
+	Url       string
+	CreatedAt time.Time
+}
+
 type TblProductSale struct {
 	ID                          int64
 	ProductID                   int64
@@ -388,6 +549,8 @@ type TblProductSale struct {
 	CreatedAt                   time.Time
 	UpdatedAt                   time.Time
 	DeletedAt                   time.Time
+	SaleCampaignID              sql.NullInt64
+	NotifiedAt                  sql.NullTime
 }
 
 type TblProductSpec struct {
@@ -466,6 +629,68 @@ type TblQuotationStatusHistory struct {
 	UpdatedAt   time.Time
 }
 
+type TblRoleGroup struct {
+	ID          int64
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type TblRoleGroupPermission struct {
+	ID          int64
+	RoleGroupID int64
+	Role        string
+	Access      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type TblSaleCampaign struct {
+	ID            int64
+	Name          string
+	Selector      string
+	SelectorValue string
+	DiscountType  string
+	DiscountValue int64
+	StartsAt      time.Time
+	EndsAt        time.Time
+	Status        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type TblSaleCampaignProduct struct {
+	ID             int64
+	SaleCampaignID int64
+	ProductID      int64
+}
+
+type TblSearchRule struct {
+	ID         int64
+	SearchTerm string
+	Kind       string
+	ProductID  sql.NullInt64
+	CategoryID sql.NullInt64
+	BrandID    sql.NullInt64
+	Position   int64
+	CreatedBy  int64
+	CreatedAt  time.Time
+}
+
+type TblSession struct {
+	ID            int64
+	UserType      string
+	UserID        int64
+	KeyHash       string
+	UseragentID   sql.NullInt64
+	IpAddress     string
+	CreatedAt     time.Time
+	LastSeenAt    time.Time
+	RevokedAt     sql.NullTime
+	RevokedReason sql.NullString
+}
+
 type TblSetting struct {
 	ID    int64
 	Name  string
@@ -528,6 +753,71 @@ type TblStaffAttendance struct {
 	HolidayName              sql.NullString
 }
 
+type TblStaffAttendanceCorrection struct {
+	ID                    int64
+	StaffID               int64
+	ForDate               string
+	TimeIn                sql.NullString
+	TimeOut               sql.NullString
+	LunchBreakIn          sql.NullString
+	LunchBreakOut         sql.NullString
+	Reason                string
+	Status                string
+	OriginalTimeIn        sql.NullString
+	OriginalTimeOut       sql.NullString
+	OriginalLunchBreakIn  sql.NullString
+	OriginalLunchBreakOut sql.NullString
+	DecidedBy             sql.NullInt64
+	DecidedAt             sql.NullTime
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+type TblStaffIncident struct {
+	ID               int64
+	StaffID          int64
+	ReportedBy       int64
+	IncidentDate     string
+	Title            string
+	Description      string
+	Status           string
+	NteMemoID        sql.NullInt64
+	ResponseDeadline sql.NullString
+	Response         sql.NullString
+	RespondedAt      sql.NullTime
+	Decision         sql.NullString
+	DecisionNotes    sql.NullString
+	EffectiveFrom    sql.NullString
+	EffectiveTo      sql.NullString
+	DecidedBy        sql.NullInt64
+	DecidedAt        sql.NullTime
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type TblStaffKioskPin struct {
+	StaffID        int64
+	PinHash        string
+	FailedAttempts int64
+	LockedUntil    sql.NullTime
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type TblStaffLeaveLedger struct {
+	ID        int64
+	StaffID   int64
+	LeaveType string
+	EntryType string
+	Year      int64
+	Period    string
+	Credits   int64
+	TimeOffID sql.NullInt64
+	Note      string
+	CreatedBy sql.NullInt64
+	CreatedAt time.Time
+}
+
 type TblStaffLog struct {
 	ID          int64
 	StaffID     int64
@@ -538,12 +828,96 @@ type TblStaffLog struct {
 	UseragentID sql.NullInt64
 }
 
+type TblStaffOtpCode struct {
+	ID        int64
+	StaffID   int64
+	OtpCode   string
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
+type TblStaffPayRate struct {
+	ID        int64
+	StaffID   int64
+	RateType  string
+	Rate      int64
+	UpdatedBy sql.NullInt64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type TblStaffRecoveryCode struct {
+	ID        int64
+	StaffID   int64
+	CodeHash  string
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
 type TblStaffRole struct {
 	ID        int64
 	StaffID   int64
 	Role      string
 	CreatedAt sql.NullTime
 	UpdatedAt sql.NullTime
+	Access    string
+}
+
+type TblStaffRoleGroup struct {
+	ID          int64
+	StaffID     int64
+	RoleGroupID int64
+	CreatedAt   time.Time
+}
+
+type TblStaffShiftDay struct {
+	ID        int64
+	StaffID   int64
+	WeekIndex int64
+	Weekday   int64
+	TimeIn    string
+	TimeOut   string
+	RestDay   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type TblStaffShiftOverride struct {
+	ID          int64
+	StaffID     int64
+	ForDate     string
+	TimeIn      string
+	TimeOut     string
+	RestDay     bool
+	Note        string
+	ShiftSwapID sql.NullInt64
+	CreatedBy   sql.NullInt64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type TblStaffShiftRotation struct {
+	ID         int64
+	StaffID    int64
+	CycleWeeks int64
+	AnchorDate string
+	UpdatedBy  sql.NullInt64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type TblStaffShiftSwap struct {
+	ID            int64
+	RequesterID   int64
+	CounterpartID int64
+	ForDate       string
+	Reason        string
+	Status        string
+	DecidedBy     sql.NullInt64
+	DecidedAt     sql.NullTime
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 type TblStaffTimeOff struct {
@@ -561,6 +935,44 @@ type TblStaffTimeOff struct {
 	UpdatedAt   string
 }
 
+type TblStaffTrustedDevice struct {
+	ID          int64
+	StaffID     int64
+	TokenHash   string
+	UseragentID sql.NullInt64
+	ExpiresAt   time.Time
+	LastUsedAt  sql.NullTime
+	CreatedAt   time.Time
+}
+
+type TblStaffTwoFactor struct {
+	StaffID      int64
+	Secret       string
+	EnabledAt    sql.NullTime
+	LastUsedStep int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type TblStaffTwoFactorFailure struct {
+	StaffID     int64
+	Attempts    int64
+	LockedUntil sql.NullTime
+	UpdatedAt   time.Time
+}
+
+type TblStockSubscription struct {
+	ID          int64
+	ProductID   int64
+	Email       string
+	Status      string
+	TokenHash   string
+	ConfirmedAt sql.NullTime
+	NotifiedAt  sql.NullTime
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
 type TblTheme struct {
 	ID                int64
 	Title             string
@@ -611,3 +1023,10 @@ type TblUseragent struct {
 	CreatedAt      string
 	UpdatedAt      string
 }
+
+type TblWishlist struct {
+	ID         int64
+	CustomerID int64
+	ProductID  int64
+	CreatedAt  time.Time
+}
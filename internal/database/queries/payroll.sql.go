@@ -0,0 +1,673 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: payroll.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createPayrollRun = `-- name: CreatePayrollRun :one
+INSERT INTO tbl_payroll_runs (
+	period_start,
+	period_end,
+	status,
+	created_by,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, 'DRAFT', ?,
+	DATETIME('now'),
+	DATETIME('now')
+) RETURNING id
+`
+
+type CreatePayrollRunParams struct {
+	PeriodStart string
+	PeriodEnd   string
+	CreatedBy   int64
+}
+
+func (q *Queries) CreatePayrollRun(ctx context.Context, arg CreatePayrollRunParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createPayrollRun, arg.PeriodStart, arg.PeriodEnd, arg.CreatedBy)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createPayrollRunItem = `-- name: CreatePayrollRunItem :exec
+INSERT INTO tbl_payroll_run_items (
+	payroll_run_id,
+	staff_id,
+	rate_type,
+	rate,
+	daily_rate,
+	days_worked,
+	absent_days,
+	late_minutes,
+	undertime_minutes,
+	overtime_minutes,
+	basic_pay,
+	holiday_pay,
+	overtime_pay,
+	late_deduction,
+	undertime_deduction,
+	absence_deduction,
+	gross_pay,
+	sss,
+	philhealth,
+	pagibig,
+	withholding_tax,
+	net_pay,
+	created_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+	DATETIME('now')
+)
+`
+
+type CreatePayrollRunItemParams struct {
+	PayrollRunID       int64
+	StaffID            int64
+	RateType           string
+	Rate               int64
+	DailyRate          int64
+	DaysWorked         int64
+	AbsentDays         int64
+	LateMinutes        int64
+	UndertimeMinutes   int64
+	OvertimeMinutes    int64
+	BasicPay           int64
+	HolidayPay         int64
+	OvertimePay        int64
+	LateDeduction      int64
+	UndertimeDeduction int64
+	AbsenceDeduction   int64
+	GrossPay           int64
+	Sss                int64
+	Philhealth         int64
+	Pagibig            int64
+	WithholdingTax     int64
+	NetPay             int64
+}
+
+func (q *Queries) CreatePayrollRunItem(ctx context.Context, arg CreatePayrollRunItemParams) error {
+	_, err := q.db.ExecContext(ctx, createPayrollRunItem,
+		arg.PayrollRunID,
+		arg.StaffID,
+		arg.RateType,
+		arg.Rate,
+		arg.DailyRate,
+		arg.DaysWorked,
+		arg.AbsentDays,
+		arg.LateMinutes,
+		arg.UndertimeMinutes,
+		arg.OvertimeMinutes,
+		arg.BasicPay,
+		arg.HolidayPay,
+		arg.OvertimePay,
+		arg.LateDeduction,
+		arg.UndertimeDeduction,
+		arg.AbsenceDeduction,
+		arg.GrossPay,
+		arg.Sss,
+		arg.Philhealth,
+		arg.Pagibig,
+		arg.WithholdingTax,
+		arg.NetPay,
+	)
+	return err
+}
+
+const deleteDraftPayrollRunItems = `-- name: DeleteDraftPayrollRunItems :exec
+DELETE FROM tbl_payroll_run_items
+WHERE payroll_run_id IN (
+	SELECT id
+	FROM tbl_payroll_runs
+	WHERE tbl_payroll_runs.id = ? AND tbl_payroll_runs.status = 'DRAFT'
+)
+`
+
+func (q *Queries) DeleteDraftPayrollRunItems(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteDraftPayrollRunItems, id)
+	return err
+}
+
+const getApprovedTimeOffsByDateRange = `-- name: GetApprovedTimeOffsByDateRange :many
+SELECT
+	staff_id,
+	type,
+	start_date,
+	end_date
+FROM tbl_staff_time_offs
+WHERE
+	approved = true
+	AND start_date <= ?1
+	AND end_date >= ?2
+`
+
+type GetApprovedTimeOffsByDateRangeParams struct {
+	PeriodEnd   time.Time
+	PeriodStart time.Time
+}
+
+type GetApprovedTimeOffsByDateRangeRow struct {
+	StaffID   int64
+	Type      string
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+func (q *Queries) GetApprovedTimeOffsByDateRange(ctx context.Context, arg GetApprovedTimeOffsByDateRangeParams) ([]GetApprovedTimeOffsByDateRangeRow, error) {
+	rows, err := q.db.QueryContext(ctx, getApprovedTimeOffsByDateRange, arg.PeriodEnd, arg.PeriodStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetApprovedTimeOffsByDateRangeRow
+	for rows.Next() {
+		var i GetApprovedTimeOffsByDateRangeRow
+		if err := rows.Scan(
+			&i.StaffID,
+			&i.Type,
+			&i.StartDate,
+			&i.EndDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPayrollRunByID = `-- name: GetPayrollRunByID :one
+SELECT id, period_start, period_end, status, created_by, locked_by, locked_at, created_at, updated_at
+FROM tbl_payroll_runs
+WHERE id = ?
+LIMIT 1
+`
+
+func (q *Queries) GetPayrollRunByID(ctx context.Context, id int64) (TblPayrollRun, error) {
+	row := q.db.QueryRowContext(ctx, getPayrollRunByID, id)
+	var i TblPayrollRun
+	err := row.Scan(
+		&i.ID,
+		&i.PeriodStart,
+		&i.PeriodEnd,
+		&i.Status,
+		&i.CreatedBy,
+		&i.LockedBy,
+		&i.LockedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPayrollRunByPeriod = `-- name: GetPayrollRunByPeriod :one
+SELECT id
+FROM tbl_payroll_runs
+WHERE period_start = ? AND period_end = ?
+LIMIT 1
+`
+
+type GetPayrollRunByPeriodParams struct {
+	PeriodStart string
+	PeriodEnd   string
+}
+
+func (q *Queries) GetPayrollRunByPeriod(ctx context.Context, arg GetPayrollRunByPeriodParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getPayrollRunByPeriod, arg.PeriodStart, arg.PeriodEnd)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getPayrollRunItem = `-- name: GetPayrollRunItem :one
+SELECT
+	tbl_payroll_run_items.id, tbl_payroll_run_items.payroll_run_id, tbl_payroll_run_items.staff_id, tbl_payroll_run_items.rate_type, tbl_payroll_run_items.rate, tbl_payroll_run_items.daily_rate, tbl_payroll_run_items.days_worked, tbl_payroll_run_items.absent_days, tbl_payroll_run_items.late_minutes, tbl_payroll_run_items.undertime_minutes, tbl_payroll_run_items.overtime_minutes, tbl_payroll_run_items.basic_pay, tbl_payroll_run_items.holiday_pay, tbl_payroll_run_items.overtime_pay, tbl_payroll_run_items.late_deduction, tbl_payroll_run_items.undertime_deduction, tbl_payroll_run_items.absence_deduction, tbl_payroll_run_items.gross_pay, tbl_payroll_run_items.sss, tbl_payroll_run_items.philhealth, tbl_payroll_run_items.pagibig, tbl_payroll_run_items.withholding_tax, tbl_payroll_run_items.net_pay, tbl_payroll_run_items.created_at,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position
+FROM tbl_payroll_run_items
+INNER JOIN tbl_staffs ON tbl_staffs.id = tbl_payroll_run_items.staff_id
+WHERE
+	tbl_payroll_run_items.payroll_run_id = ?
+	AND tbl_payroll_run_items.staff_id = ?
+LIMIT 1
+`
+
+type GetPayrollRunItemParams struct {
+	PayrollRunID int64
+	StaffID      int64
+}
+
+type GetPayrollRunItemRow struct {
+	ID                 int64
+	PayrollRunID       int64
+	StaffID            int64
+	RateType           string
+	Rate               int64
+	DailyRate          int64
+	DaysWorked         int64
+	AbsentDays         int64
+	LateMinutes        int64
+	UndertimeMinutes   int64
+	OvertimeMinutes    int64
+	BasicPay           int64
+	HolidayPay         int64
+	OvertimePay        int64
+	LateDeduction      int64
+	UndertimeDeduction int64
+	AbsenceDeduction   int64
+	GrossPay           int64
+	Sss                int64
+	Philhealth         int64
+	Pagibig            int64
+	WithholdingTax     int64
+	NetPay             int64
+	CreatedAt          time.Time
+	FirstName          string
+	MiddleName         sql.NullString
+	LastName           string
+	Position           string
+}
+
+func (q *Queries) GetPayrollRunItem(ctx context.Context, arg GetPayrollRunItemParams) (GetPayrollRunItemRow, error) {
+	row := q.db.QueryRowContext(ctx, getPayrollRunItem, arg.PayrollRunID, arg.StaffID)
+	var i GetPayrollRunItemRow
+	err := row.Scan(
+		&i.ID,
+		&i.PayrollRunID,
+		&i.StaffID,
+		&i.RateType,
+		&i.Rate,
+		&i.DailyRate,
+		&i.DaysWorked,
+		&i.AbsentDays,
+		&i.LateMinutes,
+		&i.UndertimeMinutes,
+		&i.OvertimeMinutes,
+		&i.BasicPay,
+		&i.HolidayPay,
+		&i.OvertimePay,
+		&i.LateDeduction,
+		&i.UndertimeDeduction,
+		&i.AbsenceDeduction,
+		&i.GrossPay,
+		&i.Sss,
+		&i.Philhealth,
+		&i.Pagibig,
+		&i.WithholdingTax,
+		&i.NetPay,
+		&i.CreatedAt,
+		&i.FirstName,
+		&i.MiddleName,
+		&i.LastName,
+		&i.Position,
+	)
+	return i, err
+}
+
+const getPayrollRunItems = `-- name: GetPayrollRunItems :many
+SELECT
+	tbl_payroll_run_items.id, tbl_payroll_run_items.payroll_run_id, tbl_payroll_run_items.staff_id, tbl_payroll_run_items.rate_type, tbl_payroll_run_items.rate, tbl_payroll_run_items.daily_rate, tbl_payroll_run_items.days_worked, tbl_payroll_run_items.absent_days, tbl_payroll_run_items.late_minutes, tbl_payroll_run_items.undertime_minutes, tbl_payroll_run_items.overtime_minutes, tbl_payroll_run_items.basic_pay, tbl_payroll_run_items.holiday_pay, tbl_payroll_run_items.overtime_pay, tbl_payroll_run_items.late_deduction, tbl_payroll_run_items.undertime_deduction, tbl_payroll_run_items.absence_deduction, tbl_payroll_run_items.gross_pay, tbl_payroll_run_items.sss, tbl_payroll_run_items.philhealth, tbl_payroll_run_items.pagibig, tbl_payroll_run_items.withholding_tax, tbl_payroll_run_items.net_pay, tbl_payroll_run_items.created_at,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position
+FROM tbl_payroll_run_items
+INNER JOIN tbl_staffs ON tbl_staffs.id = tbl_payroll_run_items.staff_id
+WHERE tbl_payroll_run_items.payroll_run_id = ?
+ORDER BY tbl_staffs.last_name ASC, tbl_staffs.first_name ASC
+`
+
+type GetPayrollRunItemsRow struct {
+	ID                 int64
+	PayrollRunID       int64
+	StaffID            int64
+	RateType           string
+	Rate               int64
+	DailyRate          int64
+	DaysWorked         int64
+	AbsentDays         int64
+	LateMinutes        int64
+	UndertimeMinutes   int64
+	OvertimeMinutes    int64
+	BasicPay           int64
+	HolidayPay         int64
+	OvertimePay        int64
+	LateDeduction      int64
+	UndertimeDeduction int64
+	AbsenceDeduction   int64
+	GrossPay           int64
+	Sss                int64
+	Philhealth         int64
+	Pagibig            int64
+	WithholdingTax     int64
+	NetPay             int64
+	CreatedAt          time.Time
+	FirstName          string
+	MiddleName         sql.NullString
+	LastName           string
+	Position           string
+}
+
+func (q *Queries) GetPayrollRunItems(ctx context.Context, payrollRunID int64) ([]GetPayrollRunItemsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPayrollRunItems, payrollRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPayrollRunItemsRow
+	for rows.Next() {
+		var i GetPayrollRunItemsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PayrollRunID,
+			&i.StaffID,
+			&i.RateType,
+			&i.Rate,
+			&i.DailyRate,
+			&i.DaysWorked,
+			&i.AbsentDays,
+			&i.LateMinutes,
+			&i.UndertimeMinutes,
+			&i.OvertimeMinutes,
+			&i.BasicPay,
+			&i.HolidayPay,
+			&i.OvertimePay,
+			&i.LateDeduction,
+			&i.UndertimeDeduction,
+			&i.AbsenceDeduction,
+			&i.GrossPay,
+			&i.Sss,
+			&i.Philhealth,
+			&i.Pagibig,
+			&i.WithholdingTax,
+			&i.NetPay,
+			&i.CreatedAt,
+			&i.FirstName,
+			&i.MiddleName,
+			&i.LastName,
+			&i.Position,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPayrollRuns = `-- name: GetPayrollRuns :many
+SELECT
+	tbl_payroll_runs.id, tbl_payroll_runs.period_start, tbl_payroll_runs.period_end, tbl_payroll_runs.status, tbl_payroll_runs.created_by, tbl_payroll_runs.locked_by, tbl_payroll_runs.locked_at, tbl_payroll_runs.created_at, tbl_payroll_runs.updated_at,
+	(
+		SELECT COUNT(*)
+		FROM tbl_payroll_run_items
+		WHERE tbl_payroll_run_items.payroll_run_id = tbl_payroll_runs.id
+	) AS staff_count,
+	CAST((
+		SELECT COALESCE(SUM(tbl_payroll_run_items.net_pay), 0)
+		FROM tbl_payroll_run_items
+		WHERE tbl_payroll_run_items.payroll_run_id = tbl_payroll_runs.id
+	) AS INTEGER) AS total_net_pay
+FROM tbl_payroll_runs
+ORDER BY tbl_payroll_runs.period_start DESC
+`
+
+type GetPayrollRunsRow struct {
+	ID          int64
+	PeriodStart string
+	PeriodEnd   string
+	Status      string
+	CreatedBy   int64
+	LockedBy    sql.NullInt64
+	LockedAt    sql.NullTime
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	StaffCount  int64
+	TotalNetPay int64
+}
+
+func (q *Queries) GetPayrollRuns(ctx context.Context) ([]GetPayrollRunsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPayrollRuns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPayrollRunsRow
+	for rows.Next() {
+		var i GetPayrollRunsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PeriodStart,
+			&i.PeriodEnd,
+			&i.Status,
+			&i.CreatedBy,
+			&i.LockedBy,
+			&i.LockedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.StaffCount,
+			&i.TotalNetPay,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPayrollStaffs = `-- name: GetPayrollStaffs :many
+SELECT
+	tbl_staffs.id,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.time_in_schedule,
+	tbl_staffs.time_out_schedule,
+	tbl_staff_pay_rates.rate_type,
+	tbl_staff_pay_rates.rate
+FROM tbl_staffs
+INNER JOIN tbl_staff_pay_rates ON tbl_staff_pay_rates.staff_id = tbl_staffs.id
+WHERE
+	tbl_staffs.deleted_at = '1970-01-01 00:00:00+00:00'
+	AND (tbl_staffs.status != 'RESIGNED' OR tbl_staffs.resigned_at >= ?1)
+ORDER BY tbl_staffs.last_name ASC, tbl_staffs.first_name ASC
+`
+
+type GetPayrollStaffsRow struct {
+	ID              int64
+	FirstName       string
+	MiddleName      sql.NullString
+	LastName        string
+	TimeInSchedule  sql.NullString
+	TimeOutSchedule sql.NullString
+	RateType        string
+	Rate            int64
+}
+
+func (q *Queries) GetPayrollStaffs(ctx context.Context, periodStart sql.NullString) ([]GetPayrollStaffsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPayrollStaffs, periodStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPayrollStaffsRow
+	for rows.Next() {
+		var i GetPayrollStaffsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.MiddleName,
+			&i.LastName,
+			&i.TimeInSchedule,
+			&i.TimeOutSchedule,
+			&i.RateType,
+			&i.Rate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffsWithPayRates = `-- name: GetStaffsWithPayRates :many
+SELECT
+	tbl_staffs.id,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position,
+	tbl_staff_pay_rates.rate_type,
+	tbl_staff_pay_rates.rate
+FROM tbl_staffs
+LEFT JOIN tbl_staff_pay_rates ON tbl_staff_pay_rates.staff_id = tbl_staffs.id
+WHERE
+	tbl_staffs.deleted_at = '1970-01-01 00:00:00+00:00'
+	AND tbl_staffs.status != 'RESIGNED'
+ORDER BY tbl_staffs.last_name ASC, tbl_staffs.first_name ASC
+`
+
+type GetStaffsWithPayRatesRow struct {
+	ID         int64
+	FirstName  string
+	MiddleName sql.NullString
+	LastName   string
+	Position   string
+	RateType   sql.NullString
+	Rate       sql.NullInt64
+}
+
+func (q *Queries) GetStaffsWithPayRates(ctx context.Context) ([]GetStaffsWithPayRatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffsWithPayRates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStaffsWithPayRatesRow
+	for rows.Next() {
+		var i GetStaffsWithPayRatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.MiddleName,
+			&i.LastName,
+			&i.Position,
+			&i.RateType,
+			&i.Rate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockPayrollRun = `-- name: LockPayrollRun :execrows
+UPDATE tbl_payroll_runs
+SET
+	status = 'LOCKED',
+	locked_by = ?,
+	locked_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE id = ? AND status = 'DRAFT'
+`
+
+type LockPayrollRunParams struct {
+	LockedBy sql.NullInt64
+	ID       int64
+}
+
+func (q *Queries) LockPayrollRun(ctx context.Context, arg LockPayrollRunParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, lockPayrollRun, arg.LockedBy, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const touchPayrollRun = `-- name: TouchPayrollRun :exec
+UPDATE tbl_payroll_runs
+SET updated_at = DATETIME('now')
+WHERE id = ? AND status = 'DRAFT'
+`
+
+func (q *Queries) TouchPayrollRun(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, touchPayrollRun, id)
+	return err
+}
+
+const upsertStaffPayRate = `-- name: UpsertStaffPayRate :exec
+INSERT INTO tbl_staff_pay_rates (
+	staff_id,
+	rate_type,
+	rate,
+	updated_by,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?,
+	DATETIME('now'),
+	DATETIME('now')
+)
+ON CONFLICT (staff_id) DO UPDATE SET
+	rate_type = excluded.rate_type,
+	rate = excluded.rate,
+	updated_by = excluded.updated_by,
+	updated_at = DATETIME('now')
+`
+
+type UpsertStaffPayRateParams struct {
+	StaffID   int64
+	RateType  string
+	Rate      int64
+	UpdatedBy sql.NullInt64
+}
+
+func (q *Queries) UpsertStaffPayRate(ctx context.Context, arg UpsertStaffPayRateParams) error {
+	_, err := q.db.ExecContext(ctx, upsertStaffPayRate,
+		arg.StaffID,
+		arg.RateType,
+		arg.Rate,
+		arg.UpdatedBy,
+	)
+	return err
+}
@@ -662,7 +662,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
@@ -671,9 +673,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_products.id NOT IN (
 		SELECT p2.id
 		FROM tbl_products_fts
@@ -709,6 +712,8 @@ type GetOtherProductsForSearchRow struct {
 	ThumbnailPath            string
 	CdnUrl                   sql.NullString
 	CdnUrlThumbnail          sql.NullString
+	RatingCount              int64
+	RatingTotal              int64
 }
 
 func (q *Queries) GetOtherProductsForSearch(ctx context.Context, arg GetOtherProductsForSearchParams) ([]GetOtherProductsForSearchRow, error) {
@@ -737,6 +742,8 @@ func (q *Queries) GetOtherProductsForSearch(ctx context.Context, arg GetOtherPro
 			&i.ThumbnailPath,
 			&i.CdnUrl,
 			&i.CdnUrlThumbnail,
+			&i.RatingCount,
+			&i.RatingTotal,
 		); err != nil {
 			return nil, err
 		}
@@ -853,7 +860,9 @@ SELECT
 	CASE
 		WHEN tbl_product_sales.id IS NOT NULL THEN tbl_product_sales.discount_value
 		ELSE 0
-	END AS discount_value
+	END AS discount_value,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 INNER JOIN tbl_brand_images ON tbl_brand_images.brand_id = tbl_brands.id
@@ -871,6 +880,7 @@ LEFT JOIN tbl_product_sales
 	ON tbl_product_sales.product_id = tbl_products.id
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE tbl_products.slug = ? AND tbl_products.status = 'ACTIVE'
 LIMIT 1
 `
@@ -913,6 +923,8 @@ type GetProductPageRow struct {
 	SalePriceWithVatCurrency    interface{}
 	DiscountType                string
 	DiscountValue               int64
+	RatingCount                 int64
+	RatingTotal                 int64
 }
 
 func (q *Queries) GetProductPage(ctx context.Context, slug sql.NullString) (GetProductPageRow, error) {
@@ -956,6 +968,8 @@ func (q *Queries) GetProductPage(ctx context.Context, slug sql.NullString) (GetP
 		&i.SalePriceWithVatCurrency,
 		&i.DiscountType,
 		&i.DiscountValue,
+		&i.RatingCount,
+		&i.RatingTotal,
 	)
 	return i, err
 }
@@ -985,7 +999,7 @@ FROM tbl_products
 INNER JOIN tbl_products_categories ON tbl_products.id = tbl_products_categories.product_id
 INNER JOIN tbl_product_specs ON tbl_products.product_specs_id = tbl_product_specs.id
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
-ORDER BY created_at DESC
+ORDER BY tbl_products.created_at DESC
 `
 
 type GetProductsRow struct {
@@ -1221,88 +1235,10 @@ func (q *Queries) GetProductsByID(ctx context.Context, id int64) (GetProductsByI
 	return i, err
 }
 
-const getProductsBySearchQuery = `-- name: GetProductsBySearchQuery :many
+const getProductsBySearchQueryPaginated = `-- name: GetProductsBySearchQueryPaginated :many
 ;
 
 
-SELECT
-	tbl_products.id,
-	tbl_products.name,
-	tbl_products.slug,
-	tbl_products.unit_price_with_vat,
-	tbl_products.unit_price_with_vat_currency,
-	tbl_brands.name AS brand_name,
-	COALESCE(
-		tbl_product_images.thumbnail,
-		'static/images/empty_96x96.webp'
-	) AS thumbnail_path,
-	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
-FROM tbl_products_fts
-INNER JOIN tbl_products ON tbl_products.id = tbl_products_fts.rowid
-INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
-LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
-WHERE
-	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
-	AND tbl_products_fts.name MATCH ?
-LIMIT ?
-`
-
-type GetProductsBySearchQueryParams struct {
-	Name  string
-	Limit int64
-}
-
-type GetProductsBySearchQueryRow struct {
-	ID                       int64
-	Name                     string
-	Slug                     sql.NullString
-	UnitPriceWithVat         int64
-	UnitPriceWithVatCurrency string
-	BrandName                string
-	ThumbnailPath            string
-	CdnUrl                   sql.NullString
-	CdnUrlThumbnail          sql.NullString
-}
-
-// TODO: (Brandon) if sqlc releases PR #3498
-//
-//	replace WHERE with `tbl_products_fts MATCH ?`
-func (q *Queries) GetProductsBySearchQuery(ctx context.Context, arg GetProductsBySearchQueryParams) ([]GetProductsBySearchQueryRow, error) {
-	rows, err := q.db.QueryContext(ctx, getProductsBySearchQuery, arg.Name, arg.Limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []GetProductsBySearchQueryRow
-	for rows.Next() {
-		var i GetProductsBySearchQueryRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Slug,
-			&i.UnitPriceWithVat,
-			&i.UnitPriceWithVatCurrency,
-			&i.BrandName,
-			&i.ThumbnailPath,
-			&i.CdnUrl,
-			&i.CdnUrlThumbnail,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
-const getProductsBySearchQueryPaginated = `-- name: GetProductsBySearchQueryPaginated :many
 SELECT
 	tbl_products.id,
 	tbl_products.serial,
@@ -1325,9 +1261,10 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
-FROM tbl_products_fts
-INNER JOIN tbl_products ON tbl_products.id = tbl_products_fts.rowid
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
+FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
 LEFT JOIN tbl_product_sales
@@ -1335,18 +1272,39 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
+LEFT JOIN tbl_search_rules AS pin
+	ON pin.product_id = tbl_products.id
+	AND pin.kind = 'PIN_PRODUCT'
+	AND pin.search_term = ?1
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
-	AND tbl_products_fts.name MATCH ?
-ORDER BY is_on_sale DESC, tbl_products.created_at DESC
-LIMIT ? OFFSET ?
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
+	AND (
+		tbl_products.id IN (
+			SELECT rowid FROM tbl_products_fts WHERE tbl_products_fts.name MATCH ?2
+		)
+		OR pin.id IS NOT NULL
+	)
+ORDER BY
+	pin.id IS NULL,
+	pin.position ASC,
+	EXISTS (
+		SELECT 1 FROM tbl_search_rules AS boost
+		WHERE boost.kind = 'BOOST_BRAND'
+		AND boost.search_term = @search_term
+		AND boost.brand_id = tbl_products.brand_id
+	) DESC,
+	is_on_sale DESC,
+	tbl_products.created_at DESC
+LIMIT ?4 OFFSET ?3
 `
 
 type GetProductsBySearchQueryPaginatedParams struct {
-	Name   string
-	Limit  int64
-	Offset int64
+	SearchTerm string
+	Name       string
+	Offset     int64
+	Limit      int64
 }
 
 type GetProductsBySearchQueryPaginatedRow struct {
@@ -1366,10 +1324,24 @@ type GetProductsBySearchQueryPaginatedRow struct {
 	ThumbnailPath            string
 	CdnUrl                   sql.NullString
 	CdnUrlThumbnail          sql.NullString
+	RatingCount              int64
+	RatingTotal              int64
 }
 
+// TODO: (Brandon) if sqlc releases PR #3498
+//
+//	replace WHERE with `tbl_products_fts MATCH ?`
+//
+// GetProductsBySearchQueryPaginated honours the merchandising rules in
+// tbl_search_rules: pinned products come first even when the full-text search
+// does not match them, followed by products of boosted brands.
 func (q *Queries) GetProductsBySearchQueryPaginated(ctx context.Context, arg GetProductsBySearchQueryPaginatedParams) ([]GetProductsBySearchQueryPaginatedRow, error) {
-	rows, err := q.db.QueryContext(ctx, getProductsBySearchQueryPaginated, arg.Name, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getProductsBySearchQueryPaginated,
+		arg.SearchTerm,
+		arg.Name,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -1394,6 +1366,8 @@ func (q *Queries) GetProductsBySearchQueryPaginated(ctx context.Context, arg Get
 			&i.ThumbnailPath,
 			&i.CdnUrl,
 			&i.CdnUrlThumbnail,
+			&i.RatingCount,
+			&i.RatingTotal,
 		); err != nil {
 			return nil, err
 		}
@@ -1608,7 +1582,7 @@ LEFT JOIN tbl_product_sales
 LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_product_sales.id IS NOT NULL
 ORDER BY RANDOM()
 LIMIT 1
@@ -1969,7 +1943,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 INNER JOIN tbl_products_categories ON tbl_products_categories.product_id = tbl_products.id
@@ -1979,9 +1955,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_products_categories.category_id IN (
 		SELECT DISTINCT pc.category_id
 		FROM tbl_products_fts
@@ -2026,6 +2003,8 @@ type GetRelatedProductsForSearchRow struct {
 	ThumbnailPath            string
 	CdnUrl                   sql.NullString
 	CdnUrlThumbnail          sql.NullString
+	RatingCount              int64
+	RatingTotal              int64
 }
 
 func (q *Queries) GetRelatedProductsForSearch(ctx context.Context, arg GetRelatedProductsForSearchParams) ([]GetRelatedProductsForSearchRow, error) {
@@ -2054,6 +2033,8 @@ func (q *Queries) GetRelatedProductsForSearch(ctx context.Context, arg GetRelate
 			&i.ThumbnailPath,
 			&i.CdnUrl,
 			&i.CdnUrlThumbnail,
+			&i.RatingCount,
+			&i.RatingTotal,
 		); err != nil {
 			return nil, err
 		}
@@ -2091,7 +2072,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
@@ -2100,9 +2083,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_products.brand_id IN (
 		SELECT DISTINCT p.brand_id
 		FROM tbl_products_fts
@@ -2146,6 +2130,8 @@ type GetRelatedProductsForSearchByBrandRow struct {
 	ThumbnailPath            string
 	CdnUrl                   sql.NullString
 	CdnUrlThumbnail          sql.NullString
+	RatingCount              int64
+	RatingTotal              int64
 }
 
 func (q *Queries) GetRelatedProductsForSearchByBrand(ctx context.Context, arg GetRelatedProductsForSearchByBrandParams) ([]GetRelatedProductsForSearchByBrandRow, error) {
@@ -2174,6 +2160,8 @@ func (q *Queries) GetRelatedProductsForSearchByBrand(ctx context.Context, arg Ge
 			&i.ThumbnailPath,
 			&i.CdnUrl,
 			&i.CdnUrlThumbnail,
+			&i.RatingCount,
+			&i.RatingTotal,
 		); err != nil {
 			return nil, err
 		}
@@ -2211,7 +2199,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 INNER JOIN tbl_products_categories ON tbl_products_categories.product_id = tbl_products.id
@@ -2222,9 +2212,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND pc.category IN (
 		SELECT DISTINCT cat.category
 		FROM tbl_products_fts
@@ -2270,6 +2261,8 @@ type GetRelatedProductsForSearchByParentCategoryRow struct {
 	ThumbnailPath            string
 	CdnUrl                   sql.NullString
 	CdnUrlThumbnail          sql.NullString
+	RatingCount              int64
+	RatingTotal              int64
 }
 
 func (q *Queries) GetRelatedProductsForSearchByParentCategory(ctx context.Context, arg GetRelatedProductsForSearchByParentCategoryParams) ([]GetRelatedProductsForSearchByParentCategoryRow, error) {
@@ -2298,6 +2291,8 @@ func (q *Queries) GetRelatedProductsForSearchByParentCategory(ctx context.Contex
 			&i.ThumbnailPath,
 			&i.CdnUrl,
 			&i.CdnUrlThumbnail,
+			&i.RatingCount,
+			&i.RatingTotal,
 		); err != nil {
 			return nil, err
 		}
@@ -2463,7 +2458,7 @@ func (q *Queries) UpdateProductSlugByID(ctx context.Context, arg UpdateProductSl
 	return err
 }
 
-const updateProducts = `-- name: UpdateProducts :execlastid
+const updateProducts = `-- name: UpdateProducts :one
 UPDATE tbl_products
 SET
 	name = ?,
@@ -2478,6 +2473,7 @@ SET
 	unit_price_with_vat_currency = ?,
 	updated_at = datetime('now')
 WHERE id = ?
+RETURNING id
 `
 
 type UpdateProductsParams struct {
@@ -2495,7 +2491,7 @@ type UpdateProductsParams struct {
 }
 
 func (q *Queries) UpdateProducts(ctx context.Context, arg UpdateProductsParams) (int64, error) {
-	result, err := q.db.ExecContext(ctx, updateProducts,
+	row := q.db.QueryRowContext(ctx, updateProducts,
 		arg.Name,
 		arg.Slug,
 		arg.Description,
@@ -2508,10 +2504,9 @@ func (q *Queries) UpdateProducts(ctx context.Context, arg UpdateProductsParams)
 		arg.UnitPriceWithVatCurrency,
 		arg.ID,
 	)
-	if err != nil {
-		return 0, err
-	}
-	return result.LastInsertId()
+	var id int64
+	err := row.Scan(&id)
+	return id, err
 }
 
 const updateProductsStatus = `-- name: UpdateProductsStatus :exec
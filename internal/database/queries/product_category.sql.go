@@ -596,7 +596,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 INNER JOIN tbl_products_categories ON tbl_products_categories.product_id = tbl_products.id
@@ -607,9 +609,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_product_categories.category = ?
 	AND tbl_product_categories.subcategory = ?
 ORDER BY is_on_sale DESC, tbl_products.created_at DESC
@@ -639,6 +642,8 @@ type GetProductsByCategoryAndSubcategorySlugRow struct {
 	ThumbnailPath            string
 	CdnUrl                   sql.NullString
 	CdnUrlThumbnail          sql.NullString
+	RatingCount              int64
+	RatingTotal              int64
 }
 
 func (q *Queries) GetProductsByCategoryAndSubcategorySlug(ctx context.Context, arg GetProductsByCategoryAndSubcategorySlugParams) ([]GetProductsByCategoryAndSubcategorySlugRow, error) {
@@ -667,6 +672,8 @@ func (q *Queries) GetProductsByCategoryAndSubcategorySlug(ctx context.Context, a
 			&i.ThumbnailPath,
 			&i.CdnUrl,
 			&i.CdnUrlThumbnail,
+			&i.RatingCount,
+			&i.RatingTotal,
 		); err != nil {
 			return nil, err
 		}
@@ -704,7 +711,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 INNER JOIN tbl_products_categories ON tbl_products_categories.product_id = tbl_products.id
@@ -714,9 +723,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_products_categories.category_id = ?1
 	AND (
 		?2 IS NULL
@@ -750,6 +760,8 @@ type GetProductsByCategoryIDRow struct {
 	ThumbnailPath            string
 	CdnUrl                   sql.NullString
 	CdnUrlThumbnail          sql.NullString
+	RatingCount              int64
+	RatingTotal              int64
 }
 
 func (q *Queries) GetProductsByCategoryID(ctx context.Context, arg GetProductsByCategoryIDParams) ([]GetProductsByCategoryIDRow, error) {
@@ -778,6 +790,8 @@ func (q *Queries) GetProductsByCategoryID(ctx context.Context, arg GetProductsBy
 			&i.ThumbnailPath,
 			&i.CdnUrl,
 			&i.CdnUrlThumbnail,
+			&i.RatingCount,
+			&i.RatingTotal,
 		); err != nil {
 			return nil, err
 		}
@@ -815,7 +829,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 INNER JOIN tbl_products_categories ON tbl_products_categories.product_id = tbl_products.id
@@ -826,9 +842,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_product_categories.category = ?
 ORDER BY is_on_sale DESC, tbl_products.created_at DESC
 LIMIT ?
@@ -856,6 +873,8 @@ type GetProductsByCategorySlugRow struct {
 	ThumbnailPath            string
 	CdnUrl                   sql.NullString
 	CdnUrlThumbnail          sql.NullString
+	RatingCount              int64
+	RatingTotal              int64
 }
 
 func (q *Queries) GetProductsByCategorySlug(ctx context.Context, arg GetProductsByCategorySlugParams) ([]GetProductsByCategorySlugRow, error) {
@@ -884,6 +903,8 @@ func (q *Queries) GetProductsByCategorySlug(ctx context.Context, arg GetProducts
 			&i.ThumbnailPath,
 			&i.CdnUrl,
 			&i.CdnUrlThumbnail,
+			&i.RatingCount,
+			&i.RatingTotal,
 		); err != nil {
 			return nil, err
 		}
@@ -384,7 +384,7 @@ func (q *Queries) ListProductInventories(ctx context.Context, limit int64) ([]Li
 	return items, nil
 }
 
-const updateProductInventory = `-- name: UpdateProductInventory :exec
+const updateProductInventory = `-- name: UpdateProductInventory :execrows
 UPDATE tbl_product_inventories
 SET
     stocks = ?,
@@ -398,9 +398,12 @@ type UpdateProductInventoryParams struct {
 	StocksIn  string
 }
 
-func (q *Queries) UpdateProductInventory(ctx context.Context, arg UpdateProductInventoryParams) error {
-	_, err := q.db.ExecContext(ctx, updateProductInventory, arg.Stocks, arg.ProductID, arg.StocksIn)
-	return err
+func (q *Queries) UpdateProductInventory(ctx context.Context, arg UpdateProductInventoryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateProductInventory, arg.Stocks, arg.ProductID, arg.StocksIn)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const updateProductInventoryByID = `-- name: UpdateProductInventoryByID :exec
@@ -0,0 +1,445 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: product_review.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+const adminCountProductReviewsByStatus = `-- name: AdminCountProductReviewsByStatus :one
+SELECT COUNT(*) AS count
+FROM tbl_product_reviews
+WHERE status = ?1
+`
+
+func (q *Queries) AdminCountProductReviewsByStatus(ctx context.Context, status string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, adminCountProductReviewsByStatus, status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const adminGetProductReviewsByStatusPaginated = `-- name: AdminGetProductReviewsByStatusPaginated :many
+SELECT
+	tbl_product_reviews.id,
+	tbl_product_reviews.rating,
+	tbl_product_reviews.title,
+	tbl_product_reviews.body,
+	tbl_product_reviews.status,
+	tbl_product_reviews.rejection_reason,
+	tbl_product_reviews.created_at,
+	tbl_products.name AS product_name,
+	tbl_products.serial AS product_serial,
+	tbl_customers.first_name AS customer_first_name,
+	tbl_customers.last_name AS customer_last_name,
+	tbl_customers.email AS customer_email
+FROM tbl_product_reviews
+INNER JOIN tbl_products ON tbl_products.id = tbl_product_reviews.product_id
+INNER JOIN tbl_customers ON tbl_customers.id = tbl_product_reviews.customer_id
+WHERE tbl_product_reviews.status = ?1
+ORDER BY tbl_product_reviews.created_at ASC
+LIMIT ?3 OFFSET ?2
+`
+
+type AdminGetProductReviewsByStatusPaginatedParams struct {
+	Status string
+	Offset int64
+	Limit  int64
+}
+
+type AdminGetProductReviewsByStatusPaginatedRow struct {
+	ID                int64
+	Rating            int64
+	Title             string
+	Body              string
+	Status            string
+	RejectionReason   string
+	CreatedAt         time.Time
+	ProductName       string
+	ProductSerial     string
+	CustomerFirstName string
+	CustomerLastName  string
+	CustomerEmail     string
+}
+
+func (q *Queries) AdminGetProductReviewsByStatusPaginated(ctx context.Context, arg AdminGetProductReviewsByStatusPaginatedParams) ([]AdminGetProductReviewsByStatusPaginatedRow, error) {
+	rows, err := q.db.QueryContext(ctx, adminGetProductReviewsByStatusPaginated, arg.Status, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AdminGetProductReviewsByStatusPaginatedRow
+	for rows.Next() {
+		var i AdminGetProductReviewsByStatusPaginatedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Rating,
+			&i.Title,
+			&i.Body,
+			&i.Status,
+			&i.RejectionReason,
+			&i.CreatedAt,
+			&i.ProductName,
+			&i.ProductSerial,
+			&i.CustomerFirstName,
+			&i.CustomerLastName,
+			&i.CustomerEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createProductReview = `-- name: CreateProductReview :one
+INSERT INTO tbl_product_reviews (
+	product_id,
+	customer_id,
+	order_id,
+	rating,
+	title,
+	body,
+	status,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, 'PENDING', datetime('now'), datetime('now')
+) RETURNING id
+`
+
+type CreateProductReviewParams struct {
+	ProductID  int64
+	CustomerID int64
+	OrderID    int64
+	Rating     int64
+	Title      string
+	Body       string
+}
+
+func (q *Queries) CreateProductReview(ctx context.Context, arg CreateProductReviewParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createProductReview,
+		arg.ProductID,
+		arg.CustomerID,
+		arg.OrderID,
+		arg.Rating,
+		arg.Title,
+		arg.Body,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createProductReviewPhoto = `-- name: CreateProductReviewPhoto :one
+INSERT INTO tbl_product_review_photos (
+	review_id,
+	path,
+	url,
+	created_at
+) VALUES (
+	?, ?, ?, datetime('now')
+) RETURNING id
+`
+
+type CreateProductReviewPhotoParams struct {
+	ReviewID int64
+	Path     string
+	Url      string
+}
+
+func (q *Queries) CreateProductReviewPhoto(ctx context.Context, arg CreateProductReviewPhotoParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createProductReviewPhoto, arg.ReviewID, arg.Path, arg.Url)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getApprovedProductReviewsByProductID = `-- name: GetApprovedProductReviewsByProductID :many
+SELECT
+	tbl_product_reviews.id,
+	tbl_product_reviews.rating,
+	tbl_product_reviews.title,
+	tbl_product_reviews.body,
+	tbl_product_reviews.created_at,
+	tbl_customers.first_name AS customer_first_name,
+	tbl_customers.last_name AS customer_last_name
+FROM tbl_product_reviews
+INNER JOIN tbl_customers ON tbl_customers.id = tbl_product_reviews.customer_id
+WHERE
+	tbl_product_reviews.product_id = ?1
+	AND tbl_product_reviews.status = 'APPROVED'
+ORDER BY tbl_product_reviews.created_at DESC
+LIMIT ?2
+`
+
+type GetApprovedProductReviewsByProductIDParams struct {
+	ProductID int64
+	Limit     int64
+}
+
+type GetApprovedProductReviewsByProductIDRow struct {
+	ID                int64
+	Rating            int64
+	Title             string
+	Body              string
+	CreatedAt         time.Time
+	CustomerFirstName string
+	CustomerLastName  string
+}
+
+func (q *Queries) GetApprovedProductReviewsByProductID(ctx context.Context, arg GetApprovedProductReviewsByProductIDParams) ([]GetApprovedProductReviewsByProductIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getApprovedProductReviewsByProductID, arg.ProductID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetApprovedProductReviewsByProductIDRow
+	for rows.Next() {
+		var i GetApprovedProductReviewsByProductIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Rating,
+			&i.Title,
+			&i.Body,
+			&i.CreatedAt,
+			&i.CustomerFirstName,
+			&i.CustomerLastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestDeliveredOrderIDForCustomerProduct = `-- name: GetLatestDeliveredOrderIDForCustomerProduct :one
+SELECT tbl_orders.id
+FROM tbl_orders
+INNER JOIN tbl_order_lines ON tbl_order_lines.order_id = tbl_orders.id
+WHERE
+	tbl_orders.customer_id = ?1
+	AND tbl_orders.status = 'DELIVERED'
+	AND tbl_order_lines.product_id = ?2
+ORDER BY tbl_orders.updated_at DESC
+LIMIT 1
+`
+
+type GetLatestDeliveredOrderIDForCustomerProductParams struct {
+	CustomerID sql.NullInt64
+	ProductID  int64
+}
+
+func (q *Queries) GetLatestDeliveredOrderIDForCustomerProduct(ctx context.Context, arg GetLatestDeliveredOrderIDForCustomerProductParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getLatestDeliveredOrderIDForCustomerProduct, arg.CustomerID, arg.ProductID)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getProductRatingByProductID = `-- name: GetProductRatingByProductID :one
+SELECT
+	rating_count,
+	rating_total
+FROM tbl_product_ratings
+WHERE product_id = ?
+LIMIT 1
+`
+
+type GetProductRatingByProductIDRow struct {
+	RatingCount int64
+	RatingTotal int64
+}
+
+func (q *Queries) GetProductRatingByProductID(ctx context.Context, productID int64) (GetProductRatingByProductIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getProductRatingByProductID, productID)
+	var i GetProductRatingByProductIDRow
+	err := row.Scan(&i.RatingCount, &i.RatingTotal)
+	return i, err
+}
+
+const getProductReviewByCustomerAndProduct = `-- name: GetProductReviewByCustomerAndProduct :one
+SELECT id, product_id, customer_id, order_id, rating, title, body, status, moderated_by, moderated_at, rejection_reason, created_at, updated_at
+FROM tbl_product_reviews
+WHERE customer_id = ? AND product_id = ?
+LIMIT 1
+`
+
+type GetProductReviewByCustomerAndProductParams struct {
+	CustomerID int64
+	ProductID  int64
+}
+
+func (q *Queries) GetProductReviewByCustomerAndProduct(ctx context.Context, arg GetProductReviewByCustomerAndProductParams) (TblProductReview, error) {
+	row := q.db.QueryRowContext(ctx, getProductReviewByCustomerAndProduct, arg.CustomerID, arg.ProductID)
+	var i TblProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.CustomerID,
+		&i.OrderID,
+		&i.Rating,
+		&i.Title,
+		&i.Body,
+		&i.Status,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+		&i.RejectionReason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getProductReviewByID = `-- name: GetProductReviewByID :one
+SELECT id, product_id, customer_id, order_id, rating, title, body, status, moderated_by, moderated_at, rejection_reason, created_at, updated_at
+FROM tbl_product_reviews
+WHERE id = ?
+LIMIT 1
+`
+
+func (q *Queries) GetProductReviewByID(ctx context.Context, id int64) (TblProductReview, error) {
+	row := q.db.QueryRowContext(ctx, getProductReviewByID, id)
+	var i TblProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.CustomerID,
+		&i.OrderID,
+		&i.Rating,
+		&i.Title,
+		&i.Body,
+		&i.Status,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+		&i.RejectionReason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getProductReviewPhotosByReviewIDs = `-- name: GetProductReviewPhotosByReviewIDs :many
+SELECT
+	id,
+	review_id,
+	url
+FROM tbl_product_review_photos
+WHERE review_id IN (/*SLICE:review_ids*/?)
+ORDER BY id ASC
+`
+
+type GetProductReviewPhotosByReviewIDsRow struct {
+	ID       int64
+	ReviewID int64
+	Url      string
+}
+
+func (q *Queries) GetProductReviewPhotosByReviewIDs(ctx context.Context, reviewIds []int64) ([]GetProductReviewPhotosByReviewIDsRow, error) {
+	query := getProductReviewPhotosByReviewIDs
+	var queryParams []interface{}
+	if len(reviewIds) > 0 {
+		for _, v := range reviewIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:review_ids*/?", strings.Repeat(",?", len(reviewIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:review_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProductReviewPhotosByReviewIDsRow
+	for rows.Next() {
+		var i GetProductReviewPhotosByReviewIDsRow
+		if err := rows.Scan(&i.ID, &i.ReviewID, &i.Url); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moderateProductReview = `-- name: ModerateProductReview :one
+UPDATE tbl_product_reviews
+SET
+	status = ?1,
+	rejection_reason = ?2,
+	moderated_by = ?3,
+	moderated_at = datetime('now'),
+	updated_at = datetime('now')
+WHERE id = ?4 AND status = 'PENDING'
+RETURNING product_id
+`
+
+type ModerateProductReviewParams struct {
+	Status          string
+	RejectionReason string
+	ModeratedBy     sql.NullInt64
+	ID              int64
+}
+
+func (q *Queries) ModerateProductReview(ctx context.Context, arg ModerateProductReviewParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, moderateProductReview,
+		arg.Status,
+		arg.RejectionReason,
+		arg.ModeratedBy,
+		arg.ID,
+	)
+	var product_id int64
+	err := row.Scan(&product_id)
+	return product_id, err
+}
+
+const refreshProductRating = `-- name: RefreshProductRating :exec
+INSERT INTO tbl_product_ratings (
+	product_id,
+	rating_count,
+	rating_total,
+	updated_at
+)
+SELECT
+	?1,
+	COUNT(tbl_product_reviews.id),
+	COALESCE(SUM(tbl_product_reviews.rating), 0),
+	datetime('now')
+FROM tbl_product_reviews
+WHERE
+	tbl_product_reviews.product_id = ?1
+	AND tbl_product_reviews.status = 'APPROVED'
+ON CONFLICT(product_id) DO UPDATE SET
+	rating_count = excluded.rating_count,
+	rating_total = excluded.rating_total,
+	updated_at = excluded.updated_at
+`
+
+func (q *Queries) RefreshProductRating(ctx context.Context, productID int64) error {
+	_, err := q.db.ExecContext(ctx, refreshProductRating, productID)
+	return err
+}
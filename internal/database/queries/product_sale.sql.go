@@ -10,6 +10,22 @@ import (
 	"time"
 )
 
+const claimProductSaleNotification = `-- name: ClaimProductSaleNotification :execrows
+UPDATE tbl_product_sales
+SET notified_at = datetime('now')
+WHERE id = ? AND notified_at IS NULL
+`
+
+// ClaimProductSaleNotification marks the sale notified and affects no row when
+// another run got there first.
+func (q *Queries) ClaimProductSaleNotification(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, claimProductSaleNotification, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createProductSale = `-- name: CreateProductSale :one
 INSERT INTO tbl_product_sales (
 	product_id,
@@ -30,7 +46,7 @@ INSERT INTO tbl_product_sales (
 	?,
 	datetime('now'),
 	datetime('now')
-) RETURNING id, product_id, sale_price_without_vat, sale_price_with_vat, sale_price_without_vat_currency, sale_price_with_vat_currency, discount_type, discount_value, starts_at, ends_at, is_active, created_at, updated_at, deleted_at
+) RETURNING id, product_id, sale_price_without_vat, sale_price_with_vat, sale_price_without_vat_currency, sale_price_with_vat_currency, discount_type, discount_value, starts_at, ends_at, is_active, created_at, updated_at, deleted_at, sale_campaign_id, notified_at
 `
 
 type CreateProductSaleParams struct {
@@ -75,6 +91,8 @@ func (q *Queries) CreateProductSale(ctx context.Context, arg CreateProductSalePa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SaleCampaignID,
+		&i.NotifiedAt,
 	)
 	return i, err
 }
@@ -93,7 +111,7 @@ func (q *Queries) DeactivateProductSalesByProductID(ctx context.Context, product
 }
 
 const getActiveSaleByProductID = `-- name: GetActiveSaleByProductID :one
-SELECT id, product_id, sale_price_without_vat, sale_price_with_vat, sale_price_without_vat_currency, sale_price_with_vat_currency, discount_type, discount_value, starts_at, ends_at, is_active, created_at, updated_at, deleted_at
+SELECT id, product_id, sale_price_without_vat, sale_price_with_vat, sale_price_without_vat_currency, sale_price_with_vat_currency, discount_type, discount_value, starts_at, ends_at, is_active, created_at, updated_at, deleted_at, sale_campaign_id, notified_at
 FROM tbl_product_sales
 WHERE product_id = ? AND is_active = 1
 LIMIT 1
@@ -117,10 +135,49 @@ func (q *Queries) GetActiveSaleByProductID(ctx context.Context, productID int64)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SaleCampaignID,
+		&i.NotifiedAt,
 	)
 	return i, err
 }
 
+const getProductSalesDueForNotification = `-- name: GetProductSalesDueForNotification :many
+SELECT id, product_id
+FROM tbl_product_sales
+WHERE
+	is_active = 1
+	AND notified_at IS NULL
+	AND datetime('now') BETWEEN starts_at AND ends_at
+`
+
+type GetProductSalesDueForNotificationRow struct {
+	ID        int64
+	ProductID int64
+}
+
+func (q *Queries) GetProductSalesDueForNotification(ctx context.Context) ([]GetProductSalesDueForNotificationRow, error) {
+	rows, err := q.db.QueryContext(ctx, getProductSalesDueForNotification)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProductSalesDueForNotificationRow
+	for rows.Next() {
+		var i GetProductSalesDueForNotificationRow
+		if err := rows.Scan(&i.ID, &i.ProductID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateProductSale = `-- name: UpdateProductSale :exec
 UPDATE tbl_product_sales
 SET
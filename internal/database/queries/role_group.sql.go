@@ -0,0 +1,240 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: role_group.sql
+
+package queries
+
+import (
+	"context"
+)
+
+const assignStaffRoleGroup = `-- name: AssignStaffRoleGroup :exec
+INSERT INTO tbl_staff_role_groups (staff_id, role_group_id, created_at)
+VALUES (?, ?, DATETIME('now'))
+ON CONFLICT (staff_id, role_group_id) DO NOTHING
+`
+
+type AssignStaffRoleGroupParams struct {
+	StaffID     int64
+	RoleGroupID int64
+}
+
+func (q *Queries) AssignStaffRoleGroup(ctx context.Context, arg AssignStaffRoleGroupParams) error {
+	_, err := q.db.ExecContext(ctx, assignStaffRoleGroup, arg.StaffID, arg.RoleGroupID)
+	return err
+}
+
+const createRoleGroup = `-- name: CreateRoleGroup :one
+INSERT INTO tbl_role_groups (name, description, created_at, updated_at)
+VALUES (?, ?, DATETIME('now'), DATETIME('now'))
+RETURNING id
+`
+
+type CreateRoleGroupParams struct {
+	Name        string
+	Description string
+}
+
+func (q *Queries) CreateRoleGroup(ctx context.Context, arg CreateRoleGroupParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createRoleGroup, arg.Name, arg.Description)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteRoleGroup = `-- name: DeleteRoleGroup :execrows
+DELETE FROM tbl_role_groups WHERE id = ?
+`
+
+func (q *Queries) DeleteRoleGroup(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRoleGroup, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRoleGroupPermission = `-- name: DeleteRoleGroupPermission :execrows
+DELETE FROM tbl_role_group_permissions WHERE role_group_id = ? AND role = ?
+`
+
+type DeleteRoleGroupPermissionParams struct {
+	RoleGroupID int64
+	Role        string
+}
+
+func (q *Queries) DeleteRoleGroupPermission(ctx context.Context, arg DeleteRoleGroupPermissionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRoleGroupPermission, arg.RoleGroupID, arg.Role)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRoleGroupPermissionsByGroupID = `-- name: DeleteRoleGroupPermissionsByGroupID :exec
+DELETE FROM tbl_role_group_permissions WHERE role_group_id = ?
+`
+
+func (q *Queries) DeleteRoleGroupPermissionsByGroupID(ctx context.Context, roleGroupID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteRoleGroupPermissionsByGroupID, roleGroupID)
+	return err
+}
+
+const deleteStaffRoleGroupsByGroupID = `-- name: DeleteStaffRoleGroupsByGroupID :exec
+DELETE FROM tbl_staff_role_groups WHERE role_group_id = ?
+`
+
+func (q *Queries) DeleteStaffRoleGroupsByGroupID(ctx context.Context, roleGroupID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteStaffRoleGroupsByGroupID, roleGroupID)
+	return err
+}
+
+const getRoleGroupByID = `-- name: GetRoleGroupByID :one
+SELECT id, name, description, created_at, updated_at FROM tbl_role_groups WHERE id = ?
+`
+
+func (q *Queries) GetRoleGroupByID(ctx context.Context, id int64) (TblRoleGroup, error) {
+	row := q.db.QueryRowContext(ctx, getRoleGroupByID, id)
+	var i TblRoleGroup
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getRoleGroupPermissions = `-- name: GetRoleGroupPermissions :many
+SELECT role_group_id, role, access FROM tbl_role_group_permissions ORDER BY role_group_id, role
+`
+
+type GetRoleGroupPermissionsRow struct {
+	RoleGroupID int64
+	Role        string
+	Access      string
+}
+
+func (q *Queries) GetRoleGroupPermissions(ctx context.Context) ([]GetRoleGroupPermissionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getRoleGroupPermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRoleGroupPermissionsRow
+	for rows.Next() {
+		var i GetRoleGroupPermissionsRow
+		if err := rows.Scan(&i.RoleGroupID, &i.Role, &i.Access); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRoleGroups = `-- name: GetRoleGroups :many
+SELECT id, name, description, created_at, updated_at FROM tbl_role_groups ORDER BY name
+`
+
+func (q *Queries) GetRoleGroups(ctx context.Context) ([]TblRoleGroup, error) {
+	rows, err := q.db.QueryContext(ctx, getRoleGroups)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TblRoleGroup
+	for rows.Next() {
+		var i TblRoleGroup
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffRoleGroups = `-- name: GetStaffRoleGroups :many
+SELECT staff_id, role_group_id FROM tbl_staff_role_groups ORDER BY staff_id, role_group_id
+`
+
+type GetStaffRoleGroupsRow struct {
+	StaffID     int64
+	RoleGroupID int64
+}
+
+func (q *Queries) GetStaffRoleGroups(ctx context.Context) ([]GetStaffRoleGroupsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffRoleGroups)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStaffRoleGroupsRow
+	for rows.Next() {
+		var i GetStaffRoleGroupsRow
+		if err := rows.Scan(&i.StaffID, &i.RoleGroupID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unassignStaffRoleGroup = `-- name: UnassignStaffRoleGroup :execrows
+DELETE FROM tbl_staff_role_groups WHERE staff_id = ? AND role_group_id = ?
+`
+
+type UnassignStaffRoleGroupParams struct {
+	StaffID     int64
+	RoleGroupID int64
+}
+
+func (q *Queries) UnassignStaffRoleGroup(ctx context.Context, arg UnassignStaffRoleGroupParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unassignStaffRoleGroup, arg.StaffID, arg.RoleGroupID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertRoleGroupPermission = `-- name: UpsertRoleGroupPermission :exec
+INSERT INTO tbl_role_group_permissions (role_group_id, role, access, created_at, updated_at)
+VALUES (?, ?, ?, DATETIME('now'), DATETIME('now'))
+ON CONFLICT (role_group_id, role) DO UPDATE SET access = excluded.access, updated_at = DATETIME('now')
+`
+
+type UpsertRoleGroupPermissionParams struct {
+	RoleGroupID int64
+	Role        string
+	Access      string
+}
+
+func (q *Queries) UpsertRoleGroupPermission(ctx context.Context, arg UpsertRoleGroupPermissionParams) error {
+	_, err := q.db.ExecContext(ctx, upsertRoleGroupPermission, arg.RoleGroupID, arg.Role, arg.Access)
+	return err
+}
@@ -0,0 +1,643 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: sale_campaign.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+const addSaleCampaignProduct = `-- name: AddSaleCampaignProduct :exec
+INSERT INTO tbl_sale_campaign_products (sale_campaign_id, product_id)
+VALUES (?, ?)
+ON CONFLICT (sale_campaign_id, product_id) DO NOTHING
+`
+
+type AddSaleCampaignProductParams struct {
+	SaleCampaignID int64
+	ProductID      int64
+}
+
+func (q *Queries) AddSaleCampaignProduct(ctx context.Context, arg AddSaleCampaignProductParams) error {
+	_, err := q.db.ExecContext(ctx, addSaleCampaignProduct, arg.SaleCampaignID, arg.ProductID)
+	return err
+}
+
+const createSaleCampaign = `-- name: CreateSaleCampaign :one
+INSERT INTO tbl_sale_campaigns (
+	name,
+	selector,
+	selector_value,
+	discount_type,
+	discount_value,
+	starts_at,
+	ends_at,
+	status,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?, 'SCHEDULED',
+	DATETIME('now'),
+	DATETIME('now')
+) RETURNING id
+`
+
+type CreateSaleCampaignParams struct {
+	Name          string
+	Selector      string
+	SelectorValue string
+	DiscountType  string
+	DiscountValue int64
+	StartsAt      time.Time
+	EndsAt        time.Time
+}
+
+func (q *Queries) CreateSaleCampaign(ctx context.Context, arg CreateSaleCampaignParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createSaleCampaign,
+		arg.Name,
+		arg.Selector,
+		arg.SelectorValue,
+		arg.DiscountType,
+		arg.DiscountValue,
+		arg.StartsAt,
+		arg.EndsAt,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createSaleCampaignProductSale = `-- name: CreateSaleCampaignProductSale :exec
+INSERT INTO tbl_product_sales (
+	product_id,
+	sale_price_without_vat,
+	sale_price_with_vat,
+	sale_price_without_vat_currency,
+	sale_price_with_vat_currency,
+	discount_type,
+	discount_value,
+	starts_at,
+	ends_at,
+	is_active,
+	sale_campaign_id,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?,
+	?, ?, ?, ?,
+	1,
+	?,
+	DATETIME('now'),
+	DATETIME('now')
+)
+`
+
+type CreateSaleCampaignProductSaleParams struct {
+	ProductID                   int64
+	SalePriceWithoutVat         int64
+	SalePriceWithVat            int64
+	SalePriceWithoutVatCurrency string
+	SalePriceWithVatCurrency    string
+	DiscountType                string
+	DiscountValue               int64
+	StartsAt                    time.Time
+	EndsAt                      time.Time
+	SaleCampaignID              sql.NullInt64
+}
+
+func (q *Queries) CreateSaleCampaignProductSale(ctx context.Context, arg CreateSaleCampaignProductSaleParams) error {
+	_, err := q.db.ExecContext(ctx, createSaleCampaignProductSale,
+		arg.ProductID,
+		arg.SalePriceWithoutVat,
+		arg.SalePriceWithVat,
+		arg.SalePriceWithoutVatCurrency,
+		arg.SalePriceWithVatCurrency,
+		arg.DiscountType,
+		arg.DiscountValue,
+		arg.StartsAt,
+		arg.EndsAt,
+		arg.SaleCampaignID,
+	)
+	return err
+}
+
+const deactivateProductSalesBySaleCampaignID = `-- name: DeactivateProductSalesBySaleCampaignID :exec
+UPDATE tbl_product_sales
+SET
+	is_active = 0,
+	updated_at = DATETIME('now')
+WHERE sale_campaign_id = ? AND is_active = 1
+`
+
+func (q *Queries) DeactivateProductSalesBySaleCampaignID(ctx context.Context, saleCampaignID sql.NullInt64) error {
+	_, err := q.db.ExecContext(ctx, deactivateProductSalesBySaleCampaignID, saleCampaignID)
+	return err
+}
+
+const getSaleCampaignByID = `-- name: GetSaleCampaignByID :one
+SELECT id, name, selector, selector_value, discount_type, discount_value, starts_at, ends_at, status, created_at, updated_at
+FROM tbl_sale_campaigns
+WHERE id = ?
+LIMIT 1
+`
+
+func (q *Queries) GetSaleCampaignByID(ctx context.Context, id int64) (TblSaleCampaign, error) {
+	row := q.db.QueryRowContext(ctx, getSaleCampaignByID, id)
+	var i TblSaleCampaign
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Selector,
+		&i.SelectorValue,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSaleCampaignCandidatesByBrandID = `-- name: GetSaleCampaignCandidatesByBrandID :many
+SELECT
+	tbl_products.id,
+	tbl_products.serial,
+	tbl_products.name,
+	tbl_products.unit_price_without_vat,
+	tbl_products.unit_price_with_vat,
+	tbl_products.unit_price_without_vat_currency,
+	tbl_products.unit_price_with_vat_currency,
+	EXISTS (
+		SELECT 1
+		FROM tbl_product_sales
+		WHERE tbl_product_sales.product_id = tbl_products.id AND tbl_product_sales.is_active = 1
+	) AS has_other_sale
+FROM tbl_products
+WHERE tbl_products.status = 'ACTIVE' AND tbl_products.brand_id = ?
+ORDER BY tbl_products.name ASC
+`
+
+type GetSaleCampaignCandidatesByBrandIDRow struct {
+	ID                          int64
+	Serial                      string
+	Name                        string
+	UnitPriceWithoutVat         int64
+	UnitPriceWithVat            int64
+	UnitPriceWithoutVatCurrency string
+	UnitPriceWithVatCurrency    string
+	HasOtherSale                bool
+}
+
+func (q *Queries) GetSaleCampaignCandidatesByBrandID(ctx context.Context, brandID int64) ([]GetSaleCampaignCandidatesByBrandIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSaleCampaignCandidatesByBrandID, brandID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSaleCampaignCandidatesByBrandIDRow
+	for rows.Next() {
+		var i GetSaleCampaignCandidatesByBrandIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Serial,
+			&i.Name,
+			&i.UnitPriceWithoutVat,
+			&i.UnitPriceWithVat,
+			&i.UnitPriceWithoutVatCurrency,
+			&i.UnitPriceWithVatCurrency,
+			&i.HasOtherSale,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSaleCampaignCandidatesByCategory = `-- name: GetSaleCampaignCandidatesByCategory :many
+SELECT DISTINCT
+	tbl_products.id,
+	tbl_products.serial,
+	tbl_products.name,
+	tbl_products.unit_price_without_vat,
+	tbl_products.unit_price_with_vat,
+	tbl_products.unit_price_without_vat_currency,
+	tbl_products.unit_price_with_vat_currency,
+	EXISTS (
+		SELECT 1
+		FROM tbl_product_sales
+		WHERE tbl_product_sales.product_id = tbl_products.id AND tbl_product_sales.is_active = 1
+	) AS has_other_sale
+FROM tbl_products
+INNER JOIN tbl_products_categories ON tbl_products_categories.product_id = tbl_products.id
+INNER JOIN tbl_product_categories ON tbl_product_categories.id = tbl_products_categories.category_id
+WHERE tbl_products.status = 'ACTIVE' AND LOWER(tbl_product_categories.category) = LOWER(?1)
+ORDER BY tbl_products.name ASC
+`
+
+type GetSaleCampaignCandidatesByCategoryRow struct {
+	ID                          int64
+	Serial                      string
+	Name                        string
+	UnitPriceWithoutVat         int64
+	UnitPriceWithVat            int64
+	UnitPriceWithoutVatCurrency string
+	UnitPriceWithVatCurrency    string
+	HasOtherSale                bool
+}
+
+func (q *Queries) GetSaleCampaignCandidatesByCategory(ctx context.Context, category string) ([]GetSaleCampaignCandidatesByCategoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSaleCampaignCandidatesByCategory, category)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSaleCampaignCandidatesByCategoryRow
+	for rows.Next() {
+		var i GetSaleCampaignCandidatesByCategoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Serial,
+			&i.Name,
+			&i.UnitPriceWithoutVat,
+			&i.UnitPriceWithVat,
+			&i.UnitPriceWithoutVatCurrency,
+			&i.UnitPriceWithVatCurrency,
+			&i.HasOtherSale,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSaleCampaignCandidatesBySerials = `-- name: GetSaleCampaignCandidatesBySerials :many
+SELECT
+	tbl_products.id,
+	tbl_products.serial,
+	tbl_products.name,
+	tbl_products.unit_price_without_vat,
+	tbl_products.unit_price_with_vat,
+	tbl_products.unit_price_without_vat_currency,
+	tbl_products.unit_price_with_vat_currency,
+	EXISTS (
+		SELECT 1
+		FROM tbl_product_sales
+		WHERE tbl_product_sales.product_id = tbl_products.id AND tbl_product_sales.is_active = 1
+	) AS has_other_sale
+FROM tbl_products
+WHERE tbl_products.status = 'ACTIVE' AND tbl_products.serial IN (/*SLICE:serials*/?)
+ORDER BY tbl_products.name ASC
+`
+
+type GetSaleCampaignCandidatesBySerialsRow struct {
+	ID                          int64
+	Serial                      string
+	Name                        string
+	UnitPriceWithoutVat         int64
+	UnitPriceWithVat            int64
+	UnitPriceWithoutVatCurrency string
+	UnitPriceWithVatCurrency    string
+	HasOtherSale                bool
+}
+
+func (q *Queries) GetSaleCampaignCandidatesBySerials(ctx context.Context, serials []string) ([]GetSaleCampaignCandidatesBySerialsRow, error) {
+	query := getSaleCampaignCandidatesBySerials
+	var queryParams []interface{}
+	if len(serials) > 0 {
+		for _, v := range serials {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:serials*/?", strings.Repeat(",?", len(serials))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:serials*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSaleCampaignCandidatesBySerialsRow
+	for rows.Next() {
+		var i GetSaleCampaignCandidatesBySerialsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Serial,
+			&i.Name,
+			&i.UnitPriceWithoutVat,
+			&i.UnitPriceWithVat,
+			&i.UnitPriceWithoutVatCurrency,
+			&i.UnitPriceWithVatCurrency,
+			&i.HasOtherSale,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSaleCampaignProducts = `-- name: GetSaleCampaignProducts :many
+SELECT
+	tbl_products.id,
+	tbl_products.serial,
+	tbl_products.name,
+	tbl_products.unit_price_without_vat,
+	tbl_products.unit_price_with_vat,
+	tbl_products.unit_price_without_vat_currency,
+	tbl_products.unit_price_with_vat_currency,
+	EXISTS (
+		SELECT 1
+		FROM tbl_product_sales
+		WHERE tbl_product_sales.product_id = tbl_products.id
+			AND tbl_product_sales.is_active = 1
+			AND COALESCE(tbl_product_sales.sale_campaign_id, 0) != tbl_sale_campaign_products.sale_campaign_id
+	) AS has_other_sale
+FROM tbl_sale_campaign_products
+INNER JOIN tbl_products ON tbl_products.id = tbl_sale_campaign_products.product_id
+WHERE tbl_sale_campaign_products.sale_campaign_id = ?
+ORDER BY tbl_products.name ASC
+`
+
+type GetSaleCampaignProductsRow struct {
+	ID                          int64
+	Serial                      string
+	Name                        string
+	UnitPriceWithoutVat         int64
+	UnitPriceWithVat            int64
+	UnitPriceWithoutVatCurrency string
+	UnitPriceWithVatCurrency    string
+	HasOtherSale                bool
+}
+
+func (q *Queries) GetSaleCampaignProducts(ctx context.Context, saleCampaignID int64) ([]GetSaleCampaignProductsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSaleCampaignProducts, saleCampaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSaleCampaignProductsRow
+	for rows.Next() {
+		var i GetSaleCampaignProductsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Serial,
+			&i.Name,
+			&i.UnitPriceWithoutVat,
+			&i.UnitPriceWithVat,
+			&i.UnitPriceWithoutVatCurrency,
+			&i.UnitPriceWithVatCurrency,
+			&i.HasOtherSale,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSaleCampaignReport = `-- name: GetSaleCampaignReport :many
+SELECT
+	tbl_products.id,
+	tbl_products.serial,
+	tbl_products.name,
+	CAST(COALESCE(SUM(tbl_order_lines.quantity), 0) AS INTEGER) AS units_sold,
+	CAST(COALESCE(SUM(tbl_order_lines.total_price), 0) AS INTEGER) AS revenue
+FROM tbl_sale_campaign_products
+INNER JOIN tbl_sale_campaigns ON tbl_sale_campaigns.id = tbl_sale_campaign_products.sale_campaign_id
+INNER JOIN tbl_products ON tbl_products.id = tbl_sale_campaign_products.product_id
+LEFT JOIN tbl_order_lines ON tbl_order_lines.product_id = tbl_products.id
+	AND EXISTS (
+		SELECT 1
+		FROM tbl_orders
+		WHERE tbl_orders.id = tbl_order_lines.order_id
+			AND tbl_orders.status NOT IN ('CANCELLED', 'REFUNDED')
+			-- A cancelled campaign stopped selling when it was cancelled, which is
+			-- the last time its row was updated.
+			AND tbl_orders.created_at BETWEEN tbl_sale_campaigns.starts_at AND (
+				CASE
+					WHEN tbl_sale_campaigns.status = 'CANCELLED' AND tbl_sale_campaigns.updated_at < tbl_sale_campaigns.ends_at
+						THEN tbl_sale_campaigns.updated_at
+					ELSE tbl_sale_campaigns.ends_at
+				END
+			)
+	)
+WHERE tbl_sale_campaign_products.sale_campaign_id = ?
+	-- Products skipped for another sale never got this campaign's price.
+	AND EXISTS (
+		SELECT 1
+		FROM tbl_product_sales
+		WHERE tbl_product_sales.product_id = tbl_products.id
+			AND tbl_product_sales.sale_campaign_id = tbl_sale_campaigns.id
+	)
+GROUP BY tbl_products.id
+ORDER BY revenue DESC, tbl_products.name ASC
+`
+
+type GetSaleCampaignReportRow struct {
+	ID        int64
+	Serial    string
+	Name      string
+	UnitsSold int64
+	Revenue   int64
+}
+
+func (q *Queries) GetSaleCampaignReport(ctx context.Context, saleCampaignID int64) ([]GetSaleCampaignReportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSaleCampaignReport, saleCampaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSaleCampaignReportRow
+	for rows.Next() {
+		var i GetSaleCampaignReportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Serial,
+			&i.Name,
+			&i.UnitsSold,
+			&i.Revenue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSaleCampaigns = `-- name: GetSaleCampaigns :many
+SELECT
+	tbl_sale_campaigns.id, tbl_sale_campaigns.name, tbl_sale_campaigns.selector, tbl_sale_campaigns.selector_value, tbl_sale_campaigns.discount_type, tbl_sale_campaigns.discount_value, tbl_sale_campaigns.starts_at, tbl_sale_campaigns.ends_at, tbl_sale_campaigns.status, tbl_sale_campaigns.created_at, tbl_sale_campaigns.updated_at,
+	(
+		SELECT COUNT(*)
+		FROM tbl_sale_campaign_products
+		WHERE tbl_sale_campaign_products.sale_campaign_id = tbl_sale_campaigns.id
+	) AS product_count
+FROM tbl_sale_campaigns
+ORDER BY tbl_sale_campaigns.starts_at DESC, tbl_sale_campaigns.id DESC
+`
+
+type GetSaleCampaignsRow struct {
+	ID            int64
+	Name          string
+	Selector      string
+	SelectorValue string
+	DiscountType  string
+	DiscountValue int64
+	StartsAt      time.Time
+	EndsAt        time.Time
+	Status        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	ProductCount  int64
+}
+
+func (q *Queries) GetSaleCampaigns(ctx context.Context) ([]GetSaleCampaignsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSaleCampaigns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSaleCampaignsRow
+	for rows.Next() {
+		var i GetSaleCampaignsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Selector,
+			&i.SelectorValue,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ProductCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSaleCampaignsDueForActivation = `-- name: GetSaleCampaignsDueForActivation :many
+SELECT id
+FROM tbl_sale_campaigns
+WHERE status = 'SCHEDULED' AND starts_at <= ?1 AND ends_at > ?1
+`
+
+func (q *Queries) GetSaleCampaignsDueForActivation(ctx context.Context, now time.Time) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, getSaleCampaignsDueForActivation, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSaleCampaignsDueForEnding = `-- name: GetSaleCampaignsDueForEnding :many
+SELECT id
+FROM tbl_sale_campaigns
+WHERE (status = 'ACTIVE' OR status = 'SCHEDULED') AND ends_at <= ?1
+`
+
+func (q *Queries) GetSaleCampaignsDueForEnding(ctx context.Context, now time.Time) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, getSaleCampaignsDueForEnding, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateSaleCampaignStatus = `-- name: UpdateSaleCampaignStatus :exec
+UPDATE tbl_sale_campaigns
+SET
+	status = ?,
+	updated_at = DATETIME('now')
+WHERE id = ?
+`
+
+type UpdateSaleCampaignStatusParams struct {
+	Status string
+	ID     int64
+}
+
+func (q *Queries) UpdateSaleCampaignStatus(ctx context.Context, arg UpdateSaleCampaignStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateSaleCampaignStatus, arg.Status, arg.ID)
+	return err
+}
@@ -0,0 +1,214 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: search_rule.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createSearchRule = `-- name: CreateSearchRule :one
+INSERT INTO tbl_search_rules (
+	search_term,
+	kind,
+	product_id,
+	category_id,
+	brand_id,
+	position,
+	created_by,
+	created_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?, DATETIME('now')
+) RETURNING id
+`
+
+type CreateSearchRuleParams struct {
+	SearchTerm string
+	Kind       string
+	ProductID  sql.NullInt64
+	CategoryID sql.NullInt64
+	BrandID    sql.NullInt64
+	Position   int64
+	CreatedBy  int64
+}
+
+func (q *Queries) CreateSearchRule(ctx context.Context, arg CreateSearchRuleParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createSearchRule,
+		arg.SearchTerm,
+		arg.Kind,
+		arg.ProductID,
+		arg.CategoryID,
+		arg.BrandID,
+		arg.Position,
+		arg.CreatedBy,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteSearchRule = `-- name: DeleteSearchRule :execrows
+DELETE FROM tbl_search_rules
+WHERE id = ?
+`
+
+func (q *Queries) DeleteSearchRule(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteSearchRule, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getSearchRedirectCategory = `-- name: GetSearchRedirectCategory :one
+SELECT
+	CAST(COALESCE(c.category, '') AS TEXT) AS category,
+	CAST(COALESCE(c.subcategory, '') AS TEXT) AS subcategory
+FROM tbl_search_rules r
+INNER JOIN tbl_product_categories c ON c.id = r.category_id
+WHERE r.search_term = ?
+AND r.kind = 'REDIRECT_CATEGORY'
+LIMIT 1
+`
+
+type GetSearchRedirectCategoryRow struct {
+	Category    string
+	Subcategory string
+}
+
+func (q *Queries) GetSearchRedirectCategory(ctx context.Context, searchTerm string) (GetSearchRedirectCategoryRow, error) {
+	row := q.db.QueryRowContext(ctx, getSearchRedirectCategory, searchTerm)
+	var i GetSearchRedirectCategoryRow
+	err := row.Scan(&i.Category, &i.Subcategory)
+	return i, err
+}
+
+const getSearchRuleByID = `-- name: GetSearchRuleByID :one
+SELECT id, search_term, kind
+FROM tbl_search_rules
+WHERE id = ?
+`
+
+type GetSearchRuleByIDRow struct {
+	ID         int64
+	SearchTerm string
+	Kind       string
+}
+
+func (q *Queries) GetSearchRuleByID(ctx context.Context, id int64) (GetSearchRuleByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getSearchRuleByID, id)
+	var i GetSearchRuleByIDRow
+	err := row.Scan(&i.ID, &i.SearchTerm, &i.Kind)
+	return i, err
+}
+
+const getSearchRuleCategoryOptions = `-- name: GetSearchRuleCategoryOptions :many
+SELECT
+	id,
+	CAST(COALESCE(category, '') AS TEXT) AS category,
+	CAST(COALESCE(subcategory, '') AS TEXT) AS subcategory
+FROM tbl_product_categories
+WHERE category IS NOT NULL
+AND category != ''
+ORDER BY category ASC, subcategory ASC
+`
+
+type GetSearchRuleCategoryOptionsRow struct {
+	ID          int64
+	Category    string
+	Subcategory string
+}
+
+func (q *Queries) GetSearchRuleCategoryOptions(ctx context.Context) ([]GetSearchRuleCategoryOptionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSearchRuleCategoryOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSearchRuleCategoryOptionsRow
+	for rows.Next() {
+		var i GetSearchRuleCategoryOptionsRow
+		if err := rows.Scan(&i.ID, &i.Category, &i.Subcategory); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSearchRules = `-- name: GetSearchRules :many
+SELECT
+	r.id,
+	r.search_term,
+	r.kind,
+	r.position,
+	r.created_at,
+	CAST(COALESCE(p.serial || ' - ' || p.name, '') AS TEXT) AS product_label,
+	CAST(COALESCE(c.category, '') AS TEXT) AS category,
+	CAST(COALESCE(c.subcategory, '') AS TEXT) AS subcategory,
+	CAST(COALESCE(b.name, '') AS TEXT) AS brand_name,
+	CAST(COALESCE(s.first_name || ' ' || s.last_name, '') AS TEXT) AS created_by_name
+FROM tbl_search_rules r
+LEFT JOIN tbl_products p ON p.id = r.product_id
+LEFT JOIN tbl_product_categories c ON c.id = r.category_id
+LEFT JOIN tbl_brands b ON b.id = r.brand_id
+LEFT JOIN tbl_staffs s ON s.id = r.created_by
+ORDER BY r.search_term ASC, r.kind ASC, r.position ASC, r.id ASC
+`
+
+type GetSearchRulesRow struct {
+	ID            int64
+	SearchTerm    string
+	Kind          string
+	Position      int64
+	CreatedAt     time.Time
+	ProductLabel  string
+	Category      string
+	Subcategory   string
+	BrandName     string
+	CreatedByName string
+}
+
+func (q *Queries) GetSearchRules(ctx context.Context) ([]GetSearchRulesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSearchRules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSearchRulesRow
+	for rows.Next() {
+		var i GetSearchRulesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SearchTerm,
+			&i.Kind,
+			&i.Position,
+			&i.CreatedAt,
+			&i.ProductLabel,
+			&i.Category,
+			&i.Subcategory,
+			&i.BrandName,
+			&i.CreatedByName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
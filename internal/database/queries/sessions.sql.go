@@ -0,0 +1,292 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: sessions.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createSession = `-- name: CreateSession :one
+INSERT INTO tbl_sessions (user_type, user_id, key_hash, useragent_id, ip_address, created_at, last_seen_at)
+VALUES (?, ?, ?, ?, ?, DATETIME('now'), DATETIME('now'))
+RETURNING id
+`
+
+type CreateSessionParams struct {
+	UserType    string
+	UserID      int64
+	KeyHash     string
+	UseragentID sql.NullInt64
+	IpAddress   string
+}
+
+func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createSession,
+		arg.UserType,
+		arg.UserID,
+		arg.KeyHash,
+		arg.UseragentID,
+		arg.IpAddress,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getActiveSessionsByUser = `-- name: GetActiveSessionsByUser :many
+SELECT
+	s.id,
+	s.key_hash,
+	s.ip_address,
+	s.created_at,
+	s.last_seen_at,
+	COALESCE(ua.browser, '') AS browser,
+	COALESCE(ua.os, '') AS os,
+	COALESCE(ua.device, '') AS device
+FROM tbl_sessions s
+LEFT JOIN tbl_useragents ua ON ua.id = s.useragent_id
+WHERE s.user_type = ? AND s.user_id = ? AND s.revoked_at IS NULL
+ORDER BY s.last_seen_at DESC
+`
+
+type GetActiveSessionsByUserParams struct {
+	UserType string
+	UserID   int64
+}
+
+type GetActiveSessionsByUserRow struct {
+	ID         int64
+	KeyHash    string
+	IpAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	Browser    string
+	Os         string
+	Device     string
+}
+
+func (q *Queries) GetActiveSessionsByUser(ctx context.Context, arg GetActiveSessionsByUserParams) ([]GetActiveSessionsByUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, getActiveSessionsByUser, arg.UserType, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActiveSessionsByUserRow
+	for rows.Next() {
+		var i GetActiveSessionsByUserRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.KeyHash,
+			&i.IpAddress,
+			&i.CreatedAt,
+			&i.LastSeenAt,
+			&i.Browser,
+			&i.Os,
+			&i.Device,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllActiveSessions = `-- name: GetAllActiveSessions :many
+SELECT
+	s.id,
+	s.user_type,
+	s.user_id,
+	s.ip_address,
+	s.created_at,
+	s.last_seen_at,
+	COALESCE(ua.browser, '') AS browser,
+	COALESCE(ua.os, '') AS os,
+	COALESCE(ua.device, '') AS device,
+	CAST(COALESCE(st.first_name || ' ' || st.last_name, c.first_name || ' ' || c.last_name, '') AS TEXT) AS full_name
+FROM tbl_sessions s
+LEFT JOIN tbl_useragents ua ON ua.id = s.useragent_id
+LEFT JOIN tbl_staffs st ON s.user_type = 'STAFF' AND st.id = s.user_id
+LEFT JOIN tbl_customers c ON s.user_type = 'CUSTOMER' AND c.id = s.user_id
+WHERE s.revoked_at IS NULL
+ORDER BY s.last_seen_at DESC
+LIMIT ?
+`
+
+type GetAllActiveSessionsRow struct {
+	ID         int64
+	UserType   string
+	UserID     int64
+	IpAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	Browser    string
+	Os         string
+	Device     string
+	FullName   string
+}
+
+func (q *Queries) GetAllActiveSessions(ctx context.Context, limit int64) ([]GetAllActiveSessionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllActiveSessions, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAllActiveSessionsRow
+	for rows.Next() {
+		var i GetAllActiveSessionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserType,
+			&i.UserID,
+			&i.IpAddress,
+			&i.CreatedAt,
+			&i.LastSeenAt,
+			&i.Browser,
+			&i.Os,
+			&i.Device,
+			&i.FullName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSessionByKeyHash = `-- name: GetSessionByKeyHash :one
+SELECT id, user_type, user_id, key_hash, useragent_id, ip_address, created_at, last_seen_at, revoked_at, revoked_reason FROM tbl_sessions WHERE key_hash = ?
+`
+
+func (q *Queries) GetSessionByKeyHash(ctx context.Context, keyHash string) (TblSession, error) {
+	row := q.db.QueryRowContext(ctx, getSessionByKeyHash, keyHash)
+	var i TblSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserType,
+		&i.UserID,
+		&i.KeyHash,
+		&i.UseragentID,
+		&i.IpAddress,
+		&i.CreatedAt,
+		&i.LastSeenAt,
+		&i.RevokedAt,
+		&i.RevokedReason,
+	)
+	return i, err
+}
+
+const revokeOtherUserSessions = `-- name: RevokeOtherUserSessions :execrows
+UPDATE tbl_sessions
+SET revoked_at = DATETIME('now'), revoked_reason = ?1
+WHERE user_type = ?2 AND user_id = ?3 AND key_hash != ?4 AND revoked_at IS NULL
+`
+
+type RevokeOtherUserSessionsParams struct {
+	Reason   sql.NullString
+	UserType string
+	UserID   int64
+	KeyHash  string
+}
+
+func (q *Queries) RevokeOtherUserSessions(ctx context.Context, arg RevokeOtherUserSessionsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeOtherUserSessions,
+		arg.Reason,
+		arg.UserType,
+		arg.UserID,
+		arg.KeyHash,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const revokeSession = `-- name: RevokeSession :execrows
+UPDATE tbl_sessions
+SET revoked_at = DATETIME('now'), revoked_reason = ?1
+WHERE id = ?2 AND revoked_at IS NULL
+`
+
+type RevokeSessionParams struct {
+	Reason sql.NullString
+	ID     int64
+}
+
+func (q *Queries) RevokeSession(ctx context.Context, arg RevokeSessionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeSession, arg.Reason, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const revokeSessionByKeyHash = `-- name: RevokeSessionByKeyHash :exec
+UPDATE tbl_sessions
+SET revoked_at = DATETIME('now'), revoked_reason = ?1
+WHERE key_hash = ?2 AND revoked_at IS NULL
+`
+
+type RevokeSessionByKeyHashParams struct {
+	Reason  sql.NullString
+	KeyHash string
+}
+
+func (q *Queries) RevokeSessionByKeyHash(ctx context.Context, arg RevokeSessionByKeyHashParams) error {
+	_, err := q.db.ExecContext(ctx, revokeSessionByKeyHash, arg.Reason, arg.KeyHash)
+	return err
+}
+
+const revokeUserSessions = `-- name: RevokeUserSessions :execrows
+UPDATE tbl_sessions
+SET revoked_at = DATETIME('now'), revoked_reason = ?1
+WHERE user_type = ?2 AND user_id = ?3 AND revoked_at IS NULL
+`
+
+type RevokeUserSessionsParams struct {
+	Reason   sql.NullString
+	UserType string
+	UserID   int64
+}
+
+func (q *Queries) RevokeUserSessions(ctx context.Context, arg RevokeUserSessionsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeUserSessions, arg.Reason, arg.UserType, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const touchSession = `-- name: TouchSession :exec
+UPDATE tbl_sessions
+SET last_seen_at = DATETIME('now'), ip_address = ?1
+WHERE id = ?2 AND revoked_at IS NULL AND last_seen_at < DATETIME('now', '-1 minutes')
+`
+
+type TouchSessionParams struct {
+	IpAddress string
+	ID        int64
+}
+
+// Only writes once a minute per session so every request does not hit the
+// write connection.
+func (q *Queries) TouchSession(ctx context.Context, arg TouchSessionParams) error {
+	_, err := q.db.ExecContext(ctx, touchSession, arg.IpAddress, arg.ID)
+	return err
+}
@@ -0,0 +1,770 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: shift.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createStaffShiftSwap = `-- name: CreateStaffShiftSwap :one
+INSERT INTO tbl_staff_shift_swaps (
+	requester_id,
+	counterpart_id,
+	for_date,
+	reason,
+	status,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, 'PENDING',
+	DATETIME('now'),
+	DATETIME('now')
+) RETURNING id
+`
+
+type CreateStaffShiftSwapParams struct {
+	RequesterID   int64
+	CounterpartID int64
+	ForDate       string
+	Reason        string
+}
+
+func (q *Queries) CreateStaffShiftSwap(ctx context.Context, arg CreateStaffShiftSwapParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createStaffShiftSwap,
+		arg.RequesterID,
+		arg.CounterpartID,
+		arg.ForDate,
+		arg.Reason,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const decideStaffShiftSwap = `-- name: DecideStaffShiftSwap :execrows
+UPDATE tbl_staff_shift_swaps
+SET
+	status = ?,
+	decided_by = ?,
+	decided_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE id = ? AND status = 'PENDING'
+`
+
+type DecideStaffShiftSwapParams struct {
+	Status    string
+	DecidedBy sql.NullInt64
+	ID        int64
+}
+
+func (q *Queries) DecideStaffShiftSwap(ctx context.Context, arg DecideStaffShiftSwapParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, decideStaffShiftSwap, arg.Status, arg.DecidedBy, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteStaffShiftDays = `-- name: DeleteStaffShiftDays :exec
+DELETE FROM tbl_staff_shift_days
+WHERE staff_id = ?
+`
+
+func (q *Queries) DeleteStaffShiftDays(ctx context.Context, staffID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteStaffShiftDays, staffID)
+	return err
+}
+
+const deleteStaffShiftDaysFromWeek = `-- name: DeleteStaffShiftDaysFromWeek :exec
+DELETE FROM tbl_staff_shift_days
+WHERE staff_id = ? AND week_index >= ?
+`
+
+type DeleteStaffShiftDaysFromWeekParams struct {
+	StaffID   int64
+	WeekIndex int64
+}
+
+func (q *Queries) DeleteStaffShiftDaysFromWeek(ctx context.Context, arg DeleteStaffShiftDaysFromWeekParams) error {
+	_, err := q.db.ExecContext(ctx, deleteStaffShiftDaysFromWeek, arg.StaffID, arg.WeekIndex)
+	return err
+}
+
+const deleteStaffShiftOverride = `-- name: DeleteStaffShiftOverride :execrows
+DELETE FROM tbl_staff_shift_overrides
+WHERE id = ? AND staff_id = ?
+`
+
+type DeleteStaffShiftOverrideParams struct {
+	ID      int64
+	StaffID int64
+}
+
+func (q *Queries) DeleteStaffShiftOverride(ctx context.Context, arg DeleteStaffShiftOverrideParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteStaffShiftOverride, arg.ID, arg.StaffID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteStaffShiftRotation = `-- name: DeleteStaffShiftRotation :exec
+DELETE FROM tbl_staff_shift_rotations
+WHERE staff_id = ?
+`
+
+func (q *Queries) DeleteStaffShiftRotation(ctx context.Context, staffID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteStaffShiftRotation, staffID)
+	return err
+}
+
+const getAllStaffShiftDays = `-- name: GetAllStaffShiftDays :many
+SELECT id, staff_id, week_index, weekday, time_in, time_out, rest_day, created_at, updated_at
+FROM tbl_staff_shift_days
+`
+
+func (q *Queries) GetAllStaffShiftDays(ctx context.Context) ([]TblStaffShiftDay, error) {
+	rows, err := q.db.QueryContext(ctx, getAllStaffShiftDays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TblStaffShiftDay
+	for rows.Next() {
+		var i TblStaffShiftDay
+		if err := rows.Scan(
+			&i.ID,
+			&i.StaffID,
+			&i.WeekIndex,
+			&i.Weekday,
+			&i.TimeIn,
+			&i.TimeOut,
+			&i.RestDay,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllStaffShiftOverridesByDateRange = `-- name: GetAllStaffShiftOverridesByDateRange :many
+SELECT id, staff_id, for_date, time_in, time_out, rest_day, note, shift_swap_id, created_by, created_at, updated_at
+FROM tbl_staff_shift_overrides
+WHERE
+	for_date >= ?1
+	AND for_date <= ?2
+`
+
+type GetAllStaffShiftOverridesByDateRangeParams struct {
+	StartDate string
+	EndDate   string
+}
+
+func (q *Queries) GetAllStaffShiftOverridesByDateRange(ctx context.Context, arg GetAllStaffShiftOverridesByDateRangeParams) ([]TblStaffShiftOverride, error) {
+	rows, err := q.db.QueryContext(ctx, getAllStaffShiftOverridesByDateRange, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TblStaffShiftOverride
+	for rows.Next() {
+		var i TblStaffShiftOverride
+		if err := rows.Scan(
+			&i.ID,
+			&i.StaffID,
+			&i.ForDate,
+			&i.TimeIn,
+			&i.TimeOut,
+			&i.RestDay,
+			&i.Note,
+			&i.ShiftSwapID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingStaffShiftSwapForDate = `-- name: GetPendingStaffShiftSwapForDate :one
+SELECT id
+FROM tbl_staff_shift_swaps
+WHERE
+	status = 'PENDING'
+	AND for_date = ?1
+	AND (requester_id IN (?2, ?3) OR counterpart_id IN (?2, ?3))
+LIMIT 1
+`
+
+type GetPendingStaffShiftSwapForDateParams struct {
+	ForDate       string
+	RequesterID   int64
+	CounterpartID int64
+}
+
+func (q *Queries) GetPendingStaffShiftSwapForDate(ctx context.Context, arg GetPendingStaffShiftSwapForDateParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getPendingStaffShiftSwapForDate, arg.ForDate, arg.RequesterID, arg.CounterpartID)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getShiftStaffByID = `-- name: GetShiftStaffByID :one
+SELECT
+	tbl_staffs.id,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position,
+	tbl_staffs.time_in_schedule,
+	tbl_staffs.time_out_schedule
+FROM tbl_staffs
+WHERE
+	tbl_staffs.id = ?
+	AND tbl_staffs.deleted_at = '1970-01-01 00:00:00+00:00'
+LIMIT 1
+`
+
+type GetShiftStaffByIDRow struct {
+	ID              int64
+	FirstName       string
+	MiddleName      sql.NullString
+	LastName        string
+	Position        string
+	TimeInSchedule  sql.NullString
+	TimeOutSchedule sql.NullString
+}
+
+func (q *Queries) GetShiftStaffByID(ctx context.Context, id int64) (GetShiftStaffByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getShiftStaffByID, id)
+	var i GetShiftStaffByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.MiddleName,
+		&i.LastName,
+		&i.Position,
+		&i.TimeInSchedule,
+		&i.TimeOutSchedule,
+	)
+	return i, err
+}
+
+const getShiftStaffs = `-- name: GetShiftStaffs :many
+SELECT
+	tbl_staffs.id,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position,
+	tbl_staffs.time_in_schedule,
+	tbl_staffs.time_out_schedule,
+	tbl_staff_shift_rotations.cycle_weeks,
+	(
+		SELECT COUNT(*)
+		FROM tbl_staff_shift_days
+		WHERE tbl_staff_shift_days.staff_id = tbl_staffs.id
+	) AS template_days
+FROM tbl_staffs
+LEFT JOIN tbl_staff_shift_rotations ON tbl_staff_shift_rotations.staff_id = tbl_staffs.id
+WHERE
+	tbl_staffs.deleted_at = '1970-01-01 00:00:00+00:00'
+	AND tbl_staffs.status != 'RESIGNED'
+ORDER BY tbl_staffs.last_name ASC, tbl_staffs.first_name ASC
+`
+
+type GetShiftStaffsRow struct {
+	ID              int64
+	FirstName       string
+	MiddleName      sql.NullString
+	LastName        string
+	Position        string
+	TimeInSchedule  sql.NullString
+	TimeOutSchedule sql.NullString
+	CycleWeeks      sql.NullInt64
+	TemplateDays    int64
+}
+
+func (q *Queries) GetShiftStaffs(ctx context.Context) ([]GetShiftStaffsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getShiftStaffs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetShiftStaffsRow
+	for rows.Next() {
+		var i GetShiftStaffsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.MiddleName,
+			&i.LastName,
+			&i.Position,
+			&i.TimeInSchedule,
+			&i.TimeOutSchedule,
+			&i.CycleWeeks,
+			&i.TemplateDays,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffShiftDays = `-- name: GetStaffShiftDays :many
+SELECT id, staff_id, week_index, weekday, time_in, time_out, rest_day, created_at, updated_at
+FROM tbl_staff_shift_days
+WHERE staff_id = ?
+ORDER BY week_index ASC, weekday ASC
+`
+
+func (q *Queries) GetStaffShiftDays(ctx context.Context, staffID int64) ([]TblStaffShiftDay, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffShiftDays, staffID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TblStaffShiftDay
+	for rows.Next() {
+		var i TblStaffShiftDay
+		if err := rows.Scan(
+			&i.ID,
+			&i.StaffID,
+			&i.WeekIndex,
+			&i.Weekday,
+			&i.TimeIn,
+			&i.TimeOut,
+			&i.RestDay,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffShiftDefaults = `-- name: GetStaffShiftDefaults :many
+SELECT
+	id,
+	time_in_schedule,
+	time_out_schedule
+FROM tbl_staffs
+WHERE deleted_at = '1970-01-01 00:00:00+00:00'
+`
+
+type GetStaffShiftDefaultsRow struct {
+	ID              int64
+	TimeInSchedule  sql.NullString
+	TimeOutSchedule sql.NullString
+}
+
+func (q *Queries) GetStaffShiftDefaults(ctx context.Context) ([]GetStaffShiftDefaultsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffShiftDefaults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStaffShiftDefaultsRow
+	for rows.Next() {
+		var i GetStaffShiftDefaultsRow
+		if err := rows.Scan(&i.ID, &i.TimeInSchedule, &i.TimeOutSchedule); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffShiftOverridesByDateRange = `-- name: GetStaffShiftOverridesByDateRange :many
+SELECT id, staff_id, for_date, time_in, time_out, rest_day, note, shift_swap_id, created_by, created_at, updated_at
+FROM tbl_staff_shift_overrides
+WHERE
+	staff_id = ?1
+	AND for_date >= ?2
+	AND for_date <= ?3
+ORDER BY for_date ASC
+`
+
+type GetStaffShiftOverridesByDateRangeParams struct {
+	StaffID   int64
+	StartDate string
+	EndDate   string
+}
+
+func (q *Queries) GetStaffShiftOverridesByDateRange(ctx context.Context, arg GetStaffShiftOverridesByDateRangeParams) ([]TblStaffShiftOverride, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffShiftOverridesByDateRange, arg.StaffID, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TblStaffShiftOverride
+	for rows.Next() {
+		var i TblStaffShiftOverride
+		if err := rows.Scan(
+			&i.ID,
+			&i.StaffID,
+			&i.ForDate,
+			&i.TimeIn,
+			&i.TimeOut,
+			&i.RestDay,
+			&i.Note,
+			&i.ShiftSwapID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffShiftRotation = `-- name: GetStaffShiftRotation :one
+SELECT id, staff_id, cycle_weeks, anchor_date, updated_by, created_at, updated_at
+FROM tbl_staff_shift_rotations
+WHERE staff_id = ?
+LIMIT 1
+`
+
+func (q *Queries) GetStaffShiftRotation(ctx context.Context, staffID int64) (TblStaffShiftRotation, error) {
+	row := q.db.QueryRowContext(ctx, getStaffShiftRotation, staffID)
+	var i TblStaffShiftRotation
+	err := row.Scan(
+		&i.ID,
+		&i.StaffID,
+		&i.CycleWeeks,
+		&i.AnchorDate,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getStaffShiftRotations = `-- name: GetStaffShiftRotations :many
+SELECT id, staff_id, cycle_weeks, anchor_date, updated_by, created_at, updated_at
+FROM tbl_staff_shift_rotations
+`
+
+func (q *Queries) GetStaffShiftRotations(ctx context.Context) ([]TblStaffShiftRotation, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffShiftRotations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TblStaffShiftRotation
+	for rows.Next() {
+		var i TblStaffShiftRotation
+		if err := rows.Scan(
+			&i.ID,
+			&i.StaffID,
+			&i.CycleWeeks,
+			&i.AnchorDate,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffShiftSwapByID = `-- name: GetStaffShiftSwapByID :one
+SELECT id, requester_id, counterpart_id, for_date, reason, status, decided_by, decided_at, created_at, updated_at
+FROM tbl_staff_shift_swaps
+WHERE id = ?
+LIMIT 1
+`
+
+func (q *Queries) GetStaffShiftSwapByID(ctx context.Context, id int64) (TblStaffShiftSwap, error) {
+	row := q.db.QueryRowContext(ctx, getStaffShiftSwapByID, id)
+	var i TblStaffShiftSwap
+	err := row.Scan(
+		&i.ID,
+		&i.RequesterID,
+		&i.CounterpartID,
+		&i.ForDate,
+		&i.Reason,
+		&i.Status,
+		&i.DecidedBy,
+		&i.DecidedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getStaffShiftSwaps = `-- name: GetStaffShiftSwaps :many
+SELECT
+	tbl_staff_shift_swaps.id, tbl_staff_shift_swaps.requester_id, tbl_staff_shift_swaps.counterpart_id, tbl_staff_shift_swaps.for_date, tbl_staff_shift_swaps.reason, tbl_staff_shift_swaps.status, tbl_staff_shift_swaps.decided_by, tbl_staff_shift_swaps.decided_at, tbl_staff_shift_swaps.created_at, tbl_staff_shift_swaps.updated_at,
+	requester.first_name AS requester_first_name,
+	requester.middle_name AS requester_middle_name,
+	requester.last_name AS requester_last_name,
+	counterpart.first_name AS counterpart_first_name,
+	counterpart.middle_name AS counterpart_middle_name,
+	counterpart.last_name AS counterpart_last_name
+FROM tbl_staff_shift_swaps
+INNER JOIN tbl_staffs requester ON requester.id = tbl_staff_shift_swaps.requester_id
+INNER JOIN tbl_staffs counterpart ON counterpart.id = tbl_staff_shift_swaps.counterpart_id
+WHERE
+	tbl_staff_shift_swaps.requester_id = ?1
+	OR tbl_staff_shift_swaps.counterpart_id = ?1
+	OR ?1 = 0
+ORDER BY tbl_staff_shift_swaps.created_at DESC, tbl_staff_shift_swaps.id DESC
+LIMIT ?2
+`
+
+type GetStaffShiftSwapsParams struct {
+	StaffID int64
+	Limit   int64
+}
+
+type GetStaffShiftSwapsRow struct {
+	ID                    int64
+	RequesterID           int64
+	CounterpartID         int64
+	ForDate               string
+	Reason                string
+	Status                string
+	DecidedBy             sql.NullInt64
+	DecidedAt             sql.NullTime
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	RequesterFirstName    string
+	RequesterMiddleName   sql.NullString
+	RequesterLastName     string
+	CounterpartFirstName  string
+	CounterpartMiddleName sql.NullString
+	CounterpartLastName   string
+}
+
+func (q *Queries) GetStaffShiftSwaps(ctx context.Context, arg GetStaffShiftSwapsParams) ([]GetStaffShiftSwapsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffShiftSwaps, arg.StaffID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStaffShiftSwapsRow
+	for rows.Next() {
+		var i GetStaffShiftSwapsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RequesterID,
+			&i.CounterpartID,
+			&i.ForDate,
+			&i.Reason,
+			&i.Status,
+			&i.DecidedBy,
+			&i.DecidedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RequesterFirstName,
+			&i.RequesterMiddleName,
+			&i.RequesterLastName,
+			&i.CounterpartFirstName,
+			&i.CounterpartMiddleName,
+			&i.CounterpartLastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertStaffShiftDay = `-- name: UpsertStaffShiftDay :exec
+INSERT INTO tbl_staff_shift_days (
+	staff_id,
+	week_index,
+	weekday,
+	time_in,
+	time_out,
+	rest_day,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, ?,
+	DATETIME('now'),
+	DATETIME('now')
+)
+ON CONFLICT (staff_id, week_index, weekday) DO UPDATE SET
+	time_in = excluded.time_in,
+	time_out = excluded.time_out,
+	rest_day = excluded.rest_day,
+	updated_at = DATETIME('now')
+`
+
+type UpsertStaffShiftDayParams struct {
+	StaffID   int64
+	WeekIndex int64
+	Weekday   int64
+	TimeIn    string
+	TimeOut   string
+	RestDay   bool
+}
+
+func (q *Queries) UpsertStaffShiftDay(ctx context.Context, arg UpsertStaffShiftDayParams) error {
+	_, err := q.db.ExecContext(ctx, upsertStaffShiftDay,
+		arg.StaffID,
+		arg.WeekIndex,
+		arg.Weekday,
+		arg.TimeIn,
+		arg.TimeOut,
+		arg.RestDay,
+	)
+	return err
+}
+
+const upsertStaffShiftOverride = `-- name: UpsertStaffShiftOverride :exec
+INSERT INTO tbl_staff_shift_overrides (
+	staff_id,
+	for_date,
+	time_in,
+	time_out,
+	rest_day,
+	note,
+	shift_swap_id,
+	created_by,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?, ?,
+	DATETIME('now'),
+	DATETIME('now')
+)
+ON CONFLICT (staff_id, for_date) DO UPDATE SET
+	time_in = excluded.time_in,
+	time_out = excluded.time_out,
+	rest_day = excluded.rest_day,
+	note = excluded.note,
+	shift_swap_id = excluded.shift_swap_id,
+	created_by = excluded.created_by,
+	updated_at = DATETIME('now')
+`
+
+type UpsertStaffShiftOverrideParams struct {
+	StaffID     int64
+	ForDate     string
+	TimeIn      string
+	TimeOut     string
+	RestDay     bool
+	Note        string
+	ShiftSwapID sql.NullInt64
+	CreatedBy   sql.NullInt64
+}
+
+func (q *Queries) UpsertStaffShiftOverride(ctx context.Context, arg UpsertStaffShiftOverrideParams) error {
+	_, err := q.db.ExecContext(ctx, upsertStaffShiftOverride,
+		arg.StaffID,
+		arg.ForDate,
+		arg.TimeIn,
+		arg.TimeOut,
+		arg.RestDay,
+		arg.Note,
+		arg.ShiftSwapID,
+		arg.CreatedBy,
+	)
+	return err
+}
+
+const upsertStaffShiftRotation = `-- name: UpsertStaffShiftRotation :exec
+INSERT INTO tbl_staff_shift_rotations (
+	staff_id,
+	cycle_weeks,
+	anchor_date,
+	updated_by,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?,
+	DATETIME('now'),
+	DATETIME('now')
+)
+ON CONFLICT (staff_id) DO UPDATE SET
+	cycle_weeks = excluded.cycle_weeks,
+	anchor_date = excluded.anchor_date,
+	updated_by = excluded.updated_by,
+	updated_at = DATETIME('now')
+`
+
+type UpsertStaffShiftRotationParams struct {
+	StaffID    int64
+	CycleWeeks int64
+	AnchorDate string
+	UpdatedBy  sql.NullInt64
+}
+
+func (q *Queries) UpsertStaffShiftRotation(ctx context.Context, arg UpsertStaffShiftRotationParams) error {
+	_, err := q.db.ExecContext(ctx, upsertStaffShiftRotation,
+		arg.StaffID,
+		arg.CycleWeeks,
+		arg.AnchorDate,
+		arg.UpdatedBy,
+	)
+	return err
+}
@@ -52,6 +52,43 @@ func (q *Queries) CancelStaffTimeOff(ctx context.Context, id int64) (int64, erro
 	return id_2, err
 }
 
+const correctStaffAttendance = `-- name: CorrectStaffAttendance :execrows
+UPDATE tbl_staff_attendances
+SET
+    time_in = COALESCE(?1, time_in),
+    time_out = COALESCE(?2, time_out),
+    lunch_break_in = COALESCE(?3, lunch_break_in),
+    lunch_break_out = COALESCE(?4, lunch_break_out),
+    updated_at = datetime('now')
+WHERE
+    staff_id = ?5
+    AND for_date = ?6
+`
+
+type CorrectStaffAttendanceParams struct {
+	TimeIn        sql.NullString
+	TimeOut       sql.NullString
+	LunchBreakIn  sql.NullString
+	LunchBreakOut sql.NullString
+	StaffID       int64
+	ForDate       string
+}
+
+func (q *Queries) CorrectStaffAttendance(ctx context.Context, arg CorrectStaffAttendanceParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, correctStaffAttendance,
+		arg.TimeIn,
+		arg.TimeOut,
+		arg.LunchBreakIn,
+		arg.LunchBreakOut,
+		arg.StaffID,
+		arg.ForDate,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createStaff = `-- name: CreateStaff :one
 INSERT INTO tbl_staffs (
     first_name,
@@ -203,6 +240,44 @@ func (q *Queries) CreateStaffTimeOff(ctx context.Context, arg CreateStaffTimeOff
 	return id, err
 }
 
+const getActiveSuperuserEmails = `-- name: GetActiveSuperuserEmails :many
+SELECT id, email
+FROM tbl_staffs
+WHERE user_type = 'SUPERUSER'
+AND email != ''
+AND deleted_at = '1970-01-01 00:00:00+00:00'
+AND status != 'RESIGNED'
+ORDER BY id ASC
+`
+
+type GetActiveSuperuserEmailsRow struct {
+	ID    int64
+	Email string
+}
+
+func (q *Queries) GetActiveSuperuserEmails(ctx context.Context) ([]GetActiveSuperuserEmailsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getActiveSuperuserEmails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActiveSuperuserEmailsRow
+	for rows.Next() {
+		var i GetActiveSuperuserEmailsRow
+		if err := rows.Scan(&i.ID, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllStaffTimeOffs = `-- name: GetAllStaffTimeOffs :many
 SELECT
     sto.id,
@@ -1248,6 +1323,42 @@ func (q *Queries) GetStaffByID(ctx context.Context, id int64) (GetStaffByIDRow,
 	return i, err
 }
 
+const getStaffTimeOffByID = `-- name: GetStaffTimeOffByID :one
+SELECT
+    id,
+    type,
+    start_date,
+    end_date,
+    staff_id,
+    approved
+FROM tbl_staff_time_offs
+WHERE id = ?
+LIMIT 1
+`
+
+type GetStaffTimeOffByIDRow struct {
+	ID        int64
+	Type      string
+	StartDate time.Time
+	EndDate   time.Time
+	StaffID   int64
+	Approved  sql.NullBool
+}
+
+func (q *Queries) GetStaffTimeOffByID(ctx context.Context, id int64) (GetStaffTimeOffByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getStaffTimeOffByID, id)
+	var i GetStaffTimeOffByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.StartDate,
+		&i.EndDate,
+		&i.StaffID,
+		&i.Approved,
+	)
+	return i, err
+}
+
 const getStaffTimeOffsByStaffID = `-- name: GetStaffTimeOffsByStaffID :many
 SELECT
     sto.id,
@@ -10,16 +10,17 @@ import (
 )
 
 const createStaffRole = `-- name: CreateStaffRole :one
-INSERT INTO tbl_staff_roles (staff_id, role, created_at, updated_at) VALUES (?, ?, datetime('now'), datetime('now')) RETURNING id
+INSERT INTO tbl_staff_roles (staff_id, role, access, created_at, updated_at) VALUES (?, ?, ?, datetime('now'), datetime('now')) RETURNING id
 `
 
 type CreateStaffRoleParams struct {
 	StaffID int64
 	Role    string
+	Access  string
 }
 
 func (q *Queries) CreateStaffRole(ctx context.Context, arg CreateStaffRoleParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, createStaffRole, arg.StaffID, arg.Role)
+	row := q.db.QueryRowContext(ctx, createStaffRole, arg.StaffID, arg.Role, arg.Access)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
@@ -41,6 +42,112 @@ func (q *Queries) DeleteStaffRole(ctx context.Context, arg DeleteStaffRoleParams
 	return id, err
 }
 
+const getAllStaffPermissions = `-- name: GetAllStaffPermissions :many
+SELECT staff_id, role, access FROM tbl_staff_roles
+UNION ALL
+SELECT tbl_staff_role_groups.staff_id, tbl_role_group_permissions.role, tbl_role_group_permissions.access
+FROM tbl_role_group_permissions
+JOIN tbl_staff_role_groups ON tbl_staff_role_groups.role_group_id = tbl_role_group_permissions.role_group_id
+`
+
+type GetAllStaffPermissionsRow struct {
+	StaffID int64
+	Role    string
+	Access  string
+}
+
+func (q *Queries) GetAllStaffPermissions(ctx context.Context) ([]GetAllStaffPermissionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllStaffPermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAllStaffPermissionsRow
+	for rows.Next() {
+		var i GetAllStaffPermissionsRow
+		if err := rows.Scan(&i.StaffID, &i.Role, &i.Access); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffDirectPermissionsByStaffID = `-- name: GetStaffDirectPermissionsByStaffID :many
+SELECT role, access FROM tbl_staff_roles WHERE staff_id = ?
+`
+
+type GetStaffDirectPermissionsByStaffIDRow struct {
+	Role   string
+	Access string
+}
+
+func (q *Queries) GetStaffDirectPermissionsByStaffID(ctx context.Context, staffID int64) ([]GetStaffDirectPermissionsByStaffIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffDirectPermissionsByStaffID, staffID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStaffDirectPermissionsByStaffIDRow
+	for rows.Next() {
+		var i GetStaffDirectPermissionsByStaffIDRow
+		if err := rows.Scan(&i.Role, &i.Access); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStaffPermissionsByStaffID = `-- name: GetStaffPermissionsByStaffID :many
+SELECT role, access FROM tbl_staff_roles WHERE tbl_staff_roles.staff_id = ?1
+UNION ALL
+SELECT tbl_role_group_permissions.role, tbl_role_group_permissions.access
+FROM tbl_role_group_permissions
+JOIN tbl_staff_role_groups ON tbl_staff_role_groups.role_group_id = tbl_role_group_permissions.role_group_id
+WHERE tbl_staff_role_groups.staff_id = ?1
+`
+
+type GetStaffPermissionsByStaffIDRow struct {
+	Role   string
+	Access string
+}
+
+func (q *Queries) GetStaffPermissionsByStaffID(ctx context.Context, staffID int64) ([]GetStaffPermissionsByStaffIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStaffPermissionsByStaffID, staffID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStaffPermissionsByStaffIDRow
+	for rows.Next() {
+		var i GetStaffPermissionsByStaffIDRow
+		if err := rows.Scan(&i.Role, &i.Access); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getStaffRolesByStaffID = `-- name: GetStaffRolesByStaffID :many
 SELECT role FROM tbl_staff_roles WHERE staff_id = ?
 `
@@ -67,3 +174,21 @@ func (q *Queries) GetStaffRolesByStaffID(ctx context.Context, staffID int64) ([]
 	}
 	return items, nil
 }
+
+const updateStaffRoleAccess = `-- name: UpdateStaffRoleAccess :execrows
+UPDATE tbl_staff_roles SET access = ?, updated_at = datetime('now') WHERE staff_id = ? AND role = ?
+`
+
+type UpdateStaffRoleAccessParams struct {
+	Access  string
+	StaffID int64
+	Role    string
+}
+
+func (q *Queries) UpdateStaffRoleAccess(ctx context.Context, arg UpdateStaffRoleAccessParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateStaffRoleAccess, arg.Access, arg.StaffID, arg.Role)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
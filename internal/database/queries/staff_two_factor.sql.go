@@ -0,0 +1,327 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: staff_two_factor.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+)
+
+const countRecentStaffOTPCodes = `-- name: CountRecentStaffOTPCodes :one
+SELECT COUNT(*) FROM tbl_staff_otp_codes
+WHERE staff_id = ? AND created_at > DATETIME('now', '-1 minutes')
+`
+
+func (q *Queries) CountRecentStaffOTPCodes(ctx context.Context, staffID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countRecentStaffOTPCodes, staffID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countStaffTrustedDevices = `-- name: CountStaffTrustedDevices :one
+SELECT COUNT(*) FROM tbl_staff_trusted_devices WHERE staff_id = ? AND expires_at > DATETIME('now')
+`
+
+func (q *Queries) CountStaffTrustedDevices(ctx context.Context, staffID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countStaffTrustedDevices, staffID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countStaffTwoFactorLocks = `-- name: CountStaffTwoFactorLocks :one
+SELECT COUNT(*) FROM tbl_staff_two_factor_failures
+WHERE staff_id = ? AND locked_until > DATETIME('now')
+`
+
+func (q *Queries) CountStaffTwoFactorLocks(ctx context.Context, staffID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countStaffTwoFactorLocks, staffID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUnusedStaffRecoveryCodes = `-- name: CountUnusedStaffRecoveryCodes :one
+SELECT COUNT(*) FROM tbl_staff_recovery_codes WHERE staff_id = ? AND used_at IS NULL
+`
+
+func (q *Queries) CountUnusedStaffRecoveryCodes(ctx context.Context, staffID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUnusedStaffRecoveryCodes, staffID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createStaffOTPCode = `-- name: CreateStaffOTPCode :exec
+INSERT INTO tbl_staff_otp_codes (staff_id, otp_code, expires_at, created_at)
+VALUES (?, ?, DATETIME('now', '+5 minutes'), DATETIME('now'))
+`
+
+type CreateStaffOTPCodeParams struct {
+	StaffID int64
+	OtpCode string
+}
+
+func (q *Queries) CreateStaffOTPCode(ctx context.Context, arg CreateStaffOTPCodeParams) error {
+	_, err := q.db.ExecContext(ctx, createStaffOTPCode, arg.StaffID, arg.OtpCode)
+	return err
+}
+
+const createStaffRecoveryCode = `-- name: CreateStaffRecoveryCode :exec
+INSERT INTO tbl_staff_recovery_codes (staff_id, code_hash, created_at)
+VALUES (?, ?, DATETIME('now'))
+`
+
+type CreateStaffRecoveryCodeParams struct {
+	StaffID  int64
+	CodeHash string
+}
+
+func (q *Queries) CreateStaffRecoveryCode(ctx context.Context, arg CreateStaffRecoveryCodeParams) error {
+	_, err := q.db.ExecContext(ctx, createStaffRecoveryCode, arg.StaffID, arg.CodeHash)
+	return err
+}
+
+const createStaffTrustedDevice = `-- name: CreateStaffTrustedDevice :exec
+INSERT INTO tbl_staff_trusted_devices (staff_id, token_hash, useragent_id, expires_at, created_at)
+VALUES (?, ?, ?, DATETIME('now', '+30 days'), DATETIME('now'))
+`
+
+type CreateStaffTrustedDeviceParams struct {
+	StaffID     int64
+	TokenHash   string
+	UseragentID sql.NullInt64
+}
+
+func (q *Queries) CreateStaffTrustedDevice(ctx context.Context, arg CreateStaffTrustedDeviceParams) error {
+	_, err := q.db.ExecContext(ctx, createStaffTrustedDevice, arg.StaffID, arg.TokenHash, arg.UseragentID)
+	return err
+}
+
+const deleteStaffOTPCodes = `-- name: DeleteStaffOTPCodes :exec
+DELETE FROM tbl_staff_otp_codes WHERE staff_id = ?
+`
+
+func (q *Queries) DeleteStaffOTPCodes(ctx context.Context, staffID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteStaffOTPCodes, staffID)
+	return err
+}
+
+const deleteStaffRecoveryCodes = `-- name: DeleteStaffRecoveryCodes :exec
+DELETE FROM tbl_staff_recovery_codes WHERE staff_id = ?
+`
+
+func (q *Queries) DeleteStaffRecoveryCodes(ctx context.Context, staffID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteStaffRecoveryCodes, staffID)
+	return err
+}
+
+const deleteStaffTrustedDevices = `-- name: DeleteStaffTrustedDevices :exec
+DELETE FROM tbl_staff_trusted_devices WHERE staff_id = ?
+`
+
+func (q *Queries) DeleteStaffTrustedDevices(ctx context.Context, staffID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteStaffTrustedDevices, staffID)
+	return err
+}
+
+const deleteStaffTwoFactor = `-- name: DeleteStaffTwoFactor :exec
+DELETE FROM tbl_staff_two_factor WHERE staff_id = ?
+`
+
+func (q *Queries) DeleteStaffTwoFactor(ctx context.Context, staffID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteStaffTwoFactor, staffID)
+	return err
+}
+
+const deleteStaffTwoFactorFailures = `-- name: DeleteStaffTwoFactorFailures :exec
+DELETE FROM tbl_staff_two_factor_failures WHERE staff_id = ?
+`
+
+func (q *Queries) DeleteStaffTwoFactorFailures(ctx context.Context, staffID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteStaffTwoFactorFailures, staffID)
+	return err
+}
+
+const enableStaffTwoFactor = `-- name: EnableStaffTwoFactor :execrows
+UPDATE tbl_staff_two_factor
+SET enabled_at = DATETIME('now'), last_used_step = ?1, updated_at = DATETIME('now')
+WHERE staff_id = ?2 AND enabled_at IS NULL
+`
+
+type EnableStaffTwoFactorParams struct {
+	Step    int64
+	StaffID int64
+}
+
+func (q *Queries) EnableStaffTwoFactor(ctx context.Context, arg EnableStaffTwoFactorParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, enableStaffTwoFactor, arg.Step, arg.StaffID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getStaffTrustedDevice = `-- name: GetStaffTrustedDevice :one
+SELECT id, staff_id, token_hash, useragent_id, expires_at, last_used_at, created_at FROM tbl_staff_trusted_devices
+WHERE token_hash = ? AND staff_id = ? AND expires_at > DATETIME('now')
+`
+
+type GetStaffTrustedDeviceParams struct {
+	TokenHash string
+	StaffID   int64
+}
+
+func (q *Queries) GetStaffTrustedDevice(ctx context.Context, arg GetStaffTrustedDeviceParams) (TblStaffTrustedDevice, error) {
+	row := q.db.QueryRowContext(ctx, getStaffTrustedDevice, arg.TokenHash, arg.StaffID)
+	var i TblStaffTrustedDevice
+	err := row.Scan(
+		&i.ID,
+		&i.StaffID,
+		&i.TokenHash,
+		&i.UseragentID,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getStaffTwoFactor = `-- name: GetStaffTwoFactor :one
+SELECT staff_id, secret, enabled_at, last_used_step, created_at, updated_at FROM tbl_staff_two_factor WHERE staff_id = ?
+`
+
+func (q *Queries) GetStaffTwoFactor(ctx context.Context, staffID int64) (TblStaffTwoFactor, error) {
+	row := q.db.QueryRowContext(ctx, getStaffTwoFactor, staffID)
+	var i TblStaffTwoFactor
+	err := row.Scan(
+		&i.StaffID,
+		&i.Secret,
+		&i.EnabledAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const lockStaffTwoFactor = `-- name: LockStaffTwoFactor :exec
+UPDATE tbl_staff_two_factor_failures
+SET attempts = 0, locked_until = DATETIME('now', '+15 minutes'), updated_at = DATETIME('now')
+WHERE staff_id = ?
+`
+
+func (q *Queries) LockStaffTwoFactor(ctx context.Context, staffID int64) error {
+	_, err := q.db.ExecContext(ctx, lockStaffTwoFactor, staffID)
+	return err
+}
+
+const recordStaffTwoFactorFailure = `-- name: RecordStaffTwoFactorFailure :one
+INSERT INTO tbl_staff_two_factor_failures (staff_id, attempts, updated_at)
+VALUES (?, 1, DATETIME('now'))
+ON CONFLICT (staff_id) DO UPDATE SET
+	attempts = CASE
+		WHEN tbl_staff_two_factor_failures.updated_at > DATETIME('now', '-15 minutes')
+			THEN tbl_staff_two_factor_failures.attempts + 1
+		ELSE 1
+	END,
+	updated_at = DATETIME('now')
+RETURNING attempts
+`
+
+func (q *Queries) RecordStaffTwoFactorFailure(ctx context.Context, staffID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, recordStaffTwoFactorFailure, staffID)
+	var attempts int64
+	err := row.Scan(&attempts)
+	return attempts, err
+}
+
+const touchStaffTrustedDevice = `-- name: TouchStaffTrustedDevice :exec
+UPDATE tbl_staff_trusted_devices SET last_used_at = DATETIME('now') WHERE id = ?
+`
+
+func (q *Queries) TouchStaffTrustedDevice(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, touchStaffTrustedDevice, id)
+	return err
+}
+
+const upsertPendingStaffTwoFactor = `-- name: UpsertPendingStaffTwoFactor :exec
+INSERT INTO tbl_staff_two_factor (staff_id, secret, created_at, updated_at)
+VALUES (?, ?, DATETIME('now'), DATETIME('now'))
+ON CONFLICT (staff_id) DO UPDATE SET
+	secret = excluded.secret,
+	last_used_step = 0,
+	updated_at = DATETIME('now')
+WHERE tbl_staff_two_factor.enabled_at IS NULL
+`
+
+type UpsertPendingStaffTwoFactorParams struct {
+	StaffID int64
+	Secret  string
+}
+
+func (q *Queries) UpsertPendingStaffTwoFactor(ctx context.Context, arg UpsertPendingStaffTwoFactorParams) error {
+	_, err := q.db.ExecContext(ctx, upsertPendingStaffTwoFactor, arg.StaffID, arg.Secret)
+	return err
+}
+
+const useStaffOTPCode = `-- name: UseStaffOTPCode :execrows
+UPDATE tbl_staff_otp_codes
+SET used_at = DATETIME('now')
+WHERE staff_id = ? AND otp_code = ? AND used_at IS NULL AND expires_at > DATETIME('now')
+`
+
+type UseStaffOTPCodeParams struct {
+	StaffID int64
+	OtpCode string
+}
+
+func (q *Queries) UseStaffOTPCode(ctx context.Context, arg UseStaffOTPCodeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, useStaffOTPCode, arg.StaffID, arg.OtpCode)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const useStaffRecoveryCode = `-- name: UseStaffRecoveryCode :execrows
+UPDATE tbl_staff_recovery_codes
+SET used_at = DATETIME('now')
+WHERE staff_id = ? AND code_hash = ? AND used_at IS NULL
+`
+
+type UseStaffRecoveryCodeParams struct {
+	StaffID  int64
+	CodeHash string
+}
+
+func (q *Queries) UseStaffRecoveryCode(ctx context.Context, arg UseStaffRecoveryCodeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, useStaffRecoveryCode, arg.StaffID, arg.CodeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const useStaffTwoFactorStep = `-- name: UseStaffTwoFactorStep :execrows
+UPDATE tbl_staff_two_factor
+SET last_used_step = ?1, updated_at = DATETIME('now')
+WHERE staff_id = ?2 AND last_used_step < ?1
+`
+
+type UseStaffTwoFactorStepParams struct {
+	Step    int64
+	StaffID int64
+}
+
+func (q *Queries) UseStaffTwoFactorStep(ctx context.Context, arg UseStaffTwoFactorStepParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, useStaffTwoFactorStep, arg.Step, arg.StaffID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,334 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.0
+// source: wishlist.sql
+
+package queries
+
+import (
+	"context"
+	"time"
+)
+
+const addWishlistItem = `-- name: AddWishlistItem :exec
+INSERT INTO tbl_wishlists (
+	customer_id,
+	product_id,
+	created_at
+) VALUES (
+	?, ?, DATETIME('now')
+) ON CONFLICT (customer_id, product_id) DO NOTHING
+`
+
+type AddWishlistItemParams struct {
+	CustomerID int64
+	ProductID  int64
+}
+
+func (q *Queries) AddWishlistItem(ctx context.Context, arg AddWishlistItemParams) error {
+	_, err := q.db.ExecContext(ctx, addWishlistItem, arg.CustomerID, arg.ProductID)
+	return err
+}
+
+const confirmStockSubscription = `-- name: ConfirmStockSubscription :one
+UPDATE tbl_stock_subscriptions
+SET
+	status = 'CONFIRMED',
+	confirmed_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE
+	token_hash = ?
+	AND status = 'PENDING'
+	AND updated_at > DATETIME('now', '-1 day')
+RETURNING product_id
+`
+
+func (q *Queries) ConfirmStockSubscription(ctx context.Context, tokenHash string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, confirmStockSubscription, tokenHash)
+	var product_id int64
+	err := row.Scan(&product_id)
+	return product_id, err
+}
+
+const getConfirmedStockSubscriptionsByProductID = `-- name: GetConfirmedStockSubscriptionsByProductID :many
+SELECT id, email
+FROM tbl_stock_subscriptions
+WHERE product_id = ? AND status = 'CONFIRMED'
+`
+
+type GetConfirmedStockSubscriptionsByProductIDRow struct {
+	ID    int64
+	Email string
+}
+
+func (q *Queries) GetConfirmedStockSubscriptionsByProductID(ctx context.Context, productID int64) ([]GetConfirmedStockSubscriptionsByProductIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getConfirmedStockSubscriptionsByProductID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetConfirmedStockSubscriptionsByProductIDRow
+	for rows.Next() {
+		var i GetConfirmedStockSubscriptionsByProductIDRow
+		if err := rows.Scan(&i.ID, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductForNotificationEmail = `-- name: GetProductForNotificationEmail :one
+SELECT
+	tbl_products.id,
+	tbl_products.name,
+	tbl_products.serial,
+	COALESCE(tbl_products.slug, '') AS slug,
+	tbl_products.unit_price_with_vat,
+	tbl_products.unit_price_with_vat_currency,
+	COALESCE(tbl_product_images.cdn_url_thumbnail, '') AS cdn_url_thumbnail,
+	COALESCE(tbl_product_sales.sale_price_with_vat, 0) AS sale_price_with_vat,
+	CAST(COALESCE(DATE(tbl_product_sales.ends_at), '') AS TEXT) AS sale_ends_at
+FROM tbl_products
+LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
+LEFT JOIN tbl_product_sales
+	ON tbl_product_sales.product_id = tbl_products.id
+	AND tbl_product_sales.is_active = 1
+WHERE tbl_products.id = ?
+LIMIT 1
+`
+
+type GetProductForNotificationEmailRow struct {
+	ID                       int64
+	Name                     string
+	Serial                   string
+	Slug                     string
+	UnitPriceWithVat         int64
+	UnitPriceWithVatCurrency string
+	CdnUrlThumbnail          string
+	SalePriceWithVat         int64
+	SaleEndsAt               string
+}
+
+func (q *Queries) GetProductForNotificationEmail(ctx context.Context, id int64) (GetProductForNotificationEmailRow, error) {
+	row := q.db.QueryRowContext(ctx, getProductForNotificationEmail, id)
+	var i GetProductForNotificationEmailRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Serial,
+		&i.Slug,
+		&i.UnitPriceWithVat,
+		&i.UnitPriceWithVatCurrency,
+		&i.CdnUrlThumbnail,
+		&i.SalePriceWithVat,
+		&i.SaleEndsAt,
+	)
+	return i, err
+}
+
+const getStockSubscriptionByProductAndEmail = `-- name: GetStockSubscriptionByProductAndEmail :one
+SELECT id, product_id, email, status, token_hash, confirmed_at, notified_at, created_at, updated_at
+FROM tbl_stock_subscriptions
+WHERE product_id = ? AND email = ?
+LIMIT 1
+`
+
+type GetStockSubscriptionByProductAndEmailParams struct {
+	ProductID int64
+	Email     string
+}
+
+func (q *Queries) GetStockSubscriptionByProductAndEmail(ctx context.Context, arg GetStockSubscriptionByProductAndEmailParams) (TblStockSubscription, error) {
+	row := q.db.QueryRowContext(ctx, getStockSubscriptionByProductAndEmail, arg.ProductID, arg.Email)
+	var i TblStockSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.Email,
+		&i.Status,
+		&i.TokenHash,
+		&i.ConfirmedAt,
+		&i.NotifiedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getWishlistByCustomerID = `-- name: GetWishlistByCustomerID :many
+SELECT
+	tbl_wishlists.product_id,
+	tbl_wishlists.created_at,
+	tbl_products.name,
+	tbl_products.serial,
+	COALESCE(tbl_products.slug, '') AS slug,
+	tbl_products.unit_price_with_vat,
+	tbl_products.unit_price_with_vat_currency,
+	COALESCE(tbl_product_images.cdn_url_thumbnail, '') AS cdn_url_thumbnail,
+	COALESCE(tbl_product_inventories.stocks, 0) AS stocks
+FROM tbl_wishlists
+INNER JOIN tbl_products ON tbl_products.id = tbl_wishlists.product_id
+LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
+LEFT JOIN tbl_product_inventories ON tbl_product_inventories.product_id = tbl_products.id
+WHERE tbl_wishlists.customer_id = ?
+ORDER BY tbl_wishlists.created_at DESC
+`
+
+type GetWishlistByCustomerIDRow struct {
+	ProductID                int64
+	CreatedAt                time.Time
+	Name                     string
+	Serial                   string
+	Slug                     string
+	UnitPriceWithVat         int64
+	UnitPriceWithVatCurrency string
+	CdnUrlThumbnail          string
+	Stocks                   int64
+}
+
+func (q *Queries) GetWishlistByCustomerID(ctx context.Context, customerID int64) ([]GetWishlistByCustomerIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWishlistByCustomerID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWishlistByCustomerIDRow
+	for rows.Next() {
+		var i GetWishlistByCustomerIDRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.CreatedAt,
+			&i.Name,
+			&i.Serial,
+			&i.Slug,
+			&i.UnitPriceWithVat,
+			&i.UnitPriceWithVatCurrency,
+			&i.CdnUrlThumbnail,
+			&i.Stocks,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWishlistCustomerEmailsByProductID = `-- name: GetWishlistCustomerEmailsByProductID :many
+SELECT tbl_customers.email
+FROM tbl_wishlists
+INNER JOIN tbl_customers ON tbl_customers.id = tbl_wishlists.customer_id
+WHERE tbl_wishlists.product_id = ?
+`
+
+func (q *Queries) GetWishlistCustomerEmailsByProductID(ctx context.Context, productID int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getWishlistCustomerEmailsByProductID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		items = append(items, email)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isProductWishlisted = `-- name: IsProductWishlisted :one
+SELECT EXISTS(
+	SELECT 1 FROM tbl_wishlists
+	WHERE customer_id = ? AND product_id = ?
+) AS is_wishlisted
+`
+
+type IsProductWishlistedParams struct {
+	CustomerID int64
+	ProductID  int64
+}
+
+func (q *Queries) IsProductWishlisted(ctx context.Context, arg IsProductWishlistedParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isProductWishlisted, arg.CustomerID, arg.ProductID)
+	var is_wishlisted bool
+	err := row.Scan(&is_wishlisted)
+	return is_wishlisted, err
+}
+
+const markStockSubscriptionNotified = `-- name: MarkStockSubscriptionNotified :exec
+UPDATE tbl_stock_subscriptions
+SET
+	status = 'NOTIFIED',
+	notified_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE id = ?
+`
+
+func (q *Queries) MarkStockSubscriptionNotified(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, markStockSubscriptionNotified, id)
+	return err
+}
+
+const removeWishlistItem = `-- name: RemoveWishlistItem :exec
+DELETE FROM tbl_wishlists
+WHERE customer_id = ? AND product_id = ?
+`
+
+type RemoveWishlistItemParams struct {
+	CustomerID int64
+	ProductID  int64
+}
+
+func (q *Queries) RemoveWishlistItem(ctx context.Context, arg RemoveWishlistItemParams) error {
+	_, err := q.db.ExecContext(ctx, removeWishlistItem, arg.CustomerID, arg.ProductID)
+	return err
+}
+
+const upsertStockSubscription = `-- name: UpsertStockSubscription :exec
+INSERT INTO tbl_stock_subscriptions (
+	product_id,
+	email,
+	status,
+	token_hash,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, 'PENDING', ?, DATETIME('now'), DATETIME('now')
+) ON CONFLICT (product_id, email) DO UPDATE SET
+	status = 'PENDING',
+	token_hash = excluded.token_hash,
+	confirmed_at = NULL,
+	notified_at = NULL,
+	updated_at = DATETIME('now')
+`
+
+type UpsertStockSubscriptionParams struct {
+	ProductID int64
+	Email     string
+	TokenHash string
+}
+
+func (q *Queries) UpsertStockSubscription(ctx context.Context, arg UpsertStockSubscriptionParams) error {
+	_, err := q.db.ExecContext(ctx, upsertStockSubscription, arg.ProductID, arg.Email, arg.TokenHash)
+	return err
+}
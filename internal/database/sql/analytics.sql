@@ -0,0 +1,146 @@
+-- Analytics count an order once it is paid and not cancelled or refunded,
+-- bucketed by the date it was paid. Timestamps are stored in UTC and shifted
+-- by '+8 hours' so the days match the PH dates picked in the admin.
+
+-- name: GetAnalyticsSalesTotals :one
+SELECT
+	COUNT(*) AS orders,
+	CAST(COALESCE(SUM(total_amount), 0) AS INTEGER) AS revenue,
+	COUNT(DISTINCT COALESCE(CAST(customer_id AS TEXT), LOWER(customer_email))) AS customers
+FROM tbl_orders
+WHERE paid_at IS NOT NULL
+AND status NOT IN ('CANCELLED', 'REFUNDED')
+AND DATE(paid_at, '+8 hours') BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT);
+
+-- name: GetAnalyticsRevenuePerDay :many
+SELECT
+	CAST(DATE(paid_at, '+8 hours') AS TEXT) AS day,
+	COUNT(*) AS orders,
+	CAST(COALESCE(SUM(total_amount), 0) AS INTEGER) AS revenue
+FROM tbl_orders
+WHERE paid_at IS NOT NULL
+AND status NOT IN ('CANCELLED', 'REFUNDED')
+AND DATE(paid_at, '+8 hours') BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT)
+GROUP BY DATE(paid_at, '+8 hours')
+ORDER BY day;
+
+-- name: GetAnalyticsRevenueByBrand :many
+SELECT
+	CAST(COALESCE(b.name, 'Unknown') AS TEXT) AS label,
+	CAST(COALESCE(SUM(ol.quantity), 0) AS INTEGER) AS units,
+	CAST(COALESCE(SUM(ol.total_price), 0) AS INTEGER) AS revenue
+FROM tbl_order_lines ol
+JOIN tbl_orders o ON o.id = ol.order_id
+LEFT JOIN tbl_products p ON p.id = ol.product_id
+LEFT JOIN tbl_brands b ON b.id = p.brand_id
+WHERE o.paid_at IS NOT NULL
+AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+AND DATE(o.paid_at, '+8 hours') BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT)
+GROUP BY label
+ORDER BY revenue DESC;
+
+-- A product can sit in several categories, so the category rows may add up
+-- to more than the total revenue.
+-- name: GetAnalyticsRevenueByCategory :many
+SELECT
+	CAST(COALESCE(pc.category, 'Uncategorized') AS TEXT) AS label,
+	CAST(COALESCE(SUM(ol.quantity), 0) AS INTEGER) AS units,
+	CAST(COALESCE(SUM(ol.total_price), 0) AS INTEGER) AS revenue
+FROM tbl_order_lines ol
+JOIN tbl_orders o ON o.id = ol.order_id
+LEFT JOIN tbl_products_categories ppc ON ppc.product_id = ol.product_id
+LEFT JOIN tbl_product_categories pc ON pc.id = ppc.category_id
+WHERE o.paid_at IS NOT NULL
+AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+AND DATE(o.paid_at, '+8 hours') BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT)
+GROUP BY label
+ORDER BY revenue DESC;
+
+-- name: GetAnalyticsTopProducts :many
+SELECT
+	ol.serial,
+	ol.name,
+	CAST(COALESCE(b.name, '') AS TEXT) AS brand,
+	CAST(COALESCE(SUM(ol.quantity), 0) AS INTEGER) AS units,
+	CAST(COALESCE(SUM(ol.total_price), 0) AS INTEGER) AS revenue
+FROM tbl_order_lines ol
+JOIN tbl_orders o ON o.id = ol.order_id
+LEFT JOIN tbl_products p ON p.id = ol.product_id
+LEFT JOIN tbl_brands b ON b.id = p.brand_id
+WHERE o.paid_at IS NOT NULL
+AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+AND DATE(o.paid_at, '+8 hours') BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT)
+AND (CAST(@brand_id AS INTEGER) = 0 OR p.brand_id = CAST(@brand_id AS INTEGER))
+GROUP BY ol.serial, ol.name, brand
+ORDER BY units DESC, revenue DESC
+LIMIT @limit;
+
+-- Customers who paid in the range, and how many of them have at least one
+-- other paid order on or before the end of the range.
+-- name: GetAnalyticsRepeatCustomers :one
+WITH paid AS (
+	SELECT
+		COALESCE(CAST(customer_id AS TEXT), LOWER(customer_email)) AS customer_key,
+		DATE(paid_at, '+8 hours') AS paid_day
+	FROM tbl_orders
+	WHERE paid_at IS NOT NULL
+	AND status NOT IN ('CANCELLED', 'REFUNDED')
+	AND DATE(paid_at, '+8 hours') <= CAST(@end_date AS TEXT)
+),
+per_customer AS (
+	SELECT
+		customer_key,
+		COUNT(*) AS orders,
+		MAX(CASE WHEN paid_day >= CAST(@start_date AS TEXT) THEN 1 ELSE 0 END) AS in_range
+	FROM paid
+	GROUP BY customer_key
+)
+SELECT
+	CAST(COALESCE(SUM(in_range), 0) AS INTEGER) AS customers,
+	CAST(COALESCE(SUM(CASE WHEN in_range = 1 AND orders > 1 THEN 1 ELSE 0 END), 0) AS INTEGER) AS repeat_customers
+FROM per_customer;
+
+-- name: GetAnalyticsPaymentMethods :many
+SELECT
+	CAST(COALESCE(NULLIF(cp.payment_method_type, ''), 'UNKNOWN') AS TEXT) AS label,
+	COUNT(*) AS orders,
+	CAST(COALESCE(SUM(o.total_amount), 0) AS INTEGER) AS revenue
+FROM tbl_orders o
+LEFT JOIN tbl_checkout_payments cp ON cp.id = o.checkout_payment_id
+WHERE o.paid_at IS NOT NULL
+AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+AND DATE(o.paid_at, '+8 hours') BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT)
+GROUP BY label
+ORDER BY orders DESC;
+
+-- Carts are checkouts that had a product added in the range, finalized carts
+-- are those that reached the payment gateway, and paid carts have a paid
+-- order.
+-- name: GetAnalyticsFunnel :one
+SELECT
+	(
+		SELECT COUNT(DISTINCT cl.checkout_id)
+		FROM tbl_checkout_lines cl
+		WHERE DATE(cl.created_at, '+8 hours') BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT)
+	) AS carts,
+	(
+		SELECT COUNT(DISTINCT cp.checkout_id)
+		FROM tbl_checkout_payments cp
+		WHERE DATE(cp.created_at, '+8 hours') BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT)
+	) AS finalized,
+	(
+		SELECT COUNT(DISTINCT o.checkout_id)
+		FROM tbl_orders o
+		WHERE o.paid_at IS NOT NULL
+		AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+		AND DATE(o.paid_at, '+8 hours') BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT)
+	) AS paid;
+
+-- name: GetAnalyticsLinkClicks :one
+SELECT
+	COUNT(*) AS clicks,
+	COUNT(DISTINCT ip_hash) AS unique_visitors
+FROM tbl_link_clicks
+WHERE is_bot = 0
+AND is_duplicate = 0
+AND DATE(clicked_at, '+8 hours') BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT);
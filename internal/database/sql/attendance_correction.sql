@@ -0,0 +1,76 @@
+-- name: CreateStaffAttendanceCorrection :one
+INSERT INTO tbl_staff_attendance_corrections (
+	staff_id,
+	for_date,
+	time_in,
+	time_out,
+	lunch_break_in,
+	lunch_break_out,
+	reason,
+	status,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?, 'PENDING',
+	DATETIME('now'),
+	DATETIME('now')
+) RETURNING id;
+
+-- name: GetStaffAttendanceCorrectionByID :one
+SELECT *
+FROM tbl_staff_attendance_corrections
+WHERE id = ?
+LIMIT 1;
+
+-- name: GetPendingStaffAttendanceCorrectionForDate :one
+SELECT id
+FROM tbl_staff_attendance_corrections
+WHERE
+	status = 'PENDING'
+	AND staff_id = @staff_id
+	AND for_date = @for_date
+LIMIT 1;
+
+-- name: ApproveStaffAttendanceCorrection :execrows
+UPDATE tbl_staff_attendance_corrections
+SET
+	status = 'APPROVED',
+	original_time_in = @original_time_in,
+	original_time_out = @original_time_out,
+	original_lunch_break_in = @original_lunch_break_in,
+	original_lunch_break_out = @original_lunch_break_out,
+	decided_by = @decided_by,
+	decided_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE id = @id AND status = 'PENDING';
+
+-- name: RejectStaffAttendanceCorrection :execrows
+UPDATE tbl_staff_attendance_corrections
+SET
+	status = 'REJECTED',
+	decided_by = ?,
+	decided_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE id = ? AND status = 'PENDING';
+
+-- name: GetStaffAttendanceCorrections :many
+SELECT
+	tbl_staff_attendance_corrections.*,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name
+FROM tbl_staff_attendance_corrections
+INNER JOIN tbl_staffs ON tbl_staffs.id = tbl_staff_attendance_corrections.staff_id
+WHERE
+	(tbl_staff_attendance_corrections.staff_id = @staff_id OR @staff_id = 0)
+	AND (tbl_staff_attendance_corrections.status = @status OR @status = '')
+ORDER BY tbl_staff_attendance_corrections.created_at DESC, tbl_staff_attendance_corrections.id DESC
+LIMIT @limit;
+
+-- name: GetApprovedStaffAttendanceCorrectionsByDateRange :many
+SELECT DISTINCT staff_id, for_date
+FROM tbl_staff_attendance_corrections
+WHERE
+	status = 'APPROVED'
+	AND for_date >= @start_date
+	AND for_date <= @end_date;
@@ -164,3 +164,102 @@ SET status = ?,
 	updated_at = DATETIME('now')
 WHERE id = ?
 RETURNING *;
+
+-- name: SetCheckoutCustomerID :exec
+UPDATE tbl_checkouts
+SET customer_id = ?,
+	updated_at = DATETIME('now')
+WHERE id = ? AND status = 'PENDING';
+
+-- name: SetCheckoutEmailBySessionID :exec
+UPDATE tbl_checkouts
+SET email = ?,
+	updated_at = DATETIME('now')
+WHERE session_id = ? AND status = 'PENDING';
+
+-- name: GetAbandonedCheckouts :many
+SELECT
+	tbl_checkouts.id,
+	tbl_checkouts.session_id,
+	CAST(COALESCE(tbl_customers.email, tbl_checkouts.email, '') AS TEXT) AS email
+FROM tbl_checkouts
+LEFT JOIN tbl_customers ON tbl_customers.id = tbl_checkouts.customer_id
+WHERE tbl_checkouts.status = 'PENDING'
+	AND tbl_checkouts.reminder_sent_at IS NULL
+	AND (tbl_checkouts.customer_id IS NOT NULL OR COALESCE(tbl_checkouts.email, '') != '')
+	AND tbl_checkouts.updated_at <= @idle_before
+	AND EXISTS (
+		SELECT 1 FROM tbl_checkout_lines
+		WHERE tbl_checkout_lines.checkout_id = tbl_checkouts.id
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM tbl_checkout_lines
+		WHERE tbl_checkout_lines.checkout_id = tbl_checkouts.id
+			AND tbl_checkout_lines.updated_at > @idle_before
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM tbl_orders
+		WHERE tbl_orders.checkout_id = tbl_checkouts.id
+	)
+ORDER BY tbl_checkouts.updated_at ASC
+LIMIT @limit;
+
+-- name: MarkCheckoutReminderSent :exec
+UPDATE tbl_checkouts
+SET reminder_sent_at = DATETIME('now'),
+	recovery_token_hash = ?
+WHERE id = ?;
+
+-- name: GetCheckoutByRecoveryTokenHash :one
+SELECT id, session_id
+FROM tbl_checkouts
+WHERE recovery_token_hash = ?
+	AND status = 'PENDING'
+LIMIT 1;
+
+-- name: MarkCheckoutRecovered :execrows
+UPDATE tbl_checkouts
+SET recovered_at = DATETIME('now')
+WHERE id = ?
+	AND reminder_sent_at IS NOT NULL
+	AND recovered_at IS NULL;
+
+-- name: GetPendingCheckoutByCustomerID :one
+SELECT tbl_checkouts.id, tbl_checkouts.session_id
+FROM tbl_checkouts
+WHERE tbl_checkouts.customer_id = ?
+	AND tbl_checkouts.status = 'PENDING'
+	AND NOT EXISTS (
+		SELECT 1 FROM tbl_orders
+		WHERE tbl_orders.checkout_id = tbl_checkouts.id
+	)
+ORDER BY tbl_checkouts.updated_at DESC, tbl_checkouts.id DESC
+LIMIT 1;
+
+-- name: SetCheckoutCustomerIDBySessionID :exec
+UPDATE tbl_checkouts
+SET customer_id = ?,
+	updated_at = DATETIME('now')
+WHERE session_id = ? AND status = 'PENDING';
+
+-- name: UpdateCheckoutSessionID :exec
+UPDATE tbl_checkouts
+SET session_id = ?,
+	updated_at = DATETIME('now')
+WHERE id = ?;
+
+-- name: DetachCheckoutsBySessionID :exec
+UPDATE tbl_checkouts
+SET session_id = '',
+	status = CASE WHEN status = 'PENDING' THEN 'CANCELLED' ELSE status END,
+	updated_at = DATETIME('now')
+WHERE session_id = @session_id
+	AND session_id != ''
+	AND id != @keep_id;
+
+-- name: ReleaseCustomerCheckoutBySessionID :exec
+UPDATE tbl_checkouts
+SET session_id = ''
+WHERE session_id = ?
+	AND customer_id IS NOT NULL
+	AND status = 'PENDING';
@@ -0,0 +1,102 @@
+-- name: CreateClientEvent :exec
+INSERT INTO tbl_client_events (
+	event,
+	value,
+	session_hash,
+	customer_id,
+	product_id,
+	result_count,
+	created_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, CAST(@created_at AS TEXT)
+);
+
+-- name: GetLatestClientEventRollupDay :one
+SELECT CAST(COALESCE(MAX(day), '') AS TEXT) AS day
+FROM tbl_client_event_rollups;
+
+-- RollupClientEvents recomputes the daily rollups from the raw events so a
+-- day that was rolled up while events were still being flushed is corrected
+-- on the next run.
+-- name: RollupClientEvents :exec
+INSERT INTO tbl_client_event_rollups (day, event, value, hits, sessions, zero_results)
+SELECT
+	DATE(created_at) AS day,
+	event,
+	value,
+	COUNT(*) AS hits,
+	COUNT(DISTINCT NULLIF(session_hash, '')) AS sessions,
+	SUM(CASE WHEN result_count = 0 THEN 1 ELSE 0 END) AS zero_results
+FROM tbl_client_events
+WHERE DATE(created_at) >= CAST(@since AS TEXT)
+AND DATE(created_at) < CAST(@before AS TEXT)
+GROUP BY DATE(created_at), event, value
+ON CONFLICT (day, event, value) DO UPDATE SET
+	hits = excluded.hits,
+	sessions = excluded.sessions,
+	zero_results = excluded.zero_results;
+
+-- name: DeleteClientEventsBefore :execrows
+DELETE FROM tbl_client_events
+WHERE DATE(created_at) < CAST(@before AS TEXT);
+
+-- GetZeroResultSearches reads rolled up days from the rollups and the days
+-- after the latest rollup from the raw events.
+-- name: GetZeroResultSearches :many
+WITH latest AS (
+	SELECT COALESCE(MAX(day), '') AS day FROM tbl_client_event_rollups
+), searches AS (
+	SELECT LOWER(TRIM(r.value)) AS term, r.zero_results AS searches, r.day AS day
+	FROM tbl_client_event_rollups r
+	WHERE r.event = CAST(@event AS TEXT)
+	AND r.zero_results > 0
+	AND r.day BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT)
+	UNION ALL
+	SELECT LOWER(TRIM(e.value)) AS term, 1 AS searches, DATE(e.created_at) AS day
+	FROM tbl_client_events e, latest
+	WHERE e.event = CAST(@event AS TEXT)
+	AND e.result_count = 0
+	AND DATE(e.created_at) > latest.day
+	AND DATE(e.created_at) BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT)
+)
+SELECT
+	CAST(term AS TEXT) AS term,
+	CAST(SUM(searches) AS INTEGER) AS searches,
+	CAST(MAX(day) AS TEXT) AS last_searched_on
+FROM searches
+WHERE term != ''
+GROUP BY term
+ORDER BY searches DESC, last_searched_on DESC
+LIMIT @limit;
+
+-- GetLowClickSearches compares searches that returned products against the
+-- clicks on those results. Terms with fewer than min_searches are left out so
+-- a single unlucky search does not top the list.
+-- name: GetLowClickSearches :many
+WITH latest AS (
+	SELECT COALESCE(MAX(day), '') AS day FROM tbl_client_event_rollups
+), events AS (
+	SELECT LOWER(TRIM(r.value)) AS term, r.event AS event, r.hits - r.zero_results AS hits
+	FROM tbl_client_event_rollups r
+	WHERE r.event IN (CAST(@search_event AS TEXT), CAST(@click_event AS TEXT))
+	AND r.day BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT)
+	UNION ALL
+	SELECT
+		LOWER(TRIM(e.value)) AS term,
+		e.event AS event,
+		CASE WHEN e.result_count = 0 THEN 0 ELSE 1 END AS hits
+	FROM tbl_client_events e, latest
+	WHERE e.event IN (CAST(@search_event AS TEXT), CAST(@click_event AS TEXT))
+	AND DATE(e.created_at) > latest.day
+	AND DATE(e.created_at) BETWEEN CAST(@start_date AS TEXT) AND CAST(@end_date AS TEXT)
+)
+SELECT
+	CAST(term AS TEXT) AS term,
+	CAST(SUM(CASE WHEN event = CAST(@search_event AS TEXT) THEN hits ELSE 0 END) AS INTEGER) AS searches,
+	CAST(SUM(CASE WHEN event = CAST(@click_event AS TEXT) THEN hits ELSE 0 END) AS INTEGER) AS clicks
+FROM events
+WHERE term != ''
+GROUP BY term
+HAVING searches >= CAST(@min_searches AS INTEGER)
+ORDER BY CAST(clicks AS REAL) / searches ASC, searches DESC
+LIMIT @limit;
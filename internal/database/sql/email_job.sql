@@ -9,10 +9,12 @@ INSERT INTO tbl_email_jobs(
 	checkout_payment_id,
 	otp_code,
 	memo_id,
+	product_id,
+	checkout_id,
 	created_at,
 	updated_at
 ) VALUES (
-	?, ?, ?, ?, ?, ?, ?, ?, ?,
+	?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
 	datetime('now'),
 	datetime('now')
 ) RETURNING *;
@@ -0,0 +1,160 @@
+-- name: CreateExperiment :one
+INSERT INTO tbl_experiments (
+	name,
+	kind,
+	start_date,
+	end_date,
+	created_by,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, DATETIME('now'), DATETIME('now')
+) RETURNING id;
+
+-- name: GetExperimentByID :one
+SELECT *
+FROM tbl_experiments
+WHERE id = ? AND status != 'DELETED'
+LIMIT 1;
+
+-- name: GetExperiments :many
+SELECT *
+FROM tbl_experiments
+WHERE status != 'DELETED'
+ORDER BY start_date DESC, id DESC;
+
+-- name: GetOverlappingExperiments :many
+SELECT id
+FROM tbl_experiments
+WHERE status = 'PUBLISHED'
+AND kind = @kind
+AND id != @id
+AND start_date <= @end_date
+AND end_date >= @start_date;
+
+-- name: GetRunningExperimentByKind :one
+SELECT *
+FROM tbl_experiments
+WHERE status = 'PUBLISHED'
+AND kind = ?
+AND start_date <= DATE('now')
+AND end_date >= DATE('now')
+ORDER BY start_date DESC
+LIMIT 1;
+
+-- name: UpdateExperimentStatus :execrows
+UPDATE tbl_experiments
+SET status = @status, updated_at = DATETIME('now')
+WHERE id = @id AND status != 'DELETED';
+
+-- name: CreateExperimentVariant :one
+INSERT INTO tbl_experiment_variants (
+	experiment_id,
+	name,
+	weight,
+	theme_id,
+	created_at
+) VALUES (
+	?, ?, ?, ?, DATETIME('now')
+) RETURNING id;
+
+-- name: AddExperimentVariantPromo :exec
+INSERT INTO tbl_experiment_variant_promos (variant_id, promo_id)
+VALUES (?, ?)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteExperimentVariant :execrows
+DELETE FROM tbl_experiment_variants
+WHERE id = @id AND experiment_id = @experiment_id;
+
+-- name: GetExperimentVariants :many
+SELECT
+	v.id,
+	v.experiment_id,
+	v.name,
+	v.weight,
+	v.theme_id,
+	CAST(COALESCE(t.title, '') AS TEXT) AS theme_title
+FROM tbl_experiment_variants v
+LEFT JOIN tbl_themes t ON t.id = v.theme_id
+WHERE v.experiment_id = ?
+ORDER BY v.id;
+
+-- name: GetExperimentVariantPromos :many
+SELECT
+	vp.variant_id,
+	p.id AS promo_id,
+	p.title
+FROM tbl_experiment_variant_promos vp
+JOIN tbl_experiment_variants v ON v.id = vp.variant_id
+JOIN tbl_promos p ON p.id = vp.promo_id
+WHERE v.experiment_id = ?
+ORDER BY p.priority ASC;
+
+-- name: GetActiveVariantPromos :many
+SELECT sqlc.embed(tbl_promos)
+FROM tbl_promos
+JOIN tbl_experiment_variant_promos vp ON vp.promo_id = tbl_promos.id
+WHERE vp.variant_id = ?
+AND tbl_promos.deleted_at = '1970-01-01 00:00:00+00:00'
+AND tbl_promos.status = 'PUBLISHED'
+AND tbl_promos.start_date <= DATETIME('now')
+AND tbl_promos.end_date >= DATETIME('now')
+ORDER BY tbl_promos.priority ASC;
+
+-- name: GetExperimentExposure :one
+SELECT variant_id
+FROM tbl_experiment_exposures
+WHERE experiment_id = ? AND bucket_key = ?;
+
+-- name: CreateExperimentExposure :exec
+INSERT INTO tbl_experiment_exposures (
+	experiment_id,
+	variant_id,
+	bucket_key,
+	created_at
+) VALUES (
+	?, ?, ?, DATETIME('now')
+)
+ON CONFLICT (experiment_id, bucket_key) DO NOTHING;
+
+-- name: MarkExperimentAddToCart :many
+UPDATE tbl_experiment_exposures
+SET added_to_cart_at = DATETIME('now')
+WHERE bucket_key = @bucket_key
+AND added_to_cart_at IS NULL
+AND experiment_id IN (
+	SELECT id FROM tbl_experiments
+	WHERE status = 'PUBLISHED'
+	AND start_date <= DATE('now')
+	AND end_date >= DATE('now')
+)
+RETURNING experiment_id, variant_id;
+
+-- name: SetExperimentExposureCheckout :exec
+UPDATE tbl_experiment_exposures
+SET checkout_id = @checkout_id
+WHERE bucket_key = @bucket_key
+AND experiment_id IN (
+	SELECT id FROM tbl_experiments
+	WHERE status = 'PUBLISHED'
+	AND start_date <= DATE('now')
+	AND end_date >= DATE('now')
+);
+
+-- name: GetExperimentVariantResults :many
+SELECT
+	v.id AS variant_id,
+	COUNT(DISTINCT e.id) AS exposures,
+	COUNT(DISTINCT CASE WHEN e.added_to_cart_at IS NOT NULL THEN e.id END) AS added_to_cart,
+	COUNT(DISTINCT CASE WHEN o.id IS NOT NULL THEN e.id END) AS paid_orders,
+	CAST(COALESCE(SUM(o.total_amount), 0) AS INTEGER) AS revenue
+FROM tbl_experiment_variants v
+LEFT JOIN tbl_experiment_exposures e ON e.variant_id = v.id
+LEFT JOIN tbl_orders o
+	ON o.checkout_id = e.checkout_id
+	AND o.paid_at IS NOT NULL
+	AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+WHERE v.experiment_id = ?
+GROUP BY v.id
+ORDER BY v.id;
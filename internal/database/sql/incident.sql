@@ -0,0 +1,83 @@
+-- name: CreateStaffIncident :one
+INSERT INTO tbl_staff_incidents (
+	staff_id,
+	reported_by,
+	incident_date,
+	title,
+	description,
+	status,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, 'OPEN',
+	DATETIME('now'),
+	DATETIME('now')
+) RETURNING id;
+
+-- name: GetStaffIncidentByID :one
+SELECT *
+FROM tbl_staff_incidents
+WHERE id = ?
+LIMIT 1;
+
+-- name: IssueStaffIncidentNTE :execrows
+UPDATE tbl_staff_incidents
+SET
+	status = 'NTE_ISSUED',
+	nte_memo_id = @nte_memo_id,
+	response_deadline = @response_deadline,
+	updated_at = DATETIME('now')
+WHERE id = @id AND status = 'OPEN';
+
+-- name: RespondToStaffIncident :execrows
+UPDATE tbl_staff_incidents
+SET
+	status = 'RESPONDED',
+	response = @response,
+	responded_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE
+	id = @id
+	AND staff_id = @staff_id
+	AND status = 'NTE_ISSUED'
+	AND response_deadline >= CAST(@today AS TEXT);
+
+-- name: DecideStaffIncident :execrows
+UPDATE tbl_staff_incidents
+SET
+	status = 'DECIDED',
+	decision = @decision,
+	decision_notes = @decision_notes,
+	effective_from = @effective_from,
+	effective_to = @effective_to,
+	decided_by = @decided_by,
+	decided_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE id = @id AND status != 'DECIDED';
+
+-- name: GetStaffIncidents :many
+SELECT
+	tbl_staff_incidents.*,
+	staff.first_name,
+	staff.middle_name,
+	staff.last_name,
+	reporter.first_name AS reporter_first_name,
+	reporter.middle_name AS reporter_middle_name,
+	reporter.last_name AS reporter_last_name
+FROM tbl_staff_incidents
+INNER JOIN tbl_staffs AS staff ON staff.id = tbl_staff_incidents.staff_id
+INNER JOIN tbl_staffs AS reporter ON reporter.id = tbl_staff_incidents.reported_by
+WHERE
+	(tbl_staff_incidents.staff_id = @staff_id OR @staff_id = 0)
+	AND (tbl_staff_incidents.status = @status OR @status = '')
+ORDER BY tbl_staff_incidents.incident_date DESC, tbl_staff_incidents.id DESC
+LIMIT @limit;
+
+-- name: GetStaffSuspensionsByDateRange :many
+SELECT staff_id, effective_from, effective_to
+FROM tbl_staff_incidents
+WHERE
+	decision = 'SUSPENSION'
+	AND (staff_id = @staff_id OR @staff_id = 0)
+	AND effective_from <= CAST(@end_date AS TEXT)
+	AND effective_to >= CAST(@start_date AS TEXT);
@@ -0,0 +1,95 @@
+-- name: UpsertStaffKioskPin :exec
+INSERT INTO tbl_staff_kiosk_pins (staff_id, pin_hash)
+VALUES (@staff_id, @pin_hash)
+ON CONFLICT (staff_id) DO UPDATE SET
+	pin_hash = excluded.pin_hash,
+	failed_attempts = 0,
+	locked_until = NULL,
+	updated_at = DATETIME('now');
+
+-- name: GetStaffKioskPin :one
+SELECT *
+FROM tbl_staff_kiosk_pins
+WHERE staff_id = ?
+LIMIT 1;
+
+-- name: IncrementStaffKioskPinFailures :one
+UPDATE tbl_staff_kiosk_pins
+SET
+	failed_attempts = failed_attempts + 1,
+	updated_at = DATETIME('now')
+WHERE staff_id = ?
+RETURNING failed_attempts;
+
+-- name: LockStaffKioskPin :exec
+UPDATE tbl_staff_kiosk_pins
+SET
+	failed_attempts = 0,
+	locked_until = @locked_until,
+	updated_at = DATETIME('now')
+WHERE staff_id = @staff_id;
+
+-- name: ResetStaffKioskPinFailures :exec
+UPDATE tbl_staff_kiosk_pins
+SET
+	failed_attempts = 0,
+	locked_until = NULL,
+	updated_at = DATETIME('now')
+WHERE staff_id = ?;
+
+-- CreateAttendanceKioskPunch returns no row when the QR token's nonce was
+-- already used by another punch.
+-- name: CreateAttendanceKioskPunch :one
+INSERT INTO tbl_attendance_kiosk_punches (
+	staff_id,
+	kiosk_staff_id,
+	for_date,
+	punch_type,
+	method,
+	photo_key,
+	useragent_id,
+	token_nonce
+) VALUES (
+	@staff_id,
+	@kiosk_staff_id,
+	@for_date,
+	@punch_type,
+	@method,
+	@photo_key,
+	@useragent_id,
+	@token_nonce
+)
+ON CONFLICT (token_nonce) DO NOTHING
+RETURNING id;
+
+-- name: UpdateAttendanceKioskPunchPhotoKey :exec
+UPDATE tbl_attendance_kiosk_punches SET photo_key = ? WHERE id = ?;
+
+-- name: DeleteAttendanceKioskPunch :exec
+DELETE FROM tbl_attendance_kiosk_punches WHERE id = ?;
+
+-- name: GetAttendanceKioskPunchesByDate :many
+SELECT
+	tbl_attendance_kiosk_punches.id,
+	tbl_attendance_kiosk_punches.staff_id,
+	tbl_attendance_kiosk_punches.for_date,
+	tbl_attendance_kiosk_punches.punch_type,
+	tbl_attendance_kiosk_punches.method,
+	tbl_attendance_kiosk_punches.photo_key,
+	tbl_attendance_kiosk_punches.created_at,
+	staff.first_name,
+	staff.middle_name,
+	staff.last_name,
+	kiosk.first_name AS kiosk_first_name,
+	kiosk.last_name AS kiosk_last_name
+FROM tbl_attendance_kiosk_punches
+INNER JOIN tbl_staffs AS staff ON staff.id = tbl_attendance_kiosk_punches.staff_id
+INNER JOIN tbl_staffs AS kiosk ON kiosk.id = tbl_attendance_kiosk_punches.kiosk_staff_id
+WHERE tbl_attendance_kiosk_punches.for_date = @for_date
+ORDER BY tbl_attendance_kiosk_punches.created_at DESC;
+
+-- name: GetAttendanceKioskPunchByID :one
+SELECT *
+FROM tbl_attendance_kiosk_punches
+WHERE id = ?
+LIMIT 1;
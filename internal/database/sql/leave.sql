@@ -0,0 +1,98 @@
+-- name: GetLeaveStaffs :many
+SELECT
+	tbl_staffs.id,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position,
+	tbl_staffs.date_hired
+FROM tbl_staffs
+WHERE
+	tbl_staffs.deleted_at = '1970-01-01 00:00:00+00:00'
+	AND tbl_staffs.status != 'RESIGNED'
+ORDER BY tbl_staffs.last_name ASC, tbl_staffs.first_name ASC;
+
+-- name: GetLeaveStaffByID :one
+SELECT
+	tbl_staffs.id,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position,
+	tbl_staffs.date_hired
+FROM tbl_staffs
+WHERE
+	tbl_staffs.id = ?
+	AND tbl_staffs.deleted_at = '1970-01-01 00:00:00+00:00'
+LIMIT 1;
+
+-- name: GetStaffLeaveFirstYear :one
+SELECT CAST(COALESCE(MIN(year), @default_year) AS INTEGER) AS first_year
+FROM tbl_staff_leave_ledger
+WHERE staff_id = @staff_id AND leave_type = @leave_type;
+
+-- name: CreateStaffLeaveEntry :exec
+INSERT INTO tbl_staff_leave_ledger (
+	staff_id,
+	leave_type,
+	entry_type,
+	year,
+	period,
+	credits,
+	time_off_id,
+	note,
+	created_by,
+	created_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?, ?, ?,
+	DATETIME('now')
+)
+ON CONFLICT DO NOTHING;
+
+-- name: GetStaffLeaveBalanceUntilYear :one
+SELECT CAST(COALESCE(SUM(credits), 0) AS INTEGER) AS balance
+FROM tbl_staff_leave_ledger
+WHERE
+	staff_id = @staff_id
+	AND leave_type = @leave_type
+	AND year <= @year;
+
+-- name: GetStaffLeaveBalance :one
+SELECT CAST(COALESCE(SUM(credits), 0) AS INTEGER) AS balance
+FROM tbl_staff_leave_ledger
+WHERE staff_id = ? AND leave_type = ?;
+
+-- name: GetStaffLeaveTimeOffCredits :one
+SELECT CAST(COALESCE(SUM(credits), 0) AS INTEGER) AS credits
+FROM tbl_staff_leave_ledger
+WHERE time_off_id = ?;
+
+-- name: GetStaffLeaveTotals :many
+SELECT
+	leave_type,
+	entry_type,
+	year,
+	CAST(SUM(credits) AS INTEGER) AS credits
+FROM tbl_staff_leave_ledger
+WHERE staff_id = ?
+GROUP BY leave_type, entry_type, year;
+
+-- name: GetLeaveBalances :many
+SELECT
+	staff_id,
+	leave_type,
+	CAST(SUM(credits) AS INTEGER) AS balance
+FROM tbl_staff_leave_ledger
+GROUP BY staff_id, leave_type;
+
+-- name: GetStaffLeaveLedger :many
+SELECT
+	tbl_staff_leave_ledger.*,
+	creator.first_name AS creator_first_name,
+	creator.middle_name AS creator_middle_name,
+	creator.last_name AS creator_last_name
+FROM tbl_staff_leave_ledger
+LEFT JOIN tbl_staffs creator ON creator.id = tbl_staff_leave_ledger.created_by
+WHERE tbl_staff_leave_ledger.staff_id = ?
+ORDER BY tbl_staff_leave_ledger.created_at DESC, tbl_staff_leave_ledger.id DESC
+LIMIT ?;
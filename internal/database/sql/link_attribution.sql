@@ -0,0 +1,72 @@
+-- name: UpsertCheckoutLinkAttribution :exec
+INSERT INTO tbl_checkout_link_attributions (
+	checkout_id,
+	first_link_id,
+	last_link_id,
+	created_at,
+	updated_at
+) VALUES (
+	@checkout_id, @first_link_id, @last_link_id, DATETIME('now'), DATETIME('now')
+)
+ON CONFLICT (checkout_id) DO UPDATE SET
+	last_link_id = excluded.last_link_id,
+	updated_at = DATETIME('now');
+
+-- name: GetLinkAttributionTotals :one
+SELECT
+	CAST(COUNT(DISTINCT CASE WHEN a.first_link_id = @link_id THEN a.checkout_id END) AS INTEGER) AS first_touch_carts,
+	CAST(COUNT(DISTINCT CASE WHEN a.last_link_id = @link_id THEN a.checkout_id END) AS INTEGER) AS last_touch_carts,
+	CAST(COUNT(DISTINCT CASE WHEN a.first_link_id = @link_id AND o.id IS NOT NULL THEN o.id END) AS INTEGER) AS first_touch_orders,
+	CAST(COUNT(DISTINCT CASE WHEN a.last_link_id = @link_id AND o.id IS NOT NULL THEN o.id END) AS INTEGER) AS last_touch_orders,
+	CAST(COALESCE(SUM(CASE WHEN a.first_link_id = @link_id THEN o.total_amount END), 0) AS INTEGER) AS first_touch_revenue,
+	CAST(COALESCE(SUM(CASE WHEN a.last_link_id = @link_id THEN o.total_amount END), 0) AS INTEGER) AS last_touch_revenue
+FROM tbl_checkout_link_attributions a
+LEFT JOIN tbl_orders o
+	ON o.checkout_id = a.checkout_id
+	AND o.paid_at IS NOT NULL
+	AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+WHERE a.first_link_id = @link_id OR a.last_link_id = @link_id;
+
+-- name: GetCampaignClickTotals :many
+SELECT
+	CAST(COALESCE(l.source, '') AS TEXT) AS source,
+	CAST(COALESCE(l.campaign, '') AS TEXT) AS campaign,
+	COUNT(DISTINCT l.id) AS links,
+	COUNT(c.id) AS clicks,
+	COUNT(DISTINCT c.ip_hash) AS unique_visitors
+FROM tbl_tracked_links l
+LEFT JOIN tbl_link_clicks c
+	ON c.link_id = l.id
+	AND (CAST(@include_filtered AS BOOLEAN) OR (c.is_bot = 0 AND c.is_duplicate = 0))
+GROUP BY 1, 2
+ORDER BY clicks DESC;
+
+-- name: GetCampaignFirstTouchTotals :many
+SELECT
+	CAST(COALESCE(l.source, '') AS TEXT) AS source,
+	CAST(COALESCE(l.campaign, '') AS TEXT) AS campaign,
+	COUNT(DISTINCT a.checkout_id) AS carts,
+	COUNT(DISTINCT o.id) AS orders,
+	CAST(COALESCE(SUM(o.total_amount), 0) AS INTEGER) AS revenue
+FROM tbl_checkout_link_attributions a
+JOIN tbl_tracked_links l ON l.id = a.first_link_id
+LEFT JOIN tbl_orders o
+	ON o.checkout_id = a.checkout_id
+	AND o.paid_at IS NOT NULL
+	AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+GROUP BY 1, 2;
+
+-- name: GetCampaignLastTouchTotals :many
+SELECT
+	CAST(COALESCE(l.source, '') AS TEXT) AS source,
+	CAST(COALESCE(l.campaign, '') AS TEXT) AS campaign,
+	COUNT(DISTINCT a.checkout_id) AS carts,
+	COUNT(DISTINCT o.id) AS orders,
+	CAST(COALESCE(SUM(o.total_amount), 0) AS INTEGER) AS revenue
+FROM tbl_checkout_link_attributions a
+JOIN tbl_tracked_links l ON l.id = a.last_link_id
+LEFT JOIN tbl_orders o
+	ON o.checkout_id = a.checkout_id
+	AND o.paid_at IS NOT NULL
+	AND o.status NOT IN ('CANCELLED', 'REFUNDED')
+GROUP BY 1, 2;
@@ -8,13 +8,20 @@ INSERT INTO tbl_link_clicks (
     device,
     utm_source,
     utm_medium,
-    utm_campaign
+    utm_campaign,
+    is_bot,
+    is_duplicate
 ) VALUES (
     ?,
     datetime('now'),
-    ?, ?, ?, ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
 );
 
+-- name: CountRecentLinkClicksByIPHash :one
+SELECT COUNT(*) AS count
+FROM tbl_link_clicks
+WHERE link_id = @link_id AND ip_hash = @ip_hash AND clicked_at >= @since;
+
 -- name: GetLinkClicksByLinkID :many
 SELECT
     id,
@@ -26,12 +33,63 @@ SELECT
     device,
     utm_source,
     utm_medium,
-    utm_campaign
+    utm_campaign,
+    is_bot,
+    is_duplicate
 FROM tbl_link_clicks
-WHERE link_id = ?
+WHERE link_id = @link_id
+AND (CAST(@include_filtered AS BOOLEAN) OR (is_bot = 0 AND is_duplicate = 0))
 ORDER BY clicked_at DESC;
 
 -- name: CountLinkClicksByLinkID :one
 SELECT COUNT(*) as count
 FROM tbl_link_clicks
-WHERE link_id = ?;
+WHERE link_id = @link_id
+AND (CAST(@include_filtered AS BOOLEAN) OR (is_bot = 0 AND is_duplicate = 0));
+
+-- name: GetLinkClickTotals :one
+SELECT
+    COUNT(*) AS clicks,
+    COUNT(DISTINCT ip_hash) AS unique_visitors
+FROM tbl_link_clicks
+WHERE link_id = @link_id
+AND (CAST(@include_filtered AS BOOLEAN) OR (is_bot = 0 AND is_duplicate = 0));
+
+-- name: GetLinkFilteredClickTotals :one
+SELECT
+    CAST(COALESCE(SUM(is_bot), 0) AS INTEGER) AS bots,
+    CAST(COALESCE(SUM(CASE WHEN is_bot = 0 THEN is_duplicate ELSE 0 END), 0) AS INTEGER) AS duplicates
+FROM tbl_link_clicks
+WHERE link_id = @link_id;
+
+-- name: GetLinkClicksPerDay :many
+SELECT
+    CAST(DATE(clicked_at) AS TEXT) AS day,
+    COUNT(*) AS clicks,
+    COUNT(DISTINCT ip_hash) AS unique_visitors
+FROM tbl_link_clicks
+WHERE link_id = @link_id AND clicked_at >= @since
+AND (CAST(@include_filtered AS BOOLEAN) OR (is_bot = 0 AND is_duplicate = 0))
+GROUP BY DATE(clicked_at)
+ORDER BY day;
+
+-- name: GetLinkClicksByDevice :many
+SELECT
+    CAST(COALESCE(NULLIF(device, ''), 'Unknown') AS TEXT) AS label,
+    COUNT(*) AS clicks
+FROM tbl_link_clicks
+WHERE link_id = @link_id
+AND (CAST(@include_filtered AS BOOLEAN) OR (is_bot = 0 AND is_duplicate = 0))
+GROUP BY label
+ORDER BY clicks DESC;
+
+-- name: GetLinkClicksByReferrer :many
+SELECT
+    CAST(COALESCE(NULLIF(referrer, ''), 'Direct') AS TEXT) AS label,
+    COUNT(*) AS clicks
+FROM tbl_link_clicks
+WHERE link_id = @link_id
+AND (CAST(@include_filtered AS BOOLEAN) OR (is_bot = 0 AND is_duplicate = 0))
+GROUP BY label
+ORDER BY clicks DESC
+LIMIT @limit;
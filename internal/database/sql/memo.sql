@@ -50,12 +50,14 @@ INSERT INTO tbl_memos (
     status,
     start_date,
     end_date,
+    requires_acknowledgement,
+    acknowledge_by,
     created_by,
     created_at,
     updated_at,
     deleted_at
 ) VALUES (
-    ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?,
     datetime('now'),
     datetime('now'),
     '1970-01-01 00:00:00+00:00'
@@ -70,6 +72,8 @@ SET
     status = ?,
     start_date = ?,
     end_date = ?,
+    requires_acknowledgement = ?,
+    acknowledge_by = ?,
     updated_at = datetime('now')
 WHERE id = ?
 AND deleted_at = '1970-01-01 00:00:00+00:00';
@@ -90,3 +94,40 @@ SET
     updated_at = datetime('now')
 WHERE id = ?
 AND deleted_at = '1970-01-01 00:00:00+00:00';
+
+-- name: GetMemosDueForEscalation :many
+SELECT sqlc.embed(m)
+FROM tbl_memos m
+WHERE m.deleted_at = '1970-01-01 00:00:00+00:00'
+AND m.status = 'PUBLISHED'
+AND m.requires_acknowledgement = 1
+AND m.acknowledge_by IS NOT NULL
+AND m.acknowledge_by < sqlc.arg(today)
+AND m.escalated_at IS NULL
+AND EXISTS (
+    SELECT 1
+    FROM tbl_memo_recipients r
+    JOIN tbl_staffs s ON s.id = r.staff_id
+    LEFT JOIN tbl_memo_staff_actions a ON a.memo_id = r.memo_id AND a.staff_id = r.staff_id
+    WHERE r.memo_id = m.id
+    AND a.id IS NULL
+    AND s.deleted_at = '1970-01-01 00:00:00+00:00'
+    AND s.status != 'RESIGNED'
+)
+ORDER BY m.acknowledge_by ASC, m.id ASC
+LIMIT sqlc.arg(limit);
+
+-- name: MarkMemoEscalated :execrows
+UPDATE tbl_memos
+SET
+    escalated_at = datetime('now'),
+    updated_at = datetime('now')
+WHERE id = ?
+AND escalated_at IS NULL;
+
+-- name: ClearMemoEscalated :exec
+UPDATE tbl_memos
+SET
+    escalated_at = NULL,
+    updated_at = datetime('now')
+WHERE id = ?;
@@ -38,6 +38,8 @@ SELECT
     s.email,
     s.position,
     s.user_type,
+    r.reminder_count,
+    r.last_reminded_at,
     a.status AS action_status,
     a.reject_reason,
     a.accepted_at,
@@ -50,3 +52,53 @@ WHERE r.memo_id = ?
 AND s.deleted_at = '1970-01-01 00:00:00+00:00'
 AND s.status != 'RESIGNED'
 ORDER BY s.last_name ASC, s.first_name ASC;
+
+-- name: GetMemoRecipientsDueForReminder :many
+SELECT
+    r.memo_id,
+    r.staff_id,
+    s.email,
+    m.title
+FROM tbl_memo_recipients r
+JOIN tbl_memos m ON m.id = r.memo_id
+JOIN tbl_staffs s ON s.id = r.staff_id
+LEFT JOIN tbl_memo_staff_actions a ON a.memo_id = r.memo_id AND a.staff_id = r.staff_id
+WHERE m.deleted_at = '1970-01-01 00:00:00+00:00'
+AND m.status = 'PUBLISHED'
+AND m.requires_acknowledgement = 1
+AND m.start_date <= sqlc.arg(today)
+AND m.end_date >= sqlc.arg(today)
+AND a.id IS NULL
+AND s.email != ''
+AND s.deleted_at = '1970-01-01 00:00:00+00:00'
+AND s.status != 'RESIGNED'
+AND COALESCE(r.last_reminded_at, r.created_at) <= sqlc.arg(remind_before)
+ORDER BY r.memo_id ASC, r.staff_id ASC
+LIMIT sqlc.arg(limit);
+
+-- name: MarkMemoRecipientReminded :exec
+UPDATE tbl_memo_recipients
+SET
+    reminder_count = reminder_count + 1,
+    last_reminded_at = datetime('now')
+WHERE memo_id = ? AND staff_id = ?;
+
+-- name: GetMemosReceivedByStaff :many
+SELECT
+    m.id,
+    m.title,
+    m.start_date,
+    m.end_date,
+    m.requires_acknowledgement,
+    m.acknowledge_by,
+    a.status AS action_status,
+    a.reject_reason,
+    a.created_at AS action_created_at
+FROM tbl_memo_recipients r
+JOIN tbl_memos m ON m.id = r.memo_id
+LEFT JOIN tbl_memo_staff_actions a ON a.memo_id = r.memo_id AND a.staff_id = r.staff_id
+WHERE r.staff_id = @staff_id
+AND m.deleted_at = '1970-01-01 00:00:00+00:00'
+AND m.status != 'DRAFT'
+ORDER BY m.start_date DESC, m.id DESC
+LIMIT @limit;
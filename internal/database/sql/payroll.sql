@@ -0,0 +1,183 @@
+-- name: UpsertStaffPayRate :exec
+INSERT INTO tbl_staff_pay_rates (
+	staff_id,
+	rate_type,
+	rate,
+	updated_by,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?,
+	DATETIME('now'),
+	DATETIME('now')
+)
+ON CONFLICT (staff_id) DO UPDATE SET
+	rate_type = excluded.rate_type,
+	rate = excluded.rate,
+	updated_by = excluded.updated_by,
+	updated_at = DATETIME('now');
+
+-- name: GetStaffsWithPayRates :many
+SELECT
+	tbl_staffs.id,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position,
+	tbl_staff_pay_rates.rate_type,
+	tbl_staff_pay_rates.rate
+FROM tbl_staffs
+LEFT JOIN tbl_staff_pay_rates ON tbl_staff_pay_rates.staff_id = tbl_staffs.id
+WHERE
+	tbl_staffs.deleted_at = '1970-01-01 00:00:00+00:00'
+	AND tbl_staffs.status != 'RESIGNED'
+ORDER BY tbl_staffs.last_name ASC, tbl_staffs.first_name ASC;
+
+-- name: GetPayrollStaffs :many
+SELECT
+	tbl_staffs.id,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.time_in_schedule,
+	tbl_staffs.time_out_schedule,
+	tbl_staff_pay_rates.rate_type,
+	tbl_staff_pay_rates.rate
+FROM tbl_staffs
+INNER JOIN tbl_staff_pay_rates ON tbl_staff_pay_rates.staff_id = tbl_staffs.id
+WHERE
+	tbl_staffs.deleted_at = '1970-01-01 00:00:00+00:00'
+	AND (tbl_staffs.status != 'RESIGNED' OR tbl_staffs.resigned_at >= @period_start)
+ORDER BY tbl_staffs.last_name ASC, tbl_staffs.first_name ASC;
+
+-- name: GetApprovedTimeOffsByDateRange :many
+SELECT
+	staff_id,
+	type,
+	start_date,
+	end_date
+FROM tbl_staff_time_offs
+WHERE
+	approved = true
+	AND start_date <= @period_end
+	AND end_date >= @period_start;
+
+-- name: CreatePayrollRun :one
+INSERT INTO tbl_payroll_runs (
+	period_start,
+	period_end,
+	status,
+	created_by,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, 'DRAFT', ?,
+	DATETIME('now'),
+	DATETIME('now')
+) RETURNING id;
+
+-- name: GetPayrollRunByID :one
+SELECT *
+FROM tbl_payroll_runs
+WHERE id = ?
+LIMIT 1;
+
+-- name: GetPayrollRunByPeriod :one
+SELECT id
+FROM tbl_payroll_runs
+WHERE period_start = ? AND period_end = ?
+LIMIT 1;
+
+-- name: GetPayrollRuns :many
+SELECT
+	tbl_payroll_runs.*,
+	(
+		SELECT COUNT(*)
+		FROM tbl_payroll_run_items
+		WHERE tbl_payroll_run_items.payroll_run_id = tbl_payroll_runs.id
+	) AS staff_count,
+	CAST((
+		SELECT COALESCE(SUM(tbl_payroll_run_items.net_pay), 0)
+		FROM tbl_payroll_run_items
+		WHERE tbl_payroll_run_items.payroll_run_id = tbl_payroll_runs.id
+	) AS INTEGER) AS total_net_pay
+FROM tbl_payroll_runs
+ORDER BY tbl_payroll_runs.period_start DESC;
+
+-- name: LockPayrollRun :execrows
+UPDATE tbl_payroll_runs
+SET
+	status = 'LOCKED',
+	locked_by = ?,
+	locked_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE id = ? AND status = 'DRAFT';
+
+-- name: TouchPayrollRun :exec
+UPDATE tbl_payroll_runs
+SET updated_at = DATETIME('now')
+WHERE id = ? AND status = 'DRAFT';
+
+-- name: DeleteDraftPayrollRunItems :exec
+DELETE FROM tbl_payroll_run_items
+WHERE payroll_run_id IN (
+	SELECT id
+	FROM tbl_payroll_runs
+	WHERE tbl_payroll_runs.id = ? AND tbl_payroll_runs.status = 'DRAFT'
+);
+
+-- name: CreatePayrollRunItem :exec
+INSERT INTO tbl_payroll_run_items (
+	payroll_run_id,
+	staff_id,
+	rate_type,
+	rate,
+	daily_rate,
+	days_worked,
+	absent_days,
+	late_minutes,
+	undertime_minutes,
+	overtime_minutes,
+	basic_pay,
+	holiday_pay,
+	overtime_pay,
+	late_deduction,
+	undertime_deduction,
+	absence_deduction,
+	gross_pay,
+	sss,
+	philhealth,
+	pagibig,
+	withholding_tax,
+	net_pay,
+	created_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+	DATETIME('now')
+);
+
+-- name: GetPayrollRunItems :many
+SELECT
+	tbl_payroll_run_items.*,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position
+FROM tbl_payroll_run_items
+INNER JOIN tbl_staffs ON tbl_staffs.id = tbl_payroll_run_items.staff_id
+WHERE tbl_payroll_run_items.payroll_run_id = ?
+ORDER BY tbl_staffs.last_name ASC, tbl_staffs.first_name ASC;
+
+-- name: GetPayrollRunItem :one
+SELECT
+	tbl_payroll_run_items.*,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position
+FROM tbl_payroll_run_items
+INNER JOIN tbl_staffs ON tbl_staffs.id = tbl_payroll_run_items.staff_id
+WHERE
+	tbl_payroll_run_items.payroll_run_id = ?
+	AND tbl_payroll_run_items.staff_id = ?
+LIMIT 1;
@@ -76,7 +76,7 @@ FROM tbl_products
 INNER JOIN tbl_products_categories ON tbl_products.id = tbl_products_categories.product_id
 INNER JOIN tbl_product_specs ON tbl_products.product_specs_id = tbl_product_specs.id
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
-ORDER BY created_at DESC;
+ORDER BY tbl_products.created_at DESC;
 
 -- name: ListProductsForQuotations :many
 SELECT
@@ -144,7 +144,7 @@ INSERT INTO tbl_products (
 	datetime('now')
 ) RETURNING *;
 
--- name: UpdateProducts :execlastid
+-- name: UpdateProducts :one
 UPDATE tbl_products
 SET
 	name = ?,
@@ -158,7 +158,8 @@ SET
 	unit_price_without_vat_currency = ?,
 	unit_price_with_vat_currency = ?,
 	updated_at = datetime('now')
-WHERE id = ?;
+WHERE id = ?
+RETURNING id;
 
 -- name: AdminGetProductsForListing :many
 SELECT
@@ -394,30 +395,9 @@ WHERE
 
 --TODO: (Brandon) if sqlc releases PR #3498
 --      replace WHERE with `tbl_products_fts MATCH ?`
--- name: GetProductsBySearchQuery :many
-SELECT
-	tbl_products.id,
-	tbl_products.name,
-	tbl_products.slug,
-	tbl_products.unit_price_with_vat,
-	tbl_products.unit_price_with_vat_currency,
-	tbl_brands.name AS brand_name,
-	COALESCE(
-		tbl_product_images.thumbnail,
-		'static/images/empty_96x96.webp'
-	) AS thumbnail_path,
-	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
-FROM tbl_products_fts
-INNER JOIN tbl_products ON tbl_products.id = tbl_products_fts.rowid
-INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
-LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
-WHERE
-	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
-	AND tbl_products_fts.name MATCH ?
-LIMIT ?;
-
+-- GetProductsBySearchQueryPaginated honours the merchandising rules in
+-- tbl_search_rules: pinned products come first even when the full-text search
+-- does not match them, followed by products of boosted brands.
 -- name: GetProductsBySearchQueryPaginated :many
 SELECT
 	tbl_products.id,
@@ -441,9 +421,10 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
-FROM tbl_products_fts
-INNER JOIN tbl_products ON tbl_products.id = tbl_products_fts.rowid
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
+FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
 LEFT JOIN tbl_product_sales
@@ -451,12 +432,32 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
+LEFT JOIN tbl_search_rules AS pin
+	ON pin.product_id = tbl_products.id
+	AND pin.kind = 'PIN_PRODUCT'
+	AND pin.search_term = @search_term
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
-	AND tbl_products_fts.name MATCH ?
-ORDER BY is_on_sale DESC, tbl_products.created_at DESC
-LIMIT ? OFFSET ?;
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
+	AND (
+		tbl_products.id IN (
+			SELECT rowid FROM tbl_products_fts WHERE tbl_products_fts.name MATCH @name
+		)
+		OR pin.id IS NOT NULL
+	)
+ORDER BY
+	pin.id IS NULL,
+	pin.position ASC,
+	EXISTS (
+		SELECT 1 FROM tbl_search_rules AS boost
+		WHERE boost.kind = 'BOOST_BRAND'
+		AND boost.search_term = @search_term
+		AND boost.brand_id = tbl_products.brand_id
+	) DESC,
+	is_on_sale DESC,
+	tbl_products.created_at DESC
+LIMIT @limit OFFSET @offset;
 
 -- name: GetRelatedProductsForSearch :many
 SELECT
@@ -481,7 +482,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 INNER JOIN tbl_products_categories ON tbl_products_categories.product_id = tbl_products.id
@@ -491,9 +494,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_products_categories.category_id IN (
 		SELECT DISTINCT pc.category_id
 		FROM tbl_products_fts
@@ -537,7 +541,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 INNER JOIN tbl_products_categories ON tbl_products_categories.product_id = tbl_products.id
@@ -548,9 +554,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND pc.category IN (
 		SELECT DISTINCT cat.category
 		FROM tbl_products_fts
@@ -595,7 +602,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
@@ -604,9 +613,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_products.brand_id IN (
 		SELECT DISTINCT p.brand_id
 		FROM tbl_products_fts
@@ -649,7 +659,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
@@ -658,9 +670,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_products.id NOT IN (
 		SELECT p2.id
 		FROM tbl_products_fts
@@ -705,7 +718,7 @@ LEFT JOIN tbl_product_sales
 LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_product_sales.id IS NOT NULL
 ORDER BY RANDOM()
 LIMIT 1;
@@ -773,7 +786,9 @@ SELECT
 	CASE
 		WHEN tbl_product_sales.id IS NOT NULL THEN tbl_product_sales.discount_value
 		ELSE 0
-	END AS discount_value
+	END AS discount_value,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 INNER JOIN tbl_brand_images ON tbl_brand_images.brand_id = tbl_brands.id
@@ -791,6 +806,7 @@ LEFT JOIN tbl_product_sales
 	ON tbl_product_sales.product_id = tbl_products.id
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE tbl_products.slug = ? AND tbl_products.status = 'ACTIVE'
 LIMIT 1;
 
@@ -100,7 +100,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 INNER JOIN tbl_products_categories ON tbl_products_categories.product_id = tbl_products.id
@@ -110,9 +112,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_products_categories.category_id = :category_id
 	AND (
 		:brand_id IS NULL
@@ -245,7 +248,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 INNER JOIN tbl_products_categories ON tbl_products_categories.product_id = tbl_products.id
@@ -256,9 +261,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_product_categories.category = ?
 ORDER BY is_on_sale DESC, tbl_products.created_at DESC
 LIMIT ?;
@@ -286,7 +292,9 @@ SELECT
 		'static/images/empty_96x96.webp'
 	) AS thumbnail_path,
 	tbl_product_images.cdn_url,
-	tbl_product_images.cdn_url_thumbnail
+	tbl_product_images.cdn_url_thumbnail,
+	COALESCE(tbl_product_ratings.rating_count, 0) AS rating_count,
+	COALESCE(tbl_product_ratings.rating_total, 0) AS rating_total
 FROM tbl_products
 INNER JOIN tbl_brands ON tbl_brands.id = tbl_products.brand_id
 INNER JOIN tbl_products_categories ON tbl_products_categories.product_id = tbl_products.id
@@ -297,9 +305,10 @@ LEFT JOIN tbl_product_sales
 	AND tbl_product_sales.is_active = 1
 	AND datetime('now') BETWEEN
 		tbl_product_sales.starts_at AND tbl_product_sales.ends_at
+LEFT JOIN tbl_product_ratings ON tbl_product_ratings.product_id = tbl_products.id
 WHERE
 	tbl_products.status = 'ACTIVE'
-	AND thumbnail_path != 'static/images/empty_96x96.webp'
+	AND COALESCE(tbl_product_images.thumbnail, 'static/images/empty_96x96.webp') != 'static/images/empty_96x96.webp'
 	AND tbl_product_categories.category = ?
 	AND tbl_product_categories.subcategory = ?
 ORDER BY is_on_sale DESC, tbl_products.created_at DESC
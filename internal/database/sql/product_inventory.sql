@@ -37,7 +37,7 @@ FROM tbl_product_inventories
 WHERE id = ?
 LIMIT 1;
 
--- name: UpdateProductInventory :exec
+-- name: UpdateProductInventory :execrows
 UPDATE tbl_product_inventories
 SET
     stocks = ?,
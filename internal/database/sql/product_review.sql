@@ -0,0 +1,139 @@
+-- name: GetLatestDeliveredOrderIDForCustomerProduct :one
+SELECT tbl_orders.id
+FROM tbl_orders
+INNER JOIN tbl_order_lines ON tbl_order_lines.order_id = tbl_orders.id
+WHERE
+	tbl_orders.customer_id = @customer_id
+	AND tbl_orders.status = 'DELIVERED'
+	AND tbl_order_lines.product_id = @product_id
+ORDER BY tbl_orders.updated_at DESC
+LIMIT 1;
+
+-- name: GetProductReviewByCustomerAndProduct :one
+SELECT *
+FROM tbl_product_reviews
+WHERE customer_id = ? AND product_id = ?
+LIMIT 1;
+
+-- name: GetProductReviewByID :one
+SELECT *
+FROM tbl_product_reviews
+WHERE id = ?
+LIMIT 1;
+
+-- name: CreateProductReview :one
+INSERT INTO tbl_product_reviews (
+	product_id,
+	customer_id,
+	order_id,
+	rating,
+	title,
+	body,
+	status,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, 'PENDING', datetime('now'), datetime('now')
+) RETURNING id;
+
+-- name: CreateProductReviewPhoto :one
+INSERT INTO tbl_product_review_photos (
+	review_id,
+	path,
+	url,
+	created_at
+) VALUES (
+	?, ?, ?, datetime('now')
+) RETURNING id;
+
+-- name: GetProductReviewPhotosByReviewIDs :many
+SELECT
+	id,
+	review_id,
+	url
+FROM tbl_product_review_photos
+WHERE review_id IN (sqlc.slice('review_ids'))
+ORDER BY id ASC;
+
+-- name: GetApprovedProductReviewsByProductID :many
+SELECT
+	tbl_product_reviews.id,
+	tbl_product_reviews.rating,
+	tbl_product_reviews.title,
+	tbl_product_reviews.body,
+	tbl_product_reviews.created_at,
+	tbl_customers.first_name AS customer_first_name,
+	tbl_customers.last_name AS customer_last_name
+FROM tbl_product_reviews
+INNER JOIN tbl_customers ON tbl_customers.id = tbl_product_reviews.customer_id
+WHERE
+	tbl_product_reviews.product_id = @product_id
+	AND tbl_product_reviews.status = 'APPROVED'
+ORDER BY tbl_product_reviews.created_at DESC
+LIMIT @limit;
+
+-- name: AdminCountProductReviewsByStatus :one
+SELECT COUNT(*) AS count
+FROM tbl_product_reviews
+WHERE status = @status;
+
+-- name: AdminGetProductReviewsByStatusPaginated :many
+SELECT
+	tbl_product_reviews.id,
+	tbl_product_reviews.rating,
+	tbl_product_reviews.title,
+	tbl_product_reviews.body,
+	tbl_product_reviews.status,
+	tbl_product_reviews.rejection_reason,
+	tbl_product_reviews.created_at,
+	tbl_products.name AS product_name,
+	tbl_products.serial AS product_serial,
+	tbl_customers.first_name AS customer_first_name,
+	tbl_customers.last_name AS customer_last_name,
+	tbl_customers.email AS customer_email
+FROM tbl_product_reviews
+INNER JOIN tbl_products ON tbl_products.id = tbl_product_reviews.product_id
+INNER JOIN tbl_customers ON tbl_customers.id = tbl_product_reviews.customer_id
+WHERE tbl_product_reviews.status = @status
+ORDER BY tbl_product_reviews.created_at ASC
+LIMIT @limit OFFSET @offset;
+
+-- name: ModerateProductReview :one
+UPDATE tbl_product_reviews
+SET
+	status = @status,
+	rejection_reason = @rejection_reason,
+	moderated_by = @moderated_by,
+	moderated_at = datetime('now'),
+	updated_at = datetime('now')
+WHERE id = @id AND status = 'PENDING'
+RETURNING product_id;
+
+-- name: RefreshProductRating :exec
+INSERT INTO tbl_product_ratings (
+	product_id,
+	rating_count,
+	rating_total,
+	updated_at
+)
+SELECT
+	@product_id,
+	COUNT(tbl_product_reviews.id),
+	COALESCE(SUM(tbl_product_reviews.rating), 0),
+	datetime('now')
+FROM tbl_product_reviews
+WHERE
+	tbl_product_reviews.product_id = @product_id
+	AND tbl_product_reviews.status = 'APPROVED'
+ON CONFLICT(product_id) DO UPDATE SET
+	rating_count = excluded.rating_count,
+	rating_total = excluded.rating_total,
+	updated_at = excluded.updated_at;
+
+-- name: GetProductRatingByProductID :one
+SELECT
+	rating_count,
+	rating_total
+FROM tbl_product_ratings
+WHERE product_id = ?
+LIMIT 1;
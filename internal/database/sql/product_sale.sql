@@ -48,3 +48,18 @@ SET
 	updated_at = datetime('now')
 WHERE product_id = ? AND is_active = 1;
 
+
+-- name: GetProductSalesDueForNotification :many
+SELECT id, product_id
+FROM tbl_product_sales
+WHERE
+	is_active = 1
+	AND notified_at IS NULL
+	AND datetime('now') BETWEEN starts_at AND ends_at;
+
+-- ClaimProductSaleNotification marks the sale notified and affects no row when
+-- another run got there first.
+-- name: ClaimProductSaleNotification :execrows
+UPDATE tbl_product_sales
+SET notified_at = datetime('now')
+WHERE id = ? AND notified_at IS NULL;
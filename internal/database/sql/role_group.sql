@@ -0,0 +1,41 @@
+-- name: GetRoleGroups :many
+SELECT * FROM tbl_role_groups ORDER BY name;
+
+-- name: GetRoleGroupByID :one
+SELECT * FROM tbl_role_groups WHERE id = ?;
+
+-- name: CreateRoleGroup :one
+INSERT INTO tbl_role_groups (name, description, created_at, updated_at)
+VALUES (?, ?, DATETIME('now'), DATETIME('now'))
+RETURNING id;
+
+-- name: DeleteRoleGroup :execrows
+DELETE FROM tbl_role_groups WHERE id = ?;
+
+-- name: GetRoleGroupPermissions :many
+SELECT role_group_id, role, access FROM tbl_role_group_permissions ORDER BY role_group_id, role;
+
+-- name: UpsertRoleGroupPermission :exec
+INSERT INTO tbl_role_group_permissions (role_group_id, role, access, created_at, updated_at)
+VALUES (?, ?, ?, DATETIME('now'), DATETIME('now'))
+ON CONFLICT (role_group_id, role) DO UPDATE SET access = excluded.access, updated_at = DATETIME('now');
+
+-- name: DeleteRoleGroupPermission :execrows
+DELETE FROM tbl_role_group_permissions WHERE role_group_id = ? AND role = ?;
+
+-- name: DeleteRoleGroupPermissionsByGroupID :exec
+DELETE FROM tbl_role_group_permissions WHERE role_group_id = ?;
+
+-- name: GetStaffRoleGroups :many
+SELECT staff_id, role_group_id FROM tbl_staff_role_groups ORDER BY staff_id, role_group_id;
+
+-- name: AssignStaffRoleGroup :exec
+INSERT INTO tbl_staff_role_groups (staff_id, role_group_id, created_at)
+VALUES (?, ?, DATETIME('now'))
+ON CONFLICT (staff_id, role_group_id) DO NOTHING;
+
+-- name: UnassignStaffRoleGroup :execrows
+DELETE FROM tbl_staff_role_groups WHERE staff_id = ? AND role_group_id = ?;
+
+-- name: DeleteStaffRoleGroupsByGroupID :exec
+DELETE FROM tbl_staff_role_groups WHERE role_group_id = ?;
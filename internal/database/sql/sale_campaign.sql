@@ -0,0 +1,201 @@
+-- name: CreateSaleCampaign :one
+INSERT INTO tbl_sale_campaigns (
+	name,
+	selector,
+	selector_value,
+	discount_type,
+	discount_value,
+	starts_at,
+	ends_at,
+	status,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?, 'SCHEDULED',
+	DATETIME('now'),
+	DATETIME('now')
+) RETURNING id;
+
+-- name: AddSaleCampaignProduct :exec
+INSERT INTO tbl_sale_campaign_products (sale_campaign_id, product_id)
+VALUES (?, ?)
+ON CONFLICT (sale_campaign_id, product_id) DO NOTHING;
+
+-- name: GetSaleCampaignByID :one
+SELECT *
+FROM tbl_sale_campaigns
+WHERE id = ?
+LIMIT 1;
+
+-- name: GetSaleCampaigns :many
+SELECT
+	tbl_sale_campaigns.*,
+	(
+		SELECT COUNT(*)
+		FROM tbl_sale_campaign_products
+		WHERE tbl_sale_campaign_products.sale_campaign_id = tbl_sale_campaigns.id
+	) AS product_count
+FROM tbl_sale_campaigns
+ORDER BY tbl_sale_campaigns.starts_at DESC, tbl_sale_campaigns.id DESC;
+
+-- name: GetSaleCampaignsDueForActivation :many
+SELECT id
+FROM tbl_sale_campaigns
+WHERE status = 'SCHEDULED' AND starts_at <= @now AND ends_at > @now;
+
+-- name: GetSaleCampaignsDueForEnding :many
+SELECT id
+FROM tbl_sale_campaigns
+WHERE (status = 'ACTIVE' OR status = 'SCHEDULED') AND ends_at <= @now;
+
+-- name: UpdateSaleCampaignStatus :exec
+UPDATE tbl_sale_campaigns
+SET
+	status = ?,
+	updated_at = DATETIME('now')
+WHERE id = ?;
+
+-- name: GetSaleCampaignProducts :many
+SELECT
+	tbl_products.id,
+	tbl_products.serial,
+	tbl_products.name,
+	tbl_products.unit_price_without_vat,
+	tbl_products.unit_price_with_vat,
+	tbl_products.unit_price_without_vat_currency,
+	tbl_products.unit_price_with_vat_currency,
+	EXISTS (
+		SELECT 1
+		FROM tbl_product_sales
+		WHERE tbl_product_sales.product_id = tbl_products.id
+			AND tbl_product_sales.is_active = 1
+			AND COALESCE(tbl_product_sales.sale_campaign_id, 0) != tbl_sale_campaign_products.sale_campaign_id
+	) AS has_other_sale
+FROM tbl_sale_campaign_products
+INNER JOIN tbl_products ON tbl_products.id = tbl_sale_campaign_products.product_id
+WHERE tbl_sale_campaign_products.sale_campaign_id = ?
+ORDER BY tbl_products.name ASC;
+
+-- name: GetSaleCampaignCandidatesByBrandID :many
+SELECT
+	tbl_products.id,
+	tbl_products.serial,
+	tbl_products.name,
+	tbl_products.unit_price_without_vat,
+	tbl_products.unit_price_with_vat,
+	tbl_products.unit_price_without_vat_currency,
+	tbl_products.unit_price_with_vat_currency,
+	EXISTS (
+		SELECT 1
+		FROM tbl_product_sales
+		WHERE tbl_product_sales.product_id = tbl_products.id AND tbl_product_sales.is_active = 1
+	) AS has_other_sale
+FROM tbl_products
+WHERE tbl_products.status = 'ACTIVE' AND tbl_products.brand_id = ?
+ORDER BY tbl_products.name ASC;
+
+-- name: GetSaleCampaignCandidatesByCategory :many
+SELECT DISTINCT
+	tbl_products.id,
+	tbl_products.serial,
+	tbl_products.name,
+	tbl_products.unit_price_without_vat,
+	tbl_products.unit_price_with_vat,
+	tbl_products.unit_price_without_vat_currency,
+	tbl_products.unit_price_with_vat_currency,
+	EXISTS (
+		SELECT 1
+		FROM tbl_product_sales
+		WHERE tbl_product_sales.product_id = tbl_products.id AND tbl_product_sales.is_active = 1
+	) AS has_other_sale
+FROM tbl_products
+INNER JOIN tbl_products_categories ON tbl_products_categories.product_id = tbl_products.id
+INNER JOIN tbl_product_categories ON tbl_product_categories.id = tbl_products_categories.category_id
+WHERE tbl_products.status = 'ACTIVE' AND LOWER(tbl_product_categories.category) = LOWER(@category)
+ORDER BY tbl_products.name ASC;
+
+-- name: GetSaleCampaignCandidatesBySerials :many
+SELECT
+	tbl_products.id,
+	tbl_products.serial,
+	tbl_products.name,
+	tbl_products.unit_price_without_vat,
+	tbl_products.unit_price_with_vat,
+	tbl_products.unit_price_without_vat_currency,
+	tbl_products.unit_price_with_vat_currency,
+	EXISTS (
+		SELECT 1
+		FROM tbl_product_sales
+		WHERE tbl_product_sales.product_id = tbl_products.id AND tbl_product_sales.is_active = 1
+	) AS has_other_sale
+FROM tbl_products
+WHERE tbl_products.status = 'ACTIVE' AND tbl_products.serial IN (sqlc.slice('serials'))
+ORDER BY tbl_products.name ASC;
+
+-- name: CreateSaleCampaignProductSale :exec
+INSERT INTO tbl_product_sales (
+	product_id,
+	sale_price_without_vat,
+	sale_price_with_vat,
+	sale_price_without_vat_currency,
+	sale_price_with_vat_currency,
+	discount_type,
+	discount_value,
+	starts_at,
+	ends_at,
+	is_active,
+	sale_campaign_id,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?,
+	?, ?, ?, ?,
+	1,
+	?,
+	DATETIME('now'),
+	DATETIME('now')
+);
+
+-- name: DeactivateProductSalesBySaleCampaignID :exec
+UPDATE tbl_product_sales
+SET
+	is_active = 0,
+	updated_at = DATETIME('now')
+WHERE sale_campaign_id = ? AND is_active = 1;
+
+-- name: GetSaleCampaignReport :many
+SELECT
+	tbl_products.id,
+	tbl_products.serial,
+	tbl_products.name,
+	CAST(COALESCE(SUM(tbl_order_lines.quantity), 0) AS INTEGER) AS units_sold,
+	CAST(COALESCE(SUM(tbl_order_lines.total_price), 0) AS INTEGER) AS revenue
+FROM tbl_sale_campaign_products
+INNER JOIN tbl_sale_campaigns ON tbl_sale_campaigns.id = tbl_sale_campaign_products.sale_campaign_id
+INNER JOIN tbl_products ON tbl_products.id = tbl_sale_campaign_products.product_id
+LEFT JOIN tbl_order_lines ON tbl_order_lines.product_id = tbl_products.id
+	AND EXISTS (
+		SELECT 1
+		FROM tbl_orders
+		WHERE tbl_orders.id = tbl_order_lines.order_id
+			AND tbl_orders.status NOT IN ('CANCELLED', 'REFUNDED')
+			-- A cancelled campaign stopped selling when it was cancelled, which is
+			-- the last time its row was updated.
+			AND tbl_orders.created_at BETWEEN tbl_sale_campaigns.starts_at AND (
+				CASE
+					WHEN tbl_sale_campaigns.status = 'CANCELLED' AND tbl_sale_campaigns.updated_at < tbl_sale_campaigns.ends_at
+						THEN tbl_sale_campaigns.updated_at
+					ELSE tbl_sale_campaigns.ends_at
+				END
+			)
+	)
+WHERE tbl_sale_campaign_products.sale_campaign_id = ?
+	-- Products skipped for another sale never got this campaign's price.
+	AND EXISTS (
+		SELECT 1
+		FROM tbl_product_sales
+		WHERE tbl_product_sales.product_id = tbl_products.id
+			AND tbl_product_sales.sale_campaign_id = tbl_sale_campaigns.id
+	)
+GROUP BY tbl_products.id
+ORDER BY revenue DESC, tbl_products.name ASC;
@@ -0,0 +1,61 @@
+-- name: CreateSearchRule :one
+INSERT INTO tbl_search_rules (
+	search_term,
+	kind,
+	product_id,
+	category_id,
+	brand_id,
+	position,
+	created_by,
+	created_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?, DATETIME('now')
+) RETURNING id;
+
+-- name: GetSearchRules :many
+SELECT
+	r.id,
+	r.search_term,
+	r.kind,
+	r.position,
+	r.created_at,
+	CAST(COALESCE(p.serial || ' - ' || p.name, '') AS TEXT) AS product_label,
+	CAST(COALESCE(c.category, '') AS TEXT) AS category,
+	CAST(COALESCE(c.subcategory, '') AS TEXT) AS subcategory,
+	CAST(COALESCE(b.name, '') AS TEXT) AS brand_name,
+	CAST(COALESCE(s.first_name || ' ' || s.last_name, '') AS TEXT) AS created_by_name
+FROM tbl_search_rules r
+LEFT JOIN tbl_products p ON p.id = r.product_id
+LEFT JOIN tbl_product_categories c ON c.id = r.category_id
+LEFT JOIN tbl_brands b ON b.id = r.brand_id
+LEFT JOIN tbl_staffs s ON s.id = r.created_by
+ORDER BY r.search_term ASC, r.kind ASC, r.position ASC, r.id ASC;
+
+-- name: GetSearchRuleByID :one
+SELECT id, search_term, kind
+FROM tbl_search_rules
+WHERE id = ?;
+
+-- name: DeleteSearchRule :execrows
+DELETE FROM tbl_search_rules
+WHERE id = ?;
+
+-- name: GetSearchRedirectCategory :one
+SELECT
+	CAST(COALESCE(c.category, '') AS TEXT) AS category,
+	CAST(COALESCE(c.subcategory, '') AS TEXT) AS subcategory
+FROM tbl_search_rules r
+INNER JOIN tbl_product_categories c ON c.id = r.category_id
+WHERE r.search_term = ?
+AND r.kind = 'REDIRECT_CATEGORY'
+LIMIT 1;
+
+-- name: GetSearchRuleCategoryOptions :many
+SELECT
+	id,
+	CAST(COALESCE(category, '') AS TEXT) AS category,
+	CAST(COALESCE(subcategory, '') AS TEXT) AS subcategory
+FROM tbl_product_categories
+WHERE category IS NOT NULL
+AND category != ''
+ORDER BY category ASC, subcategory ASC;
@@ -0,0 +1,69 @@
+-- name: CreateSession :one
+INSERT INTO tbl_sessions (user_type, user_id, key_hash, useragent_id, ip_address, created_at, last_seen_at)
+VALUES (?, ?, ?, ?, ?, DATETIME('now'), DATETIME('now'))
+RETURNING id;
+
+-- name: GetSessionByKeyHash :one
+SELECT * FROM tbl_sessions WHERE key_hash = ?;
+
+-- Only writes once a minute per session so every request does not hit the
+-- write connection.
+-- name: TouchSession :exec
+UPDATE tbl_sessions
+SET last_seen_at = DATETIME('now'), ip_address = @ip_address
+WHERE id = @id AND revoked_at IS NULL AND last_seen_at < DATETIME('now', '-1 minutes');
+
+-- name: GetActiveSessionsByUser :many
+SELECT
+	s.id,
+	s.key_hash,
+	s.ip_address,
+	s.created_at,
+	s.last_seen_at,
+	COALESCE(ua.browser, '') AS browser,
+	COALESCE(ua.os, '') AS os,
+	COALESCE(ua.device, '') AS device
+FROM tbl_sessions s
+LEFT JOIN tbl_useragents ua ON ua.id = s.useragent_id
+WHERE s.user_type = ? AND s.user_id = ? AND s.revoked_at IS NULL
+ORDER BY s.last_seen_at DESC;
+
+-- name: GetAllActiveSessions :many
+SELECT
+	s.id,
+	s.user_type,
+	s.user_id,
+	s.ip_address,
+	s.created_at,
+	s.last_seen_at,
+	COALESCE(ua.browser, '') AS browser,
+	COALESCE(ua.os, '') AS os,
+	COALESCE(ua.device, '') AS device,
+	CAST(COALESCE(st.first_name || ' ' || st.last_name, c.first_name || ' ' || c.last_name, '') AS TEXT) AS full_name
+FROM tbl_sessions s
+LEFT JOIN tbl_useragents ua ON ua.id = s.useragent_id
+LEFT JOIN tbl_staffs st ON s.user_type = 'STAFF' AND st.id = s.user_id
+LEFT JOIN tbl_customers c ON s.user_type = 'CUSTOMER' AND c.id = s.user_id
+WHERE s.revoked_at IS NULL
+ORDER BY s.last_seen_at DESC
+LIMIT ?;
+
+-- name: RevokeSession :execrows
+UPDATE tbl_sessions
+SET revoked_at = DATETIME('now'), revoked_reason = @reason
+WHERE id = @id AND revoked_at IS NULL;
+
+-- name: RevokeSessionByKeyHash :exec
+UPDATE tbl_sessions
+SET revoked_at = DATETIME('now'), revoked_reason = @reason
+WHERE key_hash = @key_hash AND revoked_at IS NULL;
+
+-- name: RevokeUserSessions :execrows
+UPDATE tbl_sessions
+SET revoked_at = DATETIME('now'), revoked_reason = @reason
+WHERE user_type = @user_type AND user_id = @user_id AND revoked_at IS NULL;
+
+-- name: RevokeOtherUserSessions :execrows
+UPDATE tbl_sessions
+SET revoked_at = DATETIME('now'), revoked_reason = @reason
+WHERE user_type = @user_type AND user_id = @user_id AND key_hash != @key_hash AND revoked_at IS NULL;
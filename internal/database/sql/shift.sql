@@ -0,0 +1,220 @@
+-- name: GetShiftStaffs :many
+SELECT
+	tbl_staffs.id,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position,
+	tbl_staffs.time_in_schedule,
+	tbl_staffs.time_out_schedule,
+	tbl_staff_shift_rotations.cycle_weeks,
+	(
+		SELECT COUNT(*)
+		FROM tbl_staff_shift_days
+		WHERE tbl_staff_shift_days.staff_id = tbl_staffs.id
+	) AS template_days
+FROM tbl_staffs
+LEFT JOIN tbl_staff_shift_rotations ON tbl_staff_shift_rotations.staff_id = tbl_staffs.id
+WHERE
+	tbl_staffs.deleted_at = '1970-01-01 00:00:00+00:00'
+	AND tbl_staffs.status != 'RESIGNED'
+ORDER BY tbl_staffs.last_name ASC, tbl_staffs.first_name ASC;
+
+-- name: GetShiftStaffByID :one
+SELECT
+	tbl_staffs.id,
+	tbl_staffs.first_name,
+	tbl_staffs.middle_name,
+	tbl_staffs.last_name,
+	tbl_staffs.position,
+	tbl_staffs.time_in_schedule,
+	tbl_staffs.time_out_schedule
+FROM tbl_staffs
+WHERE
+	tbl_staffs.id = ?
+	AND tbl_staffs.deleted_at = '1970-01-01 00:00:00+00:00'
+LIMIT 1;
+
+-- name: GetStaffShiftDefaults :many
+SELECT
+	id,
+	time_in_schedule,
+	time_out_schedule
+FROM tbl_staffs
+WHERE deleted_at = '1970-01-01 00:00:00+00:00';
+
+-- name: UpsertStaffShiftRotation :exec
+INSERT INTO tbl_staff_shift_rotations (
+	staff_id,
+	cycle_weeks,
+	anchor_date,
+	updated_by,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?,
+	DATETIME('now'),
+	DATETIME('now')
+)
+ON CONFLICT (staff_id) DO UPDATE SET
+	cycle_weeks = excluded.cycle_weeks,
+	anchor_date = excluded.anchor_date,
+	updated_by = excluded.updated_by,
+	updated_at = DATETIME('now');
+
+-- name: GetStaffShiftRotation :one
+SELECT *
+FROM tbl_staff_shift_rotations
+WHERE staff_id = ?
+LIMIT 1;
+
+-- name: GetStaffShiftRotations :many
+SELECT *
+FROM tbl_staff_shift_rotations;
+
+-- name: UpsertStaffShiftDay :exec
+INSERT INTO tbl_staff_shift_days (
+	staff_id,
+	week_index,
+	weekday,
+	time_in,
+	time_out,
+	rest_day,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, ?,
+	DATETIME('now'),
+	DATETIME('now')
+)
+ON CONFLICT (staff_id, week_index, weekday) DO UPDATE SET
+	time_in = excluded.time_in,
+	time_out = excluded.time_out,
+	rest_day = excluded.rest_day,
+	updated_at = DATETIME('now');
+
+-- name: GetStaffShiftDays :many
+SELECT *
+FROM tbl_staff_shift_days
+WHERE staff_id = ?
+ORDER BY week_index ASC, weekday ASC;
+
+-- name: GetAllStaffShiftDays :many
+SELECT *
+FROM tbl_staff_shift_days;
+
+-- name: DeleteStaffShiftDaysFromWeek :exec
+DELETE FROM tbl_staff_shift_days
+WHERE staff_id = ? AND week_index >= ?;
+
+-- name: DeleteStaffShiftDays :exec
+DELETE FROM tbl_staff_shift_days
+WHERE staff_id = ?;
+
+-- name: DeleteStaffShiftRotation :exec
+DELETE FROM tbl_staff_shift_rotations
+WHERE staff_id = ?;
+
+-- name: UpsertStaffShiftOverride :exec
+INSERT INTO tbl_staff_shift_overrides (
+	staff_id,
+	for_date,
+	time_in,
+	time_out,
+	rest_day,
+	note,
+	shift_swap_id,
+	created_by,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?, ?,
+	DATETIME('now'),
+	DATETIME('now')
+)
+ON CONFLICT (staff_id, for_date) DO UPDATE SET
+	time_in = excluded.time_in,
+	time_out = excluded.time_out,
+	rest_day = excluded.rest_day,
+	note = excluded.note,
+	shift_swap_id = excluded.shift_swap_id,
+	created_by = excluded.created_by,
+	updated_at = DATETIME('now');
+
+-- name: DeleteStaffShiftOverride :execrows
+DELETE FROM tbl_staff_shift_overrides
+WHERE id = ? AND staff_id = ?;
+
+-- name: GetStaffShiftOverridesByDateRange :many
+SELECT *
+FROM tbl_staff_shift_overrides
+WHERE
+	staff_id = @staff_id
+	AND for_date >= @start_date
+	AND for_date <= @end_date
+ORDER BY for_date ASC;
+
+-- name: GetAllStaffShiftOverridesByDateRange :many
+SELECT *
+FROM tbl_staff_shift_overrides
+WHERE
+	for_date >= @start_date
+	AND for_date <= @end_date;
+
+-- name: CreateStaffShiftSwap :one
+INSERT INTO tbl_staff_shift_swaps (
+	requester_id,
+	counterpart_id,
+	for_date,
+	reason,
+	status,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, ?, ?, 'PENDING',
+	DATETIME('now'),
+	DATETIME('now')
+) RETURNING id;
+
+-- name: GetStaffShiftSwapByID :one
+SELECT *
+FROM tbl_staff_shift_swaps
+WHERE id = ?
+LIMIT 1;
+
+-- name: GetPendingStaffShiftSwapForDate :one
+SELECT id
+FROM tbl_staff_shift_swaps
+WHERE
+	status = 'PENDING'
+	AND for_date = @for_date
+	AND (requester_id IN (@requester_id, @counterpart_id) OR counterpart_id IN (@requester_id, @counterpart_id))
+LIMIT 1;
+
+-- name: DecideStaffShiftSwap :execrows
+UPDATE tbl_staff_shift_swaps
+SET
+	status = ?,
+	decided_by = ?,
+	decided_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE id = ? AND status = 'PENDING';
+
+-- name: GetStaffShiftSwaps :many
+SELECT
+	tbl_staff_shift_swaps.*,
+	requester.first_name AS requester_first_name,
+	requester.middle_name AS requester_middle_name,
+	requester.last_name AS requester_last_name,
+	counterpart.first_name AS counterpart_first_name,
+	counterpart.middle_name AS counterpart_middle_name,
+	counterpart.last_name AS counterpart_last_name
+FROM tbl_staff_shift_swaps
+INNER JOIN tbl_staffs requester ON requester.id = tbl_staff_shift_swaps.requester_id
+INNER JOIN tbl_staffs counterpart ON counterpart.id = tbl_staff_shift_swaps.counterpart_id
+WHERE
+	tbl_staff_shift_swaps.requester_id = @staff_id
+	OR tbl_staff_shift_swaps.counterpart_id = @staff_id
+	OR @staff_id = 0
+ORDER BY tbl_staff_shift_swaps.created_at DESC, tbl_staff_shift_swaps.id DESC
+LIMIT @limit;
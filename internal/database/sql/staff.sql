@@ -524,3 +524,36 @@ SET
 WHERE
     id = sqlc.arg('id')
 RETURNING id;
+
+-- name: GetStaffTimeOffByID :one
+SELECT
+    id,
+    type,
+    start_date,
+    end_date,
+    staff_id,
+    approved
+FROM tbl_staff_time_offs
+WHERE id = ?
+LIMIT 1;
+
+-- name: CorrectStaffAttendance :execrows
+UPDATE tbl_staff_attendances
+SET
+    time_in = COALESCE(sqlc.narg('time_in'), time_in),
+    time_out = COALESCE(sqlc.narg('time_out'), time_out),
+    lunch_break_in = COALESCE(sqlc.narg('lunch_break_in'), lunch_break_in),
+    lunch_break_out = COALESCE(sqlc.narg('lunch_break_out'), lunch_break_out),
+    updated_at = datetime('now')
+WHERE
+    staff_id = sqlc.arg('staff_id')
+    AND for_date = sqlc.arg('for_date');
+
+-- name: GetActiveSuperuserEmails :many
+SELECT id, email
+FROM tbl_staffs
+WHERE user_type = 'SUPERUSER'
+AND email != ''
+AND deleted_at = '1970-01-01 00:00:00+00:00'
+AND status != 'RESIGNED'
+ORDER BY id ASC;
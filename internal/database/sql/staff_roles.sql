@@ -1,8 +1,29 @@
 -- name: GetStaffRolesByStaffID :many
 SELECT role FROM tbl_staff_roles WHERE staff_id = ?;
 
+-- name: GetStaffDirectPermissionsByStaffID :many
+SELECT role, access FROM tbl_staff_roles WHERE staff_id = ?;
+
+-- name: GetStaffPermissionsByStaffID :many
+SELECT role, access FROM tbl_staff_roles WHERE tbl_staff_roles.staff_id = @staff_id
+UNION ALL
+SELECT tbl_role_group_permissions.role, tbl_role_group_permissions.access
+FROM tbl_role_group_permissions
+JOIN tbl_staff_role_groups ON tbl_staff_role_groups.role_group_id = tbl_role_group_permissions.role_group_id
+WHERE tbl_staff_role_groups.staff_id = @staff_id;
+
+-- name: GetAllStaffPermissions :many
+SELECT staff_id, role, access FROM tbl_staff_roles
+UNION ALL
+SELECT tbl_staff_role_groups.staff_id, tbl_role_group_permissions.role, tbl_role_group_permissions.access
+FROM tbl_role_group_permissions
+JOIN tbl_staff_role_groups ON tbl_staff_role_groups.role_group_id = tbl_role_group_permissions.role_group_id;
+
 -- name: CreateStaffRole :one
-INSERT INTO tbl_staff_roles (staff_id, role, created_at, updated_at) VALUES (?, ?, datetime('now'), datetime('now')) RETURNING id;
+INSERT INTO tbl_staff_roles (staff_id, role, access, created_at, updated_at) VALUES (?, ?, ?, datetime('now'), datetime('now')) RETURNING id;
+
+-- name: UpdateStaffRoleAccess :execrows
+UPDATE tbl_staff_roles SET access = ?, updated_at = datetime('now') WHERE staff_id = ? AND role = ?;
 
 -- name: DeleteStaffRole :one
 DELETE FROM tbl_staff_roles WHERE staff_id = ? AND role = ? RETURNING id;
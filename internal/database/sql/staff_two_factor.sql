@@ -0,0 +1,96 @@
+-- name: GetStaffTwoFactor :one
+SELECT * FROM tbl_staff_two_factor WHERE staff_id = ?;
+
+-- name: UpsertPendingStaffTwoFactor :exec
+INSERT INTO tbl_staff_two_factor (staff_id, secret, created_at, updated_at)
+VALUES (?, ?, DATETIME('now'), DATETIME('now'))
+ON CONFLICT (staff_id) DO UPDATE SET
+	secret = excluded.secret,
+	last_used_step = 0,
+	updated_at = DATETIME('now')
+WHERE tbl_staff_two_factor.enabled_at IS NULL;
+
+-- name: EnableStaffTwoFactor :execrows
+UPDATE tbl_staff_two_factor
+SET enabled_at = DATETIME('now'), last_used_step = @step, updated_at = DATETIME('now')
+WHERE staff_id = @staff_id AND enabled_at IS NULL;
+
+-- name: UseStaffTwoFactorStep :execrows
+UPDATE tbl_staff_two_factor
+SET last_used_step = @step, updated_at = DATETIME('now')
+WHERE staff_id = @staff_id AND last_used_step < @step;
+
+-- name: DeleteStaffTwoFactor :exec
+DELETE FROM tbl_staff_two_factor WHERE staff_id = ?;
+
+-- name: CreateStaffRecoveryCode :exec
+INSERT INTO tbl_staff_recovery_codes (staff_id, code_hash, created_at)
+VALUES (?, ?, DATETIME('now'));
+
+-- name: UseStaffRecoveryCode :execrows
+UPDATE tbl_staff_recovery_codes
+SET used_at = DATETIME('now')
+WHERE staff_id = ? AND code_hash = ? AND used_at IS NULL;
+
+-- name: CountUnusedStaffRecoveryCodes :one
+SELECT COUNT(*) FROM tbl_staff_recovery_codes WHERE staff_id = ? AND used_at IS NULL;
+
+-- name: DeleteStaffRecoveryCodes :exec
+DELETE FROM tbl_staff_recovery_codes WHERE staff_id = ?;
+
+-- name: CreateStaffOTPCode :exec
+INSERT INTO tbl_staff_otp_codes (staff_id, otp_code, expires_at, created_at)
+VALUES (?, ?, DATETIME('now', '+5 minutes'), DATETIME('now'));
+
+-- name: CountRecentStaffOTPCodes :one
+SELECT COUNT(*) FROM tbl_staff_otp_codes
+WHERE staff_id = ? AND created_at > DATETIME('now', '-1 minutes');
+
+-- name: UseStaffOTPCode :execrows
+UPDATE tbl_staff_otp_codes
+SET used_at = DATETIME('now')
+WHERE staff_id = ? AND otp_code = ? AND used_at IS NULL AND expires_at > DATETIME('now');
+
+-- name: DeleteStaffOTPCodes :exec
+DELETE FROM tbl_staff_otp_codes WHERE staff_id = ?;
+
+-- name: CreateStaffTrustedDevice :exec
+INSERT INTO tbl_staff_trusted_devices (staff_id, token_hash, useragent_id, expires_at, created_at)
+VALUES (?, ?, ?, DATETIME('now', '+30 days'), DATETIME('now'));
+
+-- name: GetStaffTrustedDevice :one
+SELECT * FROM tbl_staff_trusted_devices
+WHERE token_hash = ? AND staff_id = ? AND expires_at > DATETIME('now');
+
+-- name: TouchStaffTrustedDevice :exec
+UPDATE tbl_staff_trusted_devices SET last_used_at = DATETIME('now') WHERE id = ?;
+
+-- name: CountStaffTrustedDevices :one
+SELECT COUNT(*) FROM tbl_staff_trusted_devices WHERE staff_id = ? AND expires_at > DATETIME('now');
+
+-- name: DeleteStaffTrustedDevices :exec
+DELETE FROM tbl_staff_trusted_devices WHERE staff_id = ?;
+
+-- name: CountStaffTwoFactorLocks :one
+SELECT COUNT(*) FROM tbl_staff_two_factor_failures
+WHERE staff_id = ? AND locked_until > DATETIME('now');
+
+-- name: RecordStaffTwoFactorFailure :one
+INSERT INTO tbl_staff_two_factor_failures (staff_id, attempts, updated_at)
+VALUES (?, 1, DATETIME('now'))
+ON CONFLICT (staff_id) DO UPDATE SET
+	attempts = CASE
+		WHEN tbl_staff_two_factor_failures.updated_at > DATETIME('now', '-15 minutes')
+			THEN tbl_staff_two_factor_failures.attempts + 1
+		ELSE 1
+	END,
+	updated_at = DATETIME('now')
+RETURNING attempts;
+
+-- name: LockStaffTwoFactor :exec
+UPDATE tbl_staff_two_factor_failures
+SET attempts = 0, locked_until = DATETIME('now', '+15 minutes'), updated_at = DATETIME('now')
+WHERE staff_id = ?;
+
+-- name: DeleteStaffTwoFactorFailures :exec
+DELETE FROM tbl_staff_two_factor_failures WHERE staff_id = ?;
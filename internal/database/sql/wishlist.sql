@@ -0,0 +1,109 @@
+-- name: AddWishlistItem :exec
+INSERT INTO tbl_wishlists (
+	customer_id,
+	product_id,
+	created_at
+) VALUES (
+	?, ?, DATETIME('now')
+) ON CONFLICT (customer_id, product_id) DO NOTHING;
+
+-- name: RemoveWishlistItem :exec
+DELETE FROM tbl_wishlists
+WHERE customer_id = ? AND product_id = ?;
+
+-- name: IsProductWishlisted :one
+SELECT EXISTS(
+	SELECT 1 FROM tbl_wishlists
+	WHERE customer_id = ? AND product_id = ?
+) AS is_wishlisted;
+
+-- name: GetWishlistByCustomerID :many
+SELECT
+	tbl_wishlists.product_id,
+	tbl_wishlists.created_at,
+	tbl_products.name,
+	tbl_products.serial,
+	COALESCE(tbl_products.slug, '') AS slug,
+	tbl_products.unit_price_with_vat,
+	tbl_products.unit_price_with_vat_currency,
+	COALESCE(tbl_product_images.cdn_url_thumbnail, '') AS cdn_url_thumbnail,
+	COALESCE(tbl_product_inventories.stocks, 0) AS stocks
+FROM tbl_wishlists
+INNER JOIN tbl_products ON tbl_products.id = tbl_wishlists.product_id
+LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
+LEFT JOIN tbl_product_inventories ON tbl_product_inventories.product_id = tbl_products.id
+WHERE tbl_wishlists.customer_id = ?
+ORDER BY tbl_wishlists.created_at DESC;
+
+-- name: GetWishlistCustomerEmailsByProductID :many
+SELECT tbl_customers.email
+FROM tbl_wishlists
+INNER JOIN tbl_customers ON tbl_customers.id = tbl_wishlists.customer_id
+WHERE tbl_wishlists.product_id = ?;
+
+-- name: UpsertStockSubscription :exec
+INSERT INTO tbl_stock_subscriptions (
+	product_id,
+	email,
+	status,
+	token_hash,
+	created_at,
+	updated_at
+) VALUES (
+	?, ?, 'PENDING', ?, DATETIME('now'), DATETIME('now')
+) ON CONFLICT (product_id, email) DO UPDATE SET
+	status = 'PENDING',
+	token_hash = excluded.token_hash,
+	confirmed_at = NULL,
+	notified_at = NULL,
+	updated_at = DATETIME('now');
+
+-- name: GetStockSubscriptionByProductAndEmail :one
+SELECT *
+FROM tbl_stock_subscriptions
+WHERE product_id = ? AND email = ?
+LIMIT 1;
+
+-- name: ConfirmStockSubscription :one
+UPDATE tbl_stock_subscriptions
+SET
+	status = 'CONFIRMED',
+	confirmed_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE
+	token_hash = ?
+	AND status = 'PENDING'
+	AND updated_at > DATETIME('now', '-1 day')
+RETURNING product_id;
+
+-- name: GetConfirmedStockSubscriptionsByProductID :many
+SELECT id, email
+FROM tbl_stock_subscriptions
+WHERE product_id = ? AND status = 'CONFIRMED';
+
+-- name: MarkStockSubscriptionNotified :exec
+UPDATE tbl_stock_subscriptions
+SET
+	status = 'NOTIFIED',
+	notified_at = DATETIME('now'),
+	updated_at = DATETIME('now')
+WHERE id = ?;
+
+-- name: GetProductForNotificationEmail :one
+SELECT
+	tbl_products.id,
+	tbl_products.name,
+	tbl_products.serial,
+	COALESCE(tbl_products.slug, '') AS slug,
+	tbl_products.unit_price_with_vat,
+	tbl_products.unit_price_with_vat_currency,
+	COALESCE(tbl_product_images.cdn_url_thumbnail, '') AS cdn_url_thumbnail,
+	COALESCE(tbl_product_sales.sale_price_with_vat, 0) AS sale_price_with_vat,
+	CAST(COALESCE(DATE(tbl_product_sales.ends_at), '') AS TEXT) AS sale_ends_at
+FROM tbl_products
+LEFT JOIN tbl_product_images ON tbl_product_images.product_id = tbl_products.id
+LEFT JOIN tbl_product_sales
+	ON tbl_product_sales.product_id = tbl_products.id
+	AND tbl_product_sales.is_active = 1
+WHERE tbl_products.id = ?
+LIMIT 1;
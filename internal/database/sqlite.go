@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteConnector hands out go-sqlite3 connections that report rejected
+// writes as errs.ErrDBReadOnly, see readOnlyError.
+type sqliteConnector struct {
+	dsn    string
+	driver *sqlite3.SQLiteDriver
+}
+
+func newSQLiteConnector(dsn string) *sqliteConnector {
+	return &sqliteConnector{dsn: dsn, driver: &sqlite3.SQLiteDriver{}}
+}
+
+func (c *sqliteConnector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteConn{SQLiteConn: conn.(*sqlite3.SQLiteConn)}, nil
+}
+
+func (c *sqliteConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+type sqliteConn struct {
+	*sqlite3.SQLiteConn
+}
+
+func (c *sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	res, err := c.SQLiteConn.ExecContext(ctx, query, args)
+	return res, readOnlyError(query, err)
+}
+
+// QueryContext only prepares the statement: SQLite runs it on the first Next,
+// which is where a write on a read-only connection fails.
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.SQLiteConn.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, readOnlyError(query, err)
+	}
+	if sqliteRows, ok := rows.(*sqlite3.SQLiteRows); ok {
+		return &sqliteQueryRows{SQLiteRows: sqliteRows, query: query}, nil
+	}
+	return rows, nil
+}
+
+type sqliteQueryRows struct {
+	*sqlite3.SQLiteRows
+	query string
+}
+
+func (r *sqliteQueryRows) Next(dest []driver.Value) error {
+	return readOnlyError(r.query, r.SQLiteRows.Next(dest))
+}
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cchoice/internal/database/queries"
+	"cchoice/internal/errs"
+	"cchoice/internal/metrics"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	txMaxAttempts  = 5
+	txRetryBackoff = 25 * time.Millisecond
+)
+
+// isBusy reports whether the transaction lost to a concurrent one and can be
+// run again: SQLite's busy and locked errors, and their PostgreSQL
+// counterparts.
+func isBusy(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+	switch postgresErrorCode(err) {
+	case pgSerializationFailed, pgDeadlockDetected, pgLockNotAvailable:
+		return true
+	}
+	return false
+}
+
+// RunInTx runs fn in a write transaction and commits it when fn returns nil.
+// When the database reports it busy or locked, the whole transaction is
+// rolled back and fn runs again after a backoff, so fn must not have side
+// effects outside the transaction.
+func (s *service) RunInTx(ctx context.Context, fn func(tx *sql.Tx, qtx *queries.Queries) error) error {
+	if s.mode != DB_MODE_RW {
+		return errs.ErrDBReadOnly
+	}
+
+	backoff := txRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := s.runTx(ctx, fn)
+		if err == nil || !isBusy(err) {
+			return err
+		}
+		if attempt == txMaxAttempts {
+			return errors.Join(errs.ErrDBBusy, err)
+		}
+
+		metrics.DB.TxRetry()
+		select {
+		case <-ctx.Done():
+			return errors.Join(ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (s *service) runTx(ctx context.Context, fn func(tx *sql.Tx, qtx *queries.Queries) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx, queries.New(instrument(tx, s.mode))); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,44 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=AttendanceCorrectionStatus -trimprefix=ATTENDANCE_CORRECTION_STATUS_
+
+type AttendanceCorrectionStatus int
+
+const (
+	ATTENDANCE_CORRECTION_STATUS_UNDEFINED AttendanceCorrectionStatus = iota
+	ATTENDANCE_CORRECTION_STATUS_PENDING
+	ATTENDANCE_CORRECTION_STATUS_APPROVED
+	ATTENDANCE_CORRECTION_STATUS_REJECTED
+)
+
+var AllAttendanceCorrectionStatuses = []AttendanceCorrectionStatus{
+	ATTENDANCE_CORRECTION_STATUS_PENDING,
+	ATTENDANCE_CORRECTION_STATUS_APPROVED,
+	ATTENDANCE_CORRECTION_STATUS_REJECTED,
+}
+
+func ParseAttendanceCorrectionStatusToEnum(s string) AttendanceCorrectionStatus {
+	switch strings.ToUpper(s) {
+	case ATTENDANCE_CORRECTION_STATUS_PENDING.String():
+		return ATTENDANCE_CORRECTION_STATUS_PENDING
+	case ATTENDANCE_CORRECTION_STATUS_APPROVED.String():
+		return ATTENDANCE_CORRECTION_STATUS_APPROVED
+	case ATTENDANCE_CORRECTION_STATUS_REJECTED.String():
+		return ATTENDANCE_CORRECTION_STATUS_REJECTED
+	default:
+		return ATTENDANCE_CORRECTION_STATUS_UNDEFINED
+	}
+}
+
+func MustParseAttendanceCorrectionStatusToEnum(s string) AttendanceCorrectionStatus {
+	res := ParseAttendanceCorrectionStatusToEnum(s)
+	if res == ATTENDANCE_CORRECTION_STATUS_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected AttendanceCorrectionStatus. Got '%s'", s))
+	}
+	return res
+}
@@ -0,0 +1,27 @@
+// Code generated by "stringer -type=AttendanceCorrectionStatus -trimprefix=ATTENDANCE_CORRECTION_STATUS_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[ATTENDANCE_CORRECTION_STATUS_UNDEFINED-0]
+	_ = x[ATTENDANCE_CORRECTION_STATUS_PENDING-1]
+	_ = x[ATTENDANCE_CORRECTION_STATUS_APPROVED-2]
+	_ = x[ATTENDANCE_CORRECTION_STATUS_REJECTED-3]
+}
+
+const _AttendanceCorrectionStatus_name = "UNDEFINEDPENDINGAPPROVEDREJECTED"
+
+var _AttendanceCorrectionStatus_index = [...]uint8{0, 9, 16, 24, 32}
+
+func (i AttendanceCorrectionStatus) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_AttendanceCorrectionStatus_index)-1 {
+		return "AttendanceCorrectionStatus(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _AttendanceCorrectionStatus_name[_AttendanceCorrectionStatus_index[idx]:_AttendanceCorrectionStatus_index[idx+1]]
+}
@@ -0,0 +1,46 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=DiscountType -trimprefix=DISCOUNT_TYPE_
+
+type DiscountType int
+
+const (
+	DISCOUNT_TYPE_UNDEFINED DiscountType = iota
+	DISCOUNT_TYPE_PERCENTAGE
+	DISCOUNT_TYPE_FIXED
+)
+
+var AllDiscountTypes = []DiscountType{
+	DISCOUNT_TYPE_PERCENTAGE,
+	DISCOUNT_TYPE_FIXED,
+}
+
+func ParseDiscountTypeToEnum(s string) DiscountType {
+	switch strings.ToUpper(s) {
+	case DISCOUNT_TYPE_PERCENTAGE.String():
+		return DISCOUNT_TYPE_PERCENTAGE
+	case DISCOUNT_TYPE_FIXED.String():
+		return DISCOUNT_TYPE_FIXED
+	default:
+		return DISCOUNT_TYPE_UNDEFINED
+	}
+}
+
+func MustParseDiscountTypeToEnum(s string) DiscountType {
+	res := ParseDiscountTypeToEnum(s)
+	if res == DISCOUNT_TYPE_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected DiscountType. Got '%s'", s))
+	}
+	return res
+}
+
+// DBValue matches the lowercase values allowed by the discount_type check
+// constraint of tbl_product_sales and tbl_sale_campaigns.
+func (d DiscountType) DBValue() string {
+	return strings.ToLower(d.String())
+}
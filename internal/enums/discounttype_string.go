@@ -0,0 +1,26 @@
+// Code generated by "stringer -type=DiscountType -trimprefix=DISCOUNT_TYPE_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[DISCOUNT_TYPE_UNDEFINED-0]
+	_ = x[DISCOUNT_TYPE_PERCENTAGE-1]
+	_ = x[DISCOUNT_TYPE_FIXED-2]
+}
+
+const _DiscountType_name = "UNDEFINEDPERCENTAGEFIXED"
+
+var _DiscountType_index = [...]uint8{0, 9, 19, 24}
+
+func (i DiscountType) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_DiscountType_index)-1 {
+		return "DiscountType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _DiscountType_name[_DiscountType_index[idx]:_DiscountType_index[idx+1]]
+}
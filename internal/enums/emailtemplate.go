@@ -12,6 +12,13 @@ const (
 	EMAIL_TEMPLATE_PASSWORD_RESET
 	EMAIL_TEMPLATE_MEMO_NOTIFICATION
 	EMAIL_TEMPLATE_ORDER_STATUS_UPDATE
+	EMAIL_TEMPLATE_STOCK_SUBSCRIPTION_CONFIRMATION
+	EMAIL_TEMPLATE_PRODUCT_BACK_IN_STOCK
+	EMAIL_TEMPLATE_PRODUCT_ON_SALE
+	EMAIL_TEMPLATE_ABANDONED_CART
+	EMAIL_TEMPLATE_MEMO_REMINDER
+	EMAIL_TEMPLATE_MEMO_ESCALATION
+	EMAIL_TEMPLATE_STAFF_LOGIN_CODE
 )
 
 func ParseEmailTemplateNameToEnum(e string) EmailTemplateName {
@@ -26,6 +33,20 @@ func ParseEmailTemplateNameToEnum(e string) EmailTemplateName {
 		return EMAIL_TEMPLATE_MEMO_NOTIFICATION
 	case EMAIL_TEMPLATE_ORDER_STATUS_UPDATE.String():
 		return EMAIL_TEMPLATE_ORDER_STATUS_UPDATE
+	case EMAIL_TEMPLATE_STOCK_SUBSCRIPTION_CONFIRMATION.String():
+		return EMAIL_TEMPLATE_STOCK_SUBSCRIPTION_CONFIRMATION
+	case EMAIL_TEMPLATE_PRODUCT_BACK_IN_STOCK.String():
+		return EMAIL_TEMPLATE_PRODUCT_BACK_IN_STOCK
+	case EMAIL_TEMPLATE_PRODUCT_ON_SALE.String():
+		return EMAIL_TEMPLATE_PRODUCT_ON_SALE
+	case EMAIL_TEMPLATE_ABANDONED_CART.String():
+		return EMAIL_TEMPLATE_ABANDONED_CART
+	case EMAIL_TEMPLATE_MEMO_REMINDER.String():
+		return EMAIL_TEMPLATE_MEMO_REMINDER
+	case EMAIL_TEMPLATE_MEMO_ESCALATION.String():
+		return EMAIL_TEMPLATE_MEMO_ESCALATION
+	case EMAIL_TEMPLATE_STAFF_LOGIN_CODE.String():
+		return EMAIL_TEMPLATE_STAFF_LOGIN_CODE
 	default:
 		return EMAIL_TEMPLATE_UNDEFINED
 	}
@@ -45,6 +66,20 @@ func (e EmailTemplateName) FileName() string {
 		return "memo_notification.html"
 	case EMAIL_TEMPLATE_ORDER_STATUS_UPDATE:
 		return "order_status_update.html"
+	case EMAIL_TEMPLATE_STOCK_SUBSCRIPTION_CONFIRMATION:
+		return "stock_subscription_confirmation.html"
+	case EMAIL_TEMPLATE_PRODUCT_BACK_IN_STOCK:
+		return "product_back_in_stock.html"
+	case EMAIL_TEMPLATE_PRODUCT_ON_SALE:
+		return "product_on_sale.html"
+	case EMAIL_TEMPLATE_ABANDONED_CART:
+		return "abandoned_cart.html"
+	case EMAIL_TEMPLATE_MEMO_REMINDER:
+		return "memo_reminder.html"
+	case EMAIL_TEMPLATE_MEMO_ESCALATION:
+		return "memo_escalation.html"
+	case EMAIL_TEMPLATE_STAFF_LOGIN_CODE:
+		return "staff_login_code.html"
 	default:
 		return ""
 	}
@@ -64,6 +99,20 @@ func (e EmailTemplateName) DBValue() string {
 		return "memo_notification"
 	case EMAIL_TEMPLATE_ORDER_STATUS_UPDATE:
 		return "order_status_update"
+	case EMAIL_TEMPLATE_STOCK_SUBSCRIPTION_CONFIRMATION:
+		return "stock_subscription_confirmation"
+	case EMAIL_TEMPLATE_PRODUCT_BACK_IN_STOCK:
+		return "product_back_in_stock"
+	case EMAIL_TEMPLATE_PRODUCT_ON_SALE:
+		return "product_on_sale"
+	case EMAIL_TEMPLATE_ABANDONED_CART:
+		return "abandoned_cart"
+	case EMAIL_TEMPLATE_MEMO_REMINDER:
+		return "memo_reminder"
+	case EMAIL_TEMPLATE_MEMO_ESCALATION:
+		return "memo_escalation"
+	case EMAIL_TEMPLATE_STAFF_LOGIN_CODE:
+		return "staff_login_code"
 	default:
 		return ""
 	}
@@ -83,6 +132,20 @@ func ParseEmailTemplateNameFromDB(s string) EmailTemplateName {
 		return EMAIL_TEMPLATE_MEMO_NOTIFICATION
 	case "order_status_update":
 		return EMAIL_TEMPLATE_ORDER_STATUS_UPDATE
+	case "stock_subscription_confirmation":
+		return EMAIL_TEMPLATE_STOCK_SUBSCRIPTION_CONFIRMATION
+	case "product_back_in_stock":
+		return EMAIL_TEMPLATE_PRODUCT_BACK_IN_STOCK
+	case "product_on_sale":
+		return EMAIL_TEMPLATE_PRODUCT_ON_SALE
+	case "abandoned_cart":
+		return EMAIL_TEMPLATE_ABANDONED_CART
+	case "memo_reminder":
+		return EMAIL_TEMPLATE_MEMO_REMINDER
+	case "memo_escalation":
+		return EMAIL_TEMPLATE_MEMO_ESCALATION
+	case "staff_login_code":
+		return EMAIL_TEMPLATE_STAFF_LOGIN_CODE
 	default:
 		return EMAIL_TEMPLATE_UNDEFINED
 	}
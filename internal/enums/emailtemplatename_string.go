@@ -15,11 +15,18 @@ func _() {
 	_ = x[EMAIL_TEMPLATE_PASSWORD_RESET-4]
 	_ = x[EMAIL_TEMPLATE_MEMO_NOTIFICATION-5]
 	_ = x[EMAIL_TEMPLATE_ORDER_STATUS_UPDATE-6]
+	_ = x[EMAIL_TEMPLATE_STOCK_SUBSCRIPTION_CONFIRMATION-7]
+	_ = x[EMAIL_TEMPLATE_PRODUCT_BACK_IN_STOCK-8]
+	_ = x[EMAIL_TEMPLATE_PRODUCT_ON_SALE-9]
+	_ = x[EMAIL_TEMPLATE_ABANDONED_CART-10]
+	_ = x[EMAIL_TEMPLATE_MEMO_REMINDER-11]
+	_ = x[EMAIL_TEMPLATE_MEMO_ESCALATION-12]
+	_ = x[EMAIL_TEMPLATE_STAFF_LOGIN_CODE-13]
 }
 
-const _EmailTemplateName_name = "UNDEFINEDORDER_CONFIRMATIONPAYMENT_CONFIRMATIONCUSTOMER_VERIFICATIONPASSWORD_RESETMEMO_NOTIFICATIONORDER_STATUS_UPDATE"
+const _EmailTemplateName_name = "UNDEFINEDORDER_CONFIRMATIONPAYMENT_CONFIRMATIONCUSTOMER_VERIFICATIONPASSWORD_RESETMEMO_NOTIFICATIONORDER_STATUS_UPDATESTOCK_SUBSCRIPTION_CONFIRMATIONPRODUCT_BACK_IN_STOCKPRODUCT_ON_SALEABANDONED_CARTMEMO_REMINDERMEMO_ESCALATIONSTAFF_LOGIN_CODE"
 
-var _EmailTemplateName_index = [...]uint8{0, 9, 27, 47, 68, 82, 99, 118}
+var _EmailTemplateName_index = [...]uint8{0, 9, 27, 47, 68, 82, 99, 118, 149, 170, 185, 199, 212, 227, 243}
 
 func (i EmailTemplateName) String() string {
 	idx := int(i) - 0
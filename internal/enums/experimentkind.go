@@ -0,0 +1,40 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=ExperimentKind -trimprefix=EXPERIMENT_KIND_
+
+type ExperimentKind int
+
+const (
+	EXPERIMENT_KIND_UNDEFINED ExperimentKind = iota
+	EXPERIMENT_KIND_THEME
+	EXPERIMENT_KIND_PROMO_BANNERS
+)
+
+var AllExperimentKinds = []ExperimentKind{
+	EXPERIMENT_KIND_THEME,
+	EXPERIMENT_KIND_PROMO_BANNERS,
+}
+
+func ParseExperimentKindToEnum(ek string) ExperimentKind {
+	switch strings.ToUpper(ek) {
+	case EXPERIMENT_KIND_THEME.String():
+		return EXPERIMENT_KIND_THEME
+	case EXPERIMENT_KIND_PROMO_BANNERS.String():
+		return EXPERIMENT_KIND_PROMO_BANNERS
+	default:
+		return EXPERIMENT_KIND_UNDEFINED
+	}
+}
+
+func MustParseExperimentKindToEnum(ek string) ExperimentKind {
+	res := ParseExperimentKindToEnum(ek)
+	if res == EXPERIMENT_KIND_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected ExperimentKind. Got '%s'", ek))
+	}
+	return res
+}
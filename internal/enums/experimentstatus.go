@@ -0,0 +1,44 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=ExperimentStatus -trimprefix=EXPERIMENT_STATUS_
+
+type ExperimentStatus int
+
+const (
+	EXPERIMENT_STATUS_UNDEFINED ExperimentStatus = iota
+	EXPERIMENT_STATUS_DRAFT
+	EXPERIMENT_STATUS_PUBLISHED
+	EXPERIMENT_STATUS_DELETED
+)
+
+var AllExperimentStatuses = []ExperimentStatus{
+	EXPERIMENT_STATUS_DRAFT,
+	EXPERIMENT_STATUS_PUBLISHED,
+	EXPERIMENT_STATUS_DELETED,
+}
+
+func ParseExperimentStatusToEnum(es string) ExperimentStatus {
+	switch strings.ToUpper(es) {
+	case EXPERIMENT_STATUS_DRAFT.String():
+		return EXPERIMENT_STATUS_DRAFT
+	case EXPERIMENT_STATUS_PUBLISHED.String():
+		return EXPERIMENT_STATUS_PUBLISHED
+	case EXPERIMENT_STATUS_DELETED.String():
+		return EXPERIMENT_STATUS_DELETED
+	default:
+		return EXPERIMENT_STATUS_UNDEFINED
+	}
+}
+
+func MustParseExperimentStatusToEnum(es string) ExperimentStatus {
+	res := ParseExperimentStatusToEnum(es)
+	if res == EXPERIMENT_STATUS_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected ExperimentStatus. Got '%s'", es))
+	}
+	return res
+}
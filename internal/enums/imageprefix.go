@@ -9,6 +9,7 @@ const (
 	IMAGE_PREFIX_PRODUCT_IMAGE
 	IMAGE_PREFIX_BRAND_IMAGE
 	IMAGE_PREFIX_PROMO_IMAGE
+	IMAGE_PREFIX_REVIEW_IMAGE
 )
 
 func ParseImagePrefix(e string) ImagePrefix {
@@ -19,6 +20,8 @@ func ParseImagePrefix(e string) ImagePrefix {
 		return IMAGE_PREFIX_BRAND_IMAGE
 	case "PROMO_IMAGE":
 		return IMAGE_PREFIX_PROMO_IMAGE
+	case "REVIEW_IMAGE":
+		return IMAGE_PREFIX_REVIEW_IMAGE
 	default:
 		return IMAGE_PREFIX_UNDEFINED
 	}
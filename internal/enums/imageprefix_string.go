@@ -12,11 +12,12 @@ func _() {
 	_ = x[IMAGE_PREFIX_PRODUCT_IMAGE-1]
 	_ = x[IMAGE_PREFIX_BRAND_IMAGE-2]
 	_ = x[IMAGE_PREFIX_PROMO_IMAGE-3]
+	_ = x[IMAGE_PREFIX_REVIEW_IMAGE-4]
 }
 
-const _ImagePrefix_name = "UNDEFINEDPRODUCT_IMAGEBRAND_IMAGEPROMO_IMAGE"
+const _ImagePrefix_name = "UNDEFINEDPRODUCT_IMAGEBRAND_IMAGEPROMO_IMAGEREVIEW_IMAGE"
 
-var _ImagePrefix_index = [...]uint8{0, 9, 22, 33, 44}
+var _ImagePrefix_index = [...]uint8{0, 9, 22, 33, 44, 56}
 
 func (i ImagePrefix) String() string {
 	idx := int(i) - 0
@@ -0,0 +1,44 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=IncidentDecision -trimprefix=INCIDENT_DECISION_
+
+type IncidentDecision int
+
+const (
+	INCIDENT_DECISION_UNDEFINED IncidentDecision = iota
+	INCIDENT_DECISION_WARNING
+	INCIDENT_DECISION_SUSPENSION
+	INCIDENT_DECISION_NO_ACTION
+)
+
+var AllIncidentDecisions = []IncidentDecision{
+	INCIDENT_DECISION_WARNING,
+	INCIDENT_DECISION_SUSPENSION,
+	INCIDENT_DECISION_NO_ACTION,
+}
+
+func ParseIncidentDecisionToEnum(s string) IncidentDecision {
+	switch strings.ToUpper(s) {
+	case INCIDENT_DECISION_WARNING.String():
+		return INCIDENT_DECISION_WARNING
+	case INCIDENT_DECISION_SUSPENSION.String():
+		return INCIDENT_DECISION_SUSPENSION
+	case INCIDENT_DECISION_NO_ACTION.String():
+		return INCIDENT_DECISION_NO_ACTION
+	default:
+		return INCIDENT_DECISION_UNDEFINED
+	}
+}
+
+func MustParseIncidentDecisionToEnum(s string) IncidentDecision {
+	res := ParseIncidentDecisionToEnum(s)
+	if res == INCIDENT_DECISION_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected IncidentDecision. Got '%s'", s))
+	}
+	return res
+}
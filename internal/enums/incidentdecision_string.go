@@ -0,0 +1,27 @@
+// Code generated by "stringer -type=IncidentDecision -trimprefix=INCIDENT_DECISION_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[INCIDENT_DECISION_UNDEFINED-0]
+	_ = x[INCIDENT_DECISION_WARNING-1]
+	_ = x[INCIDENT_DECISION_SUSPENSION-2]
+	_ = x[INCIDENT_DECISION_NO_ACTION-3]
+}
+
+const _IncidentDecision_name = "UNDEFINEDWARNINGSUSPENSIONNO_ACTION"
+
+var _IncidentDecision_index = [...]uint8{0, 9, 16, 26, 35}
+
+func (i IncidentDecision) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_IncidentDecision_index)-1 {
+		return "IncidentDecision(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _IncidentDecision_name[_IncidentDecision_index[idx]:_IncidentDecision_index[idx+1]]
+}
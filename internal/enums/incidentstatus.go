@@ -0,0 +1,48 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=IncidentStatus -trimprefix=INCIDENT_STATUS_
+
+type IncidentStatus int
+
+const (
+	INCIDENT_STATUS_UNDEFINED IncidentStatus = iota
+	INCIDENT_STATUS_OPEN
+	INCIDENT_STATUS_NTE_ISSUED
+	INCIDENT_STATUS_RESPONDED
+	INCIDENT_STATUS_DECIDED
+)
+
+var AllIncidentStatuses = []IncidentStatus{
+	INCIDENT_STATUS_OPEN,
+	INCIDENT_STATUS_NTE_ISSUED,
+	INCIDENT_STATUS_RESPONDED,
+	INCIDENT_STATUS_DECIDED,
+}
+
+func ParseIncidentStatusToEnum(s string) IncidentStatus {
+	switch strings.ToUpper(s) {
+	case INCIDENT_STATUS_OPEN.String():
+		return INCIDENT_STATUS_OPEN
+	case INCIDENT_STATUS_NTE_ISSUED.String():
+		return INCIDENT_STATUS_NTE_ISSUED
+	case INCIDENT_STATUS_RESPONDED.String():
+		return INCIDENT_STATUS_RESPONDED
+	case INCIDENT_STATUS_DECIDED.String():
+		return INCIDENT_STATUS_DECIDED
+	default:
+		return INCIDENT_STATUS_UNDEFINED
+	}
+}
+
+func MustParseIncidentStatusToEnum(s string) IncidentStatus {
+	res := ParseIncidentStatusToEnum(s)
+	if res == INCIDENT_STATUS_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected IncidentStatus. Got '%s'", s))
+	}
+	return res
+}
@@ -0,0 +1,28 @@
+// Code generated by "stringer -type=IncidentStatus -trimprefix=INCIDENT_STATUS_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[INCIDENT_STATUS_UNDEFINED-0]
+	_ = x[INCIDENT_STATUS_OPEN-1]
+	_ = x[INCIDENT_STATUS_NTE_ISSUED-2]
+	_ = x[INCIDENT_STATUS_RESPONDED-3]
+	_ = x[INCIDENT_STATUS_DECIDED-4]
+}
+
+const _IncidentStatus_name = "UNDEFINEDOPENNTE_ISSUEDRESPONDEDDECIDED"
+
+var _IncidentStatus_index = [...]uint8{0, 9, 13, 23, 32, 39}
+
+func (i IncidentStatus) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_IncidentStatus_index)-1 {
+		return "IncidentStatus(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _IncidentStatus_name[_IncidentStatus_index[idx]:_IncidentStatus_index[idx+1]]
+}
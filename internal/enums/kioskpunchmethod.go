@@ -0,0 +1,40 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=KioskPunchMethod -trimprefix=KIOSK_PUNCH_METHOD_
+
+type KioskPunchMethod int
+
+const (
+	KIOSK_PUNCH_METHOD_UNDEFINED KioskPunchMethod = iota
+	KIOSK_PUNCH_METHOD_QR
+	KIOSK_PUNCH_METHOD_PIN
+)
+
+var AllKioskPunchMethods = []KioskPunchMethod{
+	KIOSK_PUNCH_METHOD_QR,
+	KIOSK_PUNCH_METHOD_PIN,
+}
+
+func ParseKioskPunchMethodToEnum(s string) KioskPunchMethod {
+	switch strings.ToUpper(s) {
+	case KIOSK_PUNCH_METHOD_QR.String():
+		return KIOSK_PUNCH_METHOD_QR
+	case KIOSK_PUNCH_METHOD_PIN.String():
+		return KIOSK_PUNCH_METHOD_PIN
+	default:
+		return KIOSK_PUNCH_METHOD_UNDEFINED
+	}
+}
+
+func MustParseKioskPunchMethodToEnum(s string) KioskPunchMethod {
+	res := ParseKioskPunchMethodToEnum(s)
+	if res == KIOSK_PUNCH_METHOD_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected KioskPunchMethod. Got '%s'", s))
+	}
+	return res
+}
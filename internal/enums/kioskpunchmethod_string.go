@@ -0,0 +1,26 @@
+// Code generated by "stringer -type=KioskPunchMethod -trimprefix=KIOSK_PUNCH_METHOD_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[KIOSK_PUNCH_METHOD_UNDEFINED-0]
+	_ = x[KIOSK_PUNCH_METHOD_QR-1]
+	_ = x[KIOSK_PUNCH_METHOD_PIN-2]
+}
+
+const _KioskPunchMethod_name = "UNDEFINEDQRPIN"
+
+var _KioskPunchMethod_index = [...]uint8{0, 9, 11, 14}
+
+func (i KioskPunchMethod) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_KioskPunchMethod_index)-1 {
+		return "KioskPunchMethod(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _KioskPunchMethod_name[_KioskPunchMethod_index[idx]:_KioskPunchMethod_index[idx+1]]
+}
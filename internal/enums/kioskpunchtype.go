@@ -0,0 +1,40 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=KioskPunchType -trimprefix=KIOSK_PUNCH_TYPE_
+
+type KioskPunchType int
+
+const (
+	KIOSK_PUNCH_TYPE_UNDEFINED KioskPunchType = iota
+	KIOSK_PUNCH_TYPE_TIME_IN
+	KIOSK_PUNCH_TYPE_TIME_OUT
+)
+
+var AllKioskPunchTypes = []KioskPunchType{
+	KIOSK_PUNCH_TYPE_TIME_IN,
+	KIOSK_PUNCH_TYPE_TIME_OUT,
+}
+
+func ParseKioskPunchTypeToEnum(s string) KioskPunchType {
+	switch strings.ToUpper(s) {
+	case KIOSK_PUNCH_TYPE_TIME_IN.String():
+		return KIOSK_PUNCH_TYPE_TIME_IN
+	case KIOSK_PUNCH_TYPE_TIME_OUT.String():
+		return KIOSK_PUNCH_TYPE_TIME_OUT
+	default:
+		return KIOSK_PUNCH_TYPE_UNDEFINED
+	}
+}
+
+func MustParseKioskPunchTypeToEnum(s string) KioskPunchType {
+	res := ParseKioskPunchTypeToEnum(s)
+	if res == KIOSK_PUNCH_TYPE_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected KioskPunchType. Got '%s'", s))
+	}
+	return res
+}
@@ -0,0 +1,26 @@
+// Code generated by "stringer -type=KioskPunchType -trimprefix=KIOSK_PUNCH_TYPE_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[KIOSK_PUNCH_TYPE_UNDEFINED-0]
+	_ = x[KIOSK_PUNCH_TYPE_TIME_IN-1]
+	_ = x[KIOSK_PUNCH_TYPE_TIME_OUT-2]
+}
+
+const _KioskPunchType_name = "UNDEFINEDTIME_INTIME_OUT"
+
+var _KioskPunchType_index = [...]uint8{0, 9, 16, 24}
+
+func (i KioskPunchType) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_KioskPunchType_index)-1 {
+		return "KioskPunchType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _KioskPunchType_name[_KioskPunchType_index[idx]:_KioskPunchType_index[idx+1]]
+}
@@ -0,0 +1,56 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=LeaveEntryType -trimprefix=LEAVE_ENTRY_TYPE_
+
+type LeaveEntryType int
+
+const (
+	LEAVE_ENTRY_TYPE_UNDEFINED LeaveEntryType = iota
+	LEAVE_ENTRY_TYPE_ACCRUAL
+	LEAVE_ENTRY_TYPE_DEDUCTION
+	LEAVE_ENTRY_TYPE_REFUND
+	LEAVE_ENTRY_TYPE_ADJUSTMENT
+	LEAVE_ENTRY_TYPE_CONVERSION
+	LEAVE_ENTRY_TYPE_FORFEIT
+)
+
+var AllLeaveEntryTypes = []LeaveEntryType{
+	LEAVE_ENTRY_TYPE_ACCRUAL,
+	LEAVE_ENTRY_TYPE_DEDUCTION,
+	LEAVE_ENTRY_TYPE_REFUND,
+	LEAVE_ENTRY_TYPE_ADJUSTMENT,
+	LEAVE_ENTRY_TYPE_CONVERSION,
+	LEAVE_ENTRY_TYPE_FORFEIT,
+}
+
+func ParseLeaveEntryTypeToEnum(s string) LeaveEntryType {
+	switch strings.ToUpper(s) {
+	case LEAVE_ENTRY_TYPE_ACCRUAL.String():
+		return LEAVE_ENTRY_TYPE_ACCRUAL
+	case LEAVE_ENTRY_TYPE_DEDUCTION.String():
+		return LEAVE_ENTRY_TYPE_DEDUCTION
+	case LEAVE_ENTRY_TYPE_REFUND.String():
+		return LEAVE_ENTRY_TYPE_REFUND
+	case LEAVE_ENTRY_TYPE_ADJUSTMENT.String():
+		return LEAVE_ENTRY_TYPE_ADJUSTMENT
+	case LEAVE_ENTRY_TYPE_CONVERSION.String():
+		return LEAVE_ENTRY_TYPE_CONVERSION
+	case LEAVE_ENTRY_TYPE_FORFEIT.String():
+		return LEAVE_ENTRY_TYPE_FORFEIT
+	default:
+		return LEAVE_ENTRY_TYPE_UNDEFINED
+	}
+}
+
+func MustParseLeaveEntryTypeToEnum(s string) LeaveEntryType {
+	res := ParseLeaveEntryTypeToEnum(s)
+	if res == LEAVE_ENTRY_TYPE_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected LeaveEntryType. Got '%s'", s))
+	}
+	return res
+}
@@ -0,0 +1,30 @@
+// Code generated by "stringer -type=LeaveEntryType -trimprefix=LEAVE_ENTRY_TYPE_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[LEAVE_ENTRY_TYPE_UNDEFINED-0]
+	_ = x[LEAVE_ENTRY_TYPE_ACCRUAL-1]
+	_ = x[LEAVE_ENTRY_TYPE_DEDUCTION-2]
+	_ = x[LEAVE_ENTRY_TYPE_REFUND-3]
+	_ = x[LEAVE_ENTRY_TYPE_ADJUSTMENT-4]
+	_ = x[LEAVE_ENTRY_TYPE_CONVERSION-5]
+	_ = x[LEAVE_ENTRY_TYPE_FORFEIT-6]
+}
+
+const _LeaveEntryType_name = "UNDEFINEDACCRUALDEDUCTIONREFUNDADJUSTMENTCONVERSIONFORFEIT"
+
+var _LeaveEntryType_index = [...]uint8{0, 9, 16, 25, 31, 41, 51, 58}
+
+func (i LeaveEntryType) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_LeaveEntryType_index)-1 {
+		return "LeaveEntryType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _LeaveEntryType_name[_LeaveEntryType_index[idx]:_LeaveEntryType_index[idx+1]]
+}
@@ -12,6 +12,7 @@ const (
 	OUTPUT_FORMAT_UNDEFINED OutputFormat = iota
 	OUTPUT_FORMAT_CSV
 	OUTPUT_FORMAT_XLSX
+	OUTPUT_FORMAT_PDF
 )
 
 func ParseOutputFormatToEnum(format string) OutputFormat {
@@ -20,6 +21,8 @@ func ParseOutputFormatToEnum(format string) OutputFormat {
 		return OUTPUT_FORMAT_CSV
 	case OUTPUT_FORMAT_XLSX.String():
 		return OUTPUT_FORMAT_XLSX
+	case OUTPUT_FORMAT_PDF.String():
+		return OUTPUT_FORMAT_PDF
 	default:
 		return OUTPUT_FORMAT_UNDEFINED
 	}
@@ -11,11 +11,12 @@ func _() {
 	_ = x[OUTPUT_FORMAT_UNDEFINED-0]
 	_ = x[OUTPUT_FORMAT_CSV-1]
 	_ = x[OUTPUT_FORMAT_XLSX-2]
+	_ = x[OUTPUT_FORMAT_PDF-3]
 }
 
-const _OutputFormat_name = "UNDEFINEDCSVXLSX"
+const _OutputFormat_name = "UNDEFINEDCSVXLSXPDF"
 
-var _OutputFormat_index = [...]uint8{0, 9, 12, 16}
+var _OutputFormat_index = [...]uint8{0, 9, 12, 16, 19}
 
 func (i OutputFormat) String() string {
 	idx := int(i) - 0
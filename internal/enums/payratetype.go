@@ -0,0 +1,40 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=PayRateType -trimprefix=PAY_RATE_TYPE_
+
+type PayRateType int
+
+const (
+	PAY_RATE_TYPE_UNDEFINED PayRateType = iota
+	PAY_RATE_TYPE_DAILY
+	PAY_RATE_TYPE_MONTHLY
+)
+
+var AllPayRateTypes = []PayRateType{
+	PAY_RATE_TYPE_DAILY,
+	PAY_RATE_TYPE_MONTHLY,
+}
+
+func ParsePayRateTypeToEnum(s string) PayRateType {
+	switch strings.ToUpper(s) {
+	case PAY_RATE_TYPE_DAILY.String():
+		return PAY_RATE_TYPE_DAILY
+	case PAY_RATE_TYPE_MONTHLY.String():
+		return PAY_RATE_TYPE_MONTHLY
+	default:
+		return PAY_RATE_TYPE_UNDEFINED
+	}
+}
+
+func MustParsePayRateTypeToEnum(s string) PayRateType {
+	res := ParsePayRateTypeToEnum(s)
+	if res == PAY_RATE_TYPE_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected PayRateType. Got '%s'", s))
+	}
+	return res
+}
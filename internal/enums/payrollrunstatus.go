@@ -0,0 +1,40 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=PayrollRunStatus -trimprefix=PAYROLL_RUN_STATUS_
+
+type PayrollRunStatus int
+
+const (
+	PAYROLL_RUN_STATUS_UNDEFINED PayrollRunStatus = iota
+	PAYROLL_RUN_STATUS_DRAFT
+	PAYROLL_RUN_STATUS_LOCKED
+)
+
+var AllPayrollRunStatuses = []PayrollRunStatus{
+	PAYROLL_RUN_STATUS_DRAFT,
+	PAYROLL_RUN_STATUS_LOCKED,
+}
+
+func ParsePayrollRunStatusToEnum(s string) PayrollRunStatus {
+	switch strings.ToUpper(s) {
+	case PAYROLL_RUN_STATUS_DRAFT.String():
+		return PAYROLL_RUN_STATUS_DRAFT
+	case PAYROLL_RUN_STATUS_LOCKED.String():
+		return PAYROLL_RUN_STATUS_LOCKED
+	default:
+		return PAYROLL_RUN_STATUS_UNDEFINED
+	}
+}
+
+func MustParsePayrollRunStatusToEnum(s string) PayrollRunStatus {
+	res := ParsePayrollRunStatusToEnum(s)
+	if res == PAYROLL_RUN_STATUS_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected PayrollRunStatus. Got '%s'", s))
+	}
+	return res
+}
@@ -0,0 +1,42 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=PermissionAccess -trimprefix=PERMISSION_ACCESS_
+
+// PermissionAccess is ordered so that a higher value also grants every lower
+// one: WRITE implies READ.
+type PermissionAccess int
+
+const (
+	PERMISSION_ACCESS_UNDEFINED PermissionAccess = iota
+	PERMISSION_ACCESS_READ
+	PERMISSION_ACCESS_WRITE
+)
+
+var AllPermissionAccesses = []PermissionAccess{
+	PERMISSION_ACCESS_READ,
+	PERMISSION_ACCESS_WRITE,
+}
+
+func ParsePermissionAccessToEnum(s string) PermissionAccess {
+	switch strings.ToUpper(s) {
+	case PERMISSION_ACCESS_READ.String():
+		return PERMISSION_ACCESS_READ
+	case PERMISSION_ACCESS_WRITE.String():
+		return PERMISSION_ACCESS_WRITE
+	default:
+		return PERMISSION_ACCESS_UNDEFINED
+	}
+}
+
+func MustParsePermissionAccessToEnum(s string) PermissionAccess {
+	res := ParsePermissionAccessToEnum(s)
+	if res == PERMISSION_ACCESS_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected PermissionAccess. Got '%s'", s))
+	}
+	return res
+}
@@ -0,0 +1,26 @@
+// Code generated by "stringer -type=PermissionAccess -trimprefix=PERMISSION_ACCESS_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[PERMISSION_ACCESS_UNDEFINED-0]
+	_ = x[PERMISSION_ACCESS_READ-1]
+	_ = x[PERMISSION_ACCESS_WRITE-2]
+}
+
+const _PermissionAccess_name = "UNDEFINEDREADWRITE"
+
+var _PermissionAccess_index = [...]uint8{0, 9, 13, 18}
+
+func (i PermissionAccess) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_PermissionAccess_index)-1 {
+		return "PermissionAccess(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _PermissionAccess_name[_PermissionAccess_index[idx]:_PermissionAccess_index[idx+1]]
+}
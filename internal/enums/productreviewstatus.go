@@ -0,0 +1,44 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=ProductReviewStatus -trimprefix=PRODUCT_REVIEW_STATUS_
+
+type ProductReviewStatus int
+
+const (
+	PRODUCT_REVIEW_STATUS_UNDEFINED ProductReviewStatus = iota
+	PRODUCT_REVIEW_STATUS_PENDING
+	PRODUCT_REVIEW_STATUS_APPROVED
+	PRODUCT_REVIEW_STATUS_REJECTED
+)
+
+var AllProductReviewStatuses = []ProductReviewStatus{
+	PRODUCT_REVIEW_STATUS_PENDING,
+	PRODUCT_REVIEW_STATUS_APPROVED,
+	PRODUCT_REVIEW_STATUS_REJECTED,
+}
+
+func ParseProductReviewStatusToEnum(s string) ProductReviewStatus {
+	switch strings.ToUpper(s) {
+	case PRODUCT_REVIEW_STATUS_PENDING.String():
+		return PRODUCT_REVIEW_STATUS_PENDING
+	case PRODUCT_REVIEW_STATUS_APPROVED.String():
+		return PRODUCT_REVIEW_STATUS_APPROVED
+	case PRODUCT_REVIEW_STATUS_REJECTED.String():
+		return PRODUCT_REVIEW_STATUS_REJECTED
+	default:
+		return PRODUCT_REVIEW_STATUS_UNDEFINED
+	}
+}
+
+func MustParseProductReviewStatusToEnum(s string) ProductReviewStatus {
+	res := ParseProductReviewStatusToEnum(s)
+	if res == PRODUCT_REVIEW_STATUS_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected ProductReviewStatus. Got '%s'", s))
+	}
+	return res
+}
@@ -0,0 +1,27 @@
+// Code generated by "stringer -type=ProductReviewStatus -trimprefix=PRODUCT_REVIEW_STATUS_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[PRODUCT_REVIEW_STATUS_UNDEFINED-0]
+	_ = x[PRODUCT_REVIEW_STATUS_PENDING-1]
+	_ = x[PRODUCT_REVIEW_STATUS_APPROVED-2]
+	_ = x[PRODUCT_REVIEW_STATUS_REJECTED-3]
+}
+
+const _ProductReviewStatus_name = "UNDEFINEDPENDINGAPPROVEDREJECTED"
+
+var _ProductReviewStatus_index = [...]uint8{0, 9, 16, 24, 32}
+
+func (i ProductReviewStatus) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_ProductReviewStatus_index)-1 {
+		return "ProductReviewStatus(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _ProductReviewStatus_name[_ProductReviewStatus_index[idx]:_ProductReviewStatus_index[idx+1]]
+}
@@ -0,0 +1,44 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=SaleCampaignSelector -trimprefix=SALE_CAMPAIGN_SELECTOR_
+
+type SaleCampaignSelector int
+
+const (
+	SALE_CAMPAIGN_SELECTOR_UNDEFINED SaleCampaignSelector = iota
+	SALE_CAMPAIGN_SELECTOR_BRAND
+	SALE_CAMPAIGN_SELECTOR_CATEGORY
+	SALE_CAMPAIGN_SELECTOR_PRODUCTS
+)
+
+var AllSaleCampaignSelectors = []SaleCampaignSelector{
+	SALE_CAMPAIGN_SELECTOR_BRAND,
+	SALE_CAMPAIGN_SELECTOR_CATEGORY,
+	SALE_CAMPAIGN_SELECTOR_PRODUCTS,
+}
+
+func ParseSaleCampaignSelectorToEnum(s string) SaleCampaignSelector {
+	switch strings.ToUpper(s) {
+	case SALE_CAMPAIGN_SELECTOR_BRAND.String():
+		return SALE_CAMPAIGN_SELECTOR_BRAND
+	case SALE_CAMPAIGN_SELECTOR_CATEGORY.String():
+		return SALE_CAMPAIGN_SELECTOR_CATEGORY
+	case SALE_CAMPAIGN_SELECTOR_PRODUCTS.String():
+		return SALE_CAMPAIGN_SELECTOR_PRODUCTS
+	default:
+		return SALE_CAMPAIGN_SELECTOR_UNDEFINED
+	}
+}
+
+func MustParseSaleCampaignSelectorToEnum(s string) SaleCampaignSelector {
+	res := ParseSaleCampaignSelectorToEnum(s)
+	if res == SALE_CAMPAIGN_SELECTOR_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected SaleCampaignSelector. Got '%s'", s))
+	}
+	return res
+}
@@ -0,0 +1,27 @@
+// Code generated by "stringer -type=SaleCampaignSelector -trimprefix=SALE_CAMPAIGN_SELECTOR_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[SALE_CAMPAIGN_SELECTOR_UNDEFINED-0]
+	_ = x[SALE_CAMPAIGN_SELECTOR_BRAND-1]
+	_ = x[SALE_CAMPAIGN_SELECTOR_CATEGORY-2]
+	_ = x[SALE_CAMPAIGN_SELECTOR_PRODUCTS-3]
+}
+
+const _SaleCampaignSelector_name = "UNDEFINEDBRANDCATEGORYPRODUCTS"
+
+var _SaleCampaignSelector_index = [...]uint8{0, 9, 14, 22, 30}
+
+func (i SaleCampaignSelector) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_SaleCampaignSelector_index)-1 {
+		return "SaleCampaignSelector(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _SaleCampaignSelector_name[_SaleCampaignSelector_index[idx]:_SaleCampaignSelector_index[idx+1]]
+}
@@ -0,0 +1,48 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=SaleCampaignStatus -trimprefix=SALE_CAMPAIGN_STATUS_
+
+type SaleCampaignStatus int
+
+const (
+	SALE_CAMPAIGN_STATUS_UNDEFINED SaleCampaignStatus = iota
+	SALE_CAMPAIGN_STATUS_SCHEDULED
+	SALE_CAMPAIGN_STATUS_ACTIVE
+	SALE_CAMPAIGN_STATUS_ENDED
+	SALE_CAMPAIGN_STATUS_CANCELLED
+)
+
+var AllSaleCampaignStatuses = []SaleCampaignStatus{
+	SALE_CAMPAIGN_STATUS_SCHEDULED,
+	SALE_CAMPAIGN_STATUS_ACTIVE,
+	SALE_CAMPAIGN_STATUS_ENDED,
+	SALE_CAMPAIGN_STATUS_CANCELLED,
+}
+
+func ParseSaleCampaignStatusToEnum(s string) SaleCampaignStatus {
+	switch strings.ToUpper(s) {
+	case SALE_CAMPAIGN_STATUS_SCHEDULED.String():
+		return SALE_CAMPAIGN_STATUS_SCHEDULED
+	case SALE_CAMPAIGN_STATUS_ACTIVE.String():
+		return SALE_CAMPAIGN_STATUS_ACTIVE
+	case SALE_CAMPAIGN_STATUS_ENDED.String():
+		return SALE_CAMPAIGN_STATUS_ENDED
+	case SALE_CAMPAIGN_STATUS_CANCELLED.String():
+		return SALE_CAMPAIGN_STATUS_CANCELLED
+	default:
+		return SALE_CAMPAIGN_STATUS_UNDEFINED
+	}
+}
+
+func MustParseSaleCampaignStatusToEnum(s string) SaleCampaignStatus {
+	res := ParseSaleCampaignStatusToEnum(s)
+	if res == SALE_CAMPAIGN_STATUS_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected SaleCampaignStatus. Got '%s'", s))
+	}
+	return res
+}
@@ -0,0 +1,28 @@
+// Code generated by "stringer -type=SaleCampaignStatus -trimprefix=SALE_CAMPAIGN_STATUS_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[SALE_CAMPAIGN_STATUS_UNDEFINED-0]
+	_ = x[SALE_CAMPAIGN_STATUS_SCHEDULED-1]
+	_ = x[SALE_CAMPAIGN_STATUS_ACTIVE-2]
+	_ = x[SALE_CAMPAIGN_STATUS_ENDED-3]
+	_ = x[SALE_CAMPAIGN_STATUS_CANCELLED-4]
+}
+
+const _SaleCampaignStatus_name = "UNDEFINEDSCHEDULEDACTIVEENDEDCANCELLED"
+
+var _SaleCampaignStatus_index = [...]uint8{0, 9, 18, 24, 29, 38}
+
+func (i SaleCampaignStatus) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_SaleCampaignStatus_index)-1 {
+		return "SaleCampaignStatus(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _SaleCampaignStatus_name[_SaleCampaignStatus_index[idx]:_SaleCampaignStatus_index[idx+1]]
+}
@@ -0,0 +1,44 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=SearchRuleKind -trimprefix=SEARCH_RULE_KIND_
+
+type SearchRuleKind int
+
+const (
+	SEARCH_RULE_KIND_UNDEFINED SearchRuleKind = iota
+	SEARCH_RULE_KIND_PIN_PRODUCT
+	SEARCH_RULE_KIND_REDIRECT_CATEGORY
+	SEARCH_RULE_KIND_BOOST_BRAND
+)
+
+var AllSearchRuleKinds = []SearchRuleKind{
+	SEARCH_RULE_KIND_PIN_PRODUCT,
+	SEARCH_RULE_KIND_REDIRECT_CATEGORY,
+	SEARCH_RULE_KIND_BOOST_BRAND,
+}
+
+func ParseSearchRuleKindToEnum(srk string) SearchRuleKind {
+	switch strings.ToUpper(srk) {
+	case SEARCH_RULE_KIND_PIN_PRODUCT.String():
+		return SEARCH_RULE_KIND_PIN_PRODUCT
+	case SEARCH_RULE_KIND_REDIRECT_CATEGORY.String():
+		return SEARCH_RULE_KIND_REDIRECT_CATEGORY
+	case SEARCH_RULE_KIND_BOOST_BRAND.String():
+		return SEARCH_RULE_KIND_BOOST_BRAND
+	default:
+		return SEARCH_RULE_KIND_UNDEFINED
+	}
+}
+
+func MustParseSearchRuleKindToEnum(srk string) SearchRuleKind {
+	res := ParseSearchRuleKindToEnum(srk)
+	if res == SEARCH_RULE_KIND_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected SearchRuleKind. Got '%s'", srk))
+	}
+	return res
+}
@@ -0,0 +1,44 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=SessionRevokeReason -trimprefix=SESSION_REVOKE_REASON_
+
+type SessionRevokeReason int
+
+const (
+	SESSION_REVOKE_REASON_UNDEFINED SessionRevokeReason = iota
+	SESSION_REVOKE_REASON_LOGOUT
+	SESSION_REVOKE_REASON_SIGN_OUT_OTHERS
+	SESSION_REVOKE_REASON_FORCED
+	SESSION_REVOKE_REASON_PASSWORD_CHANGE
+	SESSION_REVOKE_REASON_STATUS_CHANGE
+)
+
+func ParseSessionRevokeReasonToEnum(s string) SessionRevokeReason {
+	switch strings.ToUpper(s) {
+	case SESSION_REVOKE_REASON_LOGOUT.String():
+		return SESSION_REVOKE_REASON_LOGOUT
+	case SESSION_REVOKE_REASON_SIGN_OUT_OTHERS.String():
+		return SESSION_REVOKE_REASON_SIGN_OUT_OTHERS
+	case SESSION_REVOKE_REASON_FORCED.String():
+		return SESSION_REVOKE_REASON_FORCED
+	case SESSION_REVOKE_REASON_PASSWORD_CHANGE.String():
+		return SESSION_REVOKE_REASON_PASSWORD_CHANGE
+	case SESSION_REVOKE_REASON_STATUS_CHANGE.String():
+		return SESSION_REVOKE_REASON_STATUS_CHANGE
+	default:
+		return SESSION_REVOKE_REASON_UNDEFINED
+	}
+}
+
+func MustParseSessionRevokeReasonToEnum(s string) SessionRevokeReason {
+	res := ParseSessionRevokeReasonToEnum(s)
+	if res == SESSION_REVOKE_REASON_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected SessionRevokeReason. Got '%s'", s))
+	}
+	return res
+}
@@ -0,0 +1,29 @@
+// Code generated by "stringer -type=SessionRevokeReason -trimprefix=SESSION_REVOKE_REASON_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[SESSION_REVOKE_REASON_UNDEFINED-0]
+	_ = x[SESSION_REVOKE_REASON_LOGOUT-1]
+	_ = x[SESSION_REVOKE_REASON_SIGN_OUT_OTHERS-2]
+	_ = x[SESSION_REVOKE_REASON_FORCED-3]
+	_ = x[SESSION_REVOKE_REASON_PASSWORD_CHANGE-4]
+	_ = x[SESSION_REVOKE_REASON_STATUS_CHANGE-5]
+}
+
+const _SessionRevokeReason_name = "UNDEFINEDLOGOUTSIGN_OUT_OTHERSFORCEDPASSWORD_CHANGESTATUS_CHANGE"
+
+var _SessionRevokeReason_index = [...]uint8{0, 9, 15, 30, 36, 51, 64}
+
+func (i SessionRevokeReason) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_SessionRevokeReason_index)-1 {
+		return "SessionRevokeReason(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _SessionRevokeReason_name[_SessionRevokeReason_index[idx]:_SessionRevokeReason_index[idx+1]]
+}
@@ -0,0 +1,44 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=ShiftSwapStatus -trimprefix=SHIFT_SWAP_STATUS_
+
+type ShiftSwapStatus int
+
+const (
+	SHIFT_SWAP_STATUS_UNDEFINED ShiftSwapStatus = iota
+	SHIFT_SWAP_STATUS_PENDING
+	SHIFT_SWAP_STATUS_APPROVED
+	SHIFT_SWAP_STATUS_REJECTED
+)
+
+var AllShiftSwapStatuses = []ShiftSwapStatus{
+	SHIFT_SWAP_STATUS_PENDING,
+	SHIFT_SWAP_STATUS_APPROVED,
+	SHIFT_SWAP_STATUS_REJECTED,
+}
+
+func ParseShiftSwapStatusToEnum(s string) ShiftSwapStatus {
+	switch strings.ToUpper(s) {
+	case SHIFT_SWAP_STATUS_PENDING.String():
+		return SHIFT_SWAP_STATUS_PENDING
+	case SHIFT_SWAP_STATUS_APPROVED.String():
+		return SHIFT_SWAP_STATUS_APPROVED
+	case SHIFT_SWAP_STATUS_REJECTED.String():
+		return SHIFT_SWAP_STATUS_REJECTED
+	default:
+		return SHIFT_SWAP_STATUS_UNDEFINED
+	}
+}
+
+func MustParseShiftSwapStatusToEnum(s string) ShiftSwapStatus {
+	res := ParseShiftSwapStatusToEnum(s)
+	if res == SHIFT_SWAP_STATUS_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected ShiftSwapStatus. Got '%s'", s))
+	}
+	return res
+}
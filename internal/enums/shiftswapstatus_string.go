@@ -0,0 +1,27 @@
+// Code generated by "stringer -type=ShiftSwapStatus -trimprefix=SHIFT_SWAP_STATUS_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[SHIFT_SWAP_STATUS_UNDEFINED-0]
+	_ = x[SHIFT_SWAP_STATUS_PENDING-1]
+	_ = x[SHIFT_SWAP_STATUS_APPROVED-2]
+	_ = x[SHIFT_SWAP_STATUS_REJECTED-3]
+}
+
+const _ShiftSwapStatus_name = "UNDEFINEDPENDINGAPPROVEDREJECTED"
+
+var _ShiftSwapStatus_index = [...]uint8{0, 9, 16, 24, 32}
+
+func (i ShiftSwapStatus) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_ShiftSwapStatus_index)-1 {
+		return "ShiftSwapStatus(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _ShiftSwapStatus_name[_ShiftSwapStatus_index[idx]:_ShiftSwapStatus_index[idx+1]]
+}
@@ -23,6 +23,11 @@ const (
 	STAFF_ROLE_MANAGE_ORDER_STATUS
 	STAFF_ROLE_MANAGE_QUOTATIONS
 	STAFF_ROLE_MANAGE_THEMES
+	STAFF_ROLE_MANAGE_REVIEWS
+	STAFF_ROLE_MANAGE_PAYROLL
+	STAFF_ROLE_ATTENDANCE_KIOSK
+	STAFF_ROLE_VIEW_ANALYTICS
+	STAFF_ROLE_MANAGE_SEARCH
 )
 
 func ParseStaffRoleToEnum(e string) StaffRole {
@@ -61,6 +66,16 @@ func ParseStaffRoleToEnum(e string) StaffRole {
 		return STAFF_ROLE_MANAGE_QUOTATIONS
 	case STAFF_ROLE_MANAGE_THEMES.String():
 		return STAFF_ROLE_MANAGE_THEMES
+	case STAFF_ROLE_MANAGE_REVIEWS.String():
+		return STAFF_ROLE_MANAGE_REVIEWS
+	case STAFF_ROLE_MANAGE_PAYROLL.String():
+		return STAFF_ROLE_MANAGE_PAYROLL
+	case STAFF_ROLE_ATTENDANCE_KIOSK.String():
+		return STAFF_ROLE_ATTENDANCE_KIOSK
+	case STAFF_ROLE_VIEW_ANALYTICS.String():
+		return STAFF_ROLE_VIEW_ANALYTICS
+	case STAFF_ROLE_MANAGE_SEARCH.String():
+		return STAFF_ROLE_MANAGE_SEARCH
 	default:
 		return STAFF_ROLE_UNDEFINED
 	}
@@ -102,6 +117,16 @@ func MustParseStaffRoleToEnum(e string) StaffRole {
 		return STAFF_ROLE_MANAGE_QUOTATIONS
 	case STAFF_ROLE_MANAGE_THEMES.String():
 		return STAFF_ROLE_MANAGE_THEMES
+	case STAFF_ROLE_MANAGE_REVIEWS.String():
+		return STAFF_ROLE_MANAGE_REVIEWS
+	case STAFF_ROLE_MANAGE_PAYROLL.String():
+		return STAFF_ROLE_MANAGE_PAYROLL
+	case STAFF_ROLE_ATTENDANCE_KIOSK.String():
+		return STAFF_ROLE_ATTENDANCE_KIOSK
+	case STAFF_ROLE_VIEW_ANALYTICS.String():
+		return STAFF_ROLE_VIEW_ANALYTICS
+	case STAFF_ROLE_MANAGE_SEARCH.String():
+		return STAFF_ROLE_MANAGE_SEARCH
 	default:
 		panic("Invalid StaffRole. Got '" + e + "'")
 	}
@@ -130,6 +155,11 @@ func GetAllStaffRoles() []StaffRole {
 		STAFF_ROLE_MANAGE_ORDER_STATUS,
 		STAFF_ROLE_MANAGE_QUOTATIONS,
 		STAFF_ROLE_MANAGE_THEMES,
+		STAFF_ROLE_MANAGE_REVIEWS,
+		STAFF_ROLE_MANAGE_PAYROLL,
+		STAFF_ROLE_ATTENDANCE_KIOSK,
+		STAFF_ROLE_VIEW_ANALYTICS,
+		STAFF_ROLE_MANAGE_SEARCH,
 	}
 }
 
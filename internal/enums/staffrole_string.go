@@ -26,11 +26,16 @@ func _() {
 	_ = x[STAFF_ROLE_MANAGE_ORDER_STATUS-15]
 	_ = x[STAFF_ROLE_MANAGE_QUOTATIONS-16]
 	_ = x[STAFF_ROLE_MANAGE_THEMES-17]
+	_ = x[STAFF_ROLE_MANAGE_REVIEWS-18]
+	_ = x[STAFF_ROLE_MANAGE_PAYROLL-19]
+	_ = x[STAFF_ROLE_ATTENDANCE_KIOSK-20]
+	_ = x[STAFF_ROLE_VIEW_ANALYTICS-21]
+	_ = x[STAFF_ROLE_MANAGE_SEARCH-22]
 }
 
-const _StaffRole_name = "UNDEFINEDCREATE_PRODUCTCREATE_CPOINTSMANAGE_HOLIDAYSMANAGE_BRANDSMANAGE_PROMOSMANAGE_TRACKED_LINKSMANAGE_PRODUCT_INVENTORIESMANAGE_MEMOEXPORTSEXPORTS_PRODUCTSEDIT_PRODUCTSPUBLISH_PRODUCTSMANAGE_CATEGORIESMANAGE_ORDERSMANAGE_ORDER_STATUSMANAGE_QUOTATIONSMANAGE_THEMES"
+const _StaffRole_name = "UNDEFINEDCREATE_PRODUCTCREATE_CPOINTSMANAGE_HOLIDAYSMANAGE_BRANDSMANAGE_PROMOSMANAGE_TRACKED_LINKSMANAGE_PRODUCT_INVENTORIESMANAGE_MEMOEXPORTSEXPORTS_PRODUCTSEDIT_PRODUCTSPUBLISH_PRODUCTSMANAGE_CATEGORIESMANAGE_ORDERSMANAGE_ORDER_STATUSMANAGE_QUOTATIONSMANAGE_THEMESMANAGE_REVIEWSMANAGE_PAYROLLATTENDANCE_KIOSKVIEW_ANALYTICSMANAGE_SEARCH"
 
-var _StaffRole_index = [...]uint16{0, 9, 23, 37, 52, 65, 78, 98, 124, 135, 142, 158, 171, 187, 204, 217, 236, 253, 266}
+var _StaffRole_index = [...]uint16{0, 9, 23, 37, 52, 65, 78, 98, 124, 135, 142, 158, 171, 187, 204, 217, 236, 253, 266, 280, 294, 310, 324, 337}
 
 func (i StaffRole) String() string {
 	idx := int(i) - 0
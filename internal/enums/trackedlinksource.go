@@ -14,12 +14,14 @@ const (
 	TRACKED_LINK_SOURCE_QR
 	TRACKED_LINK_SOURCE_EMAIL
 	TRACKED_LINK_SOURCE_FACEBOOK
+	TRACKED_LINK_SOURCE_TIKTOK
 )
 
 var AllTrackedLinkSources = []TrackedLinkSource{
 	TRACKED_LINK_SOURCE_QR,
 	TRACKED_LINK_SOURCE_EMAIL,
 	TRACKED_LINK_SOURCE_FACEBOOK,
+	TRACKED_LINK_SOURCE_TIKTOK,
 }
 
 func ParseTrackedLinkSourceToEnum(s string) TrackedLinkSource {
@@ -30,6 +32,8 @@ func ParseTrackedLinkSourceToEnum(s string) TrackedLinkSource {
 		return TRACKED_LINK_SOURCE_EMAIL
 	case TRACKED_LINK_SOURCE_FACEBOOK.String():
 		return TRACKED_LINK_SOURCE_FACEBOOK
+	case TRACKED_LINK_SOURCE_TIKTOK.String():
+		return TRACKED_LINK_SOURCE_TIKTOK
 	default:
 		return TRACKED_LINK_SOURCE_UNDEFINED
 	}
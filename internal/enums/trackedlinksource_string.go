@@ -12,11 +12,12 @@ func _() {
 	_ = x[TRACKED_LINK_SOURCE_QR-1]
 	_ = x[TRACKED_LINK_SOURCE_EMAIL-2]
 	_ = x[TRACKED_LINK_SOURCE_FACEBOOK-3]
+	_ = x[TRACKED_LINK_SOURCE_TIKTOK-4]
 }
 
-const _TrackedLinkSource_name = "UNDEFINEDQREMAILFACEBOOK"
+const _TrackedLinkSource_name = "UNDEFINEDQREMAILFACEBOOKTIKTOK"
 
-var _TrackedLinkSource_index = [...]uint8{0, 9, 11, 16, 24}
+var _TrackedLinkSource_index = [...]uint8{0, 9, 11, 16, 24, 30}
 
 func (i TrackedLinkSource) String() string {
 	idx := int(i) - 0
@@ -0,0 +1,38 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go tool stringer -type=TwoFactorMethod -trimprefix=TWO_FACTOR_METHOD_
+
+type TwoFactorMethod int
+
+const (
+	TWO_FACTOR_METHOD_UNDEFINED TwoFactorMethod = iota
+	TWO_FACTOR_METHOD_TOTP
+	TWO_FACTOR_METHOD_RECOVERY_CODE
+	TWO_FACTOR_METHOD_EMAIL
+)
+
+func ParseTwoFactorMethodToEnum(s string) TwoFactorMethod {
+	switch strings.ToUpper(s) {
+	case TWO_FACTOR_METHOD_TOTP.String():
+		return TWO_FACTOR_METHOD_TOTP
+	case TWO_FACTOR_METHOD_RECOVERY_CODE.String():
+		return TWO_FACTOR_METHOD_RECOVERY_CODE
+	case TWO_FACTOR_METHOD_EMAIL.String():
+		return TWO_FACTOR_METHOD_EMAIL
+	default:
+		return TWO_FACTOR_METHOD_UNDEFINED
+	}
+}
+
+func MustParseTwoFactorMethodToEnum(s string) TwoFactorMethod {
+	res := ParseTwoFactorMethodToEnum(s)
+	if res == TWO_FACTOR_METHOD_UNDEFINED {
+		panic(fmt.Sprintf("Unexpected TwoFactorMethod. Got '%s'", s))
+	}
+	return res
+}
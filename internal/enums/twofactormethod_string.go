@@ -0,0 +1,27 @@
+// Code generated by "stringer -type=TwoFactorMethod -trimprefix=TWO_FACTOR_METHOD_"; DO NOT EDIT.
+
+package enums
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[TWO_FACTOR_METHOD_UNDEFINED-0]
+	_ = x[TWO_FACTOR_METHOD_TOTP-1]
+	_ = x[TWO_FACTOR_METHOD_RECOVERY_CODE-2]
+	_ = x[TWO_FACTOR_METHOD_EMAIL-3]
+}
+
+const _TwoFactorMethod_name = "UNDEFINEDTOTPRECOVERY_CODEEMAIL"
+
+var _TwoFactorMethod_index = [...]uint8{0, 9, 13, 26, 31}
+
+func (i TwoFactorMethod) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_TwoFactorMethod_index)-1 {
+		return "TwoFactorMethod(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _TwoFactorMethod_name[_TwoFactorMethod_index[idx]:_TwoFactorMethod_index[idx+1]]
+}
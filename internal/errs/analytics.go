@@ -0,0 +1,8 @@
+package errs
+
+import "errors"
+
+var (
+	ErrAnalytics             = errors.New("[ANALYTICS]: Error on analytics service")
+	ErrAnalyticsInvalidRange = errors.New("[ANALYTICS]: Invalid date range")
+)
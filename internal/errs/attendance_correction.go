@@ -0,0 +1,14 @@
+package errs
+
+import "errors"
+
+var (
+	ErrAttendanceCorrection            = errors.New("[ATTENDANCE CORRECTION]: Error on attendance correction service")
+	ErrAttendanceCorrectionInvalidDate = errors.New("[ATTENDANCE CORRECTION]: Date must be within the last 31 days and not in the future")
+	ErrAttendanceCorrectionEmpty       = errors.New("[ATTENDANCE CORRECTION]: Enter at least one time to correct")
+	ErrAttendanceCorrectionInvalidTime = errors.New("[ATTENDANCE CORRECTION]: Times must be HH:MM")
+	ErrAttendanceCorrectionTimeOrder   = errors.New("[ATTENDANCE CORRECTION]: Time out must be after time in and lunch break end after its start")
+	ErrAttendanceCorrectionNoTimeIn    = errors.New("[ATTENDANCE CORRECTION]: There is no time in on that date. Include the time in")
+	ErrAttendanceCorrectionPending     = errors.New("[ATTENDANCE CORRECTION]: There is already a pending correction for that date")
+	ErrAttendanceCorrectionNotPending  = errors.New("[ATTENDANCE CORRECTION]: Correction is no longer pending")
+)
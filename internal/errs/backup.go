@@ -0,0 +1,17 @@
+package errs
+
+import "errors"
+
+var (
+	ErrBackup            = errors.New("[BACKUP]: Error on database backup")
+	ErrBackupNotFound    = errors.New("[BACKUP]: Snapshot not found")
+	ErrBackupIntegrity   = errors.New("[BACKUP]: Snapshot failed the integrity check")
+	ErrBackupVersion     = errors.New("[BACKUP]: Snapshot migration version mismatch")
+	ErrBackupDBURL       = errors.New("[BACKUP]: DB_URL is not a SQLite file")
+	ErrBackupUnsupported = errors.New("[BACKUP]: Storage provider cannot hold snapshots")
+
+	ErrReplica              = errors.New("[REPLICA]: Error on WAL replication")
+	ErrReplicaWAL           = errors.New("[REPLICA]: Invalid WAL")
+	ErrReplicaDiscontinuity = errors.New("[REPLICA]: WAL restarted with frames that were not shipped")
+	ErrReplicaNoGeneration  = errors.New("[REPLICA]: No generation covers the requested time")
+)
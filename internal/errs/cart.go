@@ -5,4 +5,7 @@ import "errors"
 var (
 	ErrCartMissingCheckoutLines = errors.New("[CART]: No checkout lines found for the given session")
 	ErrCartNilOrder             = errors.New("[CART]: nil order returned")
+	ErrCartAbandoned            = errors.New("[CART]: Abandoned cart error")
+	ErrCartInvalidRecoveryToken = errors.New("[CART]: Invalid or expired cart recovery link")
+	ErrCartRestore              = errors.New("[CART]: Could not restore your saved cart")
 )
@@ -0,0 +1,7 @@
+package errs
+
+import "errors"
+
+var (
+	ErrClientEvent = errors.New("[CLIENT EVENT]: Error on client event store")
+)
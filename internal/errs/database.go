@@ -0,0 +1,11 @@
+package errs
+
+import "errors"
+
+var (
+	ErrDBReadOnly = errors.New("[DB]: Write attempted through the read-only connection")
+	ErrDBBusy     = errors.New("[DB]: Database stayed busy after retries")
+	ErrDBDriver   = errors.New("[DB]: Unsupported database driver")
+	ErrDBMigrate  = errors.New("[DB]: Migration to postgres failed verification")
+	ErrDBDialect  = errors.New("[DB]: Query uses SQLite-only functions with no PostgreSQL rewrite")
+)
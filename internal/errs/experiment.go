@@ -0,0 +1,13 @@
+package errs
+
+import "errors"
+
+var (
+	ErrExperiment                 = errors.New("[EXPERIMENT]: Error on experiment service")
+	ErrExperimentNotFound         = errors.New("[EXPERIMENT]: Experiment not found")
+	ErrExperimentVariantNotFound  = errors.New("[EXPERIMENT]: Variant not found")
+	ErrExperimentOverlappingDates = errors.New("[EXPERIMENT]: Another experiment of the same kind runs in this date range")
+	ErrExperimentNotEnoughVariant = errors.New("[EXPERIMENT]: An experiment needs at least two variants")
+	ErrExperimentPublished        = errors.New("[EXPERIMENT]: Variants cannot change once the experiment is published")
+	ErrExperimentInvalidVariant   = errors.New("[EXPERIMENT]: Variant does not match the experiment kind")
+)
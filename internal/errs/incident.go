@@ -0,0 +1,16 @@
+package errs
+
+import "errors"
+
+var (
+	ErrIncident                 = errors.New("[INCIDENT]: Error on incident service")
+	ErrIncidentInvalidDate      = errors.New("[INCIDENT]: Incident date must not be in the future")
+	ErrIncidentNotOpen          = errors.New("[INCIDENT]: A notice to explain was already issued for this incident")
+	ErrIncidentDeadline         = errors.New("[INCIDENT]: Response deadline must be after today")
+	ErrIncidentCannotRespond    = errors.New("[INCIDENT]: Incident is not awaiting your response or the deadline has passed")
+	ErrIncidentResponseRequired = errors.New("[INCIDENT]: Response is required")
+	ErrIncidentAlreadyDecided   = errors.New("[INCIDENT]: Incident was already decided")
+	ErrIncidentInvalidDecision  = errors.New("[INCIDENT]: Invalid decision")
+	ErrIncidentSuspensionDates  = errors.New("[INCIDENT]: Suspension needs an effective start and end date, with the end on or after the start")
+	ErrIncidentSuspended        = errors.New("[INCIDENT]: You are suspended today")
+)
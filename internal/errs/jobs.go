@@ -9,6 +9,8 @@ var (
 	ErrJobsNilMessage        = errors.New("[JOBS]: Received nil message")
 	ErrJobsOrderNotFound     = errors.New("[JOBS]: Order not found for job")
 	ErrJobsPaymentNotFound   = errors.New("[JOBS]: Payment not found for job")
+	ErrJobsProductNotFound   = errors.New("[JOBS]: Product not found for job")
+	ErrJobsCheckoutNotFound  = errors.New("[JOBS]: Checkout not found for job")
 	ErrJobsSendEmail         = errors.New("[JOBS]: Failed to send email")
 	ErrJobsThumbnailNotFound = errors.New("[JOBS]: Thumbnail job not found")
 	ErrJobsThumbnailFailed   = errors.New("[JOBS]: Failed to create thumbnail")
@@ -0,0 +1,22 @@
+package errs
+
+import "errors"
+
+var (
+	ErrKiosk                   = errors.New("[KIOSK]: Kiosk error")
+	ErrKioskInvalidTokenFormat = errors.New("[KIOSK]: Invalid QR code")
+	ErrKioskInvalidSignature   = errors.New("[KIOSK]: Invalid QR code signature")
+	ErrKioskTokenExpired       = errors.New("[KIOSK]: QR code expired. Scan the code on the kiosk again")
+	ErrKioskInvalidPunchType   = errors.New("[KIOSK]: Invalid punch type")
+	ErrKioskInvalidPin         = errors.New("[KIOSK]: PIN must be 4 to 6 digits")
+	ErrKioskWrongPin           = errors.New("[KIOSK]: Wrong PIN")
+	ErrKioskPinNotSet          = errors.New("[KIOSK]: No kiosk PIN set. Set one in your profile")
+	ErrKioskPinLocked          = errors.New("[KIOSK]: Too many wrong PINs. Try again later or scan the QR code")
+	ErrKioskPhotoRequired      = errors.New("[KIOSK]: A photo is required to punch")
+	ErrKioskTokenUsed          = errors.New("[KIOSK]: QR code already used. Scan the code on the kiosk again")
+	ErrKioskPhotoTooLarge      = errors.New("[KIOSK]: Photo is too large")
+	ErrKioskAlreadyTimedIn     = errors.New("[KIOSK]: Already timed in today")
+	ErrKioskNotTimedIn         = errors.New("[KIOSK]: No time in yet today")
+	ErrKioskAlreadyTimedOut    = errors.New("[KIOSK]: Already timed out today")
+	ErrKioskPhotoUnavailable   = errors.New("[KIOSK]: Photo not found")
+)
@@ -0,0 +1,11 @@
+package errs
+
+import "errors"
+
+var (
+	ErrLeave                    = errors.New("[LEAVE]: Error on leave credits service")
+	ErrLeaveInvalidType         = errors.New("[LEAVE]: Only vacation and sick leaves use leave credits")
+	ErrLeaveInvalidAdjustment   = errors.New("[LEAVE]: Adjustment must be a non-zero number of days with a note")
+	ErrLeaveInsufficientBalance = errors.New("[LEAVE]: Insufficient leave balance")
+	ErrLeaveInvalidDateHired    = errors.New("[LEAVE]: Staff has an invalid date hired")
+)
@@ -17,4 +17,5 @@ var (
 	ErrMemoNoRecipientEmails    = errors.New("[MEMO]: No recipients with email addresses found")
 	ErrMemoNotPublished         = errors.New("[MEMO]: Only published memos can send notification emails")
 	ErrMemoInvalidStatus        = errors.New("[MEMO]: Invalid status")
+	ErrMemoAcknowledgeByRange   = errors.New("[MEMO]: Acknowledge by date must be within the memo start and end dates")
 )
@@ -0,0 +1,14 @@
+package errs
+
+import "errors"
+
+var (
+	ErrPayroll               = errors.New("[PAYROLL]: Error on payroll service")
+	ErrPayrollInvalidPeriod  = errors.New("[PAYROLL]: Invalid pay period")
+	ErrPayrollInvalidRate    = errors.New("[PAYROLL]: Rate type and a positive rate are required")
+	ErrPayrollRunExists      = errors.New("[PAYROLL]: A payroll run already exists for this period")
+	ErrPayrollRunNotFound    = errors.New("[PAYROLL]: Payroll run not found")
+	ErrPayrollRunLocked      = errors.New("[PAYROLL]: Payroll run is locked and can no longer be changed")
+	ErrPayrollNoRatedStaff   = errors.New("[PAYROLL]: No staff has a pay rate yet")
+	ErrPayrollPayslipMissing = errors.New("[PAYROLL]: Payslip not found")
+)
@@ -0,0 +1,13 @@
+package errs
+
+import "errors"
+
+var (
+	ErrProductReview              = errors.New("[PRODUCT REVIEW]: Error on product review service")
+	ErrProductReviewNotEligible   = errors.New("[PRODUCT REVIEW]: Only customers with a delivered order of this product can leave a review")
+	ErrProductReviewAlreadyExists = errors.New("[PRODUCT REVIEW]: You have already reviewed this product")
+	ErrProductReviewInvalidRating = errors.New("[PRODUCT REVIEW]: Rating must be between 1 and 5")
+	ErrProductReviewTooManyPhotos = errors.New("[PRODUCT REVIEW]: Too many photos attached")
+	ErrProductReviewBodyTooLong   = errors.New("[PRODUCT REVIEW]: Review is too long")
+	ErrProductReviewNotPending    = errors.New("[PRODUCT REVIEW]: Review has already been moderated")
+)
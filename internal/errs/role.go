@@ -0,0 +1,10 @@
+package errs
+
+import "errors"
+
+var (
+	ErrRoleGroup             = errors.New("[ROLE]: Error on role group")
+	ErrRoleGroupNameRequired = errors.New("[ROLE]: Role group name is required")
+	ErrRoleGroupNotFound     = errors.New("[ROLE]: Role group not found")
+	ErrRoleForbidden         = errors.New("[ROLE]: You do not have permission to access this page")
+)
@@ -0,0 +1,14 @@
+package errs
+
+import "errors"
+
+var (
+	ErrSaleCampaign                = errors.New("[SALE CAMPAIGN]: Error on sale campaign service")
+	ErrSaleCampaignNotFound        = errors.New("[SALE CAMPAIGN]: Sale campaign not found")
+	ErrSaleCampaignNameRequired    = errors.New("[SALE CAMPAIGN]: Campaign name is required")
+	ErrSaleCampaignInvalidDiscount = errors.New("[SALE CAMPAIGN]: Invalid discount value")
+	ErrSaleCampaignInvalidSelector = errors.New("[SALE CAMPAIGN]: Select a brand, category or list of products")
+	ErrSaleCampaignNoProducts      = errors.New("[SALE CAMPAIGN]: No products matched the selection")
+	ErrSaleCampaignNotCancellable  = errors.New("[SALE CAMPAIGN]: Only scheduled or active campaigns can be cancelled")
+	ErrSaleCampaignEndsInPast      = errors.New("[SALE CAMPAIGN]: End date must be in the future")
+)
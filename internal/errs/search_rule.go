@@ -0,0 +1,10 @@
+package errs
+
+import "errors"
+
+var (
+	ErrSearchRule         = errors.New("[SEARCH RULE]: Error on search merchandising service")
+	ErrSearchRuleNotFound = errors.New("[SEARCH RULE]: Search rule not found")
+	ErrSearchRuleExists   = errors.New("[SEARCH RULE]: The same rule already exists for this search")
+	ErrSearchRuleTarget   = errors.New("[SEARCH RULE]: Choose an existing product, category or brand for the rule")
+)
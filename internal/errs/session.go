@@ -4,4 +4,7 @@ import "errors"
 
 var (
 	ErrSessionCheckoutLineProductIDs = errors.New("[SESSION]: Failed to cast product IDs to []string")
+	ErrSession                       = errors.New("[SESSION]: Error on session registry")
+	ErrSessionRevoked                = errors.New("[SESSION]: You were signed out, please log in again")
+	ErrSessionNotFound               = errors.New("[SESSION]: Session not found")
 )
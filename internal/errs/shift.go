@@ -0,0 +1,16 @@
+package errs
+
+import "errors"
+
+var (
+	ErrShift                = errors.New("[SHIFT]: Error on shift schedule service")
+	ErrShiftInvalid         = errors.New("[SHIFT]: Invalid shift. Time in and out must be HH:MM with time out after time in")
+	ErrShiftInvalidRotation = errors.New("[SHIFT]: Rotation must be 1 to 4 weeks with a valid start date")
+	ErrShiftInvalidSlot     = errors.New("[SHIFT]: Day is outside of the rotation")
+	ErrShiftInvalidDate     = errors.New("[SHIFT]: Invalid date")
+	ErrShiftOverrideMissing = errors.New("[SHIFT]: Shift override not found")
+	ErrShiftSwapInvalid     = errors.New("[SHIFT]: A swap needs another staff and a date from today onwards")
+	ErrShiftSwapSameShift   = errors.New("[SHIFT]: Both staff have the same shift on that date")
+	ErrShiftSwapPending     = errors.New("[SHIFT]: There is already a pending swap for that date")
+	ErrShiftSwapNotPending  = errors.New("[SHIFT]: Shift swap is no longer pending")
+)
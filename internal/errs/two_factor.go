@@ -0,0 +1,15 @@
+package errs
+
+import "errors"
+
+var (
+	ErrTwoFactor                = errors.New("[TWO_FACTOR]: Error on two-factor authentication")
+	ErrTwoFactorInvalidCode     = errors.New("[TWO_FACTOR]: Invalid or expired code")
+	ErrTwoFactorAlreadyEnabled  = errors.New("[TWO_FACTOR]: Two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled      = errors.New("[TWO_FACTOR]: Two-factor authentication is not enabled")
+	ErrTwoFactorNotEnrolling    = errors.New("[TWO_FACTOR]: Start the two-factor setup again")
+	ErrTwoFactorRequired        = errors.New("[TWO_FACTOR]: Two-factor authentication is required for superusers")
+	ErrTwoFactorPending         = errors.New("[TWO_FACTOR]: Your sign in expired, please log in again")
+	ErrTwoFactorTooManyAttempts = errors.New("[TWO_FACTOR]: Too many wrong codes, please try again in 15 minutes")
+	ErrTwoFactorRateLimited     = errors.New("[TWO_FACTOR]: Please wait a minute before requesting another code")
+)
@@ -0,0 +1,12 @@
+package errs
+
+import "errors"
+
+var (
+	ErrWishlist                          = errors.New("[WISHLIST]: Error on wishlist service")
+	ErrStockSubscription                 = errors.New("[STOCK SUBSCRIPTION]: Error on stock subscription")
+	ErrStockSubscriptionInStock          = errors.New("[STOCK SUBSCRIPTION]: Product is currently in stock")
+	ErrStockSubscriptionRateLimited      = errors.New("[STOCK SUBSCRIPTION]: Rate limited, please wait before requesting another confirmation email")
+	ErrStockSubscriptionInvalidToken     = errors.New("[STOCK SUBSCRIPTION]: Invalid or expired confirmation link")
+	ErrStockSubscriptionAlreadyConfirmed = errors.New("[STOCK SUBSCRIPTION]: You are already subscribed to this product")
+)
@@ -38,6 +38,8 @@ type EmailJobParams struct {
 	OrderID           *int64
 	CheckoutPaymentID *string
 	MemoID            *int64
+	ProductID         *int64
+	CheckoutID        *int64
 	Recipient         string
 	CC                string
 	Subject           string
@@ -48,6 +50,13 @@ type EmailJobParams struct {
 	TemplateName      enums.EmailTemplateName
 }
 
+func sqlFlavor(db database.IService) goqite.SQLFlavor {
+	if db.Driver() == database.DB_DRIVER_POSTGRES {
+		return goqite.SQLFlavorPostgreSQL
+	}
+	return goqite.SQLFlavorSQLite
+}
+
 type EmailJobRunner struct {
 	queue       *goqite.Queue
 	runner      *jobs.Runner
@@ -58,8 +67,9 @@ type EmailJobRunner struct {
 
 func NewEmailJobRunner(db *sql.DB, dbRO, dbRW database.IService, mailService mail.IMailService) *EmailJobRunner {
 	q := goqite.New(goqite.NewOpts{
-		DB:   db,
-		Name: EmailQueueName,
+		DB:        db,
+		Name:      EmailQueueName,
+		SQLFlavor: sqlFlavor(dbRW),
 	})
 
 	runner := jobs.NewRunner(jobs.NewRunnerOpts{
@@ -141,6 +151,12 @@ func (ejr *EmailJobRunner) QueueEmailJob(ctx context.Context, params EmailJobPar
 	if params.MemoID != nil {
 		insertParams.MemoID = sql.NullInt64{Int64: *params.MemoID, Valid: true}
 	}
+	if params.ProductID != nil {
+		insertParams.ProductID = sql.NullInt64{Int64: *params.ProductID, Valid: true}
+	}
+	if params.CheckoutID != nil {
+		insertParams.CheckoutID = sql.NullInt64{Int64: *params.CheckoutID, Valid: true}
+	}
 
 	emailJob, err := ejr.dbRW.GetQueries().InsertEmailJob(ctx, insertParams)
 	if err != nil {
@@ -213,12 +229,22 @@ func (ejr *EmailJobRunner) handleSendEmail(ctx context.Context, m []byte) error
 		return ejr.sendPaymentConfirmationEmail(ctx, emailJob, recipient, cc, emailJob.Subject)
 	case enums.EMAIL_TEMPLATE_CUSTOMER_VERIFICATION:
 		return ejr.sendCustomerVerificationEmail(ctx, emailJob, recipient, cc, emailJob.Subject)
+	case enums.EMAIL_TEMPLATE_STAFF_LOGIN_CODE:
+		return ejr.sendStaffLoginCodeEmail(ctx, emailJob, recipient, cc, emailJob.Subject)
 	case enums.EMAIL_TEMPLATE_PASSWORD_RESET:
 		return ejr.sendPasswordResetEmail(ctx, emailJob, recipient, cc, emailJob.Subject)
-	case enums.EMAIL_TEMPLATE_MEMO_NOTIFICATION:
-		return ejr.sendMemoNotificationEmail(ctx, emailJob, recipient, cc, emailJob.Subject)
+	case enums.EMAIL_TEMPLATE_MEMO_NOTIFICATION, enums.EMAIL_TEMPLATE_MEMO_REMINDER:
+		return ejr.sendMemoNotificationEmail(ctx, emailJob, templateName, recipient, cc, emailJob.Subject)
+	case enums.EMAIL_TEMPLATE_MEMO_ESCALATION:
+		return ejr.sendMemoEscalationEmail(ctx, emailJob, recipient, cc, emailJob.Subject)
 	case enums.EMAIL_TEMPLATE_ORDER_STATUS_UPDATE:
 		return ejr.sendOrderStatusUpdateEmail(ctx, emailJob, recipient, cc, emailJob.Subject)
+	case enums.EMAIL_TEMPLATE_STOCK_SUBSCRIPTION_CONFIRMATION,
+		enums.EMAIL_TEMPLATE_PRODUCT_BACK_IN_STOCK,
+		enums.EMAIL_TEMPLATE_PRODUCT_ON_SALE:
+		return ejr.sendProductNotificationEmail(ctx, emailJob, templateName, recipient, cc, emailJob.Subject)
+	case enums.EMAIL_TEMPLATE_ABANDONED_CART:
+		return ejr.sendAbandonedCartEmail(ctx, emailJob, recipient, cc, emailJob.Subject)
 	default:
 		err := fmt.Errorf("unknown template: %s", emailJob.TemplateName)
 		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
@@ -365,6 +391,32 @@ func (ejr *EmailJobRunner) sendCustomerVerificationEmail(ctx context.Context, em
 	return nil
 }
 
+func (ejr *EmailJobRunner) sendStaffLoginCodeEmail(ctx context.Context, emailJob queries.TblEmailJob, recipient string, cc []string, subject string) error {
+	const logtag = "[EmailJobRunner sendStaffLoginCodeEmail]"
+
+	cfg := conf.Conf()
+	templateData := mail.TemplateData{
+		"OTPCode":  emailJob.OtpCode.String,
+		"LogoURL":  constants.PathEmailLogoCDN,
+		"MobileNo": cfg.Settings.MobileNo,
+		"EMail":    cfg.Settings.EMail,
+	}
+
+	if err := ejr.mailService.SendTemplateEmail(recipient, cc, subject, enums.EMAIL_TEMPLATE_STAFF_LOGIN_CODE.FileName(), templateData); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		return errors.Join(errs.ErrJobsSendEmail, err)
+	}
+
+	logs.LogCtx(ctx).Info(
+		logtag,
+		zap.String("result", "success"),
+		zap.String("recipient", recipient),
+		zap.Strings("cc", cc),
+	)
+
+	return nil
+}
+
 func (ejr *EmailJobRunner) sendPasswordResetEmail(ctx context.Context, emailJob queries.TblEmailJob, recipient string, cc []string, subject string) error {
 	const logtag = "[EmailJobRunner sendPasswordResetEmail]"
 
@@ -391,7 +443,14 @@ func (ejr *EmailJobRunner) sendPasswordResetEmail(ctx context.Context, emailJob
 	return nil
 }
 
-func (ejr *EmailJobRunner) sendMemoNotificationEmail(ctx context.Context, emailJob queries.TblEmailJob, recipient string, cc []string, subject string) error {
+func (ejr *EmailJobRunner) sendMemoNotificationEmail(
+	ctx context.Context,
+	emailJob queries.TblEmailJob,
+	templateName enums.EmailTemplateName,
+	recipient string,
+	cc []string,
+	subject string,
+) error {
 	const logtag = "[EmailJobRunner sendMemoNotificationEmail]"
 
 	if !emailJob.MemoID.Valid {
@@ -435,7 +494,13 @@ func (ejr *EmailJobRunner) sendMemoNotificationEmail(ctx context.Context, emailJ
 		"EMail":           cfg.Settings.EMail,
 	}
 
-	if err := ejr.mailService.SendTemplateEmail(recipient, cc, subject, enums.EMAIL_TEMPLATE_MEMO_NOTIFICATION.FileName(), templateData); err != nil {
+	if row.TblMemo.AcknowledgeBy.Valid {
+		if acknowledgeBy, err := time.Parse(constants.DateLayoutISO, row.TblMemo.AcknowledgeBy.String); err == nil {
+			templateData["AcknowledgeBy"] = acknowledgeBy.Format(constants.DateLayoutDisplay)
+		}
+	}
+
+	if err := ejr.mailService.SendTemplateEmail(recipient, cc, subject, templateName.FileName(), templateData); err != nil {
 		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
 		return errors.Join(errs.ErrJobsSendEmail, err)
 	}
@@ -444,6 +509,7 @@ func (ejr *EmailJobRunner) sendMemoNotificationEmail(ctx context.Context, emailJ
 		logtag,
 		zap.String("result", "success"),
 		zap.Int64("memo_id", emailJob.MemoID.Int64),
+		zap.String("template", templateName.String()),
 		zap.String("recipient", recipient),
 		zap.Strings("cc", cc),
 	)
@@ -451,6 +517,82 @@ func (ejr *EmailJobRunner) sendMemoNotificationEmail(ctx context.Context, emailJ
 	return nil
 }
 
+func (ejr *EmailJobRunner) sendMemoEscalationEmail(
+	ctx context.Context,
+	emailJob queries.TblEmailJob,
+	recipient string,
+	cc []string,
+	subject string,
+) error {
+	const logtag = "[EmailJobRunner sendMemoEscalationEmail]"
+
+	if !emailJob.MemoID.Valid {
+		return errs.ErrMemoNotFound
+	}
+
+	row, err := ejr.dbRO.GetQueries().GetMemoWithCreatorByID(ctx, emailJob.MemoID.Int64)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Int64("memo_id", emailJob.MemoID.Int64), zap.Error(err))
+		return errors.Join(errs.ErrMemoNotFound, err)
+	}
+
+	recipients, err := ejr.dbRO.GetQueries().GetMemoRecipientsWithActions(ctx, emailJob.MemoID.Int64)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Int64("memo_id", emailJob.MemoID.Int64), zap.Error(err))
+		return errors.Join(errs.ErrMemo, err)
+	}
+
+	overdue := make([]map[string]any, 0, len(recipients))
+	for _, r := range recipients {
+		if r.ActionStatus.Valid {
+			continue
+		}
+		overdue = append(overdue, map[string]any{
+			"Name":     utils.BuildFullName(r.FirstName, r.MiddleName.String, r.LastName),
+			"Position": r.Position,
+		})
+	}
+	if len(overdue) == 0 {
+		logs.LogCtx(ctx).Info(logtag, zap.Int64("memo_id", emailJob.MemoID.Int64), zap.String("result", "skipped (all acknowledged)"))
+		return nil
+	}
+
+	acknowledgeBy := row.TblMemo.AcknowledgeBy.String
+	if t, err := time.Parse(constants.DateLayoutISO, acknowledgeBy); err == nil {
+		acknowledgeBy = t.Format(constants.DateLayoutDisplay)
+	}
+
+	cfg := conf.Conf()
+	templateData := mail.TemplateData{
+		"LogoURL":         constants.PathEmailLogoCDN,
+		"CreatorName":     utils.BuildFullName(row.CreatorFirstName, row.CreatorMiddleName.String, row.CreatorLastName),
+		"CreatorPosition": row.CreatorPosition,
+		"Title":           row.TblMemo.Title,
+		"AcknowledgeBy":   acknowledgeBy,
+		"OverdueStaff":    overdue,
+		"Acknowledged":    len(recipients) - len(overdue),
+		"Total":           len(recipients),
+		"DashboardURL":    utils.FullURL("/admin/memos"),
+		"MobileNo":        cfg.Settings.MobileNo,
+		"EMail":           cfg.Settings.EMail,
+	}
+
+	if err := ejr.mailService.SendTemplateEmail(recipient, cc, subject, enums.EMAIL_TEMPLATE_MEMO_ESCALATION.FileName(), templateData); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		return errors.Join(errs.ErrJobsSendEmail, err)
+	}
+
+	logs.LogCtx(ctx).Info(
+		logtag,
+		zap.String("result", "success"),
+		zap.Int64("memo_id", emailJob.MemoID.Int64),
+		zap.Int("overdue_count", len(overdue)),
+		zap.String("recipient", recipient),
+	)
+
+	return nil
+}
+
 func (ejr *EmailJobRunner) QueueOrderStatusUpdateEmail(ctx context.Context, order queries.TblOrder) error {
 	cfg := conf.Conf()
 	orderID := order.ID
@@ -529,6 +671,134 @@ func (ejr *EmailJobRunner) sendOrderStatusUpdateEmail(
 	return nil
 }
 
+func (ejr *EmailJobRunner) sendProductNotificationEmail(
+	ctx context.Context,
+	emailJob queries.TblEmailJob,
+	templateName enums.EmailTemplateName,
+	recipient string,
+	cc []string,
+	subject string,
+) error {
+	const logtag = "[EmailJobRunner sendProductNotificationEmail]"
+
+	if !emailJob.ProductID.Valid {
+		return errs.ErrJobsProductNotFound
+	}
+
+	product, err := ejr.dbRO.GetQueries().GetProductForNotificationEmail(ctx, emailJob.ProductID.Int64)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Int64("product_id", emailJob.ProductID.Int64), zap.Error(err))
+		return errors.Join(errs.ErrJobsProductNotFound, err)
+	}
+
+	var salePrice string
+	if product.SalePriceWithVat > 0 {
+		salePrice = utils.NewMoney(product.SalePriceWithVat, product.UnitPriceWithVatCurrency).Display()
+	}
+
+	cfg := conf.Conf()
+	templateData := mail.TemplateData{
+		"LogoURL":     constants.PathEmailLogoCDN,
+		"ProductName": product.Name,
+		"ProductURL":  utils.FullURL("/product/" + product.Slug),
+		"ImageURL":    product.CdnUrlThumbnail,
+		"Price":       utils.NewMoney(product.UnitPriceWithVat, product.UnitPriceWithVatCurrency).Display(),
+		"SalePrice":   salePrice,
+		"SaleEndsAt":  product.SaleEndsAt,
+		"ConfirmLink": emailJob.OtpCode.String,
+		"MobileNo":    cfg.Settings.MobileNo,
+		"EMail":       cfg.Settings.EMail,
+	}
+
+	if err := ejr.mailService.SendTemplateEmail(recipient, cc, subject, templateName.FileName(), templateData); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		return errors.Join(errs.ErrJobsSendEmail, err)
+	}
+
+	logs.LogCtx(ctx).Info(
+		logtag,
+		zap.String("result", "success"),
+		zap.Int64("product_id", product.ID),
+		zap.Stringer("template", templateName),
+		zap.String("recipient", recipient),
+		zap.Strings("cc", cc),
+	)
+
+	return nil
+}
+
+func (ejr *EmailJobRunner) sendAbandonedCartEmail(
+	ctx context.Context,
+	emailJob queries.TblEmailJob,
+	recipient string,
+	cc []string,
+	subject string,
+) error {
+	const logtag = "[EmailJobRunner sendAbandonedCartEmail]"
+
+	if !emailJob.CheckoutID.Valid {
+		return errs.ErrJobsCheckoutNotFound
+	}
+
+	checkoutLines, err := ejr.dbRO.GetQueries().GetCheckoutLinesByCheckoutID(ctx, emailJob.CheckoutID.Int64)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Int64("checkout_id", emailJob.CheckoutID.Int64), zap.Error(err))
+		return errors.Join(errs.ErrJobsCheckoutNotFound, err)
+	}
+	if len(checkoutLines) == 0 {
+		return errs.ErrJobsCheckoutNotFound
+	}
+
+	lineItems := make([]map[string]any, 0, len(checkoutLines))
+	subtotal := utils.NewMoney(0, constants.PHP)
+	for _, line := range checkoutLines {
+		_, price, _ := utils.GetOrigAndDiscounted(
+			line.IsOnSale,
+			line.UnitPriceWithVat,
+			line.UnitPriceWithVatCurrency,
+			line.SalePriceWithVat,
+			line.SalePriceWithVatCurrency,
+		)
+		lineTotal := price.Multiply(line.Quantity)
+		if sum, err := subtotal.Add(lineTotal); err == nil {
+			subtotal = sum
+		}
+		lineItems = append(lineItems, map[string]any{
+			"Name":      line.Name,
+			"BrandName": line.BrandName,
+			"Quantity":  line.Quantity,
+			"Price":     lineTotal.Display(),
+			"ImageURL":  line.CdnUrlThumbnail.String,
+		})
+	}
+
+	cfg := conf.Conf()
+	templateData := mail.TemplateData{
+		"LogoURL":    constants.PathEmailLogoCDN,
+		"LineItems":  lineItems,
+		"Subtotal":   subtotal.Display(),
+		"ResumeLink": emailJob.OtpCode.String,
+		"MobileNo":   cfg.Settings.MobileNo,
+		"EMail":      cfg.Settings.EMail,
+	}
+
+	if err := ejr.mailService.SendTemplateEmail(recipient, cc, subject, enums.EMAIL_TEMPLATE_ABANDONED_CART.FileName(), templateData); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		return errors.Join(errs.ErrJobsSendEmail, err)
+	}
+
+	logs.LogCtx(ctx).Info(
+		logtag,
+		zap.String("result", "success"),
+		zap.Int64("checkout_id", emailJob.CheckoutID.Int64),
+		zap.Int("line_items", len(lineItems)),
+		zap.String("recipient", recipient),
+		zap.Strings("cc", cc),
+	)
+
+	return nil
+}
+
 func buildAddress(line1, line2, city, state, postalCode string) string {
 	parts := []string{}
 	if line1 != "" {
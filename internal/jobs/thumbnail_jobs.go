@@ -59,8 +59,9 @@ func NewThumbnailJobRunner(db *sql.DB, dbRO, dbRW database.IService, thumbnailSe
 	}
 
 	q := goqite.New(goqite.NewOpts{
-		DB:   db,
-		Name: ThumbnailQueueName,
+		DB:        db,
+		Name:      ThumbnailQueueName,
+		SQLFlavor: sqlFlavor(dbRW),
 	})
 
 	runner := jobs.NewRunner(jobs.NewRunnerOpts{
@@ -0,0 +1,159 @@
+package leave
+
+import (
+	"fmt"
+	"time"
+
+	"cchoice/internal/enums"
+)
+
+// Credits are leave amounts in hundredths of a day, the same way money is kept
+// in cents, so a monthly accrual of a twelfth of the yearly entitlement does
+// not need floats.
+type Credits int64
+
+const CreditsPerDay Credits = 100
+
+func Days(n int64) Credits {
+	return Credits(n) * CreditsPerDay
+}
+
+// String formats the credits as days with two decimals, e.g. "1.25".
+func (c Credits) String() string {
+	sign := ""
+	if c < 0 {
+		sign = "-"
+		c = -c
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, c/CreditsPerDay, c%CreditsPerDay)
+}
+
+// Tier is the yearly entitlement once a staff has at least MinYears of tenure.
+type Tier struct {
+	MinYears    int
+	DaysPerYear int64
+}
+
+// Policy holds the rules for one leave type. Unused credits above
+// CarryOverCap at the end of a year are taken out as an ExcessEntry, which is
+// either a cash conversion or a forfeit.
+type Policy struct {
+	Tiers        []Tier
+	CarryOverCap Credits
+	ExcessEntry  enums.LeaveEntryType
+}
+
+var policies = map[enums.TimeOff]Policy{
+	enums.TIME_OFF_VL: {
+		Tiers: []Tier{
+			{MinYears: 0, DaysPerYear: 5},
+			{MinYears: 1, DaysPerYear: 7},
+			{MinYears: 3, DaysPerYear: 10},
+			{MinYears: 5, DaysPerYear: 15},
+		},
+		CarryOverCap: Days(5),
+		ExcessEntry:  enums.LEAVE_ENTRY_TYPE_CONVERSION,
+	},
+	enums.TIME_OFF_SL: {
+		Tiers: []Tier{
+			{MinYears: 0, DaysPerYear: 5},
+			{MinYears: 1, DaysPerYear: 7},
+			{MinYears: 5, DaysPerYear: 10},
+		},
+		CarryOverCap: Days(10),
+		ExcessEntry:  enums.LEAVE_ENTRY_TYPE_FORFEIT,
+	},
+}
+
+// Types lists the time off types that use leave credits.
+var Types = []enums.TimeOff{enums.TIME_OFF_VL, enums.TIME_OFF_SL}
+
+func IsCredited(t enums.TimeOff) bool {
+	_, ok := policies[t]
+	return ok
+}
+
+func PolicyFor(t enums.TimeOff) (Policy, bool) {
+	p, ok := policies[t]
+	return p, ok
+}
+
+// TenureYears counts the full years between the hire date and the given date.
+func TenureYears(hired, on time.Time) int {
+	if on.Before(hired) {
+		return 0
+	}
+	years := on.Year() - hired.Year()
+	if on.Month() < hired.Month() || (on.Month() == hired.Month() && on.Day() < hired.Day()) {
+		years--
+	}
+	return years
+}
+
+// Entitlement is the yearly number of leave credits for the tenure the staff
+// has on the given date.
+func (p Policy) Entitlement(hired, on time.Time) Credits {
+	tenure := TenureYears(hired, on)
+	var days int64
+	for _, tier := range p.Tiers {
+		if tenure >= tier.MinYears {
+			days = tier.DaysPerYear
+		}
+	}
+	return Days(days)
+}
+
+// Accrual is the credit earned for one month, keyed by its "YYYY-MM" period.
+type Accrual struct {
+	Period  string
+	Credits Credits
+}
+
+// Accruals lists the monthly credits of a year that are due as of the given
+// date. A month is credited once it has ended, and only if the staff was
+// already hired on its first day. Each month earns the difference between the
+// rounded running totals so twelve months always add up to the entitlement.
+func (p Policy) Accruals(hired time.Time, year int, asOf time.Time) []Accrual {
+	res := make([]Accrual, 0, 12)
+	for month := 1; month <= 12; month++ {
+		start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		if start.AddDate(0, 1, 0).After(asOf) {
+			break
+		}
+		if start.Before(hired) {
+			continue
+		}
+		yearly := int64(p.Entitlement(hired, start))
+		credits := roundedTwelfth(yearly, int64(month)) - roundedTwelfth(yearly, int64(month-1))
+		if credits == 0 {
+			continue
+		}
+		res = append(res, Accrual{
+			Period:  start.Format("2006-01"),
+			Credits: Credits(credits),
+		})
+	}
+	return res
+}
+
+func roundedTwelfth(yearly, months int64) int64 {
+	return (yearly*months + 6) / 12
+}
+
+// Excess is the part of a year-end balance that cannot be carried over.
+func (p Policy) Excess(balance Credits) Credits {
+	return max(0, balance-p.CarryOverCap)
+}
+
+// RequestCredits is the number of credits a time off from start to end, both
+// inclusive, uses. Only the days isWorkday reports are counted, so rest days
+// and holidays inside the range cost nothing.
+func RequestCredits(start, end time.Time, isWorkday func(time.Time) bool) Credits {
+	var days int64
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if isWorkday(d) {
+			days++
+		}
+	}
+	return Days(days)
+}
@@ -0,0 +1,125 @@
+package leave
+
+import (
+	"testing"
+	"time"
+
+	"cchoice/internal/enums"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCreditsString(t *testing.T) {
+	assert.Equal(t, "0.00", Credits(0).String())
+	assert.Equal(t, "1.25", Credits(125).String())
+	assert.Equal(t, "-0.42", Credits(-42).String())
+	assert.Equal(t, "5.00", Days(5).String())
+}
+
+func TestTenureYears(t *testing.T) {
+	hired := date(2020, time.March, 15)
+	assert.Equal(t, 0, TenureYears(hired, date(2019, time.January, 1)))
+	assert.Equal(t, 0, TenureYears(hired, date(2021, time.March, 14)))
+	assert.Equal(t, 1, TenureYears(hired, date(2021, time.March, 15)))
+	assert.Equal(t, 5, TenureYears(hired, date(2026, time.January, 1)))
+}
+
+func TestEntitlement(t *testing.T) {
+	vl, ok := PolicyFor(enums.TIME_OFF_VL)
+	require.True(t, ok)
+	hired := date(2020, time.January, 1)
+	assert.Equal(t, Days(5), vl.Entitlement(hired, date(2020, time.June, 1)))
+	assert.Equal(t, Days(7), vl.Entitlement(hired, date(2021, time.June, 1)))
+	assert.Equal(t, Days(10), vl.Entitlement(hired, date(2023, time.January, 1)))
+	assert.Equal(t, Days(15), vl.Entitlement(hired, date(2030, time.January, 1)))
+
+	_, ok = PolicyFor(enums.TIME_OFF_ABSENT)
+	assert.False(t, ok)
+	assert.False(t, IsCredited(enums.TIME_OFF_ABSENT))
+	assert.True(t, IsCredited(enums.TIME_OFF_SL))
+}
+
+func TestAccruals(t *testing.T) {
+	vl, _ := PolicyFor(enums.TIME_OFF_VL)
+
+	t.Run("full year adds up to the entitlement", func(t *testing.T) {
+		accruals := vl.Accruals(date(2018, time.January, 1), 2026, date(2027, time.January, 1))
+		require.Len(t, accruals, 12)
+		var total Credits
+		for _, a := range accruals {
+			total += a.Credits
+		}
+		assert.Equal(t, Days(15), total)
+		assert.Equal(t, "2026-01", accruals[0].Period)
+		assert.Equal(t, Credits(125), accruals[0].Credits)
+	})
+
+	t.Run("only completed months are credited", func(t *testing.T) {
+		accruals := vl.Accruals(date(2018, time.January, 1), 2026, date(2026, time.March, 31))
+		require.Len(t, accruals, 2)
+		assert.Equal(t, "2026-02", accruals[1].Period)
+	})
+
+	t.Run("hire month is skipped unless hired on the first", func(t *testing.T) {
+		accruals := vl.Accruals(date(2026, time.March, 10), 2026, date(2026, time.June, 1))
+		require.Len(t, accruals, 2)
+		assert.Equal(t, "2026-04", accruals[0].Period)
+		assert.Equal(t, Credits(42), accruals[0].Credits)
+		assert.Equal(t, Credits(41), accruals[1].Credits)
+
+		accruals = vl.Accruals(date(2026, time.March, 1), 2026, date(2026, time.June, 1))
+		assert.Len(t, accruals, 3)
+	})
+
+	t.Run("tier change applies from the month it is reached", func(t *testing.T) {
+		accruals := vl.Accruals(date(2025, time.July, 1), 2026, date(2027, time.January, 1))
+		require.Len(t, accruals, 12)
+		var firstHalf, secondHalf Credits
+		for i, a := range accruals {
+			if i < 6 {
+				firstHalf += a.Credits
+			} else {
+				secondHalf += a.Credits
+			}
+		}
+		assert.Equal(t, Credits(250), firstHalf)
+		assert.Equal(t, Credits(350), secondHalf)
+	})
+}
+
+func TestExcess(t *testing.T) {
+	vl, _ := PolicyFor(enums.TIME_OFF_VL)
+	assert.Equal(t, Credits(0), vl.Excess(Days(3)))
+	assert.Equal(t, Credits(0), vl.Excess(Days(-2)))
+	assert.Equal(t, Credits(250), vl.Excess(Credits(750)))
+	assert.Equal(t, enums.LEAVE_ENTRY_TYPE_CONVERSION, vl.ExcessEntry)
+
+	sl, _ := PolicyFor(enums.TIME_OFF_SL)
+	assert.Equal(t, Days(2), sl.Excess(Days(12)))
+	assert.Equal(t, enums.LEAVE_ENTRY_TYPE_FORFEIT, sl.ExcessEntry)
+}
+
+func TestRequestCredits(t *testing.T) {
+	everyDay := func(time.Time) bool { return true }
+	assert.Equal(t, Days(1), RequestCredits(date(2026, time.May, 4), date(2026, time.May, 4), everyDay))
+	assert.Equal(t, Days(3), RequestCredits(date(2026, time.May, 30), date(2026, time.June, 1), everyDay))
+	assert.Equal(t, Credits(0), RequestCredits(date(2026, time.May, 4), date(2026, time.May, 3), everyDay))
+
+	t.Run("skips the weekend and holidays", func(t *testing.T) {
+		weekdays := func(d time.Time) bool {
+			return d.Weekday() != time.Saturday && d.Weekday() != time.Sunday
+		}
+		// Friday to Monday.
+		assert.Equal(t, Days(2), RequestCredits(date(2026, time.May, 8), date(2026, time.May, 11), weekdays))
+
+		holiday := date(2026, time.May, 11)
+		workdays := func(d time.Time) bool { return weekdays(d) && !d.Equal(holiday) }
+		assert.Equal(t, Days(1), RequestCredits(date(2026, time.May, 8), date(2026, time.May, 11), workdays))
+		assert.Equal(t, Credits(0), RequestCredits(date(2026, time.May, 9), date(2026, time.May, 10), workdays))
+	})
+}
@@ -71,6 +71,13 @@ func Log() *zap.Logger {
 	return logger
 }
 
+// SetLogger replaces the logger InitLog would build from the configuration,
+// e.g. with zap.NewNop() in tests that run without one.
+func SetLogger(l *zap.Logger) {
+	loggerOnce.Do(func() {})
+	logger = l
+}
+
 func LogCtx(ctx context.Context) *zap.Logger {
 	logger := Log()
 
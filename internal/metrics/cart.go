@@ -19,10 +19,27 @@ var (
 		},
 		[]string{"result"},
 	)
+	cartAbandonedRemindersTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "cchoice",
+			Subsystem: "cart",
+			Name:      "abandoned_reminders_total",
+			Help:      "Total abandoned cart reminder emails queued",
+		},
+		[]string{"result"},
+	)
+	cartRecoveredOrdersTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "cchoice",
+			Subsystem: "cart",
+			Name:      "recovered_orders_total",
+			Help:      "Total orders placed from an abandoned cart reminder",
+		},
+	)
 )
 
 func init() {
-	prometheus.MustRegister(cartCheckoutAttemptsTotal)
+	prometheus.MustRegister(cartCheckoutAttemptsTotal, cartAbandonedRemindersTotal, cartRecoveredOrdersTotal)
 }
 
 type metricsCart struct{}
@@ -31,4 +48,12 @@ func (c *metricsCart) CheckoutAttempt(result string) {
 	cartCheckoutAttemptsTotal.WithLabelValues(result).Inc()
 }
 
+func (c *metricsCart) AbandonedReminder(result string) {
+	cartAbandonedRemindersTotal.WithLabelValues(result).Inc()
+}
+
+func (c *metricsCart) RecoveredOrder() {
+	cartRecoveredOrdersTotal.Inc()
+}
+
 var Cart metricsCart
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	dbQueryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "cchoice",
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Latency of database queries by sqlc query name",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		},
+		[]string{"query", "mode"},
+	)
+	dbQueryErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "cchoice",
+			Subsystem: "db",
+			Name:      "query_errors_total",
+			Help:      "Total failed database queries by sqlc query name",
+		},
+		[]string{"query", "mode"},
+	)
+	dbTxRetries = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "cchoice",
+			Subsystem: "db",
+			Name:      "tx_busy_retries_total",
+			Help:      "Total transactions retried because the database was busy",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		dbQueryDuration,
+		dbQueryErrors,
+		dbTxRetries,
+	)
+}
+
+type metricsDB struct{}
+
+func (m *metricsDB) Query(query string, mode string, d time.Duration, failed bool) {
+	dbQueryDuration.WithLabelValues(query, mode).Observe(d.Seconds())
+	if failed {
+		dbQueryErrors.WithLabelValues(query, mode).Inc()
+	}
+}
+
+func (m *metricsDB) TxRetry() {
+	dbTxRetries.Inc()
+}
+
+var DB metricsDB
@@ -14,10 +14,27 @@ var (
 		},
 		[]string{"event", "value"},
 	)
+	clientEventsStored = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "cchoice",
+			Subsystem: "client",
+			Name:      "events_stored_total",
+			Help:      "Total client events written to the event store",
+		},
+	)
+	clientEventsDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "cchoice",
+			Subsystem: "client",
+			Name:      "events_dropped_total",
+			Help:      "Total client events that never reached the event store",
+		},
+		[]string{"reason"},
+	)
 )
 
 func init() {
-	prometheus.MustRegister(clientEvent)
+	prometheus.MustRegister(clientEvent, clientEventsStored, clientEventsDropped)
 }
 
 type metricsClientEvent struct{}
@@ -26,4 +43,12 @@ func (c *metricsClientEvent) ClientEventHit(event string, value string) {
 	clientEvent.WithLabelValues(event, value).Inc()
 }
 
+func (c *metricsClientEvent) Stored(n int) {
+	clientEventsStored.Add(float64(n))
+}
+
+func (c *metricsClientEvent) Dropped(reason string, n int) {
+	clientEventsDropped.WithLabelValues(reason).Add(float64(n))
+}
+
 var ClientEvent metricsClientEvent
@@ -28,8 +28,13 @@ const (
 	EventBrandsSidePanelClick   = "brands_side_panel_click"
 	EventProductExternalLinkClick = "product_external_link_click"
 	EventCheckedPaymentMethod   = "checked_payment_method"
+	EventSearchResultClick      = "search_result_click"
 )
 
+// EventSearchResults is recorded by the server, not the client, so it carries
+// the number of products the search returned.
+const EventSearchResults = "search_results"
+
 // Visit values.
 const (
 	VisitHomepage       = "homepage"
@@ -106,12 +111,21 @@ var allowedClientEvents = map[string]struct{}{
 	EventBrandsSidePanelClick:     {},
 	EventProductExternalLinkClick: {},
 	EventCheckedPaymentMethod:     {},
+	EventSearchResultClick:        {},
 }
 
 var highCardinalityEvents = map[string]struct{}{
-	EventSearchDesktop:    {},
-	EventSearchMobile:     {},
-	EventOrderTrackSearch: {},
+	EventSearchDesktop:     {},
+	EventSearchMobile:      {},
+	EventOrderTrackSearch:  {},
+	EventSearchResultClick: {},
+}
+
+// productEvents send an encoded product ID as their value.
+var productEvents = map[string]struct{}{
+	EventAddToCart:         {},
+	EventProductClick:      {},
+	EventPromoProductClick: {},
 }
 
 func IsAllowedClientEvent(event string) bool {
@@ -119,7 +133,26 @@ func IsAllowedClientEvent(event string) bool {
 	return ok
 }
 
+func IsProductEvent(event string) bool {
+	_, ok := productEvents[event]
+	return ok
+}
+
 func SanitizeClientEventValue(event, value string) string {
+	maxLen := maxEventValueLen
+	if _, ok := highCardinalityEvents[event]; ok {
+		maxLen = highCardinalityValueLen
+	}
+	return sanitizeEventValue(value, maxLen)
+}
+
+// SanitizeStoredEventValue keeps the full value up to maxEventValueLen since
+// the event store is not bound by label cardinality.
+func SanitizeStoredEventValue(value string) string {
+	return sanitizeEventValue(value, maxEventValueLen)
+}
+
+func sanitizeEventValue(value string, maxLen int) string {
 	value = strings.Map(func(r rune) rune {
 		if r == '\n' || r == '\r' {
 			return -1
@@ -130,11 +163,6 @@ func SanitizeClientEventValue(event, value string) string {
 		return r
 	}, value)
 
-	maxLen := maxEventValueLen
-	if _, ok := highCardinalityEvents[event]; ok {
-		maxLen = highCardinalityValueLen
-	}
-
 	if len(value) > maxLen {
 		value = value[:maxLen]
 	}
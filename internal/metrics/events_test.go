@@ -65,3 +65,12 @@ func TestSanitizeClientEventValue(t *testing.T) {
 		})
 	}
 }
+
+func TestSanitizeStoredEventValue(t *testing.T) {
+	t.Parallel()
+
+	query := strings.Repeat("q", highCardinalityValueLen+10)
+	assert.Equal(t, query, SanitizeStoredEventValue(query))
+	assert.Equal(t, "drill bits", SanitizeStoredEventValue("drill\r\n bits"))
+	assert.Equal(t, strings.Repeat("a", maxEventValueLen), SanitizeStoredEventValue(strings.Repeat("a", maxEventValueLen+1)))
+}
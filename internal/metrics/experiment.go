@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	experimentExposuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "cchoice",
+			Subsystem: "experiment",
+			Name:      "exposures_total",
+			Help:      "Total first-time visitor exposures per experiment variant",
+		},
+		[]string{"experiment", "variant"},
+	)
+	experimentAddToCartTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "cchoice",
+			Subsystem: "experiment",
+			Name:      "add_to_cart_total",
+			Help:      "Total exposed visitors who added to cart per experiment variant",
+		},
+		[]string{"experiment", "variant"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(experimentExposuresTotal, experimentAddToCartTotal)
+}
+
+type metricsExperiment struct{}
+
+func (e *metricsExperiment) Exposure(experiment, variant string) {
+	experimentExposuresTotal.WithLabelValues(experiment, variant).Inc()
+}
+
+func (e *metricsExperiment) AddToCart(experiment, variant string) {
+	experimentAddToCartTotal.WithLabelValues(experiment, variant).Inc()
+}
+
+var Experiment metricsExperiment
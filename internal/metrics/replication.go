@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	replicationLag = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "cchoice",
+			Subsystem: "db_replication",
+			Name:      "lag_seconds",
+			Help:      "Seconds since every committed WAL frame was last shipped to the replica",
+		},
+	)
+	replicationFrames = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "cchoice",
+			Subsystem: "db_replication",
+			Name:      "frames_total",
+			Help:      "Total WAL frames shipped to the replica",
+		},
+	)
+	replicationBytes = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "cchoice",
+			Subsystem: "db_replication",
+			Name:      "bytes_total",
+			Help:      "Total uncompressed WAL bytes shipped to the replica",
+		},
+	)
+	replicationGenerations = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "cchoice",
+			Subsystem: "db_replication",
+			Name:      "generations_total",
+			Help:      "Total replica generations started, each beginning with a full snapshot",
+		},
+	)
+	replicationErrors = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "cchoice",
+			Subsystem: "db_replication",
+			Name:      "errors_total",
+			Help:      "Total failed replication syncs",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		replicationLag,
+		replicationFrames,
+		replicationBytes,
+		replicationGenerations,
+		replicationErrors,
+	)
+}
+
+type metricsReplication struct{}
+
+func (m *metricsReplication) Lag(d time.Duration) {
+	replicationLag.Set(d.Seconds())
+}
+
+func (m *metricsReplication) Shipped(frames int, bytes int64) {
+	replicationFrames.Add(float64(frames))
+	replicationBytes.Add(float64(bytes))
+}
+
+func (m *metricsReplication) Generation() {
+	replicationGenerations.Inc()
+}
+
+func (m *metricsReplication) Error() {
+	replicationErrors.Inc()
+}
+
+var Replication metricsReplication
@@ -56,6 +56,12 @@ func (rl *RateLimiter) Stop() {
 }
 
 func (rl *RateLimiter) getIP(r *http.Request) string {
+	return ClientIP(r)
+}
+
+// ClientIP is the address of the visitor. Forwarding headers are only
+// believed when the request comes from a local or private proxy.
+func ClientIP(r *http.Request) string {
 	if isTrustedProxy(r.RemoteAddr) {
 		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
 			parts := strings.Split(forwarded, ",")
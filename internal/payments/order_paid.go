@@ -79,115 +79,105 @@ func OnOrderPaid(ctx context.Context, params OnOrderPaidParams) (*OnOrderPaidRes
 		return nil, err
 	}
 
-	tx, err := params.DBRW.GetDB().BeginTx(ctx, nil)
-	if err != nil {
-		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
-		return nil, err
-	}
-	defer func() {
-		if err := tx.Rollback(); err != nil {
-			logs.LogCtx(ctx).Debug(logtag, zap.Error(err))
+	var updatedOrder queries.TblOrder
+	err = params.DBRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		updatedCheckoutPayment, err := qtx.UpdateCheckoutPaymentOnSuccess(ctx, queries.UpdateCheckoutPaymentOnSuccessParams{
+			Status: enums.PAYMENT_STATUS_PAID.String(),
+			ID:     checkoutPayment.ID,
+		})
+		if err != nil {
+			logs.LogCtx(ctx).Error(
+				logtag,
+				zap.String("checkout_payment_id", checkoutPayment.ID),
+				zap.Error(err),
+			)
+			return err
 		}
-	}()
-
-	qtx := params.DBRW.GetQueries().WithTx(tx)
-
-	updatedCheckoutPayment, err := qtx.UpdateCheckoutPaymentOnSuccess(ctx, queries.UpdateCheckoutPaymentOnSuccessParams{
-		Status: enums.PAYMENT_STATUS_PAID.String(),
-		ID:     checkoutPayment.ID,
-	})
-	if err != nil {
-		logs.LogCtx(ctx).Error(
+		logs.LogCtx(ctx).Info(
 			logtag,
-			zap.String("checkout_payment_id", checkoutPayment.ID),
-			zap.Error(err),
+			zap.String("action", "updated_checkout_payment"),
+			zap.String("checkout_payment_id", updatedCheckoutPayment.ID),
+			zap.String("new_status", updatedCheckoutPayment.Status),
 		)
-		return nil, err
-	}
-	logs.LogCtx(ctx).Info(
-		logtag,
-		zap.String("action", "updated_checkout_payment"),
-		zap.String("checkout_payment_id", updatedCheckoutPayment.ID),
-		zap.String("new_status", updatedCheckoutPayment.Status),
-	)
 
-	updatedCheckout, err := qtx.UpdateCheckoutStatus(ctx, queries.UpdateCheckoutStatusParams{
-		Status: enums.CHECKOUT_STATUS_COMPLETED.String(),
-		ID:     order.CheckoutID,
-	})
-	if err != nil {
-		logs.LogCtx(ctx).Error(
+		updatedCheckout, err := qtx.UpdateCheckoutStatus(ctx, queries.UpdateCheckoutStatusParams{
+			Status: enums.CHECKOUT_STATUS_COMPLETED.String(),
+			ID:     order.CheckoutID,
+		})
+		if err != nil {
+			logs.LogCtx(ctx).Error(
+				logtag,
+				zap.Int64("checkout_id", order.CheckoutID),
+				zap.Error(err),
+			)
+			return err
+		}
+		logs.LogCtx(ctx).Info(
 			logtag,
-			zap.Int64("checkout_id", order.CheckoutID),
-			zap.Error(err),
+			zap.String("action", "updated_checkout"),
+			zap.Int64("checkout_id", updatedCheckout.ID),
+			zap.String("new_status", updatedCheckout.Status),
 		)
-		return nil, err
-	}
-	logs.LogCtx(ctx).Info(
-		logtag,
-		zap.String("action", "updated_checkout"),
-		zap.Int64("checkout_id", updatedCheckout.ID),
-		zap.String("new_status", updatedCheckout.Status),
-	)
 
-	earnedCPoints := int64(0)
-	if params.CPointAwarder != nil && order.CustomerID.Valid {
-		earnedCPoints = utils.CalculateOrderEarnedCPoints(order.TotalAmount)
-		if earnedCPoints > 0 {
-			if _, err := params.CPointAwarder.AwardForPaidOrder(ctx, qtx, order); err != nil {
-				logs.LogCtx(ctx).Error(
-					logtag,
-					zap.Int64("order_id", order.ID),
-					zap.String("action", "award_cpoints"),
-					zap.Error(err),
-				)
-				return nil, err
+		earnedCPoints := int64(0)
+		if params.CPointAwarder != nil && order.CustomerID.Valid {
+			earnedCPoints = utils.CalculateOrderEarnedCPoints(order.TotalAmount)
+			if earnedCPoints > 0 {
+				if _, err := params.CPointAwarder.AwardForPaidOrder(ctx, qtx, order); err != nil {
+					logs.LogCtx(ctx).Error(
+						logtag,
+						zap.Int64("order_id", order.ID),
+						zap.String("action", "award_cpoints"),
+						zap.Error(err),
+					)
+					return err
+				}
 			}
 		}
-	}
 
-	updatedOrder, err := qtx.UpdateOrderOnPaymentSuccess(ctx, queries.UpdateOrderOnPaymentSuccessParams{
-		Status:        enums.ORDER_STATUS_CONFIRMED.String(),
-		EarnedCpoints: earnedCPoints,
-		ID:            order.ID,
-	})
-	if err != nil {
-		logs.LogCtx(ctx).Error(
-			logtag,
-			zap.Int64("order_id", order.ID),
-			zap.Error(err),
-		)
-		return nil, err
-	}
+		updatedOrder, err = qtx.UpdateOrderOnPaymentSuccess(ctx, queries.UpdateOrderOnPaymentSuccessParams{
+			Status:        enums.ORDER_STATUS_CONFIRMED.String(),
+			EarnedCpoints: earnedCPoints,
+			ID:            order.ID,
+		})
+		if err != nil {
+			logs.LogCtx(ctx).Error(
+				logtag,
+				zap.Int64("order_id", order.ID),
+				zap.Error(err),
+			)
+			return err
+		}
 
-	if err := orderhistory.RecordWithQueries(
-		ctx,
-		qtx,
-		order.ID,
-		sql.NullInt64{},
-		sql.NullString{String: order.Status, Valid: true},
-		enums.ORDER_STATUS_CONFIRMED.String(),
-		sql.NullString{},
-	); err != nil {
-		logs.LogCtx(ctx).Error(
+		if err := orderhistory.RecordWithQueries(
+			ctx,
+			qtx,
+			order.ID,
+			sql.NullInt64{},
+			sql.NullString{String: order.Status, Valid: true},
+			enums.ORDER_STATUS_CONFIRMED.String(),
+			sql.NullString{},
+		); err != nil {
+			logs.LogCtx(ctx).Error(
+				logtag,
+				zap.Int64("order_id", order.ID),
+				zap.String("action", "record_status_history"),
+				zap.Error(err),
+			)
+			return err
+		}
+
+		logs.LogCtx(ctx).Info(
 			logtag,
-			zap.Int64("order_id", order.ID),
-			zap.String("action", "record_status_history"),
-			zap.Error(err),
+			zap.String("action", "updated_order"),
+			zap.Int64("order_id", updatedOrder.ID),
+			zap.String("order_number", updatedOrder.OrderNumber),
+			zap.String("new_status", updatedOrder.Status),
+			zap.Time("paid_at", updatedOrder.PaidAt.Time),
 		)
-		return nil, err
-	}
-
-	logs.LogCtx(ctx).Info(
-		logtag,
-		zap.String("action", "updated_order"),
-		zap.Int64("order_id", updatedOrder.ID),
-		zap.String("order_number", updatedOrder.OrderNumber),
-		zap.String("new_status", updatedOrder.Status),
-		zap.Time("paid_at", updatedOrder.PaidAt.Time),
-	)
-
-	if err := tx.Commit(); err != nil {
+		return nil
+	})
+	if err != nil {
 		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
 		return nil, err
 	}
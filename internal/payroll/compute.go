@@ -0,0 +1,175 @@
+package payroll
+
+import "cchoice/internal/enums"
+
+const (
+	// WorkingDaysPerYear converts a monthly rate to a daily rate. 261 counts
+	// weekdays only, with regular holidays already part of the monthly pay.
+	WorkingDaysPerYear int64 = 261
+	MinutesPerWorkDay  int64 = 8 * 60
+)
+
+// Premiums as a percent of the daily or hourly rate.
+const (
+	regularHolidayWorkedPremium int64 = 100
+	specialHolidayWorkedPremium int64 = 30
+	overtimeOrdinaryPercent     int64 = 125
+	overtimeSpecialPercent      int64 = 169
+	overtimeRegularPercent      int64 = 260
+)
+
+type dayKind int
+
+const (
+	dayKindOrdinary dayKind = iota
+	dayKindRegularHoliday
+	dayKindSpecialHoliday
+)
+
+// Day is one calendar date of a staff member within a pay period.
+type Day struct {
+	Date             string
+	Holiday          enums.HolidayType
+	Leave            enums.TimeOff
+	Suspended        bool
+	Worked           bool
+	LateMinutes      int64
+	UndertimeMinutes int64
+	OvertimeMinutes  int64
+}
+
+type Input struct {
+	RateType enums.PayRateType
+	Rate     int64
+	Days     []Day
+}
+
+// Result holds every amount of a payslip in cents.
+type Result struct {
+	DailyRate          int64
+	DaysWorked         int64
+	AbsentDays         int64
+	LateMinutes        int64
+	UndertimeMinutes   int64
+	OvertimeMinutes    int64
+	BasicPay           int64
+	HolidayPay         int64
+	OvertimePay        int64
+	LateDeduction      int64
+	UndertimeDeduction int64
+	AbsenceDeduction   int64
+	GrossPay           int64
+	SSS                int64
+	PhilHealth         int64
+	PagIBIG            int64
+	WithholdingTax     int64
+	NetPay             int64
+}
+
+func DailyRate(rateType enums.PayRateType, rate int64) int64 {
+	if rateType == enums.PAY_RATE_TYPE_MONTHLY {
+		return (rate*12 + WorkingDaysPerYear/2) / WorkingDaysPerYear
+	}
+	return rate
+}
+
+// MonthlyEquivalent is the monthly compensation used to look up the
+// contribution tables, so a daily-rated staff pays the same share every period
+// regardless of how many days fell in it.
+func MonthlyEquivalent(rateType enums.PayRateType, rate int64) int64 {
+	if rateType == enums.PAY_RATE_TYPE_MONTHLY {
+		return rate
+	}
+	return (rate*WorkingDaysPerYear + 6) / 12
+}
+
+func kindOf(holiday enums.HolidayType) dayKind {
+	switch holiday {
+	case enums.HOLIDAY_TYPE_REGULAR, enums.HOLIDAY_TYPE_PUBLIC:
+		return dayKindRegularHoliday
+	case enums.HOLIDAY_TYPE_SPECIAL_NON_WORKING:
+		return dayKindSpecialHoliday
+	default:
+		return dayKindOrdinary
+	}
+}
+
+func minutesPay(dailyRate, minutes, percent int64) int64 {
+	return (dailyRate*minutes*percent + MinutesPerWorkDay*50) / (MinutesPerWorkDay * 100)
+}
+
+// Compute turns a semi-monthly period of attendance into pay.
+//
+// Daily-rated staff are paid per day worked or on paid leave, plus the
+// regular holiday pay when they do not work it. Monthly-rated staff get half
+// the monthly rate with unpaid absences deducted, suspended days included.
+// Both get the holiday and overtime premiums and the late and undertime
+// deductions.
+func Compute(in Input) Result {
+	res := Result{DailyRate: DailyRate(in.RateType, in.Rate)}
+	daily := res.DailyRate
+
+	for _, day := range in.Days {
+		kind := kindOf(day.Holiday)
+		paidLeave := day.Leave == enums.TIME_OFF_VL || day.Leave == enums.TIME_OFF_SL
+		absent := (day.Leave == enums.TIME_OFF_ABSENT || day.Suspended) && !day.Worked
+
+		if absent {
+			res.AbsentDays++
+		}
+
+		if day.Worked {
+			res.DaysWorked++
+			res.LateMinutes += day.LateMinutes
+			res.UndertimeMinutes += day.UndertimeMinutes
+			res.OvertimeMinutes += day.OvertimeMinutes
+
+			if in.RateType == enums.PAY_RATE_TYPE_DAILY {
+				res.BasicPay += daily
+			}
+
+			overtimePercent := overtimeOrdinaryPercent
+			switch kind {
+			case dayKindRegularHoliday:
+				res.HolidayPay += percentOf(daily, regularHolidayWorkedPremium)
+				overtimePercent = overtimeRegularPercent
+			case dayKindSpecialHoliday:
+				res.HolidayPay += percentOf(daily, specialHolidayWorkedPremium)
+				overtimePercent = overtimeSpecialPercent
+			}
+			res.OvertimePay += minutesPay(daily, day.OvertimeMinutes, overtimePercent)
+			continue
+		}
+
+		if in.RateType != enums.PAY_RATE_TYPE_DAILY {
+			continue
+		}
+		switch {
+		case paidLeave:
+			res.BasicPay += daily
+		case kind == dayKindRegularHoliday && !absent:
+			res.HolidayPay += daily
+		}
+	}
+
+	if in.RateType == enums.PAY_RATE_TYPE_MONTHLY {
+		res.BasicPay = in.Rate / 2
+		res.AbsenceDeduction = daily * res.AbsentDays
+	}
+	res.LateDeduction = minutesPay(daily, res.LateMinutes, 100)
+	res.UndertimeDeduction = minutesPay(daily, res.UndertimeMinutes, 100)
+
+	res.GrossPay = max(0, res.BasicPay+res.HolidayPay+res.OvertimePay-
+		res.LateDeduction-res.UndertimeDeduction-res.AbsenceDeduction)
+
+	monthly := MonthlyEquivalent(in.RateType, in.Rate)
+	if res.GrossPay > 0 {
+		res.SSS = SSSEmployeeShare(monthly) / 2
+		res.PhilHealth = PhilHealthEmployeeShare(monthly) / 2
+		res.PagIBIG = PagIBIGEmployeeShare(monthly) / 2
+	}
+	contributions := res.SSS + res.PhilHealth + res.PagIBIG
+	res.WithholdingTax = SemiMonthlyWithholdingTax(max(0, res.GrossPay-contributions))
+	res.NetPay = res.GrossPay - contributions - res.WithholdingTax
+	return res
+}
@@ -0,0 +1,66 @@
+package payroll
+
+// Contribution tables in effect for 2025 onwards. All amounts are in cents and
+// are the employee share of a whole month; a semi-monthly run deducts half.
+
+const (
+	sssMinMSC          int64 = 5_000_00
+	sssMaxMSC          int64 = 35_000_00
+	sssMSCStep         int64 = 500_00
+	sssEmployeePercent int64 = 5
+
+	philHealthFloor           int64 = 10_000_00
+	philHealthCeiling         int64 = 100_000_00
+	philHealthEmployeeBasisPt int64 = 250
+
+	pagIBIGMaxFundSalary   int64 = 10_000_00
+	pagIBIGLowSalaryCutoff int64 = 1_500_00
+)
+
+// SSSMonthlySalaryCredit maps monthly compensation to its SSS salary credit.
+// Brackets are 500 pesos wide and centered on the credit, so 5,250.00 to
+// 5,749.99 falls under the 5,500 credit.
+func SSSMonthlySalaryCredit(monthly int64) int64 {
+	msc := sssMinMSC + sssMSCStep*floorDiv(monthly-(sssMinMSC-sssMSCStep/2), sssMSCStep)
+	return min(max(msc, sssMinMSC), sssMaxMSC)
+}
+
+func SSSEmployeeShare(monthly int64) int64 {
+	return percentOf(SSSMonthlySalaryCredit(monthly), sssEmployeePercent)
+}
+
+// PhilHealthEmployeeShare is half of the 5% premium, computed on the monthly
+// basic salary clamped to the floor and ceiling.
+func PhilHealthEmployeeShare(monthly int64) int64 {
+	basis := min(max(monthly, philHealthFloor), philHealthCeiling)
+	return basisPointsOf(basis, philHealthEmployeeBasisPt)
+}
+
+// PagIBIGEmployeeShare is 1% for salaries up to 1,500 and 2% above, with the
+// fund salary capped at 10,000.
+func PagIBIGEmployeeShare(monthly int64) int64 {
+	if monthly <= 0 {
+		return 0
+	}
+	rate := int64(2)
+	if monthly <= pagIBIGLowSalaryCutoff {
+		rate = 1
+	}
+	return percentOf(min(monthly, pagIBIGMaxFundSalary), rate)
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+func percentOf(amount, percent int64) int64 {
+	return (amount*percent + 50) / 100
+}
+
+func basisPointsOf(amount, bp int64) int64 {
+	return (amount*bp + 5_000) / 10_000
+}
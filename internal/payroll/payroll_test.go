@@ -0,0 +1,168 @@
+package payroll
+
+import (
+	"testing"
+	"time"
+
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeriod(t *testing.T) {
+	p, err := ParsePeriod("2026-02", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-02-16", p.StartDate())
+	assert.Equal(t, "2026-02-28", p.EndDate())
+	assert.Len(t, p.Dates(), 13)
+	assert.False(t, p.IsFirstHalf())
+	assert.Equal(t, "Feb 16-28, 2026", p.Label())
+
+	p, err = ParsePeriod("2026-07", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-07-01", p.StartDate())
+	assert.Equal(t, "2026-07-15", p.EndDate())
+	assert.True(t, p.IsFirstHalf())
+
+	_, err = ParsePeriod("2026-07", 3)
+	assert.ErrorIs(t, err, errs.ErrPayrollInvalidPeriod)
+	_, err = ParsePeriod("July", 1)
+	assert.ErrorIs(t, err, errs.ErrPayrollInvalidPeriod)
+
+	p = PeriodContaining(time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, "2026-07-16", p.StartDate())
+	assert.Equal(t, "2026-07-31", p.EndDate())
+}
+
+func TestSSSMonthlySalaryCredit(t *testing.T) {
+	tests := []struct {
+		monthly int64
+		want    int64
+	}{
+		{4_000_00, 5_000_00},
+		{5_249_99, 5_000_00},
+		{5_250_00, 5_500_00},
+		{5_749_99, 5_500_00},
+		{5_750_00, 6_000_00},
+		{20_000_00, 20_000_00},
+		{50_000_00, 35_000_00},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, SSSMonthlySalaryCredit(tt.monthly), "monthly %d", tt.monthly)
+	}
+	assert.Equal(t, int64(1_000_00), SSSEmployeeShare(20_000_00))
+}
+
+func TestPhilHealthEmployeeShare(t *testing.T) {
+	assert.Equal(t, int64(250_00), PhilHealthEmployeeShare(5_000_00))
+	assert.Equal(t, int64(750_00), PhilHealthEmployeeShare(30_000_00))
+	assert.Equal(t, int64(2_500_00), PhilHealthEmployeeShare(150_000_00))
+}
+
+func TestPagIBIGEmployeeShare(t *testing.T) {
+	assert.Equal(t, int64(0), PagIBIGEmployeeShare(0))
+	assert.Equal(t, int64(10_00), PagIBIGEmployeeShare(1_000_00))
+	assert.Equal(t, int64(100_00), PagIBIGEmployeeShare(5_000_00))
+	assert.Equal(t, int64(200_00), PagIBIGEmployeeShare(30_000_00))
+}
+
+func TestSemiMonthlyWithholdingTax(t *testing.T) {
+	tests := []struct {
+		taxable int64
+		want    int64
+	}{
+		{10_000_00, 0},
+		{10_417_00, 0},
+		{15_000_00, 687_45},
+		{20_000_00, 1_604_10},
+		{50_000_00, 8_437_45},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, SemiMonthlyWithholdingTax(tt.taxable), "taxable %d", tt.taxable)
+	}
+}
+
+func TestComputeDailyRate(t *testing.T) {
+	res := Compute(Input{
+		RateType: enums.PAY_RATE_TYPE_DAILY,
+		Rate:     700_00,
+		Days: []Day{
+			{Date: "2026-07-01", Worked: true},
+			{Date: "2026-07-02", Worked: true, LateMinutes: 30},
+			{Date: "2026-07-03", Worked: true, OvertimeMinutes: 60},
+			{Date: "2026-07-06", Holiday: enums.HOLIDAY_TYPE_REGULAR},
+			{Date: "2026-07-07", Holiday: enums.HOLIDAY_TYPE_SPECIAL_NON_WORKING, Worked: true},
+			{Date: "2026-07-08", Leave: enums.TIME_OFF_VL},
+			{Date: "2026-07-09", Leave: enums.TIME_OFF_ABSENT},
+		},
+	})
+
+	assert.Equal(t, int64(700_00), res.DailyRate)
+	assert.Equal(t, int64(4), res.DaysWorked)
+	assert.Equal(t, int64(1), res.AbsentDays)
+	assert.Equal(t, int64(3_500_00), res.BasicPay)
+	assert.Equal(t, int64(910_00), res.HolidayPay)
+	assert.Equal(t, int64(109_38), res.OvertimePay)
+	assert.Equal(t, int64(43_75), res.LateDeduction)
+	assert.Equal(t, int64(0), res.AbsenceDeduction)
+	assert.Equal(t, int64(4_475_63), res.GrossPay)
+	assert.Equal(t, int64(375_00), res.SSS)
+	assert.Equal(t, int64(190_31), res.PhilHealth)
+	assert.Equal(t, int64(100_00), res.PagIBIG)
+	assert.Equal(t, int64(0), res.WithholdingTax)
+	assert.Equal(t, int64(3_810_32), res.NetPay)
+}
+
+func TestComputeMonthlyRate(t *testing.T) {
+	res := Compute(Input{
+		RateType: enums.PAY_RATE_TYPE_MONTHLY,
+		Rate:     30_000_00,
+		Days: []Day{
+			{Date: "2026-07-01", Leave: enums.TIME_OFF_ABSENT},
+			{Date: "2026-07-02", Holiday: enums.HOLIDAY_TYPE_REGULAR, Worked: true, UndertimeMinutes: 15},
+			{Date: "2026-07-03", Holiday: enums.HOLIDAY_TYPE_REGULAR},
+		},
+	})
+
+	assert.Equal(t, int64(1_379_31), res.DailyRate)
+	assert.Equal(t, int64(15_000_00), res.BasicPay)
+	assert.Equal(t, int64(1_379_31), res.HolidayPay)
+	assert.Equal(t, int64(1_379_31), res.AbsenceDeduction)
+	assert.Equal(t, int64(43_10), res.UndertimeDeduction)
+	assert.Equal(t, int64(14_956_90), res.GrossPay)
+	assert.Equal(t, int64(750_00), res.SSS)
+	assert.Equal(t, int64(375_00), res.PhilHealth)
+	assert.Equal(t, int64(100_00), res.PagIBIG)
+	assert.Equal(t, int64(497_24), res.WithholdingTax)
+	assert.Equal(t, int64(13_234_66), res.NetPay)
+}
+
+func TestComputeSuspendedDaysAreUnpaid(t *testing.T) {
+	days := []Day{
+		{Date: "2026-07-01", Worked: true},
+		{Date: "2026-07-02", Suspended: true},
+		{Date: "2026-07-03", Holiday: enums.HOLIDAY_TYPE_REGULAR, Suspended: true},
+	}
+
+	daily := Compute(Input{RateType: enums.PAY_RATE_TYPE_DAILY, Rate: 700_00, Days: days})
+	assert.Equal(t, int64(2), daily.AbsentDays)
+	assert.Equal(t, int64(700_00), daily.BasicPay)
+	assert.Equal(t, int64(0), daily.HolidayPay)
+
+	monthly := Compute(Input{RateType: enums.PAY_RATE_TYPE_MONTHLY, Rate: 30_000_00, Days: days})
+	assert.Equal(t, int64(2), monthly.AbsentDays)
+	assert.Equal(t, 2*monthly.DailyRate, monthly.AbsenceDeduction)
+}
+
+func TestComputeNoPayNoContributions(t *testing.T) {
+	res := Compute(Input{
+		RateType: enums.PAY_RATE_TYPE_DAILY,
+		Rate:     700_00,
+		Days:     []Day{{Date: "2026-07-01", Leave: enums.TIME_OFF_ABSENT}},
+	})
+	assert.Equal(t, int64(0), res.GrossPay)
+	assert.Equal(t, int64(0), res.SSS+res.PhilHealth+res.PagIBIG)
+	assert.Equal(t, int64(0), res.NetPay)
+}
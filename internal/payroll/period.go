@@ -0,0 +1,69 @@
+package payroll
+
+import (
+	"fmt"
+	"time"
+
+	"cchoice/internal/constants"
+	"cchoice/internal/errs"
+)
+
+// Period is a semi-monthly pay period. The first half runs from the 1st to the
+// 15th and the second half from the 16th to the last day of the month. Both
+// bounds are dates at midnight UTC, the same way time-off dates are stored.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// ParsePeriod builds the period for a month in "YYYY-MM" form and a half,
+// which is 1 for the 1st-15th and 2 for the 16th onwards.
+func ParsePeriod(month string, half int) (Period, error) {
+	first, err := time.Parse("2006-01", month)
+	if err != nil {
+		return Period{}, errs.ErrPayrollInvalidPeriod
+	}
+	switch half {
+	case 1:
+		return Period{Start: first, End: first.AddDate(0, 0, 14)}, nil
+	case 2:
+		return Period{Start: first.AddDate(0, 0, 15), End: first.AddDate(0, 1, -1)}, nil
+	default:
+		return Period{}, errs.ErrPayrollInvalidPeriod
+	}
+}
+
+// PeriodContaining returns the pay period the given calendar date falls in.
+func PeriodContaining(date time.Time) Period {
+	half := 1
+	if date.Day() > 15 {
+		half = 2
+	}
+	p, _ := ParsePeriod(date.Format("2006-01"), half)
+	return p
+}
+
+func (p Period) IsFirstHalf() bool {
+	return p.Start.Day() == 1
+}
+
+func (p Period) StartDate() string {
+	return p.Start.Format(constants.DateLayoutISO)
+}
+
+func (p Period) EndDate() string {
+	return p.End.Format(constants.DateLayoutISO)
+}
+
+// Dates lists every calendar date in the period in DateLayoutISO.
+func (p Period) Dates() []string {
+	dates := make([]string, 0, 16)
+	for d := p.Start; !d.After(p.End); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d.Format(constants.DateLayoutISO))
+	}
+	return dates
+}
+
+func (p Period) Label() string {
+	return fmt.Sprintf("%s %d-%d, %d", p.Start.Format("Jan"), p.Start.Day(), p.End.Day(), p.Start.Year())
+}
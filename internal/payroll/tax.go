@@ -0,0 +1,28 @@
+package payroll
+
+type taxBracket struct {
+	over    int64
+	base    int64
+	percent int64
+}
+
+// semiMonthlyTaxTable is the BIR withholding tax table on compensation for
+// semi-monthly payrolls effective January 2023, in cents.
+var semiMonthlyTaxTable = []taxBracket{
+	{over: 333_333_00, base: 91_770_70, percent: 35},
+	{over: 83_333_00, base: 16_770_70, percent: 30},
+	{over: 33_333_00, base: 4_270_70, percent: 25},
+	{over: 16_667_00, base: 937_50, percent: 20},
+	{over: 10_417_00, base: 0, percent: 15},
+}
+
+// SemiMonthlyWithholdingTax returns the tax withheld from one semi-monthly
+// taxable compensation, which is gross pay less the mandatory contributions.
+func SemiMonthlyWithholdingTax(taxable int64) int64 {
+	for _, bracket := range semiMonthlyTaxTable {
+		if taxable > bracket.over {
+			return bracket.base + percentOf(taxable-bracket.over, bracket.percent)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,51 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, `Net \(PHP\) \\ 1`, escape(`Net (PHP) \ 1`))
+	assert.Equal(t, "PHP 1,000.00", escape("₱1,000.00"))
+	assert.Equal(t, `Pe\361a`, escape("Peña"))
+	assert.Equal(t, "?", escape("✓"))
+}
+
+func TestWriteToXrefOffsets(t *testing.T) {
+	doc := NewDocument()
+	doc.AddPage()
+	doc.Text(40, 40, 12, true, "Payslip")
+	doc.Line(40, 555, 50)
+	doc.AddPage()
+	doc.TextRight(555, 40, 10, false, "₱1.00")
+
+	var buf bytes.Buffer
+	_, err := doc.WriteTo(&buf)
+	require.NoError(t, err)
+	out := buf.Bytes()
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, buf.String(), "/Count 2")
+
+	m := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(out)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out, -1)
+	require.Len(t, entries, 8)
+	for i, entry := range entries {
+		off, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(out[off:], fmt.Appendf(nil, "%d 0 obj", i+1)), "object %d", i+1)
+	}
+}
@@ -0,0 +1,173 @@
+package rbac
+
+import (
+	"strings"
+
+	"cchoice/internal/enums"
+)
+
+// Permission is a role at a given access level, e.g. READ on MANAGE_BRANDS.
+type Permission struct {
+	Role   enums.StaffRole
+	Access enums.PermissionAccess
+}
+
+func (p Permission) String() string {
+	return p.Access.String() + ":" + p.Role.String()
+}
+
+// Set holds the highest access a staff has for each role.
+type Set map[enums.StaffRole]enums.PermissionAccess
+
+// NewSet merges permissions coming from direct roles and role groups. When a
+// role is granted more than once the higher access wins.
+func NewSet(perms ...Permission) Set {
+	set := make(Set, len(perms))
+	for _, p := range perms {
+		set.Grant(p)
+	}
+	return set
+}
+
+func (s Set) Grant(p Permission) {
+	if !p.Role.IsValid() || p.Access == enums.PERMISSION_ACCESS_UNDEFINED {
+		return
+	}
+	if p.Access > s[p.Role] {
+		s[p.Role] = p.Access
+	}
+}
+
+func (s Set) Allows(p Permission) bool {
+	return p.Access != enums.PERMISSION_ACCESS_UNDEFINED && s[p.Role] >= p.Access
+}
+
+// Roles lists the roles that have at least READ access, in the order of
+// enums.GetAllStaffRoles.
+func (s Set) Roles() []enums.StaffRole {
+	roles := make([]enums.StaffRole, 0, len(s))
+	for _, role := range enums.GetAllStaffRoles() {
+		if _, ok := s[role]; ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+type requirementKind int
+
+const (
+	kindUndefined requirementKind = iota
+	kindPublic
+	kindStaff
+	kindSuperuser
+	kindPermission
+)
+
+// Requirement is what a route declares it needs. The zero value is not a
+// valid declaration, so a route that never states one can be caught.
+type Requirement struct {
+	kind  requirementKind
+	anyOf []Permission
+}
+
+// Public routes need no login, e.g. the login page itself.
+func Public() Requirement {
+	return Requirement{kind: kindPublic}
+}
+
+// Staff routes only need a logged in staff and serve their own data.
+func Staff() Requirement {
+	return Requirement{kind: kindStaff}
+}
+
+func Superuser() Requirement {
+	return Requirement{kind: kindSuperuser}
+}
+
+func Read(role enums.StaffRole) Requirement {
+	return AnyOf(Permission{Role: role, Access: enums.PERMISSION_ACCESS_READ})
+}
+
+func Write(role enums.StaffRole) Requirement {
+	return AnyOf(Permission{Role: role, Access: enums.PERMISSION_ACCESS_WRITE})
+}
+
+// AnyOf is satisfied by holding at least one of the given permissions.
+func AnyOf(perms ...Permission) Requirement {
+	return Requirement{kind: kindPermission, anyOf: perms}
+}
+
+func (r Requirement) IsDeclared() bool {
+	return r.kind != kindUndefined
+}
+
+func (r Requirement) IsPublic() bool {
+	return r.kind == kindPublic
+}
+
+func (r Requirement) IsSuperuserOnly() bool {
+	return r.kind == kindSuperuser
+}
+
+func (r Requirement) Permissions() []Permission {
+	return r.anyOf
+}
+
+// Writes reports whether the requirement grants a change, which every
+// mutating route must ask for.
+func (r Requirement) Writes() bool {
+	switch r.kind {
+	case kindStaff, kindSuperuser, kindPublic:
+		return true
+	case kindPermission:
+		for _, p := range r.anyOf {
+			if p.Access != enums.PERMISSION_ACCESS_WRITE {
+				return false
+			}
+		}
+		return len(r.anyOf) > 0
+	default:
+		return false
+	}
+}
+
+// Allows checks a logged in staff against the requirement. Superusers pass
+// everything.
+func (r Requirement) Allows(isSuperuser bool, set Set) bool {
+	if isSuperuser {
+		return r.kind != kindUndefined
+	}
+	switch r.kind {
+	case kindPublic, kindStaff:
+		return true
+	case kindPermission:
+		for _, p := range r.anyOf {
+			if set.Allows(p) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func (r Requirement) String() string {
+	switch r.kind {
+	case kindPublic:
+		return "Public"
+	case kindStaff:
+		return "Staff"
+	case kindSuperuser:
+		return "Superuser"
+	case kindPermission:
+		perms := make([]string, 0, len(r.anyOf))
+		for _, p := range r.anyOf {
+			perms = append(perms, p.String())
+		}
+		return strings.Join(perms, " | ")
+	default:
+		return "Undeclared"
+	}
+}
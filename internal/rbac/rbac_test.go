@@ -0,0 +1,67 @@
+package rbac
+
+import (
+	"testing"
+
+	"cchoice/internal/enums"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetKeepsHighestAccess(t *testing.T) {
+	set := NewSet(
+		Permission{Role: enums.STAFF_ROLE_MANAGE_PROMOS, Access: enums.PERMISSION_ACCESS_WRITE},
+		Permission{Role: enums.STAFF_ROLE_MANAGE_PROMOS, Access: enums.PERMISSION_ACCESS_READ},
+		Permission{Role: enums.STAFF_ROLE_MANAGE_ORDERS, Access: enums.PERMISSION_ACCESS_READ},
+		Permission{Role: enums.STAFF_ROLE_UNDEFINED, Access: enums.PERMISSION_ACCESS_WRITE},
+	)
+
+	assert.Len(t, set, 2)
+	assert.Equal(t, enums.PERMISSION_ACCESS_WRITE, set[enums.STAFF_ROLE_MANAGE_PROMOS])
+	assert.Equal(t, []enums.StaffRole{enums.STAFF_ROLE_MANAGE_PROMOS, enums.STAFF_ROLE_MANAGE_ORDERS}, set.Roles())
+}
+
+func TestRequirementAllows(t *testing.T) {
+	set := NewSet(
+		Permission{Role: enums.STAFF_ROLE_MANAGE_PROMOS, Access: enums.PERMISSION_ACCESS_WRITE},
+		Permission{Role: enums.STAFF_ROLE_MANAGE_ORDERS, Access: enums.PERMISSION_ACCESS_READ},
+	)
+
+	tcs := []struct {
+		name      string
+		req       Requirement
+		superuser bool
+		want      bool
+	}{
+		{"write implies read", Read(enums.STAFF_ROLE_MANAGE_PROMOS), false, true},
+		{"write", Write(enums.STAFF_ROLE_MANAGE_PROMOS), false, true},
+		{"read only", Read(enums.STAFF_ROLE_MANAGE_ORDERS), false, true},
+		{"read does not imply write", Write(enums.STAFF_ROLE_MANAGE_ORDERS), false, false},
+		{"missing role", Read(enums.STAFF_ROLE_MANAGE_BRANDS), false, false},
+		{"any of", AnyOf(
+			Permission{Role: enums.STAFF_ROLE_MANAGE_BRANDS, Access: enums.PERMISSION_ACCESS_READ},
+			Permission{Role: enums.STAFF_ROLE_MANAGE_ORDERS, Access: enums.PERMISSION_ACCESS_READ},
+		), false, true},
+		{"staff", Staff(), false, true},
+		{"superuser only", Superuser(), false, false},
+		{"superuser passes permissions", Write(enums.STAFF_ROLE_MANAGE_BRANDS), true, true},
+		{"superuser", Superuser(), true, true},
+		{"undeclared", Requirement{}, true, false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.req.Allows(tc.superuser, set))
+		})
+	}
+}
+
+func TestRequirementWrites(t *testing.T) {
+	assert.True(t, Write(enums.STAFF_ROLE_MANAGE_BRANDS).Writes())
+	assert.False(t, Read(enums.STAFF_ROLE_MANAGE_BRANDS).Writes())
+	assert.False(t, AnyOf(
+		Permission{Role: enums.STAFF_ROLE_MANAGE_BRANDS, Access: enums.PERMISSION_ACCESS_WRITE},
+		Permission{Role: enums.STAFF_ROLE_MANAGE_ORDERS, Access: enums.PERMISSION_ACCESS_READ},
+	).Writes())
+	assert.True(t, Superuser().Writes())
+	assert.False(t, Requirement{}.Writes())
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,6 +28,17 @@ type Product struct {
 	ProductCategory    string
 	ProductSubcategory string
 	OnSale             bool
+	RatingCount        int64
+	RatingAverage      float64
+	Reviews            []ProductReview
+}
+
+type ProductReview struct {
+	AuthorName    string
+	Rating        int64
+	Title         string
+	Body          string
+	DatePublished string
 }
 
 type ProductMeta struct {
@@ -62,11 +74,28 @@ type reviewAuthor struct {
 	Name string `json:"name"`
 }
 
+type ratingSchema struct {
+	Type        string `json:"@type"`
+	RatingValue string `json:"ratingValue"`
+	BestRating  string `json:"bestRating"`
+	WorstRating string `json:"worstRating"`
+}
+
 type reviewSchema struct {
-	Type       string       `json:"@type"`
-	Name       string       `json:"name,omitempty"`
-	ReviewBody string       `json:"reviewBody,omitempty"`
-	Author     reviewAuthor `json:"author"`
+	Type          string       `json:"@type"`
+	Name          string       `json:"name,omitempty"`
+	ReviewBody    string       `json:"reviewBody,omitempty"`
+	DatePublished string       `json:"datePublished,omitempty"`
+	ReviewRating  ratingSchema `json:"reviewRating"`
+	Author        reviewAuthor `json:"author"`
+}
+
+type aggregateRatingSchema struct {
+	Type        string `json:"@type"`
+	RatingValue string `json:"ratingValue"`
+	ReviewCount int64  `json:"reviewCount"`
+	BestRating  string `json:"bestRating"`
+	WorstRating string `json:"worstRating"`
 }
 
 func ProductCanonicalURL(siteBaseURL, slug string) string {
@@ -233,20 +262,44 @@ func buildProductBreadcrumbItems(product Product, siteBaseURL string) []breadcru
 	return items
 }
 
-func buildProductReview(product Product) *reviewSchema {
-	description := strings.TrimSpace(product.Description)
-	if description == "" {
+func buildProductReviews(product Product) []reviewSchema {
+	if len(product.Reviews) == 0 {
 		return nil
 	}
 
-	return &reviewSchema{
-		Type:       "Review",
-		Name:       "Product Overview",
-		ReviewBody: description,
-		Author: reviewAuthor{
-			Type: "Organization",
-			Name: "C-Choice",
-		},
+	reviews := make([]reviewSchema, 0, len(product.Reviews))
+	for _, review := range product.Reviews {
+		reviews = append(reviews, reviewSchema{
+			Type:          "Review",
+			Name:          strings.TrimSpace(review.Title),
+			ReviewBody:    strings.TrimSpace(review.Body),
+			DatePublished: review.DatePublished,
+			ReviewRating: ratingSchema{
+				Type:        "Rating",
+				RatingValue: strconv.FormatInt(review.Rating, 10),
+				BestRating:  "5",
+				WorstRating: "1",
+			},
+			Author: reviewAuthor{
+				Type: "Person",
+				Name: review.AuthorName,
+			},
+		})
+	}
+	return reviews
+}
+
+func buildProductAggregateRating(product Product) *aggregateRatingSchema {
+	if product.RatingCount <= 0 {
+		return nil
+	}
+
+	return &aggregateRatingSchema{
+		Type:        "AggregateRating",
+		RatingValue: utils.RatingDisplay(product.RatingAverage),
+		ReviewCount: product.RatingCount,
+		BestRating:  "5",
+		WorstRating: "1",
 	}
 }
 
@@ -280,15 +333,16 @@ func BuildProductStructuredData(
 		ItemList []breadcrumbItem `json:"itemListElement"`
 	}
 	type productSchema struct {
-		Type        string        `json:"@type"`
-		Name        string        `json:"name"`
-		Description string        `json:"description,omitempty"`
-		Image       []string      `json:"image,omitempty"`
-		SKU         string        `json:"sku"`
-		URL         string        `json:"url"`
-		Brand       brand         `json:"brand"`
-		Review      *reviewSchema `json:"review,omitempty"`
-		Offers      offer         `json:"offers"`
+		Type            string                 `json:"@type"`
+		Name            string                 `json:"name"`
+		Description     string                 `json:"description,omitempty"`
+		Image           []string               `json:"image,omitempty"`
+		SKU             string                 `json:"sku"`
+		URL             string                 `json:"url"`
+		Brand           brand                  `json:"brand"`
+		AggregateRating *aggregateRatingSchema `json:"aggregateRating,omitempty"`
+		Review          []reviewSchema         `json:"review,omitempty"`
+		Offers          offer                  `json:"offers"`
 	}
 	type graph struct {
 		Context string `json:"@context"`
@@ -305,14 +359,15 @@ func BuildProductStructuredData(
 		Context: "https://schema.org",
 		Graph: []any{
 			productSchema{
-				Type:        "Product",
-				Name:        fmt.Sprintf("%s %s", product.BrandName, product.Name),
-				Description: description,
-				Image:       images,
-				SKU:         product.Serial,
-				URL:         canonicalURL,
-				Brand:       brand{Type: "Brand", Name: product.BrandName},
-				Review:      buildProductReview(product),
+				Type:            "Product",
+				Name:            fmt.Sprintf("%s %s", product.BrandName, product.Name),
+				Description:     description,
+				Image:           images,
+				SKU:             product.Serial,
+				URL:             canonicalURL,
+				Brand:           brand{Type: "Brand", Name: product.BrandName},
+				AggregateRating: buildProductAggregateRating(product),
+				Review:          buildProductReviews(product),
 				Offers: offer{
 					Type:            "Offer",
 					URL:             canonicalURL,
@@ -170,6 +170,12 @@ func TestGenerateProductMeta_FallbackDescription(t *testing.T) {
 func TestBuildProductStructuredData(t *testing.T) {
 	product := gma55Product()
 	product.Description = "Heavy-duty table saw"
+	product.RatingCount = 2
+	product.RatingAverage = 4.5
+	product.Reviews = []ProductReview{
+		{AuthorName: "Juan D.", Rating: 5, Title: "Solid", Body: "Accurate readings", DatePublished: "2026-07-01"},
+		{AuthorName: "Maria S.", Rating: 4, Body: "Works well"},
+	}
 
 	raw := BuildProductStructuredData(
 		product,
@@ -203,16 +209,33 @@ func TestBuildProductStructuredData(t *testing.T) {
 	assert.Equal(t, "https://cchoice.shop/product/bosch-gma-55", offers["url"])
 	assert.NotEmpty(t, offers["priceValidUntil"])
 
-	review, ok := productNode["review"].(map[string]any)
+	aggregate, ok := productNode["aggregateRating"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "AggregateRating", aggregate["@type"])
+	assert.Equal(t, "4.5", aggregate["ratingValue"])
+	assert.EqualValues(t, 2, aggregate["reviewCount"])
+	assert.Equal(t, "5", aggregate["bestRating"])
+
+	reviews, ok := productNode["review"].([]any)
+	require.True(t, ok)
+	require.Len(t, reviews, 2)
+
+	review, ok := reviews[0].(map[string]any)
 	require.True(t, ok)
 	assert.Equal(t, "Review", review["@type"])
-	assert.Equal(t, "Heavy-duty table saw", review["reviewBody"])
-	assert.Equal(t, "Product Overview", review["name"])
+	assert.Equal(t, "Solid", review["name"])
+	assert.Equal(t, "Accurate readings", review["reviewBody"])
+	assert.Equal(t, "2026-07-01", review["datePublished"])
+
+	reviewRating, ok := review["reviewRating"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "Rating", reviewRating["@type"])
+	assert.Equal(t, "5", reviewRating["ratingValue"])
 
 	author, ok := review["author"].(map[string]any)
 	require.True(t, ok)
-	assert.Equal(t, "Organization", author["@type"])
-	assert.Equal(t, "C-Choice", author["name"])
+	assert.Equal(t, "Person", author["@type"])
+	assert.Equal(t, "Juan D.", author["name"])
 
 	breadcrumb, ok := graph[1].(map[string]any)
 	require.True(t, ok)
@@ -239,8 +262,9 @@ func TestBuildProductStructuredData(t *testing.T) {
 	assert.False(t, hasItem)
 }
 
-func TestBuildProductStructuredData_OmitsReviewWithoutDescription(t *testing.T) {
+func TestBuildProductStructuredData_OmitsReviewWithoutStoredReviews(t *testing.T) {
 	product := gma55Product()
+	product.Description = "Heavy-duty table saw"
 
 	raw := BuildProductStructuredData(
 		product,
@@ -261,4 +285,6 @@ func TestBuildProductStructuredData_OmitsReviewWithoutDescription(t *testing.T)
 	require.True(t, ok)
 	_, hasReview := productNode["review"]
 	assert.False(t, hasReview)
+	_, hasAggregateRating := productNode["aggregateRating"]
+	assert.False(t, hasAggregateRating)
 }
@@ -14,6 +14,7 @@ import (
 	"cchoice/internal/httputil"
 	"cchoice/internal/logs"
 	"cchoice/internal/metrics"
+	"cchoice/internal/rbac"
 	"cchoice/internal/server/forms"
 	"cchoice/internal/utils"
 
@@ -53,163 +54,267 @@ func getOrCreateUserAgentID(ctx context.Context, db database.IService, userAgent
 }
 
 func AddAdminHandlers(s *Server, r chi.Router) {
-	r.Get("/admin", s.adminLoginPageHandler)
+	r.With(s.Permit(rbac.Public())).Get("/admin", s.adminLoginPageHandler)
 	r.Group(func(r chi.Router) {
 		r.Use(s.rateLimiter.Middleware)
-		r.Post("/admin/login", s.adminLoginHandler)
+		r.With(s.Permit(rbac.Public())).Post("/admin/login", s.adminLoginHandler)
+		r.With(s.Permit(rbac.Public())).Post("/admin/login/2fa", s.adminTwoFactorVerifyHandler)
+		r.With(s.Permit(rbac.Public())).Post("/admin/login/2fa/email", s.adminTwoFactorEmailHandler)
+		r.With(s.Permit(rbac.Public())).Post("/admin/login/2fa/enroll", s.adminTwoFactorEnrolHandler)
 	})
-	r.With(s.requireStaffAuth).Post("/admin/logout", s.adminLogoutHandler)
-	r.With(s.requireStaffAuth).Get("/admin/staff", s.adminStaffHomeHandler)
-	r.With(s.requireStaffAuth).Post("/admin/staff/memos/{id}/accept", s.adminStaffMemoAcceptHandler)
-	r.With(s.requireStaffAuth).Get("/admin/staff/memos/{id}/reject", s.adminStaffMemoRejectModalHandler)
-	r.With(s.requireStaffAuth).Post("/admin/staff/memos/{id}/reject", s.adminStaffMemoRejectHandler)
-	r.With(s.requireStaffAuth).Get("/admin/profile", s.adminStaffProfileHandler)
-	r.With(s.requireStaffAuth).Get("/admin/profile-header", s.adminStaffProfileHeaderHandler)
-	r.With(s.requireStaffAuth).Get("/admin/profile/edit", s.adminProfileEditFormHandler)
-	r.With(s.requireStaffAuth).Patch("/admin/profile", s.adminProfileUpdateHandler)
-	r.With(s.requireStaffAuth).Post("/admin/change-password", s.adminChangePasswordHandler)
-	r.With(s.requireStaffAuth).Get("/admin/staff/list", s.adminStaffListHandler)
-	r.With(s.requireStaffAuth).Get("/admin/customers/list", s.adminCustomersListHandler)
-	r.With(s.requireStaffAuth).Get("/admin/staff/attendance", s.adminStaffPageHandler)
-	r.With(s.requireStaffAuth).Get("/admin/staff/attendance/table", s.adminStaffAttendanceTableHandler)
-	r.With(s.requireStaffAuth).Get("/admin/staff/attendance/rows", s.adminStaffAttendanceRowsHandler)
-	r.With(s.requireStaffAuth).Post("/admin/staff/time-in", s.adminStaffTimeInHandler)
-	r.With(s.requireStaffAuth).Post("/admin/staff/time-out", s.adminStaffTimeOutHandler)
-	r.With(s.requireStaffAuth).Post("/admin/staff/lunch-break-start", s.adminStaffLunchBreakInHandler)
-	r.With(s.requireStaffAuth).Post("/admin/staff/lunch-break-end", s.adminStaffLunchBreakOutHandler)
-	r.With(s.requireStaffAuth).Get("/admin/staff/time-off", s.adminStaffTimeOffPageHandler)
-	r.With(s.requireStaffAuth).Post("/admin/staff/time-off", s.adminStaffTimeOffHandler)
-	r.With(s.requireStaffAuth).Get("/admin/staff/time-off/table", s.adminStaffTimeOffTableHandler)
-	r.With(s.requireStaffAuth).Post("/admin/staff/attendance/location", s.adminStaffAttendanceLocationHandler)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_CREATE_PRODUCT)).Get("/admin/superuser/products/create", s.adminSuperuserProductsCreatePageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_CREATE_PRODUCT)).Post("/admin/superuser/products/create", s.adminSuperuserProductsCreatePostHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_CREATE_PRODUCT, enums.STAFF_ROLE_EDIT_PRODUCTS)).Get("/admin/superuser/products/subcategories", s.adminSuperuserProductsSubcategoriesHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_CREATE_PRODUCT)).Get("/admin/superuser/products/validate-serial", s.adminSuperuserProductsValidateSerialHandler)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_EDIT_PRODUCTS)).Get("/admin/products", s.adminStaffProductsListPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_EDIT_PRODUCTS)).Get("/admin/products/table", s.adminStaffProductsListTableHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_EDIT_PRODUCTS)).Get("/admin/products/{id}/edit", s.adminStaffProductsEditPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_EDIT_PRODUCTS)).Patch("/admin/products/{id}", s.adminStaffProductsUpdateHandler)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_CREATE_CPOINTS)).Get("/admin/cpoints/generate", s.adminCPointsGeneratePageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_CREATE_CPOINTS)).Post("/admin/cpoints/generate", s.adminCPointsGeneratePostHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_CREATE_CPOINTS)).Get("/admin/cpoints/code", s.adminCPointsCodePageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_CREATE_CPOINTS)).Get("/admin/cpoints/qr", s.adminCPointsQRHandler)
-
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser", s.adminSuperuserHomeHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/envs", s.adminSuperuserEnvsHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/attendance", s.adminSuperuserAttendancePageHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/attendance/table", s.adminSuperuserAttendanceHandler)
-	r.With(s.requireSuperuserAuth).Post("/admin/superuser/attendance/report", s.adminSuperuserAttendanceReportHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/time-off", s.adminSuperuserTimeOffPageHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/time-off/table", s.adminSuperuserTimeOffTableHandler)
-	r.With(s.requireSuperuserAuth).Patch("/admin/superuser/time-off/{id}/approve", s.adminSuperuserTimeOffApproveHandler)
-	r.With(s.requireSuperuserAuth).Patch("/admin/superuser/time-off/{id}/cancel", s.adminSuperuserTimeOffCancelHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_PUBLISH_PRODUCTS)).Get("/admin/superuser/products", s.adminSuperuserProductsListPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_PUBLISH_PRODUCTS)).Get("/admin/superuser/products/table", s.adminSuperuserProductsListTableHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_PUBLISH_PRODUCTS)).Patch("/admin/superuser/products/{id}/status", s.adminSuperuserProductsUpdateStatusHandler)
-	r.With(s.requireSuperuserAuth).Delete("/admin/superuser/products/{id}", s.adminSuperuserProductsDeleteHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/products/{id}/edit", s.adminSuperuserProductsEditPageHandler)
-	r.With(s.requireSuperuserAuth).Patch("/admin/superuser/products/{id}", s.adminSuperuserProductsUpdateHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/logs", s.adminSuperuserLogsPageHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/logs/table", s.adminSuperuserLogsTableHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/logs/actions", s.adminSuperuserLogsActionsHandler)
-
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/customers", s.adminCustomersListPageHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/customers/table", s.adminCustomersListTableHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/staffs", s.adminSuperuserStaffsListPageHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/staffs/create", s.adminSuperuserStaffsCreatePageHandler)
-	r.With(s.requireSuperuserAuth).Post("/admin/superuser/staffs/create", s.adminSuperuserStaffsCreatePostHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/staffs/table", s.adminSuperuserStaffsListTableHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/staffs/{id}/edit", s.adminSuperuserStaffsEditPageHandler)
-	r.With(s.requireSuperuserAuth).Patch("/admin/superuser/staffs/{id}", s.adminSuperuserStaffsUpdateHandler)
-	r.With(s.requireSuperuserAuth).Get("/admin/superuser/staffs/roles", s.adminSuperuserStaffsRolesOptionsHandler)
-	r.With(s.requireSuperuserAuth).Patch("/admin/superuser/staffs/{id}/role", s.adminSuperuserStaffsRoleHandler)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_HOLIDAYS)).Get("/admin/holidays", s.adminHolidaysListPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_HOLIDAYS)).Get("/admin/holidays/table", s.adminHolidaysListTableHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_HOLIDAYS)).Get("/admin/holidays/{id}/edit", s.adminHolidaysEditPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_HOLIDAYS)).Post("/admin/holidays", s.adminHolidaysCreateHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_HOLIDAYS)).Patch("/admin/holidays/{id}", s.adminHolidaysUpdateHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_HOLIDAYS)).Delete("/admin/holidays/{id}", s.adminHolidaysDeleteHandler)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_BRANDS)).Get("/admin/brands", s.adminBrandsListPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_BRANDS)).Get("/admin/brands/table", s.adminBrandsListTableHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_BRANDS)).Get("/admin/brands/create", s.adminBrandsCreatePageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_BRANDS)).Get("/admin/brands/{id}/edit", s.adminBrandsEditPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_BRANDS)).Post("/admin/brands", s.adminBrandsCreateHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_BRANDS)).Patch("/admin/brands/{id}", s.adminBrandsUpdateHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_BRANDS)).Patch("/admin/brands/{id}/status", s.adminBrandsUpdateStatusHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_BRANDS)).Delete("/admin/brands/{id}", s.adminBrandsDeleteHandler)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_CATEGORIES)).Get("/admin/categories", s.adminCategoriesListPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_CATEGORIES)).Get("/admin/categories/table", s.adminCategoriesListTableHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_CATEGORIES)).Get("/admin/categories/subcategories", s.adminCategoriesSubcategoriesHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_CATEGORIES)).Get("/admin/categories/create", s.adminCategoriesCreatePageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_CATEGORIES)).Post("/admin/categories", s.adminCategoriesCreateHandler)
-
-	r.With(s.requireSuperuserAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_PRODUCT_INVENTORIES)).Get("/admin/product-inventories", s.adminProductInventoriesPageHandler)
-	r.With(s.requireSuperuserAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_PRODUCT_INVENTORIES)).Get("/admin/product-inventories/table", s.adminProductInventoriesTableHandler)
-	r.With(s.requireSuperuserAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_PRODUCT_INVENTORIES)).Get("/admin/product-inventories/{id}/update", s.adminProductInventoryUpdateModalHandler)
-	r.With(s.requireSuperuserAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_PRODUCT_INVENTORIES)).Patch("/admin/product-inventories/{id}/update", s.adminProductInventoryUpdateHandler)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_PROMOS)).Get("/admin/promos", s.adminPromosListPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_PROMOS)).Get("/admin/promos/table", s.adminPromosListTableHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_PROMOS)).Get("/admin/promos/create", s.adminPromosCreatePageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_PROMOS)).Get("/admin/promos/{id}/edit", s.adminPromosEditPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_PROMOS)).Post("/admin/promos", s.adminPromosCreateHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_PROMOS)).Patch("/admin/promos/{id}", s.adminPromosUpdateHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_PROMOS)).Delete("/admin/promos/{id}", s.adminPromosDeleteHandler)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_MEMO)).Get("/admin/memos", s.adminMemosListPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_MEMO)).Get("/admin/memos/table", s.adminMemosListTableHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_MEMO)).Get("/admin/memos/{id}/staff", s.adminMemosStaffRowsHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_MEMO)).Get("/admin/memos/create", s.adminMemosCreatePageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_MEMO)).Get("/admin/memos/{id}/edit", s.adminMemosEditPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_MEMO)).Post("/admin/memos", s.adminMemosCreateHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_MEMO)).Patch("/admin/memos/{id}", s.adminMemosUpdateHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_MEMO)).Delete("/admin/memos/{id}", s.adminMemosDeleteHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_MEMO)).Post("/admin/memos/{id}/send-emails", s.adminMemosSendEmailsHandler)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_EXPORTS)).Get("/admin/exports", s.adminExportsPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_EXPORTS_PRODUCTS)).Get("/admin/exports/products/modal", s.adminExportsProductsModalHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_EXPORTS_PRODUCTS)).Get("/admin/exports/products/count", s.adminExportsProductsCountHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_EXPORTS_PRODUCTS)).Post("/admin/exports/products", s.adminExportsProductsHandler)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_EDIT_PRODUCTS)).Get("/admin/imports", s.adminImportsPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_EDIT_PRODUCTS)).Get("/admin/imports/products/modal", s.adminImportsProductsModalHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_EDIT_PRODUCTS)).Post("/admin/imports/products/preview", s.adminImportsProductsPreviewHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_EDIT_PRODUCTS)).Post("/admin/imports/products/apply", s.adminImportsProductsApplyHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_THEMES)).Get("/admin/themes", s.adminThemesListPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_THEMES)).Get("/admin/themes/table", s.adminThemesListTableHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_THEMES)).Get("/admin/themes/create", s.adminThemesCreatePageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_THEMES)).Post("/admin/themes", s.adminThemesCreateHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_THEMES)).Get("/admin/themes/{id}/edit", s.adminThemesEditPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_THEMES)).Patch("/admin/themes/{id}", s.adminThemesUpdateHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_THEMES)).Delete("/admin/themes/{id}", s.adminThemesDeleteHandler)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS)).Get("/admin/tracked-links", s.adminTrackedLinksListPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS)).Get("/admin/tracked-links/table", s.adminTrackedLinksListTableHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS)).Get("/admin/tracked-links/create", s.adminTrackedLinksCreateModalHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS)).Get("/admin/tracked-links/{id}/edit", s.adminTrackedLinksEditPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS)).Post("/admin/tracked-links", s.adminTrackedLinksCreateHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS)).Patch("/admin/tracked-links/{id}", s.adminTrackedLinksUpdateHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS)).Delete("/admin/tracked-links/{id}", s.adminTrackedLinksDeleteHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS)).Post("/admin/tracked-links/{id}/qr", s.handleTrackedLinkQR)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_ORDERS)).Get("/admin/orders", s.adminOrdersListPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_ORDERS)).Get("/admin/orders/table", s.adminOrdersListTableHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_ORDERS)).Get("/admin/orders/{id}/details", s.adminOrdersDetailsHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_ORDERS)).Get("/admin/orders/{id}/manage", s.adminOrdersManageModalHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_ORDERS)).Get("/admin/orders/{id}/track", s.adminOrdersTrackModalHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_ORDERS)).Patch("/admin/orders/{id}/status", s.adminOrdersUpdateStatusHandler)
-
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_QUOTATIONS)).Get("/admin/quotations", s.adminQuotationsListPageHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_QUOTATIONS)).Get("/admin/quotations/table", s.adminQuotationsListTableHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_QUOTATIONS)).Get("/admin/quotations/{id}/details", s.adminQuotationsDetailsHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_QUOTATIONS)).Get("/admin/quotations/{id}/approve", s.adminQuotationsApproveModalHandler)
-	r.With(s.requireStaffAuth, s.AllowRoles(enums.STAFF_ROLE_MANAGE_QUOTATIONS)).Patch("/admin/quotations/{id}/approve", s.adminQuotationsApproveHandler)
+	r.With(s.Permit(rbac.Public())).Get("/admin/login/2fa", s.adminTwoFactorVerifyPageHandler)
+	r.With(s.Permit(rbac.Public())).Get("/admin/login/2fa/enroll", s.adminTwoFactorEnrolPageHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/logout", s.adminLogoutHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff", s.adminStaffHomeHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/memos/{id}/accept", s.adminStaffMemoAcceptHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff/memos/{id}/reject", s.adminStaffMemoRejectModalHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/memos/{id}/reject", s.adminStaffMemoRejectHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/profile", s.adminStaffProfileHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/profile-header", s.adminStaffProfileHeaderHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/profile/edit", s.adminProfileEditFormHandler)
+	r.With(s.Permit(rbac.Staff())).Patch("/admin/profile", s.adminProfileUpdateHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/change-password", s.adminChangePasswordHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/profile/sessions", s.adminProfileSessionsHandler)
+	r.With(s.Permit(rbac.Staff())).Delete("/admin/profile/sessions", s.adminProfileSessionsSignOutOthersHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/profile/2fa", s.adminProfileTwoFactorPageHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/profile/2fa/enroll", s.adminProfileTwoFactorEnrolHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/profile/2fa/recovery-codes", s.adminProfileTwoFactorRecoveryCodesHandler)
+	r.With(s.Permit(rbac.Staff())).Delete("/admin/profile/2fa/devices", s.adminProfileTwoFactorDevicesDeleteHandler)
+	r.With(s.Permit(rbac.Staff())).Delete("/admin/profile/2fa", s.adminProfileTwoFactorDeleteHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff/list", s.adminStaffListHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/customers/list", s.adminCustomersListHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff/attendance", s.adminStaffPageHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff/attendance/table", s.adminStaffAttendanceTableHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff/attendance/rows", s.adminStaffAttendanceRowsHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff/attendance/corrections", s.adminStaffAttendanceCorrectionsTableHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/attendance/corrections", s.adminStaffAttendanceCorrectionRequestHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/time-in", s.adminStaffTimeInHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/time-out", s.adminStaffTimeOutHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/lunch-break-start", s.adminStaffLunchBreakInHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/lunch-break-end", s.adminStaffLunchBreakOutHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff/time-off", s.adminStaffTimeOffPageHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/time-off", s.adminStaffTimeOffHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff/time-off/table", s.adminStaffTimeOffTableHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff/time-off/balance", s.adminStaffTimeOffBalanceHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff/shifts", s.adminStaffShiftsPageHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/shifts/swaps", s.adminStaffShiftSwapRequestHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff/incidents", s.adminStaffIncidentsPageHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/incidents/{id}/response", s.adminStaffIncidentRespondHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/attendance/location", s.adminStaffAttendanceLocationHandler)
+	r.With(s.Permit(rbac.Staff())).Get("/admin/staff/kiosk/scan", s.adminStaffKioskScanPageHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/kiosk/scan", s.adminStaffKioskScanPunchHandler)
+	r.With(s.Permit(rbac.Staff())).Post("/admin/staff/kiosk/pin", s.adminStaffKioskSetPinHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_ATTENDANCE_KIOSK))).Get("/admin/staff/kiosk", s.adminStaffKioskPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_ATTENDANCE_KIOSK))).Get("/admin/staff/kiosk/qr", s.adminStaffKioskQRHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_ATTENDANCE_KIOSK))).Post("/admin/staff/kiosk/pin-punch", s.adminStaffKioskPinPunchHandler)
+
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_CREATE_PRODUCT))).Get("/admin/superuser/products/create", s.adminSuperuserProductsCreatePageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_CREATE_PRODUCT))).Post("/admin/superuser/products/create", s.adminSuperuserProductsCreatePostHandler)
+	r.With(s.Permit(rbac.AnyOf(rbac.Permission{Role: enums.STAFF_ROLE_CREATE_PRODUCT, Access: enums.PERMISSION_ACCESS_READ}, rbac.Permission{Role: enums.STAFF_ROLE_EDIT_PRODUCTS, Access: enums.PERMISSION_ACCESS_READ}))).Get("/admin/superuser/products/subcategories", s.adminSuperuserProductsSubcategoriesHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_CREATE_PRODUCT))).Get("/admin/superuser/products/validate-serial", s.adminSuperuserProductsValidateSerialHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_EDIT_PRODUCTS))).Get("/admin/products", s.adminStaffProductsListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_EDIT_PRODUCTS))).Get("/admin/products/table", s.adminStaffProductsListTableHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_EDIT_PRODUCTS))).Get("/admin/products/{id}/edit", s.adminStaffProductsEditPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_EDIT_PRODUCTS))).Patch("/admin/products/{id}", s.adminStaffProductsUpdateHandler)
+
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_CREATE_CPOINTS))).Get("/admin/cpoints/generate", s.adminCPointsGeneratePageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_CREATE_CPOINTS))).Post("/admin/cpoints/generate", s.adminCPointsGeneratePostHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_CREATE_CPOINTS))).Get("/admin/cpoints/code", s.adminCPointsCodePageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_CREATE_CPOINTS))).Get("/admin/cpoints/qr", s.adminCPointsQRHandler)
+
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser", s.adminSuperuserHomeHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/envs", s.adminSuperuserEnvsHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/attendance", s.adminSuperuserAttendancePageHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/attendance/table", s.adminSuperuserAttendanceHandler)
+	r.With(s.Permit(rbac.Superuser())).Post("/admin/superuser/attendance/report", s.adminSuperuserAttendanceReportHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/attendance/corrections", s.adminSuperuserAttendanceCorrectionsTableHandler)
+	r.With(s.Permit(rbac.Superuser())).Patch("/admin/superuser/attendance/corrections/{id}/approve", s.adminSuperuserAttendanceCorrectionApproveHandler)
+	r.With(s.Permit(rbac.Superuser())).Patch("/admin/superuser/attendance/corrections/{id}/reject", s.adminSuperuserAttendanceCorrectionRejectHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/time-off", s.adminSuperuserTimeOffPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/time-off/table", s.adminSuperuserTimeOffTableHandler)
+	r.With(s.Permit(rbac.Superuser())).Patch("/admin/superuser/time-off/{id}/approve", s.adminSuperuserTimeOffApproveHandler)
+	r.With(s.Permit(rbac.Superuser())).Patch("/admin/superuser/time-off/{id}/cancel", s.adminSuperuserTimeOffCancelHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/leave-credits", s.adminSuperuserLeaveCreditsPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/leave-credits/table", s.adminSuperuserLeaveCreditsTableHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/leave-credits/{id}/ledger", s.adminSuperuserLeaveLedgerHandler)
+	r.With(s.Permit(rbac.Superuser())).Post("/admin/superuser/leave-credits/adjustments", s.adminSuperuserLeaveAdjustHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/incidents", s.adminSuperuserIncidentsPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/incidents/table", s.adminSuperuserIncidentsTableHandler)
+	r.With(s.Permit(rbac.Superuser())).Post("/admin/superuser/incidents", s.adminSuperuserIncidentCreateHandler)
+	r.With(s.Permit(rbac.Superuser())).Post("/admin/superuser/incidents/{id}/nte", s.adminSuperuserIncidentNTEHandler)
+	r.With(s.Permit(rbac.Superuser())).Post("/admin/superuser/incidents/{id}/decision", s.adminSuperuserIncidentDecisionHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/kiosk-punches", s.adminSuperuserKioskPunchesPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/kiosk-punches/table", s.adminSuperuserKioskPunchesTableHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/kiosk-punches/{id}/photo", s.adminSuperuserKioskPunchPhotoHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/shifts", s.adminSuperuserShiftsPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/shifts/table", s.adminSuperuserShiftsTableHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/shifts/swaps", s.adminSuperuserShiftSwapsTableHandler)
+	r.With(s.Permit(rbac.Superuser())).Patch("/admin/superuser/shifts/swaps/{id}/approve", s.adminSuperuserShiftSwapApproveHandler)
+	r.With(s.Permit(rbac.Superuser())).Patch("/admin/superuser/shifts/swaps/{id}/reject", s.adminSuperuserShiftSwapRejectHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/shifts/{id}", s.adminSuperuserShiftEditorPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Patch("/admin/superuser/shifts/{id}/rotation", s.adminSuperuserShiftRotationHandler)
+	r.With(s.Permit(rbac.Superuser())).Patch("/admin/superuser/shifts/{id}/days", s.adminSuperuserShiftDayHandler)
+	r.With(s.Permit(rbac.Superuser())).Delete("/admin/superuser/shifts/{id}/days", s.adminSuperuserShiftClearHandler)
+	r.With(s.Permit(rbac.Superuser())).Post("/admin/superuser/shifts/{id}/overrides", s.adminSuperuserShiftOverrideCreateHandler)
+	r.With(s.Permit(rbac.Superuser())).Delete("/admin/superuser/shifts/{id}/overrides/{override_id}", s.adminSuperuserShiftOverrideDeleteHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_PUBLISH_PRODUCTS))).Get("/admin/superuser/products", s.adminSuperuserProductsListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_PUBLISH_PRODUCTS))).Get("/admin/superuser/products/table", s.adminSuperuserProductsListTableHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_PUBLISH_PRODUCTS))).Patch("/admin/superuser/products/{id}/status", s.adminSuperuserProductsUpdateStatusHandler)
+	r.With(s.Permit(rbac.Superuser())).Delete("/admin/superuser/products/{id}", s.adminSuperuserProductsDeleteHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/products/{id}/edit", s.adminSuperuserProductsEditPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Patch("/admin/superuser/products/{id}", s.adminSuperuserProductsUpdateHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/logs", s.adminSuperuserLogsPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/logs/table", s.adminSuperuserLogsTableHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/logs/actions", s.adminSuperuserLogsActionsHandler)
+
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/customers", s.adminCustomersListPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/customers/table", s.adminCustomersListTableHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/staffs", s.adminSuperuserStaffsListPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/staffs/create", s.adminSuperuserStaffsCreatePageHandler)
+	r.With(s.Permit(rbac.Superuser())).Post("/admin/superuser/staffs/create", s.adminSuperuserStaffsCreatePostHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/staffs/table", s.adminSuperuserStaffsListTableHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/staffs/{id}/edit", s.adminSuperuserStaffsEditPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/staffs/{id}/hr-file", s.adminSuperuserStaffHRFilePageHandler)
+	r.With(s.Permit(rbac.Superuser())).Patch("/admin/superuser/staffs/{id}", s.adminSuperuserStaffsUpdateHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/staffs/roles", s.adminSuperuserStaffsRolesOptionsHandler)
+	r.With(s.Permit(rbac.Superuser())).Patch("/admin/superuser/staffs/{id}/role", s.adminSuperuserStaffsRoleHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/sessions", s.adminSuperuserSessionsPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Delete("/admin/superuser/sessions/{id}", s.adminSuperuserSessionDeleteHandler)
+	r.With(s.Permit(rbac.Superuser())).Delete("/admin/superuser/sessions/users/{user_type}/{id}", s.adminSuperuserSessionsUserDeleteHandler)
+	r.With(s.Permit(rbac.Superuser())).Get("/admin/superuser/permissions", s.adminSuperuserPermissionsPageHandler)
+	r.With(s.Permit(rbac.Superuser())).Post("/admin/superuser/permissions/groups", s.adminSuperuserPermissionGroupCreateHandler)
+	r.With(s.Permit(rbac.Superuser())).Delete("/admin/superuser/permissions/groups/{id}", s.adminSuperuserPermissionGroupDeleteHandler)
+	r.With(s.Permit(rbac.Superuser())).Patch("/admin/superuser/permissions/groups/{id}/roles", s.adminSuperuserPermissionGroupRoleHandler)
+	r.With(s.Permit(rbac.Superuser())).Post("/admin/superuser/permissions/staffs/{id}/groups", s.adminSuperuserPermissionStaffGroupAssignHandler)
+	r.With(s.Permit(rbac.Superuser())).Delete("/admin/superuser/permissions/staffs/{id}/groups/{group_id}", s.adminSuperuserPermissionStaffGroupUnassignHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_HOLIDAYS))).Get("/admin/holidays", s.adminHolidaysListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_HOLIDAYS))).Get("/admin/holidays/table", s.adminHolidaysListTableHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_HOLIDAYS))).Get("/admin/holidays/{id}/edit", s.adminHolidaysEditPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_HOLIDAYS))).Post("/admin/holidays", s.adminHolidaysCreateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_HOLIDAYS))).Patch("/admin/holidays/{id}", s.adminHolidaysUpdateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_HOLIDAYS))).Delete("/admin/holidays/{id}", s.adminHolidaysDeleteHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_BRANDS))).Get("/admin/brands", s.adminBrandsListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_BRANDS))).Get("/admin/brands/table", s.adminBrandsListTableHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_BRANDS))).Get("/admin/brands/create", s.adminBrandsCreatePageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_BRANDS))).Get("/admin/brands/{id}/edit", s.adminBrandsEditPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_BRANDS))).Post("/admin/brands", s.adminBrandsCreateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_BRANDS))).Patch("/admin/brands/{id}", s.adminBrandsUpdateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_BRANDS))).Patch("/admin/brands/{id}/status", s.adminBrandsUpdateStatusHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_BRANDS))).Delete("/admin/brands/{id}", s.adminBrandsDeleteHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_CATEGORIES))).Get("/admin/categories", s.adminCategoriesListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_CATEGORIES))).Get("/admin/categories/table", s.adminCategoriesListTableHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_CATEGORIES))).Get("/admin/categories/subcategories", s.adminCategoriesSubcategoriesHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_CATEGORIES))).Get("/admin/categories/create", s.adminCategoriesCreatePageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_CATEGORIES))).Post("/admin/categories", s.adminCategoriesCreateHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_PRODUCT_INVENTORIES))).Get("/admin/product-inventories", s.adminProductInventoriesPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_PRODUCT_INVENTORIES))).Get("/admin/product-inventories/table", s.adminProductInventoriesTableHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PRODUCT_INVENTORIES))).Get("/admin/product-inventories/{id}/update", s.adminProductInventoryUpdateModalHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PRODUCT_INVENTORIES))).Patch("/admin/product-inventories/{id}/update", s.adminProductInventoryUpdateHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_PROMOS))).Get("/admin/promos", s.adminPromosListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_PROMOS))).Get("/admin/promos/table", s.adminPromosListTableHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PROMOS))).Get("/admin/promos/create", s.adminPromosCreatePageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PROMOS))).Get("/admin/promos/{id}/edit", s.adminPromosEditPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PROMOS))).Post("/admin/promos", s.adminPromosCreateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PROMOS))).Patch("/admin/promos/{id}", s.adminPromosUpdateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PROMOS))).Delete("/admin/promos/{id}", s.adminPromosDeleteHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_PROMOS))).Get("/admin/sale-campaigns", s.adminSaleCampaignsListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_PROMOS))).Get("/admin/sale-campaigns/table", s.adminSaleCampaignsListTableHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PROMOS))).Get("/admin/sale-campaigns/create", s.adminSaleCampaignsCreatePageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PROMOS))).Post("/admin/sale-campaigns/preview", s.adminSaleCampaignsPreviewHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PROMOS))).Post("/admin/sale-campaigns", s.adminSaleCampaignsCreateHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_PROMOS))).Get("/admin/sale-campaigns/{id}", s.adminSaleCampaignDetailPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PROMOS))).Patch("/admin/sale-campaigns/{id}/cancel", s.adminSaleCampaignCancelHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_REVIEWS))).Get("/admin/reviews", s.adminProductReviewsListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_REVIEWS))).Get("/admin/reviews/table", s.adminProductReviewsListTableHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_REVIEWS))).Get("/admin/reviews/{id}/reject", s.adminProductReviewRejectModalHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_REVIEWS))).Patch("/admin/reviews/{id}/approve", s.adminProductReviewApproveHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_REVIEWS))).Patch("/admin/reviews/{id}/reject", s.adminProductReviewRejectHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_PAYROLL))).Get("/admin/payroll", s.adminPayrollPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_PAYROLL))).Get("/admin/payroll/runs/table", s.adminPayrollRunsTableHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PAYROLL))).Patch("/admin/payroll/rates", s.adminPayRateUpdateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PAYROLL))).Post("/admin/payroll/runs", s.adminPayrollRunCreateHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_PAYROLL))).Get("/admin/payroll/runs/{id}", s.adminPayrollRunDetailPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PAYROLL))).Patch("/admin/payroll/runs/{id}/recompute", s.adminPayrollRunRecomputeHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_PAYROLL))).Patch("/admin/payroll/runs/{id}/lock", s.adminPayrollRunLockHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_PAYROLL))).Get("/admin/payroll/runs/{id}/payslips/{staff_id}", s.adminPayslipDownloadHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_MEMO))).Get("/admin/memos", s.adminMemosListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_MEMO))).Get("/admin/memos/table", s.adminMemosListTableHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_MEMO))).Get("/admin/memos/{id}/staff", s.adminMemosStaffRowsHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_MEMO))).Get("/admin/memos/{id}/acknowledgements", s.adminMemosAcknowledgementsPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_MEMO))).Get("/admin/memos/create", s.adminMemosCreatePageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_MEMO))).Get("/admin/memos/{id}/edit", s.adminMemosEditPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_MEMO))).Post("/admin/memos", s.adminMemosCreateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_MEMO))).Patch("/admin/memos/{id}", s.adminMemosUpdateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_MEMO))).Delete("/admin/memos/{id}", s.adminMemosDeleteHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_MEMO))).Post("/admin/memos/{id}/send-emails", s.adminMemosSendEmailsHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_EXPORTS))).Get("/admin/exports", s.adminExportsPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_EXPORTS_PRODUCTS))).Get("/admin/exports/products/modal", s.adminExportsProductsModalHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_EXPORTS_PRODUCTS))).Get("/admin/exports/products/count", s.adminExportsProductsCountHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_EXPORTS_PRODUCTS))).Post("/admin/exports/products", s.adminExportsProductsHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_EDIT_PRODUCTS))).Get("/admin/imports", s.adminImportsPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_EDIT_PRODUCTS))).Get("/admin/imports/products/modal", s.adminImportsProductsModalHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_EDIT_PRODUCTS))).Post("/admin/imports/products/preview", s.adminImportsProductsPreviewHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_EDIT_PRODUCTS))).Post("/admin/imports/products/apply", s.adminImportsProductsApplyHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_THEMES))).Get("/admin/themes", s.adminThemesListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_THEMES))).Get("/admin/themes/table", s.adminThemesListTableHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_THEMES))).Get("/admin/themes/create", s.adminThemesCreatePageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_THEMES))).Post("/admin/themes", s.adminThemesCreateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_THEMES))).Get("/admin/themes/{id}/edit", s.adminThemesEditPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_THEMES))).Patch("/admin/themes/{id}", s.adminThemesUpdateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_THEMES))).Delete("/admin/themes/{id}", s.adminThemesDeleteHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_VIEW_ANALYTICS))).Get("/admin/analytics", s.adminAnalyticsPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_VIEW_ANALYTICS))).Get("/admin/analytics/export.xlsx", s.adminAnalyticsExportHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_SEARCH))).Get("/admin/search", s.adminSearchPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_SEARCH))).Post("/admin/search/rules", s.adminSearchRuleCreateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_SEARCH))).Delete("/admin/search/rules/{id}", s.adminSearchRuleDeleteHandler)
+
+	r.With(s.Permit(experimentsRead)).Get("/admin/experiments", s.adminExperimentsListPageHandler)
+	r.With(s.Permit(experimentsWrite)).Post("/admin/experiments", s.adminExperimentsCreateHandler)
+	r.With(s.Permit(experimentsRead)).Get("/admin/experiments/{id}", s.adminExperimentDetailPageHandler)
+	r.With(s.Permit(experimentsWrite)).Post("/admin/experiments/{id}/variants", s.adminExperimentVariantCreateHandler)
+	r.With(s.Permit(experimentsWrite)).Delete("/admin/experiments/{id}/variants/{variant_id}", s.adminExperimentVariantDeleteHandler)
+	r.With(s.Permit(experimentsWrite)).Patch("/admin/experiments/{id}/publish", s.adminExperimentPublishHandler)
+	r.With(s.Permit(experimentsWrite)).Patch("/admin/experiments/{id}/unpublish", s.adminExperimentUnpublishHandler)
+	r.With(s.Permit(experimentsWrite)).Delete("/admin/experiments/{id}", s.adminExperimentDeleteHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS))).Get("/admin/tracked-links", s.adminTrackedLinksListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS))).Get("/admin/tracked-links/table", s.adminTrackedLinksListTableHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS))).Get("/admin/tracked-links/campaigns", s.adminTrackedLinkCampaignsPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS))).Get("/admin/tracked-links/{id}/analytics", s.adminTrackedLinkAnalyticsPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS))).Get("/admin/tracked-links/{id}/clicks.csv", s.adminTrackedLinkClicksExportHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS))).Get("/admin/tracked-links/create", s.adminTrackedLinksCreateModalHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS))).Get("/admin/tracked-links/{id}/edit", s.adminTrackedLinksEditPageHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS))).Post("/admin/tracked-links", s.adminTrackedLinksCreateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS))).Patch("/admin/tracked-links/{id}", s.adminTrackedLinksUpdateHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS))).Delete("/admin/tracked-links/{id}", s.adminTrackedLinksDeleteHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_TRACKED_LINKS))).Post("/admin/tracked-links/{id}/qr", s.handleTrackedLinkQR)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_ORDERS))).Get("/admin/orders", s.adminOrdersListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_ORDERS))).Get("/admin/orders/table", s.adminOrdersListTableHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_ORDERS))).Get("/admin/orders/{id}/details", s.adminOrdersDetailsHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_ORDERS))).Get("/admin/orders/{id}/manage", s.adminOrdersManageModalHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_ORDERS))).Get("/admin/orders/{id}/track", s.adminOrdersTrackModalHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_ORDERS))).Patch("/admin/orders/{id}/status", s.adminOrdersUpdateStatusHandler)
+
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_QUOTATIONS))).Get("/admin/quotations", s.adminQuotationsListPageHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_QUOTATIONS))).Get("/admin/quotations/table", s.adminQuotationsListTableHandler)
+	r.With(s.Permit(rbac.Read(enums.STAFF_ROLE_MANAGE_QUOTATIONS))).Get("/admin/quotations/{id}/details", s.adminQuotationsDetailsHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_QUOTATIONS))).Get("/admin/quotations/{id}/approve", s.adminQuotationsApproveModalHandler)
+	r.With(s.Permit(rbac.Write(enums.STAFF_ROLE_MANAGE_QUOTATIONS))).Patch("/admin/quotations/{id}/approve", s.adminQuotationsApproveHandler)
 }
 
 func (s *Server) adminLoginPageHandler(w http.ResponseWriter, r *http.Request) {
@@ -257,8 +362,6 @@ func (s *Server) adminLoginPageHandler(w http.ResponseWriter, r *http.Request) {
 func (s *Server) adminLoginHandler(w http.ResponseWriter, r *http.Request) {
 	const logtag = "[Admin Login Handler]"
 	const page = "/admin"
-	const superuserPage = "/admin/superuser"
-	const staffPage = "/admin/staff"
 	ctx := r.Context()
 
 	var f forms.AdminLoginForm
@@ -306,37 +409,40 @@ func (s *Server) adminLoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	metrics.Auth.LoginAttempt(metrics.AuthUserTypeAdmin, metrics.AuthResultSuccess)
-
-	s.sessionManager.Put(ctx, SessionStaffID, s.encoder.Encode(staff.ID))
-
-	useragentID := sql.NullInt64{}
-	if ua := r.UserAgent(); ua != "" {
-		useragentID = getOrCreateUserAgentID(context.Background(), s.dbRW, ua)
+	if f.LocationLat != "" && f.LocationLng != "" {
+		SetLocation(ctx, s.sessionManager, f.LocationLat, f.LocationLng)
 	}
-	accessID, err := s.dbRW.GetQueries().CreateStaffAccess(context.Background(), queries.CreateStaffAccessParams{
-		StaffID:     staff.ID,
-		UseragentID: useragentID,
-	})
+
+	staffID := s.encoder.Encode(staff.ID)
+	twoFactor, err := s.services.staffTwoFactor.GetStatus(ctx, staffID)
 	if err != nil {
 		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
-	} else {
-		s.sessionManager.Put(ctx, SessionStaffAccessID, accessID)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
 	}
 
-	if f.LocationLat != "" && f.LocationLng != "" {
-		SetLocation(ctx, s.sessionManager, f.LocationLat, f.LocationLng)
+	switch {
+	case twoFactor.Enabled && !s.isTrustedStaffDevice(r, staffID):
+		s.startPendingStaff(ctx, staffID)
+		redirectHX(w, r, utils.URL("/admin/login/2fa"))
+		return
+	case !twoFactor.Enabled && enums.ParseStaffUserTypeToEnum(staff.UserType) == enums.STAFF_USER_TYPE_SUPERUSER:
+		s.startPendingStaff(ctx, staffID)
+		redirectHX(w, r, utils.URL("/admin/login/2fa/enroll"))
+		return
+	}
+
+	if err := s.startStaffSession(r, staff.ID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
 	}
+	metrics.Auth.LoginAttempt(metrics.AuthUserTypeAdmin, metrics.AuthResultSuccess)
 
-	switch enums.ParseStaffUserTypeToEnum(staff.UserType) {
-	case enums.STAFF_USER_TYPE_SUPERUSER:
-		redirectHX(w, r, utils.URL(superuserPage))
-	case enums.STAFF_USER_TYPE_STAFF:
-		redirectHX(w, r, utils.URL(staffPage))
-	default:
+	if enums.ParseStaffUserTypeToEnum(staff.UserType) == enums.STAFF_USER_TYPE_UNDEFINED {
 		logs.Log().Warn(logtag, zap.String("got unhandled", staff.UserType))
-		redirectHX(w, r, utils.URL(page))
 	}
+	redirectHX(w, r, staffHomeURL(staff.UserType))
 }
 
 func (s *Server) adminLogoutHandler(w http.ResponseWriter, r *http.Request) {
@@ -351,6 +457,7 @@ func (s *Server) adminLogoutHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	s.signOutSession(r)
 	if err := s.sessionManager.Destroy(ctx); err != nil {
 		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
 	}
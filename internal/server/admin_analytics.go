@@ -0,0 +1,110 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/services"
+	"cchoice/internal/utils"
+
+	"github.com/xuri/excelize/v2"
+	"go.uber.org/zap"
+)
+
+const adminAnalyticsPage = "/admin/analytics"
+
+func (s *Server) adminAnalyticsPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Analytics Page Handler]"
+	ctx := r.Context()
+
+	dashboard, ok := s.loadAnalyticsDashboard(w, r, logtag)
+	if !ok {
+		return
+	}
+
+	activeBrands, err := s.services.brand.GetAllActive(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError("/admin", err.Error()))
+		return
+	}
+	brands := make([]models.AdminAnalyticsOption, 0, len(activeBrands))
+	for _, brand := range activeBrands {
+		brands = append(brands, models.AdminAnalyticsOption{Value: s.encoder.Encode(brand.ID), Label: brand.Name})
+	}
+
+	if err := compadmin.AdminAnalyticsPage(*dashboard, brands).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError("/admin", err.Error()))
+	}
+}
+
+func (s *Server) adminAnalyticsExportHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Analytics Export Handler]"
+	ctx := r.Context()
+
+	dashboard, ok := s.loadAnalyticsDashboard(w, r, logtag)
+	if !ok {
+		return
+	}
+
+	reportName := fmt.Sprintf(
+		"export_analytics_%s_%s.xlsx",
+		dashboard.StartDate,
+		dashboard.EndDate,
+	)
+
+	file := excelize.NewFile()
+	defer file.Close()
+
+	adminStaffID := s.sessionManager.GetString(ctx, SessionStaffID)
+	if err := s.services.analytics.WriteDashboardXLSX(ctx, file, dashboard, adminStaffID, reportName); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(adminAnalyticsPage, err.Error()))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := file.Write(&buf); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(adminAnalyticsPage, err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Disposition", "attachment; filename="+reportName)
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+	}
+}
+
+func (s *Server) loadAnalyticsDashboard(w http.ResponseWriter, r *http.Request, logtag string) (*services.AnalyticsDashboard, bool) {
+	ctx := r.Context()
+
+	var q forms.AdminAnalyticsQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		redirectHX(w, r, utils.URLWithError(adminAnalyticsPage, httputil.ErrorMessage(err)))
+		return nil, false
+	}
+
+	start, end, err := services.ResolveAnalyticsRange(q.StartDate, q.EndDate, utils.NowPH())
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(adminAnalyticsPage, fmt.Sprintf("%s. Ranges are limited to %d days.", err.Error(), constants.AnalyticsMaxDays)))
+		return nil, false
+	}
+
+	dashboard, err := s.services.analytics.GetDashboard(ctx, start, end, q.BrandID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(adminAnalyticsPage, err.Error()))
+		return nil, false
+	}
+	return dashboard, true
+}
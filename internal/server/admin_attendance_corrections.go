@@ -0,0 +1,172 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/enums"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/services"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+func (s *Server) adminStaffAttendanceCorrectionsTableHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Attendance Corrections Table Handler]"
+	ctx := r.Context()
+
+	corrections, err := s.services.attendanceCorrection.GetCorrections(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		enums.ATTENDANCE_CORRECTION_STATUS_UNDEFINED,
+	)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := compadmin.StaffAttendanceCorrectionsTable(attendanceCorrectionItems(corrections)).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminStaffAttendanceCorrectionRequestHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Attendance Correction Request Handler]"
+	const page = "/admin/staff/attendance"
+	ctx := r.Context()
+
+	var f forms.AdminStaffAttendanceCorrectionForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if _, err := s.services.attendanceCorrection.RequestCorrection(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		f.Date,
+		services.AttendanceCorrectionTimes{
+			TimeIn:        f.TimeIn,
+			TimeOut:       f.TimeOut,
+			LunchBreakIn:  f.LunchBreakIn,
+			LunchBreakOut: f.LunchBreakOut,
+		},
+		f.Reason,
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Correction requested. Waiting for approval."))
+}
+
+func (s *Server) adminSuperuserAttendanceCorrectionsTableHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Attendance Corrections Table Handler]"
+	ctx := r.Context()
+
+	var q forms.AdminAttendanceCorrectionsQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		http.Error(w, httputil.ErrorMessage(err), http.StatusBadRequest)
+		return
+	}
+
+	corrections, err := s.services.attendanceCorrection.GetCorrections(
+		ctx,
+		"",
+		enums.ParseAttendanceCorrectionStatusToEnum(q.Status),
+	)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := compadmin.AdminSuperuserAttendanceCorrectionsTable(attendanceCorrectionItems(corrections)).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserAttendanceCorrectionApproveHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Attendance Correction Approve Handler]"
+	const page = "/admin/superuser/attendance"
+	ctx := r.Context()
+
+	var p forms.AdminAttendanceCorrectionPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if err := s.services.attendanceCorrection.ApproveCorrection(ctx, s.sessionManager.GetString(ctx, SessionStaffID), p.ID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("correction id", p.ID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Attendance correction approved"))
+}
+
+func (s *Server) adminSuperuserAttendanceCorrectionRejectHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Attendance Correction Reject Handler]"
+	const page = "/admin/superuser/attendance"
+	ctx := r.Context()
+
+	var p forms.AdminAttendanceCorrectionPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if err := s.services.attendanceCorrection.RejectCorrection(ctx, s.sessionManager.GetString(ctx, SessionStaffID), p.ID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("correction id", p.ID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Attendance correction rejected"))
+}
+
+func attendanceCorrectionItems(corrections []services.AttendanceCorrection) []models.AttendanceCorrectionItem {
+	items := make([]models.AttendanceCorrectionItem, 0, len(corrections))
+	for _, c := range corrections {
+		items = append(items, models.AttendanceCorrectionItem{
+			ID:        c.ID,
+			StaffName: c.StaffName,
+			Date:      c.Date,
+			Requested: formatCorrectionTimes(c.Requested),
+			Original:  formatCorrectionTimes(c.Original),
+			Reason:    c.Reason,
+			Status:    c.Status,
+			CreatedAt: utils.ConvertToPH(c.CreatedAt.UTC().Format(constants.DateTimeLayoutISO)),
+		})
+	}
+	return items
+}
+
+func formatCorrectionTimes(t services.AttendanceCorrectionTimes) string {
+	parts := make([]string, 0, 4)
+	for _, field := range []struct {
+		label string
+		value string
+	}{
+		{"In", t.TimeIn},
+		{"Out", t.TimeOut},
+		{"Break start", t.LunchBreakIn},
+		{"Break end", t.LunchBreakOut},
+	} {
+		if field.value != "" {
+			parts = append(parts, field.label+" "+field.value)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,351 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/rbac"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/services"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+const adminExperimentsPage = "/admin/experiments"
+
+// The experiment routes let in staff managing either themes or promos. Each
+// handler then checks the role of the experiment's own kind with
+// allowExperimentKind, so one role cannot change what the other manages.
+var (
+	experimentsRead = rbac.AnyOf(
+		rbac.Permission{Role: enums.STAFF_ROLE_MANAGE_THEMES, Access: enums.PERMISSION_ACCESS_READ},
+		rbac.Permission{Role: enums.STAFF_ROLE_MANAGE_PROMOS, Access: enums.PERMISSION_ACCESS_READ},
+	)
+	experimentsWrite = rbac.AnyOf(
+		rbac.Permission{Role: enums.STAFF_ROLE_MANAGE_THEMES, Access: enums.PERMISSION_ACCESS_WRITE},
+		rbac.Permission{Role: enums.STAFF_ROLE_MANAGE_PROMOS, Access: enums.PERMISSION_ACCESS_WRITE},
+	)
+)
+
+// experimentRole is the role an experiment kind is managed under.
+func experimentRole(kind enums.ExperimentKind) enums.StaffRole {
+	if kind == enums.EXPERIMENT_KIND_PROMO_BANNERS {
+		return enums.STAFF_ROLE_MANAGE_PROMOS
+	}
+	return enums.STAFF_ROLE_MANAGE_THEMES
+}
+
+// allowExperimentKind redirects to page with ErrRoleForbidden unless the staff
+// has access to the role of kind. access is rbac.Read or rbac.Write.
+func (s *Server) allowExperimentKind(
+	w http.ResponseWriter,
+	r *http.Request,
+	kind enums.ExperimentKind,
+	access func(enums.StaffRole) rbac.Requirement,
+	page string,
+) bool {
+	if s.HasPermission(r.Context(), access(experimentRole(kind))) {
+		return true
+	}
+	redirectHX(w, r, utils.URLWithError(page, errs.ErrRoleForbidden.Error()))
+	return false
+}
+
+// allowExperiment is allowExperimentKind for a saved experiment.
+func (s *Server) allowExperiment(
+	w http.ResponseWriter,
+	r *http.Request,
+	experimentID string,
+	access func(enums.StaffRole) rbac.Requirement,
+	page string,
+) bool {
+	ctx := r.Context()
+	detail, err := s.services.experiment.GetExperimentDetail(ctx, experimentID)
+	if err != nil {
+		logs.LogCtx(ctx).Error("[Admin Experiments]", zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return false
+	}
+	return s.allowExperimentKind(w, r, detail.Experiment.Kind, access, page)
+}
+
+func (s *Server) adminExperimentsListPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Experiments List Page Handler]"
+	ctx := r.Context()
+
+	all, err := s.services.experiment.GetExperiments(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError("/admin", err.Error()))
+		return
+	}
+	readable := map[enums.ExperimentKind]bool{}
+	for _, kind := range enums.AllExperimentKinds {
+		readable[kind] = s.HasPermission(ctx, rbac.Read(experimentRole(kind)))
+	}
+	experiments := make([]services.Experiment, 0, len(all))
+	for _, e := range all {
+		if readable[e.Kind] {
+			experiments = append(experiments, e)
+		}
+	}
+
+	if err := compadmin.AdminExperimentsListPage(experiments).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, err.Error()))
+	}
+}
+
+func (s *Server) adminExperimentsCreateHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Experiments Create Handler]"
+	ctx := r.Context()
+
+	var f forms.AdminExperimentForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, httputil.ErrorMessage(err)))
+		return
+	}
+
+	kind := enums.ParseExperimentKindToEnum(f.Kind)
+	if kind == enums.EXPERIMENT_KIND_UNDEFINED {
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, errs.ErrInvalidParams.Error()))
+		return
+	}
+	if !s.allowExperimentKind(w, r, kind, rbac.Write, adminExperimentsPage) {
+		return
+	}
+	startDate, err := time.Parse(constants.DateLayoutISO, f.StartDate)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, "Invalid start date format"))
+		return
+	}
+	endDate, err := time.Parse(constants.DateLayoutISO, f.EndDate)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, "Invalid end date format"))
+		return
+	}
+
+	experimentID, err := s.services.experiment.CreateExperiment(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		f.Name,
+		kind,
+		startDate,
+		endDate,
+	)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(adminExperimentsPage+"/"+experimentID, "Experiment created. Add the variants next."))
+}
+
+func (s *Server) adminExperimentDetailPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Experiment Detail Page Handler]"
+	ctx := r.Context()
+
+	experimentID, ok := s.bindExperimentPath(w, r)
+	if !ok {
+		return
+	}
+
+	detail, err := s.services.experiment.GetExperimentDetail(ctx, experimentID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, err.Error()))
+		return
+	}
+	if !s.allowExperimentKind(w, r, detail.Experiment.Kind, rbac.Read, adminExperimentsPage) {
+		return
+	}
+
+	var themes, promos []models.AdminExperimentOption
+	switch detail.Experiment.Kind {
+	case enums.EXPERIMENT_KIND_THEME:
+		allThemes, err := s.services.theme.GetAllThemes(ctx, "", "", "")
+		if err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+			redirectHX(w, r, utils.URLWithError(adminExperimentsPage, err.Error()))
+			return
+		}
+		for _, t := range allThemes {
+			if t.Status == enums.THEME_STATUS_DELETED {
+				continue
+			}
+			themes = append(themes, models.AdminExperimentOption{Value: s.encoder.Encode(t.ID), Label: t.Title})
+		}
+	case enums.EXPERIMENT_KIND_PROMO_BANNERS:
+		allPromos, err := s.services.promo.GetAllPromos(ctx)
+		if err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+			redirectHX(w, r, utils.URLWithError(adminExperimentsPage, err.Error()))
+			return
+		}
+		for _, p := range allPromos {
+			promos = append(promos, models.AdminExperimentOption{Value: s.encoder.Encode(p.ID), Label: p.Title})
+		}
+	}
+
+	if err := compadmin.AdminExperimentDetailPage(*detail, themes, promos).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, err.Error()))
+	}
+}
+
+func (s *Server) adminExperimentVariantCreateHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Experiment Variant Create Handler]"
+	ctx := r.Context()
+
+	experimentID, ok := s.bindExperimentPath(w, r)
+	if !ok {
+		return
+	}
+	page := adminExperimentsPage + "/" + experimentID
+	if !s.allowExperiment(w, r, experimentID, rbac.Write, page) {
+		return
+	}
+
+	var f forms.AdminExperimentVariantForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if _, err := s.services.experiment.AddVariant(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		experimentID,
+		f.Name,
+		f.Weight,
+		f.ThemeID,
+		f.PromoIDs,
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Variant added"))
+}
+
+func (s *Server) adminExperimentVariantDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Experiment Variant Delete Handler]"
+	ctx := r.Context()
+
+	var p forms.AdminExperimentVariantPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, errs.ErrInvalidParams.Error()))
+		return
+	}
+	experimentID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, errs.ErrInvalidParams.Error()))
+		return
+	}
+	page := adminExperimentsPage + "/" + experimentID
+	if !s.allowExperiment(w, r, experimentID, rbac.Write, page) {
+		return
+	}
+	variantID, err := httputil.RequireEncodedID(s.encoder, p.VariantID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	if err := s.services.experiment.DeleteVariant(ctx, s.sessionManager.GetString(ctx, SessionStaffID), experimentID, variantID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Variant removed"))
+}
+
+func (s *Server) adminExperimentPublishHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Experiment Publish Handler]"
+	ctx := r.Context()
+
+	experimentID, ok := s.bindExperimentPath(w, r)
+	if !ok {
+		return
+	}
+	page := adminExperimentsPage + "/" + experimentID
+	if !s.allowExperiment(w, r, experimentID, rbac.Write, page) {
+		return
+	}
+
+	if err := s.services.experiment.Publish(ctx, s.sessionManager.GetString(ctx, SessionStaffID), experimentID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Experiment published"))
+}
+
+func (s *Server) adminExperimentUnpublishHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Experiment Unpublish Handler]"
+	ctx := r.Context()
+
+	experimentID, ok := s.bindExperimentPath(w, r)
+	if !ok {
+		return
+	}
+	page := adminExperimentsPage + "/" + experimentID
+	if !s.allowExperiment(w, r, experimentID, rbac.Write, page) {
+		return
+	}
+
+	if err := s.services.experiment.Unpublish(ctx, s.sessionManager.GetString(ctx, SessionStaffID), experimentID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Experiment moved back to draft"))
+}
+
+func (s *Server) adminExperimentDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Experiment Delete Handler]"
+	ctx := r.Context()
+
+	experimentID, ok := s.bindExperimentPath(w, r)
+	if !ok {
+		return
+	}
+	if !s.allowExperiment(w, r, experimentID, rbac.Write, adminExperimentsPage) {
+		return
+	}
+
+	if err := s.services.experiment.DeleteExperiment(ctx, s.sessionManager.GetString(ctx, SessionStaffID), experimentID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(adminExperimentsPage, "Experiment deleted"))
+}
+
+func (s *Server) bindExperimentPath(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var p forms.AdminExperimentPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, errs.ErrInvalidParams.Error()))
+		return "", false
+	}
+	experimentID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(adminExperimentsPage, errs.ErrInvalidParams.Error()))
+		return "", false
+	}
+	return experimentID, true
+}
@@ -35,7 +35,7 @@ func (s *Server) adminExportsPageHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	roles, err := s.services.role.GetByStaffID(ctx, staffIDStr)
+	roles, err := s.services.role.GetEffectiveRolesByStaffID(ctx, staffIDStr)
 	if err != nil {
 		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
 	}
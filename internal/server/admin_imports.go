@@ -28,7 +28,7 @@ func (s *Server) adminImportsPageHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	roles, err := s.services.role.GetByStaffID(ctx, staffIDStr)
+	roles, err := s.services.role.GetEffectiveRolesByStaffID(ctx, staffIDStr)
 	if err != nil {
 		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
 	}
@@ -0,0 +1,318 @@
+package server
+
+import (
+	"net/http"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/services"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+func (s *Server) adminStaffIncidentsPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Incidents Page Handler]"
+	const page = "/admin/staff"
+	ctx := r.Context()
+
+	incidents, err := s.services.incident.GetIncidents(ctx, s.sessionManager.GetString(ctx, SessionStaffID), enums.INCIDENT_STATUS_UNDEFINED)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	// Incidents stay with the superusers until a notice to explain goes out.
+	shared := make([]services.Incident, 0, len(incidents))
+	for _, incident := range incidents {
+		if incident.Status != enums.INCIDENT_STATUS_OPEN {
+			shared = append(shared, incident)
+		}
+	}
+
+	if err := compadmin.AdminStaffIncidentsPage(incidentItems(shared)).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminStaffIncidentRespondHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Incident Respond Handler]"
+	const page = "/admin/staff/incidents"
+	ctx := r.Context()
+
+	var p forms.AdminIncidentPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	var f forms.AdminStaffIncidentResponseForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if err := s.services.incident.Respond(ctx, s.sessionManager.GetString(ctx, SessionStaffID), p.ID, f.Response); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("incident id", p.ID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Response submitted"))
+}
+
+func (s *Server) adminSuperuserIncidentsPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Incidents Page Handler]"
+	const page = "/admin/superuser"
+	ctx := r.Context()
+
+	staffs, err := s.services.staff.GetAll(ctx, maxStaffListSize)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	if err := compadmin.AdminSuperuserIncidentsPage(staffs).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserIncidentsTableHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Incidents Table Handler]"
+	ctx := r.Context()
+
+	var q forms.AdminIncidentsQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		http.Error(w, httputil.ErrorMessage(err), http.StatusBadRequest)
+		return
+	}
+
+	incidents, err := s.services.incident.GetIncidents(ctx, "", enums.ParseIncidentStatusToEnum(q.Status))
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := compadmin.AdminSuperuserIncidentsTable(incidentItems(incidents)).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserIncidentCreateHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Incident Create Handler]"
+	const page = "/admin/superuser/incidents"
+	ctx := r.Context()
+
+	var f forms.AdminSuperuserIncidentForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	staffID, err := httputil.RequireEncodedID(s.encoder, f.StaffID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrStaffIDRequired.Error()))
+		return
+	}
+
+	if _, err := s.services.incident.FileIncident(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		staffID,
+		f.IncidentDate,
+		f.Title,
+		f.Description,
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(hrFilePage(staffID), "Incident filed"))
+}
+
+func (s *Server) adminSuperuserIncidentNTEHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Incident NTE Handler]"
+	ctx := r.Context()
+
+	var p forms.AdminIncidentPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError("/admin/superuser/incidents", httputil.ErrorMessage(err)))
+		return
+	}
+	var f forms.AdminSuperuserIncidentNTEForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError("/admin/superuser/incidents", httputil.ErrorMessage(err)))
+		return
+	}
+	staffID, err := httputil.RequireEncodedID(s.encoder, f.StaffID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError("/admin/superuser/incidents", errs.ErrStaffIDRequired.Error()))
+		return
+	}
+	page := hrFilePage(staffID)
+
+	deadline, err := utils.ParseInPH(constants.DateLayoutISO, f.Deadline)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrIncidentDeadline.Error()))
+		return
+	}
+
+	if err := s.services.incident.IssueNTE(ctx, s.sessionManager.GetString(ctx, SessionStaffID), p.ID, deadline, f.Message); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("incident id", p.ID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Notice to explain issued"))
+}
+
+func (s *Server) adminSuperuserIncidentDecisionHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Incident Decision Handler]"
+	ctx := r.Context()
+
+	var p forms.AdminIncidentPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError("/admin/superuser/incidents", httputil.ErrorMessage(err)))
+		return
+	}
+	var f forms.AdminSuperuserIncidentDecisionForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError("/admin/superuser/incidents", httputil.ErrorMessage(err)))
+		return
+	}
+	staffID, err := httputil.RequireEncodedID(s.encoder, f.StaffID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError("/admin/superuser/incidents", errs.ErrStaffIDRequired.Error()))
+		return
+	}
+	page := hrFilePage(staffID)
+
+	if err := s.services.incident.Decide(ctx, s.sessionManager.GetString(ctx, SessionStaffID), p.ID, services.IncidentDecisionInput{
+		Decision:      enums.ParseIncidentDecisionToEnum(f.Decision),
+		Notes:         f.Notes,
+		EffectiveFrom: f.EffectiveFrom,
+		EffectiveTo:   f.EffectiveTo,
+	}); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("incident id", p.ID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Decision recorded"))
+}
+
+func (s *Server) adminSuperuserStaffHRFilePageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Staff HR File Page Handler]"
+	const page = "/admin/superuser/staffs"
+	ctx := r.Context()
+
+	var p forms.AdminSuperuserStaffHRFilePath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrStaffIDRequired.Error()))
+		return
+	}
+	staffID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrStaffIDRequired.Error()))
+		return
+	}
+
+	staff, err := s.services.staff.GetByID(ctx, staffID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrStaffLoadFailed.Error()))
+		return
+	}
+
+	incidents, err := s.services.incident.GetIncidents(ctx, staffID, enums.INCIDENT_STATUS_UNDEFINED)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	memos, err := s.services.memo.GetReceivedByStaff(ctx, staffID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	memoItems := make([]models.StaffHRFileMemo, 0, len(memos))
+	for _, memo := range memos {
+		memoItems = append(memoItems, models.StaffHRFileMemo{
+			ID:                      memo.ID,
+			Title:                   memo.Title,
+			StartDate:               memo.StartDate,
+			EndDate:                 memo.EndDate,
+			RequiresAcknowledgement: memo.RequiresAcknowledgement,
+			AcknowledgeBy:           memo.AcknowledgeBy,
+			ActionStatus:            memo.ActionStatus,
+			RejectReason:            memo.RejectReason,
+			ActedAt:                 utils.ConvertToPH(memo.ActedAt),
+		})
+	}
+
+	data := models.StaffHRFilePageData{
+		StaffID:   staffID,
+		StaffName: staff.FullName,
+		Position:  staff.Position,
+		DateHired: staff.DateHired,
+		Status:    staff.Status,
+		Today:     utils.NowPH().Format(constants.DateLayoutISO),
+		Incidents: incidentItems(incidents),
+		Memos:     memoItems,
+	}
+	if err := compadmin.AdminSuperuserStaffHRFilePage(data).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func hrFilePage(staffID string) string {
+	return "/admin/superuser/staffs/" + staffID + "/hr-file"
+}
+
+func incidentItems(incidents []services.Incident) []models.IncidentItem {
+	today := utils.NowPH().Format(constants.DateLayoutISO)
+	items := make([]models.IncidentItem, 0, len(incidents))
+	for _, incident := range incidents {
+		item := models.IncidentItem{
+			ID:               incident.ID,
+			StaffID:          incident.StaffID,
+			StaffName:        incident.StaffName,
+			ReportedByName:   incident.ReportedByName,
+			IncidentDate:     incident.IncidentDate,
+			Title:            incident.Title,
+			Description:      incident.Description,
+			Status:           incident.Status,
+			NTEMemoID:        incident.NTEMemoID,
+			ResponseDeadline: incident.ResponseDeadline,
+			Response:         incident.Response,
+			Decision:         incident.Decision,
+			DecisionNotes:    incident.DecisionNotes,
+			EffectiveFrom:    incident.EffectiveFrom,
+			EffectiveTo:      incident.EffectiveTo,
+			CanRespond:       incident.CanRespond(today),
+		}
+		if !incident.RespondedAt.IsZero() {
+			item.RespondedAt = utils.ConvertToPH(incident.RespondedAt.UTC().Format(constants.DateTimeLayoutISO))
+		}
+		if !incident.DecidedAt.IsZero() {
+			item.DecidedAt = utils.ConvertToPH(incident.DecidedAt.UTC().Format(constants.DateTimeLayoutISO))
+		}
+		items = append(items, item)
+	}
+	return items
+}
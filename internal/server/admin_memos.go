@@ -62,6 +62,9 @@ func (s *Server) adminMemosListTableHandler(w http.ResponseWriter, r *http.Reque
 			CreatedByName: m.CreatedByName,
 			CreatedAt:     m.CreatedAt.Format(constants.DateTimeLayoutISO),
 			EmailsSentAt:  m.EmailsSentAt,
+
+			RequiresAcknowledgement: m.RequiresAcknowledgement,
+			AcknowledgeBy:           m.AcknowledgeBy,
 		})
 	}
 
@@ -184,6 +187,9 @@ func (s *Server) adminMemosEditPageHandler(w http.ResponseWriter, r *http.Reques
 		StartDate:    memo.StartDate,
 		EndDate:      memo.EndDate,
 		RecipientIDs: recipientIDs,
+
+		RequiresAcknowledgement: memo.RequiresAcknowledgement,
+		AcknowledgeBy:           memo.AcknowledgeBy,
 	}
 
 	currentStaffID := s.sessionManager.GetString(ctx, SessionStaffID)
@@ -241,6 +247,12 @@ func (s *Server) adminMemosCreateHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	ack, err := memoAcknowledgementFromForm(f)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
 	if _, err := s.services.memo.CreateMemo(
 		ctx,
 		s.sessionManager.GetString(ctx, SessionStaffID),
@@ -250,6 +262,7 @@ func (s *Server) adminMemosCreateHandler(w http.ResponseWriter, r *http.Request)
 		status,
 		startDate,
 		endDate,
+		ack,
 		staffIDs,
 	); err != nil {
 		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
@@ -317,6 +330,12 @@ func (s *Server) adminMemosUpdateHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	ack, err := memoAcknowledgementFromForm(f)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
 	if err := s.services.memo.UpdateMemo(
 		ctx,
 		s.sessionManager.GetString(ctx, SessionStaffID),
@@ -327,6 +346,7 @@ func (s *Server) adminMemosUpdateHandler(w http.ResponseWriter, r *http.Request)
 		status,
 		startDate,
 		endDate,
+		ack,
 		staffIDs,
 	); err != nil {
 		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
@@ -391,3 +411,74 @@ func (s *Server) adminMemosSendEmailsHandler(w http.ResponseWriter, r *http.Requ
 
 	redirectHX(w, r, utils.URLWithSuccess(page, "Notification emails sent successfully"))
 }
+
+func (s *Server) adminMemosAcknowledgementsPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Memos Acknowledgements Page Handler]"
+	const page = "/admin/memos"
+	ctx := r.Context()
+
+	var p forms.AdminMemoPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	idStr, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	summary, err := s.services.memo.GetAcknowledgementSummary(ctx, idStr, utils.NowPH().Format(constants.DateLayoutISO))
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	pending := make([]models.AdminMemoRecipientRow, 0, len(summary.Pending))
+	for _, row := range summary.Pending {
+		pending = append(pending, models.AdminMemoRecipientRow{
+			StaffID:        row.StaffID,
+			StaffName:      row.StaffName,
+			Email:          row.Email,
+			Position:       row.Position,
+			UserType:       row.UserType,
+			ActionStatus:   row.ActionStatus,
+			ReminderCount:  row.ReminderCount,
+			LastRemindedAt: utils.ConvertToPH(row.LastRemindedAt),
+		})
+	}
+
+	data := models.AdminMemoAcknowledgementSummary{
+		MemoID:        idStr,
+		Title:         summary.Memo.Title,
+		StartDate:     summary.Memo.StartDate,
+		EndDate:       summary.Memo.EndDate,
+		AcknowledgeBy: summary.Memo.AcknowledgeBy,
+		EscalatedAt:   utils.ConvertToPH(summary.Memo.EscalatedAt),
+		Total:         summary.Total,
+		Accepted:      summary.Accepted,
+		Rejected:      summary.Rejected,
+		Percent:       summary.Percent(),
+		Overdue:       summary.Overdue,
+		Pending:       pending,
+	}
+
+	if err := compadmin.AdminMemoAcknowledgementsPage(data).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+	}
+}
+
+func memoAcknowledgementFromForm(f forms.AdminMemoForm) (services.MemoAcknowledgement, error) {
+	ack := services.MemoAcknowledgement{Required: f.RequiresAcknowledgement == "true"}
+	if !ack.Required || f.AcknowledgeBy == "" {
+		return ack, nil
+	}
+	deadline, err := time.Parse(constants.DateLayoutISO, f.AcknowledgeBy)
+	if err != nil {
+		return ack, errs.ErrTimeParse
+	}
+	ack.Deadline = deadline
+	return ack, nil
+}
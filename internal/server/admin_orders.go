@@ -9,6 +9,7 @@ import (
 	"cchoice/internal/enums"
 	"cchoice/internal/httputil"
 	"cchoice/internal/logs"
+	"cchoice/internal/rbac"
 	"cchoice/internal/server/forms"
 	"cchoice/internal/services"
 	"cchoice/internal/utils"
@@ -142,7 +143,7 @@ func (s *Server) adminOrdersManageModalHandler(w http.ResponseWriter, r *http.Re
 		ID:              idStr,
 		OrderReference:  data.OrderReference,
 		CurrentStatus:   data.Status,
-		CanUpdateStatus: s.HasRole(ctx, enums.STAFF_ROLE_MANAGE_ORDER_STATUS),
+		CanUpdateStatus: s.HasPermission(ctx, rbac.Write(enums.STAFF_ROLE_MANAGE_ORDER_STATUS)),
 	}
 
 	if err := compadmin.OrderManageModal(modalData).Render(ctx, w); err != nil {
@@ -220,7 +221,7 @@ func (s *Server) adminOrdersUpdateStatusHandler(w http.ResponseWriter, r *http.R
 	}
 	status := f.Status
 	notes := f.Notes
-	canUpdateStatus := s.HasRole(ctx, enums.STAFF_ROLE_MANAGE_ORDER_STATUS)
+	canUpdateStatus := s.HasPermission(ctx, rbac.Write(enums.STAFF_ROLE_MANAGE_ORDER_STATUS))
 
 	if err := s.services.order.UpdateOrderForAdmin(
 		ctx,
@@ -0,0 +1,342 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/payroll"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/services"
+	"cchoice/internal/utils"
+
+	"github.com/xuri/excelize/v2"
+	"go.uber.org/zap"
+)
+
+func (s *Server) adminPayrollPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Payroll Page Handler]"
+	const page = "/admin/payroll"
+	ctx := r.Context()
+
+	serviceRates, err := s.services.payroll.GetStaffPayRates(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	rates := make([]models.AdminPayRateItem, 0, len(serviceRates))
+	for _, rate := range serviceRates {
+		item := models.AdminPayRateItem{
+			StaffID:  rate.StaffID,
+			FullName: rate.FullName,
+			Position: rate.Position,
+			RateType: rate.RateType,
+		}
+		if rate.Rate > 0 {
+			item.Rate = strconv.FormatFloat(float64(rate.Rate)/100, 'f', 2, 64)
+		}
+		rates = append(rates, item)
+	}
+
+	current := payroll.PeriodContaining(utils.NowPH())
+	if err := compadmin.AdminPayrollPage(rates, current.Start.Format("2006-01"), current.IsFirstHalf()).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+	}
+}
+
+func (s *Server) adminPayrollRunsTableHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Payroll Runs Table Handler]"
+	const page = "/admin/payroll"
+	ctx := r.Context()
+
+	serviceRuns, err := s.services.payroll.GetRuns(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	runs := make([]models.AdminPayrollRunListItem, 0, len(serviceRuns))
+	for _, run := range serviceRuns {
+		runs = append(runs, toAdminPayrollRunListItem(run))
+	}
+
+	if err := compadmin.AdminPayrollRunsTable(runs).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+	}
+}
+
+func (s *Server) adminPayRateUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Pay Rate Update Handler]"
+	const page = "/admin/payroll"
+	ctx := r.Context()
+
+	var f forms.AdminPayRateForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	rate, err := strconv.ParseFloat(f.Rate, 64)
+	if err != nil || rate <= 0 {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrPayrollInvalidRate.Error()))
+		return
+	}
+
+	if err := s.services.payroll.SetPayRate(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		f.StaffID,
+		enums.ParsePayRateTypeToEnum(f.RateType),
+		int64(math.Round(rate*100)),
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Pay rate saved"))
+}
+
+func (s *Server) adminPayrollRunCreateHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Payroll Run Create Handler]"
+	const page = "/admin/payroll"
+	ctx := r.Context()
+
+	var f forms.AdminPayrollRunForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	period, err := payroll.ParsePeriod(f.Month, f.Half)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	runID, err := s.services.payroll.CreateRun(ctx, s.sessionManager.GetString(ctx, SessionStaffID), period)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page+"/runs/"+runID, "Payroll run computed"))
+}
+
+func (s *Server) adminPayrollRunDetailPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Payroll Run Detail Page Handler]"
+	const page = "/admin/payroll"
+	ctx := r.Context()
+
+	var p forms.AdminPayrollRunPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	runID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	run, serviceItems, err := s.services.payroll.GetRun(ctx, runID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	data := models.AdminPayrollRunDetailPageData{
+		Run:   toAdminPayrollRunListItem(*run),
+		Items: make([]models.AdminPayrollRunItem, 0, len(serviceItems)),
+	}
+	for _, item := range serviceItems {
+		data.Items = append(data.Items, models.AdminPayrollRunItem{
+			StaffID:          item.StaffID,
+			FullName:         item.FullName,
+			Position:         item.Position,
+			RateType:         item.RateType,
+			DailyRate:        utils.NewMoney(item.DailyRate, constants.PHP).Display(),
+			DaysWorked:       item.DaysWorked,
+			AbsentDays:       item.AbsentDays,
+			LateMinutes:      item.LateMinutes,
+			UndertimeMinutes: item.UndertimeMinutes,
+			OvertimeMinutes:  item.OvertimeMinutes,
+			GrossPay:         utils.NewMoney(item.GrossPay, constants.PHP).Display(),
+			Contributions:    utils.NewMoney(item.SSS+item.PhilHealth+item.PagIBIG, constants.PHP).Display(),
+			WithholdingTax:   utils.NewMoney(item.WithholdingTax, constants.PHP).Display(),
+			NetPay:           utils.NewMoney(item.NetPay, constants.PHP).Display(),
+		})
+	}
+
+	if err := compadmin.AdminPayrollRunDetailPage(data).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+	}
+}
+
+func (s *Server) adminPayrollRunRecomputeHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Payroll Run Recompute Handler]"
+	const page = "/admin/payroll"
+	ctx := r.Context()
+
+	var p forms.AdminPayrollRunPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	runID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	if err := s.services.payroll.RecomputeRun(ctx, s.sessionManager.GetString(ctx, SessionStaffID), runID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page+"/runs/"+runID, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page+"/runs/"+runID, "Payroll run recomputed"))
+}
+
+func (s *Server) adminPayrollRunLockHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Payroll Run Lock Handler]"
+	const page = "/admin/payroll"
+	ctx := r.Context()
+
+	var p forms.AdminPayrollRunPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	runID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	if err := s.services.payroll.LockRun(ctx, s.sessionManager.GetString(ctx, SessionStaffID), runID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page+"/runs/"+runID, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page+"/runs/"+runID, "Payroll run locked"))
+}
+
+func (s *Server) adminPayslipDownloadHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Payslip Download Handler]"
+	const page = "/admin/payroll"
+	ctx := r.Context()
+
+	var p forms.AdminPayslipPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	runID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	staffID, err := httputil.RequireEncodedID(s.encoder, p.StaffID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	var q forms.AdminPayslipQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		logs.LogCtx(ctx).Warn(logtag, zap.Error(err))
+	}
+	formatEnum := enums.ParseOutputFormatToEnum(q.Format)
+	if formatEnum == enums.OUTPUT_FORMAT_UNDEFINED {
+		formatEnum = enums.OUTPUT_FORMAT_PDF
+	}
+	if formatEnum != enums.OUTPUT_FORMAT_PDF && formatEnum != enums.OUTPUT_FORMAT_XLSX {
+		redirectHX(w, r, utils.URLWithError(page+"/runs/"+runID, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	payslip, err := s.services.payroll.GetPayslip(ctx, runID, staffID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page+"/runs/"+runID, err.Error()))
+		return
+	}
+
+	adminStaffID := s.sessionManager.GetString(ctx, SessionStaffID)
+	filename := fmt.Sprintf(
+		"payslip_%s_%s_%s.%s",
+		payslip.Run.Period.StartDate(),
+		payslip.Run.Period.EndDate(),
+		staffID,
+		strings.ToLower(formatEnum.String()),
+	)
+	logs.Log().Info(
+		logtag,
+		zap.String("file", filename),
+		zap.String("run id", runID),
+		zap.String("staff id", adminStaffID),
+		zap.String("param staff id", staffID),
+	)
+
+	switch formatEnum {
+	case enums.OUTPUT_FORMAT_PDF:
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+		if err := s.services.payroll.WritePayslipPDF(ctx, w, adminStaffID, payslip); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+			redirectHX(w, r, utils.URLWithError(page+"/runs/"+runID, err.Error()))
+			return
+		}
+	case enums.OUTPUT_FORMAT_XLSX:
+		file := excelize.NewFile()
+		defer file.Close()
+
+		if err := s.services.payroll.WritePayslipXLSX(ctx, file, adminStaffID, payslip); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+			redirectHX(w, r, utils.URLWithError(page+"/runs/"+runID, err.Error()))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+		if err := file.Write(w); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+			redirectHX(w, r, utils.URLWithError(page+"/runs/"+runID, err.Error()))
+			return
+		}
+	}
+}
+
+func toAdminPayrollRunListItem(run services.PayrollRun) models.AdminPayrollRunListItem {
+	item := models.AdminPayrollRunListItem{
+		ID:          run.ID,
+		Period:      run.Period.Label(),
+		Status:      run.Status,
+		StaffCount:  run.StaffCount,
+		TotalNetPay: utils.NewMoney(run.TotalNetPay, constants.PHP).Display(),
+		UpdatedAt:   utils.ConvertToPH(run.UpdatedAt.UTC().Format(constants.DateTimeLayoutISO)),
+	}
+	if run.LockedAt != nil {
+		item.LockedAt = utils.ConvertToPH(run.LockedAt.UTC().Format(constants.DateTimeLayoutISO))
+	}
+	return item
+}
@@ -0,0 +1,285 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/rbac"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/services"
+	"cchoice/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type routePermission struct {
+	Method string
+	Route  string
+	Req    rbac.Requirement
+}
+
+// adminRoutePermissions lists every /admin route with the requirement it
+// declared through Permit. A route without one gets the zero Requirement.
+func adminRoutePermissions(routes chi.Routes) ([]routePermission, error) {
+	var res []routePermission
+	err := chi.Walk(routes, func(method string, route string, _ http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		if route != "/admin" && !strings.HasPrefix(route, "/admin/") {
+			return nil
+		}
+
+		rp := routePermission{Method: method, Route: route}
+		for _, mw := range middlewares {
+			if h, ok := mw(http.NotFoundHandler()).(*permitHandler); ok {
+				rp.Req = h.req
+			}
+		}
+		res = append(res, rp)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(res, func(a, b routePermission) int {
+		if c := strings.Compare(a.Route, b.Route); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Method, b.Method)
+	})
+	return res, nil
+}
+
+func (s *Server) adminSuperuserPermissionsPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Permissions Page Handler]"
+	const page = "/admin/superuser"
+	ctx := r.Context()
+
+	matrix, err := s.services.role.GetMatrix(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	staffs, err := s.services.staff.GetAll(ctx, maxStaffListSize)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	var routes []routePermission
+	if rctx := chi.RouteContext(ctx); rctx != nil && rctx.Routes != nil {
+		routes, err = adminRoutePermissions(rctx.Routes)
+		if err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		}
+	}
+
+	if err := compadmin.AdminSuperuserPermissionsPage(permissionsPage(matrix, staffs, routes)).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserPermissionGroupCreateHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Permission Group Create Handler]"
+	const page = "/admin/superuser/permissions"
+	ctx := r.Context()
+
+	var f forms.AdminPermissionGroupForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if _, err := s.services.role.CreateGroup(ctx, f.Name, f.Description); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("name", f.Name), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Role group created"))
+}
+
+func (s *Server) adminSuperuserPermissionGroupDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Permission Group Delete Handler]"
+	const page = "/admin/superuser/permissions"
+	ctx := r.Context()
+
+	var p forms.AdminPermissionGroupPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	groupID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrRoleGroupNotFound.Error()))
+		return
+	}
+
+	if err := s.services.role.DeleteGroup(ctx, groupID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("group id", groupID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Role group deleted"))
+}
+
+func (s *Server) adminSuperuserPermissionGroupRoleHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Permission Group Role Handler]"
+	const page = "/admin/superuser/permissions"
+	ctx := r.Context()
+
+	var p forms.AdminPermissionGroupPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	groupID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrRoleGroupNotFound.Error()))
+		return
+	}
+
+	var f forms.AdminPermissionGroupRoleForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	role := enums.ParseStaffRoleToEnum(f.Role)
+	access := enums.ParsePermissionAccessToEnum(f.Access)
+	if !role.IsValid() || (f.Access != "" && access == enums.PERMISSION_ACCESS_UNDEFINED) {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrEnumInvalid.Error()))
+		return
+	}
+
+	if err := s.services.role.SetGroupPermission(ctx, groupID, role, access); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("group id", groupID), zap.Stringer("role", role), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Role group updated"))
+}
+
+func (s *Server) adminSuperuserPermissionStaffGroupAssignHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Permission Staff Group Assign Handler]"
+	const page = "/admin/superuser/permissions"
+	ctx := r.Context()
+
+	var p forms.AdminPermissionGroupPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrStaffIDRequired.Error()))
+		return
+	}
+	staffID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrStaffIDRequired.Error()))
+		return
+	}
+
+	var f forms.AdminPermissionStaffGroupForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	groupID, err := httputil.RequireEncodedID(s.encoder, f.GroupID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrRoleGroupNotFound.Error()))
+		return
+	}
+
+	if err := s.services.role.AssignGroup(ctx, staffID, groupID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.String("group id", groupID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Role group assigned"))
+}
+
+func (s *Server) adminSuperuserPermissionStaffGroupUnassignHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Permission Staff Group Unassign Handler]"
+	const page = "/admin/superuser/permissions"
+	ctx := r.Context()
+
+	var p forms.AdminPermissionStaffGroupPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	staffID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrStaffIDRequired.Error()))
+		return
+	}
+	groupID, err := httputil.RequireEncodedID(s.encoder, p.GroupID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrRoleGroupNotFound.Error()))
+		return
+	}
+
+	if err := s.services.role.UnassignGroup(ctx, staffID, groupID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.String("group id", groupID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Role group removed"))
+}
+
+func permissionsPage(matrix services.PermissionMatrix, staffs []models.Staff, routes []routePermission) models.PermissionsPage {
+	res := models.PermissionsPage{
+		Roles:  enums.GetAllStaffRoles(),
+		Groups: make([]models.PermissionGroup, 0, len(matrix.Groups)),
+		Staffs: make([]models.PermissionStaffRow, 0, len(staffs)),
+		Routes: make([]models.RoutePermission, 0, len(routes)),
+	}
+
+	groupsByID := make(map[string]models.PermissionGroup, len(matrix.Groups))
+	for _, group := range matrix.Groups {
+		item := models.PermissionGroup{
+			ID:          group.ID,
+			Name:        group.Name,
+			Description: group.Description,
+			Access:      group.Permissions,
+		}
+		groupsByID[group.ID] = item
+		res.Groups = append(res.Groups, item)
+	}
+
+	for _, staff := range staffs {
+		row := models.PermissionStaffRow{
+			ID:          staff.ID,
+			FullName:    staff.FullName,
+			IsSuperuser: staff.UserType == enums.STAFF_USER_TYPE_SUPERUSER,
+			Access:      matrix.StaffPerms[staff.ID],
+		}
+		for _, groupID := range matrix.StaffGroups[staff.ID] {
+			if group, ok := groupsByID[groupID]; ok {
+				row.Groups = append(row.Groups, group)
+			}
+		}
+		res.Staffs = append(res.Staffs, row)
+	}
+
+	for _, route := range routes {
+		res.Routes = append(res.Routes, models.RoutePermission{
+			Method:      route.Method,
+			Route:       route.Route,
+			Requirement: route.Req.String(),
+		})
+	}
+	return res
+}
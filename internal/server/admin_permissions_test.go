@@ -0,0 +1,31 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Every /admin route must state what it needs through Permit, and anything
+// other than a GET must ask for write access.
+func TestAdminRoutesDeclarePermissions(t *testing.T) {
+	r := chi.NewRouter()
+	AddAdminHandlers(&Server{}, r)
+
+	routes, err := adminRoutePermissions(r)
+	require.NoError(t, err)
+	require.NotEmpty(t, routes)
+
+	for _, route := range routes {
+		if !route.Req.IsDeclared() {
+			t.Errorf("%s %s does not declare a permission", route.Method, route.Route)
+			continue
+		}
+		if route.Method != http.MethodGet {
+			assert.Truef(t, route.Req.Writes(), "%s %s is mutating but only requires %s", route.Method, route.Route, route.Req)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package server
+
+import (
+	"net/http"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+func (s *Server) adminProductReviewsListPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Product Reviews List Page Handler]"
+	const page = "/admin/reviews"
+	ctx := r.Context()
+
+	if err := compadmin.AdminProductReviewsListPage().Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+	}
+}
+
+func (s *Server) adminProductReviewsListTableHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Product Reviews List Table Handler]"
+	const page = "/admin/reviews"
+	ctx := r.Context()
+
+	var q forms.AdminProductReviewsListQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	status := enums.ParseProductReviewStatusToEnum(q.Status)
+	if q.Status != "" && status == enums.PRODUCT_REVIEW_STATUS_UNDEFINED {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrEnumInvalid.Error()))
+		return
+	}
+
+	listPage := httputil.PageOrDefault(q.Page, 1)
+
+	serviceReviews, totalCount, listPage, err := s.services.productReview.GetForModerationPaginated(
+		ctx,
+		status,
+		listPage,
+		constants.DefaultAdminTablePageSize,
+	)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	reviews := make([]models.AdminProductReviewListItem, 0, len(serviceReviews))
+	for _, review := range serviceReviews {
+		reviews = append(reviews, models.AdminProductReviewListItem{
+			ID:              review.ID,
+			ProductName:     review.ProductName,
+			ProductSerial:   review.ProductSerial,
+			CustomerName:    review.CustomerName,
+			CustomerEmail:   review.CustomerEmail,
+			Rating:          review.Rating,
+			Title:           review.Title,
+			Body:            review.Body,
+			Status:          review.Status,
+			RejectionReason: review.RejectionReason,
+			PhotoURLs:       review.PhotoURLs,
+			CreatedAt:       review.CreatedAt,
+		})
+	}
+
+	pagination := models.TablePagination{
+		Page:          listPage,
+		PerPage:       constants.DefaultAdminTablePageSize,
+		TotalCount:    totalCount,
+		TableURL:      utils.URL("/admin/reviews/table"),
+		Include:       "[name='status']",
+		ContentTarget: "#product-reviews-table",
+	}
+
+	if err := compadmin.AdminProductReviewsTableContent(reviews, pagination).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+	}
+}
+
+func (s *Server) adminProductReviewRejectModalHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Product Review Reject Modal Handler]"
+	const page = "/admin/reviews"
+	ctx := r.Context()
+
+	var p forms.AdminProductReviewPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	idStr, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	if err := compadmin.ProductReviewRejectModal(idStr).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+	}
+}
+
+func (s *Server) adminProductReviewApproveHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Product Review Approve Handler]"
+	const page = "/admin/reviews"
+	ctx := r.Context()
+
+	var p forms.AdminProductReviewPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	idStr, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	if err := s.services.productReview.Approve(ctx, s.sessionManager.GetString(ctx, SessionStaffID), idStr); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Review approved and published"))
+}
+
+func (s *Server) adminProductReviewRejectHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Product Review Reject Handler]"
+	const page = "/admin/reviews"
+	ctx := r.Context()
+
+	var p forms.AdminProductReviewPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	idStr, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	var f forms.AdminProductReviewRejectForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if err := s.services.productReview.Reject(ctx, s.sessionManager.GetString(ctx, SessionStaffID), idStr, f.Reason); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Review rejected"))
+}
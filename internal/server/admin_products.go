@@ -22,6 +22,7 @@ import (
 	"cchoice/internal/httputil"
 	"cchoice/internal/jobs"
 	"cchoice/internal/logs"
+	"cchoice/internal/rbac"
 	"cchoice/internal/requests"
 	"cchoice/internal/server/forms"
 	"cchoice/internal/services"
@@ -461,7 +462,7 @@ func (s *Server) adminSuperuserProductsListTableHandler(w http.ResponseWriter, r
 	staff, err := s.services.staff.GetCurrentStaff(ctx, s.sessionManager.GetString(ctx, SessionStaffID))
 	isSuperuser := err == nil && staff.UserType == enums.STAFF_USER_TYPE_SUPERUSER.String()
 	actions := models.AdminProductListActions{
-		CanPublish: s.HasRole(ctx, enums.STAFF_ROLE_PUBLISH_PRODUCTS),
+		CanPublish: s.HasPermission(ctx, rbac.Write(enums.STAFF_ROLE_PUBLISH_PRODUCTS)),
 		CanEdit:    isSuperuser,
 		CanDelete:  isSuperuser,
 	}
@@ -0,0 +1,290 @@
+package server
+
+import (
+	"net/http"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/services"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+func (s *Server) adminSaleCampaignsListPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Sale Campaigns List Page Handler]"
+	const page = "/admin/sale-campaigns"
+	ctx := r.Context()
+
+	if err := compadmin.AdminSaleCampaignsListPage().Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+	}
+}
+
+func (s *Server) adminSaleCampaignsListTableHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Sale Campaigns List Table Handler]"
+	const page = "/admin/sale-campaigns"
+	ctx := r.Context()
+
+	serviceCampaigns, err := s.services.saleCampaign.GetAll(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	campaigns := make([]models.AdminSaleCampaignListItem, 0, len(serviceCampaigns))
+	for _, campaign := range serviceCampaigns {
+		campaigns = append(campaigns, toAdminSaleCampaignListItem(campaign))
+	}
+
+	if err := compadmin.AdminSaleCampaignsTable(campaigns).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+	}
+}
+
+func (s *Server) adminSaleCampaignsCreatePageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Sale Campaigns Create Page Handler]"
+	const page = "/admin/sale-campaigns"
+	ctx := r.Context()
+
+	brands, err := s.services.brand.GetAllActive(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	categories, err := s.services.productCategory.GetAllCategoryNames(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	opts := models.AdminSaleCampaignFormOptions{
+		Brands:     make([]models.AdminSaleCampaignOption, 0, len(brands)),
+		Categories: categories,
+	}
+	for _, brand := range brands {
+		opts.Brands = append(opts.Brands, models.AdminSaleCampaignOption{
+			Value: s.encoder.Encode(brand.ID),
+			Label: brand.Name,
+		})
+	}
+
+	if err := compadmin.SaleCampaignCreateModal(opts).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+	}
+}
+
+func parseSaleCampaignForm(f forms.AdminSaleCampaignForm) (services.SaleCampaignInput, error) {
+	selector := enums.ParseSaleCampaignSelectorToEnum(f.Selector)
+	var selectorValue string
+	switch selector {
+	case enums.SALE_CAMPAIGN_SELECTOR_BRAND:
+		selectorValue = f.BrandID
+	case enums.SALE_CAMPAIGN_SELECTOR_CATEGORY:
+		selectorValue = f.Category
+	case enums.SALE_CAMPAIGN_SELECTOR_PRODUCTS:
+		selectorValue = f.Serials
+	default:
+		return services.SaleCampaignInput{}, errs.ErrSaleCampaignInvalidSelector
+	}
+
+	startsAt, err := utils.ParseInPH(constants.DateTimeLayoutInput, f.StartsAt)
+	if err != nil {
+		return services.SaleCampaignInput{}, errs.ErrTimeParse
+	}
+	endsAt, err := utils.ParseInPH(constants.DateTimeLayoutInput, f.EndsAt)
+	if err != nil {
+		return services.SaleCampaignInput{}, errs.ErrTimeParse
+	}
+
+	discountType := enums.ParseDiscountTypeToEnum(f.DiscountType)
+	discountValue := f.DiscountValue
+	if discountType == enums.DISCOUNT_TYPE_FIXED {
+		discountValue *= 100
+	}
+
+	return services.SaleCampaignInput{
+		Name:          f.Name,
+		Selector:      selector,
+		SelectorValue: selectorValue,
+		DiscountType:  discountType,
+		DiscountValue: discountValue,
+		StartsAt:      startsAt,
+		EndsAt:        endsAt,
+	}, nil
+}
+
+func (s *Server) adminSaleCampaignsPreviewHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Sale Campaigns Preview Handler]"
+	ctx := r.Context()
+
+	var f forms.AdminSaleCampaignForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		http.Error(w, httputil.ErrorMessage(err), http.StatusBadRequest)
+		return
+	}
+
+	input, err := parseSaleCampaignForm(f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	serviceItems, err := s.services.saleCampaign.Preview(ctx, input)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := compadmin.SaleCampaignPreviewTable(toAdminSaleCampaignPreviewItems(serviceItems)).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSaleCampaignsCreateHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Sale Campaigns Create Handler]"
+	const page = "/admin/sale-campaigns"
+	ctx := r.Context()
+
+	var f forms.AdminSaleCampaignForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	input, err := parseSaleCampaignForm(f)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	staffID := s.sessionManager.GetString(ctx, SessionStaffID)
+	campaignID, err := s.services.saleCampaign.Create(ctx, staffID, input)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page+"/"+campaignID, "Sale campaign created"))
+}
+
+func (s *Server) adminSaleCampaignDetailPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Sale Campaign Detail Page Handler]"
+	const page = "/admin/sale-campaigns"
+	ctx := r.Context()
+
+	var p forms.AdminSaleCampaignPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	campaignID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	campaign, products, err := s.services.saleCampaign.GetByID(ctx, campaignID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	report, err := s.services.saleCampaign.GetReport(ctx, campaignID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	data := models.AdminSaleCampaignDetailPageData{
+		Campaign:     toAdminSaleCampaignListItem(*campaign),
+		Products:     toAdminSaleCampaignPreviewItems(products),
+		Report:       make([]models.AdminSaleCampaignReportItem, 0, len(report.Items)),
+		TotalUnits:   report.TotalUnits,
+		TotalRevenue: report.TotalRevenue,
+	}
+	for _, item := range report.Items {
+		data.Report = append(data.Report, models.AdminSaleCampaignReportItem{
+			Serial:    item.Serial,
+			Name:      item.Name,
+			UnitsSold: item.UnitsSold,
+			Revenue:   item.Revenue,
+		})
+	}
+
+	if err := compadmin.AdminSaleCampaignDetailPage(data).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+	}
+}
+
+func (s *Server) adminSaleCampaignCancelHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Sale Campaign Cancel Handler]"
+	const page = "/admin/sale-campaigns"
+	ctx := r.Context()
+
+	var p forms.AdminSaleCampaignPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	campaignID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	if err := s.services.saleCampaign.Cancel(ctx, s.sessionManager.GetString(ctx, SessionStaffID), campaignID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page+"/"+campaignID, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page+"/"+campaignID, "Sale campaign cancelled"))
+}
+
+func toAdminSaleCampaignListItem(campaign services.SaleCampaign) models.AdminSaleCampaignListItem {
+	return models.AdminSaleCampaignListItem{
+		ID:            campaign.ID,
+		Name:          campaign.Name,
+		Selector:      campaign.Selector,
+		SelectorValue: campaign.SelectorValue,
+		DiscountLabel: campaign.DiscountLabel,
+		StartsAt:      campaign.StartsAt,
+		EndsAt:        campaign.EndsAt,
+		Status:        campaign.Status,
+		ProductCount:  campaign.ProductCount,
+	}
+}
+
+func toAdminSaleCampaignPreviewItems(serviceItems []services.SaleCampaignPreviewItem) []models.AdminSaleCampaignPreviewItem {
+	items := make([]models.AdminSaleCampaignPreviewItem, 0, len(serviceItems))
+	for _, item := range serviceItems {
+		items = append(items, models.AdminSaleCampaignPreviewItem{
+			Serial:       item.Serial,
+			Name:         item.Name,
+			UnitPrice:    item.UnitPrice,
+			SalePrice:    item.SalePrice,
+			HasOtherSale: item.HasOtherSale,
+		})
+	}
+	return items
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/services"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+const adminSearchPage = "/admin/search"
+
+func (s *Server) adminSearchPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Search Page Handler]"
+	ctx := r.Context()
+
+	var q forms.AdminSearchQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		redirectHX(w, r, utils.URLWithError("/admin", httputil.ErrorMessage(err)))
+		return
+	}
+
+	start, end, err := services.ResolveAnalyticsRange(q.StartDate, q.EndDate, time.Now().UTC())
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(adminSearchPage, fmt.Sprintf("%s. Ranges are limited to %d days.", err.Error(), constants.AnalyticsMaxDays)))
+		return
+	}
+
+	insights, err := s.services.searchMerchandising.GetInsights(ctx, start, end)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError("/admin", err.Error()))
+		return
+	}
+	rules, err := s.services.searchMerchandising.GetRules(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError("/admin", err.Error()))
+		return
+	}
+	categories, err := s.services.searchMerchandising.GetCategoryOptions(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError("/admin", err.Error()))
+		return
+	}
+	activeBrands, err := s.services.brand.GetAllActive(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError("/admin", err.Error()))
+		return
+	}
+	brands := make([]models.AdminSearchRuleOption, 0, len(activeBrands))
+	for _, brand := range activeBrands {
+		brands = append(brands, models.AdminSearchRuleOption{Value: s.encoder.Encode(brand.ID), Label: brand.Name})
+	}
+
+	if err := compadmin.AdminSearchPage(*insights, rules, categories, brands, services.NormalizeSearchTerm(q.Term)).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError("/admin", err.Error()))
+	}
+}
+
+func (s *Server) adminSearchRuleCreateHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Search Rule Create Handler]"
+	ctx := r.Context()
+
+	var f forms.AdminSearchRuleForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(adminSearchPage, httputil.ErrorMessage(err)))
+		return
+	}
+
+	kind := enums.ParseSearchRuleKindToEnum(f.Kind)
+	if kind == enums.SEARCH_RULE_KIND_UNDEFINED {
+		redirectHX(w, r, utils.URLWithError(adminSearchPage, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	if _, err := s.services.searchMerchandising.CreateRule(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		services.CreateSearchRuleParams{
+			SearchTerm:    f.SearchTerm,
+			Kind:          kind,
+			ProductSerial: f.ProductSerial,
+			CategoryID:    f.CategoryID,
+			BrandID:       f.BrandID,
+			Position:      f.Position,
+		},
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(adminSearchPage, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(adminSearchPage, "Search rule created"))
+}
+
+func (s *Server) adminSearchRuleDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Search Rule Delete Handler]"
+	ctx := r.Context()
+
+	var p forms.AdminSearchRulePath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(adminSearchPage, errs.ErrInvalidParams.Error()))
+		return
+	}
+	ruleID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(adminSearchPage, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	if err := s.services.searchMerchandising.DeleteRule(ctx, s.sessionManager.GetString(ctx, SessionStaffID), ruleID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(adminSearchPage, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(adminSearchPage, "Search rule deleted"))
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"time"
 
@@ -33,7 +34,7 @@ func (s *Server) adminStaffHomeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	roles, err := s.services.role.GetByStaffID(ctx, staffIDStr)
+	roles, err := s.services.role.GetEffectiveRolesByStaffID(ctx, staffIDStr)
 	if err != nil {
 		logs.LogCtx(ctx).Error(logtag, zap.Int64("staff_id", staff.ID), zap.Error(err))
 	}
@@ -48,10 +49,11 @@ func (s *Server) adminStaffHomeHandler(w http.ResponseWriter, r *http.Request) {
 	memoCards := make([]models.StaffMemoCard, 0, len(pendingMemos))
 	for _, m := range pendingMemos {
 		memoCards = append(memoCards, models.StaffMemoCard{
-			ID:      m.ID,
-			Title:   m.Title,
-			Message: m.Message,
-			FileURL: m.FileURL,
+			ID:                      m.ID,
+			Title:                   m.Title,
+			Message:                 m.Message,
+			FileURL:                 m.FileURL,
+			RequiresAcknowledgement: m.RequiresAcknowledgement,
 		})
 	}
 
@@ -134,6 +136,7 @@ func (s *Server) adminChangePasswordHandler(w http.ResponseWriter, r *http.Reque
 		redirectHX(w, r, utils.URLWithError(page, errs.ErrStaffPasswordUpdateFailed.Error()))
 		return
 	}
+	s.revokeOtherSessions(r, enums.USER_TYPE_STAFF, staffID, enums.SESSION_REVOKE_REASON_PASSWORD_CHANGE)
 
 	redirectHX(w, r, utils.URLWithSuccess(page, "Password updated successfully"))
 }
@@ -241,6 +244,12 @@ func (s *Server) adminStaffPageHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	today := time.Now().Format(constants.DateLayoutISO)
+	schedule, err := s.services.shift.GetSchedule(ctx, staff.ID, today, today)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInternalServer.Error()))
+		return
+	}
 	attendance, err := s.services.staff.GetAttendanceByDate(ctx, staff.ID, today)
 	var hasTimeIn, hasTimeOut bool
 	var hasLunchBreakIn, hasLunchBreakOut bool
@@ -281,6 +290,7 @@ func (s *Server) adminStaffPageHandler(w http.ResponseWriter, r *http.Request) {
 				CreatedAt:                attendance.CreatedAt,
 				UpdatedAt:                attendance.UpdatedAt,
 			},
+			schedule,
 		)
 		myAttendance = &rec
 	}
@@ -292,10 +302,13 @@ func (s *Server) adminStaffPageHandler(w http.ResponseWriter, r *http.Request) {
 		attendance.OutLocation,
 	)
 
-	scheduledTimeIn := staff.TimeInSchedule.String
-	scheduledTimeOut := staff.TimeOutSchedule.String
+	todayShift := schedule.For(today)
+	scheduledTimeIn, scheduledTimeOut := todayShift.String(), "-"
+	if !todayShift.RestDay {
+		scheduledTimeIn, scheduledTimeOut = todayShift.TimeIn, todayShift.TimeOut
+	}
 
-	canTimeIn := !hasTimeIn
+	canTimeIn := !hasTimeIn && !todayShift.Suspended
 	canTimeOut := hasTimeIn && !hasTimeOut
 	canLunchBreakIn := hasTimeIn && !hasLunchBreakIn
 	canLunchBreakOut := !hasTimeOut && hasLunchBreakIn && !hasLunchBreakOut
@@ -327,6 +340,12 @@ func (s *Server) adminStaffPageHandler(w http.ResponseWriter, r *http.Request) {
 	profile.LocationDisplay = locationDisplay
 	profile.DistanceMeters = distanceMeters
 
+	hasKioskPIN, err := s.services.kiosk.HasPIN(ctx, s.sessionManager.GetString(ctx, SessionStaffID))
+	if err != nil {
+		logs.LogCtx(ctx).Warn(logtag, zap.Error(err))
+	}
+	profile.HasKioskPIN = hasKioskPIN
+
 	if err := compadmin.AdminStaffPage(profile).Render(ctx, w); err != nil {
 		logs.LogCtx(ctx).Error(
 			logtag,
@@ -357,6 +376,12 @@ func (s *Server) adminStaffAttendanceTableHandler(w http.ResponseWriter, r *http
 	attendance, err := s.services.staff.GetAttendanceByDate(ctx, staff.ID, date)
 	var record *models.Attendance
 	if err == nil {
+		schedule, err := s.services.shift.GetSchedule(ctx, staff.ID, date, date)
+		if err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
 		rec := s.services.attendance.ComputeData(
 			services.StaffRowBase(staff),
 			services.StaffRow{
@@ -378,6 +403,7 @@ func (s *Server) adminStaffAttendanceTableHandler(w http.ResponseWriter, r *http
 				CreatedAt:                attendance.CreatedAt,
 				UpdatedAt:                attendance.UpdatedAt,
 			},
+			schedule,
 		)
 		record = &rec
 	}
@@ -408,6 +434,12 @@ func (s *Server) adminStaffAttendanceRowsHandler(w http.ResponseWriter, r *http.
 	attendance, err := s.services.staff.GetAttendanceByDate(ctx, staff.ID, date)
 	var record *models.Attendance
 	if err == nil {
+		schedule, err := s.services.shift.GetSchedule(ctx, staff.ID, date, date)
+		if err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
 		rec := s.services.attendance.ComputeData(
 			services.StaffRowBase(staff),
 			services.StaffRow{
@@ -429,6 +461,7 @@ func (s *Server) adminStaffAttendanceRowsHandler(w http.ResponseWriter, r *http.
 				CreatedAt:                attendance.CreatedAt,
 				UpdatedAt:                attendance.UpdatedAt,
 			},
+			schedule,
 		)
 		record = &rec
 	}
@@ -448,6 +481,10 @@ func (s *Server) adminStaffTimeInHandler(w http.ResponseWriter, r *http.Request)
 	location := GetLocation(ctx, s.sessionManager)
 	useragentID := getOrCreateUserAgentID(ctx, s.dbRW, r.UserAgent())
 	if err := s.services.attendance.TimeIn(ctx, s.sessionManager.GetString(ctx, SessionStaffID), date, now, location, useragentID); err != nil {
+		if errors.Is(err, errs.ErrIncidentSuspended) {
+			redirectHX(w, r, utils.URLWithError(page, err.Error()))
+			return
+		}
 		http.Error(w, "Unable to time in", http.StatusBadRequest)
 		return
 	}
@@ -601,6 +638,43 @@ func (s *Server) adminStaffTimeOffTableHandler(w http.ResponseWriter, r *http.Re
 	}
 }
 
+func (s *Server) adminStaffTimeOffBalanceHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Time Off Balance Handler]"
+	ctx := r.Context()
+
+	staffID := s.sessionManager.GetString(ctx, SessionStaffID)
+	if staffID == "" {
+		redirectHXLogin(w, r)
+		return
+	}
+
+	serviceBalances, err := s.services.leave.GetBalances(ctx, staffID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.Int64("staff_id", s.encoder.Decode(staffID)))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	balances := make([]models.LeaveBalance, 0, len(serviceBalances))
+	for _, balance := range serviceBalances {
+		balances = append(balances, models.LeaveBalance{
+			Type:        balance.Type,
+			Entitlement: balance.Entitlement.String(),
+			CarriedOver: balance.CarriedOver.String(),
+			Accrued:     balance.Accrued.String(),
+			Used:        balance.Used.String(),
+			Adjusted:    balance.Adjusted.String(),
+			Available:   balance.Available.String(),
+		})
+	}
+
+	if err := compadmin.StaffLeaveBalances(balances).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
 func (s *Server) adminStaffAttendanceLocationHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	staffIDStr := s.sessionManager.GetString(ctx, SessionStaffID)
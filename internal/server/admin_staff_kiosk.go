@@ -0,0 +1,325 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/encode/b64"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/services"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+func (s *Server) adminStaffKioskPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Kiosk Page Handler]"
+	ctx := r.Context()
+
+	staffs, err := s.services.staff.GetAll(ctx, maxStaffListSize)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(s.staffHomeRedirect(ctx), err.Error()))
+		return
+	}
+
+	data := models.StaffKioskPageData{
+		Staffs:         staffs,
+		RefreshSeconds: int(constants.KioskQRRefresh.Seconds()),
+	}
+	if err := compadmin.AdminStaffKioskPage(data).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminStaffKioskQRHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Kiosk QR Handler]"
+	ctx := r.Context()
+
+	token, err := s.services.kiosk.GenerateToken(s.sessionManager.GetString(ctx, SessionStaffID))
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "failed to generate token", http.StatusInternalServerError)
+		return
+	}
+
+	scanURL := utils.FullURL("/admin/staff/kiosk/scan?token=" + url.QueryEscape(token))
+	qrBytes, err := s.services.qr.GenerateQR(ctx, scanURL)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "failed to generate QR", http.StatusInternalServerError)
+		return
+	}
+
+	qrBase64 := enums.IMAGE_FORMAT_PNG.DataURIPrefix() + b64.ToBase64(qrBytes)
+	if err := compadmin.KioskQR(qrBase64).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+	}
+}
+
+func (s *Server) adminStaffKioskPinPunchHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Kiosk PIN Punch Handler]"
+	const page = "/admin/staff/kiosk"
+	ctx := r.Context()
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(errs.ErrInvalidParams)))
+		return
+	}
+
+	var f forms.AdminKioskPinPunchForm
+	if err := httputil.BindMultipartForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	photo, err := readKioskPhoto(r)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	if err := s.services.kiosk.PunchWithPIN(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		f.StaffID,
+		f.Pin,
+		enums.ParseKioskPunchTypeToEnum(f.PunchType),
+		photo,
+		getOrCreateUserAgentID(ctx, s.dbRW, r.UserAgent()),
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("staff_id", f.StaffID))
+		redirectHX(w, r, utils.URLWithError(page, kioskErrorMessage(err)))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Punch recorded successfully"))
+}
+
+func (s *Server) adminStaffKioskScanPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Kiosk Scan Page Handler]"
+	ctx := r.Context()
+
+	var q forms.AdminKioskScanQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		redirectHX(w, r, utils.URLWithError(s.staffHomeRedirect(ctx), errs.ErrKioskInvalidTokenFormat.Error()))
+		return
+	}
+
+	hasPIN, err := s.services.kiosk.HasPIN(ctx, s.sessionManager.GetString(ctx, SessionStaffID))
+	if err != nil {
+		logs.LogCtx(ctx).Warn(logtag, zap.Error(err))
+	}
+
+	data := models.StaffKioskScanPageData{
+		Token:  q.Token,
+		HasPIN: hasPIN,
+	}
+	if _, err := s.services.kioskToken.Verify(q.Token); err != nil {
+		if !errors.Is(err, errs.ErrKioskTokenExpired) {
+			redirectHX(w, r, utils.URLWithError(s.staffHomeRedirect(ctx), err.Error()))
+			return
+		}
+		data.Expired = true
+	}
+
+	if err := compadmin.AdminStaffKioskScanPage(data).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminStaffKioskScanPunchHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Kiosk Scan Punch Handler]"
+	const page = "/admin/staff/attendance"
+	ctx := r.Context()
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(errs.ErrInvalidParams)))
+		return
+	}
+
+	var f forms.AdminKioskScanPunchForm
+	if err := httputil.BindMultipartForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	photo, err := readKioskPhoto(r)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	staffID := s.sessionManager.GetString(ctx, SessionStaffID)
+	if err := s.services.kiosk.PunchWithToken(
+		ctx,
+		f.Token,
+		staffID,
+		enums.ParseKioskPunchTypeToEnum(f.PunchType),
+		photo,
+		getOrCreateUserAgentID(ctx, s.dbRW, r.UserAgent()),
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("staff_id", staffID))
+		redirectHX(w, r, utils.URLWithError(page, kioskErrorMessage(err)))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Punch recorded successfully"))
+}
+
+func (s *Server) adminStaffKioskSetPinHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Kiosk Set PIN Handler]"
+	const page = "/admin/staff/attendance"
+	ctx := r.Context()
+
+	var f forms.AdminKioskPinForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	if f.Pin != f.ConfirmPin {
+		redirectHX(w, r, utils.URLWithError(page, "PINs do not match"))
+		return
+	}
+
+	if err := s.services.kiosk.SetPIN(ctx, s.sessionManager.GetString(ctx, SessionStaffID), f.Pin); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, kioskErrorMessage(err)))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Kiosk PIN saved"))
+}
+
+func (s *Server) adminSuperuserKioskPunchesPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Kiosk Punches Page Handler]"
+	ctx := r.Context()
+
+	if err := compadmin.AdminSuperuserKioskPunchesPage(utils.NowPH().Format(constants.DateLayoutISO)).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserKioskPunchesTableHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Kiosk Punches Table Handler]"
+	ctx := r.Context()
+
+	var q forms.AdminKioskPunchesQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		http.Error(w, httputil.ErrorMessage(err), http.StatusBadRequest)
+		return
+	}
+	date := q.Date
+	if date == "" {
+		date = utils.NowPH().Format(constants.DateLayoutISO)
+	}
+
+	punches, err := s.services.kiosk.GetPunches(ctx, date)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	items := make([]models.KioskPunchItem, 0, len(punches))
+	for _, punch := range punches {
+		items = append(items, models.KioskPunchItem{
+			ID:        punch.ID,
+			StaffName: punch.StaffName,
+			KioskName: punch.KioskName,
+			Type:      punch.Type,
+			Method:    punch.Method,
+			HasPhoto:  punch.HasPhoto,
+			CreatedAt: utils.ConvertToPH(punch.CreatedAt.UTC().Format(constants.DateTimeLayoutISO)),
+		})
+	}
+
+	if err := compadmin.AdminSuperuserKioskPunchesTable(items).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserKioskPunchPhotoHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Kiosk Punch Photo Handler]"
+	ctx := r.Context()
+
+	var p forms.AdminKioskPunchPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		http.Error(w, httputil.ErrorMessage(err), http.StatusBadRequest)
+		return
+	}
+
+	photoURL, err := s.services.kiosk.GetPhotoURL(ctx, p.ID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	http.Redirect(w, r, photoURL, http.StatusFound)
+}
+
+// readKioskPhoto reads the optional "photo" field of a kiosk form. It returns
+// ErrKioskPhotoRequired when no file was attached so callers can decide
+// whether that is fine.
+func readKioskPhoto(r *http.Request) (*services.KioskPhoto, error) {
+	file, header, err := r.FormFile("photo")
+	if err != nil {
+		return nil, errs.ErrKioskPhotoRequired
+	}
+	defer file.Close()
+
+	if header.Size > constants.KioskPhotoMaxSize {
+		return nil, errs.ErrKioskPhotoTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, constants.KioskPhotoMaxSize+1))
+	if err != nil {
+		return nil, errors.Join(errs.ErrKiosk, err)
+	}
+	if len(data) > constants.KioskPhotoMaxSize {
+		return nil, errs.ErrKioskPhotoTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, errs.ErrKioskPhotoRequired
+	}
+
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	if ext == "" {
+		ext = ".jpg"
+	}
+
+	return &services.KioskPhoto{
+		Data:        data,
+		ContentType: contentType,
+		Ext:         ext,
+	}, nil
+}
+
+// kioskErrorMessage hides wrapped database errors from the kiosk screen while
+// keeping the kiosk errors readable.
+func kioskErrorMessage(err error) string {
+	if errors.Is(err, errs.ErrKiosk) || errors.Is(err, errs.ErrDecode) {
+		return errs.ErrInternalServer.Error()
+	}
+	return err.Error()
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+func (s *Server) adminStaffShiftsPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Shifts Page Handler]"
+	ctx := r.Context()
+	staffID := s.sessionManager.GetString(ctx, SessionStaffID)
+
+	days, err := s.services.shift.GetUpcoming(ctx, staffID, constants.ShiftUpcomingDays)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(s.staffHomeRedirect(ctx), err.Error()))
+		return
+	}
+	swaps, err := s.services.shift.GetSwaps(ctx, staffID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(s.staffHomeRedirect(ctx), err.Error()))
+		return
+	}
+	staffs, err := s.services.staff.GetAll(ctx, maxStaffListSize)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(s.staffHomeRedirect(ctx), err.Error()))
+		return
+	}
+
+	data := models.StaffShiftsPageData{
+		Days:      make([]models.StaffShiftDayItem, 0, len(days)),
+		Swaps:     shiftSwapItems(swaps),
+		Coworkers: make([]models.Staff, 0, len(staffs)),
+	}
+	for _, day := range days {
+		var weekday string
+		if date, err := time.Parse(constants.DateLayoutISO, day.Date); err == nil {
+			weekday = date.Weekday().String()
+		}
+		data.Days = append(data.Days, models.StaffShiftDayItem{
+			Date:    day.Date,
+			Weekday: weekday,
+			Shift:   day.Shift.String(),
+			RestDay: day.Shift.RestDay,
+		})
+	}
+	for _, staff := range staffs {
+		if staff.ID != staffID {
+			data.Coworkers = append(data.Coworkers, staff)
+		}
+	}
+
+	if err := compadmin.AdminStaffShiftsPage(data).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminStaffShiftSwapRequestHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Staff Shift Swap Request Handler]"
+	const page = "/admin/staff/shifts"
+	ctx := r.Context()
+
+	var f forms.AdminStaffShiftSwapForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if _, err := s.services.shift.RequestSwap(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		f.CounterpartID,
+		f.Date,
+		f.Reason,
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Shift swap requested. Waiting for approval."))
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -46,10 +47,11 @@ func (s *Server) adminSuperuserHomeHandler(w http.ResponseWriter, r *http.Reques
 	memoCards := make([]models.StaffMemoCard, 0, len(pendingMemos))
 	for _, m := range pendingMemos {
 		memoCards = append(memoCards, models.StaffMemoCard{
-			ID:      m.ID,
-			Title:   m.Title,
-			Message: m.Message,
-			FileURL: m.FileURL,
+			ID:                      m.ID,
+			Title:                   m.Title,
+			Message:                 m.Message,
+			FileURL:                 m.FileURL,
+			RequiresAcknowledgement: m.RequiresAcknowledgement,
 		})
 	}
 
@@ -87,6 +89,10 @@ func (s *Server) adminSuperuserEnvsHandler(w http.ResponseWriter, r *http.Reques
 		{Key: "Settings.ShowRandomSaleProduct", Value: strconv.FormatBool(cfg.Settings.ShowRandomSaleProduct)},
 		{Key: "Settings.ShowPromoBanners", Value: strconv.FormatBool(cfg.Settings.ShowPromoBanners)},
 		{Key: "AppEnv", Value: cfg.AppEnv.String()},
+		{Key: "AbandonedCart.IdleAfter", Value: cfg.AbandonedCart.IdleAfter.String()},
+		{Key: "AbandonedCart.ScanInterval", Value: cfg.AbandonedCart.ScanInterval.String()},
+		{Key: "Memo.ReminderInterval", Value: cfg.Memo.ReminderInterval.String()},
+		{Key: "Memo.ScanInterval", Value: cfg.Memo.ScanInterval.String()},
 		{Key: "Business.Lat", Value: cfg.Business.Lat},
 		{Key: "Business.Lng", Value: cfg.Business.Lng},
 		{Key: "Business.Address", Value: cfg.Business.Address},
@@ -100,6 +106,8 @@ func (s *Server) adminSuperuserEnvsHandler(w http.ResponseWriter, r *http.Reques
 		{Key: "Test.LocalOTP", Value: strconv.FormatBool(cfg.Test.LocalOTP)},
 		{Key: "Test.LocalForgotPassword", Value: strconv.FormatBool(cfg.Test.LocalForgotPassword)},
 		{Key: "Test.LocalMemoEmailSend", Value: strconv.FormatBool(cfg.Test.LocalMemoEmailSend)},
+		{Key: "Test.LocalWishlistEmail", Value: strconv.FormatBool(cfg.Test.LocalWishlistEmail)},
+		{Key: "Test.LocalAbandonedCart", Value: strconv.FormatBool(cfg.Test.LocalAbandonedCart)},
 	}...)
 
 	for _, s := range s.services.all {
@@ -149,6 +157,13 @@ func (s *Server) adminSuperuserAttendanceHandler(w http.ResponseWriter, r *http.
 		staffMap[staff.ID] = staff
 	}
 
+	schedules, err := s.services.shift.GetSchedules(ctx, startDate, endDate)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	attendanceData := make([]models.Attendance, 0, len(attendances))
 	for _, att := range attendances {
 		staff, ok := staffMap[att.StaffID]
@@ -157,7 +172,7 @@ func (s *Server) adminSuperuserAttendanceHandler(w http.ResponseWriter, r *http.
 		}
 		attendanceData = append(
 			attendanceData,
-			s.services.attendance.ComputeData(services.StaffRowBase(staff), att),
+			s.services.attendance.ComputeData(services.StaffRowBase(staff), att, schedules[att.StaffID]),
 		)
 	}
 
@@ -387,6 +402,10 @@ func (s *Server) adminSuperuserTimeOffApproveHandler(w http.ResponseWriter, r *h
 			zap.String("time_off_id", timeOffID),
 			zap.Error(err),
 		)
+		if errors.Is(err, errs.ErrLeaveInsufficientBalance) {
+			redirectHX(w, r, utils.URLWithError(page, err.Error()))
+			return
+		}
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/leave"
+	"cchoice/internal/logs"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+func (s *Server) adminSuperuserLeaveCreditsPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Leave Credits Page Handler]"
+	const page = "/admin/superuser"
+	ctx := r.Context()
+
+	items, err := s.adminLeaveCreditStaffItems(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	if err := compadmin.AdminSuperuserLeaveCreditsPage(items).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserLeaveCreditsTableHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Leave Credits Table Handler]"
+	ctx := r.Context()
+
+	items, err := s.adminLeaveCreditStaffItems(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := compadmin.AdminSuperuserLeaveCreditsTable(items).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserLeaveLedgerHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Leave Ledger Handler]"
+	ctx := r.Context()
+
+	var p forms.AdminSuperuserLeaveCreditPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		http.Error(w, httputil.ErrorMessage(err), http.StatusBadRequest)
+		return
+	}
+	staffID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		http.Error(w, "Invalid staff ID", http.StatusBadRequest)
+		return
+	}
+
+	entries, err := s.services.leave.GetLedger(ctx, staffID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	items := make([]models.AdminLeaveLedgerItem, 0, len(entries))
+	for _, entry := range entries {
+		period := entry.Period
+		if period == "" {
+			period = strconv.FormatInt(entry.Year, 10)
+		}
+		items = append(items, models.AdminLeaveLedgerItem{
+			LeaveType: entry.LeaveType,
+			EntryType: entry.EntryType,
+			Period:    period,
+			Credits:   entry.Credits.String(),
+			Note:      entry.Note,
+			CreatedBy: entry.CreatedBy,
+			CreatedAt: utils.ConvertToPH(entry.CreatedAt.UTC().Format(constants.DateTimeLayoutISO)),
+		})
+	}
+
+	if err := compadmin.AdminSuperuserLeaveLedgerTable(items).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserLeaveAdjustHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Leave Adjust Handler]"
+	const page = "/admin/superuser/leave-credits"
+	ctx := r.Context()
+
+	var f forms.AdminSuperuserLeaveAdjustmentForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	days, err := strconv.ParseFloat(f.Days, 64)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrLeaveInvalidAdjustment.Error()))
+		return
+	}
+
+	if err := s.services.leave.Adjust(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		f.StaffID,
+		enums.ParseTimeOffToEnum(f.Type),
+		leave.Credits(math.Round(days*float64(leave.CreditsPerDay))),
+		f.Note,
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Leave credits adjusted"))
+}
+
+func (s *Server) adminLeaveCreditStaffItems(ctx context.Context) ([]models.AdminLeaveCreditStaffItem, error) {
+	balances, err := s.services.leave.GetStaffBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.AdminLeaveCreditStaffItem, 0, len(balances))
+	for _, balance := range balances {
+		items = append(items, models.AdminLeaveCreditStaffItem{
+			StaffID:   balance.StaffID,
+			FullName:  balance.FullName,
+			Position:  balance.Position,
+			DateHired: balance.DateHired,
+			VL:        balance.VL.String(),
+			SL:        balance.SL.String(),
+		})
+	}
+	return items, nil
+}
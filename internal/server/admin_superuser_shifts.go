@@ -0,0 +1,368 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/services"
+	"cchoice/internal/shift"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+func (s *Server) adminSuperuserShiftsPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Shifts Page Handler]"
+	ctx := r.Context()
+
+	if err := compadmin.AdminSuperuserShiftsPage().Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserShiftsTableHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Shifts Table Handler]"
+	ctx := r.Context()
+
+	summaries, err := s.services.shift.GetStaffSummaries(ctx)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	items := make([]models.AdminShiftStaffItem, 0, len(summaries))
+	for _, summary := range summaries {
+		pattern := "Fixed"
+		switch {
+		case summary.TemplateDays > 0 && summary.CycleWeeks > 1:
+			pattern = fmt.Sprintf("Rotating, %d weeks", summary.CycleWeeks)
+		case summary.TemplateDays > 0:
+			pattern = "Weekly"
+		}
+		items = append(items, models.AdminShiftStaffItem{
+			StaffID:      summary.StaffID,
+			FullName:     summary.FullName,
+			Position:     summary.Position,
+			DefaultShift: summary.Default.String(),
+			Pattern:      pattern,
+		})
+	}
+
+	if err := compadmin.AdminSuperuserShiftsTable(items).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserShiftSwapsTableHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Shift Swaps Table Handler]"
+	ctx := r.Context()
+
+	swaps, err := s.services.shift.GetSwaps(ctx, "")
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := compadmin.AdminSuperuserShiftSwapsTable(shiftSwapItems(swaps)).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserShiftEditorPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Shift Editor Page Handler]"
+	const page = "/admin/superuser/shifts"
+	ctx := r.Context()
+
+	var p forms.AdminShiftStaffPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	staffID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, "Invalid staff ID"))
+		return
+	}
+
+	editor, err := s.services.shift.GetEditor(ctx, staffID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	if err := compadmin.AdminSuperuserShiftEditorPage(adminShiftEditor(editor)).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserShiftRotationHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Shift Rotation Handler]"
+	ctx := r.Context()
+
+	var p forms.AdminShiftStaffPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		http.Error(w, httputil.ErrorMessage(err), http.StatusBadRequest)
+		return
+	}
+	page := "/admin/superuser/shifts/" + p.ID
+
+	var f forms.AdminShiftRotationForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if err := s.services.shift.SetRotation(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		p.ID,
+		f.CycleWeeks,
+		f.AnchorDate,
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Rotation updated"))
+}
+
+func (s *Server) adminSuperuserShiftDayHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Shift Day Handler]"
+	ctx := r.Context()
+
+	var p forms.AdminShiftStaffPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		http.Error(w, httputil.ErrorMessage(err), http.StatusBadRequest)
+		return
+	}
+	page := "/admin/superuser/shifts/" + p.ID
+
+	var f forms.AdminShiftDayForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if err := s.services.shift.SetDay(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		p.ID,
+		shift.Slot{Week: f.Week, Weekday: time.Weekday(f.Weekday)},
+		formShift(f.TimeIn, f.TimeOut, f.RestDay),
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Shift saved"))
+}
+
+func (s *Server) adminSuperuserShiftClearHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Shift Clear Handler]"
+	ctx := r.Context()
+
+	var p forms.AdminShiftStaffPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		http.Error(w, httputil.ErrorMessage(err), http.StatusBadRequest)
+		return
+	}
+	page := "/admin/superuser/shifts/" + p.ID
+
+	if err := s.services.shift.ClearTemplate(ctx, s.sessionManager.GetString(ctx, SessionStaffID), p.ID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Weekly schedule cleared"))
+}
+
+func (s *Server) adminSuperuserShiftOverrideCreateHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Shift Override Create Handler]"
+	ctx := r.Context()
+
+	var p forms.AdminShiftStaffPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		http.Error(w, httputil.ErrorMessage(err), http.StatusBadRequest)
+		return
+	}
+	page := "/admin/superuser/shifts/" + p.ID
+
+	var f forms.AdminShiftOverrideForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if err := s.services.shift.SetOverride(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		p.ID,
+		f.Date,
+		formShift(f.TimeIn, f.TimeOut, f.RestDay),
+		f.Note,
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Override saved"))
+}
+
+func (s *Server) adminSuperuserShiftOverrideDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Shift Override Delete Handler]"
+	ctx := r.Context()
+
+	var p forms.AdminShiftOverridePath
+	if err := httputil.BindPath(r, &p); err != nil {
+		http.Error(w, httputil.ErrorMessage(err), http.StatusBadRequest)
+		return
+	}
+	page := "/admin/superuser/shifts/" + p.ID
+
+	if err := s.services.shift.DeleteOverride(
+		ctx,
+		s.sessionManager.GetString(ctx, SessionStaffID),
+		p.ID,
+		p.OverrideID,
+	); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Override removed"))
+}
+
+func (s *Server) adminSuperuserShiftSwapApproveHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Shift Swap Approve Handler]"
+	const page = "/admin/superuser/shifts"
+	ctx := r.Context()
+
+	var p forms.AdminShiftSwapPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if err := s.services.shift.ApproveSwap(ctx, s.sessionManager.GetString(ctx, SessionStaffID), p.ID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("swap id", p.ID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Shift swap approved"))
+}
+
+func (s *Server) adminSuperuserShiftSwapRejectHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Shift Swap Reject Handler]"
+	const page = "/admin/superuser/shifts"
+	ctx := r.Context()
+
+	var p forms.AdminShiftSwapPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+
+	if err := s.services.shift.RejectSwap(ctx, s.sessionManager.GetString(ctx, SessionStaffID), p.ID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("swap id", p.ID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Shift swap rejected"))
+}
+
+func formShift(timeIn, timeOut, restDay string) shift.Shift {
+	if restDay == "true" {
+		return shift.Shift{RestDay: true}
+	}
+	return shift.Shift{TimeIn: timeIn, TimeOut: timeOut}
+}
+
+// adminShiftEditor lays the template out week by week starting on Monday. A
+// staff without a template gets their default shift prefilled on every day.
+func adminShiftEditor(editor services.StaffShiftEditor) models.AdminShiftEditor {
+	res := models.AdminShiftEditor{
+		StaffID:      editor.StaffID,
+		FullName:     editor.FullName,
+		Position:     editor.Position,
+		DefaultShift: editor.Default.String(),
+		CycleWeeks:   editor.CycleWeeks,
+		AnchorDate:   editor.Anchor,
+		Weeks:        make([]models.AdminShiftWeek, 0, editor.CycleWeeks),
+		Overrides:    make([]models.AdminShiftOverrideItem, 0, len(editor.Overrides)),
+	}
+
+	anchor, _ := time.Parse(constants.DateLayoutISO, editor.Anchor)
+	for week := range editor.CycleWeeks {
+		label := "Every week"
+		if editor.CycleWeeks > 1 {
+			label = fmt.Sprintf("Week %d (starting %s)", week+1, anchor.AddDate(0, 0, 7*week).Format(constants.DateLayoutISO))
+		}
+		days := make([]models.AdminShiftDay, 0, len(shift.Weekdays))
+		for _, weekday := range shift.Weekdays {
+			sh, ok := editor.Template[shift.Slot{Week: week, Weekday: weekday}]
+			switch {
+			case !ok && len(editor.Template) == 0:
+				sh = editor.Default
+			case !ok:
+				sh = shift.Shift{RestDay: true}
+			}
+			days = append(days, models.AdminShiftDay{
+				Week:    week,
+				Weekday: int(weekday),
+				Label:   weekday.String(),
+				TimeIn:  sh.TimeIn,
+				TimeOut: sh.TimeOut,
+				RestDay: sh.RestDay,
+			})
+		}
+		res.Weeks = append(res.Weeks, models.AdminShiftWeek{Index: week, Label: label, Days: days})
+	}
+
+	for _, o := range editor.Overrides {
+		res.Overrides = append(res.Overrides, models.AdminShiftOverrideItem{
+			ID:       o.ID,
+			Date:     o.Date,
+			Shift:    o.Shift.String(),
+			Note:     o.Note,
+			FromSwap: o.FromSwap,
+		})
+	}
+	return res
+}
+
+func shiftSwapItems(swaps []services.ShiftSwap) []models.ShiftSwapItem {
+	items := make([]models.ShiftSwapItem, 0, len(swaps))
+	for _, swap := range swaps {
+		items = append(items, models.ShiftSwapItem{
+			ID:          swap.ID,
+			Requester:   swap.RequesterName,
+			Counterpart: swap.CounterpartName,
+			Date:        swap.Date,
+			Reason:      swap.Reason,
+			Status:      swap.Status,
+			CreatedAt:   utils.ConvertToPH(swap.CreatedAt.UTC().Format(constants.DateTimeLayoutISO)),
+		})
+	}
+	return items
+}
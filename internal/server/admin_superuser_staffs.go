@@ -358,6 +358,13 @@ func (s *Server) adminSuperuserStaffsUpdateHandler(w http.ResponseWriter, r *htt
 		}
 	}()
 
+	current, err := s.services.staff.GetCurrentStaff(ctx, staffID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrStaffIDRequired.Error()))
+		return
+	}
+
 	if err := s.services.staff.UpdateEmployment(ctx, services.UpdateEmploymentParams{
 		ID:              staffID,
 		Status:          status,
@@ -383,6 +390,12 @@ func (s *Server) adminSuperuserStaffsUpdateHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
+	if current.Status != status.String() {
+		if _, err := s.services.session.RevokeUser(ctx, enums.USER_TYPE_STAFF, staffID, enums.SESSION_REVOKE_REASON_STATUS_CHANGE); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		}
+	}
+
 	result = fmt.Sprintf("success. ID '%s'", staffID)
 	redirectHX(w, r, utils.URLWithSuccess(page, "Employee updated successfully"))
 }
@@ -2,8 +2,11 @@ package server
 
 import (
 	"cmp"
+	"encoding/csv"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	compadmin "cchoice/cmd/web/components/admin"
 	"cchoice/cmd/web/models"
@@ -41,7 +44,7 @@ func (s *Server) adminTrackedLinksEditPageHandler(w http.ResponseWriter, r *http
 		return
 	}
 
-	clickCount, _ := s.services.trackedLink.GetClickCount(ctx, link.ID)
+	clickCount, _ := s.services.trackedLink.GetClickCount(ctx, link.ID, false)
 
 	item := models.AdminTrackedLinkListItem{
 		ID:             link.ID,
@@ -98,7 +101,7 @@ func (s *Server) adminTrackedLinksListTableHandler(w http.ResponseWriter, r *htt
 
 	linkList := make([]models.AdminTrackedLinkListItem, 0, len(links))
 	for _, l := range links {
-		clickCount, _ := s.services.trackedLink.GetClickCount(ctx, l.ID)
+		clickCount, _ := s.services.trackedLink.GetClickCount(ctx, l.ID, false)
 		linkList = append(linkList, models.AdminTrackedLinkListItem{
 			ID:             l.ID,
 			Name:           l.Name,
@@ -257,3 +260,103 @@ func (s *Server) adminTrackedLinksDeleteHandler(w http.ResponseWriter, r *http.R
 
 	redirectHX(w, r, utils.URLWithSuccess(page, "Tracked link deleted successfully"))
 }
+
+func (s *Server) adminTrackedLinkAnalyticsPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Tracked Link Analytics Page Handler]"
+	const page = "/admin/tracked-links"
+	ctx := r.Context()
+
+	var p forms.AdminTrackedLinkPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	idStr, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	var q forms.AdminTrackedLinkAnalyticsQuery
+	_ = httputil.BindQuery(r, &q)
+	days := q.Days
+	if days <= 0 {
+		days = constants.TrackedLinkAnalyticsDefaultDays
+	}
+	days = min(days, constants.TrackedLinkAnalyticsMaxDays)
+
+	analytics, err := s.services.trackedLink.GetLinkAnalytics(ctx, idStr, days, q.IncludeFiltered)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("id", idStr), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	if err := compadmin.AdminTrackedLinkAnalyticsPage(*analytics).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+}
+
+func (s *Server) adminTrackedLinkCampaignsPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Tracked Link Campaigns Page Handler]"
+	const page = "/admin/tracked-links"
+	ctx := r.Context()
+
+	var q forms.AdminTrackedLinkAnalyticsQuery
+	_ = httputil.BindQuery(r, &q)
+
+	campaigns, err := s.services.trackedLink.GetCampaignAnalytics(ctx, q.IncludeFiltered)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	if err := compadmin.AdminTrackedLinkCampaignsPage(campaigns, q.IncludeFiltered).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+}
+
+func (s *Server) adminTrackedLinkClicksExportHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Tracked Link Clicks Export Handler]"
+	const page = "/admin/tracked-links"
+	ctx := r.Context()
+
+	var p forms.AdminTrackedLinkPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	idStr, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	var q forms.AdminTrackedLinkAnalyticsQuery
+	_ = httputil.BindQuery(r, &q)
+
+	link, err := s.services.trackedLink.GetTrackedLinkByID(ctx, idStr)
+	if err != nil || link == nil {
+		err = cmp.Or(err, errs.ErrDBNil)
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	filename := fmt.Sprintf("link_clicks_%s_%s.csv", link.Slug, time.Now().Format(constants.DateTimeLayoutFilename))
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	if err := s.services.trackedLink.ExportClicksCSV(ctx, writer, link.ID, q.IncludeFiltered); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("id", link.ID), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,457 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/metrics"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+// A staff who passed the password step but not the second factor is kept
+// under these keys instead of SessionStaffID, so requireStaffAuth still
+// treats them as logged out.
+const (
+	skStaffPendingID = "staff_pending_id"
+	skStaffPendingAt = "staff_pending_at"
+)
+
+func staffHomeURL(userType string) string {
+	switch enums.ParseStaffUserTypeToEnum(userType) {
+	case enums.STAFF_USER_TYPE_SUPERUSER:
+		return utils.URL("/admin/superuser")
+	case enums.STAFF_USER_TYPE_STAFF:
+		return utils.URL("/admin/staff")
+	default:
+		return utils.URL("/admin")
+	}
+}
+
+func (s *Server) requestUserAgentID(r *http.Request) sql.NullInt64 {
+	if ua := r.UserAgent(); ua != "" {
+		return getOrCreateUserAgentID(context.Background(), s.dbRW, ua)
+	}
+	return sql.NullInt64{}
+}
+
+// startStaffSession is the last step of every successful login. The session
+// token is renewed so a token seen before the second factor cannot be reused.
+func (s *Server) startStaffSession(r *http.Request, staffID int64) error {
+	const logtag = "[Start Staff Session]"
+	ctx := r.Context()
+
+	if err := s.sessionManager.RenewToken(ctx); err != nil {
+		return err
+	}
+	s.clearPendingStaff(ctx)
+	s.sessionManager.Put(ctx, SessionStaffID, s.encoder.Encode(staffID))
+	s.registerSession(r, enums.USER_TYPE_STAFF, s.encoder.Encode(staffID))
+
+	accessID, err := s.dbRW.GetQueries().CreateStaffAccess(context.Background(), queries.CreateStaffAccessParams{
+		StaffID:     staffID,
+		UseragentID: s.requestUserAgentID(r),
+	})
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+	} else {
+		s.sessionManager.Put(ctx, SessionStaffAccessID, accessID)
+	}
+	return nil
+}
+
+func (s *Server) startPendingStaff(ctx context.Context, staffID string) {
+	s.sessionManager.Put(ctx, skStaffPendingID, staffID)
+	s.sessionManager.Put(ctx, skStaffPendingAt, utils.NowPH().Unix())
+}
+
+func (s *Server) clearPendingStaff(ctx context.Context) {
+	s.sessionManager.Remove(ctx, skStaffPendingID)
+	s.sessionManager.Remove(ctx, skStaffPendingAt)
+}
+
+// pendingStaff returns the staff waiting on their second factor, dropping
+// the pending login once it is older than TwoFactorPendingTTL.
+func (s *Server) pendingStaff(ctx context.Context) (queries.GetStaffByIDRow, bool) {
+	staffID := s.sessionManager.GetString(ctx, skStaffPendingID)
+	if staffID == "" {
+		return queries.GetStaffByIDRow{}, false
+	}
+
+	startedAt := time.Unix(s.sessionManager.GetInt64(ctx, skStaffPendingAt), 0)
+	if utils.NowPH().Sub(startedAt) > constants.TwoFactorPendingTTL {
+		s.clearPendingStaff(ctx)
+		return queries.GetStaffByIDRow{}, false
+	}
+
+	staff, err := s.services.staff.GetCurrentStaff(ctx, staffID)
+	if err != nil || enums.ParseStaffStatusToEnum(staff.Status) == enums.STAFF_STATUS_RESIGNED {
+		s.clearPendingStaff(ctx)
+		return queries.GetStaffByIDRow{}, false
+	}
+	return staff, true
+}
+
+// failPendingStaff records a failed second factor and reports whether the
+// pending login was thrown away because the staff's second factor is locked.
+// The wrong codes themselves are counted per staff by StaffTwoFactorService.
+func (s *Server) failPendingStaff(ctx context.Context, err error) bool {
+	metrics.Auth.LoginAttempt(metrics.AuthUserTypeAdmin, metrics.AuthResultFailure)
+	if errors.Is(err, errs.ErrTwoFactorTooManyAttempts) {
+		s.clearPendingStaff(ctx)
+		return true
+	}
+	return false
+}
+
+func (s *Server) isTrustedStaffDevice(r *http.Request, staffID string) bool {
+	cookie, err := r.Cookie(constants.TwoFactorDeviceCookie)
+	if err != nil {
+		return false
+	}
+	return s.services.staffTwoFactor.IsTrustedDevice(r.Context(), staffID, cookie.Value, s.requestUserAgentID(r))
+}
+
+func (s *Server) setStaffDeviceCookie(w http.ResponseWriter, token string, maxAge time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     constants.TwoFactorDeviceCookie,
+		Value:    token,
+		Path:     "/admin",
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   s.useSSL,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (s *Server) enrolmentPage(ctx context.Context, staffID string, email string, confirmURL string) (models.TwoFactorEnrolment, error) {
+	enrolment, err := s.services.staffTwoFactor.BeginEnrolment(ctx, staffID, email)
+	if err != nil {
+		return models.TwoFactorEnrolment{}, err
+	}
+	qr, err := s.services.qr.GenerateQRBase64(ctx, enrolment.URI)
+	if err != nil {
+		return models.TwoFactorEnrolment{}, err
+	}
+	return models.TwoFactorEnrolment{
+		QRCode:     qr,
+		Secret:     enrolment.Secret,
+		ConfirmURL: confirmURL,
+	}, nil
+}
+
+func (s *Server) adminTwoFactorVerifyPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Two Factor Verify Page Handler]"
+	ctx := r.Context()
+
+	if _, ok := s.pendingStaff(ctx); !ok {
+		redirectHX(w, r, utils.URLWithError("/admin", errs.ErrTwoFactorPending.Error()))
+		return
+	}
+
+	if err := compadmin.AdminTwoFactorVerifyPage().Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminTwoFactorVerifyHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Two Factor Verify Handler]"
+	const page = "/admin/login/2fa"
+	ctx := r.Context()
+
+	staff, ok := s.pendingStaff(ctx)
+	if !ok {
+		redirectHX(w, r, utils.URLWithError("/admin", errs.ErrTwoFactorPending.Error()))
+		return
+	}
+
+	var f forms.AdminTwoFactorVerifyForm
+	if err := httputil.BindPostForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrTwoFactorInvalidCode.Error()))
+		return
+	}
+	method := enums.TWO_FACTOR_METHOD_TOTP
+	if f.Method != "" {
+		method = enums.ParseTwoFactorMethodToEnum(f.Method)
+	}
+
+	staffID := s.encoder.Encode(staff.ID)
+	if err := s.services.staffTwoFactor.Verify(ctx, staffID, method, f.Code); err != nil {
+		if !errors.Is(err, errs.ErrTwoFactorInvalidCode) && !errors.Is(err, errs.ErrTwoFactorTooManyAttempts) {
+			logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		}
+		if s.failPendingStaff(ctx, err) {
+			redirectHX(w, r, utils.URLWithError("/admin", errs.ErrTwoFactorTooManyAttempts.Error()))
+			return
+		}
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrTwoFactorInvalidCode.Error()))
+		return
+	}
+
+	if f.Remember != "" {
+		token, err := s.services.staffTwoFactor.RememberDevice(ctx, staffID, s.requestUserAgentID(r))
+		if err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		} else {
+			s.setStaffDeviceCookie(w, token, constants.TwoFactorDeviceTTL)
+		}
+	}
+
+	if err := s.startStaffSession(r, staff.ID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	metrics.Auth.LoginAttempt(metrics.AuthUserTypeAdmin, metrics.AuthResultSuccess)
+	redirectHX(w, r, staffHomeURL(staff.UserType))
+}
+
+func (s *Server) adminTwoFactorEmailHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Two Factor Email Handler]"
+	const page = "/admin/login/2fa"
+	ctx := r.Context()
+
+	staff, ok := s.pendingStaff(ctx)
+	if !ok {
+		redirectHX(w, r, utils.URLWithError("/admin", errs.ErrTwoFactorPending.Error()))
+		return
+	}
+
+	if err := s.services.staffTwoFactor.SendEmailCode(ctx, s.encoder.Encode(staff.ID), staff.Email); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Int64("staff id", staff.ID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "A sign in code was sent to your email"))
+}
+
+func (s *Server) adminTwoFactorEnrolPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Two Factor Enrol Page Handler]"
+	ctx := r.Context()
+
+	staff, ok := s.pendingStaff(ctx)
+	if !ok {
+		redirectHX(w, r, utils.URLWithError("/admin", errs.ErrTwoFactorPending.Error()))
+		return
+	}
+
+	enrolment, err := s.enrolmentPage(ctx, s.encoder.Encode(staff.ID), staff.Email, utils.URL("/admin/login/2fa/enroll"))
+	if err != nil {
+		if errors.Is(err, errs.ErrTwoFactorAlreadyEnabled) {
+			redirectHX(w, r, utils.URL("/admin/login/2fa"))
+			return
+		}
+		logs.LogCtx(ctx).Error(logtag, zap.Int64("staff id", staff.ID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError("/admin", err.Error()))
+		return
+	}
+
+	if err := compadmin.AdminTwoFactorEnrolPage(enrolment).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminTwoFactorEnrolHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Two Factor Enrol Handler]"
+	const page = "/admin/login/2fa/enroll"
+	ctx := r.Context()
+
+	staff, ok := s.pendingStaff(ctx)
+	if !ok {
+		redirectHX(w, r, utils.URLWithError("/admin", errs.ErrTwoFactorPending.Error()))
+		return
+	}
+
+	var f forms.AdminTwoFactorCodeForm
+	if err := httputil.BindPostForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrTwoFactorInvalidCode.Error()))
+		return
+	}
+
+	codes, err := s.services.staffTwoFactor.ConfirmEnrolment(ctx, s.encoder.Encode(staff.ID), f.Code)
+	if err != nil {
+		if !errors.Is(err, errs.ErrTwoFactorInvalidCode) && !errors.Is(err, errs.ErrTwoFactorTooManyAttempts) {
+			logs.LogCtx(ctx).Error(logtag, zap.Int64("staff id", staff.ID), zap.Error(err))
+		}
+		if s.failPendingStaff(ctx, err) {
+			redirectHX(w, r, utils.URLWithError("/admin", errs.ErrTwoFactorTooManyAttempts.Error()))
+			return
+		}
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	if err := s.startStaffSession(r, staff.ID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	metrics.Auth.LoginAttempt(metrics.AuthUserTypeAdmin, metrics.AuthResultSuccess)
+
+	if err := compadmin.AdminTwoFactorRecoveryCodesPage(models.TwoFactorRecoveryCodes{
+		Codes:       codes,
+		ContinueURL: staffHomeURL(staff.UserType),
+	}).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminProfileTwoFactorPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Profile Two Factor Page Handler]"
+	const page = "/admin/profile"
+	ctx := r.Context()
+
+	staffID := s.sessionManager.GetString(ctx, SessionStaffID)
+	staff, err := s.services.staff.GetCurrentStaff(ctx, staffID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHXLogin(w, r)
+		return
+	}
+
+	status, err := s.services.staffTwoFactor.GetStatus(ctx, staffID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	settings := models.TwoFactorSettings{
+		Enabled:           status.Enabled,
+		RecoveryCodesLeft: status.RecoveryCodesLeft,
+		TrustedDevices:    status.TrustedDevices,
+		IsSuperuser:       enums.ParseStaffUserTypeToEnum(staff.UserType) == enums.STAFF_USER_TYPE_SUPERUSER,
+	}
+	if status.Enabled {
+		settings.EnabledAt = utils.ConvertToPH(status.EnabledAt.UTC().Format(constants.DateTimeLayoutISO))
+	} else {
+		settings.Enrolment, err = s.enrolmentPage(ctx, staffID, staff.Email, utils.URL("/admin/profile/2fa/enroll"))
+		if err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+			redirectHX(w, r, utils.URLWithError(page, err.Error()))
+			return
+		}
+	}
+
+	if err := compadmin.AdminProfileTwoFactorPage(settings).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminProfileTwoFactorEnrolHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Profile Two Factor Enrol Handler]"
+	const page = "/admin/profile/2fa"
+	ctx := r.Context()
+
+	var f forms.AdminTwoFactorCodeForm
+	if err := httputil.BindPostForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrTwoFactorInvalidCode.Error()))
+		return
+	}
+
+	staffID := s.sessionManager.GetString(ctx, SessionStaffID)
+	codes, err := s.services.staffTwoFactor.ConfirmEnrolment(ctx, staffID, f.Code)
+	if err != nil {
+		if !errors.Is(err, errs.ErrTwoFactorInvalidCode) && !errors.Is(err, errs.ErrTwoFactorTooManyAttempts) {
+			logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		}
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	if err := compadmin.AdminTwoFactorRecoveryCodesPage(models.TwoFactorRecoveryCodes{
+		Codes:       codes,
+		ContinueURL: utils.URL(page),
+	}).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminProfileTwoFactorRecoveryCodesHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Profile Two Factor Recovery Codes Handler]"
+	const page = "/admin/profile/2fa"
+	ctx := r.Context()
+
+	staffID := s.sessionManager.GetString(ctx, SessionStaffID)
+	codes, err := s.services.staffTwoFactor.RegenerateRecoveryCodes(ctx, staffID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	if err := compadmin.AdminTwoFactorRecoveryCodesPage(models.TwoFactorRecoveryCodes{
+		Codes:       codes,
+		ContinueURL: utils.URL(page),
+	}).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminProfileTwoFactorDevicesDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Profile Two Factor Devices Delete Handler]"
+	const page = "/admin/profile/2fa"
+	ctx := r.Context()
+
+	staffID := s.sessionManager.GetString(ctx, SessionStaffID)
+	if err := s.services.staffTwoFactor.ForgetDevices(ctx, staffID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	s.setStaffDeviceCookie(w, "", -time.Second)
+	redirectHX(w, r, utils.URLWithSuccess(page, "Remembered devices forgotten"))
+}
+
+func (s *Server) adminProfileTwoFactorDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Profile Two Factor Delete Handler]"
+	const page = "/admin/profile/2fa"
+	ctx := r.Context()
+
+	var f forms.AdminTwoFactorCodeForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrTwoFactorInvalidCode.Error()))
+		return
+	}
+
+	staffID := s.sessionManager.GetString(ctx, SessionStaffID)
+	staff, err := s.services.staff.GetCurrentStaff(ctx, staffID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHXLogin(w, r)
+		return
+	}
+
+	isSuperuser := enums.ParseStaffUserTypeToEnum(staff.UserType) == enums.STAFF_USER_TYPE_SUPERUSER
+	if err := s.services.staffTwoFactor.Disable(ctx, staffID, isSuperuser, f.Code); err != nil {
+		if !errors.Is(err, errs.ErrTwoFactorInvalidCode) && !errors.Is(err, errs.ErrTwoFactorTooManyAttempts) {
+			logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		}
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	s.setStaffDeviceCookie(w, "", -time.Second)
+	redirectHX(w, r, utils.URLWithSuccess(page, "Two-factor authentication turned off"))
+}
@@ -51,6 +51,8 @@ func AddCartsHandlers(s *Server, r chi.Router) {
 	r.Patch("/carts/lines/{checkoutline_id}/toggle", s.toggleCartLineCheckboxHandler)
 	r.Get("/carts/payment-methods", s.cartsPaymentMethodsHandler)
 	r.Post("/carts/finalize", s.cartsFinalizeHandler)
+	r.Get("/carts/resume", s.cartsResumeHandler)
+	r.With(s.requireCustomerAuth).Post("/carts/restore", s.cartsRestoreHandler)
 }
 
 type cartSummaryData struct {
@@ -85,6 +87,41 @@ func (s *Server) getCartShippingPrefill(ctx context.Context) models.CartShipping
 	return prefill
 }
 
+// attachCustomerCart makes the logged-in customer's saved cart the session cart,
+// merging whatever the session already holds. It writes to the database, so it
+// only runs on login and when the customer asks to restore their cart.
+func (s *Server) attachCustomerCart(ctx context.Context, mode cart.MergeMode) error {
+	const logtag = "[Attach Customer Cart]"
+
+	customerID := s.getSessionCustomerID(ctx)
+	if !customerID.Valid {
+		return nil
+	}
+
+	sessionProductIDs, _ := s.sessionManager.Get(ctx, skCheckoutLineProductIDs).([]string)
+	productIDs, err := cart.AttachCustomer(
+		ctx,
+		s.dbRW,
+		s.encoder,
+		s.sessionManager.Token(ctx),
+		customerID.Int64,
+		sessionProductIDs,
+		mode,
+	)
+	if err != nil {
+		logs.LogCtx(ctx).Warn(
+			logtag,
+			zap.Int64("customer id", customerID.Int64),
+			zap.Error(err),
+		)
+		return err
+	}
+	if len(productIDs) > 0 {
+		s.sessionManager.Put(ctx, skCheckoutLineProductIDs, productIDs)
+	}
+	return nil
+}
+
 func (s *Server) getSessionCustomerID(ctx context.Context) sql.NullInt64 {
 	customerIDStr := s.sessionManager.GetString(ctx, SessionCustomerID)
 	if customerIDStr == "" {
@@ -183,7 +220,8 @@ func (s *Server) cartsPageHandler(w http.ResponseWriter, r *http.Request) {
 
 	checkoutlineProductIDs, ok := s.sessionManager.Get(ctx, skCheckoutLineProductIDs).([]string)
 	if len(checkoutlineProductIDs) == 0 {
-		if err := compcart.CartPage(compcart.CartPageBodyEmpty()).Render(ctx, w); err != nil {
+		loggedIn := s.getSessionCustomerID(ctx).Valid
+		if err := compcart.CartPage(compcart.CartPageBodyEmpty(loggedIn)).Render(ctx, w); err != nil {
 			logs.LogCtx(ctx).Error(
 				logtag,
 				zap.Error(err),
@@ -200,7 +238,7 @@ func (s *Server) cartsPageHandler(w http.ResponseWriter, r *http.Request) {
 			zap.String("token", token),
 			zap.Error(errs.ErrSessionCheckoutLineProductIDs),
 		)
-		if err := compcart.CartPage(compcart.CartPageBodyEmpty()).Render(ctx, w); err != nil {
+		if err := compcart.CartPage(compcart.CartPageBodyEmpty(false)).Render(ctx, w); err != nil {
 			logs.LogCtx(ctx).Error(
 				logtag,
 				zap.Error(err),
@@ -223,7 +261,7 @@ func (s *Server) cartsPageHandler(w http.ResponseWriter, r *http.Request) {
 			zap.String("token", token),
 			zap.Error(err),
 		)
-		if err := compcart.CartPage(compcart.CartPageBodyEmpty()).Render(ctx, w); err != nil {
+		if err := compcart.CartPage(compcart.CartPageBodyEmpty(false)).Render(ctx, w); err != nil {
 			logs.LogCtx(ctx).Error(
 				logtag,
 				zap.Error(err),
@@ -233,6 +271,22 @@ func (s *Server) cartsPageHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if customerID := s.getSessionCustomerID(ctx); customerID.Valid {
+		if err := s.dbRW.GetQueries().SetCheckoutCustomerID(ctx, queries.SetCheckoutCustomerIDParams{
+			CustomerID: customerID,
+			ID:         checkoutID,
+		}); err != nil {
+			logs.LogCtx(ctx).Warn(
+				logtag,
+				zap.Int64("checkout id", checkoutID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	s.attributeCheckout(r, checkoutID)
+	s.attachExperimentCheckout(r, checkoutID)
+
 	showCPointsBanner := s.sessionManager.GetString(ctx, SessionCustomerID) == ""
 	summaryContent := s.generateCartSummaryComponent(ctx)
 	shippingPrefill := s.getCartShippingPrefill(ctx)
@@ -259,7 +313,7 @@ func (s *Server) cartLinesHandler(w http.ResponseWriter, r *http.Request) {
 			zap.Error(err),
 			zap.Error(errs.ErrCartMissingCheckoutLines),
 		)
-		if err := compcart.CartPage(compcart.CartPageBodyEmpty()).Render(ctx, w); err != nil {
+		if err := compcart.CartPage(compcart.CartPageBodyEmpty(false)).Render(ctx, w); err != nil {
 			logs.LogCtx(ctx).Error(
 				logtag,
 				zap.Error(err),
@@ -441,6 +495,7 @@ func (s *Server) addProductToCartHandler(w http.ResponseWriter, r *http.Request)
 		zap.String("product id", productID),
 		zap.Int64("qty", qty),
 	)
+	s.markExperimentAddToCart(r)
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -860,6 +915,14 @@ func (s *Server) cartsFinalizeHandler(w http.ResponseWriter, r *http.Request) {
 		metrics.Orders.Created(cartCheckout.PaymentMethod)
 		checkoutResult = metrics.CheckoutResultSuccess
 
+		recoveredCheckoutID := s.sessionManager.GetInt64(ctx, skRecoveredCheckoutID)
+		if recovered, err := s.services.abandonedCart.MarkRecovered(ctx, checkoutID, recoveredCheckoutID); err != nil {
+			logs.LogCtx(ctx).Warn(logtag, zap.Int64("checkout id", checkoutID), zap.Error(err))
+		} else if recovered {
+			logs.LogCtx(ctx).Info(logtag, zap.String("result", "recovered abandoned cart"), zap.Int64("order_id", order.ID))
+		}
+		s.sessionManager.Remove(ctx, skRecoveredCheckoutID)
+
 		// Redirect to payment gateway
 		w.Header().Set("HX-Redirect", checkoutURL)
 
@@ -874,6 +937,54 @@ func (s *Server) cartsFinalizeHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *Server) cartsResumeHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Cart Resume Handler]"
+	const page = "/carts"
+	ctx := r.Context()
+
+	var q forms.CartResumeQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrCartInvalidRecoveryToken.Error()))
+		return
+	}
+
+	resume, err := s.services.abandonedCart.Resume(ctx, q.Token)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	// Same browser: the session still owns the checkout, so there is nothing
+	// to restore.
+	if resume.SessionID != s.sessionManager.Token(ctx) {
+		existing, _ := s.sessionManager.Get(ctx, skCheckoutLineProductIDs).([]string)
+		s.sessionManager.Put(ctx, skCheckoutLineProductIDs, resume.MergeProductIDs(existing))
+	}
+	s.sessionManager.Put(ctx, skRecoveredCheckoutID, resume.CheckoutID)
+
+	logs.LogCtx(ctx).Info(
+		logtag,
+		zap.Int64("checkout id", resume.CheckoutID),
+		zap.Int("lines", len(resume.Lines)),
+	)
+
+	redirectHX(w, r, utils.URL(page))
+}
+
+// cartsRestoreHandler brings the cart saved to the customer's account onto
+// this device. Quantities already in the session are kept, not added to.
+func (s *Server) cartsRestoreHandler(w http.ResponseWriter, r *http.Request) {
+	const page = "/carts"
+	ctx := r.Context()
+
+	if err := s.attachCustomerCart(ctx, cart.MergeModeRestore); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrCartRestore.Error()))
+		return
+	}
+	redirectHX(w, r, utils.URLWithSuccess(page, "Your saved cart was restored"))
+}
+
 func (s *Server) getPaymentImageURL(pm payments.PaymentMethod) string {
 	imgPath := pm.GetImagePath()
 	if imgPath == "" {
@@ -74,7 +74,7 @@ func (s *Server) renderCategoryPage(
 		return
 	}
 
-	pageData.ThemeCSS = s.activeThemeCSS(ctx, logtag)
+	pageData.ThemeCSS = s.storefrontThemeCSS(ctx, s.experimentBucketKey(w, r), logtag)
 
 	if err := compshop.CategoryPage(*pageData).Render(ctx, w); err != nil {
 		logs.LogCtx(ctx).Error(
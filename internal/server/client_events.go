@@ -0,0 +1,37 @@
+package server
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"net/http"
+
+	"cchoice/internal/encode"
+	"cchoice/internal/metrics"
+	"cchoice/internal/services"
+)
+
+// recordClientEvent queues the event for the event store along with who sent
+// it. Product events carry an encoded product ID that is decoded here so the
+// store can be joined against tbl_products.
+func (s *Server) recordClientEvent(r *http.Request, event string, value string, resultCount sql.NullInt64) {
+	ctx := r.Context()
+
+	ev := services.ClientEvent{
+		Event:       event,
+		Value:       metrics.SanitizeStoredEventValue(value),
+		CustomerID:  s.getSessionCustomerID(ctx),
+		ResultCount: resultCount,
+	}
+	if token := s.sessionManager.Token(ctx); token != "" {
+		hash := sha256.Sum256([]byte(token))
+		ev.SessionHash = hex.EncodeToString(hash[:])
+	}
+	if metrics.IsProductEvent(event) {
+		if productID := s.encoder.Decode(value); productID != encode.INVALID {
+			ev.ProductID = sql.NullInt64{Int64: productID, Valid: true}
+		}
+	}
+
+	s.services.clientEvent.Record(ev)
+}
@@ -6,6 +6,7 @@ import (
 
 	compcustomer "cchoice/cmd/web/components/customers"
 	"cchoice/cmd/web/models"
+	"cchoice/internal/cart"
 	"cchoice/internal/enums"
 	"cchoice/internal/errs"
 	"cchoice/internal/httputil"
@@ -46,6 +47,12 @@ func AddCustomerHandlers(s *Server, r chi.Router) {
 	r.With(s.requireCustomerAuth).Get("/customer/quotations/table", s.customerQuotationsListTableHandler)
 	r.With(s.requireCustomerAuth).Get("/customer/quotations/{id}", s.customerQuotationDetailPageHandler)
 
+	r.With(s.requireCustomerAuth).Post("/customer/reviews/{productID}", s.customerProductReviewCreateHandler)
+
+	r.With(s.requireCustomerAuth).Get("/customer/wishlist", s.customerWishlistPageHandler)
+	r.With(s.requireCustomerAuth).Post("/customer/wishlist/{productID}", s.customerWishlistAddHandler)
+	r.With(s.requireCustomerAuth).Delete("/customer/wishlist/{productID}", s.customerWishlistRemoveHandler)
+
 	r.With(s.requireCustomerAuth).Get("/customer/profile", s.customerProfileHandler)
 	r.With(s.requireCustomerAuth).Get("/customer/orders", s.customerOrdersListPageHandler)
 	r.With(s.requireCustomerAuth).Get("/customer/orders/table", s.customerOrdersListTableHandler)
@@ -53,6 +60,8 @@ func AddCustomerHandlers(s *Server, r chi.Router) {
 	r.With(s.requireCustomerAuth).Get("/customer/profile/edit", s.customerProfileEditFormHandler)
 	r.With(s.requireCustomerAuth).Patch("/customer/profile", s.customerProfileUpdateHandler)
 	r.With(s.requireCustomerAuth).Post("/customer/change-password", s.customerChangePasswordHandler)
+	r.With(s.requireCustomerAuth).Get("/customer/profile/sessions", s.customerProfileSessionsHandler)
+	r.With(s.requireCustomerAuth).Delete("/customer/profile/sessions", s.customerProfileSessionsSignOutOthersHandler)
 	r.With(s.requireCustomerAuth).Post("/customer/verify/send", s.customerVerifySendHandler)
 	r.Group(func(r chi.Router) {
 		r.Use(s.requireCustomerAuth, s.rateLimiter.Middleware)
@@ -105,14 +114,26 @@ func (s *Server) customerLoginHandler(w http.ResponseWriter, r *http.Request) {
 
 	s.sessionManager.Put(ctx, SessionCustomerID, s.encoder.Encode(customer.ID))
 	s.sessionManager.Put(ctx, SessionCustomerAccessID, 0)
+	s.registerSession(r, enums.USER_TYPE_CUSTOMER, s.encoder.Encode(customer.ID))
+	// A failed merge leaves the anonymous cart as it is, which is no reason to
+	// fail the login.
+	_ = s.attachCustomerCart(ctx, cart.MergeModeCombine)
 	redirectHX(w, r, utils.URL(portalPage))
 }
 
 func (s *Server) customerLogoutHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Customer Logout Handler]"
 	const page = "/customer"
 	ctx := r.Context()
+
+	if err := cart.ReleaseSession(ctx, s.dbRW, s.sessionManager.Token(ctx)); err != nil {
+		logs.LogCtx(ctx).Warn(logtag, zap.Error(err))
+	}
+	s.signOutSession(r)
 	s.sessionManager.Remove(ctx, SessionCustomerID)
 	s.sessionManager.Remove(ctx, SessionCustomerAccessID)
+	s.sessionManager.Remove(ctx, skCheckoutLineProductIDs)
+	s.sessionManager.Remove(ctx, skCheckedItems)
 	redirectHX(w, r, utils.URL(page))
 }
 
@@ -300,6 +321,7 @@ func (s *Server) customerChangePasswordHandler(w http.ResponseWriter, r *http.Re
 		redirectHX(w, r, utils.URLWithError(page, errs.ErrCustomerPasswordUpdateFailed.Error()))
 		return
 	}
+	s.revokeOtherSessions(r, enums.USER_TYPE_CUSTOMER, customerIDStr, enums.SESSION_REVOKE_REASON_PASSWORD_CHANGE)
 
 	redirectHX(w, r, utils.URLWithSuccess(page, "Password changed successfully"))
 }
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/enums"
+	"cchoice/internal/logs"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+// experimentBucketKey returns the visitor's bucket key, issuing one when the
+// visitor has none yet.
+func (s *Server) experimentBucketKey(w http.ResponseWriter, r *http.Request) string {
+	if c, err := r.Cookie(constants.ExperimentBucketCookie); err == nil && c.Value != "" {
+		return c.Value
+	}
+
+	key := utils.GenString(constants.ExperimentBucketKeyLength)
+	http.SetCookie(w, &http.Cookie{
+		Name:     constants.ExperimentBucketCookie,
+		Value:    key,
+		Path:     "/",
+		MaxAge:   int(constants.ExperimentBucketTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   s.useSSL,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return key
+}
+
+// existingBucketKey is for conversion hooks: a visitor without a bucket was
+// never exposed, so there is nothing to record.
+func existingBucketKey(r *http.Request) string {
+	c, err := r.Cookie(constants.ExperimentBucketCookie)
+	if err != nil {
+		return ""
+	}
+	return c.Value
+}
+
+// storefrontThemeCSS prefers the theme of the visitor's variant in a running
+// THEME experiment. Control variants fall through to the scheduled theme.
+func (s *Server) storefrontThemeCSS(ctx context.Context, bucketKey string, logtag string) string {
+	assignment, err := s.services.experiment.Assign(ctx, enums.EXPERIMENT_KIND_THEME, bucketKey)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("message", "failed to assign theme experiment"))
+	}
+	if assignment == nil || !assignment.HasTheme {
+		return s.activeThemeCSS(ctx, logtag)
+	}
+
+	theme, err := s.services.theme.GetThemeByID(ctx, assignment.ThemeID)
+	if err != nil || theme == nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.Int64("theme id", assignment.ThemeID))
+		return s.activeThemeCSS(ctx, logtag)
+	}
+	return themeCSS(ctx, theme, logtag)
+}
+
+// experimentPromoBanners returns the banners of the visitor's variant in a
+// running PROMO_BANNERS experiment. ok is false when the regular rotation
+// should be shown instead.
+func (s *Server) experimentPromoBanners(ctx context.Context, bucketKey string, logtag string) ([]models.PromoItem, bool) {
+	assignment, err := s.services.experiment.Assign(ctx, enums.EXPERIMENT_KIND_PROMO_BANNERS, bucketKey)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("message", "failed to assign promo banners experiment"))
+		return nil, false
+	}
+	if assignment == nil {
+		return nil, false
+	}
+
+	promos, err := s.services.promo.GetVariantPromos(ctx, assignment.VariantID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.Int64("variant id", assignment.VariantID))
+		return nil, false
+	}
+	if len(promos) == 0 {
+		return nil, false
+	}
+
+	banners := make([]models.PromoItem, 0, len(promos))
+	for _, p := range promos {
+		banners = append(banners, models.PromoItem{
+			ID:          s.encoder.Encode(p.ID),
+			Title:       p.Title,
+			Description: p.Description,
+			MediaURL:    p.MediaURL,
+			Type:        p.Type,
+			BannerOnly:  p.BannerOnly.Bool,
+		})
+	}
+	return banners, true
+}
+
+func (s *Server) markExperimentAddToCart(r *http.Request) {
+	const logtag = "[Experiment Add To Cart]"
+	key := existingBucketKey(r)
+	if key == "" {
+		return
+	}
+	go func() {
+		if err := s.services.experiment.MarkAddToCart(context.Background(), key); err != nil {
+			logs.Log().Warn(logtag, zap.Error(err))
+		}
+	}()
+}
+
+func (s *Server) attachExperimentCheckout(r *http.Request, checkoutID int64) {
+	const logtag = "[Experiment Attach Checkout]"
+	ctx := r.Context()
+	if err := s.services.experiment.AttachCheckout(ctx, existingBucketKey(r), checkoutID); err != nil {
+		logs.LogCtx(ctx).Warn(logtag, zap.Int64("checkout id", checkoutID), zap.Error(err))
+	}
+}
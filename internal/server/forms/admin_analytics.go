@@ -0,0 +1,7 @@
+package forms
+
+type AdminAnalyticsQuery struct {
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+	BrandID   string `form:"brand_id"`
+}
@@ -0,0 +1,18 @@
+package forms
+
+type AdminAttendanceCorrectionPath struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AdminStaffAttendanceCorrectionForm struct {
+	Date          string `form:"date" validate:"required"`
+	TimeIn        string `form:"time_in"`
+	TimeOut       string `form:"time_out"`
+	LunchBreakIn  string `form:"lunch_break_in"`
+	LunchBreakOut string `form:"lunch_break_out"`
+	Reason        string `form:"reason" validate:"required"`
+}
+
+type AdminAttendanceCorrectionsQuery struct {
+	Status string `form:"status"`
+}
@@ -0,0 +1,24 @@
+package forms
+
+type AdminExperimentPath struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AdminExperimentVariantPath struct {
+	ID        string `param:"id" validate:"required"`
+	VariantID string `param:"variant_id" validate:"required"`
+}
+
+type AdminExperimentForm struct {
+	Name      string `form:"name" validate:"required"`
+	Kind      string `form:"kind" validate:"required"`
+	StartDate string `form:"start_date" validate:"required"`
+	EndDate   string `form:"end_date" validate:"required"`
+}
+
+type AdminExperimentVariantForm struct {
+	Name     string   `form:"name" validate:"required"`
+	Weight   int64    `form:"weight" validate:"required,min=1"`
+	ThemeID  string   `form:"theme_id"`
+	PromoIDs []string `form:"promo_ids"`
+}
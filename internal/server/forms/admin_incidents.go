@@ -0,0 +1,38 @@
+package forms
+
+type AdminIncidentPath struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AdminIncidentsQuery struct {
+	Status string `form:"status"`
+}
+
+type AdminSuperuserIncidentForm struct {
+	StaffID      string `form:"staff_id" validate:"required"`
+	IncidentDate string `form:"incident_date" validate:"required"`
+	Title        string `form:"title" validate:"required"`
+	Description  string `form:"description" validate:"required"`
+}
+
+type AdminSuperuserIncidentNTEForm struct {
+	StaffID  string `form:"staff_id" validate:"required"`
+	Deadline string `form:"deadline" validate:"required"`
+	Message  string `form:"message"`
+}
+
+type AdminSuperuserIncidentDecisionForm struct {
+	StaffID       string `form:"staff_id" validate:"required"`
+	Decision      string `form:"decision" validate:"required"`
+	Notes         string `form:"notes"`
+	EffectiveFrom string `form:"effective_from"`
+	EffectiveTo   string `form:"effective_to"`
+}
+
+type AdminStaffIncidentResponseForm struct {
+	Response string `form:"response" validate:"required"`
+}
+
+type AdminSuperuserStaffHRFilePath struct {
+	ID string `param:"id" validate:"required"`
+}
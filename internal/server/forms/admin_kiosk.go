@@ -0,0 +1,29 @@
+package forms
+
+type AdminKioskScanQuery struct {
+	Token string `form:"token" validate:"required"`
+}
+
+type AdminKioskScanPunchForm struct {
+	Token     string `form:"token" validate:"required"`
+	PunchType string `form:"punch_type" validate:"required"`
+}
+
+type AdminKioskPinPunchForm struct {
+	StaffID   string `form:"staff_id" validate:"required"`
+	Pin       string `form:"pin" validate:"required"`
+	PunchType string `form:"punch_type" validate:"required"`
+}
+
+type AdminKioskPinForm struct {
+	Pin        string `form:"pin" validate:"required"`
+	ConfirmPin string `form:"confirm_pin" validate:"required"`
+}
+
+type AdminKioskPunchesQuery struct {
+	Date string `form:"date"`
+}
+
+type AdminKioskPunchPath struct {
+	ID string `param:"id" validate:"required"`
+}
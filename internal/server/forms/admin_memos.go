@@ -12,4 +12,7 @@ type AdminMemoForm struct {
 	StartDate string   `form:"start_date" validate:"required"`
 	EndDate   string   `form:"end_date" validate:"required"`
 	StaffIDs  []string `form:"staff_ids"`
+
+	RequiresAcknowledgement string `form:"requires_acknowledgement"`
+	AcknowledgeBy           string `form:"acknowledge_by"`
 }
@@ -0,0 +1,25 @@
+package forms
+
+type AdminPayrollRunPath struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AdminPayslipPath struct {
+	ID      string `param:"id" validate:"required"`
+	StaffID string `param:"staff_id" validate:"required"`
+}
+
+type AdminPayslipQuery struct {
+	Format string `form:"format"`
+}
+
+type AdminPayRateForm struct {
+	StaffID  string `form:"staff_id" validate:"required"`
+	RateType string `form:"rate_type" validate:"required"`
+	Rate     string `form:"rate" validate:"required"`
+}
+
+type AdminPayrollRunForm struct {
+	Month string `form:"month" validate:"required"`
+	Half  int    `form:"half" validate:"required,oneof=1 2"`
+}
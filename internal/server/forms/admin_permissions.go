@@ -0,0 +1,25 @@
+package forms
+
+type AdminPermissionGroupPath struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AdminPermissionStaffGroupPath struct {
+	ID      string `param:"id" validate:"required"`
+	GroupID string `param:"group_id" validate:"required"`
+}
+
+type AdminPermissionGroupForm struct {
+	Name        string `form:"name" validate:"required"`
+	Description string `form:"description"`
+}
+
+// Access is empty when the role is removed from the group.
+type AdminPermissionGroupRoleForm struct {
+	Role   string `form:"role" validate:"required"`
+	Access string `form:"access"`
+}
+
+type AdminPermissionStaffGroupForm struct {
+	GroupID string `form:"group_id" validate:"required"`
+}
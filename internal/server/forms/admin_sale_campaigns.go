@@ -0,0 +1,17 @@
+package forms
+
+type AdminSaleCampaignPath struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AdminSaleCampaignForm struct {
+	Name          string `form:"name" validate:"required"`
+	Selector      string `form:"selector" validate:"required"`
+	BrandID       string `form:"brand_id"`
+	Category      string `form:"category"`
+	Serials       string `form:"serials"`
+	DiscountType  string `form:"discount_type" validate:"required"`
+	DiscountValue int64  `form:"discount_value" validate:"required,min=1"`
+	StartsAt      string `form:"starts_at" validate:"required"`
+	EndsAt        string `form:"ends_at" validate:"required"`
+}
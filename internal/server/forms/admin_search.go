@@ -0,0 +1,20 @@
+package forms
+
+type AdminSearchQuery struct {
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+	Term      string `form:"term"`
+}
+
+type AdminSearchRulePath struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AdminSearchRuleForm struct {
+	SearchTerm    string `form:"search_term" validate:"required"`
+	Kind          string `form:"kind" validate:"required"`
+	ProductSerial string `form:"product_serial"`
+	CategoryID    string `form:"category_id"`
+	BrandID       string `form:"brand_id"`
+	Position      int64  `form:"position"`
+}
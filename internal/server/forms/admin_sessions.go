@@ -0,0 +1,10 @@
+package forms
+
+type AdminSessionPath struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AdminSessionUserPath struct {
+	UserType string `param:"user_type" validate:"required"`
+	ID       string `param:"id" validate:"required"`
+}
@@ -0,0 +1,41 @@
+package forms
+
+type AdminShiftStaffPath struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AdminShiftOverridePath struct {
+	ID         string `param:"id" validate:"required"`
+	OverrideID string `param:"override_id" validate:"required"`
+}
+
+type AdminShiftSwapPath struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AdminShiftRotationForm struct {
+	CycleWeeks int    `form:"cycle_weeks" validate:"required,min=1,max=4"`
+	AnchorDate string `form:"anchor_date" validate:"required"`
+}
+
+type AdminShiftDayForm struct {
+	Week    int    `form:"week" validate:"min=0"`
+	Weekday int    `form:"weekday" validate:"min=0,max=6"`
+	TimeIn  string `form:"time_in"`
+	TimeOut string `form:"time_out"`
+	RestDay string `form:"rest_day"`
+}
+
+type AdminShiftOverrideForm struct {
+	Date    string `form:"date" validate:"required"`
+	TimeIn  string `form:"time_in"`
+	TimeOut string `form:"time_out"`
+	RestDay string `form:"rest_day"`
+	Note    string `form:"note"`
+}
+
+type AdminStaffShiftSwapForm struct {
+	CounterpartID string `form:"counterpart_id" validate:"required"`
+	Date          string `form:"date" validate:"required"`
+	Reason        string `form:"reason" validate:"required"`
+}
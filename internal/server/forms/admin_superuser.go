@@ -23,3 +23,14 @@ type AdminSuperuserAttendanceReportQuery struct {
 type AdminSuperuserTimeOffPath struct {
 	ID string `param:"id" validate:"required"`
 }
+
+type AdminSuperuserLeaveCreditPath struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AdminSuperuserLeaveAdjustmentForm struct {
+	StaffID string `form:"staff_id" validate:"required"`
+	Type    string `form:"type" validate:"required"`
+	Days    string `form:"days" validate:"required"`
+	Note    string `form:"note" validate:"required"`
+}
@@ -13,3 +13,8 @@ type AdminTrackedLinkForm struct {
 	Campaign       string `form:"campaign"`
 	Status         string `form:"status"`
 }
+
+type AdminTrackedLinkAnalyticsQuery struct {
+	Days            int  `form:"days"`
+	IncludeFiltered bool `form:"include_filtered"`
+}
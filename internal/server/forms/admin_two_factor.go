@@ -0,0 +1,13 @@
+package forms
+
+// Method is empty for authenticator app codes. Remember is the "remember
+// this device" checkbox.
+type AdminTwoFactorVerifyForm struct {
+	Code     string `form:"code" validate:"required,max=32"`
+	Method   string `form:"method"`
+	Remember string `form:"remember"`
+}
+
+type AdminTwoFactorCodeForm struct {
+	Code string `form:"code" validate:"required,max=32"`
+}
@@ -11,6 +11,10 @@ type CartCheckoutLinePath struct {
 	CheckoutLineID string `param:"checkoutline_id" validate:"required"`
 }
 
+type CartResumeQuery struct {
+	Token string `form:"token" validate:"required"`
+}
+
 type CartSummaryQuery struct {
 	Data string `form:"data" validate:"required,oneof=summary_total"`
 }
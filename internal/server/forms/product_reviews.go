@@ -0,0 +1,29 @@
+package forms
+
+type ProductReviewsPath struct {
+	ProductID string `param:"productID" validate:"required"`
+}
+
+type ProductReviewsQuery struct {
+	Slug string `form:"slug"`
+}
+
+type CustomerProductReviewForm struct {
+	Slug   string `form:"slug" validate:"required"`
+	Rating int64  `form:"rating" validate:"required,min=1,max=5"`
+	Title  string `form:"title"`
+	Body   string `form:"body" validate:"required"`
+}
+
+type AdminProductReviewsListQuery struct {
+	Status string `form:"status"`
+	Page   int    `form:"page"`
+}
+
+type AdminProductReviewPath struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AdminProductReviewRejectForm struct {
+	Reason string `form:"reason" validate:"required"`
+}
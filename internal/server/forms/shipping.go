@@ -25,6 +25,7 @@ func (q ShippingAddressQuery) Validate() error {
 const ncrProvince = "National Capital Region (NCR)"
 
 type ShippingQuotationForm struct {
+	Email        string `form:"email"`
 	AddressLine1 string `form:"address_line1"`
 	AddressLine2 string `form:"address_line2"`
 	City         string `form:"city"`
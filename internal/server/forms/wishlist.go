@@ -0,0 +1,18 @@
+package forms
+
+type WishlistProductPath struct {
+	ProductID string `param:"productID" validate:"required"`
+}
+
+type WishlistSectionQuery struct {
+	Slug string `form:"slug"`
+}
+
+type StockSubscriptionForm struct {
+	Email string `form:"email" validate:"required,ph_email"`
+	Slug  string `form:"slug"`
+}
+
+type StockSubscriptionConfirmQuery struct {
+	Token string `form:"token" validate:"required"`
+}
@@ -31,6 +31,17 @@ func (si *ServerInstance) StartBackgroundJobs() {
 	if si.internal.thumbnailJobRunner != nil {
 		go si.internal.thumbnailJobRunner.Start(si.jobRunnerCtx)
 	}
+	go si.internal.services.saleCampaign.RunScheduler(si.jobRunnerCtx)
+	go si.internal.services.abandonedCart.RunScheduler(si.jobRunnerCtx)
+	go si.internal.services.leave.RunScheduler(si.jobRunnerCtx)
+	go si.internal.services.memo.RunScheduler(si.jobRunnerCtx)
+	go si.internal.services.clientEvent.RunScheduler(si.jobRunnerCtx)
+	if si.internal.backup != nil {
+		go si.internal.backup.RunScheduler(si.jobRunnerCtx, cfg.Backup.Interval)
+	}
+	if si.internal.replica != nil {
+		go si.internal.replica.Run(si.jobRunnerCtx, cfg.Replica.SyncInterval, cfg.Replica.SnapshotInterval)
+	}
 	logs.Log().Info("Background job runners started")
 }
 
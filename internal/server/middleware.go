@@ -6,11 +6,11 @@ import (
 	"cchoice/internal/enums"
 	"cchoice/internal/errs"
 	"cchoice/internal/metrics"
+	"cchoice/internal/rbac"
 	"cchoice/internal/utils"
 	"context"
 	"crypto/subtle"
 	"net/http"
-	"slices"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
@@ -158,6 +158,13 @@ func (s *Server) requireStaffAuth(next http.Handler) http.Handler {
 			return
 		}
 
+		if !s.checkSession(r, enums.USER_TYPE_STAFF, staffIDStr) {
+			s.sessionManager.Remove(ctx, SessionStaffID)
+			s.sessionManager.Remove(ctx, SessionStaffAccessID)
+			redirectHX(w, r, utils.URLWithError(page, errs.ErrSessionRevoked.Error()))
+			return
+		}
+
 		next.ServeHTTP(w, r)
 	})
 }
@@ -183,64 +190,80 @@ func (s *Server) requireSuperuserAuth(next http.Handler) http.Handler {
 func (s *Server) requireCustomerAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		const page = "/customer"
-		customerID := s.encoder.Decode(s.sessionManager.GetString(r.Context(), SessionCustomerID))
+		ctx := r.Context()
+		customerIDStr := s.sessionManager.GetString(ctx, SessionCustomerID)
+		customerID := s.encoder.Decode(customerIDStr)
 		if customerID == encode.INVALID {
 			redirectHX(w, r, utils.URLWithError(page, errs.ErrLoginRequired.Error()))
 			return
 		}
+		if !s.checkSession(r, enums.USER_TYPE_CUSTOMER, customerIDStr) {
+			s.sessionManager.Remove(ctx, SessionCustomerID)
+			s.sessionManager.Remove(ctx, SessionCustomerAccessID)
+			redirectHX(w, r, utils.URLWithError(page, errs.ErrSessionRevoked.Error()))
+			return
+		}
 		next.ServeHTTP(w, r)
 	})
 }
 
-func (s *Server) HasRole(ctx context.Context, role enums.StaffRole) bool {
+// HasPermission reports whether the logged in staff meets req. It is for
+// pages that show or hide actions; routes declare theirs through Permit.
+func (s *Server) HasPermission(ctx context.Context, req rbac.Requirement) bool {
 	staffIDStr := s.sessionManager.GetString(ctx, SessionStaffID)
 	if staffIDStr == "" {
 		return false
 	}
 
-	staffID := s.encoder.Decode(staffIDStr)
-	if staffID == 0 {
-		return false
-	}
-
 	staff, err := s.services.staff.GetCurrentStaff(ctx, staffIDStr)
 	if err != nil {
 		return false
 	}
 
 	if staff.UserType == enums.STAFF_USER_TYPE_SUPERUSER.String() {
-		return true
+		return req.Allows(true, nil)
 	}
 
-	roles, err := s.dbRO.GetQueries().GetStaffRolesByStaffID(ctx, staffID)
+	perms, err := s.services.role.GetPermissionsByStaffID(ctx, staffIDStr)
 	if err != nil {
 		return false
 	}
+	return req.Allows(false, perms)
+}
 
-	roleStr := role.String()
-	return slices.Contains(roles, roleStr)
+// permitHandler keeps the requirement next to the handler so the route table
+// can be audited with chi.Walk.
+type permitHandler struct {
+	s    *Server
+	req  rbac.Requirement
+	next http.Handler
 }
 
-func (s *Server) AllowRoles(roles ...enums.StaffRole) func(http.Handler) http.Handler {
+// Permit is how every /admin route states what it needs. Public routes pass
+// through, everything else goes through requireStaffAuth and then either
+// requireSuperuserAuth or the permission check.
+func (s *Server) Permit(req rbac.Requirement) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			const page = "/admin"
-			ctx := r.Context()
-			hasAccess := false
-
-			for _, role := range roles {
-				if s.HasRole(ctx, role) {
-					hasAccess = true
-					break
-				}
-			}
+		return &permitHandler{s: s, req: req, next: next}
+	}
+}
 
-			if !hasAccess {
-				redirectHX(w, r, utils.URLWithError(page, errs.ErrLoginRequired.Error()))
-				return
-			}
+func (h *permitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case h.req.IsPublic():
+		h.next.ServeHTTP(w, r)
+	case h.req.IsSuperuserOnly():
+		h.s.requireStaffAuth(h.s.requireSuperuserAuth(h.next)).ServeHTTP(w, r)
+	default:
+		h.s.requireStaffAuth(http.HandlerFunc(h.authorize)).ServeHTTP(w, r)
+	}
+}
 
-			next.ServeHTTP(w, r)
-		})
+func (h *permitHandler) authorize(w http.ResponseWriter, r *http.Request) {
+	const page = "/admin/staff"
+	if !h.s.HasPermission(r.Context(), h.req) {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrRoleForbidden.Error()))
+		return
 	}
+	h.next.ServeHTTP(w, r)
 }
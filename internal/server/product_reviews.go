@@ -0,0 +1,177 @@
+package server
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	compproduct "cchoice/cmd/web/components/product"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/services"
+	"cchoice/internal/utils"
+)
+
+func (s *Server) productReviewsHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Product Reviews Handler]"
+	ctx := r.Context()
+
+	var p forms.ProductReviewsPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		http.Error(w, errs.ErrInvalidParams.Error(), http.StatusBadRequest)
+		return
+	}
+	productID, err := httputil.RequireEncodedID(s.encoder, p.ProductID)
+	if err != nil {
+		http.Error(w, errs.ErrInvalidParams.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var q forms.ProductReviewsQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		http.Error(w, errs.ErrInvalidParams.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rating, err := s.services.productReview.GetRating(ctx, productID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("product id", productID))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	serviceReviews, err := s.services.productReview.GetApprovedForProduct(ctx, productID, constants.ProductReviewsPageLimit)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("product id", productID))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	customerID := s.sessionManager.GetString(ctx, SessionCustomerID)
+	eligibility, err := s.services.productReview.GetEligibility(ctx, customerID, productID)
+	if err != nil {
+		logs.LogCtx(ctx).Warn(logtag, zap.Error(err), zap.String("product id", productID))
+	}
+
+	reviews := make([]models.ProductReviewItem, 0, len(serviceReviews))
+	for _, review := range serviceReviews {
+		reviews = append(reviews, models.ProductReviewItem{
+			ID:           review.ID,
+			ReviewerName: review.ReviewerName,
+			Rating:       review.Rating,
+			Title:        review.Title,
+			Body:         review.Body,
+			PhotoURLs:    review.PhotoURLs,
+			CreatedAt:    review.CreatedAt,
+		})
+	}
+
+	data := models.ProductReviewsSectionData{
+		ProductID:       productID,
+		Slug:            q.Slug,
+		RatingAverage:   rating.Average,
+		RatingCount:     rating.Count,
+		Reviews:         reviews,
+		IsLoggedIn:      customerID != "",
+		CanReview:       eligibility.CanReview && q.Slug != "",
+		AlreadyReviewed: eligibility.AlreadyReviewed,
+	}
+
+	if err := compproduct.ProductReviews(data).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("product id", productID))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) customerProductReviewCreateHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Customer Product Review Create Handler]"
+	ctx := r.Context()
+	page := "/"
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(errs.ErrInvalidParams)))
+		return
+	}
+
+	var p forms.ProductReviewsPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	productID, err := httputil.RequireEncodedID(s.encoder, p.ProductID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	var f forms.CustomerProductReviewForm
+	if err := httputil.BindMultipartForm(r, &f); err != nil {
+		if f.Slug != "" {
+			page = "/product/" + f.Slug
+		}
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	page = "/product/" + f.Slug
+
+	headers := r.MultipartForm.File["photos"]
+	if err := services.ValidateProductReviewInput(f.Rating, f.Title, f.Body, len(headers)); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	customerID := s.sessionManager.GetString(ctx, SessionCustomerID)
+	eligibility, err := s.services.productReview.GetEligibility(ctx, customerID, productID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInternalServer.Error()))
+		return
+	}
+	if eligibility.AlreadyReviewed {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrProductReviewAlreadyExists.Error()))
+		return
+	}
+	if !eligibility.CanReview {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrProductReviewNotEligible.Error()))
+		return
+	}
+
+	photos := make([]services.ProductReviewPhoto, 0, len(headers))
+	for _, header := range headers {
+		file, err := header.Open()
+		if err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+			redirectHX(w, r, utils.URLWithError(page, errs.ErrFileRead.Error()))
+			return
+		}
+
+		filename := s.services.image.GenerateFilename(
+			enums.IMAGE_PREFIX_REVIEW_IMAGE,
+			filepath.Ext(header.Filename),
+			productID,
+		)
+		url, err := s.services.image.UploadReviewPhoto(ctx, productID, filename, file, header.Header.Get("Content-Type"))
+		file.Close()
+		if err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+			redirectHX(w, r, utils.URLWithError(page, err.Error()))
+			return
+		}
+		photos = append(photos, services.ProductReviewPhoto{Path: filename, URL: url})
+	}
+
+	if _, err := s.services.productReview.Create(ctx, customerID, productID, f.Rating, f.Title, f.Body, photos); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Thank you! Your review will be published once approved."))
+}
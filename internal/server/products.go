@@ -19,6 +19,13 @@ import (
 func AddProductHandlers(s *Server, r chi.Router) {
 	r.Get("/product/{slug}/related", s.productRelatedProductsHandler)
 	r.Get("/product/{slug}", s.productPageHandler)
+	r.Get("/product-reviews/{productID}", s.productReviewsHandler)
+	r.Get("/product-wishlist/{productID}", s.productWishlistHandler)
+	r.Get("/stock-alerts/confirm", s.stockSubscriptionConfirmHandler)
+	r.Group(func(r chi.Router) {
+		r.Use(s.rateLimiter.Middleware)
+		r.Post("/stock-alerts/{productID}", s.stockSubscriptionCreateHandler)
+	})
 }
 
 func (s *Server) productPageHandler(w http.ResponseWriter, r *http.Request) {
@@ -2,8 +2,10 @@ package server
 
 import (
 	"cmp"
+	"database/sql"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"path/filepath"
 	"strings"
@@ -16,7 +18,6 @@ import (
 	"cchoice/cmd/web/models"
 	"cchoice/internal/conf"
 	"cchoice/internal/constants"
-	"cchoice/internal/database/queries"
 	"cchoice/internal/enums"
 	"cchoice/internal/errs"
 	"cchoice/internal/httputil"
@@ -402,23 +403,29 @@ func (s *Server) indexHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	bucketKey := s.experimentBucketKey(w, r)
+
 	var promoBanners []models.PromoItem
 	if conf.Conf().Settings.ShowPromoBanners {
-		activePromos, err := s.services.promo.GetActivePromos(ctx)
-		if err != nil {
-			logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("message", "failed to get active promos"))
-		}
-
-		promoBanners = make([]models.PromoItem, 0, len(activePromos))
-		for _, p := range activePromos {
-			promoBanners = append(promoBanners, models.PromoItem{
-				ID:          s.encoder.Encode(p.ID),
-				Title:       p.Title,
-				Description: p.Description,
-				MediaURL:    p.MediaURL,
-				Type:        p.Type,
-				BannerOnly:  p.BannerOnly.Bool,
-			})
+		if banners, ok := s.experimentPromoBanners(ctx, bucketKey, logtag); ok {
+			promoBanners = banners
+		} else {
+			activePromos, err := s.services.promo.GetActivePromos(ctx)
+			if err != nil {
+				logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("message", "failed to get active promos"))
+			}
+
+			promoBanners = make([]models.PromoItem, 0, len(activePromos))
+			for _, p := range activePromos {
+				promoBanners = append(promoBanners, models.PromoItem{
+					ID:          s.encoder.Encode(p.ID),
+					Title:       p.Title,
+					Description: p.Description,
+					MediaURL:    p.MediaURL,
+					Type:        p.Type,
+					BannerOnly:  p.BannerOnly.Bool,
+				})
+			}
 		}
 	}
 
@@ -446,7 +453,7 @@ func (s *Server) indexHandler(w http.ResponseWriter, r *http.Request) {
 		RandomSaleProduct: randomSaleProduct,
 		ActivePromos:      promoBanners,
 		Filters:           filters,
-		ThemeCSS:          s.activeThemeCSS(ctx, logtag),
+		ThemeCSS:          s.storefrontThemeCSS(ctx, bucketKey, logtag),
 	}
 
 	if err := compshop.HomePage(homePageData).Render(ctx, w); err != nil {
@@ -538,7 +545,12 @@ func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
 	const logtag = "[Health Handler]"
 	ctx := r.Context()
 
-	jsonResp, err := json.Marshal(s.dbRO.Health())
+	health := s.dbRO.Health()
+	if s.replica != nil {
+		maps.Copy(health, s.replica.Health())
+	}
+
+	jsonResp, err := json.Marshal(health)
 	if err != nil {
 		logs.LogCtx(ctx).Error(
 			logtag,
@@ -701,13 +713,17 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	searchQuery := cmp.Or(f.Search, f.SearchMobile)
 
-	products, err := s.dbRO.GetQueries().GetProductsBySearchQuery(
-		ctx,
-		queries.GetProductsBySearchQueryParams{
-			Name:  searchQuery,
-			Limit: constants.MaxSearchShowResults,
-		},
-	)
+	if redirectPath := s.searchRedirectPath(ctx, searchQuery); redirectPath != "" {
+		if err := compsearch.SearchRedirect(searchQuery, redirectPath).Render(ctx, w); err != nil {
+			logs.LogCtx(ctx).Error(
+				logtag,
+				zap.Error(err),
+			)
+		}
+		return
+	}
+
+	products, err := s.searchProducts(r, searchQuery, constants.MaxSearchShowResults, 0)
 	if err != nil || len(products) == 0 {
 		logs.LogCtx(ctx).Error(
 			logtag,
@@ -726,9 +742,6 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 
 	productResults := make([]models.SearchResultProduct, 0, len(products))
 	for i := range products {
-		if strings.HasSuffix(products[i].ThumbnailPath, constants.EmptyImageFilename) {
-			continue
-		}
 		productResults = append(productResults, models.ToSearchResultProduct(s.encoder, s.GetCDNURL, products[i]))
 	}
 
@@ -803,6 +816,7 @@ func (s *Server) metricsEventHandler(w http.ResponseWriter, r *http.Request) {
 	)
 
 	metrics.ClientEvent.ClientEventHit(event, value)
+	s.recordClientEvent(r, event, q.Value, sql.NullInt64{})
 	w.WriteHeader(http.StatusNoContent)
 }
 
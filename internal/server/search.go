@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+	"database/sql"
 	"net/http"
 	"strings"
 
@@ -11,7 +13,9 @@ import (
 	"cchoice/internal/errs"
 	"cchoice/internal/httputil"
 	"cchoice/internal/logs"
+	"cchoice/internal/metrics"
 	"cchoice/internal/server/forms"
+	"cchoice/internal/services"
 	"cchoice/internal/utils"
 
 	"github.com/go-chi/chi/v5"
@@ -35,6 +39,11 @@ func (s *Server) searchPageHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if redirectPath := s.searchRedirectPath(ctx, req.Q); redirectPath != "" {
+		http.Redirect(w, r, redirectPath, http.StatusSeeOther)
+		return
+	}
+
 	if err := compshop.SearchPage(models.SearchPageData{Query: req.Q}).Render(ctx, w); err != nil {
 		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -57,18 +66,13 @@ func (s *Server) searchProductsHandler(w http.ResponseWriter, r *http.Request) {
 	limit := constants.DefaultLimitSearchResultsPage
 	offset := page * limit
 
-	rows, err := s.dbRO.GetQueries().GetProductsBySearchQueryPaginated(ctx, queries.GetProductsBySearchQueryPaginatedParams{
-		Name:   req.Q,
-		Limit:  int64(limit),
-		Offset: int64(offset),
-	})
+	validRows, err := s.searchProducts(r, req.Q, limit, offset)
 	if err != nil {
 		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("query", req.Q))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	validRows := filterSearchPaginatedRows(rows)
 	if len(validRows) == 0 {
 		if page == 0 {
 			if err := compshop.SearchNoResults(req.Q).Render(ctx, w); err != nil {
@@ -135,6 +139,38 @@ func (s *Server) searchRelatedProductsHandler(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// searchRedirectPath returns where a redirect rule sends the query, or "" to
+// search as usual.
+func (s *Server) searchRedirectPath(ctx context.Context, q string) string {
+	redirectPath, err := s.services.searchMerchandising.GetRedirectPath(ctx, q)
+	if err != nil {
+		logs.LogCtx(ctx).Error("[Search Redirect Path]", zap.Error(err), zap.String("query", q))
+		return ""
+	}
+	return redirectPath
+}
+
+// searchProducts is the storefront search behind both the search page and the
+// search bar: pinned and boosted products come first, and the first page of
+// results is recorded with its count for the search insights.
+func (s *Server) searchProducts(r *http.Request, q string, limit, offset int) ([]queries.GetProductsBySearchQueryPaginatedRow, error) {
+	rows, err := s.dbRO.GetQueries().GetProductsBySearchQueryPaginated(r.Context(), queries.GetProductsBySearchQueryPaginatedParams{
+		SearchTerm: services.NormalizeSearchTerm(q),
+		Name:       q,
+		Limit:      int64(limit),
+		Offset:     int64(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	validRows := filterSearchPaginatedRows(rows)
+	if offset == 0 {
+		s.recordClientEvent(r, metrics.EventSearchResults, q, sql.NullInt64{Int64: int64(len(validRows)), Valid: true})
+	}
+	return validRows, nil
+}
+
 func filterSearchPaginatedRows(rows []queries.GetProductsBySearchQueryPaginatedRow) []queries.GetProductsBySearchQueryPaginatedRow {
 	valid := make([]queries.GetProductsBySearchQueryPaginatedRow, 0, len(rows))
 	for _, row := range rows {
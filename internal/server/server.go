@@ -18,6 +18,7 @@ import (
 	"golang.org/x/sync/singleflight"
 	"golang.org/x/time/rate"
 
+	"cchoice/internal/backup"
 	"cchoice/internal/conf"
 	"cchoice/internal/constants"
 	"cchoice/internal/database"
@@ -39,33 +40,50 @@ import (
 )
 
 type Services struct {
-	attendance        *services.AttendanceService
-	brand             *services.BrandService
-	cpoint            *services.CPointService
-	cpointToken       *services.CPointTokenService
-	customer          *services.CustomerService
-	customerOTP       *services.CustomerOTPService
-	export            *services.ExportService
-	productBulkImport *services.ProductBulkImportService
-	passwordReset     *services.PasswordResetService
-	holiday           *services.HolidayService
-	location          *services.LocationService
-	memo              *services.MemoService
-	product           *services.ProductService
-	productCategory   *services.ProductCategoryService
-	productInventory  *services.ProductInventoryService
-	image             *services.ImageService
-	promo             *services.PromoService
-	qr                *services.QRService
-	quotation         *services.QuotationService
-	report            *services.ReportService
-	role              *services.RoleService
-	staff             *services.StaffService
-	staffLog          *services.StaffLogsService
-	theme             *services.ThemeService
-	trackedLink       *services.TrackedLinkService
-	order             *services.OrderService
-	all               []services.IService
+	abandonedCart        *services.AbandonedCartService
+	analytics            *services.AnalyticsService
+	attendance           *services.AttendanceService
+	attendanceCorrection *services.AttendanceCorrectionService
+	brand                *services.BrandService
+	clientEvent          *services.ClientEventService
+	cpoint               *services.CPointService
+	cpointToken          *services.CPointTokenService
+	customer             *services.CustomerService
+	customerOTP          *services.CustomerOTPService
+	experiment           *services.ExperimentService
+	export               *services.ExportService
+	productBulkImport    *services.ProductBulkImportService
+	passwordReset        *services.PasswordResetService
+	payroll              *services.PayrollService
+	holiday              *services.HolidayService
+	incident             *services.IncidentService
+	kiosk                *services.KioskService
+	kioskToken           *services.KioskTokenService
+	leave                *services.LeaveService
+	location             *services.LocationService
+	memo                 *services.MemoService
+	product              *services.ProductService
+	productCategory      *services.ProductCategoryService
+	productInventory     *services.ProductInventoryService
+	productReview        *services.ProductReviewService
+	image                *services.ImageService
+	promo                *services.PromoService
+	qr                   *services.QRService
+	quotation            *services.QuotationService
+	saleCampaign         *services.SaleCampaignService
+	searchMerchandising  *services.SearchMerchandisingService
+	session              *services.SessionService
+	shift                *services.ShiftService
+	report               *services.ReportService
+	role                 *services.RoleService
+	staff                *services.StaffService
+	staffLog             *services.StaffLogsService
+	staffTwoFactor       *services.StaffTwoFactorService
+	theme                *services.ThemeService
+	trackedLink          *services.TrackedLinkService
+	wishlist             *services.WishlistService
+	order                *services.OrderService
+	all                  []services.IService
 }
 
 type Server struct {
@@ -85,6 +103,8 @@ type Server struct {
 	sessionManager     *scs.SessionManager
 	mailJobRunner      *jobs.EmailJobRunner
 	thumbnailJobRunner *jobs.ThumbnailJobRunner
+	backup             *backup.Backup
+	replica            *backup.Replica
 	rateLimiter        *middleware.RateLimiter
 	address            string
 	services           Services
@@ -143,6 +163,8 @@ func NewServer() *ServerInstance {
 	var geocoder geocoding.IGeocoder
 	var thumbnailService *services.ThumbnailService
 	var thumbnailJobRunner *jobs.ThumbnailJobRunner
+	var dbBackup *backup.Backup
+	var dbReplica *backup.Replica
 
 	if cfg.IsWeb() {
 		objStorage, productImageFS = mustInitStorageProvider()
@@ -158,10 +180,31 @@ func NewServer() *ServerInstance {
 		thumbnailService = services.NewThumbnailService(objStorage)
 		thumbnailJobRunner = jobs.NewThumbnailJobRunner(dbRW.GetDB(), dbRO, dbRW, thumbnailService)
 	}
-	if cfg.IsProd() || cfg.Test.LocalOTP || cfg.Test.LocalForgotPassword {
+	if cfg.IsProd() || cfg.Test.LocalOTP || cfg.Test.LocalForgotPassword || cfg.Test.LocalWishlistEmail || cfg.Test.LocalAbandonedCart {
 		mailService = mustInitMailService()
 		emailJobRunner = jobs.NewEmailJobRunner(dbRW.GetDB(), dbRO, dbRW, mailService)
 	}
+	if (cfg.Backup.Enabled || cfg.Replica.Enabled) && dbRW.Driver() != database.DB_DRIVER_SQLITE3 {
+		panic(fmt.Sprintf("backups and replication need the sqlite3 driver, not %s", dbRW.Driver()))
+	}
+	if cfg.Backup.Enabled {
+		backupStorage, err := backup.NewStorage()
+		if err != nil {
+			panic(err)
+		}
+		dbBackup = backup.New(dbRW.GetDB(), backupStorage, cfg.Backup.Prefix, cfg.Backup.KeepLast)
+	}
+	if cfg.Replica.Enabled {
+		dbPath, err := backup.DBPath(cfg.DBURL)
+		if err != nil {
+			panic(err)
+		}
+		replicaStorage, err := backup.NewStorage()
+		if err != nil {
+			panic(err)
+		}
+		dbReplica = backup.NewReplica(dbPath, replicaStorage, cfg.Replica.Prefix, cfg.Replica.CheckpointPages, cfg.Replica.KeepGenerations)
+	}
 
 	newServer := &Server{
 		address:            cfg.Server.Address,
@@ -182,6 +225,8 @@ func NewServer() *ServerInstance {
 		mailJobRunner:      emailJobRunner,
 		thumbnailService:   thumbnailService,
 		thumbnailJobRunner: thumbnailJobRunner,
+		backup:             dbBackup,
+		replica:            dbReplica,
 		useHTTP2:           cfg.Server.UseHTTP2,
 		useSSL:             cfg.Server.UseSSL,
 		rateLimiter: middleware.NewRateLimiterWithDebug(
@@ -193,70 +238,112 @@ func NewServer() *ServerInstance {
 	}
 
 	staffLogService := services.NewStaffLogsService(newServer.encoder, newServer.dbRO, newServer.dbRW)
+	sessionService := services.NewSessionService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService)
 	cpointTokenService := services.NewCPointTokenService(cfg.CPointHMACSecret)
 	holidayService := services.NewHolidayService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService)
-	attendanceService := services.NewAttendanceService(newServer.encoder, newServer.dbRO, newServer.dbRW, holidayService, staffLogService)
-	productInventoryService := services.NewProductInventoryService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService)
+	shiftService := services.NewShiftService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService)
+	leaveService := services.NewLeaveService(newServer.encoder, newServer.dbRO, newServer.dbRW, holidayService, shiftService, staffLogService)
+	attendanceService := services.NewAttendanceService(newServer.encoder, newServer.dbRO, newServer.dbRW, holidayService, leaveService, shiftService, staffLogService)
+	kioskTokenService := services.NewKioskTokenService(cfg.KioskHMACSecret)
+	attendanceCorrectionService := services.NewAttendanceCorrectionService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService)
+	memoService := services.NewMemoService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService, emailJobRunner)
+	wishlistService := services.NewWishlistService(newServer.encoder, newServer.dbRO, newServer.dbRW, emailJobRunner)
+	productInventoryService := services.NewProductInventoryService(newServer.encoder, newServer.dbRO, newServer.dbRW, wishlistService, staffLogService)
 	productCategoryService := services.NewProductCategoryService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService)
-	productService := services.NewProductService(newServer.encoder, newServer.dbRO, newServer.dbRW, newServer.GetCDNURL, productInventoryService, staffLogService)
+	productReviewService := services.NewProductReviewService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService)
+	productService := services.NewProductService(newServer.encoder, newServer.dbRO, newServer.dbRW, newServer.GetCDNURL, productInventoryService, productReviewService, wishlistService, staffLogService)
 	exportService := services.NewExportService(productService, staffLogService)
 	productBulkImportService := services.NewProductBulkImportService(productService, staffLogService)
 
 	newServer.services = Services{
-		attendance:        attendanceService,
-		brand:             services.NewBrandService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService),
-		customer:          services.NewCustomerService(newServer.encoder, newServer.dbRO, newServer.dbRW),
-		customerOTP:       services.NewCustomerOTPService(newServer.encoder, newServer.dbRO, newServer.dbRW, mailService, emailJobRunner),
-		export:            exportService,
-		productBulkImport: productBulkImportService,
-		passwordReset:     services.NewPasswordResetService(newServer.encoder, newServer.dbRO, newServer.dbRW, emailJobRunner, staffLogService),
-		cpoint:            services.NewCpointService(newServer.encoder, newServer.dbRO, newServer.dbRW, cpointTokenService, staffLogService),
-		cpointToken:       cpointTokenService,
-		holiday:           holidayService,
-		location:          services.NewLocationService(cfg.Settings.ShopLocation),
-		memo:              services.NewMemoService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService, emailJobRunner),
-		product:           productService,
-		productCategory:   productCategoryService,
-		productInventory:  productInventoryService,
-		image:             services.NewImageService(newServer.objectStorage, newServer.encoder, newServer.dbRO, newServer.dbRW),
-		promo:             services.NewPromoService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService),
-		qr:                services.NewQRService(newServer.cache),
-		quotation:         services.NewQuotationService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService),
-		report:            services.NewReportService(newServer.encoder, newServer.dbRO, attendanceService, holidayService, staffLogService),
-		role:              services.NewRoleService(newServer.encoder, newServer.dbRO, newServer.dbRW),
-		staff:             services.NewStaffService(newServer.encoder, newServer.dbRO, newServer.dbRW),
-		staffLog:          staffLogService,
-		theme:             services.NewThemeService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService),
-		trackedLink:       services.NewTrackedLinkService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService),
-		order:             services.NewOrderService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService, emailJobRunner),
+		abandonedCart:        services.NewAbandonedCartService(newServer.encoder, newServer.dbRO, newServer.dbRW, emailJobRunner),
+		analytics:            services.NewAnalyticsService(newServer.encoder, newServer.dbRO, staffLogService),
+		attendance:           attendanceService,
+		attendanceCorrection: attendanceCorrectionService,
+		brand:                services.NewBrandService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService),
+		clientEvent:          services.NewClientEventService(newServer.dbRO, newServer.dbRW, conf.Conf().Events.BufferSize),
+		customer:             services.NewCustomerService(newServer.encoder, newServer.dbRO, newServer.dbRW),
+		customerOTP:          services.NewCustomerOTPService(newServer.encoder, newServer.dbRO, newServer.dbRW, mailService, emailJobRunner),
+		experiment:           services.NewExperimentService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService),
+		export:               exportService,
+		productBulkImport:    productBulkImportService,
+		passwordReset:        services.NewPasswordResetService(newServer.encoder, newServer.dbRO, newServer.dbRW, emailJobRunner, sessionService, staffLogService),
+		payroll:              services.NewPayrollService(newServer.encoder, newServer.dbRO, newServer.dbRW, attendanceService, holidayService, shiftService, staffLogService),
+		cpoint:               services.NewCpointService(newServer.encoder, newServer.dbRO, newServer.dbRW, cpointTokenService, staffLogService),
+		cpointToken:          cpointTokenService,
+		holiday:              holidayService,
+		incident:             services.NewIncidentService(newServer.encoder, newServer.dbRO, newServer.dbRW, memoService, staffLogService),
+		kiosk:                services.NewKioskService(newServer.encoder, newServer.dbRO, newServer.dbRW, kioskTokenService, attendanceService, newServer.objectStorage, staffLogService),
+		kioskToken:           kioskTokenService,
+		leave:                leaveService,
+		location:             services.NewLocationService(cfg.Settings.ShopLocation),
+		memo:                 memoService,
+		product:              productService,
+		productCategory:      productCategoryService,
+		productInventory:     productInventoryService,
+		productReview:        productReviewService,
+		image:                services.NewImageService(newServer.objectStorage, newServer.encoder, newServer.dbRO, newServer.dbRW),
+		promo:                services.NewPromoService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService),
+		qr:                   services.NewQRService(newServer.cache),
+		quotation:            services.NewQuotationService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService),
+		saleCampaign:         services.NewSaleCampaignService(newServer.encoder, newServer.dbRO, newServer.dbRW, wishlistService, staffLogService),
+		searchMerchandising:  services.NewSearchMerchandisingService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService),
+		session:              sessionService,
+		shift:                shiftService,
+		report:               services.NewReportService(newServer.encoder, newServer.dbRO, attendanceService, holidayService, shiftService, attendanceCorrectionService, staffLogService),
+		role:                 services.NewRoleService(newServer.encoder, newServer.dbRO, newServer.dbRW),
+		staff:                services.NewStaffService(newServer.encoder, newServer.dbRO, newServer.dbRW),
+		staffLog:             staffLogService,
+		staffTwoFactor:       services.NewStaffTwoFactorService(newServer.encoder, newServer.dbRO, newServer.dbRW, emailJobRunner, staffLogService),
+		theme:                services.NewThemeService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService),
+		trackedLink:          services.NewTrackedLinkService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService),
+		wishlist:             wishlistService,
+		order:                services.NewOrderService(newServer.encoder, newServer.dbRO, newServer.dbRW, staffLogService, emailJobRunner),
 	}
 
 	newServer.services.all = []services.IService{
+		newServer.services.abandonedCart,
+		newServer.services.analytics,
 		newServer.services.attendance,
+		newServer.services.attendanceCorrection,
 		newServer.services.brand,
+		newServer.services.clientEvent,
 		newServer.services.cpoint,
 		newServer.services.cpointToken,
 		newServer.services.customer,
 		newServer.services.customerOTP,
+		newServer.services.experiment,
 		newServer.services.export,
 		newServer.services.productBulkImport,
 		newServer.services.holiday,
 		newServer.services.image,
+		newServer.services.incident,
+		newServer.services.kiosk,
+		newServer.services.kioskToken,
+		newServer.services.leave,
 		newServer.services.location,
 		newServer.services.memo,
 		newServer.services.passwordReset,
+		newServer.services.payroll,
 		newServer.services.product,
 		newServer.services.productCategory,
 		newServer.services.productInventory,
+		newServer.services.productReview,
 		newServer.services.promo,
 		newServer.services.qr,
 		newServer.services.quotation,
+		newServer.services.saleCampaign,
+		newServer.services.searchMerchandising,
+		newServer.services.session,
+		newServer.services.shift,
 		newServer.services.report,
 		newServer.services.role,
 		newServer.services.staff,
 		newServer.services.staffLog,
+		newServer.services.staffTwoFactor,
 		newServer.services.theme,
 		newServer.services.trackedLink,
+		newServer.services.wishlist,
 		newServer.services.order,
 	}
 
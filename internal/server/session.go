@@ -26,6 +26,8 @@ const (
 	skLocationLng            = "location_lng"
 	skHomePageFilters        = "home_page_filters"
 	skProductImportPreview   = "product_import_preview"
+	skRecoveredCheckoutID    = "recovered_checkout_id"
+	skSessionKey             = "session_key"
 )
 
 func init() {
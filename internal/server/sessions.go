@@ -0,0 +1,215 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	compadmin "cchoice/cmd/web/components/admin"
+	compcustomer "cchoice/cmd/web/components/customers"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/middleware"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+const maxSessionListSize = 500
+
+// registerSession adds the login to the session registry. A failure is only
+// logged: the next checkSession signs the browser out again.
+func (s *Server) registerSession(r *http.Request, userType enums.UserType, userID string) {
+	const logtag = "[Register Session]"
+	ctx := r.Context()
+
+	key, err := s.services.session.Register(ctx, userType, userID, s.requestUserAgentID(r), middleware.ClientIP(r))
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Stringer("user type", userType), zap.String("user id", userID), zap.Error(err))
+		return
+	}
+	s.sessionManager.Put(ctx, skSessionKey, key)
+}
+
+// checkSession reports whether the registry still holds the session. Only a
+// revoked or unknown session fails it; a database error is logged and let
+// through so an outage does not sign everybody out.
+func (s *Server) checkSession(r *http.Request, userType enums.UserType, userID string) bool {
+	const logtag = "[Check Session]"
+	ctx := r.Context()
+
+	err := s.services.session.Check(ctx, userType, userID, s.sessionManager.GetString(ctx, skSessionKey), middleware.ClientIP(r))
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, errs.ErrSessionRevoked) {
+		s.sessionManager.Remove(ctx, skSessionKey)
+		return false
+	}
+	logs.LogCtx(ctx).Error(logtag, zap.Stringer("user type", userType), zap.String("user id", userID), zap.Error(err))
+	return true
+}
+
+func (s *Server) signOutSession(r *http.Request) {
+	const logtag = "[Sign Out Session]"
+	ctx := r.Context()
+
+	if err := s.services.session.SignOut(ctx, s.sessionManager.GetString(ctx, skSessionKey)); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+	}
+	s.sessionManager.Remove(ctx, skSessionKey)
+}
+
+// revokeOtherSessions is called after a password change, keeping only the
+// browser that made it.
+func (s *Server) revokeOtherSessions(r *http.Request, userType enums.UserType, userID string, reason enums.SessionRevokeReason) {
+	const logtag = "[Revoke Other Sessions]"
+	ctx := r.Context()
+
+	if _, err := s.services.session.RevokeOthers(ctx, userType, userID, s.sessionManager.GetString(ctx, skSessionKey), reason); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Stringer("user type", userType), zap.String("user id", userID), zap.Error(err))
+	}
+}
+
+func (s *Server) adminProfileSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Profile Sessions Handler]"
+	ctx := r.Context()
+
+	staffID := s.sessionManager.GetString(ctx, SessionStaffID)
+	sessions, err := s.services.session.ListByUser(ctx, enums.USER_TYPE_STAFF, staffID, s.sessionManager.GetString(ctx, skSessionKey))
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := compadmin.AdminProfileSessions(sessions).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminProfileSessionsSignOutOthersHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Profile Sessions Sign Out Others Handler]"
+	const page = "/admin/profile"
+	ctx := r.Context()
+
+	staffID := s.sessionManager.GetString(ctx, SessionStaffID)
+	if _, err := s.services.session.RevokeOthers(ctx, enums.USER_TYPE_STAFF, staffID, s.sessionManager.GetString(ctx, skSessionKey), enums.SESSION_REVOKE_REASON_SIGN_OUT_OTHERS); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("staff id", staffID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Signed out of other devices"))
+}
+
+func (s *Server) customerProfileSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Customer Profile Sessions Handler]"
+	ctx := r.Context()
+
+	customerID := s.sessionManager.GetString(ctx, SessionCustomerID)
+	sessions, err := s.services.session.ListByUser(ctx, enums.USER_TYPE_CUSTOMER, customerID, s.sessionManager.GetString(ctx, skSessionKey))
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("customer id", customerID), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := compcustomer.CustomerProfileSessions(sessions).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) customerProfileSessionsSignOutOthersHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Customer Profile Sessions Sign Out Others Handler]"
+	const page = "/customer/profile"
+	ctx := r.Context()
+
+	customerID := s.sessionManager.GetString(ctx, SessionCustomerID)
+	if _, err := s.services.session.RevokeOthers(ctx, enums.USER_TYPE_CUSTOMER, customerID, s.sessionManager.GetString(ctx, skSessionKey), enums.SESSION_REVOKE_REASON_SIGN_OUT_OTHERS); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("customer id", customerID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Signed out of other devices"))
+}
+
+func (s *Server) adminSuperuserSessionsPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Sessions Page Handler]"
+	const page = "/admin/superuser"
+	ctx := r.Context()
+
+	sessions, err := s.services.session.ListAll(ctx, maxSessionListSize)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	if err := compadmin.AdminSuperuserSessionsPage(sessions).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) adminSuperuserSessionDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Session Delete Handler]"
+	const page = "/admin/superuser/sessions"
+	ctx := r.Context()
+
+	var p forms.AdminSessionPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	sessionID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrSessionNotFound.Error()))
+		return
+	}
+
+	if err := s.services.session.ForceSignOut(ctx, s.sessionManager.GetString(ctx, SessionStaffID), sessionID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("session id", sessionID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Session signed out"))
+}
+
+func (s *Server) adminSuperuserSessionsUserDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Admin Superuser Sessions User Delete Handler]"
+	const page = "/admin/superuser/sessions"
+	ctx := r.Context()
+
+	var p forms.AdminSessionUserPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	userType := enums.ParseUserTypeToEnum(p.UserType)
+	if !userType.IsValid() {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrEnumInvalid.Error()))
+		return
+	}
+	userID, err := httputil.RequireEncodedID(s.encoder, p.ID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrDecode.Error()))
+		return
+	}
+
+	n, err := s.services.session.ForceSignOutUser(ctx, s.sessionManager.GetString(ctx, SessionStaffID), userType, userID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Stringer("user type", userType), zap.String("user id", userID), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, fmt.Sprintf("%d session(s) signed out", n)))
+}
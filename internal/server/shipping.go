@@ -4,6 +4,8 @@ import (
 	"cchoice/cmd/parse_map/models"
 	compcart "cchoice/cmd/web/components/cart"
 	"cchoice/internal/cart"
+	"cchoice/internal/constants"
+	"cchoice/internal/database/queries"
 	"cchoice/internal/errs"
 	"cchoice/internal/geocoding"
 	"cchoice/internal/httputil"
@@ -12,6 +14,7 @@ import (
 	"cchoice/internal/server/forms"
 	"cchoice/internal/shipping"
 	"cchoice/internal/utils"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"strings"
@@ -136,6 +139,19 @@ func (s *Server) shippingQuotationHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if email := strings.TrimSpace(formReq.Email); constants.ReEmail.MatchString(email) {
+		if err := s.dbRW.GetQueries().SetCheckoutEmailBySessionID(ctx, queries.SetCheckoutEmailBySessionIDParams{
+			Email:     sql.NullString{String: strings.ToLower(email), Valid: true},
+			SessionID: token,
+		}); err != nil {
+			logs.LogCtx(ctx).Warn(
+				logtag,
+				zap.String("token", token),
+				zap.Error(err),
+			)
+		}
+	}
+
 	addressLine1 := formReq.AddressLine1
 	addressLine2 := formReq.AddressLine2
 	city := formReq.City
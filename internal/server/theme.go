@@ -36,6 +36,10 @@ func (s *Server) activeThemeCSS(ctx context.Context, logtag string) string {
 		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("message", "failed to get active theme"))
 		return ""
 	}
+	return themeCSS(ctx, theme, logtag)
+}
+
+func themeCSS(ctx context.Context, theme *services.Theme, logtag string) string {
 	if theme == nil {
 		return ""
 	}
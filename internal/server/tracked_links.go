@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"net/http"
 
+	"cchoice/internal/constants"
 	"cchoice/internal/enums"
 	"cchoice/internal/errs"
 	"cchoice/internal/httputil"
 	"cchoice/internal/logs"
+	"cchoice/internal/middleware"
 	"cchoice/internal/server/forms"
 	"cchoice/internal/utils"
 
@@ -47,9 +49,12 @@ func (s *Server) handleTrackedLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.setTrackedLinkCookies(w, r, link.ID)
+
 	var utmReq forms.TrackedLinkUTMQuery
 	_ = httputil.BindQuery(r, &utmReq)
 
+	ip := middleware.ClientIP(r)
 	go func() {
 		ua := utils.ParseUserAgent(r.UserAgent())
 		_ = s.services.trackedLink.RecordClick(
@@ -57,7 +62,8 @@ func (s *Server) handleTrackedLink(w http.ResponseWriter, r *http.Request) {
 			slug,
 			r.Referer(),
 			r.UserAgent(),
-			hashIP(r.RemoteAddr),
+			ip,
+			hashIP(ip),
 			ua.Device,
 			utmReq.UTMSource,
 			utmReq.UTMMedium,
@@ -68,9 +74,52 @@ func (s *Server) handleTrackedLink(w http.ResponseWriter, r *http.Request) {
 	redirectHX(w, r, link.DestinationURL)
 }
 
+func (s *Server) setTrackedLinkCookies(w http.ResponseWriter, r *http.Request, linkID string) {
+	maxAge := int(constants.TrackedLinkAttributionTTL.Seconds())
+	if _, err := r.Cookie(constants.TrackedLinkFirstTouchCookie); err != nil {
+		http.SetCookie(w, &http.Cookie{
+			Name:     constants.TrackedLinkFirstTouchCookie,
+			Value:    linkID,
+			Path:     "/",
+			MaxAge:   maxAge,
+			HttpOnly: true,
+			Secure:   s.useSSL,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     constants.TrackedLinkLastTouchCookie,
+		Value:    linkID,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   s.useSSL,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// attributeCheckout records which tracked links brought in the cart. Visitors
+// that never came through /l/{slug} are left unattributed.
+func (s *Server) attributeCheckout(r *http.Request, checkoutID int64) {
+	const logtag = "[Attribute Checkout]"
+	last, err := r.Cookie(constants.TrackedLinkLastTouchCookie)
+	if err != nil {
+		return
+	}
+	var firstLinkID string
+	if first, err := r.Cookie(constants.TrackedLinkFirstTouchCookie); err == nil {
+		firstLinkID = first.Value
+	}
+
+	ctx := r.Context()
+	if err := s.services.trackedLink.AttributeCheckout(ctx, checkoutID, firstLinkID, last.Value); err != nil {
+		logs.LogCtx(ctx).Warn(logtag, zap.Int64("checkout id", checkoutID), zap.Error(err))
+	}
+}
+
 // TODO: Move to utils
-func hashIP(remoteAddr string) string {
-	hash := sha256.Sum256([]byte(remoteAddr))
+func hashIP(ip string) string {
+	hash := sha256.Sum256([]byte(ip))
 	return hex.EncodeToString(hash[:])
 }
 
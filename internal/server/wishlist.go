@@ -0,0 +1,216 @@
+package server
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	compcustomer "cchoice/cmd/web/components/customers"
+	compproduct "cchoice/cmd/web/components/product"
+	"cchoice/cmd/web/models"
+	"cchoice/internal/errs"
+	"cchoice/internal/httputil"
+	"cchoice/internal/logs"
+	"cchoice/internal/server/forms"
+	"cchoice/internal/utils"
+)
+
+func (s *Server) productWishlistHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Product Wishlist Handler]"
+	ctx := r.Context()
+
+	var p forms.WishlistProductPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		http.Error(w, errs.ErrInvalidParams.Error(), http.StatusBadRequest)
+		return
+	}
+	productID, err := httputil.RequireEncodedID(s.encoder, p.ProductID)
+	if err != nil {
+		http.Error(w, errs.ErrInvalidParams.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var q forms.WishlistSectionQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		http.Error(w, errs.ErrInvalidParams.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := s.productWishlistSectionData(r, productID, q.Slug)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("product id", productID))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := compproduct.ProductWishlist(data).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("product id", productID))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) productWishlistSectionData(r *http.Request, productID string, slug string) (models.ProductWishlistSectionData, error) {
+	ctx := r.Context()
+	customerID := s.sessionManager.GetString(ctx, SessionCustomerID)
+
+	inventory, err := s.services.productInventory.GetByProductID(ctx, productID)
+	if err != nil {
+		return models.ProductWishlistSectionData{}, err
+	}
+
+	isWishlisted, err := s.services.wishlist.IsWishlisted(ctx, customerID, productID)
+	if err != nil {
+		return models.ProductWishlistSectionData{}, err
+	}
+
+	return models.ProductWishlistSectionData{
+		ProductID:    productID,
+		Slug:         slug,
+		IsLoggedIn:   customerID != "",
+		IsWishlisted: isWishlisted,
+		InStock:      inventory != nil && inventory.Stocks > 0,
+	}, nil
+}
+
+func (s *Server) customerWishlistAddHandler(w http.ResponseWriter, r *http.Request) {
+	s.customerWishlistToggle(w, r, "[Customer Wishlist Add Handler]", true)
+}
+
+func (s *Server) customerWishlistRemoveHandler(w http.ResponseWriter, r *http.Request) {
+	s.customerWishlistToggle(w, r, "[Customer Wishlist Remove Handler]", false)
+}
+
+func (s *Server) customerWishlistToggle(w http.ResponseWriter, r *http.Request, logtag string, add bool) {
+	ctx := r.Context()
+
+	var p forms.WishlistProductPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		http.Error(w, errs.ErrInvalidParams.Error(), http.StatusBadRequest)
+		return
+	}
+	productID, err := httputil.RequireEncodedID(s.encoder, p.ProductID)
+	if err != nil {
+		http.Error(w, errs.ErrInvalidParams.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var q forms.WishlistSectionQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		http.Error(w, errs.ErrInvalidParams.Error(), http.StatusBadRequest)
+		return
+	}
+
+	customerID := s.sessionManager.GetString(ctx, SessionCustomerID)
+	if add {
+		err = s.services.wishlist.Add(ctx, customerID, productID)
+	} else {
+		err = s.services.wishlist.Remove(ctx, customerID, productID)
+	}
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("product id", productID))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := models.ProductWishlistSectionData{
+		ProductID:    productID,
+		Slug:         q.Slug,
+		IsLoggedIn:   true,
+		IsWishlisted: add,
+	}
+	if err := compproduct.ProductWishlistToggle(data).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("product id", productID))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) customerWishlistPageHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Customer Wishlist Page Handler]"
+	const page = "/customer/portal"
+	ctx := r.Context()
+
+	customerID := s.sessionManager.GetString(ctx, SessionCustomerID)
+	serviceItems, err := s.services.wishlist.GetForCustomer(ctx, customerID)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	items := make([]models.CustomerWishlistItem, 0, len(serviceItems))
+	for _, item := range serviceItems {
+		items = append(items, models.CustomerWishlistItem{
+			ProductID:    item.ProductID,
+			Name:         item.Name,
+			Serial:       item.Serial,
+			Slug:         item.Slug,
+			PriceDisplay: item.PriceDisplay,
+			ImageURL:     item.ImageURL,
+			InStock:      item.InStock,
+			AddedAt:      item.AddedAt,
+		})
+	}
+
+	if err := compcustomer.CustomerWishlistPage(items).Render(ctx, w); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.String("path", r.URL.Path), zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+	}
+}
+
+func (s *Server) stockSubscriptionCreateHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Stock Subscription Create Handler]"
+	ctx := r.Context()
+	page := "/"
+
+	var p forms.WishlistProductPath
+	if err := httputil.BindPath(r, &p); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+	productID, err := httputil.RequireEncodedID(s.encoder, p.ProductID)
+	if err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrInvalidParams.Error()))
+		return
+	}
+
+	var f forms.StockSubscriptionForm
+	if err := httputil.BindForm(r, &f); err != nil {
+		if f.Slug != "" {
+			page = "/product/" + f.Slug
+		}
+		redirectHX(w, r, utils.URLWithError(page, httputil.ErrorMessage(err)))
+		return
+	}
+	if f.Slug != "" {
+		page = "/product/" + f.Slug
+	}
+
+	if err := s.services.wishlist.Subscribe(ctx, productID, f.Email); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err), zap.String("product id", productID))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess(page, "Check your email to confirm your back-in-stock alert."))
+}
+
+func (s *Server) stockSubscriptionConfirmHandler(w http.ResponseWriter, r *http.Request) {
+	const logtag = "[Stock Subscription Confirm Handler]"
+	const page = "/"
+	ctx := r.Context()
+
+	var q forms.StockSubscriptionConfirmQuery
+	if err := httputil.BindQuery(r, &q); err != nil {
+		redirectHX(w, r, utils.URLWithError(page, errs.ErrStockSubscriptionInvalidToken.Error()))
+		return
+	}
+
+	slug, err := s.services.wishlist.ConfirmSubscription(ctx, q.Token)
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		redirectHX(w, r, utils.URLWithError(page, err.Error()))
+		return
+	}
+
+	redirectHX(w, r, utils.URLWithSuccess("/product/"+slug, "Back-in-stock alert confirmed. We'll email you once it's available."))
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"cchoice/internal/conf"
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/jobs"
+	"cchoice/internal/logs"
+	"cchoice/internal/metrics"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+type AbandonedCartService struct {
+	encoder     encode.IEncode
+	dbRO        database.IService
+	dbRW        database.IService
+	emailRunner *jobs.EmailJobRunner
+}
+
+func NewAbandonedCartService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	emailRunner *jobs.EmailJobRunner,
+) *AbandonedCartService {
+	if shouldSendAbandonedCartEmails() && emailRunner == nil {
+		panic("emailRunner is required")
+	}
+	return &AbandonedCartService{
+		encoder:     encoder,
+		dbRO:        dbRO,
+		dbRW:        dbRW,
+		emailRunner: emailRunner,
+	}
+}
+
+func shouldSendAbandonedCartEmails() bool {
+	return conf.Conf().IsProd() || conf.Conf().Test.LocalAbandonedCart
+}
+
+// SendReminders queues a single reminder for every cart that has a known
+// recipient and has not been touched since now minus the configured idle time.
+func (s *AbandonedCartService) SendReminders(ctx context.Context, now time.Time) (int, error) {
+	const logtag = "[AbandonedCartService SendReminders]"
+
+	idleBefore := now.Add(-conf.Conf().AbandonedCart.IdleAfter).UTC()
+	checkouts, err := s.dbRO.GetQueries().GetAbandonedCheckouts(ctx, queries.GetAbandonedCheckoutsParams{
+		IdleBefore: idleBefore,
+		Limit:      constants.AbandonedCartBatchSize,
+	})
+	if err != nil {
+		return 0, errors.Join(errs.ErrCartAbandoned, err)
+	}
+
+	sent := 0
+	for _, checkout := range checkouts {
+		if err := s.sendReminder(ctx, checkout.ID, checkout.Email); err != nil {
+			metrics.Cart.AbandonedReminder(metrics.CheckoutResultFailure)
+			logs.LogCtx(ctx).Error(logtag, zap.Int64("checkout_id", checkout.ID), zap.Error(err))
+			continue
+		}
+		metrics.Cart.AbandonedReminder(metrics.CheckoutResultSuccess)
+		sent++
+	}
+	return sent, nil
+}
+
+func (s *AbandonedCartService) sendReminder(ctx context.Context, checkoutID int64, recipient string) error {
+	const logtag = "[AbandonedCartService sendReminder]"
+
+	rawToken, err := generateResetToken()
+	if err != nil {
+		return errors.Join(errs.ErrCartAbandoned, err)
+	}
+
+	// Marked before queueing so a failing mail queue never turns into repeated
+	// reminders on every scan.
+	if err := s.dbRW.GetQueries().MarkCheckoutReminderSent(ctx, queries.MarkCheckoutReminderSentParams{
+		RecoveryTokenHash: sql.NullString{String: hashToken(rawToken), Valid: true},
+		ID:                checkoutID,
+	}); err != nil {
+		return errors.Join(errs.ErrCartAbandoned, err)
+	}
+
+	resumeLink := fmt.Sprintf("%s?token=%s", utils.FullURL("/carts/resume"), rawToken)
+	if !shouldSendAbandonedCartEmails() {
+		logs.LogCtx(ctx).Info(
+			logtag,
+			zap.String("result", "skipped (non-prod)"),
+			zap.Int64("checkout_id", checkoutID),
+			zap.String("resume_link", resumeLink),
+			zap.String("recipient", recipient),
+		)
+		return nil
+	}
+
+	if err := s.emailRunner.QueueEmailJob(ctx, jobs.EmailJobParams{
+		CheckoutID:   &checkoutID,
+		Recipient:    recipient,
+		Subject:      "You Left Something in Your Cart - C-Choice",
+		TemplateName: enums.EMAIL_TEMPLATE_ABANDONED_CART,
+		OTPCode:      resumeLink,
+	}); err != nil {
+		return errors.Join(errs.ErrJobsCreateFailed, err)
+	}
+	return nil
+}
+
+// Resume looks up the cart behind a reminder link. The returned product IDs
+// are encoded so the caller can put them straight back into the session.
+func (s *AbandonedCartService) Resume(ctx context.Context, token string) (*AbandonedCartResume, error) {
+	if token == "" {
+		return nil, errs.ErrCartInvalidRecoveryToken
+	}
+
+	checkout, err := s.dbRO.GetQueries().GetCheckoutByRecoveryTokenHash(ctx, sql.NullString{
+		String: hashToken(token),
+		Valid:  true,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrCartInvalidRecoveryToken
+		}
+		return nil, errors.Join(errs.ErrCartAbandoned, err)
+	}
+
+	checkoutLines, err := s.dbRO.GetQueries().GetCheckoutLinesByCheckoutID(ctx, checkout.ID)
+	if err != nil {
+		return nil, errors.Join(errs.ErrCartAbandoned, err)
+	}
+	if len(checkoutLines) == 0 {
+		return nil, errs.ErrCartMissingCheckoutLines
+	}
+
+	resume := &AbandonedCartResume{
+		CheckoutID: checkout.ID,
+		SessionID:  checkout.SessionID,
+		Lines:      make([]AbandonedCartLine, 0, len(checkoutLines)),
+	}
+	for _, line := range checkoutLines {
+		resume.Lines = append(resume.Lines, AbandonedCartLine{
+			ProductID: s.encoder.Encode(line.ProductID),
+			Quantity:  line.Quantity,
+		})
+	}
+	return resume, nil
+}
+
+// MarkRecovered flags the reminded checkouts among checkoutIDs as recovered
+// and counts the order once. It reports false when none of them were reminded
+// or they were already counted.
+func (s *AbandonedCartService) MarkRecovered(ctx context.Context, checkoutIDs ...int64) (bool, error) {
+	var total int64
+	for _, checkoutID := range checkoutIDs {
+		affected, err := s.dbRW.GetQueries().MarkCheckoutRecovered(ctx, checkoutID)
+		if err != nil {
+			return false, errors.Join(errs.ErrCartAbandoned, err)
+		}
+		total += affected
+	}
+	if total == 0 {
+		return false, nil
+	}
+	metrics.Cart.RecoveredOrder()
+	return true, nil
+}
+
+func (s *AbandonedCartService) RunScheduler(ctx context.Context) {
+	const logtag = "[AbandonedCartService] RunScheduler"
+	logs.Log().Info("[AbandonedCartService] Starting abandoned cart scheduler")
+
+	ticker := time.NewTicker(conf.Conf().AbandonedCart.ScanInterval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := s.SendReminders(ctx, time.Now())
+		if err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		} else if sent > 0 {
+			logs.LogCtx(ctx).Info(logtag, zap.Int("reminders_sent", sent))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *AbandonedCartService) ID() string {
+	return "AbandonedCart"
+}
+
+func (s *AbandonedCartService) Log() {
+	logs.Log().Info("[AbandonedCartService] Loaded")
+}
+
+var _ IService = (*AbandonedCartService)(nil)
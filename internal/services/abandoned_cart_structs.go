@@ -0,0 +1,30 @@
+package services
+
+import (
+	"cchoice/internal/cart"
+	"cchoice/internal/constants"
+)
+
+type AbandonedCartLine struct {
+	ProductID string
+	Quantity  int64
+}
+
+type AbandonedCartResume struct {
+	CheckoutID int64
+	SessionID  string
+	Lines      []AbandonedCartLine
+}
+
+// MergeProductIDs restores the resumed lines into the session cart with the
+// cart's own merge: a product keeps the larger of the two quantities, capped at
+// the per-line limit, so reusing a resume link never piles quantities up.
+func (r AbandonedCartResume) MergeProductIDs(existing []string) []string {
+	resumed := make([]string, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		for range min(line.Quantity, constants.MaxCartLineQty) {
+			resumed = append(resumed, line.ProductID)
+		}
+	}
+	return cart.MergeProductIDs(existing, resumed, cart.MergeModeRestore)
+}
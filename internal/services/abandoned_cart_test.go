@@ -0,0 +1,28 @@
+package services
+
+import (
+	"testing"
+
+	"cchoice/internal/constants"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAbandonedCartResumeMergeProductIDs(t *testing.T) {
+	resume := AbandonedCartResume{
+		Lines: []AbandonedCartLine{
+			{ProductID: "a", Quantity: 2},
+			{ProductID: "b", Quantity: 1},
+		},
+	}
+
+	assert.Equal(t, []string{"a", "a", "b"}, resume.MergeProductIDs(nil))
+	assert.Equal(t, []string{"b", "a", "a"}, resume.MergeProductIDs([]string{"b"}))
+	assert.Equal(t, []string{"a", "a", "b"}, resume.MergeProductIDs([]string{"a", "b"}))
+
+	merged := resume.MergeProductIDs(resume.MergeProductIDs(nil))
+	assert.Equal(t, []string{"a", "a", "b"}, merged, "resuming twice does not add up")
+
+	replayed := AbandonedCartResume{Lines: []AbandonedCartLine{{ProductID: "a", Quantity: 1_000}}}
+	assert.Len(t, replayed.MergeProductIDs([]string{"a"}), constants.MaxCartLineQty)
+}
@@ -0,0 +1,393 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+	"cchoice/internal/metrics"
+	"cchoice/internal/utils"
+
+	"github.com/xuri/excelize/v2"
+	"go.uber.org/zap"
+)
+
+type AnalyticsService struct {
+	encoder  encode.IEncode
+	dbRO     database.IService
+	staffLog *StaffLogsService
+}
+
+func NewAnalyticsService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	staffLog *StaffLogsService,
+) *AnalyticsService {
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &AnalyticsService{
+		encoder:  encoder,
+		dbRO:     dbRO,
+		staffLog: staffLog,
+	}
+}
+
+// ResolveAnalyticsRange parses the dashboard date pickers. Empty values
+// default to the last AnalyticsDefaultDays days ending on today's date in its
+// own location, which is PH time for the dashboard.
+func ResolveAnalyticsRange(startDate, endDate string, today time.Time) (time.Time, time.Time, error) {
+	end := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if endDate != "" {
+		parsed, err := time.Parse(constants.DateLayoutISO, endDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, errs.ErrAnalyticsInvalidRange
+		}
+		end = parsed
+	}
+
+	start := end.AddDate(0, 0, -(constants.AnalyticsDefaultDays - 1))
+	if startDate != "" {
+		parsed, err := time.Parse(constants.DateLayoutISO, startDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, errs.ErrAnalyticsInvalidRange
+		}
+		start = parsed
+	}
+
+	if start.After(end) || analyticsDaysBetween(start, end) > constants.AnalyticsMaxDays {
+		return time.Time{}, time.Time{}, errs.ErrAnalyticsInvalidRange
+	}
+	return start, end, nil
+}
+
+func (s *AnalyticsService) GetDashboard(ctx context.Context, start, end time.Time, brandID string) (*AnalyticsDashboard, error) {
+	var dbBrandID int64
+	if brandID != "" {
+		dbBrandID = s.encoder.Decode(brandID)
+		if dbBrandID == encode.INVALID {
+			return nil, errs.ErrDecode
+		}
+	}
+
+	startDate := start.Format(constants.DateLayoutISO)
+	endDate := end.Format(constants.DateLayoutISO)
+	q := s.dbRO.GetQueries()
+
+	totals, err := q.GetAnalyticsSalesTotals(ctx, queries.GetAnalyticsSalesTotalsParams{StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		return nil, errors.Join(errs.ErrAnalytics, err)
+	}
+	repeat, err := q.GetAnalyticsRepeatCustomers(ctx, queries.GetAnalyticsRepeatCustomersParams{StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		return nil, errors.Join(errs.ErrAnalytics, err)
+	}
+	clicks, err := q.GetAnalyticsLinkClicks(ctx, queries.GetAnalyticsLinkClicksParams{StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		return nil, errors.Join(errs.ErrAnalytics, err)
+	}
+	perDay, err := q.GetAnalyticsRevenuePerDay(ctx, queries.GetAnalyticsRevenuePerDayParams{StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		return nil, errors.Join(errs.ErrAnalytics, err)
+	}
+	brands, err := q.GetAnalyticsRevenueByBrand(ctx, queries.GetAnalyticsRevenueByBrandParams{StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		return nil, errors.Join(errs.ErrAnalytics, err)
+	}
+	categories, err := q.GetAnalyticsRevenueByCategory(ctx, queries.GetAnalyticsRevenueByCategoryParams{StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		return nil, errors.Join(errs.ErrAnalytics, err)
+	}
+	paymentMethods, err := q.GetAnalyticsPaymentMethods(ctx, queries.GetAnalyticsPaymentMethodsParams{StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		return nil, errors.Join(errs.ErrAnalytics, err)
+	}
+	topProducts, err := q.GetAnalyticsTopProducts(ctx, queries.GetAnalyticsTopProductsParams{
+		StartDate: startDate,
+		EndDate:   endDate,
+		BrandID:   dbBrandID,
+		Limit:     constants.AnalyticsTopProductsLimit,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrAnalytics, err)
+	}
+	funnel, err := q.GetAnalyticsFunnel(ctx, queries.GetAnalyticsFunnelParams{StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		return nil, errors.Join(errs.ErrAnalytics, err)
+	}
+	zeroResults, err := q.GetZeroResultSearches(ctx, queries.GetZeroResultSearchesParams{
+		Event:     metrics.EventSearchResults,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Limit:     constants.AnalyticsZeroResultsLimit,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrAnalytics, err)
+	}
+
+	dashboard := &AnalyticsDashboard{
+		StartDate:          startDate,
+		EndDate:            endDate,
+		BrandID:            brandID,
+		Orders:             totals.Orders,
+		Customers:          totals.Customers,
+		Revenue:            displayPHP(totals.Revenue),
+		RevenueCents:       totals.Revenue,
+		AverageOrderValue:  displayPHP(averageOrderValue(totals.Revenue, totals.Orders)),
+		RepeatCustomers:    repeat.RepeatCustomers,
+		RepeatCustomerRate: formatRate(repeat.RepeatCustomers, repeat.Customers),
+		LinkClicks:         clicks.Clicks,
+		LinkVisitors:       clicks.UniqueVisitors,
+		PerDay:             fillAnalyticsDays(perDay, start, analyticsDaysBetween(start, end)),
+		TopProducts:        make([]AnalyticsTopProduct, 0, len(topProducts)),
+		Funnel:             buildAnalyticsFunnel(funnel.Carts, funnel.Finalized, funnel.Paid),
+		ZeroResultSearches: make([]AnalyticsSearchTerm, 0, len(zeroResults)),
+	}
+
+	for _, row := range brands {
+		dashboard.Brands = append(dashboard.Brands, AnalyticsBreakdown{Label: row.Label, Units: row.Units, RevenueCents: row.Revenue})
+	}
+	for _, row := range categories {
+		dashboard.Categories = append(dashboard.Categories, AnalyticsBreakdown{Label: row.Label, Units: row.Units, RevenueCents: row.Revenue})
+	}
+	for _, row := range paymentMethods {
+		dashboard.PaymentMethods = append(dashboard.PaymentMethods, AnalyticsBreakdown{Label: row.Label, Orders: row.Orders, RevenueCents: row.Revenue})
+	}
+	dashboard.Brands = withRevenuePercents(dashboard.Brands)
+	dashboard.Categories = withRevenuePercents(dashboard.Categories)
+	dashboard.PaymentMethods = withRevenuePercents(dashboard.PaymentMethods)
+
+	for _, row := range topProducts {
+		dashboard.TopProducts = append(dashboard.TopProducts, AnalyticsTopProduct{
+			Serial:       row.Serial,
+			Name:         row.Name,
+			Brand:        row.Brand,
+			Units:        row.Units,
+			Revenue:      displayPHP(row.Revenue),
+			RevenueCents: row.Revenue,
+		})
+	}
+
+	for _, row := range zeroResults {
+		dashboard.ZeroResultSearches = append(dashboard.ZeroResultSearches, AnalyticsSearchTerm{
+			Term:           row.Term,
+			Searches:       row.Searches,
+			LastSearchedOn: row.LastSearchedOn,
+		})
+	}
+
+	return dashboard, nil
+}
+
+// WriteDashboardXLSX writes one sheet per dashboard section so staff can
+// pivot the numbers further in a spreadsheet.
+func (s *AnalyticsService) WriteDashboardXLSX(
+	ctx context.Context,
+	file *excelize.File,
+	dashboard *AnalyticsDashboard,
+	adminStaffID string,
+	filename string,
+) error {
+	result := fmt.Sprintf("success. filename '%s'", filename)
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionExport,
+			constants.ModuleAnalyticsExportXLSX,
+			result,
+			nil,
+		); err != nil {
+			logs.LogCtx(ctx).Error("[AnalyticsService] failed to log analytics xlsx export", zap.Error(err))
+		}
+	}()
+
+	sheets := []struct {
+		name string
+		rows [][]any
+	}{
+		{"Summary", [][]any{
+			{"Start Date", dashboard.StartDate},
+			{"End Date", dashboard.EndDate},
+			{"Paid Orders", dashboard.Orders},
+			{"Revenue (PHP)", centsToPesos(dashboard.RevenueCents)},
+			{"Average Order Value (PHP)", centsToPesos(averageOrderValue(dashboard.RevenueCents, dashboard.Orders))},
+			{"Customers", dashboard.Customers},
+			{"Repeat Customers", dashboard.RepeatCustomers},
+			{"Repeat Customer Rate", dashboard.RepeatCustomerRate},
+			{"Tracked Link Clicks", dashboard.LinkClicks},
+			{"Tracked Link Visitors", dashboard.LinkVisitors},
+		}},
+		{"Revenue by Day", analyticsDayRows(dashboard.PerDay)},
+		{"Brands", analyticsBreakdownRows("Brand", dashboard.Brands)},
+		{"Categories", analyticsBreakdownRows("Category", dashboard.Categories)},
+		{"Payment Methods", analyticsBreakdownRows("Payment Method", dashboard.PaymentMethods)},
+		{"Top Products", analyticsTopProductRows(dashboard.TopProducts)},
+		{"Funnel", analyticsFunnelRows(dashboard.Funnel)},
+		{"Zero-Result Searches", analyticsSearchTermRows(dashboard.ZeroResultSearches)},
+	}
+
+	for _, sheet := range sheets {
+		if _, err := file.NewSheet(sheet.name); err != nil {
+			result = err.Error()
+			return err
+		}
+		for rowIdx, row := range sheet.rows {
+			cell, err := excelize.CoordinatesToCellName(1, rowIdx+1)
+			if err != nil {
+				result = err.Error()
+				return err
+			}
+			if err := file.SetSheetRow(sheet.name, cell, &row); err != nil {
+				result = err.Error()
+				return err
+			}
+		}
+	}
+	if err := file.DeleteSheet("Sheet1"); err != nil {
+		result = err.Error()
+		return err
+	}
+
+	return nil
+}
+
+func analyticsDaysBetween(start, end time.Time) int {
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+func averageOrderValue(revenue, orders int64) int64 {
+	if orders <= 0 {
+		return 0
+	}
+	return revenue / orders
+}
+
+func displayPHP(cents int64) string {
+	return utils.NewMoney(cents, constants.PHP).Display()
+}
+
+func centsToPesos(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+func fillAnalyticsDays(rows []queries.GetAnalyticsRevenuePerDayRow, start time.Time, days int) []AnalyticsDay {
+	byDay := make(map[string]queries.GetAnalyticsRevenuePerDayRow, len(rows))
+	var peak int64
+	for _, row := range rows {
+		byDay[row.Day] = row
+		peak = max(peak, row.Revenue)
+	}
+
+	result := make([]AnalyticsDay, 0, days)
+	for i := range days {
+		day := start.AddDate(0, 0, i).Format(constants.DateLayoutISO)
+		row := byDay[day]
+		item := AnalyticsDay{
+			Day:          day,
+			Orders:       row.Orders,
+			Revenue:      displayPHP(row.Revenue),
+			RevenueCents: row.Revenue,
+		}
+		if peak > 0 {
+			item.Percent = int(row.Revenue * 100 / peak)
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+func withRevenuePercents(items []AnalyticsBreakdown) []AnalyticsBreakdown {
+	var total int64
+	for _, item := range items {
+		total += item.RevenueCents
+	}
+	for i := range items {
+		items[i].Revenue = displayPHP(items[i].RevenueCents)
+		if total > 0 {
+			items[i].Percent = int(items[i].RevenueCents * 100 / total)
+		}
+	}
+	return items
+}
+
+func buildAnalyticsFunnel(carts, finalized, paid int64) []AnalyticsFunnelStep {
+	counts := []struct {
+		label string
+		count int64
+	}{
+		{"Carts", carts},
+		{"Finalized", finalized},
+		{"Paid", paid},
+	}
+
+	steps := make([]AnalyticsFunnelStep, 0, len(counts))
+	for i, c := range counts {
+		step := AnalyticsFunnelStep{Label: c.label, Count: c.count, Rate: "-"}
+		if carts > 0 {
+			step.Percent = int(c.count * 100 / carts)
+		}
+		if i > 0 {
+			step.Rate = formatRate(c.count, counts[i-1].count)
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+func analyticsDayRows(days []AnalyticsDay) [][]any {
+	rows := [][]any{{"Date", "Paid Orders", "Revenue (PHP)"}}
+	for _, d := range days {
+		rows = append(rows, []any{d.Day, d.Orders, centsToPesos(d.RevenueCents)})
+	}
+	return rows
+}
+
+func analyticsBreakdownRows(label string, items []AnalyticsBreakdown) [][]any {
+	rows := [][]any{{label, "Units", "Paid Orders", "Revenue (PHP)", "Share %"}}
+	for _, item := range items {
+		rows = append(rows, []any{item.Label, item.Units, item.Orders, centsToPesos(item.RevenueCents), item.Percent})
+	}
+	return rows
+}
+
+func analyticsTopProductRows(products []AnalyticsTopProduct) [][]any {
+	rows := [][]any{{"Serial", "Name", "Brand", "Units", "Revenue (PHP)"}}
+	for _, p := range products {
+		rows = append(rows, []any{p.Serial, p.Name, p.Brand, p.Units, centsToPesos(p.RevenueCents)})
+	}
+	return rows
+}
+
+func analyticsFunnelRows(steps []AnalyticsFunnelStep) [][]any {
+	rows := [][]any{{"Step", "Count", "% of Carts", "From Previous Step"}}
+	for _, step := range steps {
+		rows = append(rows, []any{step.Label, step.Count, step.Percent, step.Rate})
+	}
+	return rows
+}
+
+func analyticsSearchTermRows(terms []AnalyticsSearchTerm) [][]any {
+	rows := [][]any{{"Search", "Searches", "Last Searched On"}}
+	for _, t := range terms {
+		rows = append(rows, []any{t.Term, t.Searches, t.LastSearchedOn})
+	}
+	return rows
+}
+
+func (s *AnalyticsService) ID() string {
+	return "Analytics"
+}
+
+func (s *AnalyticsService) Log() {
+	logs.Log().Info("[AnalyticsService] Loaded")
+}
+
+var _ IService = (*AnalyticsService)(nil)
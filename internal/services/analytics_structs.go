@@ -0,0 +1,63 @@
+package services
+
+type AnalyticsDay struct {
+	Day          string
+	Orders       int64
+	Revenue      string
+	RevenueCents int64
+	Percent      int
+}
+
+type AnalyticsBreakdown struct {
+	Label        string
+	Units        int64
+	Orders       int64
+	Revenue      string
+	RevenueCents int64
+	Percent      int
+}
+
+type AnalyticsTopProduct struct {
+	Serial       string
+	Name         string
+	Brand        string
+	Units        int64
+	Revenue      string
+	RevenueCents int64
+}
+
+type AnalyticsFunnelStep struct {
+	Label   string
+	Count   int64
+	Percent int
+	// Rate is the share of the previous step that made it to this one.
+	Rate string
+}
+
+type AnalyticsSearchTerm struct {
+	Term           string
+	Searches       int64
+	LastSearchedOn string
+}
+
+type AnalyticsDashboard struct {
+	StartDate          string
+	EndDate            string
+	BrandID            string
+	Orders             int64
+	Customers          int64
+	Revenue            string
+	RevenueCents       int64
+	AverageOrderValue  string
+	RepeatCustomers    int64
+	RepeatCustomerRate string
+	LinkClicks         int64
+	LinkVisitors       int64
+	PerDay             []AnalyticsDay
+	Brands             []AnalyticsBreakdown
+	Categories         []AnalyticsBreakdown
+	PaymentMethods     []AnalyticsBreakdown
+	TopProducts        []AnalyticsTopProduct
+	Funnel             []AnalyticsFunnelStep
+	ZeroResultSearches []AnalyticsSearchTerm
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"cchoice/internal/database/queries"
+	"cchoice/internal/errs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveAnalyticsRange(t *testing.T) {
+	today := time.Date(2026, 5, 31, 15, 4, 0, 0, time.UTC)
+
+	start, end, err := ResolveAnalyticsRange("", "", today)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-05-02", start.Format("2006-01-02"))
+	assert.Equal(t, "2026-05-31", end.Format("2006-01-02"))
+
+	start, end, err = ResolveAnalyticsRange("2026-04-01", "2026-04-30", today)
+	require.NoError(t, err)
+	assert.Equal(t, 30, analyticsDaysBetween(start, end))
+
+	_, _, err = ResolveAnalyticsRange("2026-05-10", "2026-05-01", today)
+	assert.ErrorIs(t, err, errs.ErrAnalyticsInvalidRange)
+	_, _, err = ResolveAnalyticsRange("2024-01-01", "2026-01-01", today)
+	assert.ErrorIs(t, err, errs.ErrAnalyticsInvalidRange)
+	_, _, err = ResolveAnalyticsRange("05/01/2026", "", today)
+	assert.ErrorIs(t, err, errs.ErrAnalyticsInvalidRange)
+}
+
+func TestFillAnalyticsDays(t *testing.T) {
+	start := time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC)
+	days := fillAnalyticsDays([]queries.GetAnalyticsRevenuePerDayRow{
+		{Day: "2026-02-27", Orders: 2, Revenue: 40000},
+		{Day: "2026-03-01", Orders: 1, Revenue: 10000},
+	}, start, 3)
+
+	require.Len(t, days, 3)
+	assert.Equal(t, "2026-02-28", days[1].Day)
+	assert.Zero(t, days[1].Orders)
+	assert.Equal(t, 100, days[0].Percent)
+	assert.Equal(t, 25, days[2].Percent)
+}
+
+func TestBuildAnalyticsFunnel(t *testing.T) {
+	steps := buildAnalyticsFunnel(200, 50, 40)
+	require.Len(t, steps, 3)
+	assert.Equal(t, []int{100, 25, 20}, []int{steps[0].Percent, steps[1].Percent, steps[2].Percent})
+	assert.Equal(t, "-", steps[0].Rate)
+	assert.Equal(t, "25.00%", steps[1].Rate)
+	assert.Equal(t, "80.00%", steps[2].Rate)
+
+	empty := buildAnalyticsFunnel(0, 0, 0)
+	assert.Zero(t, empty[2].Percent)
+	assert.Equal(t, "-", empty[2].Rate)
+}
+
+func TestWithRevenuePercents(t *testing.T) {
+	items := withRevenuePercents([]AnalyticsBreakdown{
+		{Label: "Makita", RevenueCents: 75000},
+		{Label: "Bosch", RevenueCents: 25000},
+	})
+	assert.Equal(t, 75, items[0].Percent)
+	assert.Equal(t, 25, items[1].Percent)
+	assert.NotEmpty(t, items[0].Revenue)
+}
+
+func TestAverageOrderValue(t *testing.T) {
+	assert.Equal(t, int64(2500), averageOrderValue(10000, 4))
+	assert.Zero(t, averageOrderValue(10000, 0))
+}
@@ -13,7 +13,9 @@ import (
 	"cchoice/internal/database/queries"
 	"cchoice/internal/encode"
 	"cchoice/internal/enums"
+	"cchoice/internal/errs"
 	"cchoice/internal/logs"
+	"cchoice/internal/shift"
 	"cchoice/internal/types"
 	"cchoice/internal/utils"
 
@@ -25,6 +27,8 @@ type AttendanceService struct {
 	dbRO         database.IService
 	dbRW         database.IService
 	holiday      *HolidayService
+	leave        *LeaveService
+	shift        *ShiftService
 	staffLog     *StaffLogsService
 	shopLocation types.Location
 }
@@ -33,8 +37,16 @@ func NewAttendanceService(
 	encoder encode.IEncode,
 	ro, rw database.IService,
 	holiday *HolidayService,
+	leave *LeaveService,
+	shift *ShiftService,
 	staffLog *StaffLogsService,
 ) *AttendanceService {
+	if leave == nil {
+		panic("LeaveService is required")
+	}
+	if shift == nil {
+		panic("ShiftService is required")
+	}
 	if staffLog == nil {
 		panic("StaffLogsService is required")
 	}
@@ -44,6 +56,8 @@ func NewAttendanceService(
 		dbRW:         rw,
 		shopLocation: conf.Conf().Settings.ShopLocation,
 		holiday:      holiday,
+		leave:        leave,
+		shift:        shift,
 		staffLog:     staffLog,
 	}
 }
@@ -57,6 +71,14 @@ func (s *AttendanceService) TimeIn(
 	useragentID sql.NullInt64,
 ) error {
 	dbStaffID := s.encoder.Decode(staffID)
+	schedule, err := s.shift.GetSchedule(ctx, dbStaffID, date, date)
+	if err != nil {
+		return err
+	}
+	if schedule.For(date).Suspended {
+		return errs.ErrIncidentSuspended
+	}
+
 	existing, err := s.dbRO.GetQueries().GetStaffAttendanceByDate(ctx,
 		queries.GetStaffAttendanceByDateParams{
 			StaffID: dbStaffID,
@@ -244,6 +266,9 @@ func (s *AttendanceService) UpsertLocation(
 	return err
 }
 
+// ApproveTimeOff approves a pending request. Vacation and sick leaves take
+// their credits in the same transaction and are rejected with
+// errs.ErrLeaveInsufficientBalance when the balance does not cover them.
 func (s *AttendanceService) ApproveTimeOff(
 	ctx context.Context,
 	timeOffID string,
@@ -263,9 +288,19 @@ func (s *AttendanceService) ApproveTimeOff(
 		}
 	}()
 
-	if _, err := s.dbRW.GetQueries().ApproveStaffTimeOff(ctx, queries.ApproveStaffTimeOffParams{
-		ApprovedBy: sql.NullInt64{Int64: s.encoder.Decode(approvedByID), Valid: true},
-		ID:         s.encoder.Decode(timeOffID),
+	dbApprovedByID := s.encoder.Decode(approvedByID)
+	if err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		timeOff, err := qtx.GetStaffTimeOffByID(ctx, s.encoder.Decode(timeOffID))
+		if err != nil {
+			return err
+		}
+		if _, err := qtx.ApproveStaffTimeOff(ctx, queries.ApproveStaffTimeOffParams{
+			ApprovedBy: sql.NullInt64{Int64: dbApprovedByID, Valid: true},
+			ID:         timeOff.ID,
+		}); err != nil {
+			return err
+		}
+		return s.leave.deductTx(ctx, qtx, timeOff, dbApprovedByID)
 	}); err != nil {
 		result = err.Error()
 		return err
@@ -275,6 +310,7 @@ func (s *AttendanceService) ApproveTimeOff(
 	return nil
 }
 
+// CancelTimeOff withdraws an approval and refunds any leave credits it took.
 func (s *AttendanceService) CancelTimeOff(
 	ctx context.Context,
 	timeOffID string,
@@ -294,7 +330,16 @@ func (s *AttendanceService) CancelTimeOff(
 		}
 	}()
 
-	if _, err := s.dbRW.GetQueries().CancelStaffTimeOff(ctx, s.encoder.Decode(timeOffID)); err != nil {
+	if err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		timeOff, err := qtx.GetStaffTimeOffByID(ctx, s.encoder.Decode(timeOffID))
+		if err != nil {
+			return err
+		}
+		if _, err := qtx.CancelStaffTimeOff(ctx, timeOff.ID); err != nil {
+			return err
+		}
+		return s.leave.refundTx(ctx, qtx, timeOff, s.encoder.Decode(approvedByID))
+	}); err != nil {
 		result = err.Error()
 		return err
 	}
@@ -342,17 +387,16 @@ func (s *AttendanceService) GetAttendance(
 	return data, nil
 }
 
+// ComputeData compares the attendance against the shift the schedule resolves
+// for its date. Rest days have no scheduled times so nothing is marked late or
+// undertime.
 func (s *AttendanceService) ComputeData(
 	staff StaffRowBase,
 	att StaffRow,
+	schedule shift.Schedule,
 ) models.Attendance {
-	schedIn, schedOut := "", ""
-	if staff.TimeInSchedule.Valid {
-		schedIn = staff.TimeInSchedule.String
-	}
-	if staff.TimeOutSchedule.Valid {
-		schedOut = staff.TimeOutSchedule.String
-	}
+	sh := schedule.For(att.ForDate)
+	schedIn, schedOut := sh.TimeIn, sh.TimeOut
 	timeIn, timeOut := utils.ExtractTimeToPH(att.TimeIn.String), utils.ExtractTimeToPH(att.TimeOut.String)
 	lunchbreakIn, lunchbreakOut := utils.ExtractTimeToPH(att.LunchBreakIn.String), utils.ExtractTimeToPH(att.LunchBreakOut.String)
 	c := computeInOutStatus(timeIn, timeOut, schedIn, schedOut)
@@ -424,6 +468,7 @@ func (s *AttendanceService) ComputeData(
 			DurationColor: c.durationColor,
 			InLate:        c.inLate,
 			Undertime:     c.undertime,
+			Overtime:      c.overtime,
 			EarlyIn:       c.earlyIn,
 			InShop:        inShop,
 			OutShop:       outShop,
@@ -504,6 +549,15 @@ func computeInOutStatus(actualIn, actualOut, schedIn, schedOut string) attendanc
 			out.outStatus = enums.TIME_OUT_STATUS_ON_TIME
 		default:
 			out.outStatus = enums.TIME_OUT_STATUS_OVERTIME
+			timeSchedOut, err := time.Parse(constants.TimeLayoutHHMM, schedOut)
+			if err != nil {
+				logs.Log().Warn("computeInOutStatus", zap.String("sched out", schedOut), zap.Error(err))
+			}
+			timeActualOut, err := time.Parse(constants.TimeLayoutHHMMSS, actualOut)
+			if err != nil {
+				logs.Log().Warn("computeInOutStatus", zap.String("actual out", actualOut), zap.Error(err))
+			}
+			out.overtime = timeActualOut.Sub(timeSchedOut)
 		}
 	}
 
@@ -530,14 +584,28 @@ func computeInOutStatus(actualIn, actualOut, schedIn, schedOut string) attendanc
 func (s *AttendanceService) GetExtraStats(ctx context.Context, staffID string, data []StaffRow) AttendanceExtraStats {
 	var res AttendanceExtraStats
 
+	if len(data) == 0 {
+		return res
+	}
+
 	decodedStaffID := s.encoder.Decode(staffID)
 	staffDB, err := s.dbRO.GetQueries().GetStaffByID(ctx, decodedStaffID)
 	if err != nil {
 		return res
 	}
 
+	startDate, endDate := data[0].ForDate, data[0].ForDate
+	for _, d := range data {
+		startDate = min(startDate, d.ForDate)
+		endDate = max(endDate, d.ForDate)
+	}
+	schedule, err := s.shift.GetSchedule(ctx, decodedStaffID, startDate, endDate)
+	if err != nil {
+		return res
+	}
+
 	for _, d := range data {
-		c := s.ComputeData(StaffRowBase(staffDB), d)
+		c := s.ComputeData(StaffRowBase(staffDB), d, schedule)
 		res.TotalLateMinutes += c.Attendance.InLate.Minutes()
 		if c.Attendance.InStatus == enums.TIME_IN_STATUS_LATE {
 			res.TotalLateCount++
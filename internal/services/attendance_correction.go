@@ -0,0 +1,421 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+type AttendanceCorrectionService struct {
+	encoder  encode.IEncode
+	dbRO     database.IService
+	dbRW     database.IService
+	staffLog *StaffLogsService
+}
+
+func NewAttendanceCorrectionService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	staffLog *StaffLogsService,
+) *AttendanceCorrectionService {
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &AttendanceCorrectionService{
+		encoder:  encoder,
+		dbRO:     dbRO,
+		dbRW:     dbRW,
+		staffLog: staffLog,
+	}
+}
+
+// correctionTime turns an HH:MM in PH time on date into the UTC datetime
+// stored in tbl_staff_attendances.
+func correctionTime(date string, hhmm string) (sql.NullString, error) {
+	if hhmm == "" {
+		return sql.NullString{}, nil
+	}
+	if _, err := time.Parse(constants.TimeLayoutHHMM, hhmm); err != nil {
+		return sql.NullString{}, errs.ErrAttendanceCorrectionInvalidTime
+	}
+	t, err := utils.ParseInPH(constants.DateLayoutISO+" "+constants.TimeLayoutHHMM, date+" "+hhmm)
+	if err != nil {
+		return sql.NullString{}, errs.ErrAttendanceCorrectionInvalidTime
+	}
+	return sql.NullString{String: t.UTC().Format(constants.DateTimeLayoutISO), Valid: true}, nil
+}
+
+func correctionHHMM(datetime sql.NullString) string {
+	if !datetime.Valid {
+		return ""
+	}
+	t := utils.ExtractTimeToPH(datetime.String)
+	if len(t) >= len(constants.TimeLayoutHHMM) {
+		return t[:len(constants.TimeLayoutHHMM)]
+	}
+	return t
+}
+
+// correctionPunch returns the requested punch when there is one, otherwise
+// the punch already recorded.
+func correctionPunch(requested, existing sql.NullString) sql.NullString {
+	if requested.Valid {
+		return requested
+	}
+	return existing
+}
+
+func correctionInOrder(a, b sql.NullString) bool {
+	return !a.Valid || !b.Valid || a.String < b.String
+}
+
+// RequestCorrection files a correction of the punches of staffID on date.
+// Nothing changes on the attendance until a superuser approves it.
+func (s *AttendanceCorrectionService) RequestCorrection(
+	ctx context.Context,
+	staffID string,
+	date string,
+	times AttendanceCorrectionTimes,
+	reason string,
+) (string, error) {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionCreate,
+			constants.ModuleAttendanceCorrections,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return "", errs.ErrDecode
+	}
+
+	forDate, err := utils.ParseInPH(constants.DateLayoutISO, date)
+	if err != nil {
+		result = errs.ErrAttendanceCorrectionInvalidDate.Error()
+		return "", errs.ErrAttendanceCorrectionInvalidDate
+	}
+	today := utils.NowPH()
+	if forDate.After(today) || forDate.Before(today.AddDate(0, 0, -constants.AttendanceCorrectionMaxAgeDays)) {
+		result = errs.ErrAttendanceCorrectionInvalidDate.Error()
+		return "", errs.ErrAttendanceCorrectionInvalidDate
+	}
+
+	if times == (AttendanceCorrectionTimes{}) {
+		result = errs.ErrAttendanceCorrectionEmpty.Error()
+		return "", errs.ErrAttendanceCorrectionEmpty
+	}
+
+	params := queries.CreateStaffAttendanceCorrectionParams{
+		StaffID: dbStaffID,
+		ForDate: date,
+		Reason:  reason,
+	}
+	for _, field := range []struct {
+		dst  *sql.NullString
+		hhmm string
+	}{
+		{&params.TimeIn, times.TimeIn},
+		{&params.TimeOut, times.TimeOut},
+		{&params.LunchBreakIn, times.LunchBreakIn},
+		{&params.LunchBreakOut, times.LunchBreakOut},
+	} {
+		if *field.dst, err = correctionTime(date, field.hhmm); err != nil {
+			result = err.Error()
+			return "", err
+		}
+	}
+
+	existing, err := s.dbRO.GetQueries().GetStaffAttendanceByDate(ctx, queries.GetStaffAttendanceByDateParams{
+		StaffID: dbStaffID,
+		ForDate: date,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		result = err.Error()
+		return "", errors.Join(errs.ErrAttendanceCorrection, err)
+	}
+
+	timeIn := correctionPunch(params.TimeIn, existing.TimeIn)
+	if !timeIn.Valid {
+		result = errs.ErrAttendanceCorrectionNoTimeIn.Error()
+		return "", errs.ErrAttendanceCorrectionNoTimeIn
+	}
+	lunchBreakIn := correctionPunch(params.LunchBreakIn, existing.LunchBreakIn)
+	if !correctionInOrder(timeIn, correctionPunch(params.TimeOut, existing.TimeOut)) ||
+		!correctionInOrder(lunchBreakIn, correctionPunch(params.LunchBreakOut, existing.LunchBreakOut)) {
+		result = errs.ErrAttendanceCorrectionTimeOrder.Error()
+		return "", errs.ErrAttendanceCorrectionTimeOrder
+	}
+
+	if _, err := s.dbRO.GetQueries().GetPendingStaffAttendanceCorrectionForDate(ctx, queries.GetPendingStaffAttendanceCorrectionForDateParams{
+		StaffID: dbStaffID,
+		ForDate: date,
+	}); err == nil {
+		result = errs.ErrAttendanceCorrectionPending.Error()
+		return "", errs.ErrAttendanceCorrectionPending
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		result = err.Error()
+		return "", errors.Join(errs.ErrAttendanceCorrection, err)
+	}
+
+	id, err := s.dbRW.GetQueries().CreateStaffAttendanceCorrection(ctx, params)
+	if err != nil {
+		result = err.Error()
+		return "", errors.Join(errs.ErrAttendanceCorrection, err)
+	}
+
+	correctionID := s.encoder.Encode(id)
+	result = fmt.Sprintf("success. correction '%s' for %s requested", correctionID, date)
+	return correctionID, nil
+}
+
+// ApproveCorrection applies the requested punches to the attendance of that
+// date. The punches it replaces are kept on the correction.
+func (s *AttendanceCorrectionService) ApproveCorrection(ctx context.Context, adminStaffID string, correctionID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionApprove,
+			constants.ModuleAttendanceCorrections,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	dbCorrectionID := s.encoder.Decode(correctionID)
+	if dbCorrectionID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	var (
+		correction queries.TblStaffAttendanceCorrection
+		existing   queries.GetStaffAttendanceByDateRow
+	)
+	err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		var err error
+		correction, err = qtx.GetStaffAttendanceCorrectionByID(ctx, dbCorrectionID)
+		if err != nil {
+			return err
+		}
+		if enums.ParseAttendanceCorrectionStatusToEnum(correction.Status) != enums.ATTENDANCE_CORRECTION_STATUS_PENDING {
+			return errs.ErrAttendanceCorrectionNotPending
+		}
+
+		existing, err = qtx.GetStaffAttendanceByDate(ctx, queries.GetStaffAttendanceByDateParams{
+			StaffID: correction.StaffID,
+			ForDate: correction.ForDate,
+		})
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			if !correction.TimeIn.Valid {
+				return errs.ErrAttendanceCorrectionNoTimeIn
+			}
+			if _, err := qtx.CreateStaffAttendance(ctx, queries.CreateStaffAttendanceParams{
+				StaffID: correction.StaffID,
+				ForDate: correction.ForDate,
+				TimeIn:  correction.TimeIn,
+				TimeOut: correction.TimeOut,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if _, err := qtx.CorrectStaffAttendance(ctx, queries.CorrectStaffAttendanceParams{
+			TimeIn:        correction.TimeIn,
+			TimeOut:       correction.TimeOut,
+			LunchBreakIn:  correction.LunchBreakIn,
+			LunchBreakOut: correction.LunchBreakOut,
+			StaffID:       correction.StaffID,
+			ForDate:       correction.ForDate,
+		}); err != nil {
+			return err
+		}
+
+		affected, err := qtx.ApproveStaffAttendanceCorrection(ctx, queries.ApproveStaffAttendanceCorrectionParams{
+			OriginalTimeIn:        existing.TimeIn,
+			OriginalTimeOut:       existing.TimeOut,
+			OriginalLunchBreakIn:  existing.LunchBreakIn,
+			OriginalLunchBreakOut: existing.LunchBreakOut,
+			DecidedBy:             sql.NullInt64{Int64: s.encoder.Decode(adminStaffID), Valid: true},
+			ID:                    dbCorrectionID,
+		})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return errs.ErrAttendanceCorrectionNotPending
+		}
+		return nil
+	})
+	if errors.Is(err, errs.ErrAttendanceCorrectionNotPending) || errors.Is(err, errs.ErrAttendanceCorrectionNoTimeIn) {
+		result = err.Error()
+		return err
+	}
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrAttendanceCorrection, err)
+	}
+
+	result = fmt.Sprintf(
+		"success. correction '%s' of staff '%s' on %s applied. time in %q -> %q, time out %q -> %q, lunch break %q-%q -> %q-%q",
+		correctionID,
+		s.encoder.Encode(correction.StaffID),
+		correction.ForDate,
+		correctionHHMM(existing.TimeIn), correctionHHMM(correctionPunch(correction.TimeIn, existing.TimeIn)),
+		correctionHHMM(existing.TimeOut), correctionHHMM(correctionPunch(correction.TimeOut, existing.TimeOut)),
+		correctionHHMM(existing.LunchBreakIn), correctionHHMM(existing.LunchBreakOut),
+		correctionHHMM(correctionPunch(correction.LunchBreakIn, existing.LunchBreakIn)), correctionHHMM(correctionPunch(correction.LunchBreakOut, existing.LunchBreakOut)),
+	)
+	return nil
+}
+
+func (s *AttendanceCorrectionService) RejectCorrection(ctx context.Context, adminStaffID string, correctionID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionReject,
+			constants.ModuleAttendanceCorrections,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	dbCorrectionID := s.encoder.Decode(correctionID)
+	if dbCorrectionID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	affected, err := s.dbRW.GetQueries().RejectStaffAttendanceCorrection(ctx, queries.RejectStaffAttendanceCorrectionParams{
+		DecidedBy: sql.NullInt64{Int64: s.encoder.Decode(adminStaffID), Valid: true},
+		ID:        dbCorrectionID,
+	})
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrAttendanceCorrection, err)
+	}
+	if affected == 0 {
+		result = errs.ErrAttendanceCorrectionNotPending.Error()
+		return errs.ErrAttendanceCorrectionNotPending
+	}
+
+	result = fmt.Sprintf("success. correction '%s' rejected", correctionID)
+	return nil
+}
+
+// GetCorrections lists the corrections of staffID, or of everyone when
+// staffID is empty. An undefined status lists all statuses.
+func (s *AttendanceCorrectionService) GetCorrections(
+	ctx context.Context,
+	staffID string,
+	status enums.AttendanceCorrectionStatus,
+) ([]AttendanceCorrection, error) {
+	var dbStaffID int64
+	if staffID != "" {
+		dbStaffID = s.encoder.Decode(staffID)
+		if dbStaffID == encode.INVALID {
+			return nil, errs.ErrDecode
+		}
+	}
+	var statusFilter string
+	if status != enums.ATTENDANCE_CORRECTION_STATUS_UNDEFINED {
+		statusFilter = status.String()
+	}
+
+	rows, err := s.dbRO.GetQueries().GetStaffAttendanceCorrections(ctx, queries.GetStaffAttendanceCorrectionsParams{
+		StaffID: dbStaffID,
+		Status:  statusFilter,
+		Limit:   constants.AttendanceCorrectionListLimit,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrAttendanceCorrection, err)
+	}
+
+	res := make([]AttendanceCorrection, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, AttendanceCorrection{
+			ID:        s.encoder.Encode(row.ID),
+			StaffID:   s.encoder.Encode(row.StaffID),
+			StaffName: utils.BuildFullName(row.FirstName, row.MiddleName.String, row.LastName),
+			Date:      row.ForDate,
+			Requested: AttendanceCorrectionTimes{
+				TimeIn:        correctionHHMM(row.TimeIn),
+				TimeOut:       correctionHHMM(row.TimeOut),
+				LunchBreakIn:  correctionHHMM(row.LunchBreakIn),
+				LunchBreakOut: correctionHHMM(row.LunchBreakOut),
+			},
+			Original: AttendanceCorrectionTimes{
+				TimeIn:        correctionHHMM(row.OriginalTimeIn),
+				TimeOut:       correctionHHMM(row.OriginalTimeOut),
+				LunchBreakIn:  correctionHHMM(row.OriginalLunchBreakIn),
+				LunchBreakOut: correctionHHMM(row.OriginalLunchBreakOut),
+			},
+			Reason:    row.Reason,
+			Status:    enums.ParseAttendanceCorrectionStatusToEnum(row.Status),
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+func (s *AttendanceCorrectionService) GetCorrectedDates(ctx context.Context, startDate string, endDate string) (CorrectedDates, error) {
+	rows, err := s.dbRO.GetQueries().GetApprovedStaffAttendanceCorrectionsByDateRange(ctx, queries.GetApprovedStaffAttendanceCorrectionsByDateRangeParams{
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrAttendanceCorrection, err)
+	}
+
+	res := make(CorrectedDates)
+	for _, row := range rows {
+		if res[row.StaffID] == nil {
+			res[row.StaffID] = make(map[string]bool)
+		}
+		res[row.StaffID][row.ForDate] = true
+	}
+	return res, nil
+}
+
+func (s *AttendanceCorrectionService) ID() string {
+	return "AttendanceCorrection"
+}
+
+func (s *AttendanceCorrectionService) Log() {
+	logs.Log().Info("[AttendanceCorrectionService] Loaded")
+}
+
+var _ IService = (*AttendanceCorrectionService)(nil)
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"time"
+
+	"cchoice/internal/enums"
+)
+
+// AttendanceCorrectionTimes are the punches of a correction in PH time as
+// HH:MM. An empty value means that punch is not part of the correction.
+type AttendanceCorrectionTimes struct {
+	TimeIn        string
+	TimeOut       string
+	LunchBreakIn  string
+	LunchBreakOut string
+}
+
+type AttendanceCorrection struct {
+	ID        string
+	StaffID   string
+	StaffName string
+	Date      string
+	Requested AttendanceCorrectionTimes
+	Original  AttendanceCorrectionTimes
+	Reason    string
+	Status    enums.AttendanceCorrectionStatus
+	CreatedAt time.Time
+}
+
+// CorrectedDates holds the dates of each staff that had an approved
+// correction, keyed by the DB staff ID.
+type CorrectedDates map[int64]map[string]bool
+
+func (c CorrectedDates) Has(staffID int64, date string) bool {
+	return c[staffID][date]
+}
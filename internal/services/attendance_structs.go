@@ -13,6 +13,7 @@ type attendanceStatusResult struct {
 	outStatus     enums.TimeOutStatus
 	inLate        time.Duration
 	undertime     time.Duration
+	overtime      time.Duration
 	earlyIn       time.Duration
 }
 
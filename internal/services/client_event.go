@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cchoice/internal/conf"
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+	"cchoice/internal/metrics"
+
+	"go.uber.org/zap"
+)
+
+type ClientEventService struct {
+	dbRO   database.IService
+	dbRW   database.IService
+	events chan ClientEvent
+}
+
+func NewClientEventService(
+	dbRO database.IService,
+	dbRW database.IService,
+	bufferSize int,
+) *ClientEventService {
+	return &ClientEventService{
+		dbRO:   dbRO,
+		dbRW:   dbRW,
+		events: make(chan ClientEvent, max(bufferSize, 1)),
+	}
+}
+
+// Record queues the event for the next batch. It never blocks the request;
+// when the buffer is full the event is dropped and counted instead.
+func (s *ClientEventService) Record(ev ClientEvent) {
+	if ev.At.IsZero() {
+		ev.At = time.Now()
+	}
+	select {
+	case s.events <- ev:
+	default:
+		metrics.ClientEvent.Dropped("buffer_full", 1)
+	}
+}
+
+func (s *ClientEventService) writeBatch(ctx context.Context, batch []ClientEvent) error {
+	err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		for _, ev := range batch {
+			if err := qtx.CreateClientEvent(ctx, queries.CreateClientEventParams{
+				Event:       ev.Event,
+				Value:       ev.Value,
+				SessionHash: ev.SessionHash,
+				CustomerID:  ev.CustomerID,
+				ProductID:   ev.ProductID,
+				ResultCount: ev.ResultCount,
+				CreatedAt:   ev.At.UTC().Format(time.DateTime),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Join(errs.ErrClientEvent, err)
+	}
+	return nil
+}
+
+// Rollup aggregates every day before today into tbl_client_event_rollups and
+// then deletes raw events older than the retention. The latest rolled up day
+// is recomputed each run to pick up events that were flushed after it.
+func (s *ClientEventService) Rollup(ctx context.Context, now time.Time, retentionDays int) (int64, error) {
+	since, err := s.dbRO.GetQueries().GetLatestClientEventRollupDay(ctx)
+	if err != nil {
+		return 0, errors.Join(errs.ErrClientEvent, err)
+	}
+
+	today := now.UTC()
+	var deleted int64
+	err = s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		if err := qtx.RollupClientEvents(ctx, queries.RollupClientEventsParams{
+			Since:  since,
+			Before: today.Format(constants.DateLayoutISO),
+		}); err != nil {
+			return err
+		}
+		if retentionDays <= 0 {
+			return nil
+		}
+		n, err := qtx.DeleteClientEventsBefore(ctx, today.AddDate(0, 0, -retentionDays).Format(constants.DateLayoutISO))
+		deleted = n
+		return err
+	})
+	if err != nil {
+		return 0, errors.Join(errs.ErrClientEvent, err)
+	}
+	return deleted, nil
+}
+
+func (s *ClientEventService) RunScheduler(ctx context.Context) {
+	const logtag = "[ClientEventService] RunScheduler"
+	logs.Log().Info("[ClientEventService] Starting client event writer")
+
+	cfg := conf.Conf().Events
+	flushTicker := time.NewTicker(cfg.FlushInterval)
+	defer flushTicker.Stop()
+	rollupTicker := time.NewTicker(cfg.RollupInterval)
+	defer rollupTicker.Stop()
+
+	batch := make([]ClientEvent, 0, cfg.BatchSize)
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.writeBatch(ctx, batch); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Int("events", len(batch)), zap.Error(err))
+			metrics.ClientEvent.Dropped("write_failed", len(batch))
+		} else {
+			metrics.ClientEvent.Stored(len(batch))
+		}
+		batch = batch[:0]
+	}
+	rollup := func() {
+		deleted, err := s.Rollup(ctx, time.Now(), cfg.RetentionDays)
+		if err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		} else if deleted > 0 {
+			logs.LogCtx(ctx).Info(logtag, zap.Int64("expired_events", deleted))
+		}
+	}
+
+	rollup()
+	for {
+		select {
+		case <-ctx.Done():
+			// Write whatever is already buffered so a restart does not lose it.
+		drain:
+			for {
+				select {
+				case ev := <-s.events:
+					batch = append(batch, ev)
+				default:
+					break drain
+				}
+			}
+			flush(context.Background())
+			return
+		case ev := <-s.events:
+			batch = append(batch, ev)
+			if len(batch) >= cfg.BatchSize {
+				flush(ctx)
+			}
+		case <-flushTicker.C:
+			flush(ctx)
+		case <-rollupTicker.C:
+			rollup()
+		}
+	}
+}
+
+func (s *ClientEventService) ID() string {
+	return "ClientEvent"
+}
+
+func (s *ClientEventService) Log() {
+	logs.Log().Info("[ClientEventService] Loaded")
+}
+
+var _ IService = (*ClientEventService)(nil)
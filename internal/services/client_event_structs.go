@@ -0,0 +1,16 @@
+package services
+
+import (
+	"database/sql"
+	"time"
+)
+
+type ClientEvent struct {
+	Event       string
+	Value       string
+	SessionHash string
+	CustomerID  sql.NullInt64
+	ProductID   sql.NullInt64
+	ResultCount sql.NullInt64
+	At          time.Time
+}
@@ -0,0 +1,634 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+	"cchoice/internal/metrics"
+	"cchoice/internal/utils"
+)
+
+type ExperimentService struct {
+	encoder  encode.IEncode
+	dbRO     database.IService
+	dbRW     database.IService
+	staffLog *StaffLogsService
+}
+
+func NewExperimentService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	staffLog *StaffLogsService,
+) *ExperimentService {
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &ExperimentService{
+		encoder:  encoder,
+		dbRO:     dbRO,
+		dbRW:     dbRW,
+		staffLog: staffLog,
+	}
+}
+
+func (s *ExperimentService) CreateExperiment(
+	ctx context.Context,
+	staffID string,
+	name string,
+	kind enums.ExperimentKind,
+	startDate time.Time,
+	endDate time.Time,
+) (string, error) {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionCreate,
+			constants.ModuleExperiments,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	name = strings.TrimSpace(name)
+	if name == "" || kind == enums.EXPERIMENT_KIND_UNDEFINED {
+		result = errs.ErrValidation.Error()
+		return "", errs.ErrValidation
+	}
+	if startDate.After(endDate) {
+		result = errs.ErrValidationStartEndDates.Error()
+		return "", errs.ErrValidationStartEndDates
+	}
+
+	createdBy := s.encoder.Decode(staffID)
+	if createdBy == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return "", errs.ErrDecode
+	}
+
+	id, err := s.dbRW.GetQueries().CreateExperiment(ctx, queries.CreateExperimentParams{
+		Name:      name,
+		Kind:      kind.String(),
+		StartDate: startDate.Format(constants.DateLayoutISO),
+		EndDate:   endDate.Format(constants.DateLayoutISO),
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		result = err.Error()
+		return "", errors.Join(errs.ErrExperiment, err)
+	}
+
+	experimentID := s.encoder.Encode(id)
+	result = fmt.Sprintf("success. ID '%s'", experimentID)
+	return experimentID, nil
+}
+
+func (s *ExperimentService) GetExperiments(ctx context.Context) ([]Experiment, error) {
+	rows, err := s.dbRO.GetQueries().GetExperiments(ctx)
+	if err != nil {
+		return nil, errors.Join(errs.ErrExperiment, err)
+	}
+
+	today := time.Now().Format(constants.DateLayoutISO)
+	result := make([]Experiment, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, s.mapRowToExperiment(row, today))
+	}
+	return result, nil
+}
+
+// GetExperimentDetail returns the experiment with one result row per variant.
+// The first variant is the control the others are compared against.
+func (s *ExperimentService) GetExperimentDetail(ctx context.Context, experimentID string) (*ExperimentDetail, error) {
+	id := s.encoder.Decode(experimentID)
+	if id == encode.INVALID {
+		return nil, errs.ErrDecode
+	}
+
+	q := s.dbRO.GetQueries()
+	row, err := q.GetExperimentByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrExperimentNotFound
+		}
+		return nil, errors.Join(errs.ErrExperiment, err)
+	}
+
+	variants, err := s.getVariants(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := q.GetExperimentVariantResults(ctx, id)
+	if err != nil {
+		return nil, errors.Join(errs.ErrExperiment, err)
+	}
+	statsByVariant := make(map[int64]queries.GetExperimentVariantResultsRow, len(stats))
+	for _, st := range stats {
+		statsByVariant[st.VariantID] = st
+	}
+
+	results := make([]ExperimentVariantResult, 0, len(variants))
+	var control queries.GetExperimentVariantResultsRow
+	for i, v := range variants {
+		st := statsByVariant[s.encoder.Decode(v.ID)]
+		res := ExperimentVariantResult{
+			Variant:       v,
+			Control:       i == 0,
+			Exposures:     st.Exposures,
+			AddedToCart:   st.AddedToCart,
+			AddToCartRate: formatRate(st.AddedToCart, st.Exposures),
+			PaidOrders:    st.PaidOrders,
+			OrderRate:     formatRate(st.PaidOrders, st.Exposures),
+			Revenue:       utils.NewMoney(st.Revenue, constants.PHP).Display(),
+		}
+		if i == 0 {
+			control = st
+		} else {
+			res.AddToCart = compareExperimentRates(control.AddedToCart, control.Exposures, st.AddedToCart, st.Exposures)
+			res.Order = compareExperimentRates(control.PaidOrders, control.Exposures, st.PaidOrders, st.Exposures)
+		}
+		results = append(results, res)
+	}
+
+	return &ExperimentDetail{
+		Experiment: s.mapRowToExperiment(row, time.Now().Format(constants.DateLayoutISO)),
+		Results:    results,
+	}, nil
+}
+
+// AddVariant adds a variant to a draft experiment. THEME variants take a
+// theme and PROMO_BANNERS variants take promos; leaving both empty makes the
+// variant a control that keeps the regular storefront.
+func (s *ExperimentService) AddVariant(
+	ctx context.Context,
+	staffID string,
+	experimentID string,
+	name string,
+	weight int64,
+	themeID string,
+	promoIDs []string,
+) (string, error) {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionCreate,
+			constants.ModuleExperiments,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	experiment, err := s.getDraftExperiment(ctx, experimentID)
+	if err != nil {
+		result = err.Error()
+		return "", err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || weight < 1 || weight > constants.ExperimentMaxVariantWeight {
+		result = errs.ErrValidation.Error()
+		return "", errs.ErrValidation
+	}
+
+	var dbThemeID sql.NullInt64
+	dbPromoIDs := make([]int64, 0, len(promoIDs))
+	switch enums.ParseExperimentKindToEnum(experiment.Kind) {
+	case enums.EXPERIMENT_KIND_THEME:
+		if len(promoIDs) > 0 {
+			result = errs.ErrExperimentInvalidVariant.Error()
+			return "", errs.ErrExperimentInvalidVariant
+		}
+		if themeID != "" {
+			decoded := s.encoder.Decode(themeID)
+			if decoded == encode.INVALID {
+				result = errs.ErrDecode.Error()
+				return "", errs.ErrDecode
+			}
+			dbThemeID = sql.NullInt64{Int64: decoded, Valid: true}
+		}
+	case enums.EXPERIMENT_KIND_PROMO_BANNERS:
+		if themeID != "" {
+			result = errs.ErrExperimentInvalidVariant.Error()
+			return "", errs.ErrExperimentInvalidVariant
+		}
+		for _, promoID := range promoIDs {
+			decoded := s.encoder.Decode(promoID)
+			if decoded == encode.INVALID {
+				result = errs.ErrDecode.Error()
+				return "", errs.ErrDecode
+			}
+			dbPromoIDs = append(dbPromoIDs, decoded)
+		}
+	default:
+		result = errs.ErrExperimentInvalidVariant.Error()
+		return "", errs.ErrExperimentInvalidVariant
+	}
+
+	var variantID int64
+	if err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		var err error
+		variantID, err = qtx.CreateExperimentVariant(ctx, queries.CreateExperimentVariantParams{
+			ExperimentID: experiment.ID,
+			Name:         name,
+			Weight:       weight,
+			ThemeID:      dbThemeID,
+		})
+		if err != nil {
+			return err
+		}
+		for _, promoID := range dbPromoIDs {
+			if err := qtx.AddExperimentVariantPromo(ctx, queries.AddExperimentVariantPromoParams{
+				VariantID: variantID,
+				PromoID:   promoID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		result = err.Error()
+		return "", errors.Join(errs.ErrExperiment, err)
+	}
+
+	encodedVariantID := s.encoder.Encode(variantID)
+	result = fmt.Sprintf("success. ID '%s' variant '%s'", experimentID, encodedVariantID)
+	return encodedVariantID, nil
+}
+
+func (s *ExperimentService) DeleteVariant(ctx context.Context, staffID string, experimentID string, variantID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionDelete,
+			constants.ModuleExperiments,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	experiment, err := s.getDraftExperiment(ctx, experimentID)
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+
+	dbVariantID := s.encoder.Decode(variantID)
+	if dbVariantID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	affected, err := s.dbRW.GetQueries().DeleteExperimentVariant(ctx, queries.DeleteExperimentVariantParams{
+		ID:           dbVariantID,
+		ExperimentID: experiment.ID,
+	})
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrExperiment, err)
+	}
+	if affected == 0 {
+		result = errs.ErrExperimentVariantNotFound.Error()
+		return errs.ErrExperimentVariantNotFound
+	}
+
+	result = fmt.Sprintf("success. ID '%s' variant '%s'", experimentID, variantID)
+	return nil
+}
+
+// Publish schedules the experiment. Only one experiment per kind may cover a
+// given day so that a visitor is never split twice over the same surface.
+func (s *ExperimentService) Publish(ctx context.Context, staffID string, experimentID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionUpdateStatus,
+			constants.ModuleExperiments,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	experiment, err := s.getDraftExperiment(ctx, experimentID)
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+
+	q := s.dbRO.GetQueries()
+	variants, err := q.GetExperimentVariants(ctx, experiment.ID)
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrExperiment, err)
+	}
+	if len(variants) < 2 {
+		result = errs.ErrExperimentNotEnoughVariant.Error()
+		return errs.ErrExperimentNotEnoughVariant
+	}
+
+	overlapping, err := q.GetOverlappingExperiments(ctx, queries.GetOverlappingExperimentsParams{
+		Kind:      experiment.Kind,
+		ID:        experiment.ID,
+		StartDate: experiment.StartDate,
+		EndDate:   experiment.EndDate,
+	})
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrExperiment, err)
+	}
+	if len(overlapping) > 0 {
+		result = errs.ErrExperimentOverlappingDates.Error()
+		return errs.ErrExperimentOverlappingDates
+	}
+
+	if err := s.updateStatus(ctx, experiment.ID, enums.EXPERIMENT_STATUS_PUBLISHED); err != nil {
+		result = err.Error()
+		return err
+	}
+
+	result = fmt.Sprintf("success. ID '%s' published", experimentID)
+	return nil
+}
+
+// Unpublish stops the experiment and returns it to draft. Collected
+// exposures are kept so the report still shows them.
+func (s *ExperimentService) Unpublish(ctx context.Context, staffID string, experimentID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionUpdateStatus,
+			constants.ModuleExperiments,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	id := s.encoder.Decode(experimentID)
+	if id == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+	if err := s.updateStatus(ctx, id, enums.EXPERIMENT_STATUS_DRAFT); err != nil {
+		result = err.Error()
+		return err
+	}
+
+	result = fmt.Sprintf("success. ID '%s' unpublished", experimentID)
+	return nil
+}
+
+func (s *ExperimentService) DeleteExperiment(ctx context.Context, staffID string, experimentID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionDelete,
+			constants.ModuleExperiments,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	id := s.encoder.Decode(experimentID)
+	if id == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+	if err := s.updateStatus(ctx, id, enums.EXPERIMENT_STATUS_DELETED); err != nil {
+		result = err.Error()
+		return err
+	}
+
+	result = fmt.Sprintf("success. ID '%s'", experimentID)
+	return nil
+}
+
+// Assign returns the visitor's variant for the running experiment of the
+// given kind, or nil when none is running. The first assignment is stored as
+// an exposure so later visits and conversions stay on the same variant.
+func (s *ExperimentService) Assign(ctx context.Context, kind enums.ExperimentKind, bucketKey string) (*ExperimentAssignment, error) {
+	if bucketKey == "" {
+		return nil, nil
+	}
+
+	q := s.dbRO.GetQueries()
+	experiment, err := q.GetRunningExperimentByKind(ctx, kind.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Join(errs.ErrExperiment, err)
+	}
+
+	variants, err := q.GetExperimentVariants(ctx, experiment.ID)
+	if err != nil {
+		return nil, errors.Join(errs.ErrExperiment, err)
+	}
+	if len(variants) < 2 {
+		return nil, nil
+	}
+
+	assigned, err := q.GetExperimentExposure(ctx, queries.GetExperimentExposureParams{
+		ExperimentID: experiment.ID,
+		BucketKey:    bucketKey,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Join(errs.ErrExperiment, err)
+	}
+	for _, v := range variants {
+		if v.ID == assigned {
+			return newExperimentAssignment(experiment, v), nil
+		}
+	}
+
+	rows := make([]experimentVariantRow, 0, len(variants))
+	for _, v := range variants {
+		rows = append(rows, experimentVariantRow{id: v.ID, weight: v.Weight})
+	}
+	picked, ok := pickExperimentVariant(rows, experiment.ID, bucketKey)
+	if !ok {
+		return nil, nil
+	}
+
+	if err := s.dbRW.GetQueries().CreateExperimentExposure(ctx, queries.CreateExperimentExposureParams{
+		ExperimentID: experiment.ID,
+		VariantID:    picked.id,
+		BucketKey:    bucketKey,
+	}); err != nil {
+		return nil, errors.Join(errs.ErrExperiment, err)
+	}
+	metrics.Experiment.Exposure(s.encoder.Encode(experiment.ID), s.encoder.Encode(picked.id))
+
+	for _, v := range variants {
+		if v.ID == picked.id {
+			return newExperimentAssignment(experiment, v), nil
+		}
+	}
+	return nil, nil
+}
+
+// MarkAddToCart records the first add to cart of an exposed visitor in every
+// running experiment.
+func (s *ExperimentService) MarkAddToCart(ctx context.Context, bucketKey string) error {
+	if bucketKey == "" {
+		return nil
+	}
+
+	rows, err := s.dbRW.GetQueries().MarkExperimentAddToCart(ctx, bucketKey)
+	if err != nil {
+		return errors.Join(errs.ErrExperiment, err)
+	}
+	for _, row := range rows {
+		metrics.Experiment.AddToCart(s.encoder.Encode(row.ExperimentID), s.encoder.Encode(row.VariantID))
+	}
+	return nil
+}
+
+// AttachCheckout links the visitor's exposures to their checkout so paid
+// orders can be credited to the variant.
+func (s *ExperimentService) AttachCheckout(ctx context.Context, bucketKey string, checkoutID int64) error {
+	if bucketKey == "" {
+		return nil
+	}
+
+	if err := s.dbRW.GetQueries().SetExperimentExposureCheckout(ctx, queries.SetExperimentExposureCheckoutParams{
+		CheckoutID: sql.NullInt64{Int64: checkoutID, Valid: true},
+		BucketKey:  bucketKey,
+	}); err != nil {
+		return errors.Join(errs.ErrExperiment, err)
+	}
+	return nil
+}
+
+func (s *ExperimentService) getDraftExperiment(ctx context.Context, experimentID string) (queries.TblExperiment, error) {
+	id := s.encoder.Decode(experimentID)
+	if id == encode.INVALID {
+		return queries.TblExperiment{}, errs.ErrDecode
+	}
+
+	experiment, err := s.dbRO.GetQueries().GetExperimentByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return queries.TblExperiment{}, errs.ErrExperimentNotFound
+		}
+		return queries.TblExperiment{}, errors.Join(errs.ErrExperiment, err)
+	}
+	if experiment.Status != enums.EXPERIMENT_STATUS_DRAFT.String() {
+		return queries.TblExperiment{}, errs.ErrExperimentPublished
+	}
+	return experiment, nil
+}
+
+func (s *ExperimentService) getVariants(ctx context.Context, experimentID int64) ([]ExperimentVariant, error) {
+	q := s.dbRO.GetQueries()
+	rows, err := q.GetExperimentVariants(ctx, experimentID)
+	if err != nil {
+		return nil, errors.Join(errs.ErrExperiment, err)
+	}
+	promos, err := q.GetExperimentVariantPromos(ctx, experimentID)
+	if err != nil {
+		return nil, errors.Join(errs.ErrExperiment, err)
+	}
+
+	promoNames := make(map[int64][]string, len(rows))
+	for _, p := range promos {
+		promoNames[p.VariantID] = append(promoNames[p.VariantID], p.Title)
+	}
+
+	result := make([]ExperimentVariant, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, ExperimentVariant{
+			ID:         s.encoder.Encode(row.ID),
+			Name:       row.Name,
+			Weight:     row.Weight,
+			ThemeTitle: row.ThemeTitle,
+			PromoNames: promoNames[row.ID],
+		})
+	}
+	return result, nil
+}
+
+func (s *ExperimentService) updateStatus(ctx context.Context, id int64, status enums.ExperimentStatus) error {
+	affected, err := s.dbRW.GetQueries().UpdateExperimentStatus(ctx, queries.UpdateExperimentStatusParams{
+		Status: status.String(),
+		ID:     id,
+	})
+	if err != nil {
+		return errors.Join(errs.ErrExperiment, err)
+	}
+	if affected == 0 {
+		return errs.ErrExperimentNotFound
+	}
+	return nil
+}
+
+func (s *ExperimentService) mapRowToExperiment(row queries.TblExperiment, today string) Experiment {
+	status := enums.ParseExperimentStatusToEnum(row.Status)
+	return Experiment{
+		ID:        s.encoder.Encode(row.ID),
+		Name:      row.Name,
+		Kind:      enums.ParseExperimentKindToEnum(row.Kind),
+		Status:    status,
+		StartDate: row.StartDate,
+		EndDate:   row.EndDate,
+		Running:   status == enums.EXPERIMENT_STATUS_PUBLISHED && row.StartDate <= today && row.EndDate >= today,
+		CreatedAt: row.CreatedAt.Format(constants.DateTimeLayoutISO),
+	}
+}
+
+func newExperimentAssignment(experiment queries.TblExperiment, v queries.GetExperimentVariantsRow) *ExperimentAssignment {
+	return &ExperimentAssignment{
+		ExperimentID:   experiment.ID,
+		ExperimentName: experiment.Name,
+		VariantID:      v.ID,
+		VariantName:    v.Name,
+		ThemeID:        v.ThemeID.Int64,
+		HasTheme:       v.ThemeID.Valid,
+	}
+}
+
+func (s *ExperimentService) ID() string {
+	return "Experiment"
+}
+
+func (s *ExperimentService) Log() {
+	logs.Log().Info("[ExperimentService] Loaded")
+}
+
+var _ IService = (*ExperimentService)(nil)
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+
+	"cchoice/internal/constants"
+)
+
+// pickExperimentVariant maps a bucket key onto a variant by weight. The same
+// key always lands on the same variant for a given experiment.
+func pickExperimentVariant(variants []experimentVariantRow, experimentID int64, bucketKey string) (experimentVariantRow, bool) {
+	var total int64
+	for _, v := range variants {
+		total += v.weight
+	}
+	if total <= 0 {
+		return experimentVariantRow{}, false
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strconv.FormatInt(experimentID, 10) + ":" + bucketKey))
+	point := int64(h.Sum64() % uint64(total))
+	for _, v := range variants {
+		if point < v.weight {
+			return v, true
+		}
+		point -= v.weight
+	}
+	return variants[len(variants)-1], true
+}
+
+// twoProportionPValue is the two-sided p-value of a pooled two-proportion
+// z-test. ok is false when either group is empty or the pooled rate is 0 or
+// 1, where the test says nothing.
+func twoProportionPValue(convA, nA, convB, nB int64) (float64, bool) {
+	if nA <= 0 || nB <= 0 {
+		return 0, false
+	}
+	pooled := float64(convA+convB) / float64(nA+nB)
+	if pooled <= 0 || pooled >= 1 {
+		return 0, false
+	}
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(nA) + 1/float64(nB)))
+	z := (float64(convB)/float64(nB) - float64(convA)/float64(nA)) / se
+	return math.Erfc(math.Abs(z) / math.Sqrt2), true
+}
+
+func compareExperimentRates(controlConv, controlN, conv, n int64) ExperimentComparison {
+	p, ok := twoProportionPValue(controlConv, controlN, conv, n)
+	if !ok {
+		return ExperimentComparison{}
+	}
+
+	res := ExperimentComparison{
+		PValue:      fmt.Sprintf("%.3f", p),
+		Significant: p < constants.ExperimentSignificanceLevel,
+	}
+	if controlConv > 0 {
+		controlRate := float64(controlConv) / float64(controlN)
+		rate := float64(conv) / float64(n)
+		res.Lift = fmt.Sprintf("%+.1f%%", (rate-controlRate)/controlRate*100)
+	}
+	return res
+}
+
+func formatRate(conv, n int64) string {
+	if n <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", float64(conv)/float64(n)*100)
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickExperimentVariant_Sticky(t *testing.T) {
+	variants := []experimentVariantRow{{id: 1, weight: 1}, {id: 2, weight: 1}}
+
+	first, ok := pickExperimentVariant(variants, 7, "bucket-a")
+	require.True(t, ok)
+	for range 10 {
+		again, _ := pickExperimentVariant(variants, 7, "bucket-a")
+		assert.Equal(t, first, again)
+	}
+}
+
+func TestPickExperimentVariant_Weights(t *testing.T) {
+	variants := []experimentVariantRow{{id: 1, weight: 3}, {id: 2, weight: 1}}
+
+	counts := map[int64]int{}
+	for i := range 4000 {
+		v, ok := pickExperimentVariant(variants, 1, fmt.Sprintf("bucket-%d", i))
+		require.True(t, ok)
+		counts[v.id]++
+	}
+	assert.InDelta(t, 3000, counts[1], 200)
+	assert.InDelta(t, 1000, counts[2], 200)
+
+	_, ok := pickExperimentVariant([]experimentVariantRow{{id: 1}}, 1, "bucket")
+	assert.False(t, ok, "zero total weight")
+}
+
+func TestTwoProportionPValue(t *testing.T) {
+	p, ok := twoProportionPValue(100, 1000, 130, 1000)
+	require.True(t, ok)
+	assert.InDelta(t, 0.0355, p, 0.001)
+
+	p, ok = twoProportionPValue(50, 500, 50, 500)
+	require.True(t, ok)
+	assert.InDelta(t, 1.0, p, 1e-9)
+
+	_, ok = twoProportionPValue(0, 0, 5, 10)
+	assert.False(t, ok, "empty control")
+	_, ok = twoProportionPValue(0, 10, 0, 10)
+	assert.False(t, ok, "no conversions")
+}
+
+func TestCompareExperimentRates(t *testing.T) {
+	res := compareExperimentRates(100, 1000, 130, 1000)
+	assert.True(t, res.Significant)
+	assert.Equal(t, "+30.0%", res.Lift)
+	assert.Equal(t, "0.035", res.PValue)
+
+	res = compareExperimentRates(10, 100, 11, 100)
+	assert.False(t, res.Significant)
+
+	assert.Equal(t, ExperimentComparison{}, compareExperimentRates(0, 0, 1, 10))
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"cchoice/internal/enums"
+)
+
+type Experiment struct {
+	ID        string
+	Name      string
+	Kind      enums.ExperimentKind
+	Status    enums.ExperimentStatus
+	StartDate string
+	EndDate   string
+	Running   bool
+	CreatedAt string
+}
+
+type ExperimentVariant struct {
+	ID         string
+	Name       string
+	Weight     int64
+	ThemeTitle string
+	PromoNames []string
+}
+
+type ExperimentVariantResult struct {
+	Variant       ExperimentVariant
+	Control       bool
+	Exposures     int64
+	AddedToCart   int64
+	AddToCartRate string
+	PaidOrders    int64
+	OrderRate     string
+	Revenue       string
+	// AddToCart and Order compare this variant against the control. They are
+	// empty for the control and while there is not enough data.
+	AddToCart ExperimentComparison
+	Order     ExperimentComparison
+}
+
+type ExperimentComparison struct {
+	Lift        string
+	PValue      string
+	Significant bool
+}
+
+type ExperimentDetail struct {
+	Experiment Experiment
+	Results    []ExperimentVariantResult
+}
+
+// ExperimentAssignment is the variant a visitor sees for a running
+// experiment.
+type ExperimentAssignment struct {
+	ExperimentID   int64
+	ExperimentName string
+	VariantID      int64
+	VariantName    string
+	ThemeID        int64
+	HasTheme       bool
+}
+
+type ExperimentConversion struct {
+	ExperimentID int64
+	VariantID    int64
+}
+
+type experimentVariantRow struct {
+	id     int64
+	weight int64
+}
@@ -232,6 +232,52 @@ func (s *ImageService) UploadPromoBannerImage(
 	return key, nil
 }
 
+func (s *ImageService) UploadReviewPhoto(
+	ctx context.Context,
+	productID string,
+	filename string,
+	file io.Reader,
+	contentType string,
+) (string, error) {
+	const logtag = "[ImageService] Review Photo"
+	if err := s.ValidateContentType(contentType); err != nil {
+		return "", err
+	}
+	data, err := s.ValidateSize(file)
+	if err != nil {
+		return "", err
+	}
+
+	file = bytes.NewReader(data)
+	isLocalStorage := s.objectStorage.ProviderEnum() == storage.STORAGE_PROVIDER_LOCAL
+
+	logs.Log().Info(
+		logtag,
+		zap.String("storing review photo", filename),
+		zap.Stringer("using", s.objectStorage.ProviderEnum()),
+		zap.String("product id", productID),
+		zap.Bool("local storage", isLocalStorage),
+	)
+
+	if !conf.Conf().IsProd() && !conf.Conf().Test.LocalUploadImage || isLocalStorage {
+		sourceName := filepath.Base(filename)
+		localPath := filepath.Join("cmd/web/static/images/review_images", productID, sourceName)
+		if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(localPath, data, 0644); err != nil {
+			return "", err
+		}
+		return s.objectStorage.GetPublicURL(filename), nil
+	}
+
+	if err := s.objectStorage.PutObject(ctx, filename, file, contentType); err != nil {
+		return "", err
+	}
+
+	return s.objectStorage.GetPublicURL(filename), nil
+}
+
 // ValidateThemeLogoContentType restricts theme logo uploads to PNG and SVG
 // only, since logos need transparency and SVG support (unlike product,
 // brand, and promo images, which stay JPEG/PNG/WebP via ValidateContentType).
@@ -0,0 +1,415 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+type IncidentService struct {
+	encoder  encode.IEncode
+	dbRO     database.IService
+	dbRW     database.IService
+	memo     *MemoService
+	staffLog *StaffLogsService
+}
+
+func NewIncidentService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	memo *MemoService,
+	staffLog *StaffLogsService,
+) *IncidentService {
+	if memo == nil {
+		panic("MemoService is required")
+	}
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &IncidentService{
+		encoder:  encoder,
+		dbRO:     dbRO,
+		dbRW:     dbRW,
+		memo:     memo,
+		staffLog: staffLog,
+	}
+}
+
+// ValidateIncidentDecision checks the effective dates of a decision and
+// returns the ones to store. A suspension needs both dates. A warning takes
+// effect on today when no start is given and may have no end. No action
+// keeps no dates.
+func ValidateIncidentDecision(in IncidentDecisionInput, today string) (sql.NullString, sql.NullString, error) {
+	parse := func(date string) (sql.NullString, error) {
+		if date == "" {
+			return sql.NullString{}, nil
+		}
+		if _, err := time.Parse(constants.DateLayoutISO, date); err != nil {
+			return sql.NullString{}, errs.ErrIncidentSuspensionDates
+		}
+		return sql.NullString{String: date, Valid: true}, nil
+	}
+
+	switch in.Decision {
+	case enums.INCIDENT_DECISION_NO_ACTION:
+		return sql.NullString{}, sql.NullString{}, nil
+	case enums.INCIDENT_DECISION_WARNING, enums.INCIDENT_DECISION_SUSPENSION:
+	default:
+		return sql.NullString{}, sql.NullString{}, errs.ErrIncidentInvalidDecision
+	}
+
+	from, err := parse(in.EffectiveFrom)
+	if err != nil {
+		return sql.NullString{}, sql.NullString{}, err
+	}
+	to, err := parse(in.EffectiveTo)
+	if err != nil {
+		return sql.NullString{}, sql.NullString{}, err
+	}
+
+	if in.Decision == enums.INCIDENT_DECISION_SUSPENSION && (!from.Valid || !to.Valid) {
+		return sql.NullString{}, sql.NullString{}, errs.ErrIncidentSuspensionDates
+	}
+	if !from.Valid {
+		from = sql.NullString{String: today, Valid: true}
+	}
+	if to.Valid && to.String < from.String {
+		return sql.NullString{}, sql.NullString{}, errs.ErrIncidentSuspensionDates
+	}
+	return from, to, nil
+}
+
+// FileIncident records an incident against staffID. Nothing is sent to the
+// staff until a notice to explain is issued.
+func (s *IncidentService) FileIncident(
+	ctx context.Context,
+	adminStaffID string,
+	staffID string,
+	incidentDate string,
+	title string,
+	description string,
+) (string, error) {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionCreate,
+			constants.ModuleIncidents,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	dbStaffID := s.encoder.Decode(staffID)
+	dbAdminStaffID := s.encoder.Decode(adminStaffID)
+	if dbStaffID == encode.INVALID || dbAdminStaffID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return "", errs.ErrDecode
+	}
+
+	if _, err := time.Parse(constants.DateLayoutISO, incidentDate); err != nil ||
+		incidentDate > utils.NowPH().Format(constants.DateLayoutISO) {
+		result = errs.ErrIncidentInvalidDate.Error()
+		return "", errs.ErrIncidentInvalidDate
+	}
+
+	id, err := s.dbRW.GetQueries().CreateStaffIncident(ctx, queries.CreateStaffIncidentParams{
+		StaffID:      dbStaffID,
+		ReportedBy:   dbAdminStaffID,
+		IncidentDate: incidentDate,
+		Title:        strings.TrimSpace(title),
+		Description:  strings.TrimSpace(description),
+	})
+	if err != nil {
+		result = err.Error()
+		return "", errors.Join(errs.ErrIncident, err)
+	}
+
+	incidentID := s.encoder.Encode(id)
+	result = fmt.Sprintf("success. incident '%s' filed against staff '%s'", incidentID, staffID)
+	return incidentID, nil
+}
+
+// IssueNTE sends the staff a notice to explain as a published memo that has
+// to be acknowledged by the deadline, the same deadline the written response
+// is due.
+func (s *IncidentService) IssueNTE(
+	ctx context.Context,
+	adminStaffID string,
+	incidentID string,
+	deadline time.Time,
+	message string,
+) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionUpdate,
+			constants.ModuleIncidents,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	dbIncidentID := s.encoder.Decode(incidentID)
+	if dbIncidentID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	today := utils.NowPH()
+	deadlineDate := deadline.Format(constants.DateLayoutISO)
+	if deadlineDate <= today.Format(constants.DateLayoutISO) {
+		result = errs.ErrIncidentDeadline.Error()
+		return errs.ErrIncidentDeadline
+	}
+
+	incident, err := s.dbRO.GetQueries().GetStaffIncidentByID(ctx, dbIncidentID)
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrIncident, err)
+	}
+	if enums.ParseIncidentStatusToEnum(incident.Status) != enums.INCIDENT_STATUS_OPEN {
+		result = errs.ErrIncidentNotOpen.Error()
+		return errs.ErrIncidentNotOpen
+	}
+
+	body := fmt.Sprintf(
+		"You are asked to explain in writing the incident on %s: %s\n\n%s\n\nSubmit your response from the Incidents page on or before %s.",
+		incident.IncidentDate,
+		incident.Title,
+		incident.Description,
+		deadlineDate,
+	)
+	if message = strings.TrimSpace(message); message != "" {
+		body = message + "\n\n" + body
+	}
+
+	memoID, err := s.memo.CreateMemo(
+		ctx,
+		adminStaffID,
+		"Notice to Explain: "+incident.Title,
+		body,
+		"",
+		enums.MEMO_STATUS_PUBLISHED,
+		today,
+		deadline,
+		MemoAcknowledgement{Required: true, Deadline: deadline},
+		[]string{s.encoder.Encode(incident.StaffID)},
+	)
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+
+	affected, err := s.dbRW.GetQueries().IssueStaffIncidentNTE(ctx, queries.IssueStaffIncidentNTEParams{
+		NteMemoID:        sql.NullInt64{Int64: s.encoder.Decode(memoID), Valid: true},
+		ResponseDeadline: sql.NullString{String: deadlineDate, Valid: true},
+		ID:               dbIncidentID,
+	})
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrIncident, err)
+	}
+	if affected == 0 {
+		result = errs.ErrIncidentNotOpen.Error()
+		return errs.ErrIncidentNotOpen
+	}
+
+	result = fmt.Sprintf("success. notice to explain for incident '%s' issued as memo '%s', due %s", incidentID, memoID, deadlineDate)
+	return nil
+}
+
+// Respond stores the written response of the staff to a notice to explain.
+// Only the staff the incident is about can respond, once, up to the deadline.
+func (s *IncidentService) Respond(ctx context.Context, staffID string, incidentID string, response string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionUpdate,
+			constants.ModuleIncidents,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	dbStaffID := s.encoder.Decode(staffID)
+	dbIncidentID := s.encoder.Decode(incidentID)
+	if dbStaffID == encode.INVALID || dbIncidentID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	response = strings.TrimSpace(response)
+	if response == "" {
+		result = errs.ErrIncidentResponseRequired.Error()
+		return errs.ErrIncidentResponseRequired
+	}
+
+	affected, err := s.dbRW.GetQueries().RespondToStaffIncident(ctx, queries.RespondToStaffIncidentParams{
+		Response: sql.NullString{String: response, Valid: true},
+		ID:       dbIncidentID,
+		StaffID:  dbStaffID,
+		Today:    utils.NowPH().Format(constants.DateLayoutISO),
+	})
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrIncident, err)
+	}
+	if affected == 0 {
+		result = errs.ErrIncidentCannotRespond.Error()
+		return errs.ErrIncidentCannotRespond
+	}
+
+	result = fmt.Sprintf("success. response to incident '%s' submitted", incidentID)
+	return nil
+}
+
+// Decide closes an incident. A suspension makes every date from its
+// effective start to end a non-working day in the staff's schedule.
+func (s *IncidentService) Decide(
+	ctx context.Context,
+	adminStaffID string,
+	incidentID string,
+	in IncidentDecisionInput,
+) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionUpdate,
+			constants.ModuleIncidents,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	dbIncidentID := s.encoder.Decode(incidentID)
+	if dbIncidentID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	from, to, err := ValidateIncidentDecision(in, utils.NowPH().Format(constants.DateLayoutISO))
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+
+	affected, err := s.dbRW.GetQueries().DecideStaffIncident(ctx, queries.DecideStaffIncidentParams{
+		Decision:      sql.NullString{String: in.Decision.String(), Valid: true},
+		DecisionNotes: sql.NullString{String: strings.TrimSpace(in.Notes), Valid: strings.TrimSpace(in.Notes) != ""},
+		EffectiveFrom: from,
+		EffectiveTo:   to,
+		DecidedBy:     sql.NullInt64{Int64: s.encoder.Decode(adminStaffID), Valid: true},
+		ID:            dbIncidentID,
+	})
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrIncident, err)
+	}
+	if affected == 0 {
+		result = errs.ErrIncidentAlreadyDecided.Error()
+		return errs.ErrIncidentAlreadyDecided
+	}
+
+	result = fmt.Sprintf(
+		"success. incident '%s' decided as %s effective %q to %q",
+		incidentID,
+		in.Decision.String(),
+		from.String,
+		to.String,
+	)
+	return nil
+}
+
+// GetIncidents lists the incidents of staffID, or of everyone when staffID
+// is empty. An undefined status lists all statuses.
+func (s *IncidentService) GetIncidents(ctx context.Context, staffID string, status enums.IncidentStatus) ([]Incident, error) {
+	var dbStaffID int64
+	if staffID != "" {
+		dbStaffID = s.encoder.Decode(staffID)
+		if dbStaffID == encode.INVALID {
+			return nil, errs.ErrDecode
+		}
+	}
+	var statusFilter string
+	if status != enums.INCIDENT_STATUS_UNDEFINED {
+		statusFilter = status.String()
+	}
+
+	rows, err := s.dbRO.GetQueries().GetStaffIncidents(ctx, queries.GetStaffIncidentsParams{
+		StaffID: dbStaffID,
+		Status:  statusFilter,
+		Limit:   constants.IncidentListLimit,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrIncident, err)
+	}
+
+	res := make([]Incident, 0, len(rows))
+	for _, row := range rows {
+		incident := Incident{
+			ID:               s.encoder.Encode(row.ID),
+			StaffID:          s.encoder.Encode(row.StaffID),
+			StaffName:        utils.BuildFullName(row.FirstName, row.MiddleName.String, row.LastName),
+			ReportedByName:   utils.BuildFullName(row.ReporterFirstName, row.ReporterMiddleName.String, row.ReporterLastName),
+			IncidentDate:     row.IncidentDate,
+			Title:            row.Title,
+			Description:      row.Description,
+			Status:           enums.ParseIncidentStatusToEnum(row.Status),
+			ResponseDeadline: row.ResponseDeadline.String,
+			Response:         row.Response.String,
+			RespondedAt:      row.RespondedAt.Time,
+			Decision:         enums.ParseIncidentDecisionToEnum(row.Decision.String),
+			DecisionNotes:    row.DecisionNotes.String,
+			EffectiveFrom:    row.EffectiveFrom.String,
+			EffectiveTo:      row.EffectiveTo.String,
+			DecidedAt:        row.DecidedAt.Time,
+			CreatedAt:        row.CreatedAt,
+		}
+		if row.NteMemoID.Valid {
+			incident.NTEMemoID = s.encoder.Encode(row.NteMemoID.Int64)
+		}
+		res = append(res, incident)
+	}
+	return res, nil
+}
+
+func (s *IncidentService) ID() string {
+	return "Incident"
+}
+
+func (s *IncidentService) Log() {
+	logs.Log().Info("[IncidentService] Loaded")
+}
+
+var _ IService = (*IncidentService)(nil)
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"time"
+
+	"cchoice/internal/enums"
+)
+
+type Incident struct {
+	ID               string
+	StaffID          string
+	StaffName        string
+	ReportedByName   string
+	IncidentDate     string
+	Title            string
+	Description      string
+	Status           enums.IncidentStatus
+	NTEMemoID        string
+	ResponseDeadline string
+	Response         string
+	RespondedAt      time.Time
+	Decision         enums.IncidentDecision
+	DecisionNotes    string
+	EffectiveFrom    string
+	EffectiveTo      string
+	DecidedAt        time.Time
+	CreatedAt        time.Time
+}
+
+// CanRespond reports whether the staff can still send a written response on
+// today, a date in constants.DateLayoutISO.
+func (i Incident) CanRespond(today string) bool {
+	return i.Status == enums.INCIDENT_STATUS_NTE_ISSUED && i.ResponseDeadline >= today
+}
+
+// IncidentDecisionInput is a decision as submitted. Dates are in
+// constants.DateLayoutISO.
+type IncidentDecisionInput struct {
+	Decision      enums.IncidentDecision
+	Notes         string
+	EffectiveFrom string
+	EffectiveTo   string
+}
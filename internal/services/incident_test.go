@@ -0,0 +1,57 @@
+package services
+
+import (
+	"testing"
+
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/shift"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIncidentDecision(t *testing.T) {
+	const today = "2026-07-20"
+
+	tcs := []struct {
+		name     string
+		in       IncidentDecisionInput
+		wantFrom string
+		wantTo   string
+		want     error
+	}{
+		{"no action drops dates", IncidentDecisionInput{Decision: enums.INCIDENT_DECISION_NO_ACTION, EffectiveFrom: "2026-07-21"}, "", "", nil},
+		{"warning defaults to today", IncidentDecisionInput{Decision: enums.INCIDENT_DECISION_WARNING}, today, "", nil},
+		{"warning with end", IncidentDecisionInput{Decision: enums.INCIDENT_DECISION_WARNING, EffectiveFrom: "2026-07-21", EffectiveTo: "2027-01-21"}, "2026-07-21", "2027-01-21", nil},
+		{"suspension", IncidentDecisionInput{Decision: enums.INCIDENT_DECISION_SUSPENSION, EffectiveFrom: "2026-07-22", EffectiveTo: "2026-07-24"}, "2026-07-22", "2026-07-24", nil},
+		{"single day suspension", IncidentDecisionInput{Decision: enums.INCIDENT_DECISION_SUSPENSION, EffectiveFrom: "2026-07-22", EffectiveTo: "2026-07-22"}, "2026-07-22", "2026-07-22", nil},
+		{"suspension without end", IncidentDecisionInput{Decision: enums.INCIDENT_DECISION_SUSPENSION, EffectiveFrom: "2026-07-22"}, "", "", errs.ErrIncidentSuspensionDates},
+		{"suspension ends before start", IncidentDecisionInput{Decision: enums.INCIDENT_DECISION_SUSPENSION, EffectiveFrom: "2026-07-22", EffectiveTo: "2026-07-21"}, "", "", errs.ErrIncidentSuspensionDates},
+		{"bad date", IncidentDecisionInput{Decision: enums.INCIDENT_DECISION_SUSPENSION, EffectiveFrom: "07/22/2026", EffectiveTo: "2026-07-24"}, "", "", errs.ErrIncidentSuspensionDates},
+		{"undefined decision", IncidentDecisionInput{}, "", "", errs.ErrIncidentInvalidDecision},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to, err := ValidateIncidentDecision(tc.in, today)
+			assert.ErrorIs(t, err, tc.want)
+			assert.Equal(t, tc.wantFrom, from.String)
+			assert.Equal(t, tc.wantTo, to.String)
+		})
+	}
+}
+
+func TestApplySuspension(t *testing.T) {
+	regular := shift.Shift{TimeIn: "08:00", TimeOut: "17:00"}
+	schedule := shift.Schedule{
+		Default:   regular,
+		Overrides: map[string]shift.Shift{"2026-07-02": {TimeIn: "09:00", TimeOut: "18:00"}},
+	}
+
+	applySuspension(schedule, "2026-06-29", "2026-07-02", "2026-07-01", "2026-07-15")
+
+	assert.Len(t, schedule.Overrides, 2)
+	assert.True(t, schedule.For("2026-07-01").Suspended)
+	assert.True(t, schedule.For("2026-07-02").Suspended)
+	assert.True(t, schedule.For("2026-07-02").RestDay)
+	assert.Equal(t, regular, schedule.For("2026-07-03"))
+}
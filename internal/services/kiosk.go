@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"cchoice/internal/conf"
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+	"cchoice/internal/storage"
+	"cchoice/internal/types"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var kioskPinPattern = regexp.MustCompile(`^[0-9]{4,6}$`)
+
+type KioskService struct {
+	encoder       encode.IEncode
+	dbRO          database.IService
+	dbRW          database.IService
+	token         *KioskTokenService
+	attendance    *AttendanceService
+	objectStorage storage.IObjectStorage
+	staffLog      *StaffLogsService
+}
+
+// NewKioskService creates the kiosk service. objectStorage may be nil, in
+// which case punch photos are not kept.
+func NewKioskService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	token *KioskTokenService,
+	attendance *AttendanceService,
+	objectStorage storage.IObjectStorage,
+	staffLog *StaffLogsService,
+) *KioskService {
+	if token == nil {
+		panic("KioskTokenService is required")
+	}
+	if attendance == nil {
+		panic("AttendanceService is required")
+	}
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &KioskService{
+		encoder:       encoder,
+		dbRO:          dbRO,
+		dbRW:          dbRW,
+		token:         token,
+		attendance:    attendance,
+		objectStorage: objectStorage,
+		staffLog:      staffLog,
+	}
+}
+
+// GenerateToken returns a fresh signed token for the QR code shown on the
+// kiosk logged in as kioskID.
+func (s *KioskService) GenerateToken(kioskID string) (string, error) {
+	return s.token.Generate(kioskID, constants.KioskTokenTTL)
+}
+
+// PunchWithToken records a time in/out for staffID after they scanned the
+// kiosk QR code with their own phone. Each token punches once, and a photo is
+// required so a forwarded screenshot of the QR code is not enough.
+func (s *KioskService) PunchWithToken(
+	ctx context.Context,
+	token string,
+	staffID string,
+	punchType enums.KioskPunchType,
+	photo *KioskPhoto,
+	useragentID sql.NullInt64,
+) error {
+	if photo == nil || len(photo.Data) == 0 {
+		return errs.ErrKioskPhotoRequired
+	}
+	payload, err := s.token.Verify(token)
+	if err != nil {
+		return err
+	}
+	nonce := sql.NullString{String: payload.Nonce, Valid: true}
+	return s.punch(ctx, payload.KioskID, staffID, punchType, enums.KIOSK_PUNCH_METHOD_QR, nonce, photo, useragentID)
+}
+
+// PunchWithPIN records a time in/out typed on the kiosk itself. Since anyone
+// standing at the kiosk could type a PIN, a photo is always required.
+func (s *KioskService) PunchWithPIN(
+	ctx context.Context,
+	kioskID string,
+	staffID string,
+	pin string,
+	punchType enums.KioskPunchType,
+	photo *KioskPhoto,
+	useragentID sql.NullInt64,
+) error {
+	if photo == nil || len(photo.Data) == 0 {
+		return errs.ErrKioskPhotoRequired
+	}
+	if err := s.checkPIN(ctx, staffID, pin); err != nil {
+		return err
+	}
+	return s.punch(ctx, kioskID, staffID, punchType, enums.KIOSK_PUNCH_METHOD_PIN, sql.NullString{}, photo, useragentID)
+}
+
+func (s *KioskService) checkPIN(ctx context.Context, staffID string, pin string) error {
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		return errs.ErrDecode
+	}
+
+	row, err := s.dbRO.GetQueries().GetStaffKioskPin(ctx, dbStaffID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.ErrKioskPinNotSet
+		}
+		return errors.Join(errs.ErrKiosk, err)
+	}
+
+	if row.LockedUntil.Valid && row.LockedUntil.Time.After(time.Now().UTC()) {
+		return errs.ErrKioskPinLocked
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(row.PinHash), []byte(pin)) != nil {
+		attempts, err := s.dbRW.GetQueries().IncrementStaffKioskPinFailures(ctx, dbStaffID)
+		if err != nil {
+			return errors.Join(errs.ErrKiosk, err)
+		}
+		if attempts >= constants.KioskPinMaxAttempts {
+			if err := s.dbRW.GetQueries().LockStaffKioskPin(ctx, queries.LockStaffKioskPinParams{
+				LockedUntil: sql.NullTime{Time: time.Now().UTC().Add(constants.KioskPinLockout), Valid: true},
+				StaffID:     dbStaffID,
+			}); err != nil {
+				return errors.Join(errs.ErrKiosk, err)
+			}
+			logs.LogCtx(ctx).Warn("[Kiosk] PIN locked", zap.String("staff_id", staffID))
+			return errs.ErrKioskPinLocked
+		}
+		return errs.ErrKioskWrongPin
+	}
+
+	if row.FailedAttempts > 0 || row.LockedUntil.Valid {
+		if err := s.dbRW.GetQueries().ResetStaffKioskPinFailures(ctx, dbStaffID); err != nil {
+			return errors.Join(errs.ErrKiosk, err)
+		}
+	}
+	return nil
+}
+
+// punch saves the punch row before touching the attendance, so a QR token
+// whose nonce is already taken is turned away before anything is recorded.
+// The row is removed again if the attendance rejects the punch.
+func (s *KioskService) punch(
+	ctx context.Context,
+	kioskID string,
+	staffID string,
+	punchType enums.KioskPunchType,
+	method enums.KioskPunchMethod,
+	tokenNonce sql.NullString,
+	photo *KioskPhoto,
+	useragentID sql.NullInt64,
+) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionCreate,
+			constants.ModuleAttendanceKiosk,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	dbStaffID := s.encoder.Decode(staffID)
+	dbKioskID := s.encoder.Decode(kioskID)
+	if dbStaffID == encode.INVALID || dbKioskID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	date := utils.NowPH().Format(constants.DateLayoutISO)
+	now := time.Now().UTC()
+	location := shopLocation()
+
+	punchID, err := s.dbRW.GetQueries().CreateAttendanceKioskPunch(ctx, queries.CreateAttendanceKioskPunchParams{
+		StaffID:      dbStaffID,
+		KioskStaffID: dbKioskID,
+		ForDate:      date,
+		PunchType:    punchType.String(),
+		Method:       method.String(),
+		UseragentID:  useragentID,
+		TokenNonce:   tokenNonce,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			result = errs.ErrKioskTokenUsed.Error()
+			return errs.ErrKioskTokenUsed
+		}
+		result = err.Error()
+		return errors.Join(errs.ErrKiosk, err)
+	}
+
+	switch punchType {
+	case enums.KIOSK_PUNCH_TYPE_TIME_IN:
+		err = s.attendance.TimeIn(ctx, staffID, date, now.Format(constants.DateTimeLayoutISO), location, useragentID)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = errs.ErrKioskAlreadyTimedIn
+		}
+	case enums.KIOSK_PUNCH_TYPE_TIME_OUT:
+		err = s.attendance.TimeOut(ctx, staffID, date, now.Format(constants.DateTimeLayoutISO), location, useragentID)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = errs.ErrKioskNotTimedIn
+		} else if errors.Is(err, sql.ErrTxDone) {
+			err = errs.ErrKioskAlreadyTimedOut
+		}
+	default:
+		err = errs.ErrKioskInvalidPunchType
+	}
+	if err != nil {
+		result = err.Error()
+		if delErr := s.dbRW.GetQueries().DeleteAttendanceKioskPunch(ctx, punchID); delErr != nil {
+			logs.LogCtx(ctx).Error("[Kiosk] delete rejected punch", zap.Int64("punch_id", punchID), zap.Error(delErr))
+		}
+		return err
+	}
+
+	if photoKey := s.uploadPhoto(ctx, staffID, date, punchType, now, photo); photoKey != "" {
+		if err := s.dbRW.GetQueries().UpdateAttendanceKioskPunchPhotoKey(ctx, queries.UpdateAttendanceKioskPunchPhotoKeyParams{
+			PhotoKey: photoKey,
+			ID:       punchID,
+		}); err != nil {
+			logs.LogCtx(ctx).Error("[Kiosk] save photo key", zap.Int64("punch_id", punchID), zap.Error(err))
+		}
+	}
+
+	result = fmt.Sprintf("success. %s via %s kiosk '%s' on %s", punchType.String(), method.String(), kioskID, date)
+	return nil
+}
+
+// uploadPhoto stores the punch photo and returns its key. A failed upload is
+// only logged so the punch itself is not lost.
+func (s *KioskService) uploadPhoto(
+	ctx context.Context,
+	staffID string,
+	date string,
+	punchType enums.KioskPunchType,
+	now time.Time,
+	photo *KioskPhoto,
+) string {
+	if s.objectStorage == nil || photo == nil || len(photo.Data) == 0 {
+		return ""
+	}
+
+	key := fmt.Sprintf("attendance/kiosk/%s/%s-%s-%d%s", date, staffID, punchType.String(), now.Unix(), photo.Ext)
+	if err := s.objectStorage.PutObjectFromBytes(ctx, key, photo.Data, photo.ContentType); err != nil {
+		logs.LogCtx(ctx).Error("[Kiosk] upload photo", zap.String("key", key), zap.Error(err))
+		return ""
+	}
+	return key
+}
+
+func shopLocation() sql.NullString {
+	sl := conf.Conf().Settings.ShopLocation
+	if sl.Lat == 0 && sl.Lng == 0 {
+		return sql.NullString{}
+	}
+	b, err := json.Marshal(types.Location{Lat: sl.Lat, Lng: sl.Lng})
+	if err != nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(b), Valid: true}
+}
+
+func (s *KioskService) SetPIN(ctx context.Context, staffID string, pin string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionUpdate,
+			constants.ModuleAttendanceKiosk,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	if !kioskPinPattern.MatchString(pin) {
+		result = errs.ErrKioskInvalidPin.Error()
+		return errs.ErrKioskInvalidPin
+	}
+
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(pin), bcrypt.DefaultCost)
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrKiosk, err)
+	}
+
+	if err := s.dbRW.GetQueries().UpsertStaffKioskPin(ctx, queries.UpsertStaffKioskPinParams{
+		StaffID: dbStaffID,
+		PinHash: string(hash),
+	}); err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrKiosk, err)
+	}
+
+	result = "success. kiosk PIN set"
+	return nil
+}
+
+func (s *KioskService) HasPIN(ctx context.Context, staffID string) (bool, error) {
+	_, err := s.dbRO.GetQueries().GetStaffKioskPin(ctx, s.encoder.Decode(staffID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, errors.Join(errs.ErrKiosk, err)
+	}
+	return true, nil
+}
+
+func (s *KioskService) GetPunches(ctx context.Context, date string) ([]KioskPunch, error) {
+	rows, err := s.dbRO.GetQueries().GetAttendanceKioskPunchesByDate(ctx, date)
+	if err != nil {
+		return nil, errors.Join(errs.ErrKiosk, err)
+	}
+
+	res := make([]KioskPunch, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, KioskPunch{
+			ID:        s.encoder.Encode(row.ID),
+			StaffName: utils.BuildFullName(row.FirstName, row.MiddleName.String, row.LastName),
+			KioskName: utils.BuildFullName(row.KioskFirstName, "", row.KioskLastName),
+			Date:      row.ForDate,
+			Type:      enums.ParseKioskPunchTypeToEnum(row.PunchType),
+			Method:    enums.ParseKioskPunchMethodToEnum(row.Method),
+			HasPhoto:  row.PhotoKey != "",
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+// GetPhotoURL returns a short-lived link to the photo taken on a punch.
+func (s *KioskService) GetPhotoURL(ctx context.Context, punchID string) (string, error) {
+	row, err := s.dbRO.GetQueries().GetAttendanceKioskPunchByID(ctx, s.encoder.Decode(punchID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errs.ErrKioskPhotoUnavailable
+		}
+		return "", errors.Join(errs.ErrKiosk, err)
+	}
+	if row.PhotoKey == "" || s.objectStorage == nil {
+		return "", errs.ErrKioskPhotoUnavailable
+	}
+
+	url, err := s.objectStorage.PresignedGetObject(ctx, row.PhotoKey, 15*time.Minute)
+	if err != nil {
+		return "", errors.Join(errs.ErrKiosk, err)
+	}
+	return url, nil
+}
+
+func (s *KioskService) ID() string {
+	return "Kiosk"
+}
+
+func (s *KioskService) Log() {
+	logs.Log().Info("[Kiosk] Loaded")
+}
+
+var _ IService = (*KioskService)(nil)
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"time"
+
+	"cchoice/internal/enums"
+)
+
+// KioskTokenPayload is what the rotating QR code on a kiosk carries. KioskID
+// is the encoded ID of the staff account the kiosk is logged in with.
+type KioskTokenPayload struct {
+	KioskID string `json:"kid"`
+	Nonce   string `json:"n"`
+	Exp     int64  `json:"exp"`
+}
+
+// KioskPhoto is the picture taken on punch, either by the kiosk camera or the
+// staff's phone.
+type KioskPhoto struct {
+	Data        []byte
+	ContentType string
+	Ext         string
+}
+
+type KioskPunch struct {
+	ID        string
+	StaffName string
+	KioskName string
+	Date      string
+	Type      enums.KioskPunchType
+	Method    enums.KioskPunchMethod
+	HasPhoto  bool
+	CreatedAt time.Time
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+)
+
+type KioskTokenService struct {
+	secret []byte
+}
+
+func NewKioskTokenService(secret string) *KioskTokenService {
+	if secret == "" {
+		panic("secret is required")
+	}
+	return &KioskTokenService{
+		secret: []byte(secret),
+	}
+}
+
+func (s *KioskTokenService) Generate(kioskID string, ttl time.Duration) (string, error) {
+	payload := KioskTokenPayload{
+		KioskID: kioskID,
+		Nonce:   rand.Text(),
+		Exp:     time.Now().Add(ttl).Unix(),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	encodedPayload := base64.URLEncoding.EncodeToString(payloadBytes)
+
+	h := hmac.New(sha256.New, s.secret)
+	h.Write(payloadBytes)
+	signature := base64.URLEncoding.EncodeToString(h.Sum(nil))
+
+	return encodedPayload + "." + signature, nil
+}
+
+func (s *KioskTokenService) Verify(token string) (*KioskTokenPayload, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, errs.ErrKioskInvalidTokenFormat
+	}
+
+	encodedPayload, signature := parts[0], parts[1]
+
+	payloadBytes, err := base64.URLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, errs.ErrKioskInvalidTokenFormat
+	}
+
+	h := hmac.New(sha256.New, s.secret)
+	h.Write(payloadBytes)
+	expectedSig := base64.URLEncoding.EncodeToString(h.Sum(nil))
+
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(expectedSig)) != 1 {
+		return nil, errs.ErrKioskInvalidSignature
+	}
+
+	var payload KioskTokenPayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		return nil, errs.ErrKioskInvalidTokenFormat
+	}
+
+	if payload.KioskID == "" || payload.Nonce == "" {
+		return nil, errs.ErrKioskInvalidTokenFormat
+	}
+
+	if payload.Exp < time.Now().Unix() {
+		return nil, errs.ErrKioskTokenExpired
+	}
+
+	return &payload, nil
+}
+
+func (s *KioskTokenService) ID() string {
+	return "KioskToken"
+}
+
+func (s *KioskTokenService) Log() {
+	logs.Log().Info("[KioskToken] Loaded")
+}
+
+var _ IService = (*KioskTokenService)(nil)
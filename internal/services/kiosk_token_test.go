@@ -0,0 +1,59 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cchoice/internal/errs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKioskToken_RoundTrip(t *testing.T) {
+	svc := NewKioskTokenService("secret")
+
+	token, err := svc.Generate("kiosk-1", time.Minute)
+	require.NoError(t, err)
+
+	payload, err := svc.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "kiosk-1", payload.KioskID)
+	assert.NotEmpty(t, payload.Nonce)
+
+	other, err := svc.Generate("kiosk-1", time.Minute)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other, "tokens should not repeat")
+}
+
+func TestKioskToken_Verify(t *testing.T) {
+	svc := NewKioskTokenService("secret")
+	valid, err := svc.Generate("kiosk-1", time.Minute)
+	require.NoError(t, err)
+	expired, err := svc.Generate("kiosk-1", -time.Minute)
+	require.NoError(t, err)
+	foreign, err := NewKioskTokenService("other").Generate("kiosk-1", time.Minute)
+	require.NoError(t, err)
+
+	parts := strings.Split(valid, ".")
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"no separator", "abc", errs.ErrKioskInvalidTokenFormat},
+		{"bad payload encoding", "!!!." + parts[1], errs.ErrKioskInvalidTokenFormat},
+		{"tampered signature", parts[0] + ".x" + parts[1], errs.ErrKioskInvalidSignature},
+		{"other secret", foreign, errs.ErrKioskInvalidSignature},
+		{"expired", expired, errs.ErrKioskTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Verify(tt.token)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
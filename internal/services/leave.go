@@ -0,0 +1,487 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/leave"
+	"cchoice/internal/logs"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+type LeaveService struct {
+	encoder  encode.IEncode
+	dbRO     database.IService
+	dbRW     database.IService
+	holiday  *HolidayService
+	shift    *ShiftService
+	staffLog *StaffLogsService
+}
+
+func NewLeaveService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	holiday *HolidayService,
+	shift *ShiftService,
+	staffLog *StaffLogsService,
+) *LeaveService {
+	if holiday == nil {
+		panic("HolidayService is required")
+	}
+	if shift == nil {
+		panic("ShiftService is required")
+	}
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &LeaveService{
+		encoder:  encoder,
+		dbRO:     dbRO,
+		dbRW:     dbRW,
+		holiday:  holiday,
+		shift:    shift,
+		staffLog: staffLog,
+	}
+}
+
+// leaveToday is the current Philippine calendar date at midnight UTC, which is
+// how time-off dates are stored and what the accrual rules compare against.
+func leaveToday() time.Time {
+	now := utils.NowPH()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// syncStaff posts the monthly accruals that are due and closes every finished
+// year by converting or forfeiting what is above the carry-over cap. A staff
+// without ledger entries starts from the current year; earlier balances are
+// entered as adjustments. Entries are keyed by period so running it again is
+// a no-op.
+func (s *LeaveService) syncStaff(
+	ctx context.Context,
+	qtx *queries.Queries,
+	staffID int64,
+	dateHired string,
+	asOf time.Time,
+) error {
+	hired, err := time.Parse(constants.DateLayoutISO, dateHired)
+	if err != nil {
+		return errs.ErrLeaveInvalidDateHired
+	}
+
+	for _, leaveType := range leave.Types {
+		policy, _ := leave.PolicyFor(leaveType)
+		firstYear, err := qtx.GetStaffLeaveFirstYear(ctx, queries.GetStaffLeaveFirstYearParams{
+			DefaultYear: int64(asOf.Year()),
+			StaffID:     staffID,
+			LeaveType:   leaveType.String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		for year := int(firstYear); year <= asOf.Year(); year++ {
+			for _, accrual := range policy.Accruals(hired, year, asOf) {
+				if err := qtx.CreateStaffLeaveEntry(ctx, queries.CreateStaffLeaveEntryParams{
+					StaffID:   staffID,
+					LeaveType: leaveType.String(),
+					EntryType: enums.LEAVE_ENTRY_TYPE_ACCRUAL.String(),
+					Year:      int64(year),
+					Period:    accrual.Period,
+					Credits:   int64(accrual.Credits),
+					Note:      "Monthly accrual",
+				}); err != nil {
+					return err
+				}
+			}
+			if year == asOf.Year() {
+				break
+			}
+
+			balance, err := qtx.GetStaffLeaveBalanceUntilYear(ctx, queries.GetStaffLeaveBalanceUntilYearParams{
+				StaffID:   staffID,
+				LeaveType: leaveType.String(),
+				Year:      int64(year),
+			})
+			if err != nil {
+				return err
+			}
+			excess := policy.Excess(leave.Credits(balance))
+			if err := qtx.CreateStaffLeaveEntry(ctx, queries.CreateStaffLeaveEntryParams{
+				StaffID:   staffID,
+				LeaveType: leaveType.String(),
+				EntryType: policy.ExcessEntry.String(),
+				Year:      int64(year),
+				Period:    strconv.Itoa(year),
+				Credits:   -int64(excess),
+				Note:      fmt.Sprintf("Year-end balance above the %s day carry-over cap", policy.CarryOverCap),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SyncAccruals brings the ledger of every active staff up to date.
+func (s *LeaveService) SyncAccruals(ctx context.Context, asOf time.Time) error {
+	const logtag = "[LeaveService] SyncAccruals"
+	staffs, err := s.dbRO.GetQueries().GetLeaveStaffs(ctx)
+	if err != nil {
+		return errors.Join(errs.ErrLeave, err)
+	}
+
+	if err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		for _, staff := range staffs {
+			if err := s.syncStaff(ctx, qtx, staff.ID, staff.DateHired, asOf); err != nil {
+				if errors.Is(err, errs.ErrLeaveInvalidDateHired) {
+					logs.LogCtx(ctx).Warn(logtag, zap.Int64("staff_id", staff.ID), zap.String("date_hired", staff.DateHired))
+					continue
+				}
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return errors.Join(errs.ErrLeave, err)
+	}
+	return nil
+}
+
+// deductTx takes the credits of an approved vacation or sick leave inside the
+// caller's transaction. It syncs the staff first so the check sees every
+// accrual that is due, and fails if the balance does not cover the request.
+// Other time off types do not use credits and are left alone.
+func (s *LeaveService) deductTx(
+	ctx context.Context,
+	qtx *queries.Queries,
+	timeOff queries.GetStaffTimeOffByIDRow,
+	approvedBy int64,
+) error {
+	leaveType := enums.ParseTimeOffToEnum(timeOff.Type)
+	if !leave.IsCredited(leaveType) {
+		return nil
+	}
+
+	staff, err := qtx.GetLeaveStaffByID(ctx, timeOff.StaffID)
+	if err != nil {
+		return err
+	}
+	if err := s.syncStaff(ctx, qtx, staff.ID, staff.DateHired, leaveToday()); err != nil {
+		return err
+	}
+
+	balance, err := qtx.GetStaffLeaveBalance(ctx, queries.GetStaffLeaveBalanceParams{
+		StaffID:   staff.ID,
+		LeaveType: leaveType.String(),
+	})
+	if err != nil {
+		return err
+	}
+	isWorkday, err := s.workdays(ctx, staff.ID, timeOff.StartDate, timeOff.EndDate)
+	if err != nil {
+		return err
+	}
+	needed := leave.RequestCredits(timeOff.StartDate, timeOff.EndDate, isWorkday)
+	if leave.Credits(balance) < needed {
+		return errors.Join(
+			errs.ErrLeaveInsufficientBalance,
+			fmt.Errorf("%s days available, %s days requested", leave.Credits(balance), needed),
+		)
+	}
+
+	return qtx.CreateStaffLeaveEntry(ctx, queries.CreateStaffLeaveEntryParams{
+		StaffID:   staff.ID,
+		LeaveType: leaveType.String(),
+		EntryType: enums.LEAVE_ENTRY_TYPE_DEDUCTION.String(),
+		Year:      int64(timeOff.StartDate.Year()),
+		Credits:   -int64(needed),
+		TimeOffID: sql.NullInt64{Int64: timeOff.ID, Valid: true},
+		Note:      fmt.Sprintf("%s to %s", timeOff.StartDate.Format(constants.DateLayoutISO), timeOff.EndDate.Format(constants.DateLayoutISO)),
+		CreatedBy: sql.NullInt64{Int64: approvedBy, Valid: true},
+	})
+}
+
+// workdays reports whether the staff is scheduled to work on a date between
+// start and end. Rest days, suspended days and holidays other than special
+// working ones are not workdays.
+func (s *LeaveService) workdays(ctx context.Context, staffID int64, start, end time.Time) (func(time.Time) bool, error) {
+	holidays, err := s.holiday.GetHolidaysByDateRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	offDays := make(map[string]bool, len(holidays))
+	for _, h := range holidays {
+		if h.Type != enums.HOLIDAY_TYPE_SPECIAL_WORKING {
+			offDays[h.Date] = true
+		}
+	}
+
+	schedule, err := s.shift.GetSchedule(ctx, staffID, start.Format(constants.DateLayoutISO), end.Format(constants.DateLayoutISO))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(d time.Time) bool {
+		date := d.Format(constants.DateLayoutISO)
+		sh := schedule.For(date)
+		return !offDays[date] && !sh.RestDay && !sh.Suspended
+	}, nil
+}
+
+// refundTx gives back whatever is still deducted for a time off when its
+// approval is cancelled.
+func (s *LeaveService) refundTx(
+	ctx context.Context,
+	qtx *queries.Queries,
+	timeOff queries.GetStaffTimeOffByIDRow,
+	cancelledBy int64,
+) error {
+	leaveType := enums.ParseTimeOffToEnum(timeOff.Type)
+	if !leave.IsCredited(leaveType) {
+		return nil
+	}
+
+	deducted, err := qtx.GetStaffLeaveTimeOffCredits(ctx, sql.NullInt64{Int64: timeOff.ID, Valid: true})
+	if err != nil {
+		return err
+	}
+	if deducted >= 0 {
+		return nil
+	}
+
+	return qtx.CreateStaffLeaveEntry(ctx, queries.CreateStaffLeaveEntryParams{
+		StaffID:   timeOff.StaffID,
+		LeaveType: leaveType.String(),
+		EntryType: enums.LEAVE_ENTRY_TYPE_REFUND.String(),
+		Year:      int64(timeOff.StartDate.Year()),
+		Credits:   -deducted,
+		TimeOffID: sql.NullInt64{Int64: timeOff.ID, Valid: true},
+		Note:      "Cancelled time off",
+		CreatedBy: sql.NullInt64{Int64: cancelledBy, Valid: true},
+	})
+}
+
+// Adjust adds or removes credits by hand, e.g. for opening balances or
+// corrections. The adjustment counts toward the current year.
+func (s *LeaveService) Adjust(
+	ctx context.Context,
+	adminStaffID string,
+	staffID string,
+	leaveType enums.TimeOff,
+	credits leave.Credits,
+	note string,
+) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionUpdate,
+			constants.ModuleLeaveCredits,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	if !leave.IsCredited(leaveType) {
+		result = errs.ErrLeaveInvalidType.Error()
+		return errs.ErrLeaveInvalidType
+	}
+	if credits == 0 || note == "" {
+		result = errs.ErrLeaveInvalidAdjustment.Error()
+		return errs.ErrLeaveInvalidAdjustment
+	}
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	if err := s.dbRW.GetQueries().CreateStaffLeaveEntry(ctx, queries.CreateStaffLeaveEntryParams{
+		StaffID:   dbStaffID,
+		LeaveType: leaveType.String(),
+		EntryType: enums.LEAVE_ENTRY_TYPE_ADJUSTMENT.String(),
+		Year:      int64(leaveToday().Year()),
+		Credits:   int64(credits),
+		Note:      note,
+		CreatedBy: sql.NullInt64{Int64: s.encoder.Decode(adminStaffID), Valid: true},
+	}); err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrLeave, err)
+	}
+
+	result = fmt.Sprintf("success. %s %s days for staff '%s'", leaveType, credits, staffID)
+	return nil
+}
+
+// GetBalances returns the VL and SL balances of a staff for the current year.
+func (s *LeaveService) GetBalances(ctx context.Context, staffID string) ([]LeaveBalance, error) {
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		return nil, errs.ErrDecode
+	}
+
+	staff, err := s.dbRO.GetQueries().GetLeaveStaffByID(ctx, dbStaffID)
+	if err != nil {
+		return nil, errors.Join(errs.ErrLeave, err)
+	}
+	hired, err := time.Parse(constants.DateLayoutISO, staff.DateHired)
+	if err != nil {
+		return nil, errs.ErrLeaveInvalidDateHired
+	}
+	totals, err := s.dbRO.GetQueries().GetStaffLeaveTotals(ctx, dbStaffID)
+	if err != nil {
+		return nil, errors.Join(errs.ErrLeave, err)
+	}
+
+	today := leaveToday()
+	year := int64(today.Year())
+	balances := make([]LeaveBalance, 0, len(leave.Types))
+	for _, leaveType := range leave.Types {
+		policy, _ := leave.PolicyFor(leaveType)
+		balance := LeaveBalance{
+			Type:        leaveType,
+			Entitlement: policy.Entitlement(hired, today),
+		}
+		for _, total := range totals {
+			if total.LeaveType != leaveType.String() {
+				continue
+			}
+			credits := leave.Credits(total.Credits)
+			balance.Available += credits
+			if total.Year < year {
+				balance.CarriedOver += credits
+				continue
+			}
+			if total.Year > year {
+				continue
+			}
+			switch enums.ParseLeaveEntryTypeToEnum(total.EntryType) {
+			case enums.LEAVE_ENTRY_TYPE_ACCRUAL:
+				balance.Accrued += credits
+			case enums.LEAVE_ENTRY_TYPE_DEDUCTION, enums.LEAVE_ENTRY_TYPE_REFUND:
+				balance.Used -= credits
+			case enums.LEAVE_ENTRY_TYPE_ADJUSTMENT:
+				balance.Adjusted += credits
+			}
+		}
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
+
+// GetStaffBalances lists every active staff with their available credits.
+func (s *LeaveService) GetStaffBalances(ctx context.Context) ([]StaffLeaveBalances, error) {
+	staffs, err := s.dbRO.GetQueries().GetLeaveStaffs(ctx)
+	if err != nil {
+		return nil, errors.Join(errs.ErrLeave, err)
+	}
+	rows, err := s.dbRO.GetQueries().GetLeaveBalances(ctx)
+	if err != nil {
+		return nil, errors.Join(errs.ErrLeave, err)
+	}
+
+	vl := make(map[int64]leave.Credits, len(rows))
+	sl := make(map[int64]leave.Credits, len(rows))
+	for _, row := range rows {
+		switch enums.ParseTimeOffToEnum(row.LeaveType) {
+		case enums.TIME_OFF_VL:
+			vl[row.StaffID] = leave.Credits(row.Balance)
+		case enums.TIME_OFF_SL:
+			sl[row.StaffID] = leave.Credits(row.Balance)
+		}
+	}
+
+	res := make([]StaffLeaveBalances, 0, len(staffs))
+	for _, staff := range staffs {
+		res = append(res, StaffLeaveBalances{
+			StaffID:   s.encoder.Encode(staff.ID),
+			FullName:  utils.BuildFullName(staff.FirstName, staff.MiddleName.String, staff.LastName),
+			Position:  staff.Position,
+			DateHired: staff.DateHired,
+			VL:        vl[staff.ID],
+			SL:        sl[staff.ID],
+		})
+	}
+	return res, nil
+}
+
+// GetLedger returns the latest ledger entries of a staff, newest first.
+func (s *LeaveService) GetLedger(ctx context.Context, staffID string) ([]LeaveLedgerEntry, error) {
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		return nil, errs.ErrDecode
+	}
+
+	rows, err := s.dbRO.GetQueries().GetStaffLeaveLedger(ctx, queries.GetStaffLeaveLedgerParams{
+		StaffID: dbStaffID,
+		Limit:   constants.LeaveLedgerLimit,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrLeave, err)
+	}
+
+	entries := make([]LeaveLedgerEntry, 0, len(rows))
+	for _, row := range rows {
+		createdBy := "System"
+		if row.CreatedBy.Valid {
+			createdBy = utils.BuildFullName(row.CreatorFirstName.String, row.CreatorMiddleName.String, row.CreatorLastName.String)
+		}
+		entries = append(entries, LeaveLedgerEntry{
+			LeaveType: enums.ParseTimeOffToEnum(row.LeaveType),
+			EntryType: enums.ParseLeaveEntryTypeToEnum(row.EntryType),
+			Year:      row.Year,
+			Period:    row.Period,
+			Credits:   leave.Credits(row.Credits),
+			Note:      row.Note,
+			CreatedBy: createdBy,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return entries, nil
+}
+
+func (s *LeaveService) RunScheduler(ctx context.Context) {
+	const logtag = "[LeaveService] RunScheduler"
+	logs.Log().Info("[LeaveService] Starting leave accrual scheduler")
+
+	ticker := time.NewTicker(constants.LeaveSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SyncAccruals(ctx, leaveToday()); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *LeaveService) ID() string {
+	return "Leave"
+}
+
+func (s *LeaveService) Log() {
+	logs.Log().Info("[LeaveService] Loaded")
+}
+
+var _ IService = (*LeaveService)(nil)
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"time"
+
+	"cchoice/internal/enums"
+	"cchoice/internal/leave"
+)
+
+// LeaveBalance summarizes one leave type for the current year. CarriedOver is
+// what is left of earlier years after the year-end conversion or forfeit, and
+// Used is net of refunds from cancelled requests.
+type LeaveBalance struct {
+	Type        enums.TimeOff
+	Entitlement leave.Credits
+	CarriedOver leave.Credits
+	Accrued     leave.Credits
+	Used        leave.Credits
+	Adjusted    leave.Credits
+	Available   leave.Credits
+}
+
+type StaffLeaveBalances struct {
+	StaffID   string
+	FullName  string
+	Position  string
+	DateHired string
+	VL        leave.Credits
+	SL        leave.Credits
+}
+
+type LeaveLedgerEntry struct {
+	LeaveType enums.TimeOff
+	EntryType enums.LeaveEntryType
+	Year      int64
+	Period    string
+	Credits   leave.Credits
+	Note      string
+	CreatedBy string
+	CreatedAt time.Time
+}
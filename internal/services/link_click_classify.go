@@ -0,0 +1,105 @@
+package services
+
+import (
+	"net/netip"
+	"strings"
+
+	"cchoice/internal/utils"
+
+	"github.com/medama-io/go-useragent/agents"
+)
+
+// linkPreviewUserAgents are the fetchers chat and social apps send when a
+// link is pasted, plus common crawlers and scripted clients. Matched
+// case-insensitively as substrings.
+var linkPreviewUserAgents = []string{
+	"facebookexternalhit",
+	"facebookcatalog",
+	"facebot",
+	"meta-externalagent",
+	"meta-externalfetcher",
+	"whatsapp",
+	"viber",
+	"telegrambot",
+	"twitterbot",
+	"linkedinbot",
+	"slackbot",
+	"discordbot",
+	"skypeuripreview",
+	"tiktokspider",
+	"bytespider",
+	"googlebot",
+	"bingbot",
+	"applebot",
+	"yandexbot",
+	"petalbot",
+	"ahrefsbot",
+	"semrushbot",
+	"crawler",
+	"spider",
+	"headlesschrome",
+	"curl/",
+	"wget/",
+	"python-requests",
+	"go-http-client",
+}
+
+// crawlerIPRanges are published ranges of the Meta, Google and Bing crawlers.
+// Meta's preview fetcher does not always identify itself.
+var crawlerIPRanges = mustParsePrefixes(
+	// Meta (AS32934)
+	"31.13.24.0/21",
+	"31.13.64.0/18",
+	"66.220.144.0/20",
+	"69.63.176.0/20",
+	"69.171.224.0/19",
+	"173.252.64.0/18",
+	"157.240.0.0/16",
+	"2a03:2880::/32",
+	// Googlebot
+	"66.249.64.0/19",
+	// Bingbot
+	"40.77.167.0/24",
+	"157.55.39.0/24",
+	"207.46.13.0/24",
+)
+
+func mustParsePrefixes(prefixes ...string) []netip.Prefix {
+	res := make([]netip.Prefix, 0, len(prefixes))
+	for _, p := range prefixes {
+		res = append(res, netip.MustParsePrefix(p))
+	}
+	return res
+}
+
+// isBotClick reports whether a hit on /l/{slug} came from a crawler or link
+// preview rather than a person.
+func isBotClick(userAgent string, ip string) bool {
+	if strings.TrimSpace(userAgent) == "" {
+		return true
+	}
+	lower := strings.ToLower(userAgent)
+	for _, signature := range linkPreviewUserAgents {
+		if strings.Contains(lower, signature) {
+			return true
+		}
+	}
+	if utils.ParseUserAgent(userAgent).Device == string(agents.DeviceBot) {
+		return true
+	}
+	return isCrawlerIP(ip)
+}
+
+func isCrawlerIP(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range crawlerIPRanges {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	chromeAndroidUA = "Mozilla/5.0 (Linux; Android 14; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36"
+	safariIPhoneUA  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1"
+)
+
+func TestIsBotClick(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		ip        string
+		want      bool
+	}{
+		{name: "chrome on android", userAgent: chromeAndroidUA, ip: "112.198.1.1", want: false},
+		{name: "safari on iphone", userAgent: safariIPhoneUA, ip: "2001:4451::1", want: false},
+		{name: "empty user agent", userAgent: "", ip: "112.198.1.1", want: true},
+		{name: "messenger preview", userAgent: "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)", ip: "112.198.1.1", want: true},
+		{name: "tiktok crawler", userAgent: "Mozilla/5.0 (compatible; Bytespider; spider-feedback@bytedance.com)", ip: "112.198.1.1", want: true},
+		{name: "scripted client", userAgent: "curl/8.5.0", ip: "112.198.1.1", want: true},
+		{name: "meta range with browser user agent", userAgent: chromeAndroidUA, ip: "69.171.249.1", want: true},
+		{name: "meta ipv6 range", userAgent: chromeAndroidUA, ip: "2a03:2880:f10c::1", want: true},
+		{name: "mapped ipv4", userAgent: chromeAndroidUA, ip: "::ffff:66.249.66.1", want: true},
+		{name: "unparseable ip", userAgent: chromeAndroidUA, ip: "unknown", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isBotClick(tt.userAgent, tt.ip))
+		})
+	}
+}
@@ -42,7 +42,7 @@ func NewMemoService(
 	if staffLog == nil {
 		panic("StaffLogsService is required")
 	}
-	if (conf.Conf().IsProd() || (conf.Conf().IsLocal() && conf.Conf().Test.LocalMemoEmailSend)) && emailRunner == nil {
+	if shouldSendMemoEmails() && emailRunner == nil {
 		panic("emailRunner is required")
 	}
 	return &MemoService{
@@ -54,6 +54,10 @@ func NewMemoService(
 	}
 }
 
+func shouldSendMemoEmails() bool {
+	return conf.Conf().IsProd() || (conf.Conf().IsLocal() && conf.Conf().Test.LocalMemoEmailSend)
+}
+
 func (s *MemoService) GetMemoByID(ctx context.Context, memoID string) (*Memo, error) {
 	id := s.encoder.Decode(memoID)
 	if id == encode.INVALID {
@@ -163,7 +167,7 @@ func (s *MemoService) SendMemoEmails(ctx context.Context, actorStaffID, memoID s
 		return errors.Join(errs.ErrMemo, err)
 	}
 
-	if conf.Conf().IsProd() || (conf.Conf().IsLocal() && conf.Conf().Test.LocalMemoEmailSend) {
+	if shouldSendMemoEmails() {
 		memoIDCopy := decodedMemoID
 		subject := fmt.Sprintf("New Memo: %s - C-Choice", memo.TblMemo.Title)
 		cc := conf.Conf().MailerooConfig.CC
@@ -257,6 +261,9 @@ func (s *MemoService) GetRecipientsWithActions(ctx context.Context, memoID strin
 			RejectReason: row.RejectReason.String,
 			AcceptedAt:   row.AcceptedAt.String,
 			RejectedAt:   row.RejectedAt.String,
+
+			ReminderCount:  row.ReminderCount,
+			LastRemindedAt: row.LastRemindedAt.String,
 		})
 	}
 	return result, nil
@@ -279,10 +286,48 @@ func (s *MemoService) GetPendingForStaff(ctx context.Context, staffID string) ([
 	result := make([]StaffPendingMemo, 0, len(rows))
 	for _, row := range rows {
 		result = append(result, StaffPendingMemo{
-			ID:      s.encoder.Encode(row.TblMemo.ID),
-			Title:   row.TblMemo.Title,
-			Message: row.TblMemo.Message,
-			FileURL: row.TblMemo.FileUrl.String,
+			ID:                      s.encoder.Encode(row.TblMemo.ID),
+			Title:                   row.TblMemo.Title,
+			Message:                 row.TblMemo.Message,
+			FileURL:                 row.TblMemo.FileUrl.String,
+			RequiresAcknowledgement: row.TblMemo.RequiresAcknowledgement,
+		})
+	}
+	return result, nil
+}
+
+// GetReceivedByStaff lists the published memos addressed to a staff with
+// how the staff acted on each, newest first.
+func (s *MemoService) GetReceivedByStaff(ctx context.Context, staffID string) ([]StaffReceivedMemo, error) {
+	decodedStaffID := s.encoder.Decode(staffID)
+	if decodedStaffID == encode.INVALID {
+		return nil, errs.ErrDecode
+	}
+
+	rows, err := s.dbRO.GetQueries().GetMemosReceivedByStaff(ctx, queries.GetMemosReceivedByStaffParams{
+		StaffID: decodedStaffID,
+		Limit:   constants.IncidentMemoHistoryLimit,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrMemo, err)
+	}
+
+	result := make([]StaffReceivedMemo, 0, len(rows))
+	for _, row := range rows {
+		actionStatus := enums.MEMO_STAFF_ACTION_STATUS_UNDEFINED
+		if row.ActionStatus.Valid {
+			actionStatus = enums.ParseMemoStaffActionStatusToEnum(row.ActionStatus.String)
+		}
+		result = append(result, StaffReceivedMemo{
+			ID:                      s.encoder.Encode(row.ID),
+			Title:                   row.Title,
+			StartDate:               row.StartDate,
+			EndDate:                 row.EndDate,
+			RequiresAcknowledgement: row.RequiresAcknowledgement,
+			AcknowledgeBy:           row.AcknowledgeBy.String,
+			ActionStatus:            actionStatus,
+			RejectReason:            row.RejectReason.String,
+			ActedAt:                 row.ActionCreatedAt.String,
 		})
 	}
 	return result, nil
@@ -297,6 +342,7 @@ func (s *MemoService) CreateMemo(
 	status enums.MemoStatus,
 	startDate time.Time,
 	endDate time.Time,
+	ack MemoAcknowledgement,
 	recipientStaffIDs []string,
 ) (string, error) {
 	result := "success"
@@ -318,6 +364,11 @@ func (s *MemoService) CreateMemo(
 		return "", err
 	}
 
+	if err := ValidateMemoAcknowledgement(ack, startDate, endDate); err != nil {
+		result = err.Error()
+		return "", err
+	}
+
 	if len(recipientStaffIDs) == 0 {
 		result = errs.ErrMemoRecipientsRequired.Error()
 		return "", errs.ErrMemoRecipientsRequired
@@ -342,7 +393,10 @@ func (s *MemoService) CreateMemo(
 		Status:    status.String(),
 		StartDate: startDate.Format(constants.DateLayoutISO),
 		EndDate:   endDate.Format(constants.DateLayoutISO),
-		CreatedBy: createdBy,
+
+		RequiresAcknowledgement: ack.Required,
+		AcknowledgeBy:           memoAcknowledgeBy(ack),
+		CreatedBy:               createdBy,
 	})
 	if err != nil {
 		result = err.Error()
@@ -369,6 +423,7 @@ func (s *MemoService) UpdateMemo(
 	status enums.MemoStatus,
 	startDate time.Time,
 	endDate time.Time,
+	ack MemoAcknowledgement,
 	recipientStaffIDs []string,
 ) error {
 	result := "success"
@@ -411,6 +466,11 @@ func (s *MemoService) UpdateMemo(
 		return err
 	}
 
+	if err := ValidateMemoAcknowledgement(ack, startDate, endDate); err != nil {
+		result = err.Error()
+		return err
+	}
+
 	decodedRecipients, err := s.decodeStaffIDs(recipientStaffIDs)
 	if err != nil {
 		result = err.Error()
@@ -424,7 +484,10 @@ func (s *MemoService) UpdateMemo(
 		Status:    status.String(),
 		StartDate: startDate.Format(constants.DateLayoutISO),
 		EndDate:   endDate.Format(constants.DateLayoutISO),
-		ID:        id,
+
+		RequiresAcknowledgement: ack.Required,
+		AcknowledgeBy:           memoAcknowledgeBy(ack),
+		ID:                      id,
 	}); err != nil {
 		result = err.Error()
 		return errors.Join(errs.ErrMemo, err)
@@ -637,6 +700,26 @@ func ValidateMemoDatesForUpdate(originalStart, originalEnd string, newStart, new
 	return nil
 }
 
+// ValidateMemoAcknowledgement only checks the deadline when acknowledgement
+// is required; the deadline is dropped otherwise.
+func ValidateMemoAcknowledgement(ack MemoAcknowledgement, startDate, endDate time.Time) error {
+	if !ack.Required || ack.Deadline.IsZero() {
+		return nil
+	}
+	deadline := ack.Deadline.Format(constants.DateLayoutISO)
+	if deadline < startDate.Format(constants.DateLayoutISO) || deadline > endDate.Format(constants.DateLayoutISO) {
+		return errs.ErrMemoAcknowledgeByRange
+	}
+	return nil
+}
+
+func memoAcknowledgeBy(ack MemoAcknowledgement) sql.NullString {
+	if !ack.Required || ack.Deadline.IsZero() {
+		return sql.NullString{}
+	}
+	return sql.NullString{Valid: true, String: ack.Deadline.Format(constants.DateLayoutISO)}
+}
+
 func (s *MemoService) decodeStaffIDs(encodedIDs []string) ([]int64, error) {
 	result := make([]int64, 0, len(encodedIDs))
 	seen := make(map[int64]struct{}, len(encodedIDs))
@@ -676,6 +759,205 @@ func (s *MemoService) mapRowToMemo(m queries.TblMemo) Memo {
 		UpdatedAt:    updatedAt,
 		DeletedAt:    m.DeletedAt,
 		EmailsSentAt: m.EmailsSentAt,
+
+		RequiresAcknowledgement: m.RequiresAcknowledgement,
+		AcknowledgeBy:           m.AcknowledgeBy.String,
+		EscalatedAt:             m.EscalatedAt.String,
+	}
+}
+
+// GetAcknowledgementSummary counts the recipients' actions on a memo. Pending
+// recipients are flagged overdue once the acknowledge by date has passed.
+func (s *MemoService) GetAcknowledgementSummary(ctx context.Context, memoID string, today string) (*MemoAcknowledgementSummary, error) {
+	memo, err := s.GetMemoByID(ctx, memoID)
+	if err != nil {
+		return nil, err
+	}
+	if memo == nil {
+		return nil, errs.ErrMemoNotFound
+	}
+
+	recipients, err := s.GetRecipientsWithActions(ctx, memoID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &MemoAcknowledgementSummary{
+		Memo:    *memo,
+		Total:   len(recipients),
+		Overdue: memo.AcknowledgeBy != "" && memo.AcknowledgeBy < today,
+		Pending: make([]MemoRecipientRow, 0, len(recipients)),
+	}
+	for _, r := range recipients {
+		switch r.ActionStatus {
+		case enums.MEMO_STAFF_ACTION_STATUS_ACCEPTED:
+			summary.Accepted++
+		case enums.MEMO_STAFF_ACTION_STATUS_REJECTED:
+			summary.Rejected++
+		default:
+			summary.Pending = append(summary.Pending, r)
+		}
+	}
+	return summary, nil
+}
+
+// SendReminders emails every recipient of a memo requiring acknowledgement
+// who has not acted on it and was last notified at least one reminder
+// interval ago. now is expected in PH time since memo dates are PH dates.
+func (s *MemoService) SendReminders(ctx context.Context, now time.Time) (int, error) {
+	const logtag = "[MemoService SendReminders]"
+
+	if !shouldSendMemoEmails() {
+		return 0, nil
+	}
+
+	rows, err := s.dbRO.GetQueries().GetMemoRecipientsDueForReminder(ctx, queries.GetMemoRecipientsDueForReminderParams{
+		Today: now.Format(constants.DateLayoutISO),
+		RemindBefore: sql.NullString{
+			Valid:  true,
+			String: now.Add(-conf.Conf().Memo.ReminderInterval).UTC().Format(constants.DateTimeLayoutISO),
+		},
+		Limit: constants.MemoReminderBatchSize,
+	})
+	if err != nil {
+		return 0, errors.Join(errs.ErrMemo, err)
+	}
+
+	cc := conf.Conf().MailerooConfig.CC
+	sent := 0
+	for _, row := range rows {
+		memoID := row.MemoID
+		if err := s.emailRunner.QueueEmailJob(ctx, jobs.EmailJobParams{
+			MemoID:       &memoID,
+			Recipient:    row.Email,
+			CC:           cc,
+			Subject:      fmt.Sprintf("Reminder: %s - C-Choice", row.Title),
+			TemplateName: enums.EMAIL_TEMPLATE_MEMO_REMINDER,
+		}); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Int64("memo_id", row.MemoID), zap.Int64("staff_id", row.StaffID), zap.Error(err))
+			continue
+		}
+		if err := s.dbRW.GetQueries().MarkMemoRecipientReminded(ctx, queries.MarkMemoRecipientRemindedParams{
+			MemoID:  row.MemoID,
+			StaffID: row.StaffID,
+		}); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Int64("memo_id", row.MemoID), zap.Int64("staff_id", row.StaffID), zap.Error(err))
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// EscalateOverdue notifies superusers once per memo whose acknowledge by date
+// has passed while some recipients still have not acted on it. The memo's
+// author is left out unless they are the only superuser. The memo is claimed
+// as escalated before its emails are queued, and released again when none of
+// them could be queued so the next scan retries it.
+func (s *MemoService) EscalateOverdue(ctx context.Context, now time.Time) (int, error) {
+	const logtag = "[MemoService EscalateOverdue]"
+
+	memos, err := s.dbRO.GetQueries().GetMemosDueForEscalation(ctx, queries.GetMemosDueForEscalationParams{
+		Today: sql.NullString{Valid: true, String: now.Format(constants.DateLayoutISO)},
+		Limit: constants.MemoEscalationBatchSize,
+	})
+	if err != nil {
+		return 0, errors.Join(errs.ErrMemo, err)
+	}
+	if len(memos) == 0 {
+		return 0, nil
+	}
+
+	superusers, err := s.dbRO.GetQueries().GetActiveSuperuserEmails(ctx)
+	if err != nil {
+		return 0, errors.Join(errs.ErrMemo, err)
+	}
+
+	escalated := 0
+	for _, row := range memos {
+		memo := row.TblMemo
+		affected, err := s.dbRW.GetQueries().MarkMemoEscalated(ctx, memo.ID)
+		if err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Int64("memo_id", memo.ID), zap.Error(err))
+			continue
+		}
+		if affected == 0 {
+			continue
+		}
+
+		if !shouldSendMemoEmails() {
+			logs.LogCtx(ctx).Info(logtag, zap.Int64("memo_id", memo.ID), zap.String("result", "skipped (non-prod)"))
+			escalated++
+			continue
+		}
+
+		recipients := memoEscalationRecipients(superusers, memo.CreatedBy)
+		queued := 0
+		for _, recipient := range recipients {
+			memoID := memo.ID
+			if err := s.emailRunner.QueueEmailJob(ctx, jobs.EmailJobParams{
+				MemoID:       &memoID,
+				Recipient:    recipient,
+				Subject:      fmt.Sprintf("Overdue Memo: %s - C-Choice", memo.Title),
+				TemplateName: enums.EMAIL_TEMPLATE_MEMO_ESCALATION,
+			}); err != nil {
+				logs.LogCtx(ctx).Error(logtag, zap.Int64("memo_id", memo.ID), zap.Error(err))
+				continue
+			}
+			queued++
+		}
+		if len(recipients) > 0 && queued == 0 {
+			if err := s.dbRW.GetQueries().ClearMemoEscalated(ctx, memo.ID); err != nil {
+				logs.LogCtx(ctx).Error(logtag, zap.Int64("memo_id", memo.ID), zap.Error(err))
+			}
+			continue
+		}
+		escalated++
+	}
+	return escalated, nil
+}
+
+func memoEscalationRecipients(superusers []queries.GetActiveSuperuserEmailsRow, authorID int64) []string {
+	result := make([]string, 0, len(superusers))
+	var author string
+	for _, su := range superusers {
+		if su.ID == authorID {
+			author = su.Email
+			continue
+		}
+		result = append(result, su.Email)
+	}
+	if len(result) == 0 && author != "" {
+		result = append(result, author)
+	}
+	return result
+}
+
+func (s *MemoService) RunScheduler(ctx context.Context) {
+	const logtag = "[MemoService] RunScheduler"
+	logs.Log().Info("[MemoService] Starting memo acknowledgement scheduler")
+
+	ticker := time.NewTicker(conf.Conf().Memo.ScanInterval)
+	defer ticker.Stop()
+
+	for {
+		now := utils.NowPH()
+		if sent, err := s.SendReminders(ctx, now); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		} else if sent > 0 {
+			logs.LogCtx(ctx).Info(logtag, zap.Int("reminders_sent", sent))
+		}
+		if escalated, err := s.EscalateOverdue(ctx, now); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		} else if escalated > 0 {
+			logs.LogCtx(ctx).Info(logtag, zap.Int("memos_escalated", escalated))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
 }
 
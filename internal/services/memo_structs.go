@@ -20,6 +20,10 @@ type Memo struct {
 	UpdatedAt    sql.NullString
 	DeletedAt    string
 	EmailsSentAt string
+
+	RequiresAcknowledgement bool
+	AcknowledgeBy           string
+	EscalatedAt             string
 }
 
 type MemoListItem struct {
@@ -38,11 +42,54 @@ type MemoRecipientRow struct {
 	RejectReason string
 	AcceptedAt   string
 	RejectedAt   string
+
+	ReminderCount  int64
+	LastRemindedAt string
 }
 
 type StaffPendingMemo struct {
-	ID      string
-	Title   string
-	Message string
-	FileURL string
+	ID                      string
+	Title                   string
+	Message                 string
+	FileURL                 string
+	RequiresAcknowledgement bool
+}
+
+type StaffReceivedMemo struct {
+	ID                      string
+	Title                   string
+	StartDate               string
+	EndDate                 string
+	RequiresAcknowledgement bool
+	AcknowledgeBy           string
+	ActionStatus            enums.MemoStaffActionStatus
+	RejectReason            string
+	ActedAt                 string
+}
+
+// MemoAcknowledgement is set on memos that staff must act on. A zero
+// Deadline means reminders are sent but nobody is escalated to.
+type MemoAcknowledgement struct {
+	Required bool
+	Deadline time.Time
+}
+
+type MemoAcknowledgementSummary struct {
+	Memo     Memo
+	Total    int
+	Accepted int
+	Rejected int
+	Overdue  bool
+	Pending  []MemoRecipientRow
+}
+
+func (s MemoAcknowledgementSummary) Acknowledged() int {
+	return s.Accepted + s.Rejected
+}
+
+func (s MemoAcknowledgementSummary) Percent() int {
+	if s.Total == 0 {
+		return 0
+	}
+	return s.Acknowledged() * 100 / s.Total
 }
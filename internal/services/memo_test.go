@@ -0,0 +1,51 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"cchoice/internal/database/queries"
+	"cchoice/internal/errs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMemoAcknowledgement(t *testing.T) {
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 7, 31, 0, 0, 0, 0, time.UTC)
+
+	tcs := []struct {
+		name string
+		ack  MemoAcknowledgement
+		want error
+	}{
+		{"not required", MemoAcknowledgement{Deadline: end.AddDate(0, 1, 0)}, nil},
+		{"no deadline", MemoAcknowledgement{Required: true}, nil},
+		{"on start", MemoAcknowledgement{Required: true, Deadline: start}, nil},
+		{"on end", MemoAcknowledgement{Required: true, Deadline: end}, nil},
+		{"before start", MemoAcknowledgement{Required: true, Deadline: start.AddDate(0, 0, -1)}, errs.ErrMemoAcknowledgeByRange},
+		{"after end", MemoAcknowledgement{Required: true, Deadline: end.AddDate(0, 0, 1)}, errs.ErrMemoAcknowledgeByRange},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, ValidateMemoAcknowledgement(tc.ack, start, end), tc.want)
+		})
+	}
+}
+
+func TestMemoAcknowledgementSummaryPercent(t *testing.T) {
+	assert.Equal(t, 0, MemoAcknowledgementSummary{}.Percent())
+	assert.Equal(t, 66, MemoAcknowledgementSummary{Total: 3, Accepted: 1, Rejected: 1}.Percent())
+	assert.Equal(t, 100, MemoAcknowledgementSummary{Total: 2, Accepted: 2}.Percent())
+}
+
+func TestMemoEscalationRecipients(t *testing.T) {
+	superusers := []queries.GetActiveSuperuserEmailsRow{
+		{ID: 1, Email: "author@example.com"},
+		{ID: 2, Email: "boss@example.com"},
+	}
+	assert.Equal(t, []string{"boss@example.com"}, memoEscalationRecipients(superusers, 1))
+	assert.Equal(t, []string{"author@example.com", "boss@example.com"}, memoEscalationRecipients(superusers, 3))
+	assert.Equal(t, []string{"author@example.com"}, memoEscalationRecipients(superusers[:1], 1))
+	assert.Empty(t, memoEscalationRecipients(nil, 1))
+}
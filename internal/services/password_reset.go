@@ -35,6 +35,7 @@ type PasswordResetService struct {
 	dbRO        database.IService
 	dbRW        database.IService
 	emailRunner *jobs.EmailJobRunner
+	session     *SessionService
 	staffLog    *StaffLogsService
 }
 
@@ -43,11 +44,15 @@ func NewPasswordResetService(
 	dbRO database.IService,
 	dbRW database.IService,
 	emailRunner *jobs.EmailJobRunner,
+	session *SessionService,
 	staffLog *StaffLogsService,
 ) *PasswordResetService {
 	if (conf.Conf().IsProd() || conf.Conf().Test.LocalForgotPassword) && emailRunner == nil {
 		panic("emailRunner is required")
 	}
+	if session == nil {
+		panic("SessionService is required")
+	}
 	if staffLog == nil {
 		panic("StaffLogsService is required")
 	}
@@ -57,6 +62,7 @@ func NewPasswordResetService(
 		dbRO:        dbRO,
 		dbRW:        dbRW,
 		emailRunner: emailRunner,
+		session:     session,
 		staffLog:    staffLog,
 	}
 }
@@ -296,6 +302,9 @@ func (s *PasswordResetService) ResetPassword(ctx context.Context, token string,
 	}
 
 	userID = s.encoder.Encode(resetToken.UserID)
+	if _, err := s.session.RevokeUser(ctx, userType, userID, enums.SESSION_REVOKE_REASON_PASSWORD_CHANGE); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+	}
 	return userType, nil
 }
 
@@ -0,0 +1,738 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+	"cchoice/internal/payroll"
+	"cchoice/internal/pdf"
+	"cchoice/internal/utils"
+
+	"github.com/xuri/excelize/v2"
+	"go.uber.org/zap"
+)
+
+type PayrollService struct {
+	encoder    encode.IEncode
+	dbRO       database.IService
+	dbRW       database.IService
+	attendance *AttendanceService
+	holiday    *HolidayService
+	shift      *ShiftService
+	staffLog   *StaffLogsService
+}
+
+func NewPayrollService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	attendance *AttendanceService,
+	holiday *HolidayService,
+	shift *ShiftService,
+	staffLog *StaffLogsService,
+) *PayrollService {
+	if attendance == nil {
+		panic("AttendanceService is required")
+	}
+	if holiday == nil {
+		panic("HolidayService is required")
+	}
+	if shift == nil {
+		panic("ShiftService is required")
+	}
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &PayrollService{
+		encoder:    encoder,
+		dbRO:       dbRO,
+		dbRW:       dbRW,
+		attendance: attendance,
+		holiday:    holiday,
+		shift:      shift,
+		staffLog:   staffLog,
+	}
+}
+
+func (s *PayrollService) GetStaffPayRates(ctx context.Context) ([]StaffPayRate, error) {
+	rows, err := s.dbRO.GetQueries().GetStaffsWithPayRates(ctx)
+	if err != nil {
+		return nil, errors.Join(errs.ErrPayroll, err)
+	}
+
+	rates := make([]StaffPayRate, 0, len(rows))
+	for _, row := range rows {
+		rates = append(rates, StaffPayRate{
+			StaffID:  s.encoder.Encode(row.ID),
+			FullName: utils.BuildFullName(row.FirstName, row.MiddleName.String, row.LastName),
+			Position: row.Position,
+			RateType: enums.ParsePayRateTypeToEnum(row.RateType.String),
+			Rate:     row.Rate.Int64,
+		})
+	}
+	return rates, nil
+}
+
+// SetPayRate saves the rate used by the next computed or recomputed run.
+// Locked runs keep the rate they were computed with.
+func (s *PayrollService) SetPayRate(
+	ctx context.Context,
+	adminStaffID string,
+	staffID string,
+	rateType enums.PayRateType,
+	rate int64,
+) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionUpdate,
+			constants.ModulePayroll,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	if rateType == enums.PAY_RATE_TYPE_UNDEFINED || rate <= 0 {
+		result = errs.ErrPayrollInvalidRate.Error()
+		return errs.ErrPayrollInvalidRate
+	}
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	if err := s.dbRW.GetQueries().UpsertStaffPayRate(ctx, queries.UpsertStaffPayRateParams{
+		StaffID:   dbStaffID,
+		RateType:  rateType.String(),
+		Rate:      rate,
+		UpdatedBy: sql.NullInt64{Int64: s.encoder.Decode(adminStaffID), Valid: true},
+	}); err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrPayroll, err)
+	}
+
+	result = fmt.Sprintf("success. staff ID '%s' rate %s %d", staffID, rateType, rate)
+	return nil
+}
+
+// computeItems builds one payroll item per staff with a pay rate from the
+// attendance, approved time-offs, holidays and shift schedules within the
+// period.
+func (s *PayrollService) computeItems(ctx context.Context, period payroll.Period) ([]queries.CreatePayrollRunItemParams, error) {
+	staffs, err := s.dbRO.GetQueries().GetPayrollStaffs(ctx, sql.NullString{String: period.StartDate(), Valid: true})
+	if err != nil {
+		return nil, errors.Join(errs.ErrPayroll, err)
+	}
+	if len(staffs) == 0 {
+		return nil, errs.ErrPayrollNoRatedStaff
+	}
+
+	attendances, err := s.dbRO.GetQueries().GetStaffAttendanceByDateRange(ctx, queries.GetStaffAttendanceByDateRangeParams{
+		StartDate: period.StartDate(),
+		EndDate:   period.EndDate(),
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrPayroll, err)
+	}
+	attendanceByStaff := make(map[int64]map[string]StaffRow, len(staffs))
+	for _, att := range attendances {
+		if _, ok := attendanceByStaff[att.StaffID]; !ok {
+			attendanceByStaff[att.StaffID] = map[string]StaffRow{}
+		}
+		attendanceByStaff[att.StaffID][att.ForDate] = StaffRow(att)
+	}
+
+	timeOffs, err := s.dbRO.GetQueries().GetApprovedTimeOffsByDateRange(ctx, queries.GetApprovedTimeOffsByDateRangeParams{
+		PeriodStart: period.Start,
+		PeriodEnd:   period.End,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrPayroll, err)
+	}
+	leaveByStaff := make(map[int64]map[string]enums.TimeOff, len(timeOffs))
+	for _, timeOff := range timeOffs {
+		if _, ok := leaveByStaff[timeOff.StaffID]; !ok {
+			leaveByStaff[timeOff.StaffID] = map[string]enums.TimeOff{}
+		}
+		leave := enums.ParseTimeOffToEnum(timeOff.Type)
+		start := timeOff.StartDate.UTC().Truncate(24 * time.Hour)
+		end := timeOff.EndDate.UTC().Truncate(24 * time.Hour)
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			leaveByStaff[timeOff.StaffID][d.Format(constants.DateLayoutISO)] = leave
+		}
+	}
+
+	holidays, err := s.holiday.GetHolidaysByDateRange(ctx, period.Start, period.End)
+	if err != nil {
+		return nil, errors.Join(errs.ErrPayroll, err)
+	}
+	holidayByDate := make(map[string]enums.HolidayType, len(holidays))
+	for _, h := range holidays {
+		holidayByDate[h.Date] = h.Type
+	}
+
+	schedules, err := s.shift.GetSchedules(ctx, period.StartDate(), period.EndDate())
+	if err != nil {
+		return nil, errors.Join(errs.ErrPayroll, err)
+	}
+
+	dates := period.Dates()
+	items := make([]queries.CreatePayrollRunItemParams, 0, len(staffs))
+	for _, staff := range staffs {
+		base := StaffRowBase{
+			ID:              staff.ID,
+			FirstName:       staff.FirstName,
+			MiddleName:      staff.MiddleName,
+			LastName:        staff.LastName,
+			TimeInSchedule:  staff.TimeInSchedule,
+			TimeOutSchedule: staff.TimeOutSchedule,
+		}
+
+		days := make([]payroll.Day, 0, len(dates))
+		for _, date := range dates {
+			day := payroll.Day{
+				Date:      date,
+				Holiday:   holidayByDate[date],
+				Leave:     leaveByStaff[staff.ID][date],
+				Suspended: schedules[staff.ID].For(date).Suspended,
+			}
+			if att, ok := attendanceByStaff[staff.ID][date]; ok && att.TimeIn.Valid {
+				computed := s.attendance.ComputeData(base, att, schedules[staff.ID])
+				day.Worked = true
+				day.LateMinutes = int64(computed.Attendance.InLate.Minutes())
+				day.UndertimeMinutes = int64(computed.Attendance.Undertime.Minutes())
+				day.OvertimeMinutes = int64(computed.Attendance.Overtime.Minutes())
+			}
+			days = append(days, day)
+		}
+
+		rateType := enums.ParsePayRateTypeToEnum(staff.RateType)
+		res := payroll.Compute(payroll.Input{
+			RateType: rateType,
+			Rate:     staff.Rate,
+			Days:     days,
+		})
+		items = append(items, queries.CreatePayrollRunItemParams{
+			StaffID:            staff.ID,
+			RateType:           rateType.String(),
+			Rate:               staff.Rate,
+			DailyRate:          res.DailyRate,
+			DaysWorked:         res.DaysWorked,
+			AbsentDays:         res.AbsentDays,
+			LateMinutes:        res.LateMinutes,
+			UndertimeMinutes:   res.UndertimeMinutes,
+			OvertimeMinutes:    res.OvertimeMinutes,
+			BasicPay:           res.BasicPay,
+			HolidayPay:         res.HolidayPay,
+			OvertimePay:        res.OvertimePay,
+			LateDeduction:      res.LateDeduction,
+			UndertimeDeduction: res.UndertimeDeduction,
+			AbsenceDeduction:   res.AbsenceDeduction,
+			GrossPay:           res.GrossPay,
+			Sss:                res.SSS,
+			Philhealth:         res.PhilHealth,
+			Pagibig:            res.PagIBIG,
+			WithholdingTax:     res.WithholdingTax,
+			NetPay:             res.NetPay,
+		})
+	}
+	return items, nil
+}
+
+func (s *PayrollService) insertItems(
+	ctx context.Context,
+	qtx *queries.Queries,
+	runID int64,
+	items []queries.CreatePayrollRunItemParams,
+) error {
+	for _, item := range items {
+		item.PayrollRunID = runID
+		if err := qtx.CreatePayrollRunItem(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateRun computes a DRAFT run for the period. A period can only have one
+// run; use RecomputeRun to refresh a draft instead.
+func (s *PayrollService) CreateRun(ctx context.Context, adminStaffID string, period payroll.Period) (string, error) {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionCreate,
+			constants.ModulePayroll,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	if _, err := s.dbRO.GetQueries().GetPayrollRunByPeriod(ctx, queries.GetPayrollRunByPeriodParams{
+		PeriodStart: period.StartDate(),
+		PeriodEnd:   period.EndDate(),
+	}); err == nil {
+		result = errs.ErrPayrollRunExists.Error()
+		return "", errs.ErrPayrollRunExists
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		result = err.Error()
+		return "", errors.Join(errs.ErrPayroll, err)
+	}
+
+	items, err := s.computeItems(ctx, period)
+	if err != nil {
+		result = err.Error()
+		return "", err
+	}
+
+	var runID int64
+	err = s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		var err error
+		runID, err = qtx.CreatePayrollRun(ctx, queries.CreatePayrollRunParams{
+			PeriodStart: period.StartDate(),
+			PeriodEnd:   period.EndDate(),
+			CreatedBy:   s.encoder.Decode(adminStaffID),
+		})
+		if err != nil {
+			return err
+		}
+		return s.insertItems(ctx, qtx, runID, items)
+	})
+	if err != nil {
+		result = err.Error()
+		return "", errors.Join(errs.ErrPayroll, err)
+	}
+
+	encodedID := s.encoder.Encode(runID)
+	result = fmt.Sprintf("success. ID '%s' period %s to %s", encodedID, period.StartDate(), period.EndDate())
+	return encodedID, nil
+}
+
+func (s *PayrollService) getRunRow(ctx context.Context, runID string) (queries.TblPayrollRun, error) {
+	dbRunID := s.encoder.Decode(runID)
+	if dbRunID == encode.INVALID {
+		return queries.TblPayrollRun{}, errs.ErrDecode
+	}
+	run, err := s.dbRO.GetQueries().GetPayrollRunByID(ctx, dbRunID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return queries.TblPayrollRun{}, errs.ErrPayrollRunNotFound
+		}
+		return queries.TblPayrollRun{}, errors.Join(errs.ErrPayroll, err)
+	}
+	return run, nil
+}
+
+// RecomputeRun replaces the items of a DRAFT run with freshly computed ones,
+// picking up attendance corrections, approved time-offs and rate changes.
+func (s *PayrollService) RecomputeRun(ctx context.Context, adminStaffID string, runID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionUpdate,
+			constants.ModulePayroll,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	run, err := s.getRunRow(ctx, runID)
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+	if enums.ParsePayrollRunStatusToEnum(run.Status) != enums.PAYROLL_RUN_STATUS_DRAFT {
+		result = errs.ErrPayrollRunLocked.Error()
+		return errs.ErrPayrollRunLocked
+	}
+
+	period, err := periodFromRun(run.PeriodStart, run.PeriodEnd)
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+	items, err := s.computeItems(ctx, period)
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+
+	err = s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		current, err := qtx.GetPayrollRunByID(ctx, run.ID)
+		if err != nil {
+			return err
+		}
+		if enums.ParsePayrollRunStatusToEnum(current.Status) != enums.PAYROLL_RUN_STATUS_DRAFT {
+			return errs.ErrPayrollRunLocked
+		}
+		if err := qtx.DeleteDraftPayrollRunItems(ctx, run.ID); err != nil {
+			return err
+		}
+		if err := s.insertItems(ctx, qtx, run.ID, items); err != nil {
+			return err
+		}
+		return qtx.TouchPayrollRun(ctx, run.ID)
+	})
+	if errors.Is(err, errs.ErrPayrollRunLocked) {
+		result = err.Error()
+		return err
+	}
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrPayroll, err)
+	}
+
+	result = fmt.Sprintf("success. recomputed ID '%s'", runID)
+	return nil
+}
+
+// LockRun freezes a DRAFT run. Locked runs are kept as payroll history and can
+// no longer be recomputed.
+func (s *PayrollService) LockRun(ctx context.Context, adminStaffID string, runID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionLock,
+			constants.ModulePayroll,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	run, err := s.getRunRow(ctx, runID)
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+
+	affected, err := s.dbRW.GetQueries().LockPayrollRun(ctx, queries.LockPayrollRunParams{
+		LockedBy: sql.NullInt64{Int64: s.encoder.Decode(adminStaffID), Valid: true},
+		ID:       run.ID,
+	})
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrPayroll, err)
+	}
+	if affected == 0 {
+		result = errs.ErrPayrollRunLocked.Error()
+		return errs.ErrPayrollRunLocked
+	}
+
+	result = fmt.Sprintf("success. locked ID '%s'", runID)
+	return nil
+}
+
+func periodFromRun(periodStart, periodEnd string) (payroll.Period, error) {
+	start, err := time.Parse(constants.DateLayoutISO, periodStart)
+	if err != nil {
+		return payroll.Period{}, errors.Join(errs.ErrPayrollInvalidPeriod, err)
+	}
+	end, err := time.Parse(constants.DateLayoutISO, periodEnd)
+	if err != nil {
+		return payroll.Period{}, errors.Join(errs.ErrPayrollInvalidPeriod, err)
+	}
+	return payroll.Period{Start: start, End: end}, nil
+}
+
+func (s *PayrollService) toPayrollRun(
+	id int64,
+	periodStart string,
+	periodEnd string,
+	status string,
+	lockedAt sql.NullTime,
+	updatedAt time.Time,
+) (PayrollRun, error) {
+	period, err := periodFromRun(periodStart, periodEnd)
+	if err != nil {
+		return PayrollRun{}, err
+	}
+	run := PayrollRun{
+		ID:        s.encoder.Encode(id),
+		Period:    period,
+		Status:    enums.ParsePayrollRunStatusToEnum(status),
+		UpdatedAt: updatedAt,
+	}
+	if lockedAt.Valid {
+		run.LockedAt = &lockedAt.Time
+	}
+	return run, nil
+}
+
+func (s *PayrollService) GetRuns(ctx context.Context) ([]PayrollRun, error) {
+	rows, err := s.dbRO.GetQueries().GetPayrollRuns(ctx)
+	if err != nil {
+		return nil, errors.Join(errs.ErrPayroll, err)
+	}
+
+	runs := make([]PayrollRun, 0, len(rows))
+	for _, row := range rows {
+		run, err := s.toPayrollRun(row.ID, row.PeriodStart, row.PeriodEnd, row.Status, row.LockedAt, row.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		run.StaffCount = row.StaffCount
+		run.TotalNetPay = row.TotalNetPay
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+func (s *PayrollService) GetRun(ctx context.Context, runID string) (*PayrollRun, []PayrollRunItem, error) {
+	row, err := s.getRunRow(ctx, runID)
+	if err != nil {
+		return nil, nil, err
+	}
+	run, err := s.toPayrollRun(row.ID, row.PeriodStart, row.PeriodEnd, row.Status, row.LockedAt, row.UpdatedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	itemRows, err := s.dbRO.GetQueries().GetPayrollRunItems(ctx, row.ID)
+	if err != nil {
+		return nil, nil, errors.Join(errs.ErrPayroll, err)
+	}
+	items := make([]PayrollRunItem, 0, len(itemRows))
+	for _, item := range itemRows {
+		items = append(items, s.toPayrollRunItem(queries.GetPayrollRunItemRow(item)))
+		run.TotalNetPay += item.NetPay
+	}
+	run.StaffCount = int64(len(items))
+	return &run, items, nil
+}
+
+func (s *PayrollService) toPayrollRunItem(row queries.GetPayrollRunItemRow) PayrollRunItem {
+	return PayrollRunItem{
+		StaffID:  s.encoder.Encode(row.StaffID),
+		FullName: utils.BuildFullName(row.FirstName, row.MiddleName.String, row.LastName),
+		Position: row.Position,
+		RateType: enums.ParsePayRateTypeToEnum(row.RateType),
+		Rate:     row.Rate,
+		Result: payroll.Result{
+			DailyRate:          row.DailyRate,
+			DaysWorked:         row.DaysWorked,
+			AbsentDays:         row.AbsentDays,
+			LateMinutes:        row.LateMinutes,
+			UndertimeMinutes:   row.UndertimeMinutes,
+			OvertimeMinutes:    row.OvertimeMinutes,
+			BasicPay:           row.BasicPay,
+			HolidayPay:         row.HolidayPay,
+			OvertimePay:        row.OvertimePay,
+			LateDeduction:      row.LateDeduction,
+			UndertimeDeduction: row.UndertimeDeduction,
+			AbsenceDeduction:   row.AbsenceDeduction,
+			GrossPay:           row.GrossPay,
+			SSS:                row.Sss,
+			PhilHealth:         row.Philhealth,
+			PagIBIG:            row.Pagibig,
+			WithholdingTax:     row.WithholdingTax,
+			NetPay:             row.NetPay,
+		},
+	}
+}
+
+func (s *PayrollService) GetPayslip(ctx context.Context, runID string, staffID string) (*Payslip, error) {
+	row, err := s.getRunRow(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.toPayrollRun(row.ID, row.PeriodStart, row.PeriodEnd, row.Status, row.LockedAt, row.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		return nil, errs.ErrDecode
+	}
+	item, err := s.dbRO.GetQueries().GetPayrollRunItem(ctx, queries.GetPayrollRunItemParams{
+		PayrollRunID: row.ID,
+		StaffID:      dbStaffID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrPayrollPayslipMissing
+		}
+		return nil, errors.Join(errs.ErrPayroll, err)
+	}
+
+	return &Payslip{Run: run, Item: s.toPayrollRunItem(item)}, nil
+}
+
+func payslipEarnings(item PayrollRunItem) []payslipLine {
+	return []payslipLine{
+		{Label: "Basic Pay", Amount: item.BasicPay},
+		{Label: "Holiday Pay", Amount: item.HolidayPay},
+		{Label: fmt.Sprintf("Overtime Pay (%d min)", item.OvertimeMinutes), Amount: item.OvertimePay},
+	}
+}
+
+func payslipDeductions(item PayrollRunItem) []payslipLine {
+	return []payslipLine{
+		{Label: fmt.Sprintf("Late (%d min)", item.LateMinutes), Amount: item.LateDeduction},
+		{Label: fmt.Sprintf("Undertime (%d min)", item.UndertimeMinutes), Amount: item.UndertimeDeduction},
+		{Label: fmt.Sprintf("Unpaid Absences (%d day/s)", item.AbsentDays), Amount: item.AbsenceDeduction},
+		{Label: "SSS", Amount: item.SSS},
+		{Label: "PhilHealth", Amount: item.PhilHealth},
+		{Label: "Pag-IBIG", Amount: item.PagIBIG},
+		{Label: "Withholding Tax", Amount: item.WithholdingTax},
+	}
+}
+
+func payslipAmount(cents int64) string {
+	return utils.NewMoney(cents, constants.PHP).Display()
+}
+
+func (s *PayrollService) logPayslipExport(ctx context.Context, adminStaffID string, result string) {
+	if err := s.staffLog.CreateLog(
+		ctx,
+		adminStaffID,
+		constants.ActionExport,
+		constants.ModulePayslips,
+		result,
+		nil,
+	); err != nil {
+		logs.LogCtx(ctx).Error("[PayrollService] failed to log payslip export", zap.Error(err))
+	}
+}
+
+func (s *PayrollService) WritePayslipPDF(ctx context.Context, w io.Writer, adminStaffID string, payslip *Payslip) error {
+	result := "success"
+	defer func() { s.logPayslipExport(ctx, adminStaffID, result) }()
+
+	const (
+		left  = 50.0
+		right = pdf.PageWidth - 50
+	)
+	item := payslip.Item
+
+	doc := pdf.NewDocument()
+	doc.AddPage()
+	y := 60.0
+	doc.Text(left, y, 18, true, "C-Choice Payslip")
+	doc.TextRight(right, y, 10, false, "Pay period: "+payslip.Run.Period.Label())
+	y += 28
+	doc.Text(left, y, 11, true, item.FullName)
+	y += 14
+	doc.Text(left, y, 10, false, item.Position)
+	y += 14
+	doc.Text(left, y, 10, false, fmt.Sprintf(
+		"%s rate: %s  |  Daily rate: %s  |  Days worked: %d",
+		item.RateType, payslipAmount(item.Rate), payslipAmount(item.DailyRate), item.DaysWorked,
+	))
+	y += 10
+	doc.Line(left, right, y)
+
+	section := func(title string, lines []payslipLine, totalLabel string, total int64) {
+		y += 22
+		doc.Text(left, y, 11, true, title)
+		for _, line := range lines {
+			y += 16
+			doc.Text(left+10, y, 10, false, line.Label)
+			doc.TextRight(right, y, 10, false, payslipAmount(line.Amount))
+		}
+		y += 8
+		doc.Line(left, right, y)
+		y += 14
+		doc.Text(left+10, y, 10, true, totalLabel)
+		doc.TextRight(right, y, 10, true, payslipAmount(total))
+	}
+
+	section("Earnings", payslipEarnings(item), "Gross Pay (less time deductions)", item.GrossPay)
+	section("Deductions", payslipDeductions(item), "Net Pay", item.NetPay)
+
+	if payslip.Run.Status != enums.PAYROLL_RUN_STATUS_LOCKED {
+		y += 30
+		doc.Text(left, y, 9, false, "DRAFT - amounts may still change until the payroll run is locked.")
+	}
+
+	if _, err := doc.WriteTo(w); err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrPayroll, err)
+	}
+	result = fmt.Sprintf("success. pdf run ID '%s' staff ID '%s'", payslip.Run.ID, item.StaffID)
+	return nil
+}
+
+func (s *PayrollService) WritePayslipXLSX(ctx context.Context, file *excelize.File, adminStaffID string, payslip *Payslip) error {
+	result := "success"
+	defer func() { s.logPayslipExport(ctx, adminStaffID, result) }()
+
+	const sheet = "Sheet1"
+	item := payslip.Item
+	rows := [][]any{
+		{"Payslip", payslip.Run.Period.Label()},
+		{"Name", item.FullName},
+		{"Position", item.Position},
+		{"Rate Type", item.RateType.String()},
+		{"Rate", payslipAmount(item.Rate)},
+		{"Daily Rate", payslipAmount(item.DailyRate)},
+		{"Days Worked", item.DaysWorked},
+		{"Status", payslip.Run.Status.String()},
+		{},
+		{"Earnings"},
+	}
+	for _, line := range payslipEarnings(item) {
+		rows = append(rows, []any{line.Label, payslipAmount(line.Amount)})
+	}
+	rows = append(rows, []any{"Gross Pay", payslipAmount(item.GrossPay)}, []any{}, []any{"Deductions"})
+	for _, line := range payslipDeductions(item) {
+		rows = append(rows, []any{line.Label, payslipAmount(line.Amount)})
+	}
+	rows = append(rows, []any{}, []any{"Net Pay", payslipAmount(item.NetPay)})
+
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			result = err.Error()
+			return err
+		}
+		if err := file.SetSheetRow(sheet, cell, &row); err != nil {
+			result = err.Error()
+			return err
+		}
+	}
+	if err := file.SetColWidth(sheet, "A", "B", 32); err != nil {
+		result = err.Error()
+		return err
+	}
+
+	result = fmt.Sprintf("success. xlsx run ID '%s' staff ID '%s'", payslip.Run.ID, item.StaffID)
+	return nil
+}
+
+func (s *PayrollService) ID() string {
+	return "Payroll"
+}
+
+func (s *PayrollService) Log() {
+	logs.Log().Info("[PayrollService] Loaded")
+}
+
+var _ IService = (*PayrollService)(nil)
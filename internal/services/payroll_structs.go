@@ -0,0 +1,45 @@
+package services
+
+import (
+	"time"
+
+	"cchoice/internal/enums"
+	"cchoice/internal/payroll"
+)
+
+type StaffPayRate struct {
+	StaffID  string
+	FullName string
+	Position string
+	RateType enums.PayRateType
+	Rate     int64
+}
+
+type PayrollRun struct {
+	ID          string
+	Period      payroll.Period
+	Status      enums.PayrollRunStatus
+	StaffCount  int64
+	TotalNetPay int64
+	LockedAt    *time.Time
+	UpdatedAt   time.Time
+}
+
+type PayrollRunItem struct {
+	StaffID  string
+	FullName string
+	Position string
+	RateType enums.PayRateType
+	Rate     int64
+	payroll.Result
+}
+
+type Payslip struct {
+	Run  PayrollRun
+	Item PayrollRunItem
+}
+
+type payslipLine struct {
+	Label  string
+	Amount int64
+}
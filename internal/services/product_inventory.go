@@ -22,6 +22,7 @@ type ProductInventoryService struct {
 	encoder  encode.IEncode
 	dbRO     database.IService
 	dbRW     database.IService
+	wishlist *WishlistService
 	staffLog *StaffLogsService
 }
 
@@ -29,8 +30,12 @@ func NewProductInventoryService(
 	encoder encode.IEncode,
 	dbRO database.IService,
 	dbRW database.IService,
+	wishlist *WishlistService,
 	staffLog *StaffLogsService,
 ) *ProductInventoryService {
+	if wishlist == nil {
+		panic("WishlistService is required")
+	}
 	if staffLog == nil {
 		panic("StaffLogsService is required")
 	}
@@ -38,6 +43,7 @@ func NewProductInventoryService(
 		encoder:  encoder,
 		dbRO:     dbRO,
 		dbRW:     dbRW,
+		wishlist: wishlist,
 		staffLog: staffLog,
 	}
 }
@@ -216,6 +222,7 @@ func (s *ProductInventoryService) Create(
 		result = err.Error()
 		return "", errors.Join(errs.ErrProductInventory, err)
 	}
+	s.notifyIfRestocked(ctx, productDBID, 0, stocks)
 
 	inventoryID := s.encoder.Encode(id)
 	result = fmt.Sprintf("success. ID '%s'", inventoryID)
@@ -249,14 +256,30 @@ func (s *ProductInventoryService) SetQty(
 		return errs.ErrDecode
 	}
 
-	if err := s.dbRW.GetQueries().UpdateProductInventory(ctx, queries.UpdateProductInventoryParams{
+	var prevStocks int64
+	prev, err := s.dbRO.GetQueries().GetProductInventoryByProductID(ctx, productDBID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		result = err.Error()
+		return errors.Join(errs.ErrProductInventory, err)
+	}
+	if err == nil {
+		prevStocks = prev.Stocks
+	}
+
+	affected, err := s.dbRW.GetQueries().UpdateProductInventory(ctx, queries.UpdateProductInventoryParams{
 		ProductID: productDBID,
 		StocksIn:  stocksIn.String(),
 		Stocks:    qty,
-	}); err != nil {
+	})
+	if err != nil {
 		result = err.Error()
 		return errors.Join(errs.ErrProductInventory, err)
 	}
+	if affected == 0 {
+		result = "no inventory for product and stocks in"
+		return errs.ErrProductInventory
+	}
+	s.notifyIfRestocked(ctx, productDBID, prevStocks, qty)
 
 	result = fmt.Sprintf("success. product ID '%s'", productID)
 	return nil
@@ -289,6 +312,12 @@ func (s *ProductInventoryService) UpdateByID(
 		return errs.ErrDecode
 	}
 
+	prev, err := s.dbRO.GetQueries().GetProductInventoryByID(ctx, decoded)
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrProductInventory, err)
+	}
+
 	if err := s.dbRW.GetQueries().UpdateProductInventoryByID(ctx, queries.UpdateProductInventoryByIDParams{
 		ID:       decoded,
 		Stocks:   qty,
@@ -297,11 +326,23 @@ func (s *ProductInventoryService) UpdateByID(
 		result = err.Error()
 		return errors.Join(errs.ErrProductInventory, err)
 	}
+	s.notifyIfRestocked(ctx, prev.ProductID, prev.Stocks, qty)
 
 	result = fmt.Sprintf("success. inventory ID '%s'", inventoryID)
 	return nil
 }
 
+// notifyIfRestocked sends back-in-stock alerts only when stocks go from empty
+// to available. Notification failures must not fail the inventory update.
+func (s *ProductInventoryService) notifyIfRestocked(ctx context.Context, productID int64, prevStocks, newStocks int64) {
+	if prevStocks > 0 || newStocks <= 0 {
+		return
+	}
+	if err := s.wishlist.NotifyBackInStock(ctx, productID); err != nil {
+		logs.LogCtx(ctx).Warn("[ProductInventoryService] notify back in stock", zap.Int64("product_id", productID), zap.Error(err))
+	}
+}
+
 func (s *ProductInventoryService) mapRowToProductInventory(p queries.TblProductInventory) *ProductInventory {
 	return &ProductInventory{
 		ID:        s.encoder.Encode(p.ID),
@@ -0,0 +1,410 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+
+	"go.uber.org/zap"
+)
+
+type ProductReviewService struct {
+	encoder  encode.IEncode
+	dbRO     database.IService
+	dbRW     database.IService
+	staffLog *StaffLogsService
+}
+
+func NewProductReviewService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	staffLog *StaffLogsService,
+) *ProductReviewService {
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &ProductReviewService{
+		encoder:  encoder,
+		dbRO:     dbRO,
+		dbRW:     dbRW,
+		staffLog: staffLog,
+	}
+}
+
+func (s *ProductReviewService) GetEligibility(
+	ctx context.Context,
+	customerID string,
+	productID string,
+) (ProductReviewEligibility, error) {
+	if customerID == "" {
+		return ProductReviewEligibility{}, nil
+	}
+
+	decodedCustomerID := s.encoder.Decode(customerID)
+	decodedProductID := s.encoder.Decode(productID)
+	if decodedCustomerID == encode.INVALID || decodedProductID == encode.INVALID {
+		return ProductReviewEligibility{}, errs.ErrDecode
+	}
+
+	_, err := s.dbRO.GetQueries().GetProductReviewByCustomerAndProduct(ctx, queries.GetProductReviewByCustomerAndProductParams{
+		CustomerID: decodedCustomerID,
+		ProductID:  decodedProductID,
+	})
+	if err == nil {
+		return ProductReviewEligibility{AlreadyReviewed: true}, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return ProductReviewEligibility{}, errors.Join(errs.ErrProductReview, err)
+	}
+
+	if _, err := s.getDeliveredOrderID(ctx, decodedCustomerID, decodedProductID); err != nil {
+		if errors.Is(err, errs.ErrProductReviewNotEligible) {
+			return ProductReviewEligibility{}, nil
+		}
+		return ProductReviewEligibility{}, err
+	}
+
+	return ProductReviewEligibility{CanReview: true}, nil
+}
+
+func (s *ProductReviewService) getDeliveredOrderID(ctx context.Context, customerID, productID int64) (int64, error) {
+	orderID, err := s.dbRO.GetQueries().GetLatestDeliveredOrderIDForCustomerProduct(ctx, queries.GetLatestDeliveredOrderIDForCustomerProductParams{
+		CustomerID: sql.NullInt64{Int64: customerID, Valid: true},
+		ProductID:  productID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errs.ErrProductReviewNotEligible
+		}
+		return 0, errors.Join(errs.ErrProductReview, err)
+	}
+	return orderID, nil
+}
+
+func ValidateProductReviewInput(rating int64, title, body string, photoCount int) error {
+	if rating < constants.ProductReviewMinRating || rating > constants.ProductReviewMaxRating {
+		return errs.ErrProductReviewInvalidRating
+	}
+	if utf8.RuneCountInString(title) > constants.ProductReviewMaxTitleLen ||
+		utf8.RuneCountInString(body) > constants.ProductReviewMaxBodyLen {
+		return errs.ErrProductReviewBodyTooLong
+	}
+	if photoCount > constants.ProductReviewMaxPhotos {
+		return errs.ErrProductReviewTooManyPhotos
+	}
+	return nil
+}
+
+func (s *ProductReviewService) Create(
+	ctx context.Context,
+	customerID string,
+	productID string,
+	rating int64,
+	title string,
+	body string,
+	photos []ProductReviewPhoto,
+) (string, error) {
+	const logtag = "[ProductReviewService] Create"
+
+	title = strings.TrimSpace(title)
+	body = strings.TrimSpace(body)
+	if err := ValidateProductReviewInput(rating, title, body, len(photos)); err != nil {
+		return "", err
+	}
+
+	decodedCustomerID := s.encoder.Decode(customerID)
+	decodedProductID := s.encoder.Decode(productID)
+	if decodedCustomerID == encode.INVALID || decodedProductID == encode.INVALID {
+		return "", errs.ErrDecode
+	}
+
+	eligibility, err := s.GetEligibility(ctx, customerID, productID)
+	if err != nil {
+		return "", err
+	}
+	if eligibility.AlreadyReviewed {
+		return "", errs.ErrProductReviewAlreadyExists
+	}
+	orderID, err := s.getDeliveredOrderID(ctx, decodedCustomerID, decodedProductID)
+	if err != nil {
+		return "", err
+	}
+
+	var reviewID int64
+	if err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		var err error
+		reviewID, err = qtx.CreateProductReview(ctx, queries.CreateProductReviewParams{
+			ProductID:  decodedProductID,
+			CustomerID: decodedCustomerID,
+			OrderID:    orderID,
+			Rating:     rating,
+			Title:      title,
+			Body:       body,
+		})
+		if err != nil {
+			return err
+		}
+		for _, photo := range photos {
+			if _, err := qtx.CreateProductReviewPhoto(ctx, queries.CreateProductReviewPhotoParams{
+				ReviewID: reviewID,
+				Path:     photo.Path,
+				Url:      photo.URL,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return "", errors.Join(errs.ErrProductReview, err)
+	}
+
+	encodedID := s.encoder.Encode(reviewID)
+	logs.LogCtx(ctx).Info(
+		logtag,
+		zap.String("review_id", encodedID),
+		zap.String("product_id", productID),
+		zap.Int("photos", len(photos)),
+	)
+	return encodedID, nil
+}
+
+func (s *ProductReviewService) GetRating(ctx context.Context, productID string) (ProductRating, error) {
+	decodedProductID := s.encoder.Decode(productID)
+	if decodedProductID == encode.INVALID {
+		return ProductRating{}, errs.ErrDecode
+	}
+
+	row, err := s.dbRO.GetQueries().GetProductRatingByProductID(ctx, decodedProductID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ProductRating{}, nil
+		}
+		return ProductRating{}, errors.Join(errs.ErrProductReview, err)
+	}
+	return NewProductRating(row.RatingCount, row.RatingTotal), nil
+}
+
+func (s *ProductReviewService) GetApprovedForProduct(ctx context.Context, productID string, limit int64) ([]ProductReview, error) {
+	decodedProductID := s.encoder.Decode(productID)
+	if decodedProductID == encode.INVALID {
+		return nil, errs.ErrDecode
+	}
+
+	rows, err := s.dbRO.GetQueries().GetApprovedProductReviewsByProductID(ctx, queries.GetApprovedProductReviewsByProductIDParams{
+		ProductID: decodedProductID,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrProductReview, err)
+	}
+
+	reviewIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		reviewIDs = append(reviewIDs, row.ID)
+	}
+	photos, err := s.getPhotoURLs(ctx, reviewIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ProductReview, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, ProductReview{
+			ID:           s.encoder.Encode(row.ID),
+			Rating:       row.Rating,
+			Title:        row.Title,
+			Body:         row.Body,
+			ReviewerName: ReviewerDisplayName(row.CustomerFirstName, row.CustomerLastName),
+			PhotoURLs:    photos[row.ID],
+			CreatedAt:    row.CreatedAt.Format(constants.DateLayoutISO),
+		})
+	}
+	return result, nil
+}
+
+func (s *ProductReviewService) getPhotoURLs(ctx context.Context, reviewIDs []int64) (map[int64][]string, error) {
+	result := make(map[int64][]string, len(reviewIDs))
+	if len(reviewIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := s.dbRO.GetQueries().GetProductReviewPhotosByReviewIDs(ctx, reviewIDs)
+	if err != nil {
+		return nil, errors.Join(errs.ErrProductReview, err)
+	}
+	for _, row := range rows {
+		result[row.ReviewID] = append(result[row.ReviewID], row.Url)
+	}
+	return result, nil
+}
+
+func (s *ProductReviewService) GetForModerationPaginated(
+	ctx context.Context,
+	status enums.ProductReviewStatus,
+	page, perPage int,
+) ([]ProductReviewAdminListItem, int64, int, error) {
+	if status == enums.PRODUCT_REVIEW_STATUS_UNDEFINED {
+		status = enums.PRODUCT_REVIEW_STATUS_PENDING
+	}
+
+	totalCount, err := s.dbRO.GetQueries().AdminCountProductReviewsByStatus(ctx, status.String())
+	if err != nil {
+		return nil, 0, 0, errors.Join(errs.ErrProductReview, err)
+	}
+
+	page = models.ClampPage(page, totalCount, perPage)
+	offset := int64((page - 1) * perPage)
+
+	rows, err := s.dbRO.GetQueries().AdminGetProductReviewsByStatusPaginated(ctx, queries.AdminGetProductReviewsByStatusPaginatedParams{
+		Status: status.String(),
+		Limit:  int64(perPage),
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, 0, 0, errors.Join(errs.ErrProductReview, err)
+	}
+
+	reviewIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		reviewIDs = append(reviewIDs, row.ID)
+	}
+	photos, err := s.getPhotoURLs(ctx, reviewIDs)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	items := make([]ProductReviewAdminListItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, ProductReviewAdminListItem{
+			ID:              s.encoder.Encode(row.ID),
+			ProductName:     row.ProductName,
+			ProductSerial:   row.ProductSerial,
+			CustomerName:    row.CustomerFirstName + " " + row.CustomerLastName,
+			CustomerEmail:   row.CustomerEmail,
+			Rating:          row.Rating,
+			Title:           row.Title,
+			Body:            row.Body,
+			Status:          enums.ParseProductReviewStatusToEnum(row.Status),
+			RejectionReason: row.RejectionReason,
+			PhotoURLs:       photos[row.ID],
+			CreatedAt:       row.CreatedAt.Format(constants.DateTimeLayoutISO),
+		})
+	}
+	return items, totalCount, page, nil
+}
+
+func (s *ProductReviewService) Approve(ctx context.Context, staffID string, reviewID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionApprove,
+			constants.ModuleProductReviews,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	if err := s.moderate(ctx, staffID, reviewID, enums.PRODUCT_REVIEW_STATUS_APPROVED, ""); err != nil {
+		result = err.Error()
+		return err
+	}
+
+	result = fmt.Sprintf("success. ID '%s'", reviewID)
+	return nil
+}
+
+func (s *ProductReviewService) Reject(ctx context.Context, staffID string, reviewID string, reason string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionReject,
+			constants.ModuleProductReviews,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	if err := s.moderate(ctx, staffID, reviewID, enums.PRODUCT_REVIEW_STATUS_REJECTED, strings.TrimSpace(reason)); err != nil {
+		result = err.Error()
+		return err
+	}
+
+	result = fmt.Sprintf("success. ID '%s'", reviewID)
+	return nil
+}
+
+func (s *ProductReviewService) moderate(
+	ctx context.Context,
+	staffID string,
+	reviewID string,
+	status enums.ProductReviewStatus,
+	reason string,
+) error {
+	const logtag = "[ProductReviewService] moderate"
+
+	decodedReviewID := s.encoder.Decode(reviewID)
+	if decodedReviewID == encode.INVALID {
+		return errs.ErrDecode
+	}
+	decodedStaffID := s.encoder.Decode(staffID)
+	if decodedStaffID == encode.INVALID {
+		return errs.ErrDecode
+	}
+
+	err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		productID, err := qtx.ModerateProductReview(ctx, queries.ModerateProductReviewParams{
+			Status:          status.String(),
+			RejectionReason: reason,
+			ModeratedBy:     sql.NullInt64{Int64: decodedStaffID, Valid: true},
+			ID:              decodedReviewID,
+		})
+		if err != nil {
+			return err
+		}
+		return qtx.RefreshProductRating(ctx, productID)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return errs.ErrProductReviewNotPending
+	}
+	if err != nil {
+		return errors.Join(errs.ErrProductReview, err)
+	}
+
+	logs.LogCtx(ctx).Info(
+		logtag,
+		zap.String("review_id", reviewID),
+		zap.Stringer("status", status),
+	)
+	return nil
+}
+
+func (s *ProductReviewService) ID() string {
+	return "ProductReview"
+}
+
+func (s *ProductReviewService) Log() {
+	logs.Log().Info("[ProductReviewService] Loaded")
+}
+
+var _ IService = (*ProductReviewService)(nil)
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"strings"
+
+	"cchoice/internal/enums"
+	"cchoice/internal/utils"
+)
+
+type ProductRating struct {
+	Count   int64
+	Average float64
+}
+
+type ProductReview struct {
+	ID           string
+	Rating       int64
+	Title        string
+	Body         string
+	ReviewerName string
+	PhotoURLs    []string
+	CreatedAt    string
+}
+
+type ProductReviewAdminListItem struct {
+	ID              string
+	ProductName     string
+	ProductSerial   string
+	CustomerName    string
+	CustomerEmail   string
+	Rating          int64
+	Title           string
+	Body            string
+	Status          enums.ProductReviewStatus
+	RejectionReason string
+	PhotoURLs       []string
+	CreatedAt       string
+}
+
+type ProductReviewEligibility struct {
+	CanReview       bool
+	AlreadyReviewed bool
+}
+
+type ProductReviewPhoto struct {
+	Path string
+	URL  string
+}
+
+func NewProductRating(count, total int64) ProductRating {
+	if count <= 0 {
+		return ProductRating{}
+	}
+	return ProductRating{
+		Count:   count,
+		Average: utils.RatingAverage(count, total),
+	}
+}
+
+// ReviewerDisplayName only exposes the first name and the last name initial
+// so that public reviews do not leak the customer's full name.
+func ReviewerDisplayName(firstName, lastName string) string {
+	firstName = strings.TrimSpace(firstName)
+	lastName = strings.TrimSpace(lastName)
+	if lastName == "" {
+		return firstName
+	}
+	initial := []rune(lastName)[0]
+	if firstName == "" {
+		return string(initial) + "."
+	}
+	return firstName + " " + string(initial) + "."
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"cchoice/internal/constants"
+	"cchoice/internal/errs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProductReviewInput(t *testing.T) {
+	tests := []struct {
+		name       string
+		rating     int64
+		title      string
+		body       string
+		photoCount int
+		wantErr    error
+	}{
+		{"valid", 5, "Great", "Works well", 0, nil},
+		{"valid with max photos", 1, "", "Broke quickly", constants.ProductReviewMaxPhotos, nil},
+		{"rating too low", 0, "", "body", 0, errs.ErrProductReviewInvalidRating},
+		{"rating too high", 6, "", "body", 0, errs.ErrProductReviewInvalidRating},
+		{"body too long", 3, "", strings.Repeat("a", constants.ProductReviewMaxBodyLen+1), 0, errs.ErrProductReviewBodyTooLong},
+		{"title too long", 3, strings.Repeat("a", constants.ProductReviewMaxTitleLen+1), "body", 0, errs.ErrProductReviewBodyTooLong},
+		{"too many photos", 4, "", "body", constants.ProductReviewMaxPhotos + 1, errs.ErrProductReviewTooManyPhotos},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProductReviewInput(tt.rating, tt.title, tt.body, tt.photoCount)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReviewerDisplayName(t *testing.T) {
+	tests := []struct {
+		first string
+		last  string
+		want  string
+	}{
+		{"Juan", "Dela Cruz", "Juan D."},
+		{"  Maria ", " santos", "Maria s."},
+		{"Pedro", "", "Pedro"},
+		{"", "Reyes", "R."},
+		{"Ana", "Ñuñez", "Ana Ñ."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, ReviewerDisplayName(tt.first, tt.last))
+		})
+	}
+}
+
+func TestNewProductRating(t *testing.T) {
+	assert.Equal(t, ProductRating{}, NewProductRating(0, 0))
+	assert.Equal(t, ProductRating{Count: 3, Average: 4.3}, NewProductRating(3, 13))
+}
@@ -19,6 +19,8 @@ import (
 	"cchoice/internal/logs"
 	"cchoice/internal/seo"
 	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
 )
 
 type ProductService struct {
@@ -27,6 +29,8 @@ type ProductService struct {
 	encoder          encode.IEncode
 	getCDNURL        models.CDNURLFunc
 	productInventory *ProductInventoryService
+	productReview    *ProductReviewService
+	wishlist         *WishlistService
 	staffLog         *StaffLogsService
 }
 
@@ -36,11 +40,19 @@ func NewProductService(
 	dbRW database.IService,
 	cdnURLFunc models.CDNURLFunc,
 	productInventory *ProductInventoryService,
+	productReview *ProductReviewService,
+	wishlist *WishlistService,
 	staffLog *StaffLogsService,
 ) *ProductService {
 	if productInventory == nil {
 		panic("ProductInventoryService is required")
 	}
+	if productReview == nil {
+		panic("ProductReviewService is required")
+	}
+	if wishlist == nil {
+		panic("WishlistService is required")
+	}
 	if staffLog == nil {
 		panic("StaffLogsService is required")
 	}
@@ -50,6 +62,8 @@ func NewProductService(
 		encoder:          encoder,
 		getCDNURL:        cdnURLFunc,
 		productInventory: productInventory,
+		productReview:    productReview,
+		wishlist:         wishlist,
 		staffLog:         staffLog,
 	}
 }
@@ -779,7 +793,7 @@ func (s *ProductService) syncProductSale(
 			})
 		}
 
-		_, err := s.dbRW.GetQueries().CreateProductSale(ctx, queries.CreateProductSaleParams{
+		if _, err := s.dbRW.GetQueries().CreateProductSale(ctx, queries.CreateProductSaleParams{
 			ProductID:                   productID,
 			SalePriceWithoutVat:         salePriceWithoutVat * 100,
 			SalePriceWithVat:            salePriceWithVat * 100,
@@ -790,8 +804,15 @@ func (s *ProductService) syncProductSale(
 			StartsAt:                    startsAt,
 			EndsAt:                      endsAt,
 			IsActive:                    true,
-		})
-		return err
+		}); err != nil {
+			return err
+		}
+
+		// A sale starting later is announced by the scheduler once it starts.
+		if err := s.wishlist.NotifyStartedSales(ctx); err != nil {
+			logs.LogCtx(ctx).Warn("[ProductService] notify on sale", zap.Int64("product_id", productID), zap.Error(err))
+		}
+		return nil
 	}
 
 	if hasActiveSale {
@@ -882,7 +903,15 @@ func (s *ProductService) GetForPage(ctx context.Context, slug string) (*models.P
 	if row.Slug.Valid && row.Slug.String != "" {
 		productSlug = row.Slug.String
 	}
-	meta := s.GenerateMeta(&row, productSlug, s.resolveSEOImageURL(cdnURL, cdnURL1280, row.ImagePath, row.ThumbnailPath), priceAmount, priceCurrency)
+	rating := NewProductRating(row.RatingCount, row.RatingTotal)
+	var reviews []ProductReview
+	if rating.Count > 0 {
+		reviews, err = s.productReview.GetApprovedForProduct(ctx, s.encoder.Encode(row.ID), constants.ProductReviewsSEOLimit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	meta := s.GenerateMeta(&row, productSlug, s.resolveSEOImageURL(cdnURL, cdnURL1280, row.ImagePath, row.ThumbnailPath), priceAmount, priceCurrency, rating, reviews)
 
 	externalLinks, err := s.buildProductExternalPlatformLinks(ctx, row.ID)
 	if err != nil {
@@ -916,6 +945,8 @@ func (s *ProductService) GetForPage(ctx context.Context, slug string) (*models.P
 		Sizes:                      sizes,
 		Specs:                      specs,
 		ExternalLinks:              externalLinks,
+		RatingAverage:              rating.Average,
+		RatingCount:                rating.Count,
 	}, nil
 }
 
@@ -925,7 +956,20 @@ func (s *ProductService) GenerateMeta(
 	imageURL string,
 	priceAmount string,
 	priceCurrency string,
+	rating ProductRating,
+	reviews []ProductReview,
 ) models.ProductsMeta {
+	seoReviews := make([]seo.ProductReview, 0, len(reviews))
+	for _, review := range reviews {
+		seoReviews = append(seoReviews, seo.ProductReview{
+			AuthorName:    review.ReviewerName,
+			Rating:        review.Rating,
+			Title:         review.Title,
+			Body:          review.Body,
+			DatePublished: review.CreatedAt,
+		})
+	}
+
 	meta := seo.GenerateProductMeta(
 		seo.Product{
 			BrandName:          product.BrandName,
@@ -935,6 +979,9 @@ func (s *ProductService) GenerateMeta(
 			ProductCategory:    product.ProductCategory,
 			ProductSubcategory: product.ProductSubcategory,
 			OnSale:             product.IsOnSale == 1,
+			RatingCount:        rating.Count,
+			RatingAverage:      rating.Average,
+			Reviews:            seoReviews,
 		},
 		utils.SiteURL("/product/"+slug),
 		utils.SiteURL("/"),
@@ -81,6 +81,21 @@ func (s *PromoService) GetActivePromos(ctx context.Context) ([]Promo, error) {
 	return result, nil
 }
 
+// GetVariantPromos returns the live promos that an experiment variant shows
+// in place of the regular banner rotation.
+func (s *PromoService) GetVariantPromos(ctx context.Context, variantID int64) ([]Promo, error) {
+	promos, err := s.dbRO.GetQueries().GetActiveVariantPromos(ctx, variantID)
+	if err != nil {
+		return nil, errors.Join(errs.ErrPromo, err)
+	}
+
+	result := make([]Promo, 0, len(promos))
+	for _, p := range promos {
+		result = append(result, *s.mapRowToPromo(p.TblPromo))
+	}
+	return result, nil
+}
+
 func (s *PromoService) CreatePromo(
 	ctx context.Context,
 	staffID string,
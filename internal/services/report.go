@@ -12,6 +12,7 @@ import (
 	"cchoice/internal/database"
 	"cchoice/internal/encode"
 	"cchoice/internal/logs"
+	"cchoice/internal/shift"
 	"cchoice/internal/utils"
 
 	"github.com/xuri/excelize/v2"
@@ -23,12 +24,15 @@ type ReportService struct {
 	dbRO              database.IService
 	attendanceService *AttendanceService
 	holiday           *HolidayService
+	shift             *ShiftService
+	correction        *AttendanceCorrectionService
 	staffLog          *StaffLogsService
 }
 
 var headers = []string{
 	"Date",
 	"Name",
+	"Schedule",
 	"Time In",
 	"Time Out",
 	"Holiday",
@@ -41,6 +45,7 @@ var headers = []string{
 	"Lunch Break Duration",
 	"Lunch Break In Loc/Useragent",
 	"Lunch Break Out Loc/Useragent",
+	"Corrected",
 }
 
 func NewReportService(
@@ -48,11 +53,19 @@ func NewReportService(
 	dbRO database.IService,
 	attendanceService *AttendanceService,
 	holiday *HolidayService,
+	shift *ShiftService,
+	correction *AttendanceCorrectionService,
 	staffLog *StaffLogsService,
 ) *ReportService {
 	if attendanceService == nil {
 		panic("AttendanceService is required")
 	}
+	if shift == nil {
+		panic("ShiftService is required")
+	}
+	if correction == nil {
+		panic("AttendanceCorrectionService is required")
+	}
 	if staffLog == nil {
 		panic("StaffLogsService is required")
 	}
@@ -61,6 +74,8 @@ func NewReportService(
 		dbRO:              dbRO,
 		attendanceService: attendanceService,
 		holiday:           holiday,
+		shift:             shift,
+		correction:        correction,
 		staffLog:          staffLog,
 	}
 }
@@ -111,6 +126,18 @@ func (s *ReportService) StreamReportCSV(
 		holidayMap[h.Date] = h
 	}
 
+	schedules, err := s.shift.GetSchedules(ctx, startDate, endDate)
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+
+	corrected, err := s.correction.GetCorrectedDates(ctx, startDate, endDate)
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+
 	attMap := make(map[string]StaffRow, len(data))
 	for _, att := range data {
 		attMap[att.ForDate] = att
@@ -152,16 +179,7 @@ func (s *ReportService) StreamReportCSV(
 			return err
 		}
 
-		staffDB, err := s.dbRO.GetQueries().GetStaffByID(ctx, decodedStaffID)
-		if err != nil {
-			result = err.Error()
-			return err
-		}
-		if err := writer.Write([]string{fmt.Sprintf(
-			"Scheduled working time: %s - %s",
-			staffDB.TimeInSchedule.String,
-			staffDB.TimeOutSchedule.String,
-		)}); err != nil {
+		if err := writer.Write([]string{scheduleSummary(schedules[decodedStaffID])}); err != nil {
 			result = err.Error()
 			return err
 		}
@@ -188,13 +206,18 @@ func (s *ReportService) StreamReportCSV(
 	for _, dateStr := range allDates {
 		if h, ok := holidayMap[dateStr]; ok {
 			if att, ok := attMap[dateStr]; ok {
-				if err := writer.Write(buildAttendanceRowWithHoliday(att, h)); err != nil {
+				if err := writer.Write(buildAttendanceRowWithHoliday(att, h, schedules[att.StaffID].For(dateStr), corrected.Has(att.StaffID, dateStr))); err != nil {
 					return err
 				}
 			} else {
+				var sh string
+				if decodedStaffID != encode.INVALID {
+					sh = schedules[decodedStaffID].For(dateStr).String()
+				}
 				if err := writer.Write([]string{
 					dateStr,
 					"",
+					sh,
 					"",
 					"",
 					h.Name,
@@ -207,12 +230,13 @@ func (s *ReportService) StreamReportCSV(
 					"",
 					"",
 					"",
+					"",
 				}); err != nil {
 					return err
 				}
 			}
 		} else if att, ok := attMap[dateStr]; ok {
-			if err := writer.Write(buildAttendanceRow(att)); err != nil {
+			if err := writer.Write(buildAttendanceRow(att, schedules[att.StaffID].For(dateStr), corrected.Has(att.StaffID, dateStr))); err != nil {
 				return err
 			}
 		}
@@ -220,7 +244,7 @@ func (s *ReportService) StreamReportCSV(
 	return nil
 }
 
-func buildAttendanceRow(att StaffRow) []string {
+func buildAttendanceRow(att StaffRow, sh shift.Shift, corrected bool) []string {
 	timeIn := utils.ExtractTimeToPH(att.TimeIn.String)
 	timeOut := utils.ExtractTimeToPH(att.TimeOut.String)
 
@@ -250,6 +274,7 @@ func buildAttendanceRow(att StaffRow) []string {
 	return []string{
 		att.ForDate,
 		utils.BuildFullName(att.FirstName, att.MiddleName.String, att.LastName),
+		sh.String(),
 		timeIn,
 		timeOut,
 		"",
@@ -262,10 +287,11 @@ func buildAttendanceRow(att StaffRow) []string {
 		lbDuration,
 		lbInLocUA,
 		lbOutLocUA,
+		correctedLabel(corrected),
 	}
 }
 
-func buildAttendanceRowWithHoliday(att StaffRow, h Holiday) []string {
+func buildAttendanceRowWithHoliday(att StaffRow, h Holiday, sh shift.Shift, corrected bool) []string {
 	timeIn := utils.ExtractTimeToPH(att.TimeIn.String)
 	timeOut := utils.ExtractTimeToPH(att.TimeOut.String)
 
@@ -295,6 +321,7 @@ func buildAttendanceRowWithHoliday(att StaffRow, h Holiday) []string {
 	return []string{
 		att.ForDate,
 		utils.BuildFullName(att.FirstName, att.MiddleName.String, att.LastName),
+		sh.String(),
 		timeIn,
 		timeOut,
 		h.Name,
@@ -307,6 +334,7 @@ func buildAttendanceRowWithHoliday(att StaffRow, h Holiday) []string {
 		lbDuration,
 		lbInLocUA,
 		lbOutLocUA,
+		correctedLabel(corrected),
 	}
 }
 
@@ -356,6 +384,18 @@ func (s *ReportService) StreamReportXLSX(
 		holidayMap[h.Date] = h
 	}
 
+	schedules, err := s.shift.GetSchedules(ctx, startDate, endDate)
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+
+	corrected, err := s.correction.GetCorrectedDates(ctx, startDate, endDate)
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+
 	attMap := make(map[string]StaffRow, len(data))
 	for _, att := range data {
 		attMap[att.ForDate] = att
@@ -408,19 +448,7 @@ func (s *ReportService) StreamReportXLSX(
 		}
 		row++
 
-		staffDB, err := s.dbRO.GetQueries().GetStaffByID(ctx, decodedStaffID)
-		if err != nil {
-			return err
-		}
-		if err := file.SetCellValue(
-			sheet,
-			fmt.Sprintf("A%d", row),
-			fmt.Sprintf(
-				"Scheduled working time: %s - %s",
-				staffDB.TimeInSchedule.String,
-				staffDB.TimeOutSchedule.String,
-			),
-		); err != nil {
+		if err := file.SetCellValue(sheet, fmt.Sprintf("A%d", row), scheduleSummary(schedules[decodedStaffID])); err != nil {
 			return err
 		}
 		row++
@@ -474,7 +502,7 @@ func (s *ReportService) StreamReportXLSX(
 	for _, dateStr := range allDates {
 		if h, ok := holidayMap[dateStr]; ok {
 			if att, ok := attMap[dateStr]; ok {
-				values := buildAttendanceRowWithHoliday(att, h)
+				values := buildAttendanceRowWithHoliday(att, h, schedules[att.StaffID].For(dateStr), corrected.Has(att.StaffID, dateStr))
 				for colIdx, value := range values {
 					col, err := excelize.ColumnNumberToName(colIdx + 1)
 					if err != nil {
@@ -488,16 +516,21 @@ func (s *ReportService) StreamReportXLSX(
 				if err := file.SetCellValue(sheet, fmt.Sprintf("A%d", row), dateStr); err != nil {
 					return err
 				}
-				if err := file.SetCellValue(sheet, fmt.Sprintf("E%d", row), h.Name); err != nil {
+				if decodedStaffID != encode.INVALID {
+					if err := file.SetCellValue(sheet, fmt.Sprintf("C%d", row), schedules[decodedStaffID].For(dateStr).String()); err != nil {
+						return err
+					}
+				}
+				if err := file.SetCellValue(sheet, fmt.Sprintf("F%d", row), h.Name); err != nil {
 					return err
 				}
-				if err := file.SetCellValue(sheet, fmt.Sprintf("F%d", row), h.Type.String()); err != nil {
+				if err := file.SetCellValue(sheet, fmt.Sprintf("G%d", row), h.Type.String()); err != nil {
 					return err
 				}
 			}
 			row++
 		} else if att, ok := attMap[dateStr]; ok {
-			values := buildAttendanceRow(att)
+			values := buildAttendanceRow(att, schedules[att.StaffID].For(dateStr), corrected.Has(att.StaffID, dateStr))
 			for colIdx, value := range values {
 				col, err := excelize.ColumnNumberToName(colIdx + 1)
 				if err != nil {
@@ -513,6 +546,27 @@ func (s *ReportService) StreamReportXLSX(
 	return nil
 }
 
+// correctedLabel flags rows whose punches were changed by an approved
+// attendance correction.
+func correctedLabel(corrected bool) string {
+	if corrected {
+		return "Yes"
+	}
+	return ""
+}
+
+// scheduleSummary describes the schedule of a staff in the report header. The
+// shift of each date is in the Schedule column.
+func scheduleSummary(schedule shift.Schedule) string {
+	if len(schedule.Template) == 0 {
+		return "Scheduled working time: " + schedule.Default.String()
+	}
+	if schedule.CycleWeeks > 1 {
+		return fmt.Sprintf("Scheduled working time: rotating every %d weeks", schedule.CycleWeeks)
+	}
+	return "Scheduled working time: weekly schedule"
+}
+
 func formatLocationAndUseragent(
 	location string,
 	browser, browserVersion, os, device sql.NullString,
@@ -2,6 +2,9 @@ package services
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"strings"
 
 	"cchoice/internal/database"
 	"cchoice/internal/database/queries"
@@ -9,6 +12,7 @@ import (
 	"cchoice/internal/enums"
 	"cchoice/internal/errs"
 	"cchoice/internal/logs"
+	"cchoice/internal/rbac"
 )
 
 type RoleService struct {
@@ -55,6 +59,7 @@ func (s *RoleService) AddRole(ctx context.Context, staffID string, role enums.St
 	_, err := s.dbRW.GetQueries().CreateStaffRole(ctx, queries.CreateStaffRoleParams{
 		StaffID: decodedID,
 		Role:    role.String(),
+		Access:  enums.PERMISSION_ACCESS_WRITE.String(),
 	})
 	return err
 }
@@ -72,6 +77,205 @@ func (s *RoleService) RemoveRole(ctx context.Context, staffID string, role enums
 	return err
 }
 
+// GetPermissionsByStaffID returns the effective permissions of a staff, from
+// their direct roles and every role group assigned to them.
+func (s *RoleService) GetPermissionsByStaffID(ctx context.Context, staffID string) (rbac.Set, error) {
+	rows, err := s.dbRO.GetQueries().GetStaffPermissionsByStaffID(ctx, s.encoder.Decode(staffID))
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(rbac.Set, len(rows))
+	for _, row := range rows {
+		set.Grant(rbac.Permission{
+			Role:   enums.ParseStaffRoleToEnum(row.Role),
+			Access: enums.ParsePermissionAccessToEnum(row.Access),
+		})
+	}
+	return set, nil
+}
+
+// GetEffectiveRolesByStaffID is GetPermissionsByStaffID without the access
+// levels, for pages that only decide which sections to show.
+func (s *RoleService) GetEffectiveRolesByStaffID(ctx context.Context, staffID string) ([]enums.StaffRole, error) {
+	set, err := s.GetPermissionsByStaffID(ctx, staffID)
+	if err != nil {
+		return nil, err
+	}
+	return set.Roles(), nil
+}
+
+func (s *RoleService) GetMatrix(ctx context.Context) (PermissionMatrix, error) {
+	q := s.dbRO.GetQueries()
+
+	groups, err := q.GetRoleGroups(ctx)
+	if err != nil {
+		return PermissionMatrix{}, errors.Join(errs.ErrRoleGroup, err)
+	}
+	groupPerms, err := q.GetRoleGroupPermissions(ctx)
+	if err != nil {
+		return PermissionMatrix{}, errors.Join(errs.ErrRoleGroup, err)
+	}
+	staffGroups, err := q.GetStaffRoleGroups(ctx)
+	if err != nil {
+		return PermissionMatrix{}, errors.Join(errs.ErrRoleGroup, err)
+	}
+	staffPerms, err := q.GetAllStaffPermissions(ctx)
+	if err != nil {
+		return PermissionMatrix{}, errors.Join(errs.ErrRoleGroup, err)
+	}
+
+	matrix := PermissionMatrix{
+		Groups:      make([]RoleGroup, 0, len(groups)),
+		StaffGroups: make(map[string][]string),
+		StaffPerms:  make(map[string]rbac.Set),
+	}
+
+	byID := make(map[int64]int, len(groups))
+	for i, group := range groups {
+		byID[group.ID] = i
+		matrix.Groups = append(matrix.Groups, RoleGroup{
+			ID:          s.encoder.Encode(group.ID),
+			Name:        group.Name,
+			Description: group.Description,
+			Permissions: make(rbac.Set),
+		})
+	}
+	for _, perm := range groupPerms {
+		i, ok := byID[perm.RoleGroupID]
+		if !ok {
+			continue
+		}
+		matrix.Groups[i].Permissions.Grant(rbac.Permission{
+			Role:   enums.ParseStaffRoleToEnum(perm.Role),
+			Access: enums.ParsePermissionAccessToEnum(perm.Access),
+		})
+	}
+	for _, sg := range staffGroups {
+		staffID := s.encoder.Encode(sg.StaffID)
+		matrix.StaffGroups[staffID] = append(matrix.StaffGroups[staffID], s.encoder.Encode(sg.RoleGroupID))
+	}
+	for _, perm := range staffPerms {
+		staffID := s.encoder.Encode(perm.StaffID)
+		set, ok := matrix.StaffPerms[staffID]
+		if !ok {
+			set = make(rbac.Set)
+			matrix.StaffPerms[staffID] = set
+		}
+		set.Grant(rbac.Permission{
+			Role:   enums.ParseStaffRoleToEnum(perm.Role),
+			Access: enums.ParsePermissionAccessToEnum(perm.Access),
+		})
+	}
+	return matrix, nil
+}
+
+func (s *RoleService) CreateGroup(ctx context.Context, name string, description string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errs.ErrRoleGroupNameRequired
+	}
+
+	id, err := s.dbRW.GetQueries().CreateRoleGroup(ctx, queries.CreateRoleGroupParams{
+		Name:        name,
+		Description: strings.TrimSpace(description),
+	})
+	if err != nil {
+		return "", errors.Join(errs.ErrRoleGroup, err)
+	}
+	return s.encoder.Encode(id), nil
+}
+
+func (s *RoleService) DeleteGroup(ctx context.Context, groupID string) error {
+	dbGroupID := s.encoder.Decode(groupID)
+	err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		if err := qtx.DeleteStaffRoleGroupsByGroupID(ctx, dbGroupID); err != nil {
+			return err
+		}
+		if err := qtx.DeleteRoleGroupPermissionsByGroupID(ctx, dbGroupID); err != nil {
+			return err
+		}
+		affected, err := qtx.DeleteRoleGroup(ctx, dbGroupID)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return errs.ErrRoleGroupNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, errs.ErrRoleGroupNotFound) {
+		return err
+	}
+	if err != nil {
+		return errors.Join(errs.ErrRoleGroup, err)
+	}
+	return nil
+}
+
+// SetGroupPermission sets the access of a group on a role. An undefined
+// access removes the role from the group.
+func (s *RoleService) SetGroupPermission(ctx context.Context, groupID string, role enums.StaffRole, access enums.PermissionAccess) error {
+	if !role.IsValid() {
+		return errs.ErrInvalidParams
+	}
+
+	dbGroupID := s.encoder.Decode(groupID)
+	if _, err := s.dbRO.GetQueries().GetRoleGroupByID(ctx, dbGroupID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.ErrRoleGroupNotFound
+		}
+		return errors.Join(errs.ErrRoleGroup, err)
+	}
+
+	if access == enums.PERMISSION_ACCESS_UNDEFINED {
+		if _, err := s.dbRW.GetQueries().DeleteRoleGroupPermission(ctx, queries.DeleteRoleGroupPermissionParams{
+			RoleGroupID: dbGroupID,
+			Role:        role.String(),
+		}); err != nil {
+			return errors.Join(errs.ErrRoleGroup, err)
+		}
+		return nil
+	}
+
+	if err := s.dbRW.GetQueries().UpsertRoleGroupPermission(ctx, queries.UpsertRoleGroupPermissionParams{
+		RoleGroupID: dbGroupID,
+		Role:        role.String(),
+		Access:      access.String(),
+	}); err != nil {
+		return errors.Join(errs.ErrRoleGroup, err)
+	}
+	return nil
+}
+
+func (s *RoleService) AssignGroup(ctx context.Context, staffID string, groupID string) error {
+	dbGroupID := s.encoder.Decode(groupID)
+	if _, err := s.dbRO.GetQueries().GetRoleGroupByID(ctx, dbGroupID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.ErrRoleGroupNotFound
+		}
+		return errors.Join(errs.ErrRoleGroup, err)
+	}
+
+	if err := s.dbRW.GetQueries().AssignStaffRoleGroup(ctx, queries.AssignStaffRoleGroupParams{
+		StaffID:     s.encoder.Decode(staffID),
+		RoleGroupID: dbGroupID,
+	}); err != nil {
+		return errors.Join(errs.ErrRoleGroup, err)
+	}
+	return nil
+}
+
+func (s *RoleService) UnassignGroup(ctx context.Context, staffID string, groupID string) error {
+	if _, err := s.dbRW.GetQueries().UnassignStaffRoleGroup(ctx, queries.UnassignStaffRoleGroupParams{
+		StaffID:     s.encoder.Decode(staffID),
+		RoleGroupID: s.encoder.Decode(groupID),
+	}); err != nil {
+		return errors.Join(errs.ErrRoleGroup, err)
+	}
+	return nil
+}
+
 func (s *RoleService) ID() string {
 	return "Role"
 }
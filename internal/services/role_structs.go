@@ -0,0 +1,20 @@
+package services
+
+import (
+	"cchoice/internal/rbac"
+)
+
+type RoleGroup struct {
+	ID          string
+	Name        string
+	Description string
+	Permissions rbac.Set
+}
+
+// PermissionMatrix is keyed by encoded staff ID. StaffPerms already merges
+// direct roles with the permissions of assigned groups.
+type PermissionMatrix struct {
+	Groups      []RoleGroup
+	StaffGroups map[string][]string
+	StaffPerms  map[string]rbac.Set
+}
@@ -0,0 +1,590 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+type SaleCampaignService struct {
+	encoder  encode.IEncode
+	dbRO     database.IService
+	dbRW     database.IService
+	wishlist *WishlistService
+	staffLog *StaffLogsService
+}
+
+func NewSaleCampaignService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	wishlist *WishlistService,
+	staffLog *StaffLogsService,
+) *SaleCampaignService {
+	if wishlist == nil {
+		panic("WishlistService is required")
+	}
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &SaleCampaignService{
+		encoder:  encoder,
+		dbRO:     dbRO,
+		dbRW:     dbRW,
+		wishlist: wishlist,
+		staffLog: staffLog,
+	}
+}
+
+func ValidateSaleCampaignInput(input SaleCampaignInput, now time.Time) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > constants.SaleCampaignMaxNameLen {
+		return errs.ErrSaleCampaignNameRequired
+	}
+	if input.Selector == enums.SALE_CAMPAIGN_SELECTOR_UNDEFINED || strings.TrimSpace(input.SelectorValue) == "" {
+		return errs.ErrSaleCampaignInvalidSelector
+	}
+	switch input.DiscountType {
+	case enums.DISCOUNT_TYPE_PERCENTAGE:
+		if input.DiscountValue <= 0 || input.DiscountValue > constants.SaleCampaignMaxPercentage {
+			return errs.ErrSaleCampaignInvalidDiscount
+		}
+	case enums.DISCOUNT_TYPE_FIXED:
+		if input.DiscountValue <= 0 {
+			return errs.ErrSaleCampaignInvalidDiscount
+		}
+	default:
+		return errs.ErrSaleCampaignInvalidDiscount
+	}
+	if !input.StartsAt.Before(input.EndsAt) {
+		return errs.ErrValidationStartEndDates
+	}
+	if !input.EndsAt.After(now) {
+		return errs.ErrSaleCampaignEndsInPast
+	}
+	return nil
+}
+
+// ComputeSalePrice applies a campaign discount to a unit price in cents. The
+// result never goes below zero.
+func ComputeSalePrice(unitPrice int64, discountType enums.DiscountType, discountValue int64) int64 {
+	var salePrice int64
+	switch discountType {
+	case enums.DISCOUNT_TYPE_PERCENTAGE:
+		salePrice = unitPrice - (unitPrice*discountValue)/100
+	case enums.DISCOUNT_TYPE_FIXED:
+		salePrice = unitPrice - discountValue
+	default:
+		return unitPrice
+	}
+	return max(salePrice, 0)
+}
+
+// ParseSerials splits the explicit product list of a campaign. Serials may be
+// separated by commas or new lines.
+func ParseSerials(raw string) []string {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r'
+	})
+	seen := make(map[string]struct{}, len(fields))
+	serials := make([]string, 0, len(fields))
+	for _, field := range fields {
+		serial := strings.TrimSpace(field)
+		if serial == "" {
+			continue
+		}
+		if _, ok := seen[serial]; ok {
+			continue
+		}
+		seen[serial] = struct{}{}
+		serials = append(serials, serial)
+	}
+	return serials
+}
+
+func discountLabel(discountType enums.DiscountType, discountValue int64) string {
+	if discountType == enums.DISCOUNT_TYPE_PERCENTAGE {
+		return fmt.Sprintf("%d%% off", discountValue)
+	}
+	return utils.NewMoney(discountValue, constants.PHP).Display() + " off"
+}
+
+func (s *SaleCampaignService) resolveProducts(
+	ctx context.Context,
+	selector enums.SaleCampaignSelector,
+	selectorValue string,
+) ([]saleCampaignProduct, error) {
+	var products []saleCampaignProduct
+
+	switch selector {
+	case enums.SALE_CAMPAIGN_SELECTOR_BRAND:
+		brandID := s.encoder.Decode(selectorValue)
+		if brandID == encode.INVALID {
+			return nil, errs.ErrDecode
+		}
+		rows, err := s.dbRO.GetQueries().GetSaleCampaignCandidatesByBrandID(ctx, brandID)
+		if err != nil {
+			return nil, errors.Join(errs.ErrSaleCampaign, err)
+		}
+		for _, row := range rows {
+			products = append(products, saleCampaignProduct(row))
+		}
+
+	case enums.SALE_CAMPAIGN_SELECTOR_CATEGORY:
+		rows, err := s.dbRO.GetQueries().GetSaleCampaignCandidatesByCategory(ctx, strings.TrimSpace(selectorValue))
+		if err != nil {
+			return nil, errors.Join(errs.ErrSaleCampaign, err)
+		}
+		for _, row := range rows {
+			products = append(products, saleCampaignProduct(row))
+		}
+
+	case enums.SALE_CAMPAIGN_SELECTOR_PRODUCTS:
+		serials := ParseSerials(selectorValue)
+		if len(serials) == 0 {
+			return nil, errs.ErrSaleCampaignInvalidSelector
+		}
+		rows, err := s.dbRO.GetQueries().GetSaleCampaignCandidatesBySerials(ctx, serials)
+		if err != nil {
+			return nil, errors.Join(errs.ErrSaleCampaign, err)
+		}
+		for _, row := range rows {
+			products = append(products, saleCampaignProduct(row))
+		}
+
+	default:
+		return nil, errs.ErrSaleCampaignInvalidSelector
+	}
+
+	return products, nil
+}
+
+func (s *SaleCampaignService) toPreviewItems(
+	products []saleCampaignProduct,
+	discountType enums.DiscountType,
+	discountValue int64,
+) []SaleCampaignPreviewItem {
+	items := make([]SaleCampaignPreviewItem, 0, len(products))
+	for _, p := range products {
+		salePrice := ComputeSalePrice(p.UnitPriceWithVat, discountType, discountValue)
+		items = append(items, SaleCampaignPreviewItem{
+			ProductID:    s.encoder.Encode(p.ID),
+			Serial:       p.Serial,
+			Name:         p.Name,
+			UnitPrice:    utils.NewMoney(p.UnitPriceWithVat, p.UnitPriceWithVatCurrency).Display(),
+			SalePrice:    utils.NewMoney(salePrice, p.UnitPriceWithVatCurrency).Display(),
+			HasOtherSale: p.HasOtherSale,
+		})
+	}
+	return items
+}
+
+// Preview lists the products matched by the input along with their campaign
+// prices without saving anything.
+func (s *SaleCampaignService) Preview(ctx context.Context, input SaleCampaignInput) ([]SaleCampaignPreviewItem, error) {
+	if err := ValidateSaleCampaignInput(input, time.Now()); err != nil {
+		return nil, err
+	}
+
+	products, err := s.resolveProducts(ctx, input.Selector, input.SelectorValue)
+	if err != nil {
+		return nil, err
+	}
+	return s.toPreviewItems(products, input.DiscountType, input.DiscountValue), nil
+}
+
+func (s *SaleCampaignService) Create(ctx context.Context, staffID string, input SaleCampaignInput) (string, error) {
+	const logtag = "[SaleCampaignService] Create"
+
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionCreate,
+			constants.ModuleSaleCampaigns,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	if err := ValidateSaleCampaignInput(input, time.Now()); err != nil {
+		result = err.Error()
+		return "", err
+	}
+
+	products, err := s.resolveProducts(ctx, input.Selector, input.SelectorValue)
+	if err != nil {
+		result = err.Error()
+		return "", err
+	}
+	if len(products) == 0 {
+		result = errs.ErrSaleCampaignNoProducts.Error()
+		return "", errs.ErrSaleCampaignNoProducts
+	}
+
+	selectorValue := strings.TrimSpace(input.SelectorValue)
+	if input.Selector == enums.SALE_CAMPAIGN_SELECTOR_BRAND {
+		brand, err := s.dbRO.GetQueries().GetBrandsByID(ctx, s.encoder.Decode(selectorValue))
+		if err != nil {
+			result = err.Error()
+			return "", errors.Join(errs.ErrSaleCampaign, err)
+		}
+		selectorValue = brand.Name
+	} else if input.Selector == enums.SALE_CAMPAIGN_SELECTOR_PRODUCTS {
+		selectorValue = strings.Join(ParseSerials(selectorValue), ", ")
+	}
+
+	var campaignID int64
+	err = s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		var err error
+		campaignID, err = qtx.CreateSaleCampaign(ctx, queries.CreateSaleCampaignParams{
+			Name:          strings.TrimSpace(input.Name),
+			Selector:      input.Selector.String(),
+			SelectorValue: selectorValue,
+			DiscountType:  input.DiscountType.DBValue(),
+			DiscountValue: input.DiscountValue,
+			StartsAt:      input.StartsAt.UTC(),
+			EndsAt:        input.EndsAt.UTC(),
+		})
+		if err != nil {
+			return err
+		}
+		for _, p := range products {
+			if err := qtx.AddSaleCampaignProduct(ctx, queries.AddSaleCampaignProductParams{
+				SaleCampaignID: campaignID,
+				ProductID:      p.ID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		result = err.Error()
+		return "", errors.Join(errs.ErrSaleCampaign, err)
+	}
+
+	encodedID := s.encoder.Encode(campaignID)
+	logs.LogCtx(ctx).Info(
+		logtag,
+		zap.String("sale_campaign_id", encodedID),
+		zap.Int("products", len(products)),
+	)
+
+	if !input.StartsAt.After(time.Now()) {
+		if err := s.activate(ctx, campaignID); err != nil {
+			logs.LogCtx(ctx).Warn(logtag, zap.String("sale_campaign_id", encodedID), zap.Error(err))
+		}
+	}
+
+	return encodedID, nil
+}
+
+func (s *SaleCampaignService) mapRowToSaleCampaign(row queries.TblSaleCampaign, productCount int64) SaleCampaign {
+	discountType := enums.ParseDiscountTypeToEnum(row.DiscountType)
+	return SaleCampaign{
+		ID:            s.encoder.Encode(row.ID),
+		Name:          row.Name,
+		Selector:      enums.ParseSaleCampaignSelectorToEnum(row.Selector),
+		SelectorValue: row.SelectorValue,
+		DiscountType:  discountType,
+		DiscountValue: row.DiscountValue,
+		DiscountLabel: discountLabel(discountType, row.DiscountValue),
+		StartsAt:      utils.ConvertToPH(row.StartsAt.UTC().Format(constants.DateTimeLayoutISO)),
+		EndsAt:        utils.ConvertToPH(row.EndsAt.UTC().Format(constants.DateTimeLayoutISO)),
+		Status:        enums.ParseSaleCampaignStatusToEnum(row.Status),
+		ProductCount:  productCount,
+	}
+}
+
+func (s *SaleCampaignService) GetAll(ctx context.Context) ([]SaleCampaign, error) {
+	rows, err := s.dbRO.GetQueries().GetSaleCampaigns(ctx)
+	if err != nil {
+		return nil, errors.Join(errs.ErrSaleCampaign, err)
+	}
+
+	campaigns := make([]SaleCampaign, 0, len(rows))
+	for _, row := range rows {
+		campaigns = append(campaigns, s.mapRowToSaleCampaign(queries.TblSaleCampaign{
+			ID:            row.ID,
+			Name:          row.Name,
+			Selector:      row.Selector,
+			SelectorValue: row.SelectorValue,
+			DiscountType:  row.DiscountType,
+			DiscountValue: row.DiscountValue,
+			StartsAt:      row.StartsAt,
+			EndsAt:        row.EndsAt,
+			Status:        row.Status,
+			CreatedAt:     row.CreatedAt,
+			UpdatedAt:     row.UpdatedAt,
+		}, row.ProductCount))
+	}
+	return campaigns, nil
+}
+
+func (s *SaleCampaignService) GetByID(ctx context.Context, campaignID string) (*SaleCampaign, []SaleCampaignPreviewItem, error) {
+	decodedID := s.encoder.Decode(campaignID)
+	if decodedID == encode.INVALID {
+		return nil, nil, errs.ErrDecode
+	}
+
+	row, err := s.dbRO.GetQueries().GetSaleCampaignByID(ctx, decodedID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, errs.ErrSaleCampaignNotFound
+		}
+		return nil, nil, errors.Join(errs.ErrSaleCampaign, err)
+	}
+
+	productRows, err := s.dbRO.GetQueries().GetSaleCampaignProducts(ctx, decodedID)
+	if err != nil {
+		return nil, nil, errors.Join(errs.ErrSaleCampaign, err)
+	}
+	products := make([]saleCampaignProduct, 0, len(productRows))
+	for _, p := range productRows {
+		products = append(products, saleCampaignProduct(p))
+	}
+
+	campaign := s.mapRowToSaleCampaign(row, int64(len(products)))
+	return &campaign, s.toPreviewItems(products, campaign.DiscountType, campaign.DiscountValue), nil
+}
+
+func (s *SaleCampaignService) GetReport(ctx context.Context, campaignID string) (SaleCampaignReport, error) {
+	decodedID := s.encoder.Decode(campaignID)
+	if decodedID == encode.INVALID {
+		return SaleCampaignReport{}, errs.ErrDecode
+	}
+
+	rows, err := s.dbRO.GetQueries().GetSaleCampaignReport(ctx, decodedID)
+	if err != nil {
+		return SaleCampaignReport{}, errors.Join(errs.ErrSaleCampaign, err)
+	}
+
+	var totalRevenue int64
+	report := SaleCampaignReport{Items: make([]SaleCampaignReportItem, 0, len(rows))}
+	for _, row := range rows {
+		report.Items = append(report.Items, SaleCampaignReportItem{
+			ProductID: s.encoder.Encode(row.ID),
+			Serial:    row.Serial,
+			Name:      row.Name,
+			UnitsSold: row.UnitsSold,
+			Revenue:   utils.NewMoney(row.Revenue, constants.PHP).Display(),
+		})
+		report.TotalUnits += row.UnitsSold
+		totalRevenue += row.Revenue
+	}
+	report.TotalRevenue = utils.NewMoney(totalRevenue, constants.PHP).Display()
+	return report, nil
+}
+
+func (s *SaleCampaignService) Cancel(ctx context.Context, staffID string, campaignID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionCancel,
+			constants.ModuleSaleCampaigns,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	decodedID := s.encoder.Decode(campaignID)
+	if decodedID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	row, err := s.dbRO.GetQueries().GetSaleCampaignByID(ctx, decodedID)
+	if err != nil {
+		result = err.Error()
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.ErrSaleCampaignNotFound
+		}
+		return errors.Join(errs.ErrSaleCampaign, err)
+	}
+
+	switch enums.ParseSaleCampaignStatusToEnum(row.Status) {
+	case enums.SALE_CAMPAIGN_STATUS_SCHEDULED, enums.SALE_CAMPAIGN_STATUS_ACTIVE:
+	default:
+		result = errs.ErrSaleCampaignNotCancellable.Error()
+		return errs.ErrSaleCampaignNotCancellable
+	}
+
+	if err := s.deactivate(ctx, decodedID, enums.SALE_CAMPAIGN_STATUS_CANCELLED); err != nil {
+		result = err.Error()
+		return err
+	}
+	return nil
+}
+
+// activate creates the product sales of a campaign. Products that already have
+// an active sale of their own are left untouched so that a campaign never
+// silently replaces a manually configured price.
+func (s *SaleCampaignService) activate(ctx context.Context, campaignID int64) error {
+	const logtag = "[SaleCampaignService] activate"
+
+	campaign, err := s.dbRO.GetQueries().GetSaleCampaignByID(ctx, campaignID)
+	if err != nil {
+		return errors.Join(errs.ErrSaleCampaign, err)
+	}
+	discountType := enums.ParseDiscountTypeToEnum(campaign.DiscountType)
+
+	rows, err := s.dbRO.GetQueries().GetSaleCampaignProducts(ctx, campaignID)
+	if err != nil {
+		return errors.Join(errs.ErrSaleCampaign, err)
+	}
+
+	activated := 0
+	skipped := 0
+	err = s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		activated = 0
+		skipped = 0
+		for _, row := range rows {
+			if row.HasOtherSale {
+				skipped++
+				continue
+			}
+
+			salePriceWithVat := ComputeSalePrice(row.UnitPriceWithVat, discountType, campaign.DiscountValue)
+			salePriceWithoutVat := row.UnitPriceWithoutVat
+			if row.UnitPriceWithVat > 0 {
+				salePriceWithoutVat = row.UnitPriceWithoutVat * salePriceWithVat / row.UnitPriceWithVat
+			}
+
+			if err := qtx.CreateSaleCampaignProductSale(ctx, queries.CreateSaleCampaignProductSaleParams{
+				ProductID:                   row.ID,
+				SalePriceWithoutVat:         salePriceWithoutVat,
+				SalePriceWithVat:            salePriceWithVat,
+				SalePriceWithoutVatCurrency: row.UnitPriceWithoutVatCurrency,
+				SalePriceWithVatCurrency:    row.UnitPriceWithVatCurrency,
+				DiscountType:                campaign.DiscountType,
+				DiscountValue:               campaign.DiscountValue,
+				StartsAt:                    campaign.StartsAt,
+				EndsAt:                      campaign.EndsAt,
+				SaleCampaignID:              sql.NullInt64{Int64: campaignID, Valid: true},
+			}); err != nil {
+				return err
+			}
+			activated++
+		}
+
+		return qtx.UpdateSaleCampaignStatus(ctx, queries.UpdateSaleCampaignStatusParams{
+			Status: enums.SALE_CAMPAIGN_STATUS_ACTIVE.String(),
+			ID:     campaignID,
+		})
+	})
+	if err != nil {
+		return errors.Join(errs.ErrSaleCampaign, err)
+	}
+
+	logs.LogCtx(ctx).Info(
+		logtag,
+		zap.Int64("sale_campaign_id", campaignID),
+		zap.Int("activated", activated),
+		zap.Int("skipped", skipped),
+	)
+
+	if err := s.wishlist.NotifyStartedSales(ctx); err != nil {
+		logs.LogCtx(ctx).Warn(logtag, zap.Int64("sale_campaign_id", campaignID), zap.Error(err))
+	}
+	return nil
+}
+
+func (s *SaleCampaignService) deactivate(ctx context.Context, campaignID int64, status enums.SaleCampaignStatus) error {
+	const logtag = "[SaleCampaignService] deactivate"
+
+	if err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		if err := qtx.DeactivateProductSalesBySaleCampaignID(ctx, sql.NullInt64{Int64: campaignID, Valid: true}); err != nil {
+			return err
+		}
+		return qtx.UpdateSaleCampaignStatus(ctx, queries.UpdateSaleCampaignStatusParams{
+			Status: status.String(),
+			ID:     campaignID,
+		})
+	}); err != nil {
+		return errors.Join(errs.ErrSaleCampaign, err)
+	}
+
+	logs.LogCtx(ctx).Info(logtag, zap.Int64("sale_campaign_id", campaignID), zap.Stringer("status", status))
+	return nil
+}
+
+// SyncSchedules activates campaigns whose start time has passed, ends those
+// whose end time has passed and announces product sales that have started.
+func (s *SaleCampaignService) SyncSchedules(ctx context.Context, now time.Time) error {
+	const logtag = "[SaleCampaignService] SyncSchedules"
+
+	toEnd, err := s.dbRO.GetQueries().GetSaleCampaignsDueForEnding(ctx, now.UTC())
+	if err != nil {
+		return errors.Join(errs.ErrSaleCampaign, err)
+	}
+	for _, id := range toEnd {
+		if err := s.deactivate(ctx, id, enums.SALE_CAMPAIGN_STATUS_ENDED); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Int64("sale_campaign_id", id), zap.Error(err))
+		}
+	}
+
+	toActivate, err := s.dbRO.GetQueries().GetSaleCampaignsDueForActivation(ctx, now.UTC())
+	if err != nil {
+		return errors.Join(errs.ErrSaleCampaign, err)
+	}
+	for _, id := range toActivate {
+		if err := s.activate(ctx, id); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Int64("sale_campaign_id", id), zap.Error(err))
+		}
+	}
+
+	// Product sales created ahead of their start are announced from here.
+	return s.wishlist.NotifyStartedSales(ctx)
+}
+
+func (s *SaleCampaignService) RunScheduler(ctx context.Context) {
+	const logtag = "[SaleCampaignService] RunScheduler"
+	logs.Log().Info("[SaleCampaignService] Starting sale campaign scheduler")
+
+	ticker := time.NewTicker(constants.SaleCampaignSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SyncSchedules(ctx, time.Now()); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *SaleCampaignService) ID() string {
+	return "SaleCampaign"
+}
+
+func (s *SaleCampaignService) Log() {
+	logs.Log().Info("[SaleCampaignService] Loaded")
+}
+
+var _ IService = (*SaleCampaignService)(nil)
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"time"
+
+	"cchoice/internal/enums"
+)
+
+type SaleCampaignInput struct {
+	Name          string
+	Selector      enums.SaleCampaignSelector
+	SelectorValue string
+	DiscountType  enums.DiscountType
+	DiscountValue int64 // cents if fixed, percent if percentage
+	StartsAt      time.Time
+	EndsAt        time.Time
+}
+
+type SaleCampaign struct {
+	ID            string
+	Name          string
+	Selector      enums.SaleCampaignSelector
+	SelectorValue string
+	DiscountType  enums.DiscountType
+	DiscountValue int64
+	DiscountLabel string
+	StartsAt      string
+	EndsAt        string
+	Status        enums.SaleCampaignStatus
+	ProductCount  int64
+}
+
+type SaleCampaignPreviewItem struct {
+	ProductID    string
+	Serial       string
+	Name         string
+	UnitPrice    string
+	SalePrice    string
+	HasOtherSale bool
+}
+
+type SaleCampaignReportItem struct {
+	ProductID string
+	Serial    string
+	Name      string
+	UnitsSold int64
+	Revenue   string
+}
+
+type SaleCampaignReport struct {
+	Items        []SaleCampaignReportItem
+	TotalUnits   int64
+	TotalRevenue string
+}
+
+// saleCampaignProduct is the common shape of the candidate queries so that
+// preview, creation and activation share the same pricing logic.
+type saleCampaignProduct struct {
+	ID                          int64
+	Serial                      string
+	Name                        string
+	UnitPriceWithoutVat         int64
+	UnitPriceWithVat            int64
+	UnitPriceWithoutVatCurrency string
+	UnitPriceWithVatCurrency    string
+	HasOtherSale                bool
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeSalePrice(t *testing.T) {
+	tests := []struct {
+		name          string
+		unitPrice     int64
+		discountType  enums.DiscountType
+		discountValue int64
+		want          int64
+	}{
+		{"percentage", 100000, enums.DISCOUNT_TYPE_PERCENTAGE, 15, 85000},
+		{"percentage rounds down discount", 999, enums.DISCOUNT_TYPE_PERCENTAGE, 10, 900},
+		{"fixed", 100000, enums.DISCOUNT_TYPE_FIXED, 25000, 75000},
+		{"fixed never negative", 10000, enums.DISCOUNT_TYPE_FIXED, 25000, 0},
+		{"undefined keeps price", 10000, enums.DISCOUNT_TYPE_UNDEFINED, 50, 10000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ComputeSalePrice(tt.unitPrice, tt.discountType, tt.discountValue))
+		})
+	}
+}
+
+func TestValidateSaleCampaignInput(t *testing.T) {
+	start := time.Date(2026, 11, 11, 0, 0, 0, 0, time.UTC)
+	now := start.Add(-time.Hour)
+	valid := SaleCampaignInput{
+		Name:          "11.11",
+		Selector:      enums.SALE_CAMPAIGN_SELECTOR_CATEGORY,
+		SelectorValue: "Power Tools",
+		DiscountType:  enums.DISCOUNT_TYPE_PERCENTAGE,
+		DiscountValue: 11,
+		StartsAt:      start,
+		EndsAt:        start.Add(24 * time.Hour),
+	}
+	assert.NoError(t, ValidateSaleCampaignInput(valid, now))
+
+	noName := valid
+	noName.Name = "  "
+	assert.ErrorIs(t, ValidateSaleCampaignInput(noName, now), errs.ErrSaleCampaignNameRequired)
+
+	noSelection := valid
+	noSelection.SelectorValue = ""
+	assert.ErrorIs(t, ValidateSaleCampaignInput(noSelection, now), errs.ErrSaleCampaignInvalidSelector)
+
+	tooMuch := valid
+	tooMuch.DiscountValue = 100
+	assert.ErrorIs(t, ValidateSaleCampaignInput(tooMuch, now), errs.ErrSaleCampaignInvalidDiscount)
+
+	badDates := valid
+	badDates.EndsAt = badDates.StartsAt
+	assert.ErrorIs(t, ValidateSaleCampaignInput(badDates, now), errs.ErrValidationStartEndDates)
+
+	assert.ErrorIs(t, ValidateSaleCampaignInput(valid, valid.EndsAt), errs.ErrSaleCampaignEndsInPast)
+}
+
+func TestParseSerials(t *testing.T) {
+	assert.Equal(t, []string{"A-1", "B-2", "C-3"}, ParseSerials(" A-1, B-2\nC-3\r\nA-1,,"))
+	assert.Empty(t, ParseSerials(" , \n"))
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cchoice/cmd/web/models"
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+	"cchoice/internal/metrics"
+	"cchoice/internal/utils"
+)
+
+type SearchMerchandisingService struct {
+	encoder  encode.IEncode
+	dbRO     database.IService
+	dbRW     database.IService
+	staffLog *StaffLogsService
+}
+
+func NewSearchMerchandisingService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	staffLog *StaffLogsService,
+) *SearchMerchandisingService {
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &SearchMerchandisingService{
+		encoder:  encoder,
+		dbRO:     dbRO,
+		dbRW:     dbRW,
+		staffLog: staffLog,
+	}
+}
+
+// NormalizeSearchTerm is how search terms are compared against the rules and
+// the stored search events.
+func NormalizeSearchTerm(q string) string {
+	return strings.ToLower(strings.TrimSpace(q))
+}
+
+func (s *SearchMerchandisingService) GetInsights(ctx context.Context, start, end time.Time) (*SearchInsights, error) {
+	startDate := start.Format(constants.DateLayoutISO)
+	endDate := end.Format(constants.DateLayoutISO)
+	q := s.dbRO.GetQueries()
+
+	zeroResults, err := q.GetZeroResultSearches(ctx, queries.GetZeroResultSearchesParams{
+		Event:     metrics.EventSearchResults,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Limit:     constants.SearchInsightsLimit,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrSearchRule, err)
+	}
+	lowClicks, err := q.GetLowClickSearches(ctx, queries.GetLowClickSearchesParams{
+		SearchEvent: metrics.EventSearchResults,
+		ClickEvent:  metrics.EventSearchResultClick,
+		MinSearches: constants.SearchLowClickMinSearches,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Limit:       constants.SearchInsightsLimit,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrSearchRule, err)
+	}
+
+	insights := &SearchInsights{
+		StartDate:   startDate,
+		EndDate:     endDate,
+		ZeroResults: make([]AnalyticsSearchTerm, 0, len(zeroResults)),
+		LowClicks:   make([]SearchLowClickTerm, 0, len(lowClicks)),
+	}
+	for _, row := range zeroResults {
+		insights.ZeroResults = append(insights.ZeroResults, AnalyticsSearchTerm{
+			Term:           row.Term,
+			Searches:       row.Searches,
+			LastSearchedOn: row.LastSearchedOn,
+		})
+	}
+	for _, row := range lowClicks {
+		insights.LowClicks = append(insights.LowClicks, SearchLowClickTerm{
+			Term:      row.Term,
+			Searches:  row.Searches,
+			Clicks:    row.Clicks,
+			ClickRate: formatRate(row.Clicks, row.Searches),
+		})
+	}
+	return insights, nil
+}
+
+func (s *SearchMerchandisingService) GetRules(ctx context.Context) ([]SearchRule, error) {
+	rows, err := s.dbRO.GetQueries().GetSearchRules(ctx)
+	if err != nil {
+		return nil, errors.Join(errs.ErrSearchRule, err)
+	}
+
+	result := make([]SearchRule, 0, len(rows))
+	for _, row := range rows {
+		kind := enums.ParseSearchRuleKindToEnum(row.Kind)
+		rule := SearchRule{
+			ID:         s.encoder.Encode(row.ID),
+			SearchTerm: row.SearchTerm,
+			Kind:       kind,
+			Position:   row.Position,
+			CreatedBy:  row.CreatedByName,
+			CreatedAt:  row.CreatedAt.Format(constants.DateTimeLayoutISO),
+		}
+		switch kind {
+		case enums.SEARCH_RULE_KIND_PIN_PRODUCT:
+			rule.Target = row.ProductLabel
+		case enums.SEARCH_RULE_KIND_REDIRECT_CATEGORY:
+			rule.Target = searchRuleCategoryLabel(row.Category, row.Subcategory)
+		case enums.SEARCH_RULE_KIND_BOOST_BRAND:
+			rule.Target = row.BrandName
+		}
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+func (s *SearchMerchandisingService) GetCategoryOptions(ctx context.Context) ([]models.AdminSearchRuleOption, error) {
+	rows, err := s.dbRO.GetQueries().GetSearchRuleCategoryOptions(ctx)
+	if err != nil {
+		return nil, errors.Join(errs.ErrSearchRule, err)
+	}
+
+	result := make([]models.AdminSearchRuleOption, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, models.AdminSearchRuleOption{
+			Value: s.encoder.Encode(row.ID),
+			Label: searchRuleCategoryLabel(row.Category, row.Subcategory),
+		})
+	}
+	return result, nil
+}
+
+func (s *SearchMerchandisingService) CreateRule(ctx context.Context, staffID string, params CreateSearchRuleParams) (string, error) {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionCreate,
+			constants.ModuleSearchRules,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	searchTerm := NormalizeSearchTerm(params.SearchTerm)
+	if len(searchTerm) < constants.MinSearchQueryLength || params.Kind == enums.SEARCH_RULE_KIND_UNDEFINED {
+		result = errs.ErrValidation.Error()
+		return "", errs.ErrValidation
+	}
+	if params.Position < 0 || params.Position > constants.SearchRuleMaxPosition {
+		result = errs.ErrValidation.Error()
+		return "", errs.ErrValidation
+	}
+
+	createdBy := s.encoder.Decode(staffID)
+	if createdBy == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return "", errs.ErrDecode
+	}
+
+	createParams := queries.CreateSearchRuleParams{
+		SearchTerm: searchTerm,
+		Kind:       params.Kind.String(),
+		Position:   params.Position,
+		CreatedBy:  createdBy,
+	}
+	switch params.Kind {
+	case enums.SEARCH_RULE_KIND_PIN_PRODUCT:
+		productID, err := s.dbRO.GetQueries().GetProductIDBySerial(ctx, strings.TrimSpace(params.ProductSerial))
+		if err != nil {
+			result = errs.ErrSearchRuleTarget.Error()
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", errs.ErrSearchRuleTarget
+			}
+			return "", errors.Join(errs.ErrSearchRule, err)
+		}
+		createParams.ProductID = sql.NullInt64{Int64: productID, Valid: true}
+	case enums.SEARCH_RULE_KIND_REDIRECT_CATEGORY:
+		categoryID := s.encoder.Decode(params.CategoryID)
+		if categoryID == encode.INVALID {
+			result = errs.ErrSearchRuleTarget.Error()
+			return "", errs.ErrSearchRuleTarget
+		}
+		createParams.CategoryID = sql.NullInt64{Int64: categoryID, Valid: true}
+	case enums.SEARCH_RULE_KIND_BOOST_BRAND:
+		brandID := s.encoder.Decode(params.BrandID)
+		if brandID == encode.INVALID {
+			result = errs.ErrSearchRuleTarget.Error()
+			return "", errs.ErrSearchRuleTarget
+		}
+		createParams.BrandID = sql.NullInt64{Int64: brandID, Valid: true}
+	}
+
+	id, err := s.dbRW.GetQueries().CreateSearchRule(ctx, createParams)
+	if err != nil {
+		result = err.Error()
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return "", errs.ErrSearchRuleExists
+		}
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return "", errs.ErrSearchRuleTarget
+		}
+		return "", errors.Join(errs.ErrSearchRule, err)
+	}
+
+	ruleID := s.encoder.Encode(id)
+	result = fmt.Sprintf("success. ID '%s'. %s for '%s'", ruleID, params.Kind.String(), searchTerm)
+	return ruleID, nil
+}
+
+func (s *SearchMerchandisingService) DeleteRule(ctx context.Context, staffID string, ruleID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			staffID,
+			constants.ActionDelete,
+			constants.ModuleSearchRules,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	id := s.encoder.Decode(ruleID)
+	if id == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	rule, err := s.dbRO.GetQueries().GetSearchRuleByID(ctx, id)
+	if err != nil {
+		result = err.Error()
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.ErrSearchRuleNotFound
+		}
+		return errors.Join(errs.ErrSearchRule, err)
+	}
+
+	affected, err := s.dbRW.GetQueries().DeleteSearchRule(ctx, id)
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrSearchRule, err)
+	}
+	if affected == 0 {
+		result = errs.ErrSearchRuleNotFound.Error()
+		return errs.ErrSearchRuleNotFound
+	}
+
+	result = fmt.Sprintf("success. ID '%s'. %s for '%s'", ruleID, rule.Kind, rule.SearchTerm)
+	return nil
+}
+
+// GetRedirectPath returns the category page a search should be sent to, or an
+// empty string when the search has no redirect rule.
+func (s *SearchMerchandisingService) GetRedirectPath(ctx context.Context, q string) (string, error) {
+	row, err := s.dbRO.GetQueries().GetSearchRedirectCategory(ctx, NormalizeSearchTerm(q))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", errors.Join(errs.ErrSearchRule, err)
+	}
+	if row.Subcategory == "" {
+		return utils.URLf("/categories/%s", row.Category), nil
+	}
+	return utils.URLf("/categories/%s/%s", row.Category, row.Subcategory), nil
+}
+
+func searchRuleCategoryLabel(category, subcategory string) string {
+	if subcategory == "" || subcategory == category {
+		return utils.SlugToTile(category)
+	}
+	return utils.SlugToTile(category) + " / " + utils.SlugToTile(subcategory)
+}
+
+func (s *SearchMerchandisingService) ID() string {
+	return "SearchMerchandising"
+}
+
+func (s *SearchMerchandisingService) Log() {
+	logs.Log().Info("[SearchMerchandisingService] Loaded")
+}
+
+var _ IService = (*SearchMerchandisingService)(nil)
@@ -0,0 +1,37 @@
+package services
+
+import "cchoice/internal/enums"
+
+type SearchLowClickTerm struct {
+	Term      string
+	Searches  int64
+	Clicks    int64
+	ClickRate string
+}
+
+type SearchInsights struct {
+	StartDate   string
+	EndDate     string
+	ZeroResults []AnalyticsSearchTerm
+	LowClicks   []SearchLowClickTerm
+}
+
+type SearchRule struct {
+	ID         string
+	SearchTerm string
+	Kind       enums.SearchRuleKind
+	// Target is the pinned product, redirect category or boosted brand.
+	Target    string
+	Position  int64
+	CreatedBy string
+	CreatedAt string
+}
+
+type CreateSearchRuleParams struct {
+	SearchTerm    string
+	Kind          enums.SearchRuleKind
+	ProductSerial string
+	CategoryID    string
+	BrandID       string
+	Position      int64
+}
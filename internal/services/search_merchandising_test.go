@@ -0,0 +1,18 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSearchTerm(t *testing.T) {
+	assert.Equal(t, "impact drill", NormalizeSearchTerm("  Impact Drill "))
+	assert.Equal(t, "", NormalizeSearchTerm("   "))
+}
+
+func TestSearchRuleCategoryLabel(t *testing.T) {
+	assert.Equal(t, "Power Tools", searchRuleCategoryLabel("power-tools", ""))
+	assert.Equal(t, "Power Tools", searchRuleCategoryLabel("power-tools", "power-tools"))
+	assert.Equal(t, "Power Tools / Impact Drills", searchRuleCategoryLabel("power-tools", "impact-drills"))
+}
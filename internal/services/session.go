@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+// SessionService is the registry of signed in browsers for staff and
+// customers. The scs session only carries a random key; everything that can
+// be listed or revoked lives in tbl_sessions.
+type SessionService struct {
+	encoder  encode.IEncode
+	dbRO     database.IService
+	dbRW     database.IService
+	staffLog *StaffLogsService
+}
+
+func NewSessionService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	staffLog *StaffLogsService,
+) *SessionService {
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &SessionService{
+		encoder:  encoder,
+		dbRO:     dbRO,
+		dbRW:     dbRW,
+		staffLog: staffLog,
+	}
+}
+
+// Register records a new login and returns the key to keep in the session.
+func (s *SessionService) Register(ctx context.Context, userType enums.UserType, userID string, useragentID sql.NullInt64, ip string) (string, error) {
+	if !userType.IsValid() {
+		return "", errs.ErrEnumInvalid
+	}
+	dbUserID := s.encoder.Decode(userID)
+	if dbUserID == encode.INVALID {
+		return "", errs.ErrDecode
+	}
+
+	key, err := generateResetToken()
+	if err != nil {
+		return "", errors.Join(errs.ErrSession, err)
+	}
+	if _, err := s.dbRW.GetQueries().CreateSession(ctx, queries.CreateSessionParams{
+		UserType:    userType.String(),
+		UserID:      dbUserID,
+		KeyHash:     hashToken(key),
+		UseragentID: useragentID,
+		IpAddress:   ip,
+	}); err != nil {
+		return "", errors.Join(errs.ErrSession, err)
+	}
+	return key, nil
+}
+
+// Check fails with ErrSessionRevoked when the key is unknown, revoked or
+// belongs to someone else, and otherwise refreshes last seen.
+func (s *SessionService) Check(ctx context.Context, userType enums.UserType, userID string, key string, ip string) error {
+	const logtag = "[SessionService Check]"
+	if key == "" {
+		return errs.ErrSessionRevoked
+	}
+
+	session, err := s.dbRO.GetQueries().GetSessionByKeyHash(ctx, hashToken(key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.ErrSessionRevoked
+		}
+		return errors.Join(errs.ErrSession, err)
+	}
+	if session.RevokedAt.Valid ||
+		session.UserType != userType.String() ||
+		session.UserID != s.encoder.Decode(userID) {
+		return errs.ErrSessionRevoked
+	}
+
+	if err := s.dbRW.GetQueries().TouchSession(ctx, queries.TouchSessionParams{
+		IpAddress: ip,
+		ID:        session.ID,
+	}); err != nil {
+		logs.LogCtx(ctx).Warn(logtag, zap.Int64("session id", session.ID), zap.Error(err))
+	}
+	return nil
+}
+
+func (s *SessionService) ListByUser(ctx context.Context, userType enums.UserType, userID string, currentKey string) ([]SessionItem, error) {
+	rows, err := s.dbRO.GetQueries().GetActiveSessionsByUser(ctx, queries.GetActiveSessionsByUserParams{
+		UserType: userType.String(),
+		UserID:   s.encoder.Decode(userID),
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrSession, err)
+	}
+
+	currentHash := hashToken(currentKey)
+	res := make([]SessionItem, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, SessionItem{
+			ID:         s.encoder.Encode(row.ID),
+			UserType:   userType,
+			UserID:     userID,
+			IPAddress:  row.IpAddress,
+			Device:     sessionDevice(row.Browser, row.Os, row.Device),
+			CreatedAt:  utils.ConvertToPH(row.CreatedAt.UTC().Format(constants.DateTimeLayoutISO)),
+			LastSeenAt: utils.ConvertToPH(row.LastSeenAt.UTC().Format(constants.DateTimeLayoutISO)),
+			Current:    currentKey != "" && row.KeyHash == currentHash,
+		})
+	}
+	return res, nil
+}
+
+func (s *SessionService) ListAll(ctx context.Context, limit int64) ([]SessionItem, error) {
+	rows, err := s.dbRO.GetQueries().GetAllActiveSessions(ctx, limit)
+	if err != nil {
+		return nil, errors.Join(errs.ErrSession, err)
+	}
+
+	res := make([]SessionItem, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, SessionItem{
+			ID:         s.encoder.Encode(row.ID),
+			UserType:   enums.ParseUserTypeToEnum(row.UserType),
+			UserID:     s.encoder.Encode(row.UserID),
+			FullName:   row.FullName,
+			IPAddress:  row.IpAddress,
+			Device:     sessionDevice(row.Browser, row.Os, row.Device),
+			CreatedAt:  utils.ConvertToPH(row.CreatedAt.UTC().Format(constants.DateTimeLayoutISO)),
+			LastSeenAt: utils.ConvertToPH(row.LastSeenAt.UTC().Format(constants.DateTimeLayoutISO)),
+		})
+	}
+	return res, nil
+}
+
+// SignOut revokes the session behind key, used on logout.
+func (s *SessionService) SignOut(ctx context.Context, key string) error {
+	if key == "" {
+		return nil
+	}
+	if err := s.dbRW.GetQueries().RevokeSessionByKeyHash(ctx, queries.RevokeSessionByKeyHashParams{
+		Reason:  sql.NullString{String: enums.SESSION_REVOKE_REASON_LOGOUT.String(), Valid: true},
+		KeyHash: hashToken(key),
+	}); err != nil {
+		return errors.Join(errs.ErrSession, err)
+	}
+	return nil
+}
+
+// RevokeOthers signs the user out everywhere except the session behind
+// currentKey.
+func (s *SessionService) RevokeOthers(ctx context.Context, userType enums.UserType, userID string, currentKey string, reason enums.SessionRevokeReason) (int64, error) {
+	n, err := s.dbRW.GetQueries().RevokeOtherUserSessions(ctx, queries.RevokeOtherUserSessionsParams{
+		Reason:   sql.NullString{String: reason.String(), Valid: true},
+		UserType: userType.String(),
+		UserID:   s.encoder.Decode(userID),
+		KeyHash:  hashToken(currentKey),
+	})
+	if err != nil {
+		return 0, errors.Join(errs.ErrSession, err)
+	}
+	return n, nil
+}
+
+// RevokeUser signs the user out of every session.
+func (s *SessionService) RevokeUser(ctx context.Context, userType enums.UserType, userID string, reason enums.SessionRevokeReason) (int64, error) {
+	n, err := s.dbRW.GetQueries().RevokeUserSessions(ctx, queries.RevokeUserSessionsParams{
+		Reason:   sql.NullString{String: reason.String(), Valid: true},
+		UserType: userType.String(),
+		UserID:   s.encoder.Decode(userID),
+	})
+	if err != nil {
+		return 0, errors.Join(errs.ErrSession, err)
+	}
+	return n, nil
+}
+
+// ForceSignOut is the superuser action on a single session.
+func (s *SessionService) ForceSignOut(ctx context.Context, adminStaffID string, sessionID string) error {
+	var result string
+	defer func() {
+		if err := s.staffLog.CreateLog(ctx, adminStaffID, constants.ActionDelete, constants.ModuleSessions, result, nil); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	affected, err := s.dbRW.GetQueries().RevokeSession(ctx, queries.RevokeSessionParams{
+		Reason: sql.NullString{String: enums.SESSION_REVOKE_REASON_FORCED.String(), Valid: true},
+		ID:     s.encoder.Decode(sessionID),
+	})
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrSession, err)
+	}
+	if affected == 0 {
+		result = errs.ErrSessionNotFound.Error()
+		return errs.ErrSessionNotFound
+	}
+
+	result = fmt.Sprintf("forced sign out of session '%s'", sessionID)
+	return nil
+}
+
+// ForceSignOutUser is the superuser action on everything a user has open.
+func (s *SessionService) ForceSignOutUser(ctx context.Context, adminStaffID string, userType enums.UserType, userID string) (int64, error) {
+	var result string
+	defer func() {
+		if err := s.staffLog.CreateLog(ctx, adminStaffID, constants.ActionDelete, constants.ModuleSessions, result, nil); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	n, err := s.RevokeUser(ctx, userType, userID, enums.SESSION_REVOKE_REASON_FORCED)
+	if err != nil {
+		result = err.Error()
+		return 0, err
+	}
+
+	result = fmt.Sprintf("forced sign out of %d %s session(s) of '%s'", n, strings.ToLower(userType.String()), userID)
+	return n, nil
+}
+
+func sessionDevice(browser string, os string, device string) string {
+	parts := make([]string, 0, 3)
+	for _, p := range []string{browser, os, device} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 {
+		return "Unknown device"
+	}
+	return strings.Join(parts, " · ")
+}
+
+func (s *SessionService) ID() string {
+	return "Session"
+}
+
+func (s *SessionService) Log() {
+	logs.Log().Info("[SessionService] Loaded")
+}
+
+var _ IService = (*SessionService)(nil)
@@ -0,0 +1,17 @@
+package services
+
+import "cchoice/internal/enums"
+
+// SessionItem is an active session as listed on the profile and superuser
+// pages. Current marks the browser making the request.
+type SessionItem struct {
+	ID         string
+	UserType   enums.UserType
+	UserID     string
+	FullName   string
+	IPAddress  string
+	Device     string
+	CreatedAt  string
+	LastSeenAt string
+	Current    bool
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionDevice(t *testing.T) {
+	tests := []struct {
+		name    string
+		browser string
+		os      string
+		device  string
+		want    string
+	}{
+		{name: "all parts", browser: "Chrome", os: "Android", device: "Pixel 7", want: "Chrome · Android · Pixel 7"},
+		{name: "skips empty", browser: "Firefox", os: "", device: "Desktop", want: "Firefox · Desktop"},
+		{name: "nothing known", want: "Unknown device"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sessionDevice(tt.browser, tt.os, tt.device))
+		})
+	}
+}
@@ -0,0 +1,771 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/logs"
+	"cchoice/internal/shift"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+type ShiftService struct {
+	encoder  encode.IEncode
+	dbRO     database.IService
+	dbRW     database.IService
+	staffLog *StaffLogsService
+}
+
+func NewShiftService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	staffLog *StaffLogsService,
+) *ShiftService {
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &ShiftService{
+		encoder:  encoder,
+		dbRO:     dbRO,
+		dbRW:     dbRW,
+		staffLog: staffLog,
+	}
+}
+
+func defaultShift(timeIn, timeOut sql.NullString) shift.Shift {
+	return shift.Shift{TimeIn: timeIn.String, TimeOut: timeOut.String}
+}
+
+func shiftOf(timeIn, timeOut string, restDay bool) shift.Shift {
+	if restDay {
+		return shift.Shift{RestDay: true}
+	}
+	return shift.Shift{TimeIn: timeIn, TimeOut: timeOut}
+}
+
+// applySuspension marks the days of a suspension that fall from startDate to
+// endDate. It wins over any override on those days.
+func applySuspension(schedule shift.Schedule, from, to, startDate, endDate string) {
+	from, to = max(from, startDate), min(to, endDate)
+	start, err := time.Parse(constants.DateLayoutISO, from)
+	if err != nil {
+		return
+	}
+	end, err := time.Parse(constants.DateLayoutISO, to)
+	if err != nil {
+		return
+	}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		schedule.Overrides[day.Format(constants.DateLayoutISO)] = shift.Shift{RestDay: true, Suspended: true}
+	}
+}
+
+func applyRotation(schedule *shift.Schedule, rotation queries.TblStaffShiftRotation) {
+	schedule.CycleWeeks = int(rotation.CycleWeeks)
+	if anchor, err := time.Parse(constants.DateLayoutISO, rotation.AnchorDate); err == nil {
+		schedule.Anchor = anchor
+	}
+}
+
+// GetSchedule loads everything needed to resolve the shift of one staff on the
+// dates from startDate to endDate, both in constants.DateLayoutISO.
+func (s *ShiftService) GetSchedule(ctx context.Context, staffID int64, startDate, endDate string) (shift.Schedule, error) {
+	q := s.dbRO.GetQueries()
+	staff, err := q.GetShiftStaffByID(ctx, staffID)
+	if err != nil {
+		return shift.Schedule{}, errors.Join(errs.ErrShift, err)
+	}
+	schedule := shift.Schedule{
+		Default:    defaultShift(staff.TimeInSchedule, staff.TimeOutSchedule),
+		CycleWeeks: 1,
+		Template:   map[shift.Slot]shift.Shift{},
+		Overrides:  map[string]shift.Shift{},
+	}
+
+	rotation, err := q.GetStaffShiftRotation(ctx, staffID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return shift.Schedule{}, errors.Join(errs.ErrShift, err)
+	}
+	if err == nil {
+		applyRotation(&schedule, rotation)
+	}
+
+	days, err := q.GetStaffShiftDays(ctx, staffID)
+	if err != nil {
+		return shift.Schedule{}, errors.Join(errs.ErrShift, err)
+	}
+	for _, day := range days {
+		slot := shift.Slot{Week: int(day.WeekIndex), Weekday: time.Weekday(day.Weekday)}
+		schedule.Template[slot] = shiftOf(day.TimeIn, day.TimeOut, day.RestDay)
+	}
+
+	overrides, err := q.GetStaffShiftOverridesByDateRange(ctx, queries.GetStaffShiftOverridesByDateRangeParams{
+		StaffID:   staffID,
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		return shift.Schedule{}, errors.Join(errs.ErrShift, err)
+	}
+	for _, o := range overrides {
+		schedule.Overrides[o.ForDate] = shiftOf(o.TimeIn, o.TimeOut, o.RestDay)
+	}
+
+	suspensions, err := q.GetStaffSuspensionsByDateRange(ctx, queries.GetStaffSuspensionsByDateRangeParams{
+		StaffID:   staffID,
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		return shift.Schedule{}, errors.Join(errs.ErrShift, err)
+	}
+	for _, suspension := range suspensions {
+		applySuspension(schedule, suspension.EffectiveFrom.String, suspension.EffectiveTo.String, startDate, endDate)
+	}
+	return schedule, nil
+}
+
+// GetSchedules is GetSchedule for every staff at once, keyed by staff ID, for
+// listings and reports that span many staff.
+func (s *ShiftService) GetSchedules(ctx context.Context, startDate, endDate string) (map[int64]shift.Schedule, error) {
+	q := s.dbRO.GetQueries()
+	defaults, err := q.GetStaffShiftDefaults(ctx)
+	if err != nil {
+		return nil, errors.Join(errs.ErrShift, err)
+	}
+
+	schedules := make(map[int64]shift.Schedule, len(defaults))
+	for _, d := range defaults {
+		schedules[d.ID] = shift.Schedule{
+			Default:    defaultShift(d.TimeInSchedule, d.TimeOutSchedule),
+			CycleWeeks: 1,
+			Template:   map[shift.Slot]shift.Shift{},
+			Overrides:  map[string]shift.Shift{},
+		}
+	}
+
+	rotations, err := q.GetStaffShiftRotations(ctx)
+	if err != nil {
+		return nil, errors.Join(errs.ErrShift, err)
+	}
+	for _, rotation := range rotations {
+		schedule, ok := schedules[rotation.StaffID]
+		if !ok {
+			continue
+		}
+		applyRotation(&schedule, rotation)
+		schedules[rotation.StaffID] = schedule
+	}
+
+	days, err := q.GetAllStaffShiftDays(ctx)
+	if err != nil {
+		return nil, errors.Join(errs.ErrShift, err)
+	}
+	for _, day := range days {
+		if schedule, ok := schedules[day.StaffID]; ok {
+			slot := shift.Slot{Week: int(day.WeekIndex), Weekday: time.Weekday(day.Weekday)}
+			schedule.Template[slot] = shiftOf(day.TimeIn, day.TimeOut, day.RestDay)
+		}
+	}
+
+	overrides, err := q.GetAllStaffShiftOverridesByDateRange(ctx, queries.GetAllStaffShiftOverridesByDateRangeParams{
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrShift, err)
+	}
+	for _, o := range overrides {
+		if schedule, ok := schedules[o.StaffID]; ok {
+			schedule.Overrides[o.ForDate] = shiftOf(o.TimeIn, o.TimeOut, o.RestDay)
+		}
+	}
+
+	suspensions, err := q.GetStaffSuspensionsByDateRange(ctx, queries.GetStaffSuspensionsByDateRangeParams{
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrShift, err)
+	}
+	for _, suspension := range suspensions {
+		if schedule, ok := schedules[suspension.StaffID]; ok {
+			applySuspension(schedule, suspension.EffectiveFrom.String, suspension.EffectiveTo.String, startDate, endDate)
+		}
+	}
+	return schedules, nil
+}
+
+// GetUpcoming lists the shifts of a staff for the next days starting today.
+func (s *ShiftService) GetUpcoming(ctx context.Context, staffID string, days int) ([]DayShift, error) {
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		return nil, errs.ErrDecode
+	}
+
+	today := utils.NowPH()
+	start := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, days-1)
+	schedule, err := s.GetSchedule(ctx, dbStaffID, start.Format(constants.DateLayoutISO), end.Format(constants.DateLayoutISO))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]DayShift, 0, days)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format(constants.DateLayoutISO)
+		res = append(res, DayShift{Date: date, Shift: schedule.For(date)})
+	}
+	return res, nil
+}
+
+func (s *ShiftService) GetStaffSummaries(ctx context.Context) ([]StaffShiftSummary, error) {
+	rows, err := s.dbRO.GetQueries().GetShiftStaffs(ctx)
+	if err != nil {
+		return nil, errors.Join(errs.ErrShift, err)
+	}
+
+	res := make([]StaffShiftSummary, 0, len(rows))
+	for _, row := range rows {
+		cycleWeeks := 1
+		if row.CycleWeeks.Valid {
+			cycleWeeks = int(row.CycleWeeks.Int64)
+		}
+		res = append(res, StaffShiftSummary{
+			StaffID:      s.encoder.Encode(row.ID),
+			FullName:     utils.BuildFullName(row.FirstName, row.MiddleName.String, row.LastName),
+			Position:     row.Position,
+			Default:      defaultShift(row.TimeInSchedule, row.TimeOutSchedule),
+			CycleWeeks:   cycleWeeks,
+			TemplateDays: row.TemplateDays,
+		})
+	}
+	return res, nil
+}
+
+// GetEditor returns the rotation, weekly template and upcoming overrides of a
+// staff for the schedule editor.
+func (s *ShiftService) GetEditor(ctx context.Context, staffID string) (StaffShiftEditor, error) {
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		return StaffShiftEditor{}, errs.ErrDecode
+	}
+
+	staff, err := s.dbRO.GetQueries().GetShiftStaffByID(ctx, dbStaffID)
+	if err != nil {
+		return StaffShiftEditor{}, errors.Join(errs.ErrShift, err)
+	}
+
+	today := utils.NowPH()
+	start := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	schedule, err := s.GetSchedule(
+		ctx,
+		dbStaffID,
+		start.Format(constants.DateLayoutISO),
+		start.AddDate(0, 0, constants.ShiftOverridesAhead).Format(constants.DateLayoutISO),
+	)
+	if err != nil {
+		return StaffShiftEditor{}, err
+	}
+
+	anchor := shift.MondayOf(start)
+	if !schedule.Anchor.IsZero() {
+		anchor = shift.MondayOf(schedule.Anchor)
+	}
+	editor := StaffShiftEditor{
+		StaffID:    staffID,
+		FullName:   utils.BuildFullName(staff.FirstName, staff.MiddleName.String, staff.LastName),
+		Position:   staff.Position,
+		Default:    schedule.Default,
+		CycleWeeks: max(1, schedule.CycleWeeks),
+		Anchor:     anchor.Format(constants.DateLayoutISO),
+		Template:   schedule.Template,
+	}
+
+	overrides, err := s.dbRO.GetQueries().GetStaffShiftOverridesByDateRange(ctx, queries.GetStaffShiftOverridesByDateRangeParams{
+		StaffID:   dbStaffID,
+		StartDate: start.Format(constants.DateLayoutISO),
+		EndDate:   start.AddDate(0, 0, constants.ShiftOverridesAhead).Format(constants.DateLayoutISO),
+	})
+	if err != nil {
+		return StaffShiftEditor{}, errors.Join(errs.ErrShift, err)
+	}
+	editor.Overrides = make([]ShiftOverride, 0, len(overrides))
+	for _, o := range overrides {
+		editor.Overrides = append(editor.Overrides, ShiftOverride{
+			ID:       s.encoder.Encode(o.ID),
+			Date:     o.ForDate,
+			Shift:    shiftOf(o.TimeIn, o.TimeOut, o.RestDay),
+			Note:     o.Note,
+			FromSwap: o.ShiftSwapID.Valid,
+		})
+	}
+	return editor, nil
+}
+
+// SetRotation sets how many weeks the template cycles through and the date its
+// first week starts on. Shrinking the cycle drops the days of the removed
+// weeks.
+func (s *ShiftService) SetRotation(
+	ctx context.Context,
+	adminStaffID string,
+	staffID string,
+	cycleWeeks int,
+	anchorDate string,
+) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionUpdate,
+			constants.ModuleShifts,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	anchor, err := time.Parse(constants.DateLayoutISO, anchorDate)
+	if err != nil || cycleWeeks < 1 || cycleWeeks > constants.ShiftMaxCycleWeeks {
+		result = errs.ErrShiftInvalidRotation.Error()
+		return errs.ErrShiftInvalidRotation
+	}
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	if err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		if err := qtx.UpsertStaffShiftRotation(ctx, queries.UpsertStaffShiftRotationParams{
+			StaffID:    dbStaffID,
+			CycleWeeks: int64(cycleWeeks),
+			AnchorDate: shift.MondayOf(anchor).Format(constants.DateLayoutISO),
+			UpdatedBy:  sql.NullInt64{Int64: s.encoder.Decode(adminStaffID), Valid: true},
+		}); err != nil {
+			return err
+		}
+		return qtx.DeleteStaffShiftDaysFromWeek(ctx, queries.DeleteStaffShiftDaysFromWeekParams{
+			StaffID:   dbStaffID,
+			WeekIndex: int64(cycleWeeks),
+		})
+	}); err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrShift, err)
+	}
+
+	result = fmt.Sprintf("success. rotation of %d week(s) for staff '%s'", cycleWeeks, staffID)
+	return nil
+}
+
+// SetDay saves one day of the weekly template.
+func (s *ShiftService) SetDay(
+	ctx context.Context,
+	adminStaffID string,
+	staffID string,
+	slot shift.Slot,
+	sh shift.Shift,
+) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionUpdate,
+			constants.ModuleShifts,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	if err := sh.Validate(); err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrShiftInvalid, err)
+	}
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	cycleWeeks := int64(1)
+	rotation, err := s.dbRO.GetQueries().GetStaffShiftRotation(ctx, dbStaffID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		result = err.Error()
+		return errors.Join(errs.ErrShift, err)
+	}
+	if err == nil {
+		cycleWeeks = rotation.CycleWeeks
+	}
+	if slot.Week < 0 || int64(slot.Week) >= cycleWeeks || slot.Weekday < time.Sunday || slot.Weekday > time.Saturday {
+		result = errs.ErrShiftInvalidSlot.Error()
+		return errs.ErrShiftInvalidSlot
+	}
+
+	if err := s.dbRW.GetQueries().UpsertStaffShiftDay(ctx, queries.UpsertStaffShiftDayParams{
+		StaffID:   dbStaffID,
+		WeekIndex: int64(slot.Week),
+		Weekday:   int64(slot.Weekday),
+		TimeIn:    sh.TimeIn,
+		TimeOut:   sh.TimeOut,
+		RestDay:   sh.RestDay,
+	}); err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrShift, err)
+	}
+
+	result = fmt.Sprintf("success. week %d %s set to %s for staff '%s'", slot.Week+1, slot.Weekday, sh, staffID)
+	return nil
+}
+
+// ClearTemplate removes the rotation and weekly template so the staff goes
+// back to their single time in and out pair. Overrides are kept.
+func (s *ShiftService) ClearTemplate(ctx context.Context, adminStaffID string, staffID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionReset,
+			constants.ModuleShifts,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	if err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		if err := qtx.DeleteStaffShiftDays(ctx, dbStaffID); err != nil {
+			return err
+		}
+		return qtx.DeleteStaffShiftRotation(ctx, dbStaffID)
+	}); err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrShift, err)
+	}
+
+	result = fmt.Sprintf("success. template cleared for staff '%s'", staffID)
+	return nil
+}
+
+// SetOverride replaces the shift of a staff on one date.
+func (s *ShiftService) SetOverride(
+	ctx context.Context,
+	adminStaffID string,
+	staffID string,
+	date string,
+	sh shift.Shift,
+	note string,
+) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionUpdate,
+			constants.ModuleShifts,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	if _, err := time.Parse(constants.DateLayoutISO, date); err != nil {
+		result = errs.ErrShiftInvalidDate.Error()
+		return errs.ErrShiftInvalidDate
+	}
+	if err := sh.Validate(); err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrShiftInvalid, err)
+	}
+	dbStaffID := s.encoder.Decode(staffID)
+	if dbStaffID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+
+	if err := s.dbRW.GetQueries().UpsertStaffShiftOverride(ctx, queries.UpsertStaffShiftOverrideParams{
+		StaffID:   dbStaffID,
+		ForDate:   date,
+		TimeIn:    sh.TimeIn,
+		TimeOut:   sh.TimeOut,
+		RestDay:   sh.RestDay,
+		Note:      note,
+		CreatedBy: sql.NullInt64{Int64: s.encoder.Decode(adminStaffID), Valid: true},
+	}); err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrShift, err)
+	}
+
+	result = fmt.Sprintf("success. %s set to %s for staff '%s'", date, sh, staffID)
+	return nil
+}
+
+func (s *ShiftService) DeleteOverride(ctx context.Context, adminStaffID string, staffID string, overrideID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionDelete,
+			constants.ModuleShifts,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	affected, err := s.dbRW.GetQueries().DeleteStaffShiftOverride(ctx, queries.DeleteStaffShiftOverrideParams{
+		ID:      s.encoder.Decode(overrideID),
+		StaffID: s.encoder.Decode(staffID),
+	})
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrShift, err)
+	}
+	if affected == 0 {
+		result = errs.ErrShiftOverrideMissing.Error()
+		return errs.ErrShiftOverrideMissing
+	}
+
+	result = fmt.Sprintf("success. override '%s' of staff '%s' deleted", overrideID, staffID)
+	return nil
+}
+
+// RequestSwap files a request for two staff to trade their shifts on a date.
+// Nothing changes until a superuser approves it.
+func (s *ShiftService) RequestSwap(
+	ctx context.Context,
+	requesterID string,
+	counterpartID string,
+	date string,
+	reason string,
+) (string, error) {
+	dbRequesterID := s.encoder.Decode(requesterID)
+	dbCounterpartID := s.encoder.Decode(counterpartID)
+	if dbRequesterID == encode.INVALID || dbCounterpartID == encode.INVALID {
+		return "", errs.ErrDecode
+	}
+	day, err := time.Parse(constants.DateLayoutISO, date)
+	today := utils.NowPH()
+	if err != nil || dbRequesterID == dbCounterpartID ||
+		day.Before(time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)) {
+		return "", errs.ErrShiftSwapInvalid
+	}
+
+	_, err = s.dbRO.GetQueries().GetPendingStaffShiftSwapForDate(ctx, queries.GetPendingStaffShiftSwapForDateParams{
+		ForDate:       date,
+		RequesterID:   dbRequesterID,
+		CounterpartID: dbCounterpartID,
+	})
+	if err == nil {
+		return "", errs.ErrShiftSwapPending
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", errors.Join(errs.ErrShift, err)
+	}
+
+	schedules, err := s.GetSchedules(ctx, date, date)
+	if err != nil {
+		return "", err
+	}
+	if schedules[dbRequesterID].For(date) == schedules[dbCounterpartID].For(date) {
+		return "", errs.ErrShiftSwapSameShift
+	}
+
+	swapID, err := s.dbRW.GetQueries().CreateStaffShiftSwap(ctx, queries.CreateStaffShiftSwapParams{
+		RequesterID:   dbRequesterID,
+		CounterpartID: dbCounterpartID,
+		ForDate:       date,
+		Reason:        reason,
+	})
+	if err != nil {
+		return "", errors.Join(errs.ErrShift, err)
+	}
+	return s.encoder.Encode(swapID), nil
+}
+
+// ApproveSwap gives each staff the other's shift on the swap date as
+// overrides, using their schedules at the time of approval.
+func (s *ShiftService) ApproveSwap(ctx context.Context, adminStaffID string, swapID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionApprove,
+			constants.ModuleShifts,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	dbSwapID := s.encoder.Decode(swapID)
+	if dbSwapID == encode.INVALID {
+		result = errs.ErrDecode.Error()
+		return errs.ErrDecode
+	}
+	swap, err := s.dbRO.GetQueries().GetStaffShiftSwapByID(ctx, dbSwapID)
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrShift, err)
+	}
+	schedules, err := s.GetSchedules(ctx, swap.ForDate, swap.ForDate)
+	if err != nil {
+		result = err.Error()
+		return err
+	}
+	requesterShift := schedules[swap.RequesterID].For(swap.ForDate)
+	counterpartShift := schedules[swap.CounterpartID].For(swap.ForDate)
+
+	dbAdminStaffID := s.encoder.Decode(adminStaffID)
+	err = s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		affected, err := qtx.DecideStaffShiftSwap(ctx, queries.DecideStaffShiftSwapParams{
+			Status:    enums.SHIFT_SWAP_STATUS_APPROVED.String(),
+			DecidedBy: sql.NullInt64{Int64: dbAdminStaffID, Valid: true},
+			ID:        dbSwapID,
+		})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return errs.ErrShiftSwapNotPending
+		}
+
+		for staffID, sh := range map[int64]shift.Shift{
+			swap.RequesterID:   counterpartShift,
+			swap.CounterpartID: requesterShift,
+		} {
+			if err := qtx.UpsertStaffShiftOverride(ctx, queries.UpsertStaffShiftOverrideParams{
+				StaffID:     staffID,
+				ForDate:     swap.ForDate,
+				TimeIn:      sh.TimeIn,
+				TimeOut:     sh.TimeOut,
+				RestDay:     sh.RestDay,
+				Note:        "Shift swap",
+				ShiftSwapID: sql.NullInt64{Int64: dbSwapID, Valid: true},
+				CreatedBy:   sql.NullInt64{Int64: dbAdminStaffID, Valid: true},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errs.ErrShiftSwapNotPending) {
+		result = err.Error()
+		return err
+	}
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrShift, err)
+	}
+
+	result = fmt.Sprintf("success. ID '%s'", swapID)
+	return nil
+}
+
+func (s *ShiftService) RejectSwap(ctx context.Context, adminStaffID string, swapID string) error {
+	result := "success"
+	defer func() {
+		if err := s.staffLog.CreateLog(
+			ctx,
+			adminStaffID,
+			constants.ActionReject,
+			constants.ModuleShifts,
+			result,
+			nil,
+		); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	affected, err := s.dbRW.GetQueries().DecideStaffShiftSwap(ctx, queries.DecideStaffShiftSwapParams{
+		Status:    enums.SHIFT_SWAP_STATUS_REJECTED.String(),
+		DecidedBy: sql.NullInt64{Int64: s.encoder.Decode(adminStaffID), Valid: true},
+		ID:        s.encoder.Decode(swapID),
+	})
+	if err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrShift, err)
+	}
+	if affected == 0 {
+		result = errs.ErrShiftSwapNotPending.Error()
+		return errs.ErrShiftSwapNotPending
+	}
+
+	result = fmt.Sprintf("success. ID '%s'", swapID)
+	return nil
+}
+
+// GetSwaps lists the latest swap requests. An empty staffID lists everyone's,
+// otherwise only the ones the staff is part of.
+func (s *ShiftService) GetSwaps(ctx context.Context, staffID string) ([]ShiftSwap, error) {
+	var dbStaffID int64
+	if staffID != "" {
+		dbStaffID = s.encoder.Decode(staffID)
+		if dbStaffID == encode.INVALID {
+			return nil, errs.ErrDecode
+		}
+	}
+
+	rows, err := s.dbRO.GetQueries().GetStaffShiftSwaps(ctx, queries.GetStaffShiftSwapsParams{
+		StaffID: dbStaffID,
+		Limit:   constants.ShiftSwapListLimit,
+	})
+	if err != nil {
+		return nil, errors.Join(errs.ErrShift, err)
+	}
+
+	swaps := make([]ShiftSwap, 0, len(rows))
+	for _, row := range rows {
+		swaps = append(swaps, ShiftSwap{
+			ID:              s.encoder.Encode(row.ID),
+			RequesterID:     s.encoder.Encode(row.RequesterID),
+			RequesterName:   utils.BuildFullName(row.RequesterFirstName, row.RequesterMiddleName.String, row.RequesterLastName),
+			CounterpartID:   s.encoder.Encode(row.CounterpartID),
+			CounterpartName: utils.BuildFullName(row.CounterpartFirstName, row.CounterpartMiddleName.String, row.CounterpartLastName),
+			Date:            row.ForDate,
+			Reason:          row.Reason,
+			Status:          enums.ParseShiftSwapStatusToEnum(row.Status),
+			CreatedAt:       row.CreatedAt,
+		})
+	}
+	return swaps, nil
+}
+
+func (s *ShiftService) ID() string {
+	return "Shift"
+}
+
+func (s *ShiftService) Log() {
+	logs.Log().Info("[ShiftService] Loaded")
+}
+
+var _ IService = (*ShiftService)(nil)
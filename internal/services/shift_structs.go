@@ -0,0 +1,53 @@
+package services
+
+import (
+	"time"
+
+	"cchoice/internal/enums"
+	"cchoice/internal/shift"
+)
+
+type StaffShiftSummary struct {
+	StaffID      string
+	FullName     string
+	Position     string
+	Default      shift.Shift
+	CycleWeeks   int
+	TemplateDays int64
+}
+
+type ShiftOverride struct {
+	ID       string
+	Date     string
+	Shift    shift.Shift
+	Note     string
+	FromSwap bool
+}
+
+type StaffShiftEditor struct {
+	StaffID    string
+	FullName   string
+	Position   string
+	Default    shift.Shift
+	CycleWeeks int
+	Anchor     string
+	Template   map[shift.Slot]shift.Shift
+	Overrides  []ShiftOverride
+}
+
+type ShiftSwap struct {
+	ID              string
+	RequesterID     string
+	RequesterName   string
+	CounterpartID   string
+	CounterpartName string
+	Date            string
+	Reason          string
+	Status          enums.ShiftSwapStatus
+	CreatedAt       time.Time
+}
+
+type DayShift struct {
+	Date  string
+	Shift shift.Shift
+}
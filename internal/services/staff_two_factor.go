@@ -0,0 +1,521 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cchoice/internal/conf"
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/jobs"
+	"cchoice/internal/logs"
+	"cchoice/internal/totp"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+// recoveryCodeAlphabet leaves out characters that are easy to misread.
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+type StaffTwoFactorService struct {
+	encoder     encode.IEncode
+	dbRO        database.IService
+	dbRW        database.IService
+	emailRunner *jobs.EmailJobRunner
+	staffLog    *StaffLogsService
+}
+
+func NewStaffTwoFactorService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	emailRunner *jobs.EmailJobRunner,
+	staffLog *StaffLogsService,
+) *StaffTwoFactorService {
+	if (conf.Conf().IsProd() || conf.Conf().Test.LocalOTP) && emailRunner == nil {
+		panic("emailRunner is required")
+	}
+	if staffLog == nil {
+		panic("StaffLogsService is required")
+	}
+	return &StaffTwoFactorService{
+		encoder:     encoder,
+		dbRO:        dbRO,
+		dbRW:        dbRW,
+		emailRunner: emailRunner,
+		staffLog:    staffLog,
+	}
+}
+
+func (s *StaffTwoFactorService) GetStatus(ctx context.Context, staffID string) (TwoFactorStatus, error) {
+	dbStaffID := s.encoder.Decode(staffID)
+	q := s.dbRO.GetQueries()
+
+	tf, err := q.GetStaffTwoFactor(ctx, dbStaffID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return TwoFactorStatus{}, nil
+		}
+		return TwoFactorStatus{}, errors.Join(errs.ErrTwoFactor, err)
+	}
+	if !tf.EnabledAt.Valid {
+		return TwoFactorStatus{}, nil
+	}
+
+	codesLeft, err := q.CountUnusedStaffRecoveryCodes(ctx, dbStaffID)
+	if err != nil {
+		return TwoFactorStatus{}, errors.Join(errs.ErrTwoFactor, err)
+	}
+	devices, err := q.CountStaffTrustedDevices(ctx, dbStaffID)
+	if err != nil {
+		return TwoFactorStatus{}, errors.Join(errs.ErrTwoFactor, err)
+	}
+
+	return TwoFactorStatus{
+		Enabled:           true,
+		EnabledAt:         tf.EnabledAt.Time,
+		RecoveryCodesLeft: codesLeft,
+		TrustedDevices:    devices,
+	}, nil
+}
+
+// BeginEnrolment keeps the same secret for an enrolment that was started but
+// not confirmed, so reloading the page does not invalidate an app that
+// already scanned the QR code.
+func (s *StaffTwoFactorService) BeginEnrolment(ctx context.Context, staffID string, email string) (TwoFactorEnrolment, error) {
+	dbStaffID := s.encoder.Decode(staffID)
+
+	tf, err := s.dbRO.GetQueries().GetStaffTwoFactor(ctx, dbStaffID)
+	switch {
+	case err == nil && tf.EnabledAt.Valid:
+		return TwoFactorEnrolment{}, errs.ErrTwoFactorAlreadyEnabled
+	case err == nil:
+		return TwoFactorEnrolment{
+			Secret: tf.Secret,
+			URI:    totp.KeyURI(constants.TwoFactorIssuer, email, tf.Secret),
+		}, nil
+	case !errors.Is(err, sql.ErrNoRows):
+		return TwoFactorEnrolment{}, errors.Join(errs.ErrTwoFactor, err)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return TwoFactorEnrolment{}, errors.Join(errs.ErrTwoFactor, err)
+	}
+	if err := s.dbRW.GetQueries().UpsertPendingStaffTwoFactor(ctx, queries.UpsertPendingStaffTwoFactorParams{
+		StaffID: dbStaffID,
+		Secret:  secret,
+	}); err != nil {
+		return TwoFactorEnrolment{}, errors.Join(errs.ErrTwoFactor, err)
+	}
+
+	return TwoFactorEnrolment{
+		Secret: secret,
+		URI:    totp.KeyURI(constants.TwoFactorIssuer, email, secret),
+	}, nil
+}
+
+// ConfirmEnrolment turns on 2FA once the staff proves their app works and
+// returns the recovery codes, which are only ever shown this once.
+func (s *StaffTwoFactorService) ConfirmEnrolment(ctx context.Context, staffID string, code string) ([]string, error) {
+	var result string
+	defer func() {
+		if result != "" {
+			if err := s.staffLog.CreateLog(ctx, staffID, constants.ActionCreate, constants.ModuleTwoFactor, result, nil); err != nil {
+				logs.Log().Warn("create log", zap.Error(err))
+			}
+		}
+	}()
+
+	dbStaffID := s.encoder.Decode(staffID)
+	if err := s.checkLocked(ctx, dbStaffID); err != nil {
+		return nil, err
+	}
+	tf, err := s.dbRO.GetQueries().GetStaffTwoFactor(ctx, dbStaffID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrTwoFactorNotEnrolling
+		}
+		return nil, errors.Join(errs.ErrTwoFactor, err)
+	}
+	if tf.EnabledAt.Valid {
+		return nil, errs.ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(tf.Secret, code, utils.NowPH(), tf.LastUsedStep)
+	if !ok {
+		return nil, s.recordFailure(ctx, dbStaffID)
+	}
+
+	var codes []string
+	err = s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		affected, err := qtx.EnableStaffTwoFactor(ctx, queries.EnableStaffTwoFactorParams{
+			Step:    step,
+			StaffID: dbStaffID,
+		})
+		if err != nil {
+			return errors.Join(errs.ErrTwoFactor, err)
+		}
+		if affected == 0 {
+			return errs.ErrTwoFactorAlreadyEnabled
+		}
+		codes, err = replaceRecoveryCodes(ctx, qtx, dbStaffID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.clearFailures(ctx, dbStaffID)
+
+	result = "enabled"
+	return codes, nil
+}
+
+// Verify checks the second factor of a login using whichever method the
+// staff picked. Wrong codes count towards locking the staff's second factor,
+// see recordFailure.
+func (s *StaffTwoFactorService) Verify(ctx context.Context, staffID string, method enums.TwoFactorMethod, code string) error {
+	dbStaffID := s.encoder.Decode(staffID)
+	if err := s.checkLocked(ctx, dbStaffID); err != nil {
+		return err
+	}
+
+	err := s.verify(ctx, staffID, dbStaffID, method, code)
+	switch {
+	case err == nil:
+		s.clearFailures(ctx, dbStaffID)
+	case errors.Is(err, errs.ErrTwoFactorInvalidCode):
+		return s.recordFailure(ctx, dbStaffID)
+	}
+	return err
+}
+
+func (s *StaffTwoFactorService) verify(ctx context.Context, staffID string, dbStaffID int64, method enums.TwoFactorMethod, code string) error {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return errs.ErrTwoFactorInvalidCode
+	}
+
+	tf, err := s.dbRO.GetQueries().GetStaffTwoFactor(ctx, dbStaffID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.ErrTwoFactorNotEnabled
+		}
+		return errors.Join(errs.ErrTwoFactor, err)
+	}
+	if !tf.EnabledAt.Valid {
+		return errs.ErrTwoFactorNotEnabled
+	}
+
+	var affected int64
+	switch method {
+	case enums.TWO_FACTOR_METHOD_TOTP:
+		step, ok := totp.Validate(tf.Secret, code, utils.NowPH(), tf.LastUsedStep)
+		if !ok {
+			return errs.ErrTwoFactorInvalidCode
+		}
+		affected, err = s.dbRW.GetQueries().UseStaffTwoFactorStep(ctx, queries.UseStaffTwoFactorStepParams{
+			Step:    step,
+			StaffID: dbStaffID,
+		})
+	case enums.TWO_FACTOR_METHOD_RECOVERY_CODE:
+		affected, err = s.dbRW.GetQueries().UseStaffRecoveryCode(ctx, queries.UseStaffRecoveryCodeParams{
+			StaffID:  dbStaffID,
+			CodeHash: hashToken(normalizeRecoveryCode(code)),
+		})
+		if err == nil && affected > 0 {
+			if err := s.staffLog.CreateLog(ctx, staffID, constants.ActionUpdate, constants.ModuleTwoFactor, "used a recovery code", nil); err != nil {
+				logs.Log().Warn("create log", zap.Error(err))
+			}
+		}
+	case enums.TWO_FACTOR_METHOD_EMAIL:
+		affected, err = s.dbRW.GetQueries().UseStaffOTPCode(ctx, queries.UseStaffOTPCodeParams{
+			StaffID: dbStaffID,
+			OtpCode: code,
+		})
+	default:
+		return errs.ErrEnumInvalid
+	}
+	if err != nil {
+		return errors.Join(errs.ErrTwoFactor, err)
+	}
+	if affected == 0 {
+		return errs.ErrTwoFactorInvalidCode
+	}
+	return nil
+}
+
+// SendEmailCode is the fallback for a staff without their phone. It follows
+// CustomerOTPService: the code is emailed in production and logged otherwise.
+func (s *StaffTwoFactorService) SendEmailCode(ctx context.Context, staffID string, email string) error {
+	const logtag = "[StaffTwoFactorService SendEmailCode]"
+	dbStaffID := s.encoder.Decode(staffID)
+	if err := s.checkLocked(ctx, dbStaffID); err != nil {
+		return err
+	}
+
+	recent, err := s.dbRO.GetQueries().CountRecentStaffOTPCodes(ctx, dbStaffID)
+	if err != nil {
+		return errors.Join(errs.ErrTwoFactor, err)
+	}
+	if recent > 0 {
+		return errs.ErrTwoFactorRateLimited
+	}
+
+	otpCode, err := generateRandomOTP()
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		return errs.ErrOTPGenerationFailed
+	}
+	if err := s.dbRW.GetQueries().CreateStaffOTPCode(ctx, queries.CreateStaffOTPCodeParams{
+		StaffID: dbStaffID,
+		OtpCode: otpCode,
+	}); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		return errs.ErrOTPCreationFailed
+	}
+
+	if !conf.Conf().IsProd() && !conf.Conf().Test.LocalOTP {
+		logs.Log().Info("[DEBUG]", zap.String("otp", otpCode))
+		return nil
+	}
+	if err := s.emailRunner.QueueEmailJob(ctx, jobs.EmailJobParams{
+		Recipient:    email,
+		Subject:      "Your Sign In Code - C-Choice Admin",
+		TemplateName: enums.EMAIL_TEMPLATE_STAFF_LOGIN_CODE,
+		EMail:        email,
+		OTPCode:      otpCode,
+	}); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		return errs.ErrJobsCreateFailed
+	}
+	return nil
+}
+
+func (s *StaffTwoFactorService) RegenerateRecoveryCodes(ctx context.Context, staffID string) ([]string, error) {
+	var result string
+	defer func() {
+		if err := s.staffLog.CreateLog(ctx, staffID, constants.ActionReset, constants.ModuleTwoFactor, result, nil); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	status, err := s.GetStatus(ctx, staffID)
+	if err != nil {
+		result = err.Error()
+		return nil, err
+	}
+	if !status.Enabled {
+		result = errs.ErrTwoFactorNotEnabled.Error()
+		return nil, errs.ErrTwoFactorNotEnabled
+	}
+
+	var codes []string
+	err = s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		var err error
+		codes, err = replaceRecoveryCodes(ctx, qtx, s.encoder.Decode(staffID))
+		return err
+	})
+	if err != nil {
+		result = err.Error()
+		return nil, err
+	}
+
+	result = "regenerated recovery codes"
+	return codes, nil
+}
+
+// Disable needs a current authenticator code. Superusers cannot turn it off.
+func (s *StaffTwoFactorService) Disable(ctx context.Context, staffID string, isSuperuser bool, code string) error {
+	var result string
+	defer func() {
+		if err := s.staffLog.CreateLog(ctx, staffID, constants.ActionDelete, constants.ModuleTwoFactor, result, nil); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	if isSuperuser {
+		result = errs.ErrTwoFactorRequired.Error()
+		return errs.ErrTwoFactorRequired
+	}
+	if err := s.Verify(ctx, staffID, enums.TWO_FACTOR_METHOD_TOTP, code); err != nil {
+		result = err.Error()
+		return err
+	}
+
+	dbStaffID := s.encoder.Decode(staffID)
+	if err := s.dbRW.RunInTx(ctx, func(_ *sql.Tx, qtx *queries.Queries) error {
+		if err := qtx.DeleteStaffTwoFactor(ctx, dbStaffID); err != nil {
+			return err
+		}
+		if err := qtx.DeleteStaffRecoveryCodes(ctx, dbStaffID); err != nil {
+			return err
+		}
+		if err := qtx.DeleteStaffOTPCodes(ctx, dbStaffID); err != nil {
+			return err
+		}
+		return qtx.DeleteStaffTrustedDevices(ctx, dbStaffID)
+	}); err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrTwoFactor, err)
+	}
+
+	result = "disabled"
+	return nil
+}
+
+// RememberDevice returns the token to put in the device cookie. Only its
+// hash is stored, together with the user agent it was issued to.
+func (s *StaffTwoFactorService) RememberDevice(ctx context.Context, staffID string, useragentID sql.NullInt64) (string, error) {
+	token, err := generateResetToken()
+	if err != nil {
+		return "", errors.Join(errs.ErrTwoFactor, err)
+	}
+	if err := s.dbRW.GetQueries().CreateStaffTrustedDevice(ctx, queries.CreateStaffTrustedDeviceParams{
+		StaffID:     s.encoder.Decode(staffID),
+		TokenHash:   hashToken(token),
+		UseragentID: useragentID,
+	}); err != nil {
+		return "", errors.Join(errs.ErrTwoFactor, err)
+	}
+	return token, nil
+}
+
+// IsTrustedDevice lets a remembered browser skip the second factor. The
+// token is ignored when presented by a different user agent.
+func (s *StaffTwoFactorService) IsTrustedDevice(ctx context.Context, staffID string, token string, useragentID sql.NullInt64) bool {
+	const logtag = "[StaffTwoFactorService IsTrustedDevice]"
+	if token == "" {
+		return false
+	}
+
+	device, err := s.dbRO.GetQueries().GetStaffTrustedDevice(ctx, queries.GetStaffTrustedDeviceParams{
+		TokenHash: hashToken(token),
+		StaffID:   s.encoder.Decode(staffID),
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		}
+		return false
+	}
+	if device.UseragentID != useragentID {
+		return false
+	}
+
+	if err := s.dbRW.GetQueries().TouchStaffTrustedDevice(ctx, device.ID); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+	}
+	return true
+}
+
+func (s *StaffTwoFactorService) ForgetDevices(ctx context.Context, staffID string) error {
+	var result string
+	defer func() {
+		if err := s.staffLog.CreateLog(ctx, staffID, constants.ActionDelete, constants.ModuleTwoFactor, result, nil); err != nil {
+			logs.Log().Warn("create log", zap.Error(err))
+		}
+	}()
+
+	if err := s.dbRW.GetQueries().DeleteStaffTrustedDevices(ctx, s.encoder.Decode(staffID)); err != nil {
+		result = err.Error()
+		return errors.Join(errs.ErrTwoFactor, err)
+	}
+	result = "forgot remembered devices"
+	return nil
+}
+
+func (s *StaffTwoFactorService) checkLocked(ctx context.Context, dbStaffID int64) error {
+	locked, err := s.dbRO.GetQueries().CountStaffTwoFactorLocks(ctx, dbStaffID)
+	if err != nil {
+		return errors.Join(errs.ErrTwoFactor, err)
+	}
+	if locked > 0 {
+		return errs.ErrTwoFactorTooManyAttempts
+	}
+	return nil
+}
+
+// recordFailure counts a wrong code against the staff rather than their login
+// session, so logging in again does not reset it. It returns the error to
+// show: ErrTwoFactorTooManyAttempts once the count locks the second factor.
+func (s *StaffTwoFactorService) recordFailure(ctx context.Context, dbStaffID int64) error {
+	attempts, err := s.dbRW.GetQueries().RecordStaffTwoFactorFailure(ctx, dbStaffID)
+	if err != nil {
+		return errors.Join(errs.ErrTwoFactor, err)
+	}
+	if attempts < constants.TwoFactorMaxAttempts {
+		return errs.ErrTwoFactorInvalidCode
+	}
+	if err := s.dbRW.GetQueries().LockStaffTwoFactor(ctx, dbStaffID); err != nil {
+		return errors.Join(errs.ErrTwoFactor, err)
+	}
+	if err := s.staffLog.CreateLog(ctx, s.encoder.Encode(dbStaffID), constants.ActionUpdate, constants.ModuleTwoFactor, "locked after too many wrong codes", nil); err != nil {
+		logs.Log().Warn("create log", zap.Error(err))
+	}
+	return errs.ErrTwoFactorTooManyAttempts
+}
+
+func (s *StaffTwoFactorService) clearFailures(ctx context.Context, dbStaffID int64) {
+	if err := s.dbRW.GetQueries().DeleteStaffTwoFactorFailures(ctx, dbStaffID); err != nil {
+		logs.LogCtx(ctx).Warn("[StaffTwoFactorService clearFailures]", zap.Int64("staff id", dbStaffID), zap.Error(err))
+	}
+}
+
+func replaceRecoveryCodes(ctx context.Context, qtx *queries.Queries, staffID int64) ([]string, error) {
+	if err := qtx.DeleteStaffRecoveryCodes(ctx, staffID); err != nil {
+		return nil, errors.Join(errs.ErrTwoFactor, err)
+	}
+
+	codes := make([]string, 0, constants.TwoFactorRecoveryCodes)
+	for range constants.TwoFactorRecoveryCodes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, errors.Join(errs.ErrTwoFactor, err)
+		}
+		if err := qtx.CreateStaffRecoveryCode(ctx, queries.CreateStaffRecoveryCodeParams{
+			StaffID:  staffID,
+			CodeHash: hashToken(normalizeRecoveryCode(code)),
+		}); err != nil {
+			return nil, errors.Join(errs.ErrTwoFactor, err)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// generateRecoveryCode reads like "k7m2-x9qp".
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)]
+	}
+	return fmt.Sprintf("%s-%s", buf[:4], buf[4:]), nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+func (s *StaffTwoFactorService) ID() string {
+	return "StaffTwoFactor"
+}
+
+func (s *StaffTwoFactorService) Log() {
+	logs.Log().Info("[StaffTwoFactorService] Loaded")
+}
+
+var _ IService = (*StaffTwoFactorService)(nil)
@@ -0,0 +1,17 @@
+package services
+
+import "time"
+
+type TwoFactorStatus struct {
+	Enabled           bool
+	EnabledAt         time.Time
+	RecoveryCodesLeft int64
+	TrustedDevices    int64
+}
+
+// TwoFactorEnrolment is what the authenticator app needs. URI goes in the QR
+// code; Secret is for typing in by hand.
+type TwoFactorEnrolment struct {
+	Secret string
+	URI    string
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateRecoveryCode(t *testing.T) {
+	re := regexp.MustCompile(`^[a-z2-9]{4}-[a-z2-9]{4}$`)
+	seen := map[string]bool{}
+	for range 50 {
+		code, err := generateRecoveryCode()
+		require.NoError(t, err)
+		assert.Regexp(t, re, code)
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	assert.Equal(t, "k7m2x9qp", normalizeRecoveryCode(" K7M2-X9QP "))
+	assert.Equal(t, "k7m2x9qp", normalizeRecoveryCode("k7m2 x9qp"))
+	assert.Equal(t, hashToken(normalizeRecoveryCode("k7m2-x9qp")), hashToken(normalizeRecoveryCode("K7M2X9QP")))
+}
@@ -33,3 +33,48 @@ type LinkClick struct {
 	UTMMedium   sql.NullString
 	UTMCampaign sql.NullString
 }
+
+type LinkClickDay struct {
+	Day            string
+	Clicks         int64
+	UniqueVisitors int64
+	// Percent is the bar width relative to the busiest day in the range.
+	Percent int
+}
+
+type LinkClickBreakdown struct {
+	Label   string
+	Clicks  int64
+	Percent int
+}
+
+type LinkAttribution struct {
+	Carts   int64
+	Orders  int64
+	Revenue string
+}
+
+type LinkAnalytics struct {
+	Link            TrackedLink
+	Days            int
+	IncludeFiltered bool
+	Clicks          int64
+	UniqueVisitors  int64
+	BotClicks       int64
+	DuplicateClicks int64
+	PerDay          []LinkClickDay
+	Devices         []LinkClickBreakdown
+	Referrers       []LinkClickBreakdown
+	FirstTouch      LinkAttribution
+	LastTouch       LinkAttribution
+}
+
+type CampaignAnalytics struct {
+	Source         enums.TrackedLinkSource
+	Campaign       string
+	Links          int64
+	Clicks         int64
+	UniqueVisitors int64
+	FirstTouch     LinkAttribution
+	LastTouch      LinkAttribution
+}
@@ -3,8 +3,10 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/csv"
 	"errors"
 	"fmt"
+	"time"
 
 	"cchoice/internal/constants"
 	"cchoice/internal/database"
@@ -199,6 +201,7 @@ func (s *TrackedLinkService) RecordClick(
 	slugs string,
 	referrer string,
 	userAgent string,
+	ip string,
 	ipHash string,
 	device string,
 	utmSource string,
@@ -213,6 +216,20 @@ func (s *TrackedLinkService) RecordClick(
 		return errs.ErrNotFound
 	}
 
+	isBot := isBotClick(userAgent, ip)
+	isDuplicate := false
+	if ipHash != "" {
+		recent, err := s.dbRO.GetQueries().CountRecentLinkClicksByIPHash(ctx, queries.CountRecentLinkClicksByIPHashParams{
+			LinkID: link.ID,
+			IpHash: sql.NullString{String: ipHash, Valid: true},
+			Since:  time.Now().UTC().Add(-constants.TrackedLinkDuplicateWindow).Format(constants.DateTimeLayoutISO),
+		})
+		if err != nil {
+			return err
+		}
+		isDuplicate = recent > 0
+	}
+
 	err = s.dbRW.GetQueries().CreateLinkClick(ctx, queries.CreateLinkClickParams{
 		LinkID:      link.ID,
 		Referrer:    sql.NullString{String: referrer, Valid: referrer != ""},
@@ -222,6 +239,8 @@ func (s *TrackedLinkService) RecordClick(
 		UtmSource:   sql.NullString{String: utmSource, Valid: utmSource != ""},
 		UtmMedium:   sql.NullString{String: utmMedium, Valid: utmMedium != ""},
 		UtmCampaign: sql.NullString{String: utmCampaign, Valid: utmCampaign != ""},
+		IsBot:       isBot,
+		IsDuplicate: isDuplicate,
 	})
 	if err != nil {
 		return err
@@ -230,8 +249,12 @@ func (s *TrackedLinkService) RecordClick(
 	return nil
 }
 
-func (s *TrackedLinkService) GetClickCount(ctx context.Context, linkID string) (int64, error) {
-	count, err := s.dbRO.GetQueries().CountLinkClicksByLinkID(ctx, linkID)
+// GetClickCount leaves out bot and duplicate clicks unless includeFiltered.
+func (s *TrackedLinkService) GetClickCount(ctx context.Context, linkID string, includeFiltered bool) (int64, error) {
+	count, err := s.dbRO.GetQueries().CountLinkClicksByLinkID(ctx, queries.CountLinkClicksByLinkIDParams{
+		LinkID:          linkID,
+		IncludeFiltered: includeFiltered,
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -239,6 +262,240 @@ func (s *TrackedLinkService) GetClickCount(ctx context.Context, linkID string) (
 	return count, nil
 }
 
+// AttributeCheckout ties a cart to the tracked links in the visitor's
+// cookies. The first touch is only written when the cart is first seen.
+func (s *TrackedLinkService) AttributeCheckout(ctx context.Context, checkoutID int64, firstLinkID string, lastLinkID string) error {
+	if lastLinkID == "" {
+		return nil
+	}
+	if firstLinkID == "" {
+		firstLinkID = lastLinkID
+	}
+	return s.dbRW.GetQueries().UpsertCheckoutLinkAttribution(ctx, queries.UpsertCheckoutLinkAttributionParams{
+		CheckoutID:  checkoutID,
+		FirstLinkID: firstLinkID,
+		LastLinkID:  lastLinkID,
+	})
+}
+
+func (s *TrackedLinkService) GetLinkAnalytics(ctx context.Context, linkID string, days int, includeFiltered bool) (*LinkAnalytics, error) {
+	link, err := s.GetTrackedLinkByID(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	q := s.dbRO.GetQueries()
+	totals, err := q.GetLinkClickTotals(ctx, queries.GetLinkClickTotalsParams{
+		LinkID:          linkID,
+		IncludeFiltered: includeFiltered,
+	})
+	if err != nil {
+		return nil, err
+	}
+	filtered, err := q.GetLinkFilteredClickTotals(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+	perDay, err := q.GetLinkClicksPerDay(ctx, queries.GetLinkClicksPerDayParams{
+		LinkID:          linkID,
+		Since:           start.Format(constants.DateTimeLayoutISO),
+		IncludeFiltered: includeFiltered,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := q.GetLinkClicksByDevice(ctx, queries.GetLinkClicksByDeviceParams{
+		LinkID:          linkID,
+		IncludeFiltered: includeFiltered,
+	})
+	if err != nil {
+		return nil, err
+	}
+	deviceBreakdown := make([]LinkClickBreakdown, 0, len(devices))
+	for _, d := range devices {
+		deviceBreakdown = append(deviceBreakdown, LinkClickBreakdown{Label: d.Label, Clicks: d.Clicks})
+	}
+
+	referrers, err := q.GetLinkClicksByReferrer(ctx, queries.GetLinkClicksByReferrerParams{
+		LinkID:          linkID,
+		Limit:           constants.TrackedLinkTopReferrers,
+		IncludeFiltered: includeFiltered,
+	})
+	if err != nil {
+		return nil, err
+	}
+	referrerBreakdown := make([]LinkClickBreakdown, 0, len(referrers))
+	for _, r := range referrers {
+		referrerBreakdown = append(referrerBreakdown, LinkClickBreakdown{Label: r.Label, Clicks: r.Clicks})
+	}
+
+	attribution, err := q.GetLinkAttributionTotals(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LinkAnalytics{
+		Link:            *link,
+		Days:            days,
+		IncludeFiltered: includeFiltered,
+		Clicks:          totals.Clicks,
+		UniqueVisitors:  totals.UniqueVisitors,
+		BotClicks:       filtered.Bots,
+		DuplicateClicks: filtered.Duplicates,
+		PerDay:          fillLinkClickDays(perDay, start, days),
+		Devices:         withBreakdownPercents(deviceBreakdown, totals.Clicks),
+		Referrers:       withBreakdownPercents(referrerBreakdown, totals.Clicks),
+		FirstTouch: LinkAttribution{
+			Carts:   attribution.FirstTouchCarts,
+			Orders:  attribution.FirstTouchOrders,
+			Revenue: utils.NewMoney(attribution.FirstTouchRevenue, constants.PHP).Display(),
+		},
+		LastTouch: LinkAttribution{
+			Carts:   attribution.LastTouchCarts,
+			Orders:  attribution.LastTouchOrders,
+			Revenue: utils.NewMoney(attribution.LastTouchRevenue, constants.PHP).Display(),
+		},
+	}, nil
+}
+
+// GetCampaignAnalytics rolls clicks and attributed revenue up by source and
+// campaign so paid social spend can be compared across links.
+func (s *TrackedLinkService) GetCampaignAnalytics(ctx context.Context, includeFiltered bool) ([]CampaignAnalytics, error) {
+	q := s.dbRO.GetQueries()
+	clicks, err := q.GetCampaignClickTotals(ctx, includeFiltered)
+	if err != nil {
+		return nil, err
+	}
+	firstTouch, err := q.GetCampaignFirstTouchTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lastTouch, err := q.GetCampaignLastTouchTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type campaignKey struct{ source, campaign string }
+	first := make(map[campaignKey]queries.GetCampaignFirstTouchTotalsRow, len(firstTouch))
+	for _, row := range firstTouch {
+		first[campaignKey{row.Source, row.Campaign}] = row
+	}
+	last := make(map[campaignKey]queries.GetCampaignLastTouchTotalsRow, len(lastTouch))
+	for _, row := range lastTouch {
+		last[campaignKey{row.Source, row.Campaign}] = row
+	}
+
+	res := make([]CampaignAnalytics, 0, len(clicks))
+	for _, row := range clicks {
+		key := campaignKey{row.Source, row.Campaign}
+		f, l := first[key], last[key]
+		res = append(res, CampaignAnalytics{
+			Source:         enums.ParseTrackedLinkSourceToEnum(row.Source),
+			Campaign:       row.Campaign,
+			Links:          row.Links,
+			Clicks:         row.Clicks,
+			UniqueVisitors: row.UniqueVisitors,
+			FirstTouch: LinkAttribution{
+				Carts:   f.Carts,
+				Orders:  f.Orders,
+				Revenue: utils.NewMoney(f.Revenue, constants.PHP).Display(),
+			},
+			LastTouch: LinkAttribution{
+				Carts:   l.Carts,
+				Orders:  l.Orders,
+				Revenue: utils.NewMoney(l.Revenue, constants.PHP).Display(),
+			},
+		})
+	}
+	return res, nil
+}
+
+// ExportClicksCSV writes the raw clicks of a link, newest first.
+func (s *TrackedLinkService) ExportClicksCSV(ctx context.Context, writer *csv.Writer, linkID string, includeFiltered bool) error {
+	clicks, err := s.dbRO.GetQueries().GetLinkClicksByLinkID(ctx, queries.GetLinkClicksByLinkIDParams{
+		LinkID:          linkID,
+		IncludeFiltered: includeFiltered,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writer.Write([]string{
+		"Clicked At",
+		"Referrer",
+		"User Agent",
+		"IP Hash",
+		"Device",
+		"UTM Source",
+		"UTM Medium",
+		"UTM Campaign",
+		"Bot",
+		"Duplicate",
+	}); err != nil {
+		return err
+	}
+	for _, c := range clicks {
+		if err := writer.Write([]string{
+			c.ClickedAt,
+			c.Referrer.String,
+			c.UserAgent.String,
+			c.IpHash.String,
+			c.Device.String,
+			c.UtmSource.String,
+			c.UtmMedium.String,
+			c.UtmCampaign.String,
+			utils.BoolToString(c.IsBot),
+			utils.BoolToString(c.IsDuplicate),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillLinkClickDays returns one entry per day starting at start, with zero
+// clicks for days that have no rows.
+func fillLinkClickDays(rows []queries.GetLinkClicksPerDayRow, start time.Time, days int) []LinkClickDay {
+	byDay := make(map[string]queries.GetLinkClicksPerDayRow, len(rows))
+	var busiest int64
+	for _, row := range rows {
+		byDay[row.Day] = row
+		busiest = max(busiest, row.Clicks)
+	}
+
+	res := make([]LinkClickDay, 0, days)
+	for i := range days {
+		day := start.AddDate(0, 0, i).Format(constants.DateLayoutISO)
+		row := byDay[day]
+		item := LinkClickDay{
+			Day:            day,
+			Clicks:         row.Clicks,
+			UniqueVisitors: row.UniqueVisitors,
+		}
+		if busiest > 0 {
+			item.Percent = int(row.Clicks * 100 / busiest)
+		}
+		res = append(res, item)
+	}
+	return res
+}
+
+func withBreakdownPercents(items []LinkClickBreakdown, total int64) []LinkClickBreakdown {
+	if total <= 0 {
+		return items
+	}
+	for i := range items {
+		items[i].Percent = int(items[i].Clicks * 100 / total)
+	}
+	return items
+}
+
 func (s *TrackedLinkService) mapRowToTrackedLink(row queries.TblTrackedLink) *TrackedLink {
 	return &TrackedLink{
 		ID:             row.ID,
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"cchoice/internal/database/queries"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFillLinkClickDays(t *testing.T) {
+	start := time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC)
+	rows := []queries.GetLinkClicksPerDayRow{
+		{Day: "2026-03-30", Clicks: 4, UniqueVisitors: 3},
+		{Day: "2026-04-01", Clicks: 2, UniqueVisitors: 2},
+	}
+
+	days := fillLinkClickDays(rows, start, 4)
+	require.Len(t, days, 4)
+	assert.Equal(t, []string{"2026-03-30", "2026-03-31", "2026-04-01", "2026-04-02"},
+		[]string{days[0].Day, days[1].Day, days[2].Day, days[3].Day})
+	assert.Equal(t, LinkClickDay{Day: "2026-03-30", Clicks: 4, UniqueVisitors: 3, Percent: 100}, days[0])
+	assert.Equal(t, LinkClickDay{Day: "2026-03-31"}, days[1], "missing days are zero")
+	assert.Equal(t, 50, days[2].Percent)
+}
+
+func TestFillLinkClickDays_NoClicks(t *testing.T) {
+	days := fillLinkClickDays(nil, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 3)
+	require.Len(t, days, 3)
+	for _, day := range days {
+		assert.Zero(t, day.Percent)
+	}
+}
+
+func TestWithBreakdownPercents(t *testing.T) {
+	items := withBreakdownPercents([]LinkClickBreakdown{
+		{Label: "Mobile", Clicks: 3},
+		{Label: "Desktop", Clicks: 1},
+	}, 4)
+	assert.Equal(t, 75, items[0].Percent)
+	assert.Equal(t, 25, items[1].Percent)
+
+	empty := withBreakdownPercents([]LinkClickBreakdown{{Label: "Mobile"}}, 0)
+	assert.Zero(t, empty[0].Percent)
+}
@@ -0,0 +1,371 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cchoice/internal/conf"
+	"cchoice/internal/constants"
+	"cchoice/internal/database"
+	"cchoice/internal/database/queries"
+	"cchoice/internal/encode"
+	"cchoice/internal/enums"
+	"cchoice/internal/errs"
+	"cchoice/internal/jobs"
+	"cchoice/internal/logs"
+	"cchoice/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+type WishlistService struct {
+	encoder     encode.IEncode
+	dbRO        database.IService
+	dbRW        database.IService
+	emailRunner *jobs.EmailJobRunner
+}
+
+func NewWishlistService(
+	encoder encode.IEncode,
+	dbRO database.IService,
+	dbRW database.IService,
+	emailRunner *jobs.EmailJobRunner,
+) *WishlistService {
+	if shouldSendWishlistEmails() && emailRunner == nil {
+		panic("emailRunner is required")
+	}
+	return &WishlistService{
+		encoder:     encoder,
+		dbRO:        dbRO,
+		dbRW:        dbRW,
+		emailRunner: emailRunner,
+	}
+}
+
+func shouldSendWishlistEmails() bool {
+	return conf.Conf().IsProd() || conf.Conf().Test.LocalWishlistEmail
+}
+
+func (s *WishlistService) decodeIDs(customerID, productID string) (int64, int64, error) {
+	decodedCustomerID := s.encoder.Decode(customerID)
+	decodedProductID := s.encoder.Decode(productID)
+	if decodedCustomerID == encode.INVALID || decodedProductID == encode.INVALID {
+		return 0, 0, errs.ErrDecode
+	}
+	return decodedCustomerID, decodedProductID, nil
+}
+
+func (s *WishlistService) Add(ctx context.Context, customerID, productID string) error {
+	decodedCustomerID, decodedProductID, err := s.decodeIDs(customerID, productID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.dbRW.GetQueries().AddWishlistItem(ctx, queries.AddWishlistItemParams{
+		CustomerID: decodedCustomerID,
+		ProductID:  decodedProductID,
+	}); err != nil {
+		return errors.Join(errs.ErrWishlist, err)
+	}
+	return nil
+}
+
+func (s *WishlistService) Remove(ctx context.Context, customerID, productID string) error {
+	decodedCustomerID, decodedProductID, err := s.decodeIDs(customerID, productID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.dbRW.GetQueries().RemoveWishlistItem(ctx, queries.RemoveWishlistItemParams{
+		CustomerID: decodedCustomerID,
+		ProductID:  decodedProductID,
+	}); err != nil {
+		return errors.Join(errs.ErrWishlist, err)
+	}
+	return nil
+}
+
+func (s *WishlistService) IsWishlisted(ctx context.Context, customerID, productID string) (bool, error) {
+	if customerID == "" {
+		return false, nil
+	}
+
+	decodedCustomerID, decodedProductID, err := s.decodeIDs(customerID, productID)
+	if err != nil {
+		return false, err
+	}
+
+	exists, err := s.dbRO.GetQueries().IsProductWishlisted(ctx, queries.IsProductWishlistedParams{
+		CustomerID: decodedCustomerID,
+		ProductID:  decodedProductID,
+	})
+	if err != nil {
+		return false, errors.Join(errs.ErrWishlist, err)
+	}
+	return exists, nil
+}
+
+func (s *WishlistService) GetForCustomer(ctx context.Context, customerID string) ([]WishlistItem, error) {
+	decodedCustomerID := s.encoder.Decode(customerID)
+	if decodedCustomerID == encode.INVALID {
+		return nil, errs.ErrDecode
+	}
+
+	rows, err := s.dbRO.GetQueries().GetWishlistByCustomerID(ctx, decodedCustomerID)
+	if err != nil {
+		return nil, errors.Join(errs.ErrWishlist, err)
+	}
+
+	items := make([]WishlistItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, WishlistItem{
+			ProductID:    s.encoder.Encode(row.ProductID),
+			Name:         row.Name,
+			Serial:       row.Serial,
+			Slug:         row.Slug,
+			PriceDisplay: utils.NewMoney(row.UnitPriceWithVat, row.UnitPriceWithVatCurrency).Display(),
+			ImageURL:     row.CdnUrlThumbnail,
+			InStock:      row.Stocks > 0,
+			AddedAt:      row.CreatedAt.Format(constants.DateLayoutISO),
+		})
+	}
+	return items, nil
+}
+
+// Subscribe registers an anonymous back-in-stock alert. The subscription stays
+// PENDING until the visitor clicks the emailed confirmation link.
+func (s *WishlistService) Subscribe(ctx context.Context, productID string, email string) error {
+	const logtag = "[WishlistService Subscribe]"
+
+	decodedProductID := s.encoder.Decode(productID)
+	if decodedProductID == encode.INVALID {
+		return errs.ErrDecode
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	inventory, err := s.dbRO.GetQueries().GetProductInventoryByProductID(ctx, decodedProductID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errors.Join(errs.ErrStockSubscription, err)
+	}
+	if err == nil && inventory.Stocks > 0 {
+		return errs.ErrStockSubscriptionInStock
+	}
+
+	existing, err := s.dbRO.GetQueries().GetStockSubscriptionByProductAndEmail(ctx, queries.GetStockSubscriptionByProductAndEmailParams{
+		ProductID: decodedProductID,
+		Email:     email,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errors.Join(errs.ErrStockSubscription, err)
+	}
+	if err == nil {
+		switch existing.Status {
+		case "CONFIRMED":
+			return errs.ErrStockSubscriptionAlreadyConfirmed
+		case "PENDING":
+			if time.Since(existing.UpdatedAt) < constants.StockSubscriptionRateLimitMins*time.Minute {
+				return errs.ErrStockSubscriptionRateLimited
+			}
+		}
+	}
+
+	rawToken, err := generateResetToken()
+	if err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		return errors.Join(errs.ErrStockSubscription, err)
+	}
+
+	if err := s.dbRW.GetQueries().UpsertStockSubscription(ctx, queries.UpsertStockSubscriptionParams{
+		ProductID: decodedProductID,
+		Email:     email,
+		TokenHash: hashToken(rawToken),
+	}); err != nil {
+		return errors.Join(errs.ErrStockSubscription, err)
+	}
+
+	confirmLink := fmt.Sprintf("%s?token=%s", utils.FullURL("/stock-alerts/confirm"), rawToken)
+	if !shouldSendWishlistEmails() {
+		logs.LogCtx(ctx).Info(logtag, zap.String("confirm_link", confirmLink), zap.String("recipient", email))
+		return nil
+	}
+
+	if err := s.emailRunner.QueueEmailJob(ctx, jobs.EmailJobParams{
+		ProductID:    &decodedProductID,
+		Recipient:    email,
+		Subject:      "Confirm Your Stock Alert - C-Choice",
+		TemplateName: enums.EMAIL_TEMPLATE_STOCK_SUBSCRIPTION_CONFIRMATION,
+		OTPCode:      confirmLink,
+	}); err != nil {
+		logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+		return errs.ErrJobsCreateFailed
+	}
+	return nil
+}
+
+// ConfirmSubscription activates a pending stock alert and returns the product
+// slug so the caller can send the visitor back to the product page.
+func (s *WishlistService) ConfirmSubscription(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		return "", errs.ErrStockSubscriptionInvalidToken
+	}
+
+	productID, err := s.dbRW.GetQueries().ConfirmStockSubscription(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errs.ErrStockSubscriptionInvalidToken
+		}
+		return "", errors.Join(errs.ErrStockSubscription, err)
+	}
+
+	product, err := s.dbRO.GetQueries().GetProductForNotificationEmail(ctx, productID)
+	if err != nil {
+		return "", errors.Join(errs.ErrStockSubscription, err)
+	}
+	return product.Slug, nil
+}
+
+func (s *WishlistService) NotifyBackInStock(ctx context.Context, productID int64) error {
+	const logtag = "[WishlistService NotifyBackInStock]"
+
+	recipients, err := s.dbRO.GetQueries().GetWishlistCustomerEmailsByProductID(ctx, productID)
+	if err != nil {
+		return errors.Join(errs.ErrWishlist, err)
+	}
+
+	subscriptions, err := s.dbRO.GetQueries().GetConfirmedStockSubscriptionsByProductID(ctx, productID)
+	if err != nil {
+		return errors.Join(errs.ErrStockSubscription, err)
+	}
+	for _, sub := range subscriptions {
+		recipients = append(recipients, sub.Email)
+	}
+
+	if err := s.queueProductNotifications(
+		ctx,
+		productID,
+		recipients,
+		"Back in Stock - C-Choice",
+		enums.EMAIL_TEMPLATE_PRODUCT_BACK_IN_STOCK,
+	); err != nil {
+		return err
+	}
+
+	for _, sub := range subscriptions {
+		if err := s.dbRW.GetQueries().MarkStockSubscriptionNotified(ctx, sub.ID); err != nil {
+			logs.LogCtx(ctx).Warn(logtag, zap.Int64("subscription_id", sub.ID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (s *WishlistService) NotifyOnSale(ctx context.Context, productID int64) error {
+	recipients, err := s.dbRO.GetQueries().GetWishlistCustomerEmailsByProductID(ctx, productID)
+	if err != nil {
+		return errors.Join(errs.ErrWishlist, err)
+	}
+
+	return s.queueProductNotifications(
+		ctx,
+		productID,
+		recipients,
+		"On Sale Now - C-Choice",
+		enums.EMAIL_TEMPLATE_PRODUCT_ON_SALE,
+	)
+}
+
+// NotifyStartedSales tells wishlist customers about every sale that has started
+// and not been announced yet. Sales scheduled for later are left for the sale
+// campaign scheduler, which calls this on each tick.
+func (s *WishlistService) NotifyStartedSales(ctx context.Context) error {
+	const logtag = "[WishlistService NotifyStartedSales]"
+
+	sales, err := s.dbRO.GetQueries().GetProductSalesDueForNotification(ctx)
+	if err != nil {
+		return errors.Join(errs.ErrWishlist, err)
+	}
+
+	for _, sale := range sales {
+		claimed, err := s.dbRW.GetQueries().ClaimProductSaleNotification(ctx, sale.ID)
+		if err != nil {
+			return errors.Join(errs.ErrWishlist, err)
+		}
+		if claimed == 0 {
+			continue
+		}
+		if err := s.NotifyOnSale(ctx, sale.ProductID); err != nil {
+			logs.LogCtx(ctx).Warn(logtag, zap.Int64("product_id", sale.ProductID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (s *WishlistService) queueProductNotifications(
+	ctx context.Context,
+	productID int64,
+	recipients []string,
+	subject string,
+	templateName enums.EmailTemplateName,
+) error {
+	const logtag = "[WishlistService queueProductNotifications]"
+
+	recipients = uniqueEmails(recipients)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	if !shouldSendWishlistEmails() {
+		logs.LogCtx(ctx).Info(
+			logtag,
+			zap.String("result", "skipped (non-prod)"),
+			zap.Int64("product_id", productID),
+			zap.Stringer("template", templateName),
+			zap.Int("recipient_count", len(recipients)),
+		)
+		return nil
+	}
+
+	for _, recipient := range recipients {
+		productIDCopy := productID
+		if err := s.emailRunner.QueueEmailJob(ctx, jobs.EmailJobParams{
+			ProductID:    &productIDCopy,
+			Recipient:    recipient,
+			Subject:      subject,
+			TemplateName: templateName,
+		}); err != nil {
+			logs.LogCtx(ctx).Error(logtag, zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+func uniqueEmails(emails []string) []string {
+	seen := make(map[string]struct{}, len(emails))
+	result := make([]string, 0, len(emails))
+	for _, email := range emails {
+		key := strings.ToLower(strings.TrimSpace(email))
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, key)
+	}
+	return result
+}
+
+func (s *WishlistService) ID() string {
+	return "Wishlist"
+}
+
+func (s *WishlistService) Log() {
+	logs.Log().Info("[WishlistService] Loaded")
+}
+
+var _ IService = (*WishlistService)(nil)
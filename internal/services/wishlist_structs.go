@@ -0,0 +1,12 @@
+package services
+
+type WishlistItem struct {
+	ProductID    string
+	Name         string
+	Serial       string
+	Slug         string
+	PriceDisplay string
+	ImageURL     string
+	InStock      bool
+	AddedAt      string
+}
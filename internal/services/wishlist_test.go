@@ -0,0 +1,12 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueEmails(t *testing.T) {
+	got := uniqueEmails([]string{"a@example.com", " A@Example.com ", "", "b@example.com", "a@example.com"})
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, got)
+}
@@ -0,0 +1,131 @@
+package shift
+
+import (
+	"fmt"
+	"time"
+
+	"cchoice/internal/constants"
+)
+
+// Shift is the scheduled time in and out of a single day in
+// constants.TimeLayoutHHMM. A rest day has neither. A suspended day is also a
+// rest day, one the staff is not allowed to work.
+type Shift struct {
+	TimeIn    string
+	TimeOut   string
+	RestDay   bool
+	Suspended bool
+}
+
+func (s Shift) IsZero() bool {
+	return s == Shift{}
+}
+
+// String formats the shift the way reports show it, e.g. "08:00 - 17:00".
+func (s Shift) String() string {
+	switch {
+	case s.Suspended:
+		return "Suspended"
+	case s.RestDay:
+		return "Rest day"
+	case s.TimeIn == "" && s.TimeOut == "":
+		return "-"
+	default:
+		return fmt.Sprintf("%s - %s", s.TimeIn, s.TimeOut)
+	}
+}
+
+// Validate checks that a working shift has both times and ends after it
+// starts. Overnight shifts are not supported since attendance is per date.
+func (s Shift) Validate() error {
+	if s.RestDay {
+		return nil
+	}
+	in, err := time.Parse(constants.TimeLayoutHHMM, s.TimeIn)
+	if err != nil {
+		return err
+	}
+	out, err := time.Parse(constants.TimeLayoutHHMM, s.TimeOut)
+	if err != nil {
+		return err
+	}
+	if !out.After(in) {
+		return fmt.Errorf("time out %s must be after time in %s", s.TimeOut, s.TimeIn)
+	}
+	return nil
+}
+
+// Slot identifies a day of a rotating weekly template. Week is zero-based and
+// below the rotation's CycleWeeks.
+type Slot struct {
+	Week    int
+	Weekday time.Weekday
+}
+
+// Schedule resolves the shift of a staff on any date. A date-specific override
+// wins, then the weekly template for the week of the rotation the date falls
+// in, then Default, which is the staff's single time in and out pair. Once a
+// template has any day, the days it leaves out are rest days.
+type Schedule struct {
+	Default    Shift
+	CycleWeeks int
+	Anchor     time.Time
+	Template   map[Slot]Shift
+	Overrides  map[string]Shift
+}
+
+// WeekOf returns the zero-based week of the rotation that a date falls in.
+// Weeks start on the Monday on or before the anchor.
+func (s Schedule) WeekOf(date time.Time) int {
+	if s.CycleWeeks <= 1 {
+		return 0
+	}
+	start := MondayOf(s.Anchor)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	weeks := int(day.Sub(start).Hours()/24) / 7
+	if day.Before(start) {
+		weeks = -((int(start.Sub(day).Hours()/24) + 6) / 7)
+	}
+	week := weeks % s.CycleWeeks
+	if week < 0 {
+		week += s.CycleWeeks
+	}
+	return week
+}
+
+// For returns the shift on a date in constants.DateLayoutISO. An unparsable
+// date gets the default shift.
+func (s Schedule) For(date string) Shift {
+	if override, ok := s.Overrides[date]; ok {
+		return override
+	}
+	if len(s.Template) == 0 {
+		return s.Default
+	}
+	day, err := time.Parse(constants.DateLayoutISO, date)
+	if err != nil {
+		return s.Default
+	}
+	if sh, ok := s.Template[Slot{Week: s.WeekOf(day), Weekday: day.Weekday()}]; ok {
+		return sh
+	}
+	return Shift{RestDay: true}
+}
+
+// MondayOf returns the Monday on or before the given date at midnight UTC.
+func MondayOf(date time.Time) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// Weekdays lists the days of a template week starting on Monday.
+var Weekdays = []time.Weekday{
+	time.Monday,
+	time.Tuesday,
+	time.Wednesday,
+	time.Thursday,
+	time.Friday,
+	time.Saturday,
+	time.Sunday,
+}
@@ -0,0 +1,80 @@
+package shift
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShiftValidate(t *testing.T) {
+	assert.NoError(t, Shift{TimeIn: "08:00", TimeOut: "17:00"}.Validate())
+	assert.NoError(t, Shift{RestDay: true}.Validate())
+	assert.Error(t, Shift{TimeIn: "8am", TimeOut: "17:00"}.Validate())
+	assert.Error(t, Shift{TimeIn: "17:00", TimeOut: "08:00"}.Validate())
+	assert.Error(t, Shift{TimeIn: "08:00"}.Validate())
+}
+
+func TestShiftString(t *testing.T) {
+	assert.Equal(t, "08:00 - 12:00", Shift{TimeIn: "08:00", TimeOut: "12:00"}.String())
+	assert.Equal(t, "Rest day", Shift{RestDay: true}.String())
+	assert.Equal(t, "Suspended", Shift{RestDay: true, Suspended: true}.String())
+	assert.Equal(t, "-", Shift{}.String())
+}
+
+func TestMondayOf(t *testing.T) {
+	assert.Equal(t, "2026-07-13", MondayOf(time.Date(2026, 7, 13, 0, 0, 0, 0, time.UTC)).Format("2006-01-02"))
+	assert.Equal(t, "2026-07-13", MondayOf(time.Date(2026, 7, 19, 23, 0, 0, 0, time.UTC)).Format("2006-01-02"))
+	assert.Equal(t, "2026-07-20", MondayOf(time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)).Format("2006-01-02"))
+}
+
+func TestScheduleFor(t *testing.T) {
+	regular := Shift{TimeIn: "08:00", TimeOut: "17:00"}
+	halfDay := Shift{TimeIn: "08:00", TimeOut: "12:00"}
+
+	t.Run("falls back to the default pair without a template", func(t *testing.T) {
+		s := Schedule{Default: regular}
+		assert.Equal(t, regular, s.For("2026-07-19"))
+	})
+
+	t.Run("weekly template with saturday half-day and sunday rest", func(t *testing.T) {
+		s := Schedule{Default: regular, CycleWeeks: 1, Template: map[Slot]Shift{}}
+		for _, wd := range Weekdays[:5] {
+			s.Template[Slot{Weekday: wd}] = regular
+		}
+		s.Template[Slot{Weekday: time.Saturday}] = halfDay
+
+		assert.Equal(t, regular, s.For("2026-07-15"))
+		assert.Equal(t, halfDay, s.For("2026-07-18"))
+		assert.True(t, s.For("2026-07-19").RestDay)
+	})
+
+	t.Run("rotation alternates weeks from the anchor", func(t *testing.T) {
+		night := Shift{TimeIn: "13:00", TimeOut: "22:00"}
+		s := Schedule{
+			Default:    regular,
+			CycleWeeks: 2,
+			Anchor:     time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC),
+			Template: map[Slot]Shift{
+				{Week: 0, Weekday: time.Monday}: regular,
+				{Week: 1, Weekday: time.Monday}: night,
+			},
+		}
+		assert.Equal(t, regular, s.For("2026-07-13"))
+		assert.Equal(t, night, s.For("2026-07-20"))
+		assert.Equal(t, regular, s.For("2026-07-27"))
+		assert.Equal(t, night, s.For("2026-07-06"))
+		assert.Equal(t, regular, s.For("2026-06-29"))
+		assert.True(t, s.For("2026-07-21").RestDay)
+	})
+
+	t.Run("override wins over the template", func(t *testing.T) {
+		s := Schedule{
+			Default:   regular,
+			Template:  map[Slot]Shift{{Weekday: time.Sunday}: {RestDay: true}},
+			Overrides: map[string]Shift{"2026-07-19": halfDay},
+		}
+		assert.Equal(t, halfDay, s.For("2026-07-19"))
+		assert.True(t, s.For("2026-07-26").RestDay)
+	})
+}
@@ -44,11 +44,7 @@ type Config struct {
 	APIToken   string
 }
 
-type ObjectInfo struct {
-	LastModified *time.Time
-	Key          string
-	Size         int64
-}
+type ObjectInfo = storage.ObjectInfo
 
 type HeadObjectOutput struct {
 	ContentLength *int64
@@ -422,4 +418,7 @@ func (c *Client) PresignedGetObject(ctx context.Context, key string, expiry time
 	return urlStr, nil
 }
 
-var _ storage.IObjectStorage = (*Client)(nil)
+var (
+	_ storage.IObjectStorage = (*Client)(nil)
+	_ storage.IObjectLister  = (*Client)(nil)
+)
@@ -0,0 +1,207 @@
+package local
+
+import (
+	"bytes"
+	"cchoice/internal/errs"
+	"cchoice/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ObjectStorage keeps objects as plain files under a directory. It is meant for
+// backups kept on the same machine and as a bucket stand-in when testing.
+type ObjectStorage struct {
+	dir string
+}
+
+func NewObjectStorage(dir string) (*ObjectStorage, error) {
+	if dir == "" {
+		return nil, errs.ErrPathEmpty
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.Join(errs.ErrFS, err)
+	}
+	if err := os.MkdirAll(abs, 0o750); err != nil {
+		return nil, errors.Join(errs.ErrFS, err)
+	}
+	return &ObjectStorage{dir: abs}, nil
+}
+
+func (o *ObjectStorage) normalizeKey(key string) (string, error) {
+	key = strings.TrimPrefix(path.Clean("/"+key), "/")
+	if key == "" || key == "." {
+		return "", errs.ErrPathEmpty
+	}
+	return key, nil
+}
+
+func (o *ObjectStorage) filePath(key string) (string, error) {
+	normalizedKey, err := o.normalizeKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(o.dir, filepath.FromSlash(normalizedKey)), nil
+}
+
+func (o *ObjectStorage) ProviderEnum() storage.StorageProvider {
+	return storage.STORAGE_PROVIDER_LOCAL
+}
+
+func (o *ObjectStorage) GetPublicURL(key string) string {
+	p, err := o.filePath(key)
+	if err != nil {
+		return ""
+	}
+	return "file://" + filepath.ToSlash(p)
+}
+
+func (o *ObjectStorage) PresignedGetObject(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return o.GetPublicURL(key), nil
+}
+
+// PutObject writes into a temporary file next to the target and renames it, so
+// a reader never sees a partially written object.
+func (o *ObjectStorage) PutObject(ctx context.Context, key string, body io.Reader, contentType string) error {
+	p, err := o.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return errors.Join(errs.ErrFS, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), "."+filepath.Base(p)+".*")
+	if err != nil {
+		return errors.Join(errs.ErrFS, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return errors.Join(errs.ErrFS, fmt.Errorf("failed to put object '%s': %w", key, err))
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Join(errs.ErrFS, err)
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return errors.Join(errs.ErrFS, err)
+	}
+	return nil
+}
+
+func (o *ObjectStorage) PutObjectFromBytes(ctx context.Context, key string, data []byte, contentType string) error {
+	return o.PutObject(ctx, key, bytes.NewReader(data), contentType)
+}
+
+func (o *ObjectStorage) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := o.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object '%s': %w", key, err)
+	}
+	return f, nil
+}
+
+func (o *ObjectStorage) GetObjectBytes(ctx context.Context, key string) ([]byte, error) {
+	body, err := o.GetObject(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+func (o *ObjectStorage) DeleteObject(ctx context.Context, key string) error {
+	p, err := o.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object '%s': %w", key, err)
+	}
+	return nil
+}
+
+func (o *ObjectStorage) ObjectExists(ctx context.Context, key string) (bool, error) {
+	p, err := o.filePath(key)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(p); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, errors.Join(errs.ErrFS, err)
+	}
+	return true, nil
+}
+
+func (o *ObjectStorage) HeadBucket(ctx context.Context) error {
+	info, err := os.Stat(o.dir)
+	if err != nil {
+		return errors.Join(errs.ErrFS, err)
+	}
+	if !info.IsDir() {
+		return errs.ErrNotADirectory
+	}
+	return nil
+}
+
+// ListObjects returns the objects directly under prefix, like a
+// non-recursive bucket listing. Keys use forward slashes.
+func (o *ObjectStorage) ListObjects(ctx context.Context, prefix string, maxKeys int32) ([]storage.ObjectInfo, error) {
+	prefix = strings.TrimPrefix(prefix, "/")
+	// The trailing "x" keeps "a/b/" and "a/b/c" both listing inside "a/b".
+	dirKey := strings.TrimPrefix(path.Dir(path.Clean("/"+prefix+"x")), "/")
+	dir := filepath.Join(o.dir, filepath.FromSlash(dirKey))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Join(errs.ErrFS, err)
+	}
+
+	objects := make([]storage.ObjectInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		key := path.Join(dirKey, entry.Name())
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, errors.Join(errs.ErrFS, err)
+		}
+		modTime := info.ModTime()
+		objects = append(objects, storage.ObjectInfo{
+			Key:          key,
+			Size:         info.Size(),
+			LastModified: &modTime,
+		})
+		if maxKeys > 0 && len(objects) >= int(maxKeys) {
+			break
+		}
+	}
+	return objects, nil
+}
+
+var (
+	_ storage.IObjectStorage = (*ObjectStorage)(nil)
+	_ storage.IObjectLister  = (*ObjectStorage)(nil)
+)
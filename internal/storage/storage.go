@@ -23,3 +23,16 @@ type IObjectStorage interface {
 	ObjectExists(ctx context.Context, key string) (bool, error)
 	HeadBucket(ctx context.Context) error
 }
+
+type ObjectInfo struct {
+	LastModified *time.Time
+	Key          string
+	Size         int64
+}
+
+// IObjectLister is an object storage that can also enumerate its keys. Not
+// every provider can: Cloudflare Images only addresses images by ID.
+type IObjectLister interface {
+	IObjectStorage
+	ListObjects(ctx context.Context, prefix string, maxKeys int32) ([]ObjectInfo, error)
+}
@@ -0,0 +1,73 @@
+package totp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// "12345678901234567890" from the RFC 6238 test vectors, in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCodeAtRFC6238(t *testing.T) {
+	tcs := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tc := range tcs {
+		got, err := CodeAt(rfcSecret, Step(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got, "at %d", tc.unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+
+	got, ok := Validate(rfcSecret, "050471", now, 0)
+	assert.True(t, ok)
+	assert.Equal(t, step, got)
+
+	previous, err := CodeAt(rfcSecret, step-1)
+	require.NoError(t, err)
+	_, ok = Validate(rfcSecret, previous, now, 0)
+	assert.True(t, ok, "one period of drift is allowed")
+
+	old, err := CodeAt(rfcSecret, step-2)
+	require.NoError(t, err)
+	_, ok = Validate(rfcSecret, old, now, 0)
+	assert.False(t, ok)
+
+	_, ok = Validate(rfcSecret, "050471", now, step)
+	assert.False(t, ok, "a used step cannot be replayed")
+
+	_, ok = Validate(rfcSecret, "050 471", now, 0)
+	assert.True(t, ok)
+	_, ok = Validate(rfcSecret, "12345", now, 0)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	_, err = CodeAt(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestKeyURI(t *testing.T) {
+	assert.Equal(t,
+		"otpauth://totp/C-Choice:staff@example.com?issuer=C-Choice&secret="+rfcSecret,
+		KeyURI("C-Choice", "staff@example.com", rfcSecret),
+	)
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+)
+
+// RatingAverage rounds to a single decimal place, which is what the product
+// cards and the AggregateRating structured data display.
+func RatingAverage(count, total int64) float64 {
+	if count <= 0 {
+		return 0
+	}
+	return math.Round(float64(total)/float64(count)*10) / 10
+}
+
+func RatingDisplay(average float64) string {
+	return strconv.FormatFloat(average, 'f', 1, 64)
+}
+
+// RatingFilledStars returns how many of the five stars should be filled for
+// the given average, rounding half up.
+func RatingFilledStars(average float64) int {
+	filled := int(math.Floor(average + 0.5))
+	return max(0, min(5, filled))
+}
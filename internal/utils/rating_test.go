@@ -0,0 +1,45 @@
+package utils
+
+import "testing"
+
+func TestRatingAverage(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int64
+		total    int64
+		expected float64
+	}{
+		{name: "No ratings", count: 0, total: 0, expected: 0},
+		{name: "Single rating", count: 1, total: 4, expected: 4},
+		{name: "Rounded to one decimal", count: 3, total: 13, expected: 4.3},
+		{name: "Rounded half up", count: 4, total: 17, expected: 4.3},
+		{name: "Negative count", count: -1, total: 5, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RatingAverage(tt.count, tt.total); got != tt.expected {
+				t.Errorf("RatingAverage(%d, %d) = %v, want %v", tt.count, tt.total, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRatingFilledStars(t *testing.T) {
+	tests := []struct {
+		average  float64
+		expected int
+	}{
+		{average: 0, expected: 0},
+		{average: 2.4, expected: 2},
+		{average: 2.5, expected: 3},
+		{average: 4.9, expected: 5},
+		{average: 7, expected: 5},
+	}
+
+	for _, tt := range tests {
+		if got := RatingFilledStars(tt.average); got != tt.expected {
+			t.Errorf("RatingFilledStars(%v) = %d, want %d", tt.average, got, tt.expected)
+		}
+	}
+}
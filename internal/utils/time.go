@@ -21,6 +21,10 @@ func NowPH() time.Time {
 	return time.Now().In(phLocation)
 }
 
+func ParseInPH(layout string, value string) (time.Time, error) {
+	return time.ParseInLocation(layout, value, phLocation)
+}
+
 func ConvertToPH(datetimeStr string) string {
 	if datetimeStr == "" {
 		return ""
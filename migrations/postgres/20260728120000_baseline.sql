@@ -0,0 +1,1411 @@
+-- The PostgreSQL schema as of migrations/sqlite3/20260728120000, so both sets
+-- share goose versions from here on: every new migration needs a file in each
+-- directory. Type mapping from SQLite:
+--   INTEGER PRIMARY KEY -> BIGINT identity, INTEGER -> BIGINT
+--   DATETIME/TIMESTAMP -> TIMESTAMP (UTC), REAL -> DOUBLE PRECISION, BLOB -> BYTEA
+--   DATETIME('now') -> LOCALTIMESTAMP, since connections run with TimeZone=UTC
+-- tbl_products_fts (FTS5) is replaced by a tsvector column with a GIN index.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tbl_brands (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	deleted_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	status TEXT NOT NULL DEFAULT 'DRAFT',
+	UNIQUE (name)
+);
+
+CREATE INDEX idx_tbl_brands_name ON tbl_brands(name);
+
+CREATE TABLE tbl_product_categories (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	category TEXT,
+	subcategory TEXT,
+	promoted_at_homepage BOOLEAN DEFAULT false
+);
+
+CREATE INDEX idx_tbl_product_categories_category ON tbl_product_categories(category);
+
+CREATE TABLE tbl_product_specs (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	colours TEXT,
+	sizes TEXT,
+	segmentation TEXT,
+	part_number TEXT,
+	power TEXT,
+	capacity TEXT,
+	scope_of_supply TEXT,
+	weight_unit TEXT CHECK (weight_unit IN ('kg', 'g', 'lb', 'oz') OR weight_unit IS NULL),
+	weight DOUBLE PRECISION,
+	created_at TIMESTAMP,
+	updated_at TIMESTAMP
+);
+
+CREATE TABLE tbl_products (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	serial TEXT NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT,
+	brand_id BIGINT NOT NULL,
+	status TEXT NOT NULL,
+	product_specs_id BIGINT,
+	unit_price_without_vat BIGINT NOT NULL,
+	unit_price_with_vat BIGINT NOT NULL,
+	unit_price_without_vat_currency TEXT NOT NULL,
+	unit_price_with_vat_currency TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	deleted_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	slug TEXT,
+	name_search TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', name)) STORED,
+	FOREIGN KEY (product_specs_id) REFERENCES tbl_product_specs(id),
+	FOREIGN KEY (brand_id) REFERENCES tbl_brands(id),
+	UNIQUE (serial)
+);
+
+CREATE INDEX idx_tbl_products_serial ON tbl_products(serial);
+
+CREATE INDEX idx_tbl_products_name_search ON tbl_products USING GIN (name_search);
+
+-- Stands in for the SQLite FTS5 table so queries keep joining on
+-- tbl_products_fts.rowid; "name MATCH ?" becomes "name_search @@ ?".
+CREATE VIEW tbl_products_fts AS
+SELECT id AS rowid, serial, name, name_search
+FROM tbl_products;
+
+CREATE TABLE tbl_products_categories (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	category_id BIGINT NOT NULL,
+	product_id BIGINT NOT NULL,
+	created_at TIMESTAMP,
+	updated_at TIMESTAMP,
+	FOREIGN KEY (category_id) REFERENCES tbl_product_categories(id),
+	FOREIGN KEY (product_id) REFERENCES tbl_products(id),
+	UNIQUE(product_id, category_id)
+);
+
+CREATE TABLE tbl_brand_images (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	brand_id BIGINT NOT NULL,
+	path TEXT NOT NULL,
+	is_main BOOLEAN NOT NULL DEFAULT false,
+	created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	deleted_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	s3_url TEXT,
+	FOREIGN KEY (brand_id) REFERENCES tbl_brands(id)
+);
+
+CREATE TABLE tbl_product_images (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	product_id BIGINT NOT NULL,
+	path TEXT NOT NULL,
+	thumbnail TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	deleted_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	cdn_url TEXT DEFAULT '',
+	cdn_url_thumbnail TEXT DEFAULT '',
+	FOREIGN KEY (product_id) REFERENCES tbl_products(id)
+);
+
+CREATE TABLE tbl_settings (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	value TEXT NOT NULL,
+	UNIQUE (name)
+);
+
+CREATE INDEX idx_tbl_settings_name ON tbl_settings(name);
+
+CREATE TABLE tbl_checkout_lines (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	checkout_id BIGINT NOT NULL,
+	product_id BIGINT NOT NULL,
+	name TEXT NOT NULL,
+	serial TEXT NOT NULL,
+	description TEXT NOT NULL,
+	amount BIGINT NOT NULL,
+	currency TEXT NOT NULL,
+	quantity BIGINT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	FOREIGN KEY (checkout_id) REFERENCES tbl_checkouts(id),
+	FOREIGN KEY (product_id) REFERENCES tbl_products(id)
+);
+
+CREATE TABLE tbl_checkouts (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	session_id TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'COMPLETED', 'CANCELLED')),
+	customer_id BIGINT REFERENCES tbl_customers(id),
+	email TEXT,
+	reminder_sent_at TIMESTAMP,
+	recovery_token_hash TEXT,
+	recovered_at TIMESTAMP
+);
+
+CREATE TABLE tbl_checkout_payments (
+	id TEXT PRIMARY KEY,
+	gateway TEXT NOT NULL,
+	checkout_id BIGINT NOT NULL,
+	status TEXT NOT NULL,
+	description TEXT NOT NULL,
+	total_amount BIGINT NOT NULL,
+	checkout_url TEXT NOT NULL,
+	client_key TEXT NOT NULL,
+	reference_number TEXT NOT NULL,
+	payment_method_type TEXT NOT NULL,
+	paid_at TIMESTAMP NOT NULL,
+	metadata_remarks TEXT NOT NULL,
+	metadata_notes TEXT NOT NULL,
+	metadata_customer_number TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	payment_intent_id TEXT,
+	FOREIGN KEY (checkout_id) REFERENCES tbl_checkouts(id)
+);
+
+CREATE TABLE tbl_geocoding_cache (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	address TEXT NOT NULL,
+	normalized_address TEXT NOT NULL,
+	latitude TEXT NOT NULL,
+	longitude TEXT NOT NULL,
+	formatted_address TEXT NOT NULL,
+	place_id TEXT,
+	response_data TEXT,
+	created_at TIMESTAMP DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP DEFAULT LOCALTIMESTAMP,
+	expires_at TIMESTAMP,
+	UNIQUE (normalized_address)
+);
+
+CREATE INDEX idx_geocoding_cache_address ON tbl_geocoding_cache(address);
+
+CREATE INDEX idx_geocoding_cache_normalized_address ON tbl_geocoding_cache(normalized_address);
+
+CREATE INDEX idx_geocoding_cache_expires_at ON tbl_geocoding_cache(expires_at);
+
+CREATE TABLE tbl_orders (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	checkout_id BIGINT NOT NULL,
+	checkout_payment_id TEXT NOT NULL,
+	order_number TEXT NOT NULL UNIQUE,
+	status TEXT NOT NULL CHECK (status IN ('PENDING', 'CONFIRMED', 'PROCESSING', 'SHIPPED', 'DELIVERED', 'CANCELLED', 'REFUNDED')),
+	customer_name TEXT NOT NULL,
+	customer_email TEXT NOT NULL,
+	customer_phone TEXT NOT NULL,
+	billing_address_line1 TEXT NOT NULL,
+	billing_address_line2 TEXT NOT NULL,
+	billing_city TEXT NOT NULL,
+	billing_state TEXT NOT NULL,
+	billing_postal_code TEXT NOT NULL,
+	billing_country TEXT NOT NULL DEFAULT 'PH',
+	billing_latitude TEXT,
+	billing_longitude TEXT,
+	billing_formatted_address TEXT,
+	billing_place_id TEXT,
+	shipping_address_line1 TEXT NOT NULL,
+	shipping_address_line2 TEXT NOT NULL,
+	shipping_city TEXT NOT NULL,
+	shipping_state TEXT NOT NULL,
+	shipping_postal_code TEXT NOT NULL,
+	shipping_country TEXT NOT NULL DEFAULT 'PH',
+	shipping_latitude TEXT,
+	shipping_longitude TEXT,
+	shipping_formatted_address TEXT,
+	shipping_place_id TEXT,
+	subtotal_amount BIGINT NOT NULL,
+	shipping_amount BIGINT NOT NULL,
+	discount_amount BIGINT NOT NULL DEFAULT 0,
+	total_amount BIGINT NOT NULL,
+	currency TEXT NOT NULL DEFAULT 'PHP',
+	shipping_service TEXT,
+	shipping_order_id TEXT,
+	shipping_tracking_number TEXT,
+	notes TEXT,
+	remarks TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	paid_at TIMESTAMP,
+	shipping_eta TEXT,
+	customer_id BIGINT REFERENCES tbl_customers(id),
+	shipping_barangay TEXT NOT NULL DEFAULT '',
+	earned_cpoints BIGINT NOT NULL DEFAULT 0,
+	FOREIGN KEY (checkout_id) REFERENCES tbl_checkouts(id),
+	FOREIGN KEY (checkout_payment_id) REFERENCES tbl_checkout_payments(id)
+);
+
+CREATE TABLE tbl_order_lines (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	order_id BIGINT NOT NULL,
+	checkout_line_id BIGINT NOT NULL,
+	product_id BIGINT NOT NULL,
+	name TEXT NOT NULL,
+	serial TEXT NOT NULL,
+	description TEXT NOT NULL,
+	unit_price BIGINT NOT NULL,
+	quantity BIGINT NOT NULL,
+	total_price BIGINT NOT NULL,
+	currency TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	FOREIGN KEY (order_id) REFERENCES tbl_orders(id) ON DELETE CASCADE,
+	FOREIGN KEY (checkout_line_id) REFERENCES tbl_checkout_lines(id),
+	FOREIGN KEY (product_id) REFERENCES tbl_products(id)
+);
+
+CREATE INDEX idx_orders_checkout_id ON tbl_orders(checkout_id);
+
+CREATE INDEX idx_orders_checkout_payment_id ON tbl_orders(checkout_payment_id);
+
+CREATE INDEX idx_orders_order_number ON tbl_orders(order_number);
+
+CREATE INDEX idx_orders_status ON tbl_orders(status);
+
+CREATE INDEX idx_orders_customer_email ON tbl_orders(customer_email);
+
+CREATE INDEX idx_orders_created_at ON tbl_orders(created_at);
+
+CREATE INDEX idx_order_lines_order_id ON tbl_order_lines(order_id);
+
+CREATE INDEX idx_order_lines_product_id ON tbl_order_lines(product_id);
+
+CREATE TABLE tbl_external_api_logs (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	checkout_id BIGINT,
+	service TEXT NOT NULL,
+	api TEXT NOT NULL,
+	endpoint TEXT NOT NULL,
+	http_method TEXT NOT NULL,
+	payload TEXT,
+	response TEXT,
+	status_code BIGINT,
+	error_message TEXT,
+	is_successful BIGINT NOT NULL DEFAULT 0,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	FOREIGN KEY (checkout_id) REFERENCES tbl_checkouts(id)
+);
+
+CREATE INDEX idx_external_api_logs_checkout_id ON tbl_external_api_logs(checkout_id);
+
+CREATE INDEX idx_external_api_logs_service ON tbl_external_api_logs(service);
+
+CREATE INDEX idx_external_api_logs_api ON tbl_external_api_logs(api);
+
+CREATE INDEX idx_external_api_logs_created_at ON tbl_external_api_logs(created_at);
+
+CREATE INDEX idx_external_api_logs_is_successful ON tbl_external_api_logs(is_successful);
+
+CREATE TABLE tbl_product_sales (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	product_id BIGINT NOT NULL,
+	sale_price_without_vat BIGINT NOT NULL,
+	sale_price_with_vat BIGINT NOT NULL,
+	sale_price_without_vat_currency TEXT NOT NULL,
+	sale_price_with_vat_currency TEXT NOT NULL,
+	discount_type TEXT NOT NULL CHECK (discount_type IN ('fixed', 'percentage')),
+	discount_value BIGINT NOT NULL,
+	starts_at TIMESTAMP NOT NULL,
+	ends_at TIMESTAMP NOT NULL,
+	is_active BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	deleted_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	sale_campaign_id BIGINT REFERENCES tbl_sale_campaigns(id),
+	FOREIGN KEY (product_id) REFERENCES tbl_products(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_product_sales_active ON tbl_product_sales (product_id, is_active, starts_at, ends_at);
+
+CREATE INDEX idx_product_sales_active_window ON tbl_product_sales (product_id, is_active, starts_at, ends_at);
+
+CREATE TABLE tbl_staffs (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	first_name TEXT NOT NULL,
+	middle_name TEXT,
+	last_name TEXT NOT NULL,
+	birthdate TEXT NOT NULL,
+	sex TEXT NOT NULL,
+	date_hired TEXT NOT NULL,
+	time_in_schedule TEXT,
+	time_out_schedule TEXT,
+	position TEXT NOT NULL,
+	user_type TEXT NOT NULL CHECK(user_type IN ('SUPERUSER', 'STAFF')),
+	email TEXT NOT NULL UNIQUE,
+	mobile_no TEXT NOT NULL,
+	password TEXT NOT NULL,
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	deleted_at TEXT NOT NULL DEFAULT '1970-01-01 00:00:00+00:00',
+	require_in_shop BOOLEAN NOT NULL DEFAULT FALSE,
+	status TEXT NOT NULL DEFAULT 'PROBATION',
+	resigned_at TEXT
+);
+
+CREATE TABLE tbl_staff_attendances (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL,
+	for_date TEXT NOT NULL,
+	time_in TEXT,
+	time_out TEXT,
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	in_location TEXT,
+	in_useragent_id BIGINT REFERENCES tbl_useragents(id),
+	out_useragent_id BIGINT REFERENCES tbl_useragents(id),
+	out_location TEXT,
+	lunch_break_in TEXT,
+	lunch_break_in_location TEXT,
+	lunch_break_in_useragent_id BIGINT REFERENCES tbl_useragents(id),
+	lunch_break_out TEXT,
+	lunch_break_out_location TEXT,
+	lunch_break_out_useragent_id BIGINT REFERENCES tbl_useragents(id),
+	is_holiday BIGINT DEFAULT 0,
+	holiday_type TEXT,
+	holiday_name TEXT,
+	FOREIGN KEY (staff_id) REFERENCES tbl_staffs(id),
+	UNIQUE(staff_id, for_date)
+);
+
+CREATE TABLE tbl_staff_accesses (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL,
+	login_at TEXT NOT NULL,
+	logout_at TEXT,
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	useragent_id BIGINT REFERENCES tbl_useragents(id),
+	FOREIGN KEY (staff_id) REFERENCES tbl_staffs(id)
+);
+
+CREATE INDEX idx_staff_accesses_staff_id ON tbl_staff_accesses(staff_id);
+
+CREATE INDEX idx_staff_accesses_login_at ON tbl_staff_accesses(login_at);
+
+CREATE TABLE tbl_useragents (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	user_agent TEXT NOT NULL UNIQUE,
+	browser TEXT NOT NULL DEFAULT '',
+	browser_version TEXT NOT NULL DEFAULT '',
+	os TEXT NOT NULL DEFAULT '',
+	device TEXT NOT NULL DEFAULT '',
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS')
+);
+
+CREATE TABLE tbl_staff_time_offs (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	type TEXT NOT NULL CHECK (type IN ('VL', 'SL', 'ABSENT')),
+	start_date TIMESTAMP NOT NULL,
+	end_date TIMESTAMP NOT NULL,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	useragent_id BIGINT REFERENCES tbl_useragents(id),
+	description TEXT NOT NULL,
+	approved BOOLEAN DEFAULT FALSE,
+	approved_by BIGINT REFERENCES tbl_staffs(id),
+	approved_at TIMESTAMP,
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS')
+);
+
+CREATE INDEX idx_staff_time_offs_type ON tbl_staff_time_offs(type);
+
+CREATE TABLE tbl_staff_logs (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	action TEXT NOT NULL,
+	module TEXT NOT NULL,
+	result TEXT NOT NULL,
+	useragent_id BIGINT REFERENCES tbl_useragents(id)
+);
+
+CREATE INDEX idx_staff_logs_staff_id ON tbl_staff_logs(staff_id);
+
+CREATE INDEX idx_staff_logs_created_at ON tbl_staff_logs(created_at);
+
+CREATE INDEX idx_staff_logs_module ON tbl_staff_logs(module);
+
+CREATE TABLE tbl_staff_roles (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL,
+	role TEXT NOT NULL,
+	created_at TIMESTAMP DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP,
+	access TEXT NOT NULL DEFAULT 'WRITE' CHECK (access IN ('READ', 'WRITE')),
+	FOREIGN KEY (staff_id) REFERENCES tbl_staffs(id)
+);
+
+CREATE INDEX idx_staff_roles_staff_id ON tbl_staff_roles(staff_id);
+
+CREATE TABLE tbl_thumbnail_jobs (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	queue_id TEXT NOT NULL,
+	product_id BIGINT NOT NULL,
+	brand TEXT NOT NULL,
+	source_path TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'pending',
+	error_message TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+	FOREIGN KEY (product_id) REFERENCES tbl_products(id)
+);
+
+CREATE INDEX idx_tbl_thumbnail_jobs_product_id ON tbl_thumbnail_jobs(product_id);
+
+CREATE INDEX idx_tbl_thumbnail_jobs_status ON tbl_thumbnail_jobs(status);
+
+CREATE TABLE tbl_customers (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	first_name TEXT NOT NULL,
+	middle_name TEXT,
+	last_name TEXT NOT NULL,
+	birthdate TEXT NOT NULL,
+	sex TEXT NOT NULL,
+	email TEXT NOT NULL UNIQUE,
+	mobile_no TEXT NOT NULL,
+	password TEXT NOT NULL,
+	customer_type TEXT NOT NULL CHECK(customer_type IN ('CUSTOMER', 'COMPANY')),
+	status TEXT NOT NULL CHECK(status IN ('VERIFIED', 'UNVERIFIED')) DEFAULT 'UNVERIFIED',
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	deleted_at TEXT NOT NULL DEFAULT '1970-01-01 00:00:00+00:00'
+);
+
+CREATE TABLE tbl_customer_companies (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	customer_id BIGINT NOT NULL,
+	name TEXT NOT NULL,
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	deleted_at TEXT NOT NULL DEFAULT '1970-01-01 00:00:00+00:00',
+	FOREIGN KEY (customer_id) REFERENCES tbl_customers(id)
+);
+
+CREATE TABLE tbl_cpoints (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	customer_id BIGINT NOT NULL,
+	code TEXT NOT NULL UNIQUE,
+	value BIGINT NOT NULL,
+	product_skus TEXT,
+	expires_at TEXT,
+	generated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	redeemed_at TEXT,
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	deleted_at TEXT NOT NULL DEFAULT '1970-01-01 00:00:00+00:00',
+	FOREIGN KEY (customer_id) REFERENCES tbl_customers(id)
+);
+
+CREATE INDEX idx_cpoints_customer_id ON tbl_cpoints(customer_id);
+
+CREATE TABLE tbl_customer_otp_codes (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	customer_id BIGINT NOT NULL,
+	otp_code TEXT NOT NULL,
+	expires_at TEXT NOT NULL,
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	used_at TEXT,
+	FOREIGN KEY (customer_id) REFERENCES tbl_customers(id),
+	UNIQUE (customer_id, otp_code, used_at)
+);
+
+CREATE TABLE tbl_email_jobs (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	queue_id TEXT NOT NULL,
+	recipient TEXT NOT NULL,
+	cc TEXT,
+	subject TEXT NOT NULL,
+	template_name TEXT NOT NULL,
+	order_id BIGINT,
+	otp_code TEXT,
+	checkout_payment_id TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	memo_id BIGINT REFERENCES tbl_memos(id),
+	product_id BIGINT REFERENCES tbl_products(id),
+	checkout_id BIGINT REFERENCES tbl_checkouts(id),
+	FOREIGN KEY (order_id) REFERENCES tbl_orders(id),
+	FOREIGN KEY (checkout_payment_id) REFERENCES tbl_checkout_payments(id)
+);
+
+CREATE TABLE tbl_holidays (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	date TEXT NOT NULL UNIQUE,
+	name TEXT NOT NULL,
+	type TEXT NOT NULL,
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT
+);
+
+CREATE UNIQUE INDEX idx_holidays_date ON tbl_holidays(date);
+
+CREATE TABLE tbl_password_reset_tokens (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	user_id BIGINT NOT NULL,
+	user_type TEXT NOT NULL,
+	token_hash TEXT NOT NULL,
+	expires_at TEXT NOT NULL,
+	used_at TEXT,
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TIMESTAMP
+);
+
+CREATE INDEX idx_password_reset_tokens_user_id_user_type ON tbl_password_reset_tokens(user_id, user_type);
+
+CREATE INDEX idx_password_reset_tokens_token_hash ON tbl_password_reset_tokens(token_hash);
+
+CREATE TABLE tbl_promos (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	title TEXT NOT NULL,
+	description TEXT NOT NULL,
+	media_url TEXT NOT NULL,
+	start_date TEXT NOT NULL,
+	end_date TEXT NOT NULL,
+	type TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'DRAFT',
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	deleted_at TEXT NOT NULL DEFAULT '1970-01-01 00:00:00+00:00',
+	banner_only BOOLEAN DEFAULT TRUE,
+	priority BIGINT DEFAULT 1
+);
+
+CREATE INDEX idx_promos_status ON tbl_promos(status);
+
+CREATE INDEX idx_promos_deleted_at ON tbl_promos(deleted_at);
+
+CREATE INDEX idx_promos_start_date ON tbl_promos(start_date);
+
+CREATE INDEX idx_promos_end_date ON tbl_promos(end_date);
+
+CREATE INDEX idx_products_slug ON tbl_products(slug);
+
+CREATE TABLE tbl_tracked_links (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	slug TEXT UNIQUE NOT NULL,
+	destination_url TEXT NOT NULL,
+	source TEXT,
+	medium TEXT,
+	campaign TEXT,
+	status TEXT NOT NULL DEFAULT 'DRAFT',
+	staff_id TEXT,
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS')
+);
+
+CREATE TABLE tbl_link_clicks (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	link_id TEXT NOT NULL,
+	clicked_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	referrer TEXT,
+	user_agent TEXT,
+	ip_hash TEXT,
+	device TEXT,
+	utm_source TEXT,
+	utm_medium TEXT,
+	utm_campaign TEXT,
+	is_bot BOOLEAN NOT NULL DEFAULT FALSE,
+	is_duplicate BOOLEAN NOT NULL DEFAULT FALSE,
+	FOREIGN KEY (link_id) REFERENCES tbl_tracked_links(id)
+);
+
+CREATE INDEX idx_link_clicks_link_id ON tbl_link_clicks(link_id);
+
+CREATE INDEX idx_link_clicks_clicked_at ON tbl_link_clicks(clicked_at);
+
+CREATE TABLE tbl_product_inventories (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	product_id BIGINT NOT NULL UNIQUE,
+	stocks BIGINT NOT NULL DEFAULT 0,
+	stocks_in TEXT NOT NULL,
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	FOREIGN KEY (product_id) REFERENCES tbl_products(id)
+);
+
+CREATE INDEX idx_product_inventories_product_id ON tbl_product_inventories(product_id);
+
+CREATE TABLE tbl_quotation_lines (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	quotation_id BIGINT NOT NULL,
+	product_id BIGINT NOT NULL,
+	quantity BIGINT NOT NULL DEFAULT 1,
+	original_price_snapshot BIGINT DEFAULT 0,
+	sale_price_snapshot BIGINT DEFAULT 0,
+	currency TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	FOREIGN KEY (quotation_id) REFERENCES tbl_quotations(id) ON DELETE CASCADE,
+	FOREIGN KEY (product_id) REFERENCES tbl_products(id)
+);
+
+CREATE TABLE tbl_quotations (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	customer_id BIGINT NOT NULL,
+	acknowledged_by_staff_id BIGINT,
+	status TEXT NOT NULL DEFAULT 'DRAFT',
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	FOREIGN KEY (customer_id) REFERENCES tbl_customers(id),
+	FOREIGN KEY (acknowledged_by_staff_id) REFERENCES tbl_staffs(id)
+);
+
+CREATE INDEX idx_quotation_lines ON tbl_quotation_lines(quotation_id);
+
+CREATE INDEX idx_quotations_customer_status ON tbl_quotations(customer_id, status);
+
+CREATE TABLE tbl_memos (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	title TEXT NOT NULL,
+	message TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'DRAFT' CHECK (status IN ('DRAFT', 'PUBLISHED', 'EXPIRED', 'DELETED')),
+	start_date TEXT NOT NULL,
+	end_date TEXT NOT NULL,
+	created_by BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	deleted_at TEXT NOT NULL DEFAULT '1970-01-01 00:00:00+00:00',
+	file_url TEXT,
+	emails_sent_at TEXT NOT NULL DEFAULT '1970-01-01 00:00:00+00:00',
+	requires_acknowledgement BOOLEAN NOT NULL DEFAULT FALSE,
+	acknowledge_by TEXT,
+	escalated_at TEXT
+);
+
+CREATE INDEX idx_memos_status ON tbl_memos(status);
+
+CREATE INDEX idx_memos_deleted_at ON tbl_memos(deleted_at);
+
+CREATE INDEX idx_memos_start_date ON tbl_memos(start_date);
+
+CREATE INDEX idx_memos_end_date ON tbl_memos(end_date);
+
+CREATE INDEX idx_memos_created_by ON tbl_memos(created_by);
+
+CREATE TABLE tbl_memo_recipients (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	memo_id BIGINT NOT NULL REFERENCES tbl_memos(id),
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	reminder_count BIGINT NOT NULL DEFAULT 0,
+	last_reminded_at TEXT,
+	UNIQUE(memo_id, staff_id)
+);
+
+CREATE INDEX idx_memo_recipients_memo_id ON tbl_memo_recipients(memo_id);
+
+CREATE INDEX idx_memo_recipients_staff_id ON tbl_memo_recipients(staff_id);
+
+CREATE TABLE tbl_memo_staff_actions (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	memo_id BIGINT NOT NULL REFERENCES tbl_memos(id),
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	status TEXT NOT NULL CHECK (status IN ('ACCEPTED', 'REJECTED')),
+	reject_reason TEXT,
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	accepted_at TEXT,
+	rejected_at TEXT,
+	UNIQUE(memo_id, staff_id)
+);
+
+CREATE INDEX idx_memo_staff_actions_memo_id ON tbl_memo_staff_actions(memo_id);
+
+CREATE INDEX idx_memo_staff_actions_staff_id ON tbl_memo_staff_actions(staff_id);
+
+CREATE TABLE tbl_order_status_history (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	order_id BIGINT NOT NULL REFERENCES tbl_orders(id),
+	staff_id BIGINT REFERENCES tbl_staffs(id),
+	from_status TEXT CHECK (from_status IS NULL OR from_status IN ('PENDING', 'CONFIRMED', 'PROCESSING', 'SHIPPED', 'DELIVERED', 'CANCELLED', 'REFUNDED')),
+	to_status TEXT NOT NULL CHECK (to_status IN ('PENDING', 'CONFIRMED', 'PROCESSING', 'SHIPPED', 'DELIVERED', 'CANCELLED', 'REFUNDED')),
+	notes TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_order_status_history_order_id ON tbl_order_status_history(order_id);
+
+CREATE INDEX idx_order_status_history_created_at ON tbl_order_status_history(created_at);
+
+CREATE INDEX idx_orders_customer_id ON tbl_orders(customer_id);
+
+CREATE TABLE tbl_quotation_status_history (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	quotation_id BIGINT NOT NULL REFERENCES tbl_quotations(id),
+	staff_id BIGINT REFERENCES tbl_staffs(id),
+	from_status TEXT,
+	to_status TEXT NOT NULL,
+	notes TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_quotation_status_history_quotation_id ON tbl_quotation_status_history(quotation_id);
+
+CREATE INDEX idx_quotation_status_history_created_at ON tbl_quotation_status_history(created_at);
+
+CREATE TABLE tbl_product_external_platform_links (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	product_id BIGINT NOT NULL,
+	platform TEXT NOT NULL,
+	url TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	FOREIGN KEY (product_id) REFERENCES tbl_products(id),
+	UNIQUE (product_id, platform)
+);
+
+CREATE INDEX idx_product_external_platform_links_product_id ON tbl_product_external_platform_links(product_id);
+
+CREATE TABLE tbl_themes (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	title TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'DRAFT',
+	start_date TEXT NOT NULL,
+	end_date TEXT NOT NULL,
+	configuration TEXT NOT NULL,
+	configuration_type TEXT NOT NULL DEFAULT 'JSON',
+	created_by BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	created_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	updated_at TEXT NOT NULL DEFAULT to_char(LOCALTIMESTAMP, 'YYYY-MM-DD HH24:MI:SS'),
+	deleted_at TEXT NOT NULL DEFAULT '1970-01-01 00:00:00+00:00'
+);
+
+CREATE INDEX idx_themes_status ON tbl_themes(status);
+
+CREATE INDEX idx_themes_deleted_at ON tbl_themes(deleted_at);
+
+CREATE INDEX idx_themes_start_date ON tbl_themes(start_date);
+
+CREATE INDEX idx_themes_end_date ON tbl_themes(end_date);
+
+CREATE INDEX idx_themes_created_by ON tbl_themes(created_by);
+
+CREATE TABLE tbl_product_reviews (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	product_id BIGINT NOT NULL REFERENCES tbl_products(id),
+	customer_id BIGINT NOT NULL REFERENCES tbl_customers(id),
+	order_id BIGINT NOT NULL REFERENCES tbl_orders(id),
+	rating BIGINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+	title TEXT NOT NULL DEFAULT '',
+	body TEXT NOT NULL DEFAULT '',
+	status TEXT NOT NULL DEFAULT 'PENDING',
+	moderated_by BIGINT REFERENCES tbl_staffs(id),
+	moderated_at TIMESTAMP,
+	rejection_reason TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	UNIQUE(product_id, customer_id)
+);
+
+CREATE INDEX idx_product_reviews_product_id_status ON tbl_product_reviews(product_id, status);
+
+CREATE INDEX idx_product_reviews_status_created_at ON tbl_product_reviews(status, created_at);
+
+CREATE INDEX idx_product_reviews_customer_id ON tbl_product_reviews(customer_id);
+
+CREATE TABLE tbl_product_review_photos (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	review_id BIGINT NOT NULL REFERENCES tbl_product_reviews(id) ON DELETE CASCADE,
+	path TEXT NOT NULL,
+	url TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_product_review_photos_review_id ON tbl_product_review_photos(review_id);
+
+CREATE TABLE tbl_product_ratings (
+	product_id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY REFERENCES tbl_products(id),
+	rating_count BIGINT NOT NULL DEFAULT 0,
+	rating_total BIGINT NOT NULL DEFAULT 0,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE TABLE tbl_wishlists (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	customer_id BIGINT NOT NULL REFERENCES tbl_customers(id) ON DELETE CASCADE,
+	product_id BIGINT NOT NULL REFERENCES tbl_products(id) ON DELETE CASCADE,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	UNIQUE (customer_id, product_id)
+);
+
+CREATE INDEX idx_wishlists_product_id ON tbl_wishlists(product_id);
+
+CREATE TABLE tbl_stock_subscriptions (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	product_id BIGINT NOT NULL REFERENCES tbl_products(id) ON DELETE CASCADE,
+	email TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'PENDING',
+	token_hash TEXT NOT NULL,
+	confirmed_at TIMESTAMP,
+	notified_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	UNIQUE (product_id, email)
+);
+
+CREATE INDEX idx_stock_subscriptions_token_hash ON tbl_stock_subscriptions(token_hash);
+
+CREATE INDEX idx_stock_subscriptions_product_id_status ON tbl_stock_subscriptions(product_id, status);
+
+CREATE TABLE tbl_sale_campaigns (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	selector TEXT NOT NULL CHECK (selector IN ('BRAND', 'CATEGORY', 'PRODUCTS')),
+	selector_value TEXT NOT NULL,
+	discount_type TEXT NOT NULL CHECK (discount_type IN ('fixed', 'percentage')),
+	discount_value BIGINT NOT NULL,
+	starts_at TIMESTAMP NOT NULL,
+	ends_at TIMESTAMP NOT NULL,
+	status TEXT NOT NULL DEFAULT 'SCHEDULED' CHECK (status IN ('SCHEDULED', 'ACTIVE', 'ENDED', 'CANCELLED')),
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_sale_campaigns_status_starts_at ON tbl_sale_campaigns(status, starts_at);
+
+CREATE INDEX idx_sale_campaigns_status_ends_at ON tbl_sale_campaigns(status, ends_at);
+
+CREATE TABLE tbl_sale_campaign_products (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	sale_campaign_id BIGINT NOT NULL REFERENCES tbl_sale_campaigns(id) ON DELETE CASCADE,
+	product_id BIGINT NOT NULL REFERENCES tbl_products(id) ON DELETE CASCADE,
+	UNIQUE (sale_campaign_id, product_id)
+);
+
+CREATE INDEX idx_sale_campaign_products_product_id ON tbl_sale_campaign_products(product_id);
+
+CREATE INDEX idx_product_sales_sale_campaign_id ON tbl_product_sales(sale_campaign_id);
+
+CREATE INDEX idx_checkouts_status_reminder_sent_at ON tbl_checkouts(status, reminder_sent_at);
+
+CREATE INDEX idx_checkouts_recovery_token_hash ON tbl_checkouts(recovery_token_hash);
+
+CREATE INDEX idx_checkouts_customer_id_status ON tbl_checkouts(customer_id, status);
+
+CREATE TABLE tbl_staff_pay_rates (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL UNIQUE REFERENCES tbl_staffs(id),
+	rate_type TEXT NOT NULL CHECK (rate_type IN ('DAILY', 'MONTHLY')),
+	rate BIGINT NOT NULL,
+	updated_by BIGINT REFERENCES tbl_staffs(id),
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE TABLE tbl_payroll_runs (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	period_start TEXT NOT NULL,
+	period_end TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'DRAFT' CHECK (status IN ('DRAFT', 'LOCKED')),
+	created_by BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	locked_by BIGINT REFERENCES tbl_staffs(id),
+	locked_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	UNIQUE (period_start, period_end)
+);
+
+CREATE TABLE tbl_payroll_run_items (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	payroll_run_id BIGINT NOT NULL REFERENCES tbl_payroll_runs(id) ON DELETE CASCADE,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	rate_type TEXT NOT NULL CHECK (rate_type IN ('DAILY', 'MONTHLY')),
+	rate BIGINT NOT NULL,
+	daily_rate BIGINT NOT NULL,
+	days_worked BIGINT NOT NULL DEFAULT 0,
+	absent_days BIGINT NOT NULL DEFAULT 0,
+	late_minutes BIGINT NOT NULL DEFAULT 0,
+	undertime_minutes BIGINT NOT NULL DEFAULT 0,
+	overtime_minutes BIGINT NOT NULL DEFAULT 0,
+	basic_pay BIGINT NOT NULL DEFAULT 0,
+	holiday_pay BIGINT NOT NULL DEFAULT 0,
+	overtime_pay BIGINT NOT NULL DEFAULT 0,
+	late_deduction BIGINT NOT NULL DEFAULT 0,
+	undertime_deduction BIGINT NOT NULL DEFAULT 0,
+	absence_deduction BIGINT NOT NULL DEFAULT 0,
+	gross_pay BIGINT NOT NULL DEFAULT 0,
+	sss BIGINT NOT NULL DEFAULT 0,
+	philhealth BIGINT NOT NULL DEFAULT 0,
+	pagibig BIGINT NOT NULL DEFAULT 0,
+	withholding_tax BIGINT NOT NULL DEFAULT 0,
+	net_pay BIGINT NOT NULL DEFAULT 0,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	UNIQUE (payroll_run_id, staff_id)
+);
+
+CREATE INDEX idx_payroll_run_items_staff_id ON tbl_payroll_run_items(staff_id);
+
+CREATE TABLE tbl_staff_leave_ledger (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	leave_type TEXT NOT NULL CHECK (leave_type IN ('VL', 'SL')),
+	entry_type TEXT NOT NULL CHECK (entry_type IN ('ACCRUAL', 'DEDUCTION', 'REFUND', 'ADJUSTMENT', 'CONVERSION', 'FORFEIT')),
+	year BIGINT NOT NULL,
+	period TEXT NOT NULL DEFAULT '',
+	credits BIGINT NOT NULL,
+	time_off_id BIGINT REFERENCES tbl_staff_time_offs(id),
+	note TEXT NOT NULL DEFAULT '',
+	created_by BIGINT REFERENCES tbl_staffs(id),
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_staff_leave_ledger_staff ON tbl_staff_leave_ledger(staff_id, leave_type, year);
+
+CREATE INDEX idx_staff_leave_ledger_time_off ON tbl_staff_leave_ledger(time_off_id);
+
+CREATE UNIQUE INDEX idx_staff_leave_ledger_period ON tbl_staff_leave_ledger(staff_id, leave_type, entry_type, period) WHERE period != '';
+
+CREATE TABLE tbl_staff_shift_rotations (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL UNIQUE REFERENCES tbl_staffs(id),
+	cycle_weeks BIGINT NOT NULL DEFAULT 1 CHECK (cycle_weeks BETWEEN 1 AND 4),
+	anchor_date TEXT NOT NULL,
+	updated_by BIGINT REFERENCES tbl_staffs(id),
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE TABLE tbl_staff_shift_days (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	week_index BIGINT NOT NULL DEFAULT 0,
+	weekday BIGINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+	time_in TEXT NOT NULL DEFAULT '',
+	time_out TEXT NOT NULL DEFAULT '',
+	rest_day BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	UNIQUE (staff_id, week_index, weekday)
+);
+
+CREATE TABLE tbl_staff_shift_swaps (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	requester_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	counterpart_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	for_date TEXT NOT NULL,
+	reason TEXT NOT NULL DEFAULT '',
+	status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+	decided_by BIGINT REFERENCES tbl_staffs(id),
+	decided_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE TABLE tbl_staff_shift_overrides (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	for_date TEXT NOT NULL,
+	time_in TEXT NOT NULL DEFAULT '',
+	time_out TEXT NOT NULL DEFAULT '',
+	rest_day BOOLEAN NOT NULL DEFAULT FALSE,
+	note TEXT NOT NULL DEFAULT '',
+	shift_swap_id BIGINT REFERENCES tbl_staff_shift_swaps(id),
+	created_by BIGINT REFERENCES tbl_staffs(id),
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	UNIQUE (staff_id, for_date)
+);
+
+CREATE INDEX idx_staff_shift_overrides_for_date ON tbl_staff_shift_overrides(for_date);
+
+CREATE INDEX idx_staff_shift_swaps_status ON tbl_staff_shift_swaps(status);
+
+CREATE TABLE tbl_staff_kiosk_pins (
+	staff_id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY REFERENCES tbl_staffs(id),
+	pin_hash TEXT NOT NULL,
+	failed_attempts BIGINT NOT NULL DEFAULT 0,
+	locked_until TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE TABLE tbl_attendance_kiosk_punches (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	kiosk_staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	for_date TEXT NOT NULL,
+	punch_type TEXT NOT NULL,
+	method TEXT NOT NULL,
+	photo_key TEXT NOT NULL DEFAULT '',
+	useragent_id BIGINT REFERENCES tbl_useragents(id),
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_tbl_attendance_kiosk_punches_for_date ON tbl_attendance_kiosk_punches(for_date);
+
+CREATE INDEX idx_tbl_attendance_kiosk_punches_staff_id ON tbl_attendance_kiosk_punches(staff_id);
+
+CREATE TABLE tbl_staff_attendance_corrections (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	for_date TEXT NOT NULL,
+	time_in TEXT,
+	time_out TEXT,
+	lunch_break_in TEXT,
+	lunch_break_out TEXT,
+	reason TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+	original_time_in TEXT,
+	original_time_out TEXT,
+	original_lunch_break_in TEXT,
+	original_lunch_break_out TEXT,
+	decided_by BIGINT REFERENCES tbl_staffs(id),
+	decided_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_staff_attendance_corrections_status ON tbl_staff_attendance_corrections(status);
+
+CREATE INDEX idx_staff_attendance_corrections_staff_id_for_date ON tbl_staff_attendance_corrections(staff_id, for_date);
+
+CREATE INDEX idx_memos_requires_acknowledgement ON tbl_memos(requires_acknowledgement);
+
+CREATE TABLE tbl_staff_incidents (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	reported_by BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	incident_date TEXT NOT NULL,
+	title TEXT NOT NULL,
+	description TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'NTE_ISSUED', 'RESPONDED', 'DECIDED')),
+	nte_memo_id BIGINT REFERENCES tbl_memos(id),
+	response_deadline TEXT,
+	response TEXT,
+	responded_at TIMESTAMP,
+	decision TEXT CHECK (decision IN ('WARNING', 'SUSPENSION', 'NO_ACTION')),
+	decision_notes TEXT,
+	effective_from TEXT,
+	effective_to TEXT,
+	decided_by BIGINT REFERENCES tbl_staffs(id),
+	decided_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_staff_incidents_staff_id ON tbl_staff_incidents(staff_id);
+
+CREATE INDEX idx_staff_incidents_status ON tbl_staff_incidents(status);
+
+CREATE INDEX idx_staff_incidents_suspension ON tbl_staff_incidents(decision, effective_from, effective_to);
+
+CREATE TABLE tbl_role_groups (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL UNIQUE,
+	description TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE TABLE tbl_role_group_permissions (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	role_group_id BIGINT NOT NULL REFERENCES tbl_role_groups(id) ON DELETE CASCADE,
+	role TEXT NOT NULL,
+	access TEXT NOT NULL CHECK (access IN ('READ', 'WRITE')),
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	UNIQUE (role_group_id, role)
+);
+
+CREATE TABLE tbl_staff_role_groups (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	role_group_id BIGINT NOT NULL REFERENCES tbl_role_groups(id) ON DELETE CASCADE,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	UNIQUE (staff_id, role_group_id)
+);
+
+CREATE INDEX idx_staff_role_groups_staff_id ON tbl_staff_role_groups(staff_id);
+
+CREATE TABLE tbl_staff_two_factor (
+	staff_id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY REFERENCES tbl_staffs(id),
+	secret TEXT NOT NULL,
+	enabled_at TIMESTAMP,
+	last_used_step BIGINT NOT NULL DEFAULT 0,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE TABLE tbl_staff_recovery_codes (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	code_hash TEXT NOT NULL,
+	used_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_staff_recovery_codes_staff_id ON tbl_staff_recovery_codes(staff_id);
+
+CREATE TABLE tbl_staff_otp_codes (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	otp_code TEXT NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	used_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_staff_otp_codes_staff_id ON tbl_staff_otp_codes(staff_id);
+
+CREATE TABLE tbl_staff_trusted_devices (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	staff_id BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	token_hash TEXT NOT NULL UNIQUE,
+	useragent_id BIGINT REFERENCES tbl_useragents(id),
+	expires_at TIMESTAMP NOT NULL,
+	last_used_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_staff_trusted_devices_staff_id ON tbl_staff_trusted_devices(staff_id);
+
+CREATE TABLE tbl_sessions (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	user_type TEXT NOT NULL,
+	user_id BIGINT NOT NULL,
+	key_hash TEXT NOT NULL UNIQUE,
+	useragent_id BIGINT REFERENCES tbl_useragents(id),
+	ip_address TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	last_seen_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	revoked_at TIMESTAMP,
+	revoked_reason TEXT
+);
+
+CREATE INDEX idx_sessions_user ON tbl_sessions(user_type, user_id);
+
+CREATE TABLE tbl_checkout_link_attributions (
+	checkout_id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	first_link_id TEXT NOT NULL,
+	last_link_id TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	FOREIGN KEY (checkout_id) REFERENCES tbl_checkouts(id) ON DELETE CASCADE,
+	FOREIGN KEY (first_link_id) REFERENCES tbl_tracked_links(id),
+	FOREIGN KEY (last_link_id) REFERENCES tbl_tracked_links(id)
+);
+
+CREATE INDEX idx_checkout_link_attributions_first ON tbl_checkout_link_attributions(first_link_id);
+
+CREATE INDEX idx_checkout_link_attributions_last ON tbl_checkout_link_attributions(last_link_id);
+
+CREATE INDEX idx_link_clicks_link_ip_hash ON tbl_link_clicks(link_id, ip_hash, clicked_at);
+
+CREATE TABLE tbl_experiments (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	kind TEXT NOT NULL CHECK (kind IN ('THEME', 'PROMO_BANNERS')),
+	status TEXT NOT NULL DEFAULT 'DRAFT' CHECK (status IN ('DRAFT', 'PUBLISHED', 'DELETED')),
+	start_date TEXT NOT NULL,
+	end_date TEXT NOT NULL,
+	created_by BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_experiments_kind_status ON tbl_experiments(kind, status);
+
+CREATE TABLE tbl_experiment_variants (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	experiment_id BIGINT NOT NULL REFERENCES tbl_experiments(id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	weight BIGINT NOT NULL DEFAULT 1 CHECK (weight > 0),
+	theme_id BIGINT REFERENCES tbl_themes(id),
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_experiment_variants_experiment ON tbl_experiment_variants(experiment_id);
+
+CREATE TABLE tbl_experiment_variant_promos (
+	variant_id BIGINT NOT NULL REFERENCES tbl_experiment_variants(id) ON DELETE CASCADE,
+	promo_id BIGINT NOT NULL REFERENCES tbl_promos(id),
+	PRIMARY KEY (variant_id, promo_id)
+);
+
+CREATE TABLE tbl_experiment_exposures (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	experiment_id BIGINT NOT NULL REFERENCES tbl_experiments(id) ON DELETE CASCADE,
+	variant_id BIGINT NOT NULL REFERENCES tbl_experiment_variants(id) ON DELETE CASCADE,
+	bucket_key TEXT NOT NULL,
+	checkout_id BIGINT REFERENCES tbl_checkouts(id),
+	added_to_cart_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	UNIQUE (experiment_id, bucket_key)
+);
+
+CREATE INDEX idx_experiment_exposures_bucket ON tbl_experiment_exposures(bucket_key);
+
+CREATE INDEX idx_experiment_exposures_checkout ON tbl_experiment_exposures(checkout_id);
+
+CREATE TABLE tbl_client_events (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	event TEXT NOT NULL,
+	value TEXT NOT NULL DEFAULT '',
+	session_hash TEXT NOT NULL DEFAULT '',
+	customer_id BIGINT,
+	product_id BIGINT,
+	result_count BIGINT,
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+
+CREATE INDEX idx_client_events_created_at ON tbl_client_events(created_at);
+
+CREATE INDEX idx_client_events_event_created_at ON tbl_client_events(event, created_at);
+
+CREATE TABLE tbl_client_event_rollups (
+	day TEXT NOT NULL,
+	event TEXT NOT NULL,
+	value TEXT NOT NULL,
+	hits BIGINT NOT NULL DEFAULT 0,
+	sessions BIGINT NOT NULL DEFAULT 0,
+	zero_results BIGINT NOT NULL DEFAULT 0,
+	PRIMARY KEY (day, event, value)
+);
+
+CREATE TABLE tbl_search_rules (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	search_term TEXT NOT NULL,
+	kind TEXT NOT NULL CHECK (kind IN ('PIN_PRODUCT', 'REDIRECT_CATEGORY', 'BOOST_BRAND')),
+	product_id BIGINT REFERENCES tbl_products(id) ON DELETE CASCADE,
+	category_id BIGINT REFERENCES tbl_product_categories(id) ON DELETE CASCADE,
+	brand_id BIGINT REFERENCES tbl_brands(id) ON DELETE CASCADE,
+	position BIGINT NOT NULL DEFAULT 0,
+	created_by BIGINT NOT NULL REFERENCES tbl_staffs(id),
+	created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+	CHECK ( (kind = 'PIN_PRODUCT' AND product_id IS NOT NULL AND category_id IS NULL AND brand_id IS NULL) OR (kind = 'REDIRECT_CATEGORY' AND category_id IS NOT NULL AND product_id IS NULL AND brand_id IS NULL) OR (kind = 'BOOST_BRAND' AND brand_id IS NOT NULL AND product_id IS NULL AND category_id IS NULL) )
+);
+
+CREATE INDEX idx_search_rules_search_term ON tbl_search_rules(search_term);
+
+CREATE UNIQUE INDEX idx_search_rules_pin ON tbl_search_rules(search_term, product_id) WHERE kind = 'PIN_PRODUCT';
+
+CREATE UNIQUE INDEX idx_search_rules_redirect ON tbl_search_rules(search_term) WHERE kind = 'REDIRECT_CATEGORY';
+
+CREATE UNIQUE INDEX idx_search_rules_boost ON tbl_search_rules(search_term, brand_id) WHERE kind = 'BOOST_BRAND';
+
+-- goqite's own PostgreSQL schema.
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+CREATE FUNCTION goqite_update_timestamp()
+RETURNS TRIGGER AS $$
+BEGIN
+	NEW.updated = now();
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TABLE goqite (
+	id TEXT PRIMARY KEY DEFAULT ('m_' || encode(gen_random_bytes(16), 'hex')),
+	created TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated TIMESTAMPTZ NOT NULL DEFAULT now(),
+	queue TEXT NOT NULL,
+	body BYTEA NOT NULL,
+	timeout TIMESTAMPTZ NOT NULL DEFAULT now(),
+	received INTEGER NOT NULL DEFAULT 0,
+	priority INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TRIGGER goqite_updated_timestamp
+BEFORE UPDATE ON goqite
+FOR EACH ROW EXECUTE PROCEDURE goqite_update_timestamp();
+
+CREATE INDEX goqite_queue_priority_created_idx ON goqite (queue, priority DESC, created);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW IF EXISTS tbl_products_fts;
+DROP TABLE IF EXISTS tbl_search_rules CASCADE;
+DROP TABLE IF EXISTS tbl_client_event_rollups CASCADE;
+DROP TABLE IF EXISTS tbl_client_events CASCADE;
+DROP TABLE IF EXISTS tbl_experiment_exposures CASCADE;
+DROP TABLE IF EXISTS tbl_experiment_variant_promos CASCADE;
+DROP TABLE IF EXISTS tbl_experiment_variants CASCADE;
+DROP TABLE IF EXISTS tbl_experiments CASCADE;
+DROP TABLE IF EXISTS tbl_checkout_link_attributions CASCADE;
+DROP TABLE IF EXISTS tbl_sessions CASCADE;
+DROP TABLE IF EXISTS tbl_staff_trusted_devices CASCADE;
+DROP TABLE IF EXISTS tbl_staff_otp_codes CASCADE;
+DROP TABLE IF EXISTS tbl_staff_recovery_codes CASCADE;
+DROP TABLE IF EXISTS tbl_staff_two_factor CASCADE;
+DROP TABLE IF EXISTS tbl_staff_role_groups CASCADE;
+DROP TABLE IF EXISTS tbl_role_group_permissions CASCADE;
+DROP TABLE IF EXISTS tbl_role_groups CASCADE;
+DROP TABLE IF EXISTS tbl_staff_incidents CASCADE;
+DROP TABLE IF EXISTS tbl_staff_attendance_corrections CASCADE;
+DROP TABLE IF EXISTS tbl_attendance_kiosk_punches CASCADE;
+DROP TABLE IF EXISTS tbl_staff_kiosk_pins CASCADE;
+DROP TABLE IF EXISTS tbl_staff_shift_overrides CASCADE;
+DROP TABLE IF EXISTS tbl_staff_shift_swaps CASCADE;
+DROP TABLE IF EXISTS tbl_staff_shift_days CASCADE;
+DROP TABLE IF EXISTS tbl_staff_shift_rotations CASCADE;
+DROP TABLE IF EXISTS tbl_staff_leave_ledger CASCADE;
+DROP TABLE IF EXISTS tbl_payroll_run_items CASCADE;
+DROP TABLE IF EXISTS tbl_payroll_runs CASCADE;
+DROP TABLE IF EXISTS tbl_staff_pay_rates CASCADE;
+DROP TABLE IF EXISTS tbl_sale_campaign_products CASCADE;
+DROP TABLE IF EXISTS tbl_sale_campaigns CASCADE;
+DROP TABLE IF EXISTS tbl_stock_subscriptions CASCADE;
+DROP TABLE IF EXISTS tbl_wishlists CASCADE;
+DROP TABLE IF EXISTS tbl_product_ratings CASCADE;
+DROP TABLE IF EXISTS tbl_product_review_photos CASCADE;
+DROP TABLE IF EXISTS tbl_product_reviews CASCADE;
+DROP TABLE IF EXISTS tbl_themes CASCADE;
+DROP TABLE IF EXISTS tbl_product_external_platform_links CASCADE;
+DROP TABLE IF EXISTS tbl_quotation_status_history CASCADE;
+DROP TABLE IF EXISTS tbl_order_status_history CASCADE;
+DROP TABLE IF EXISTS tbl_memo_staff_actions CASCADE;
+DROP TABLE IF EXISTS tbl_memo_recipients CASCADE;
+DROP TABLE IF EXISTS tbl_memos CASCADE;
+DROP TABLE IF EXISTS tbl_quotations CASCADE;
+DROP TABLE IF EXISTS tbl_quotation_lines CASCADE;
+DROP TABLE IF EXISTS tbl_product_inventories CASCADE;
+DROP TABLE IF EXISTS tbl_link_clicks CASCADE;
+DROP TABLE IF EXISTS tbl_tracked_links CASCADE;
+DROP TABLE IF EXISTS tbl_promos CASCADE;
+DROP TABLE IF EXISTS tbl_password_reset_tokens CASCADE;
+DROP TABLE IF EXISTS tbl_holidays CASCADE;
+DROP TABLE IF EXISTS tbl_email_jobs CASCADE;
+DROP TABLE IF EXISTS tbl_customer_otp_codes CASCADE;
+DROP TABLE IF EXISTS tbl_cpoints CASCADE;
+DROP TABLE IF EXISTS tbl_customer_companies CASCADE;
+DROP TABLE IF EXISTS tbl_customers CASCADE;
+DROP TABLE IF EXISTS tbl_thumbnail_jobs CASCADE;
+DROP TABLE IF EXISTS tbl_staff_roles CASCADE;
+DROP TABLE IF EXISTS tbl_staff_logs CASCADE;
+DROP TABLE IF EXISTS tbl_staff_time_offs CASCADE;
+DROP TABLE IF EXISTS tbl_useragents CASCADE;
+DROP TABLE IF EXISTS tbl_staff_accesses CASCADE;
+DROP TABLE IF EXISTS tbl_staff_attendances CASCADE;
+DROP TABLE IF EXISTS tbl_staffs CASCADE;
+DROP TABLE IF EXISTS tbl_product_sales CASCADE;
+DROP TABLE IF EXISTS tbl_external_api_logs CASCADE;
+DROP TABLE IF EXISTS tbl_order_lines CASCADE;
+DROP TABLE IF EXISTS tbl_orders CASCADE;
+DROP TABLE IF EXISTS tbl_geocoding_cache CASCADE;
+DROP TABLE IF EXISTS tbl_checkout_payments CASCADE;
+DROP TABLE IF EXISTS tbl_checkouts CASCADE;
+DROP TABLE IF EXISTS tbl_checkout_lines CASCADE;
+DROP TABLE IF EXISTS tbl_settings CASCADE;
+DROP TABLE IF EXISTS tbl_product_images CASCADE;
+DROP TABLE IF EXISTS tbl_brand_images CASCADE;
+DROP TABLE IF EXISTS tbl_products_categories CASCADE;
+DROP TABLE IF EXISTS tbl_products CASCADE;
+DROP TABLE IF EXISTS tbl_product_specs CASCADE;
+DROP TABLE IF EXISTS tbl_product_categories CASCADE;
+DROP TABLE IF EXISTS tbl_brands CASCADE;
+DROP TABLE IF EXISTS goqite;
+DROP FUNCTION IF EXISTS goqite_update_timestamp;
+-- +goose StatementEnd
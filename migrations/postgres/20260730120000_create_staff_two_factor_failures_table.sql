@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Wrong second factor codes per staff, kept apart from the login session so
+-- entering the password again does not start the count over. attempts only
+-- counts failures from the last 15 minutes; reaching TwoFactorMaxAttempts
+-- sets locked_until and starts it from zero.
+CREATE TABLE tbl_staff_two_factor_failures (
+	staff_id BIGINT PRIMARY KEY REFERENCES tbl_staffs(id),
+	attempts BIGINT NOT NULL DEFAULT 0,
+	locked_until TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tbl_staff_two_factor_failures;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tbl_product_reviews (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    product_id INTEGER NOT NULL REFERENCES tbl_products(id),
+    customer_id INTEGER NOT NULL REFERENCES tbl_customers(id),
+    order_id INTEGER NOT NULL REFERENCES tbl_orders(id),
+    rating INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    title TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    moderated_by INTEGER REFERENCES tbl_staffs(id),
+    moderated_at DATETIME,
+    rejection_reason TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+    updated_at DATETIME NOT NULL DEFAULT (datetime('now')),
+    UNIQUE(product_id, customer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_reviews_product_id_status ON tbl_product_reviews(product_id, status);
+CREATE INDEX IF NOT EXISTS idx_product_reviews_status_created_at ON tbl_product_reviews(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_product_reviews_customer_id ON tbl_product_reviews(customer_id);
+
+CREATE TABLE IF NOT EXISTS tbl_product_review_photos (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    review_id INTEGER NOT NULL REFERENCES tbl_product_reviews(id) ON DELETE CASCADE,
+    path TEXT NOT NULL,
+    url TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_review_photos_review_id ON tbl_product_review_photos(review_id);
+
+CREATE TABLE IF NOT EXISTS tbl_product_ratings (
+    product_id INTEGER PRIMARY KEY REFERENCES tbl_products(id),
+    rating_count INTEGER NOT NULL DEFAULT 0,
+    rating_total INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME NOT NULL DEFAULT (datetime('now'))
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS tbl_product_ratings;
+DROP INDEX IF EXISTS idx_product_review_photos_review_id;
+DROP TABLE IF EXISTS tbl_product_review_photos;
+DROP INDEX IF EXISTS idx_product_reviews_customer_id;
+DROP INDEX IF EXISTS idx_product_reviews_status_created_at;
+DROP INDEX IF EXISTS idx_product_reviews_product_id_status;
+DROP TABLE IF EXISTS tbl_product_reviews;
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tbl_wishlists (
+	id INTEGER PRIMARY KEY,
+	customer_id INTEGER NOT NULL REFERENCES tbl_customers(id) ON DELETE CASCADE,
+	product_id INTEGER NOT NULL REFERENCES tbl_products(id) ON DELETE CASCADE,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	UNIQUE (customer_id, product_id)
+);
+
+CREATE INDEX idx_wishlists_product_id ON tbl_wishlists(product_id);
+
+CREATE TABLE tbl_stock_subscriptions (
+	id INTEGER PRIMARY KEY,
+	product_id INTEGER NOT NULL REFERENCES tbl_products(id) ON DELETE CASCADE,
+	email TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'PENDING',
+	token_hash TEXT NOT NULL,
+	confirmed_at DATETIME,
+	notified_at DATETIME,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	UNIQUE (product_id, email)
+);
+
+CREATE INDEX idx_stock_subscriptions_token_hash ON tbl_stock_subscriptions(token_hash);
+CREATE INDEX idx_stock_subscriptions_product_id_status ON tbl_stock_subscriptions(product_id, status);
+
+ALTER TABLE tbl_email_jobs ADD COLUMN product_id INTEGER REFERENCES tbl_products(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tbl_email_jobs DROP COLUMN product_id;
+DROP INDEX IF EXISTS idx_stock_subscriptions_product_id_status;
+DROP INDEX IF EXISTS idx_stock_subscriptions_token_hash;
+DROP TABLE IF EXISTS tbl_stock_subscriptions;
+DROP INDEX IF EXISTS idx_wishlists_product_id;
+DROP TABLE IF EXISTS tbl_wishlists;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tbl_sale_campaigns (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	selector TEXT NOT NULL CHECK (selector IN ('BRAND', 'CATEGORY', 'PRODUCTS')),
+	selector_value TEXT NOT NULL,
+	discount_type TEXT NOT NULL CHECK (discount_type IN ('fixed', 'percentage')),
+	discount_value INTEGER NOT NULL, -- cents if fixed, percent if percentage
+	starts_at DATETIME NOT NULL,
+	ends_at DATETIME NOT NULL,
+	status TEXT NOT NULL DEFAULT 'SCHEDULED' CHECK (status IN ('SCHEDULED', 'ACTIVE', 'ENDED', 'CANCELLED')),
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE INDEX idx_sale_campaigns_status_starts_at ON tbl_sale_campaigns(status, starts_at);
+CREATE INDEX idx_sale_campaigns_status_ends_at ON tbl_sale_campaigns(status, ends_at);
+
+CREATE TABLE tbl_sale_campaign_products (
+	id INTEGER PRIMARY KEY,
+	sale_campaign_id INTEGER NOT NULL REFERENCES tbl_sale_campaigns(id) ON DELETE CASCADE,
+	product_id INTEGER NOT NULL REFERENCES tbl_products(id) ON DELETE CASCADE,
+	UNIQUE (sale_campaign_id, product_id)
+);
+
+CREATE INDEX idx_sale_campaign_products_product_id ON tbl_sale_campaign_products(product_id);
+
+ALTER TABLE tbl_product_sales ADD COLUMN sale_campaign_id INTEGER REFERENCES tbl_sale_campaigns(id);
+CREATE INDEX idx_product_sales_sale_campaign_id ON tbl_product_sales(sale_campaign_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_product_sales_sale_campaign_id;
+ALTER TABLE tbl_product_sales DROP COLUMN sale_campaign_id;
+DROP INDEX idx_sale_campaign_products_product_id;
+DROP TABLE tbl_sale_campaign_products;
+DROP INDEX idx_sale_campaigns_status_ends_at;
+DROP INDEX idx_sale_campaigns_status_starts_at;
+DROP TABLE tbl_sale_campaigns;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tbl_checkouts ADD COLUMN customer_id INTEGER REFERENCES tbl_customers(id);
+ALTER TABLE tbl_checkouts ADD COLUMN email TEXT;
+ALTER TABLE tbl_checkouts ADD COLUMN reminder_sent_at DATETIME;
+ALTER TABLE tbl_checkouts ADD COLUMN recovery_token_hash TEXT;
+ALTER TABLE tbl_checkouts ADD COLUMN recovered_at DATETIME;
+
+CREATE INDEX idx_checkouts_status_reminder_sent_at ON tbl_checkouts(status, reminder_sent_at);
+CREATE INDEX idx_checkouts_recovery_token_hash ON tbl_checkouts(recovery_token_hash);
+
+ALTER TABLE tbl_email_jobs ADD COLUMN checkout_id INTEGER REFERENCES tbl_checkouts(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tbl_email_jobs DROP COLUMN checkout_id;
+DROP INDEX IF EXISTS idx_checkouts_recovery_token_hash;
+DROP INDEX IF EXISTS idx_checkouts_status_reminder_sent_at;
+ALTER TABLE tbl_checkouts DROP COLUMN recovered_at;
+ALTER TABLE tbl_checkouts DROP COLUMN recovery_token_hash;
+ALTER TABLE tbl_checkouts DROP COLUMN reminder_sent_at;
+ALTER TABLE tbl_checkouts DROP COLUMN email;
+ALTER TABLE tbl_checkouts DROP COLUMN customer_id;
+-- +goose StatementEnd
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tbl_staff_pay_rates (
+	id INTEGER PRIMARY KEY,
+	staff_id INTEGER NOT NULL UNIQUE REFERENCES tbl_staffs(id),
+	rate_type TEXT NOT NULL CHECK (rate_type IN ('DAILY', 'MONTHLY')),
+	rate INTEGER NOT NULL, -- cents per day if DAILY, per month if MONTHLY
+	updated_by INTEGER REFERENCES tbl_staffs(id),
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE TABLE tbl_payroll_runs (
+	id INTEGER PRIMARY KEY,
+	period_start TEXT NOT NULL,
+	period_end TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'DRAFT' CHECK (status IN ('DRAFT', 'LOCKED')),
+	created_by INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	locked_by INTEGER REFERENCES tbl_staffs(id),
+	locked_at DATETIME,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	UNIQUE (period_start, period_end)
+);
+
+-- All amounts are in cents and minutes are whole minutes.
+CREATE TABLE tbl_payroll_run_items (
+	id INTEGER PRIMARY KEY,
+	payroll_run_id INTEGER NOT NULL REFERENCES tbl_payroll_runs(id) ON DELETE CASCADE,
+	staff_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	rate_type TEXT NOT NULL CHECK (rate_type IN ('DAILY', 'MONTHLY')),
+	rate INTEGER NOT NULL,
+	daily_rate INTEGER NOT NULL,
+	days_worked INTEGER NOT NULL DEFAULT 0,
+	absent_days INTEGER NOT NULL DEFAULT 0,
+	late_minutes INTEGER NOT NULL DEFAULT 0,
+	undertime_minutes INTEGER NOT NULL DEFAULT 0,
+	overtime_minutes INTEGER NOT NULL DEFAULT 0,
+	basic_pay INTEGER NOT NULL DEFAULT 0,
+	holiday_pay INTEGER NOT NULL DEFAULT 0,
+	overtime_pay INTEGER NOT NULL DEFAULT 0,
+	late_deduction INTEGER NOT NULL DEFAULT 0,
+	undertime_deduction INTEGER NOT NULL DEFAULT 0,
+	absence_deduction INTEGER NOT NULL DEFAULT 0,
+	gross_pay INTEGER NOT NULL DEFAULT 0,
+	sss INTEGER NOT NULL DEFAULT 0,
+	philhealth INTEGER NOT NULL DEFAULT 0,
+	pagibig INTEGER NOT NULL DEFAULT 0,
+	withholding_tax INTEGER NOT NULL DEFAULT 0,
+	net_pay INTEGER NOT NULL DEFAULT 0,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	UNIQUE (payroll_run_id, staff_id)
+);
+
+CREATE INDEX idx_payroll_run_items_staff_id ON tbl_payroll_run_items(staff_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_payroll_run_items_staff_id;
+DROP TABLE tbl_payroll_run_items;
+DROP TABLE tbl_payroll_runs;
+DROP TABLE tbl_staff_pay_rates;
+-- +goose StatementEnd
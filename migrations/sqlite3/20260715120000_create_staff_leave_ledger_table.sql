@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Leave credits are in hundredths of a day so monthly accruals of a twelfth of
+-- the yearly entitlement stay whole numbers. The balance of a staff for a leave
+-- type is the sum of its rows.
+CREATE TABLE tbl_staff_leave_ledger (
+	id INTEGER PRIMARY KEY,
+	staff_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	leave_type TEXT NOT NULL CHECK (leave_type IN ('VL', 'SL')),
+	entry_type TEXT NOT NULL CHECK (entry_type IN ('ACCRUAL', 'DEDUCTION', 'REFUND', 'ADJUSTMENT', 'CONVERSION', 'FORFEIT')),
+	year INTEGER NOT NULL,
+	period TEXT NOT NULL DEFAULT '', -- "YYYY-MM" for accruals, "YYYY" for year-end conversion and forfeit
+	credits INTEGER NOT NULL,
+	time_off_id INTEGER REFERENCES tbl_staff_time_offs(id),
+	note TEXT NOT NULL DEFAULT '',
+	created_by INTEGER REFERENCES tbl_staffs(id),
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE INDEX idx_staff_leave_ledger_staff ON tbl_staff_leave_ledger(staff_id, leave_type, year);
+CREATE INDEX idx_staff_leave_ledger_time_off ON tbl_staff_leave_ledger(time_off_id);
+CREATE UNIQUE INDEX idx_staff_leave_ledger_period ON tbl_staff_leave_ledger(staff_id, leave_type, entry_type, period)
+	WHERE period != '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_staff_leave_ledger_period;
+DROP INDEX idx_staff_leave_ledger_time_off;
+DROP INDEX idx_staff_leave_ledger_staff;
+DROP TABLE tbl_staff_leave_ledger;
+-- +goose StatementEnd
@@ -0,0 +1,69 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Staff without a rotation or template days keep using the time_in_schedule
+-- and time_out_schedule pair of tbl_staffs for every day.
+CREATE TABLE tbl_staff_shift_rotations (
+	id INTEGER PRIMARY KEY,
+	staff_id INTEGER NOT NULL UNIQUE REFERENCES tbl_staffs(id),
+	cycle_weeks INTEGER NOT NULL DEFAULT 1 CHECK (cycle_weeks BETWEEN 1 AND 4),
+	anchor_date TEXT NOT NULL,
+	updated_by INTEGER REFERENCES tbl_staffs(id),
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+-- weekday follows Go's time.Weekday, 0 is Sunday.
+CREATE TABLE tbl_staff_shift_days (
+	id INTEGER PRIMARY KEY,
+	staff_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	week_index INTEGER NOT NULL DEFAULT 0,
+	weekday INTEGER NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+	time_in TEXT NOT NULL DEFAULT '',
+	time_out TEXT NOT NULL DEFAULT '',
+	rest_day BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	UNIQUE (staff_id, week_index, weekday)
+);
+
+CREATE TABLE tbl_staff_shift_swaps (
+	id INTEGER PRIMARY KEY,
+	requester_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	counterpart_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	for_date TEXT NOT NULL,
+	reason TEXT NOT NULL DEFAULT '',
+	status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+	decided_by INTEGER REFERENCES tbl_staffs(id),
+	decided_at DATETIME,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE TABLE tbl_staff_shift_overrides (
+	id INTEGER PRIMARY KEY,
+	staff_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	for_date TEXT NOT NULL,
+	time_in TEXT NOT NULL DEFAULT '',
+	time_out TEXT NOT NULL DEFAULT '',
+	rest_day BOOLEAN NOT NULL DEFAULT FALSE,
+	note TEXT NOT NULL DEFAULT '',
+	shift_swap_id INTEGER REFERENCES tbl_staff_shift_swaps(id),
+	created_by INTEGER REFERENCES tbl_staffs(id),
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	UNIQUE (staff_id, for_date)
+);
+
+CREATE INDEX idx_staff_shift_overrides_for_date ON tbl_staff_shift_overrides(for_date);
+CREATE INDEX idx_staff_shift_swaps_status ON tbl_staff_shift_swaps(status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_staff_shift_swaps_status;
+DROP INDEX idx_staff_shift_overrides_for_date;
+DROP TABLE tbl_staff_shift_overrides;
+DROP TABLE tbl_staff_shift_swaps;
+DROP TABLE tbl_staff_shift_days;
+DROP TABLE tbl_staff_shift_rotations;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+-- PIN used on the shop kiosk when a staff cannot scan its QR code.
+CREATE TABLE tbl_staff_kiosk_pins (
+	staff_id INTEGER PRIMARY KEY REFERENCES tbl_staffs(id),
+	pin_hash TEXT NOT NULL,
+	failed_attempts INTEGER NOT NULL DEFAULT 0,
+	locked_until DATETIME,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+-- kiosk_staff_id is the account the shop tablet is logged in with.
+CREATE TABLE tbl_attendance_kiosk_punches (
+	id INTEGER PRIMARY KEY,
+	staff_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	kiosk_staff_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	for_date TEXT NOT NULL,
+	punch_type TEXT NOT NULL,
+	method TEXT NOT NULL,
+	photo_key TEXT NOT NULL DEFAULT '',
+	useragent_id INTEGER REFERENCES tbl_useragents(id),
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE INDEX idx_tbl_attendance_kiosk_punches_for_date ON tbl_attendance_kiosk_punches(for_date);
+CREATE INDEX idx_tbl_attendance_kiosk_punches_staff_id ON tbl_attendance_kiosk_punches(staff_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_tbl_attendance_kiosk_punches_staff_id;
+DROP INDEX idx_tbl_attendance_kiosk_punches_for_date;
+DROP TABLE tbl_attendance_kiosk_punches;
+DROP TABLE tbl_staff_kiosk_pins;
+-- +goose StatementEnd
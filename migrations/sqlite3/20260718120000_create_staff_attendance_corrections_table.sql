@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Requested times are full UTC datetimes like tbl_staff_attendances. A NULL
+-- requested time leaves that punch as is. The original_* columns keep the
+-- punches as they were right before the correction was applied.
+CREATE TABLE tbl_staff_attendance_corrections (
+	id INTEGER PRIMARY KEY,
+	staff_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	for_date TEXT NOT NULL,
+	time_in TEXT,
+	time_out TEXT,
+	lunch_break_in TEXT,
+	lunch_break_out TEXT,
+	reason TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+	original_time_in TEXT,
+	original_time_out TEXT,
+	original_lunch_break_in TEXT,
+	original_lunch_break_out TEXT,
+	decided_by INTEGER REFERENCES tbl_staffs(id),
+	decided_at DATETIME,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE INDEX idx_staff_attendance_corrections_status ON tbl_staff_attendance_corrections(status);
+CREATE INDEX idx_staff_attendance_corrections_staff_id_for_date ON tbl_staff_attendance_corrections(staff_id, for_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_staff_attendance_corrections_staff_id_for_date;
+DROP INDEX idx_staff_attendance_corrections_status;
+DROP TABLE tbl_staff_attendance_corrections;
+-- +goose StatementEnd
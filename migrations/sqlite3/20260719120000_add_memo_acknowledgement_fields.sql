@@ -0,0 +1,19 @@
+-- +goose Up
+ALTER TABLE tbl_memos ADD COLUMN requires_acknowledgement BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE tbl_memos ADD COLUMN acknowledge_by TEXT;
+ALTER TABLE tbl_memos ADD COLUMN escalated_at TEXT;
+
+ALTER TABLE tbl_memo_recipients ADD COLUMN reminder_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tbl_memo_recipients ADD COLUMN last_reminded_at TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_memos_requires_acknowledgement ON tbl_memos(requires_acknowledgement);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_memos_requires_acknowledgement;
+
+ALTER TABLE tbl_memo_recipients DROP COLUMN last_reminded_at;
+ALTER TABLE tbl_memo_recipients DROP COLUMN reminder_count;
+
+ALTER TABLE tbl_memos DROP COLUMN escalated_at;
+ALTER TABLE tbl_memos DROP COLUMN acknowledge_by;
+ALTER TABLE tbl_memos DROP COLUMN requires_acknowledgement;
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+-- An incident goes OPEN -> NTE_ISSUED -> RESPONDED -> DECIDED, although a
+-- superuser may decide without issuing a notice to explain. The notice itself
+-- is a memo addressed to the staff. effective_from and effective_to are dates
+-- in PH time; for a suspension they are the non-working days.
+CREATE TABLE tbl_staff_incidents (
+	id INTEGER PRIMARY KEY,
+	staff_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	reported_by INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	incident_date TEXT NOT NULL,
+	title TEXT NOT NULL,
+	description TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'NTE_ISSUED', 'RESPONDED', 'DECIDED')),
+	nte_memo_id INTEGER REFERENCES tbl_memos(id),
+	response_deadline TEXT,
+	response TEXT,
+	responded_at DATETIME,
+	decision TEXT CHECK (decision IN ('WARNING', 'SUSPENSION', 'NO_ACTION')),
+	decision_notes TEXT,
+	effective_from TEXT,
+	effective_to TEXT,
+	decided_by INTEGER REFERENCES tbl_staffs(id),
+	decided_at DATETIME,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE INDEX idx_staff_incidents_staff_id ON tbl_staff_incidents(staff_id);
+CREATE INDEX idx_staff_incidents_status ON tbl_staff_incidents(status);
+CREATE INDEX idx_staff_incidents_suspension ON tbl_staff_incidents(decision, effective_from, effective_to);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_staff_incidents_suspension;
+DROP INDEX idx_staff_incidents_status;
+DROP INDEX idx_staff_incidents_staff_id;
+DROP TABLE tbl_staff_incidents;
+-- +goose StatementEnd
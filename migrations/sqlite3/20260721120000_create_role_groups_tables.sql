@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Roles granted before access levels existed keep full access.
+ALTER TABLE tbl_staff_roles ADD COLUMN access TEXT NOT NULL DEFAULT 'WRITE' CHECK (access IN ('READ', 'WRITE'));
+
+-- A role group is a named bundle of permissions. A staff's effective
+-- permissions are their direct roles plus those of every group assigned to
+-- them, taking the higher access when both grant the same role.
+CREATE TABLE tbl_role_groups (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL UNIQUE,
+	description TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE TABLE tbl_role_group_permissions (
+	id INTEGER PRIMARY KEY,
+	role_group_id INTEGER NOT NULL REFERENCES tbl_role_groups(id) ON DELETE CASCADE,
+	role TEXT NOT NULL,
+	access TEXT NOT NULL CHECK (access IN ('READ', 'WRITE')),
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	UNIQUE (role_group_id, role)
+);
+
+CREATE TABLE tbl_staff_role_groups (
+	id INTEGER PRIMARY KEY,
+	staff_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	role_group_id INTEGER NOT NULL REFERENCES tbl_role_groups(id) ON DELETE CASCADE,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	UNIQUE (staff_id, role_group_id)
+);
+
+CREATE INDEX idx_staff_role_groups_staff_id ON tbl_staff_role_groups(staff_id);
+
+INSERT INTO tbl_role_groups (name, description) VALUES
+	('Warehouse', 'Stock levels and product details'),
+	('Sales', 'Orders, quotations and promos'),
+	('Marketing', 'Campaigns, tracked links, themes and reviews');
+
+INSERT INTO tbl_role_group_permissions (role_group_id, role, access)
+SELECT g.id, p.role, p.access
+FROM tbl_role_groups g
+JOIN (
+	SELECT 'Warehouse' AS grp, 'MANAGE_PRODUCT_INVENTORIES' AS role, 'WRITE' AS access
+	UNION ALL SELECT 'Warehouse', 'EDIT_PRODUCTS', 'READ'
+	UNION ALL SELECT 'Warehouse', 'MANAGE_ORDERS', 'READ'
+	UNION ALL SELECT 'Sales', 'MANAGE_ORDERS', 'WRITE'
+	UNION ALL SELECT 'Sales', 'MANAGE_ORDER_STATUS', 'WRITE'
+	UNION ALL SELECT 'Sales', 'MANAGE_QUOTATIONS', 'WRITE'
+	UNION ALL SELECT 'Sales', 'MANAGE_PROMOS', 'READ'
+	UNION ALL SELECT 'Marketing', 'MANAGE_PROMOS', 'WRITE'
+	UNION ALL SELECT 'Marketing', 'MANAGE_TRACKED_LINKS', 'WRITE'
+	UNION ALL SELECT 'Marketing', 'MANAGE_THEMES', 'WRITE'
+	UNION ALL SELECT 'Marketing', 'MANAGE_REVIEWS', 'WRITE'
+) p ON p.grp = g.name;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tbl_staff_role_groups;
+DROP TABLE IF EXISTS tbl_role_group_permissions;
+DROP TABLE IF EXISTS tbl_role_groups;
+ALTER TABLE tbl_staff_roles DROP COLUMN access;
+-- +goose StatementEnd
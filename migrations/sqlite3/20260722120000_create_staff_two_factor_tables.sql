@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A row with enabled_at NULL is an enrolment that was started but never
+-- confirmed with a code. last_used_step is the TOTP period of the last
+-- accepted code, so the same code cannot be used twice.
+CREATE TABLE tbl_staff_two_factor (
+	staff_id INTEGER PRIMARY KEY REFERENCES tbl_staffs(id),
+	secret TEXT NOT NULL,
+	enabled_at DATETIME,
+	last_used_step INTEGER NOT NULL DEFAULT 0,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE TABLE tbl_staff_recovery_codes (
+	id INTEGER PRIMARY KEY,
+	staff_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	code_hash TEXT NOT NULL,
+	used_at DATETIME,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE INDEX idx_staff_recovery_codes_staff_id ON tbl_staff_recovery_codes(staff_id);
+
+CREATE TABLE tbl_staff_otp_codes (
+	id INTEGER PRIMARY KEY,
+	staff_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	otp_code TEXT NOT NULL,
+	expires_at DATETIME NOT NULL,
+	used_at DATETIME,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE INDEX idx_staff_otp_codes_staff_id ON tbl_staff_otp_codes(staff_id);
+
+-- Only the hash of the device token is kept; the token itself lives in the
+-- browser cookie and is only honoured from the same user agent.
+CREATE TABLE tbl_staff_trusted_devices (
+	id INTEGER PRIMARY KEY,
+	staff_id INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	token_hash TEXT NOT NULL UNIQUE,
+	useragent_id INTEGER REFERENCES tbl_useragents(id),
+	expires_at DATETIME NOT NULL,
+	last_used_at DATETIME,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE INDEX idx_staff_trusted_devices_staff_id ON tbl_staff_trusted_devices(staff_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tbl_staff_trusted_devices;
+DROP TABLE IF EXISTS tbl_staff_otp_codes;
+DROP TABLE IF EXISTS tbl_staff_recovery_codes;
+DROP TABLE IF EXISTS tbl_staff_two_factor;
+-- +goose StatementEnd
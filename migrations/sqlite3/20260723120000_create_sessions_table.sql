@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- One row per signed in browser. The session itself stays in scs; the row is
+-- found through the hash of a random key kept in that session, so revoking
+-- a row signs the browser out on its next request.
+CREATE TABLE tbl_sessions (
+	id INTEGER PRIMARY KEY,
+	user_type TEXT NOT NULL,
+	user_id INTEGER NOT NULL,
+	key_hash TEXT NOT NULL UNIQUE,
+	useragent_id INTEGER REFERENCES tbl_useragents(id),
+	ip_address TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	last_seen_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	revoked_at DATETIME,
+	revoked_reason TEXT
+);
+
+CREATE INDEX idx_sessions_user ON tbl_sessions(user_type, user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_sessions_user;
+DROP TABLE IF EXISTS tbl_sessions;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tbl_checkout_link_attributions (
+	checkout_id INTEGER PRIMARY KEY,
+	first_link_id TEXT NOT NULL,
+	last_link_id TEXT NOT NULL,
+
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+
+	FOREIGN KEY (checkout_id) REFERENCES tbl_checkouts(id) ON DELETE CASCADE,
+	FOREIGN KEY (first_link_id) REFERENCES tbl_tracked_links(id),
+	FOREIGN KEY (last_link_id) REFERENCES tbl_tracked_links(id)
+);
+
+CREATE INDEX idx_checkout_link_attributions_first ON tbl_checkout_link_attributions(first_link_id);
+CREATE INDEX idx_checkout_link_attributions_last ON tbl_checkout_link_attributions(last_link_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_checkout_link_attributions_last;
+DROP INDEX IF EXISTS idx_checkout_link_attributions_first;
+DROP TABLE IF EXISTS tbl_checkout_link_attributions;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tbl_link_clicks ADD COLUMN is_bot BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE tbl_link_clicks ADD COLUMN is_duplicate BOOLEAN NOT NULL DEFAULT 0;
+CREATE INDEX idx_link_clicks_link_ip_hash ON tbl_link_clicks(link_id, ip_hash, clicked_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_link_clicks_link_ip_hash;
+ALTER TABLE tbl_link_clicks DROP COLUMN is_duplicate;
+ALTER TABLE tbl_link_clicks DROP COLUMN is_bot;
+-- +goose StatementEnd
@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tbl_experiments (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	kind TEXT NOT NULL CHECK (kind IN ('THEME', 'PROMO_BANNERS')),
+	status TEXT NOT NULL DEFAULT 'DRAFT' CHECK (status IN ('DRAFT', 'PUBLISHED', 'DELETED')),
+	start_date TEXT NOT NULL,
+	end_date TEXT NOT NULL,
+	created_by INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	updated_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE INDEX idx_experiments_kind_status ON tbl_experiments(kind, status);
+
+CREATE TABLE tbl_experiment_variants (
+	id INTEGER PRIMARY KEY,
+	experiment_id INTEGER NOT NULL REFERENCES tbl_experiments(id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	weight INTEGER NOT NULL DEFAULT 1 CHECK (weight > 0),
+	-- THEME variants point at a theme; NULL keeps the scheduled theme and
+	-- acts as the control.
+	theme_id INTEGER REFERENCES tbl_themes(id),
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE INDEX idx_experiment_variants_experiment ON tbl_experiment_variants(experiment_id);
+
+CREATE TABLE tbl_experiment_variant_promos (
+	variant_id INTEGER NOT NULL REFERENCES tbl_experiment_variants(id) ON DELETE CASCADE,
+	promo_id INTEGER NOT NULL REFERENCES tbl_promos(id),
+	PRIMARY KEY (variant_id, promo_id)
+);
+
+CREATE TABLE tbl_experiment_exposures (
+	id INTEGER PRIMARY KEY,
+	experiment_id INTEGER NOT NULL REFERENCES tbl_experiments(id) ON DELETE CASCADE,
+	variant_id INTEGER NOT NULL REFERENCES tbl_experiment_variants(id) ON DELETE CASCADE,
+	bucket_key TEXT NOT NULL,
+	checkout_id INTEGER REFERENCES tbl_checkouts(id),
+	added_to_cart_at DATETIME,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	UNIQUE (experiment_id, bucket_key)
+);
+
+CREATE INDEX idx_experiment_exposures_bucket ON tbl_experiment_exposures(bucket_key);
+CREATE INDEX idx_experiment_exposures_checkout ON tbl_experiment_exposures(checkout_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_experiment_exposures_checkout;
+DROP INDEX IF EXISTS idx_experiment_exposures_bucket;
+DROP TABLE IF EXISTS tbl_experiment_exposures;
+DROP TABLE IF EXISTS tbl_experiment_variant_promos;
+DROP INDEX IF EXISTS idx_experiment_variants_experiment;
+DROP TABLE IF EXISTS tbl_experiment_variants;
+DROP INDEX IF EXISTS idx_experiments_kind_status;
+DROP TABLE IF EXISTS tbl_experiments;
+-- +goose StatementEnd
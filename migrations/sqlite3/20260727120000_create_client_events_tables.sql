@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tbl_client_events (
+	id INTEGER PRIMARY KEY,
+	event TEXT NOT NULL,
+	value TEXT NOT NULL DEFAULT '',
+	-- SHA-256 of the session token, never the token itself.
+	session_hash TEXT NOT NULL DEFAULT '',
+	customer_id INTEGER,
+	product_id INTEGER,
+	-- Only set on server-side search events.
+	result_count INTEGER,
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now'))
+);
+
+CREATE INDEX idx_client_events_created_at ON tbl_client_events(created_at);
+CREATE INDEX idx_client_events_event_created_at ON tbl_client_events(event, created_at);
+
+CREATE TABLE tbl_client_event_rollups (
+	day TEXT NOT NULL,
+	event TEXT NOT NULL,
+	value TEXT NOT NULL,
+	hits INTEGER NOT NULL DEFAULT 0,
+	sessions INTEGER NOT NULL DEFAULT 0,
+	zero_results INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (day, event, value)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tbl_client_event_rollups;
+DROP INDEX IF EXISTS idx_client_events_event_created_at;
+DROP INDEX IF EXISTS idx_client_events_created_at;
+DROP TABLE IF EXISTS tbl_client_events;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tbl_search_rules (
+	id INTEGER PRIMARY KEY,
+	-- Lowercased and trimmed so it matches the stored search events.
+	search_term TEXT NOT NULL,
+	kind TEXT NOT NULL CHECK (kind IN ('PIN_PRODUCT', 'REDIRECT_CATEGORY', 'BOOST_BRAND')),
+	product_id INTEGER REFERENCES tbl_products(id) ON DELETE CASCADE,
+	category_id INTEGER REFERENCES tbl_product_categories(id) ON DELETE CASCADE,
+	brand_id INTEGER REFERENCES tbl_brands(id) ON DELETE CASCADE,
+	-- Pinned products are shown in ascending position.
+	position INTEGER NOT NULL DEFAULT 0,
+	created_by INTEGER NOT NULL REFERENCES tbl_staffs(id),
+	created_at DATETIME NOT NULL DEFAULT (DATETIME('now')),
+	CHECK (
+		(kind = 'PIN_PRODUCT' AND product_id IS NOT NULL AND category_id IS NULL AND brand_id IS NULL)
+		OR (kind = 'REDIRECT_CATEGORY' AND category_id IS NOT NULL AND product_id IS NULL AND brand_id IS NULL)
+		OR (kind = 'BOOST_BRAND' AND brand_id IS NOT NULL AND product_id IS NULL AND category_id IS NULL)
+	)
+);
+
+CREATE INDEX idx_search_rules_search_term ON tbl_search_rules(search_term);
+CREATE UNIQUE INDEX idx_search_rules_pin ON tbl_search_rules(search_term, product_id) WHERE kind = 'PIN_PRODUCT';
+CREATE UNIQUE INDEX idx_search_rules_redirect ON tbl_search_rules(search_term) WHERE kind = 'REDIRECT_CATEGORY';
+CREATE UNIQUE INDEX idx_search_rules_boost ON tbl_search_rules(search_term, brand_id) WHERE kind = 'BOOST_BRAND';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_search_rules_boost;
+DROP INDEX IF EXISTS idx_search_rules_redirect;
+DROP INDEX IF EXISTS idx_search_rules_pin;
+DROP INDEX IF EXISTS idx_search_rules_search_term;
+DROP TABLE IF EXISTS tbl_search_rules;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Set once the wishlist customers have been told the sale started. Sales from
+-- before this column were announced when they were created.
+ALTER TABLE tbl_product_sales ADD COLUMN notified_at DATETIME;
+UPDATE tbl_product_sales SET notified_at = created_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tbl_product_sales DROP COLUMN notified_at;
+-- +goose StatementEnd
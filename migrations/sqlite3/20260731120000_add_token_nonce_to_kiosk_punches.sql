@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The nonce of the kiosk QR token a punch was made with, so each token can be
+-- used once. PIN punches have none.
+ALTER TABLE tbl_attendance_kiosk_punches ADD COLUMN token_nonce TEXT;
+CREATE UNIQUE INDEX idx_tbl_attendance_kiosk_punches_token_nonce ON tbl_attendance_kiosk_punches(token_nonce);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_tbl_attendance_kiosk_punches_token_nonce;
+ALTER TABLE tbl_attendance_kiosk_punches DROP COLUMN token_nonce;
+-- +goose StatementEnd
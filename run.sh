@@ -46,7 +46,7 @@ buildgoose() {
 	git submodule update --init --recursive
 	cd ./cmd/goose
 	go mod tidy
-	go build -tags="no_postgres no_mysql no_clickhouse no_mssql no_vertica no_ydb" -o "../../${TMP}/goose" ./cmd/goose
+	go build -tags="no_mysql no_clickhouse no_mssql no_vertica no_ydb" -o "../../${TMP}/goose" ./cmd/goose
 	cd ../..
 	chmod +x "${TMP}/goose"
 }